@@ -0,0 +1,423 @@
+// Package agx provides methods to invoke procedures in the AGX service.
+//
+// From service docs: provides access to the action groups added by Action
+// Groups Extended, which supports up to 250 action groups per vessel.
+package agx
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// AGX - provides access to the action groups added by Action Groups Extended,
+// which supports up to 250 action groups per vessel.
+type AGX struct {
+	Client *krpcgo.KRPCClient
+}
+
+// New creates a new AGX.
+func New(client *krpcgo.KRPCClient) *AGX {
+	return &AGX{Client: client}
+}
+
+// Available checks if the AGX service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "AGX")
+}
+
+// Available - whether Action Groups Extended is installed.
+//
+// Allowed game scenes: any.
+func (s *AGX) Available() (bool, error) {
+	var err error
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "AGX",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AvailableStream - whether Action Groups Extended is installed.
+//
+// Allowed game scenes: any.
+func (s *AGX) AvailableStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "AGX",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// GetGroupState - whether an action group is active. Groups are numbered from 1
+// to 250.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) GetGroupState(vessel *spacecenter.Vessel, group int32) (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "GetGroupState",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// GetGroupStateStream - whether an action group is active. Groups are numbered
+// from 1 to 250.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) GetGroupStateStream(vessel *spacecenter.Vessel, group int32) (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "GetGroupState",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetGroupState - set the state of an action group. Groups are numbered from 1
+// to 250.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) SetGroupState(vessel *spacecenter.Vessel, group int32, state bool) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "SetGroupState",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(state)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x2),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ActivateGroup - activate an action group, as if its key had been pressed.
+// Groups are numbered from 1 to 250.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) ActivateGroup(vessel *spacecenter.Vessel, group int32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "ActivateGroup",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ToggleGroup - toggle the state of an action group. Groups are numbered from 1
+// to 250.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) ToggleGroup(vessel *spacecenter.Vessel, group int32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "ToggleGroup",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// GetGroupName - the name given to an action group, or an empty string if it
+// has no name.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) GetGroupName(vessel *spacecenter.Vessel, group int32) (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "GetGroupName",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// GetGroupNameStream - the name given to an action group, or an empty string if
+// it has no name.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) GetGroupNameStream(vessel *spacecenter.Vessel, group int32) (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "GetGroupName",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(group)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// AssignedGroups - the action groups that have at least one action assigned to
+// them.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) AssignedGroups(vessel *spacecenter.Vessel) ([]int32, error) {
+	var err error
+	var argBytes []byte
+	var vv []int32
+	request := &types.ProcedureCall{
+		Procedure: "AssignedGroups",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AssignedGroupsStream - the action groups that have at least one action
+// assigned to them.
+//
+// Allowed game scenes: FLIGHT.
+func (s *AGX) AssignedGroupsStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[[]int32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "AssignedGroups",
+		Service:   "AGX",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []int32 {
+		var value []int32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
@@ -0,0 +1,139 @@
+package agx
+
+import (
+	"fmt"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// MaxGroup is the highest action group supported by Action Groups Extended.
+const MaxGroup = 250
+
+// StockGroups is the number of action groups available without Action Groups
+// Extended.
+const StockGroups = 10
+
+// ErrGroupUnavailable is returned when an action group is out of range, or
+// needs Action Groups Extended when it isn't installed.
+type ErrGroupUnavailable struct {
+	Group    int32
+	Extended bool
+}
+
+// Error returns a human-readable error.
+func (err ErrGroupUnavailable) Error() string {
+	if err.Extended {
+		return fmt.Sprintf("Action group %v is out of range (1-%v)", err.Group, MaxGroup)
+	}
+	return fmt.Sprintf("Action group %v needs Action Groups Extended (stock groups are 1-%v)", err.Group, StockGroups)
+}
+
+// ActionGroups controls the action groups of a vessel. Groups are numbered
+// from 1 to 250 as in Action Groups Extended. If Action Groups Extended isn't
+// installed, groups 1 to 10 fall back to the stock action groups and higher
+// groups return [ErrGroupUnavailable].
+type ActionGroups struct {
+	vessel  *spacecenter.Vessel
+	agx     *AGX
+	control *spacecenter.Control
+}
+
+// NewActionGroups creates a new ActionGroups for a vessel, checking whether
+// Action Groups Extended is installed.
+func NewActionGroups(client *krpcgo.KRPCClient, vessel *spacecenter.Vessel) (*ActionGroups, error) {
+	groups := &ActionGroups{vessel: vessel}
+	available, err := Available(client)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if available {
+		agx := New(client)
+		// The service can be present with the mod itself missing.
+		ok, err := agx.Available()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if ok {
+			groups.agx = agx
+			return groups, nil
+		}
+	}
+
+	control, err := vessel.Control()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	groups.control = control
+	return groups, nil
+}
+
+// Extended returns true if Action Groups Extended is being used.
+func (g *ActionGroups) Extended() bool {
+	return g.agx != nil
+}
+
+// stockGroup converts an action group number to the stock group used by
+// [spacecenter.Control]. Stock group 0 is the group bound to the 0 key, i.e.
+// group 10.
+func stockGroup(group int32) (uint32, error) {
+	if group < 1 || group > StockGroups {
+		return 0, ErrGroupUnavailable{Group: group}
+	}
+	return uint32(group % StockGroups), nil
+}
+
+func (g *ActionGroups) checkGroup(group int32) error {
+	if group < 1 || group > MaxGroup {
+		return ErrGroupUnavailable{Group: group, Extended: true}
+	}
+	return nil
+}
+
+// State gets whether an action group is active.
+func (g *ActionGroups) State(group int32) (bool, error) {
+	if err := g.checkGroup(group); err != nil {
+		return false, err
+	}
+	if g.Extended() {
+		state, err := g.agx.GetGroupState(g.vessel, group)
+		return state, tracerr.Wrap(err)
+	}
+	stock, err := stockGroup(group)
+	if err != nil {
+		return false, err
+	}
+	state, err := g.control.GetActionGroup(stock)
+	return state, tracerr.Wrap(err)
+}
+
+// SetState sets the state of an action group.
+func (g *ActionGroups) SetState(group int32, state bool) error {
+	if err := g.checkGroup(group); err != nil {
+		return err
+	}
+	if g.Extended() {
+		return tracerr.Wrap(g.agx.SetGroupState(g.vessel, group, state))
+	}
+	stock, err := stockGroup(group)
+	if err != nil {
+		return err
+	}
+	return tracerr.Wrap(g.control.SetActionGroup(stock, state))
+}
+
+// Toggle toggles an action group, as if its key had been pressed.
+func (g *ActionGroups) Toggle(group int32) error {
+	if err := g.checkGroup(group); err != nil {
+		return err
+	}
+	if g.Extended() {
+		return tracerr.Wrap(g.agx.ToggleGroup(g.vessel, group))
+	}
+	stock, err := stockGroup(group)
+	if err != nil {
+		return err
+	}
+	return tracerr.Wrap(g.control.ToggleActionGroup(stock))
+}
@@ -0,0 +1,36 @@
+package agx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockGroup(t *testing.T) {
+	tests := []struct {
+		group    int32
+		expected uint32
+	}{
+		{group: 1, expected: 1},
+		{group: 9, expected: 9},
+		{group: 10, expected: 0},
+	}
+	for _, tc := range tests {
+		stock, err := stockGroup(tc.group)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, stock)
+	}
+
+	for _, group := range []int32{0, 11, 250} {
+		_, err := stockGroup(group)
+		require.ErrorIs(t, err, ErrGroupUnavailable{Group: group})
+	}
+}
+
+func TestCheckGroup(t *testing.T) {
+	g := &ActionGroups{}
+	require.NoError(t, g.checkGroup(1))
+	require.NoError(t, g.checkGroup(MaxGroup))
+	require.Error(t, g.checkGroup(0))
+	require.Error(t, g.checkGroup(MaxGroup+1))
+}
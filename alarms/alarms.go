@@ -0,0 +1,443 @@
+// Package alarms schedules in-game alarms and runs Go callbacks when they
+// fire. Alarms are created with the stock alarm clock (KSP 1.12 and later)
+// when the server supports it, and with Kerbal Alarm Clock otherwise.
+package alarms
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/kerbalalarmclock"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// Backend is the alarm clock that alarms are created in.
+type Backend int
+
+const (
+	// BackendStock is the stock alarm clock.
+	BackendStock Backend = iota
+	// BackendKAC is the Kerbal Alarm Clock mod.
+	BackendKAC
+)
+
+// String returns the name of the backend.
+func (b Backend) String() string {
+	switch b {
+	case BackendStock:
+		return "stock"
+	case BackendKAC:
+		return "Kerbal Alarm Clock"
+	}
+	return "unknown"
+}
+
+// Kind is the kind of event an alarm is for.
+type Kind int
+
+const (
+	// KindRaw is an alarm at a fixed time.
+	KindRaw Kind = iota
+	// KindManeuver is an alarm before a maneuver node.
+	KindManeuver
+	// KindSOI is an alarm before a sphere of influence change.
+	KindSOI
+	// KindApoapsis is an alarm before reaching apoapsis.
+	KindApoapsis
+	// KindPeriapsis is an alarm before reaching periapsis.
+	KindPeriapsis
+	// KindTransfer is an alarm before a transfer window.
+	KindTransfer
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindRaw:
+		return "raw"
+	case KindManeuver:
+		return "maneuver"
+	case KindSOI:
+		return "SOI change"
+	case KindApoapsis:
+		return "apoapsis"
+	case KindPeriapsis:
+		return "periapsis"
+	case KindTransfer:
+		return "transfer"
+	}
+	return "unknown"
+}
+
+// Alarm is an alarm created by a Scheduler.
+type Alarm struct {
+	Kind  Kind
+	Title string
+	// Stock is the underlying alarm, if it was created with the stock alarm
+	// clock.
+	Stock *spacecenter.Alarm
+	// KAC is the underlying alarm, if it was created with Kerbal Alarm Clock.
+	KAC *kerbalalarmclock.Alarm
+
+	// mu is the scheduler's lock, which guards time and fired.
+	mu       *sync.Mutex
+	time     float64
+	callback func(*Alarm)
+	fired    bool
+}
+
+// Time returns the universal time the alarm fires at.
+func (a *Alarm) Time() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.time
+}
+
+// Scheduler creates alarms and calls their callbacks when the universal time
+// passes them. Callbacks run on the scheduler's goroutine, one at a time.
+type Scheduler struct {
+	sc      *spacecenter.SpaceCenter
+	backend Backend
+	stock   *spacecenter.AlarmManager
+	kac     *kerbalalarmclock.KerbalAlarmClock
+
+	mu     sync.Mutex
+	alarms []*Alarm
+
+	ut   *krpcgo.Stream[float64]
+	done chan struct{}
+	once sync.Once
+}
+
+// useKAC sets the scheduler up to use Kerbal Alarm Clock, if it's installed.
+func (s *Scheduler) useKAC(client *krpcgo.KRPCClient) error {
+	installed, err := kerbalalarmclock.Available(client)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !installed {
+		return tracerr.Errorf("No alarm clock available: the stock alarm clock needs KSP 1.12, or install Kerbal Alarm Clock")
+	}
+	s.backend = BackendKAC
+	s.kac = kerbalalarmclock.New(client)
+	ok, err := s.kac.Available()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !ok {
+		return tracerr.Errorf("Kerbal Alarm Clock is installed but not available")
+	}
+	return nil
+}
+
+// New creates a new Scheduler, using the stock alarm clock if the server
+// supports it and Kerbal Alarm Clock otherwise. The scheduler is closed when
+// the client is.
+func New(client *krpcgo.KRPCClient) (*Scheduler, error) {
+	if !client.StreamsAvailable() {
+		return nil, tracerr.Errorf("Alarm callbacks need a stream connection")
+	}
+	s := &Scheduler{
+		sc:   spacecenter.New(client),
+		done: make(chan struct{}),
+	}
+	if stock, err := s.sc.AlarmManager(); err == nil && stock != nil {
+		s.backend = BackendStock
+		s.stock = stock
+	} else if err := s.useKAC(client); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	ut, err := s.sc.UTStream()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	s.ut = ut
+	go s.run()
+	client.OnClose(func() { _ = s.Close() })
+	return s, nil
+}
+
+// Backend returns the alarm clock that alarms are created in.
+func (s *Scheduler) Backend() Backend {
+	return s.backend
+}
+
+func (s *Scheduler) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case ut := <-s.ut.C:
+			s.mu.Lock()
+			fired := due(s.alarms, ut)
+			s.mu.Unlock()
+			for _, a := range fired {
+				if a.callback != nil {
+					a.callback(a)
+				}
+			}
+		}
+	}
+}
+
+// due marks every alarm that fires at or before ut as fired, and returns
+// them in the order they fire.
+func due(alarms []*Alarm, ut float64) []*Alarm {
+	var fired []*Alarm
+	for _, a := range alarms {
+		if !a.fired && a.time <= ut {
+			a.fired = true
+			fired = append(fired, a)
+		}
+	}
+	sort.SliceStable(fired, func(i, j int) bool {
+		return fired[i].time < fired[j].time
+	})
+	return fired
+}
+
+// add starts tracking an alarm.
+func (s *Scheduler) add(a *Alarm) *Alarm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a.mu = &s.mu
+	s.alarms = append(s.alarms, a)
+	return a
+}
+
+// stockAlarm starts tracking an alarm created with the stock alarm clock.
+func (s *Scheduler) stockAlarm(kind Kind, title string, alarm *spacecenter.Alarm, callback func(*Alarm)) (*Alarm, error) {
+	if alarm == nil {
+		return nil, tracerr.Errorf("Failed to create %v alarm %q", kind, title)
+	}
+	t, err := alarm.Time()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.add(&Alarm{Kind: kind, Title: title, Stock: alarm, time: t, callback: callback}), nil
+}
+
+// kacAlarm creates an alarm with Kerbal Alarm Clock and starts tracking it.
+func (s *Scheduler) kacAlarm(kind Kind, t kerbalalarmclock.AlarmType, title string, ut float64, vessel *spacecenter.Vessel, callback func(*Alarm)) (*Alarm, error) {
+	alarm, err := s.kac.CreateAlarm(t, title, ut)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if alarm == nil {
+		return nil, tracerr.Errorf("Failed to create %v alarm %q", kind, title)
+	}
+	if vessel != nil {
+		if err := alarm.SetVessel(vessel); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return s.add(&Alarm{Kind: kind, Title: title, KAC: alarm, time: ut, callback: callback}), nil
+}
+
+// At creates an alarm at a universal time. The vessel may be nil.
+func (s *Scheduler) At(ut float64, vessel *spacecenter.Vessel, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendKAC {
+		return s.kacAlarm(KindRaw, kerbalalarmclock.AlarmType_Raw, title, ut, vessel, callback)
+	}
+	var alarm *spacecenter.Alarm
+	var err error
+	if vessel == nil {
+		alarm, err = s.stock.AddAlarm(ut, title, "")
+	} else {
+		alarm, err = s.stock.AddVesselAlarm(ut, vessel, title, "")
+	}
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.stockAlarm(KindRaw, title, alarm, callback)
+}
+
+// Maneuver creates an alarm margin seconds before a maneuver node.
+func (s *Scheduler) Maneuver(vessel *spacecenter.Vessel, node *spacecenter.Node, margin float64, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendStock {
+		alarm, err := s.stock.AddManeuverNodeAlarm(vessel, node, margin, false, title, "")
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return s.stockAlarm(KindManeuver, title, alarm, callback)
+	}
+	ut, err := node.UT()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.kacAlarm(KindManeuver, kerbalalarmclock.AlarmType_Maneuver, title, ut-margin, vessel, callback)
+}
+
+// SOI creates an alarm margin seconds before a vessel changes sphere of
+// influence.
+func (s *Scheduler) SOI(vessel *spacecenter.Vessel, margin float64, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendStock {
+		alarm, err := s.stock.AddSOIAlarm(vessel, margin, title, "")
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return s.stockAlarm(KindSOI, title, alarm, callback)
+	}
+	ut, err := s.eventTime(vessel, (*spacecenter.Orbit).TimeToSOIChange)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.kacAlarm(KindSOI, kerbalalarmclock.AlarmType_SOIChange, title, ut-margin, vessel, callback)
+}
+
+// Apoapsis creates an alarm margin seconds before a vessel reaches apoapsis.
+func (s *Scheduler) Apoapsis(vessel *spacecenter.Vessel, margin float64, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendStock {
+		alarm, err := s.stock.AddApoapsisAlarm(vessel, margin, title, "")
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return s.stockAlarm(KindApoapsis, title, alarm, callback)
+	}
+	ut, err := s.eventTime(vessel, (*spacecenter.Orbit).TimeToApoapsis)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.kacAlarm(KindApoapsis, kerbalalarmclock.AlarmType_Apoapsis, title, ut-margin, vessel, callback)
+}
+
+// Periapsis creates an alarm margin seconds before a vessel reaches
+// periapsis.
+func (s *Scheduler) Periapsis(vessel *spacecenter.Vessel, margin float64, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendStock {
+		alarm, err := s.stock.AddPeriapsisAlarm(vessel, margin, title, "")
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return s.stockAlarm(KindPeriapsis, title, alarm, callback)
+	}
+	ut, err := s.eventTime(vessel, (*spacecenter.Orbit).TimeToPeriapsis)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return s.kacAlarm(KindPeriapsis, kerbalalarmclock.AlarmType_Periapsis, title, ut-margin, vessel, callback)
+}
+
+// Transfer creates an alarm margin seconds before a transfer window from one
+// body to another opens at a universal time. The stock alarm clock has no
+// transfer alarms, so a vessel alarm is used instead.
+func (s *Scheduler) Transfer(vessel *spacecenter.Vessel, origin, target *spacecenter.CelestialBody, ut, margin float64, title string, callback func(*Alarm)) (*Alarm, error) {
+	if s.backend == BackendStock {
+		alarm, err := s.stock.AddVesselAlarm(ut-margin, vessel, title, "")
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return s.stockAlarm(KindTransfer, title, alarm, callback)
+	}
+	a, err := s.kacAlarm(KindTransfer, kerbalalarmclock.AlarmType_Transfer, title, ut-margin, vessel, callback)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := a.KAC.SetXferOriginBody(origin); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := a.KAC.SetXferTargetBody(target); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return a, nil
+}
+
+// eventTime converts a time until an orbital event into a universal time.
+func (s *Scheduler) eventTime(vessel *spacecenter.Vessel, timeTo func(*spacecenter.Orbit) (float64, error)) (float64, error) {
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	dt, err := timeTo(orbit)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	if math.IsNaN(dt) || math.IsInf(dt, 0) {
+		return 0, tracerr.Errorf("The vessel's orbit has no such event")
+	}
+	ut, err := s.sc.UT()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return ut + dt, nil
+}
+
+// Refresh rereads the time of every pending alarm, picking up alarms that
+// were changed in the game (e.g. a maneuver node that was moved).
+func (s *Scheduler) Refresh() error {
+	s.mu.Lock()
+	pending := make([]*Alarm, 0, len(s.alarms))
+	for _, a := range s.alarms {
+		if !a.fired {
+			pending = append(pending, a)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, a := range pending {
+		var t float64
+		var err error
+		if a.Stock != nil {
+			t, err = a.Stock.Time()
+		} else {
+			t, err = a.KAC.Time()
+		}
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		s.mu.Lock()
+		a.time = t
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Cancel stops an alarm's callback from being called. Kerbal Alarm Clock
+// alarms are also removed from the game; kRPC can't remove stock alarms, so
+// they stay in the game.
+func (s *Scheduler) Cancel(a *Alarm) error {
+	s.mu.Lock()
+	for i, other := range s.alarms {
+		if other == a {
+			s.alarms = append(s.alarms[:i], s.alarms[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	if a.KAC != nil {
+		return tracerr.Wrap(a.KAC.Remove())
+	}
+	return nil
+}
+
+// Pending returns the alarms that haven't fired yet, in the order they will
+// fire.
+func (s *Scheduler) Pending() []*Alarm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []*Alarm
+	for _, a := range s.alarms {
+		if !a.fired {
+			pending = append(pending, a)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].time < pending[j].time
+	})
+	return pending
+}
+
+// Close stops the scheduler. Alarms stay in the game, but their callbacks
+// are no longer called.
+func (s *Scheduler) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.ut.Close()
+	})
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,29 @@
+package alarms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDue(t *testing.T) {
+	a := &Alarm{Title: "a", time: 30}
+	b := &Alarm{Title: "b", time: 10}
+	c := &Alarm{Title: "c", time: 50}
+	alarms := []*Alarm{a, b, c}
+
+	require.Empty(t, due(alarms, 5))
+	require.Equal(t, []*Alarm{b, a}, due(alarms, 40))
+	// Alarms only fire once.
+	require.Empty(t, due(alarms, 45))
+	require.Equal(t, []*Alarm{c}, due(alarms, 100))
+	require.True(t, a.fired)
+	require.True(t, b.fired)
+	require.True(t, c.fired)
+}
+
+func TestKindString(t *testing.T) {
+	require.Equal(t, "maneuver", KindManeuver.String())
+	require.Equal(t, "SOI change", KindSOI.String())
+	require.Equal(t, "unknown", Kind(-1).String())
+}
@@ -0,0 +1,84 @@
+// Package alert passes alerts about a vessel, such as an overheating part or
+// a flat battery, from the code that spots them to whatever reports them.
+package alert
+
+import (
+	"sync"
+	"time"
+)
+
+// Severity is how serious an alert is.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Critical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Critical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// Alert reports that a condition has been met, or that it has cleared.
+type Alert struct {
+	// Name is the kind of alert, e.g. "overheating".
+	Name string
+	// Source is what the alert is about, e.g. a part or vessel.
+	Source   string
+	Severity Severity
+	Message  string
+	// Value is the reading that raised or cleared the alert, and Threshold
+	// is the limit it was compared against.
+	Value     float64
+	Threshold float64
+	Time      time.Time
+	// Cleared is set when the condition has gone away.
+	Cleared bool
+}
+
+// Sink receives alerts.
+type Sink interface {
+	Alert(Alert)
+}
+
+// SinkFunc is a function that receives alerts.
+type SinkFunc func(Alert)
+
+// Alert calls the function.
+func (f SinkFunc) Alert(a Alert) {
+	f(a)
+}
+
+// Bridge passes alerts on to any number of sinks. Sinks can be added while
+// alerts are being sent. The zero value is ready to use.
+type Bridge struct {
+	mu    sync.RWMutex
+	sinks []Sink
+}
+
+// Add adds a sink.
+func (b *Bridge) Add(sink Sink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, sink)
+}
+
+// Alert passes an alert to every sink, in the order they were added.
+func (b *Bridge) Alert(a Alert) {
+	b.mu.RLock()
+	sinks := b.sinks
+	b.mu.RUnlock()
+	for _, sink := range sinks {
+		sink.Alert(a)
+	}
+}
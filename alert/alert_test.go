@@ -0,0 +1,33 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge(t *testing.T) {
+	var b Bridge
+	// Alerts with no sinks go nowhere.
+	b.Alert(Alert{Name: "ignored"})
+
+	var first, second []string
+	b.Add(SinkFunc(func(a Alert) {
+		first = append(first, a.Name)
+	}))
+	b.Alert(Alert{Name: "overheating"})
+	b.Add(SinkFunc(func(a Alert) {
+		second = append(second, a.Name)
+	}))
+	b.Alert(Alert{Name: "low charge"})
+
+	require.Equal(t, []string{"overheating", "low charge"}, first)
+	require.Equal(t, []string{"low charge"}, second)
+}
+
+func TestSeverityString(t *testing.T) {
+	require.Equal(t, "info", Info.String())
+	require.Equal(t, "warning", Warning.String())
+	require.Equal(t, "critical", Critical.String())
+	require.Equal(t, "unknown", Severity(7).String())
+}
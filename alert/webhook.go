@@ -0,0 +1,182 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// WebhookConfig configures a Webhook.
+type WebhookConfig struct {
+	// URL is where alerts are posted.
+	URL string
+	// Headers are added to each request, e.g. for authorization.
+	Headers map[string]string
+	// MinSeverity is the least severe alert to post. Cleared alerts are
+	// posted if the alert they clear was.
+	MinSeverity Severity
+	// Timeout is how long to wait for each request. Defaults to 10s.
+	Timeout time.Duration
+	// Queue is how many alerts can wait to be posted before new ones are
+	// dropped. Defaults to 64.
+	Queue int
+	// Logf, if set, is used to log alerts that couldn't be posted.
+	Logf func(format string, args ...any)
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *WebhookConfig) SetDefaults() {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Queue == 0 {
+		cfg.Queue = 64
+	}
+	if cfg.Logf == nil {
+		cfg.Logf = func(string, ...any) {}
+	}
+}
+
+// webhookBody is the JSON posted for an alert.
+type webhookBody struct {
+	Name      string    `json:"name"`
+	Source    string    `json:"source,omitempty"`
+	Severity  string    `json:"severity"`
+	Message   string    `json:"message"`
+	Value     *float64  `json:"value,omitempty"`
+	Threshold *float64  `json:"threshold,omitempty"`
+	Time      time.Time `json:"time"`
+	Cleared   bool      `json:"cleared"`
+}
+
+// finite returns a pointer to a value, or nil if it can't be encoded as
+// JSON.
+func finite(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+// Webhook is a sink that posts alerts as JSON to a URL, e.g.
+//
+//	{"name": "low charge", "source": "Kerbal X", "severity": "critical",
+//	 "message": "Kerbal X: low charge (charge < 0.1)", "value": 0.08,
+//	 "threshold": 0.1, "time": "2024-01-01T12:00:00Z", "cleared": false}
+//
+// Alerts are posted in order in the background, so a slow server doesn't
+// hold up whatever raised them.
+type Webhook struct {
+	cfg    WebhookConfig
+	client *http.Client
+	queue  chan Alert
+	done   chan struct{}
+
+	mu     sync.Mutex
+	posted map[string]bool
+	closed bool
+}
+
+// NewWebhook creates a new Webhook and starts posting alerts.
+func NewWebhook(cfg WebhookConfig) (*Webhook, error) {
+	cfg.SetDefaults()
+	if cfg.URL == "" {
+		return nil, tracerr.Errorf("Webhook has no URL")
+	}
+	w := &Webhook{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		queue:  make(chan Alert, cfg.Queue),
+		done:   make(chan struct{}),
+		posted: map[string]bool{},
+	}
+	go w.run()
+	return w, nil
+}
+
+// Alert queues an alert to be posted.
+func (w *Webhook) Alert(a Alert) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	key := a.Name + "\x00" + a.Source
+	if a.Cleared {
+		if !w.posted[key] {
+			return
+		}
+		delete(w.posted, key)
+	} else {
+		if a.Severity < w.cfg.MinSeverity {
+			return
+		}
+		w.posted[key] = true
+	}
+	select {
+	case w.queue <- a:
+	default:
+		w.cfg.Logf("Dropped alert %q: webhook queue is full", a.Name)
+	}
+}
+
+// run posts queued alerts until the webhook is closed.
+func (w *Webhook) run() {
+	defer close(w.done)
+	for a := range w.queue {
+		if err := w.post(a); err != nil {
+			w.cfg.Logf("Failed to post alert %q: %v", a.Name, err)
+		}
+	}
+}
+
+// post posts an alert.
+func (w *Webhook) post(a Alert) error {
+	body, err := json.Marshal(webhookBody{
+		Name:      a.Name,
+		Source:    a.Source,
+		Severity:  a.Severity.String(),
+		Message:   a.Message,
+		Value:     finite(a.Value),
+		Threshold: finite(a.Threshold),
+		Time:      a.Time,
+		Cleared:   a.Cleared,
+	})
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return tracerr.Errorf("Webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Close stops taking alerts and waits for the queued ones to be posted.
+func (w *Webhook) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	<-w.done
+	return nil
+}
@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	w, err := NewWebhook(WebhookConfig{
+		URL:         server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer secret"},
+		MinSeverity: Warning,
+	})
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	w.Alert(Alert{Name: "low charge", Source: "Kerbal X", Severity: Critical, Message: "Kerbal X: low charge", Value: 0.08, Threshold: 0.1, Time: now})
+	// Alerts below the minimum severity, and their clears, aren't posted.
+	w.Alert(Alert{Name: "weak signal", Severity: Info, Message: "weak signal", Value: math.NaN(), Time: now})
+	w.Alert(Alert{Name: "weak signal", Severity: Info, Message: "weak signal cleared", Time: now, Cleared: true})
+	w.Alert(Alert{Name: "low charge", Source: "Kerbal X", Severity: Info, Message: "Kerbal X: low charge cleared", Value: 0.5, Threshold: 0.1, Time: now, Cleared: true})
+	require.NoError(t, w.Close())
+	// Alerts after closing are dropped.
+	w.Alert(Alert{Name: "late", Severity: Critical})
+
+	require.Equal(t, []map[string]any{
+		{
+			"name":      "low charge",
+			"source":    "Kerbal X",
+			"severity":  "critical",
+			"message":   "Kerbal X: low charge",
+			"value":     0.08,
+			"threshold": 0.1,
+			"time":      "2024-01-01T12:00:00Z",
+			"cleared":   false,
+		},
+		{
+			"name":      "low charge",
+			"source":    "Kerbal X",
+			"severity":  "info",
+			"message":   "Kerbal X: low charge cleared",
+			"value":     0.5,
+			"threshold": 0.1,
+			"time":      "2024-01-01T12:00:00Z",
+			"cleared":   true,
+		},
+	}, bodies)
+}
+
+func TestWebhookErrors(t *testing.T) {
+	_, err := NewWebhook(WebhookConfig{})
+	require.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	var logs []string
+	w, err := NewWebhook(WebhookConfig{URL: server.URL, Logf: func(format string, args ...any) {
+		logs = append(logs, format)
+	}})
+	require.NoError(t, err)
+	w.Alert(Alert{Name: "overheating", Severity: Warning})
+	require.NoError(t, w.Close())
+	require.Equal(t, []string{"Failed to post alert %q: %v"}, logs)
+}
@@ -0,0 +1,113 @@
+package krpcgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+)
+
+// The benchmarks measure the client against the in-memory server, so that
+// they are reproducible without the game. Run them with:
+//
+//	make bench
+
+// benchClient returns a client of a new server.
+func benchClient(b *testing.B) (*krpctest.Server, *krpcgo.KRPCClient) {
+	b.Helper()
+	server := krpctest.NewServer()
+	b.Cleanup(func() { server.Close() })
+	client, err := server.Client(context.Background())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+// reportRate reports how many operations were done per second.
+func reportRate(b *testing.B, unit string) {
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), unit)
+}
+
+func BenchmarkCall(b *testing.B) {
+	server, client := benchClient(b)
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	sc := spacecenter.New(client)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sc.UT(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	reportRate(b, "calls/s")
+}
+
+func BenchmarkCallWithArguments(b *testing.B) {
+	server, client := benchClient(b)
+	server.Return("SpaceCenter", "Vessel_Position", types.NewTuple3(1.0, 2.0, 3.0))
+	vessel := spacecenter.NewVessel(7, client)
+	frame := spacecenter.NewReferenceFrame(8, client)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := vessel.Position(frame); err != nil {
+			b.Fatal(err)
+		}
+	}
+	reportRate(b, "calls/s")
+}
+
+func BenchmarkCallMultiple(b *testing.B) {
+	server, client := benchClient(b)
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	calls := make([]*types.ProcedureCall, 10)
+	for i := range calls {
+		calls[i] = &types.ProcedureCall{Service: "SpaceCenter", Procedure: "get_UT"}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CallMultiple(calls); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*len(calls))/b.Elapsed().Seconds(), "calls/s")
+}
+
+func BenchmarkStreamUpdates(b *testing.B) {
+	server, client := benchClient(b)
+	server.Return("SpaceCenter", "get_UT", 0.0)
+	stream, err := spacecenter.New(client).UTStream()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stream.Close()
+
+	// Updates sent before the stream is being listened to are dropped, so
+	// feed until one arrives.
+	ut := 0.0
+	for received := false; !received; {
+		ut++
+		server.Feed("SpaceCenter", "get_UT", ut)
+		select {
+		case <-stream.C:
+			received = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ut++
+		server.Feed("SpaceCenter", "get_UT", ut)
+		<-stream.C
+	}
+	reportRate(b, "updates/s")
+}
@@ -0,0 +1,268 @@
+// Package blackbox keeps the last few seconds of a flight in memory, like an
+// aircraft's flight recorder, so that when an autopilot crashes a vessel
+// there is a record of what it saw and what it asked the game to do.
+//
+// A Blackbox is a telemetry.Writer, so add it to a telemetry.Recorder, and
+// records the client's procedure calls through an OnCall hook:
+//
+//	bb := blackbox.New(recorder.Metadata(), blackbox.Config{Dir: "crashes"})
+//	recorder.AddWriter(bb)
+//	client.OnCall(bb.RecordCalls)
+//	abortMonitor.OnAbort = bb.OnAbort
+//	err = bb.Watch(runner.Run(ctx))
+//
+// Its contents are written out as JSON by Dump or DumpFile, which happens
+// automatically when an abort is triggered or a watched error occurs.
+package blackbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/ztrue/tracerr"
+)
+
+// Config configures a Blackbox.
+type Config struct {
+	// Window is how far back the blackbox remembers.
+	Window time.Duration
+	// MaxCalls is the most procedure calls to remember, however recent,
+	// since a busy client can make thousands a second.
+	MaxCalls int
+	// Dir is the directory DumpFile writes to.
+	Dir string
+	// Logf, if set, is used to log where automatic dumps are written and
+	// why they failed. It has the same signature as log.Printf.
+	Logf func(format string, args ...any)
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.Window == 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.MaxCalls == 0 {
+		cfg.MaxCalls = 10000
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = "."
+	}
+	if cfg.Logf == nil {
+		cfg.Logf = func(string, ...any) {}
+	}
+}
+
+// Call is a remembered procedure call.
+type Call struct {
+	Time      time.Time `json:"time"`
+	Service   string    `json:"service"`
+	Procedure string    `json:"procedure"`
+	// Arguments are the call's arguments, encoded as kRPC sends them.
+	Arguments [][]byte `json:"arguments,omitempty"`
+	// Result is the call's encoded result, so that the call can be
+	// replayed.
+	Result []byte `json:"result,omitempty"`
+	// Duration is how long the call's batch took, in nanoseconds.
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Event is a remembered note, such as an alert or a phase change.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// Dump is the contents of a blackbox.
+type Dump struct {
+	// Reason is why the blackbox was dumped.
+	Reason   string             `json:"reason"`
+	Time     time.Time          `json:"time"`
+	Metadata telemetry.Metadata `json:"metadata"`
+	// Records are the telemetry records, in the same form as a
+	// telemetry.JSONLWriter's lines.
+	Records []map[string]any `json:"records"`
+	Calls   []Call           `json:"calls"`
+	Events  []Event          `json:"events"`
+}
+
+// Blackbox remembers recent telemetry, procedure calls and events.
+type Blackbox struct {
+	meta telemetry.Metadata
+	cfg  Config
+	now  func() time.Time
+
+	mu      sync.Mutex
+	records []telemetry.Record
+	calls   []Call
+	events  []Event
+}
+
+// New creates a new Blackbox.
+func New(meta telemetry.Metadata, cfg Config) *Blackbox {
+	cfg.SetDefaults()
+	return &Blackbox{meta: meta, cfg: cfg, now: time.Now}
+}
+
+// prune forgets everything older than the window. b.mu must be held.
+func (b *Blackbox) prune() {
+	cutoff := b.now().Add(-b.cfg.Window)
+	i := 0
+	for i < len(b.records) && b.records[i].Time.Before(cutoff) {
+		i++
+	}
+	b.records = b.records[i:]
+
+	i = 0
+	for i < len(b.calls) && b.calls[i].Time.Before(cutoff) {
+		i++
+	}
+	if len(b.calls)-i > b.cfg.MaxCalls {
+		i = len(b.calls) - b.cfg.MaxCalls
+	}
+	b.calls = b.calls[i:]
+
+	i = 0
+	for i < len(b.events) && b.events[i].Time.Before(cutoff) {
+		i++
+	}
+	b.events = b.events[i:]
+}
+
+// Write remembers a telemetry record.
+func (b *Blackbox) Write(rec telemetry.Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.records = append(b.records, rec)
+	b.prune()
+	return nil
+}
+
+// Close does nothing: the blackbox can still be dumped after the recording
+// stops.
+func (b *Blackbox) Close() error {
+	return nil
+}
+
+// RecordCalls remembers a batch of procedure calls. Pass it to the client's
+// OnCall.
+func (b *Blackbox) RecordCalls(info krpcgo.CallInfo) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, call := range info.Calls {
+		c := Call{
+			Time:      info.Started,
+			Service:   call.Service,
+			Procedure: call.Procedure,
+			Duration:  info.Duration,
+		}
+		for _, arg := range call.Arguments {
+			c.Arguments = append(c.Arguments, arg.Value)
+		}
+		if info.Err != nil {
+			c.Error = info.Err.Error()
+		} else if i < len(info.Results) {
+			if err := info.Results[i].Error; err != nil {
+				c.Error = err.Error()
+			} else {
+				c.Result = info.Results[i].Value
+			}
+		}
+		b.calls = append(b.calls, c)
+	}
+	b.prune()
+}
+
+// Event remembers a note.
+func (b *Blackbox) Event(format string, args ...any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, Event{Time: b.now(), Message: fmt.Sprintf(format, args...)})
+	b.prune()
+}
+
+// Alert remembers an alert as an event.
+func (b *Blackbox) Alert(a alert.Alert) {
+	b.Event("%s: %s", a.Severity, a.Message)
+}
+
+// OnAbort remembers an abort and dumps the blackbox. Set it as an
+// AbortMonitor's OnAbort.
+func (b *Blackbox) OnAbort(condition spacecenter.AbortCondition, sample spacecenter.AbortSample) {
+	b.Event("abort: %s", condition.Name)
+	b.autoDump("abort: " + condition.Name)
+}
+
+// Watch dumps the blackbox if err isn't nil, and returns err, so that it can
+// wrap the result of a mission or autopilot.
+func (b *Blackbox) Watch(err error) error {
+	if err != nil {
+		b.Event("error: %v", err)
+		b.autoDump("error: " + err.Error())
+	}
+	return err
+}
+
+// autoDump dumps the blackbox to a file, logging the result.
+func (b *Blackbox) autoDump(reason string) {
+	path, err := b.DumpFile(reason)
+	if err != nil {
+		b.cfg.Logf("Failed to dump blackbox: %v", err)
+		return
+	}
+	b.cfg.Logf("Dumped blackbox to %s", path)
+}
+
+// Snapshot returns the blackbox's contents.
+func (b *Blackbox) Snapshot(reason string) Dump {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.prune()
+	d := Dump{
+		Reason:   reason,
+		Time:     b.now(),
+		Metadata: b.meta,
+		Records:  make([]map[string]any, len(b.records)),
+		Calls:    append([]Call{}, b.calls...),
+		Events:   append([]Event{}, b.events...),
+	}
+	for i, rec := range b.records {
+		d.Records[i] = telemetry.RecordFields(b.meta, rec)
+	}
+	return d
+}
+
+// Dump writes the blackbox's contents as JSON.
+func (b *Blackbox) Dump(w io.Writer, reason string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return tracerr.Wrap(enc.Encode(b.Snapshot(reason)))
+}
+
+// DumpFile writes the blackbox's contents to a new file in the configured
+// directory, named after the time, and returns its path.
+func (b *Blackbox) DumpFile(reason string) (string, error) {
+	if err := os.MkdirAll(b.cfg.Dir, 0o755); err != nil {
+		return "", tracerr.Wrap(err)
+	}
+	name := "blackbox-" + b.now().UTC().Format("20060102-150405.000") + ".json"
+	path := filepath.Join(b.cfg.Dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+	if err := b.Dump(f, reason); err != nil {
+		f.Close()
+		return "", tracerr.Wrap(err)
+	}
+	return path, tracerr.Wrap(f.Close())
+}
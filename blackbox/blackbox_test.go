@@ -0,0 +1,163 @@
+package blackbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func testMetadata() telemetry.Metadata {
+	return telemetry.Metadata{
+		Vessel:   "Kerbal X",
+		Channels: []telemetry.ChannelInfo{{Name: "altitude", Unit: "m"}},
+		Started:  start,
+	}
+}
+
+// newTestBlackbox creates a blackbox whose clock is set by the returned
+// function.
+func newTestBlackbox(cfg Config) (*Blackbox, func(time.Duration)) {
+	b := New(testMetadata(), cfg)
+	now := start
+	b.now = func() time.Time { return now }
+	return b, func(d time.Duration) { now = start.Add(d) }
+}
+
+func TestBlackboxWindow(t *testing.T) {
+	b, setTime := newTestBlackbox(Config{Window: 10 * time.Second, MaxCalls: 2})
+	for i := 0; i < 20; i++ {
+		at := time.Duration(i) * time.Second
+		setTime(at)
+		require.NoError(t, b.Write(telemetry.Record{Time: start.Add(at), Values: []float64{float64(i)}}))
+		b.RecordCalls(krpcgo.CallInfo{
+			Calls:   []*types.ProcedureCall{{Service: "SpaceCenter", Procedure: "get_UT"}},
+			Started: start.Add(at),
+		})
+	}
+	b.Event("staged")
+
+	d := b.Snapshot("test")
+	require.Len(t, d.Records, 11)
+	require.Equal(t, 9.0, d.Records[0]["altitude"])
+	require.Len(t, d.Calls, 2)
+	require.Equal(t, start.Add(18*time.Second), d.Calls[0].Time)
+	require.Equal(t, []Event{{Time: start.Add(19 * time.Second), Message: "staged"}}, d.Events)
+
+	// Everything is forgotten once it falls out of the window.
+	setTime(time.Minute)
+	d = b.Snapshot("test")
+	require.Empty(t, d.Records)
+	require.Empty(t, d.Calls)
+	require.Empty(t, d.Events)
+}
+
+func TestRecordCalls(t *testing.T) {
+	b, _ := newTestBlackbox(Config{})
+	b.RecordCalls(krpcgo.CallInfo{
+		Calls: []*types.ProcedureCall{
+			{Service: "SpaceCenter", Procedure: "Control_set_Throttle", Arguments: []*types.Argument{
+				{Position: 0, Value: []byte{1}},
+				{Position: 1, Value: []byte{0, 0, 0x80, 0x3f}},
+			}},
+			{Service: "SpaceCenter", Procedure: "Vessel_get_Name"},
+		},
+		Results: []*types.ProcedureResult{
+			{Value: []byte{2}},
+			{Error: &types.Error{Service: "SpaceCenter", Name: "InvalidOperationException", Description: "Nope"}},
+		},
+		Started:  start,
+		Duration: time.Millisecond,
+	})
+	b.RecordCalls(krpcgo.CallInfo{
+		Calls:   []*types.ProcedureCall{{Service: "KRPC", Procedure: "GetStatus"}},
+		Err:     errors.New("connection reset"),
+		Started: start,
+	})
+	require.Equal(t, []Call{
+		{
+			Time:      start,
+			Service:   "SpaceCenter",
+			Procedure: "Control_set_Throttle",
+			Arguments: [][]byte{{1}, {0, 0, 0x80, 0x3f}},
+			Result:    []byte{2},
+			Duration:  time.Millisecond,
+		},
+		{
+			Time:      start,
+			Service:   "SpaceCenter",
+			Procedure: "Vessel_get_Name",
+			Duration:  time.Millisecond,
+			Error:     "SpaceCenter - InvalidOperationException: Nope",
+		},
+		{Time: start, Service: "KRPC", Procedure: "GetStatus", Error: "connection reset"},
+	}, b.Snapshot("test").Calls)
+}
+
+func TestDump(t *testing.T) {
+	b, _ := newTestBlackbox(Config{})
+	require.NoError(t, b.Write(telemetry.Record{Time: start, Phase: "ascent", Values: []float64{100}}))
+	b.Alert(alert.Alert{Severity: alert.Critical, Message: "Vessel: overheating"})
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Dump(&buf, "manual"))
+	var d map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &d))
+	require.Equal(t, "manual", d["reason"])
+	require.Equal(t, []any{map[string]any{
+		"time":     "2024-01-01T12:00:00Z",
+		"ut":       0.0,
+		"vessel":   "Kerbal X",
+		"phase":    "ascent",
+		"altitude": 100.0,
+	}}, d["records"])
+	require.Equal(t, []any{map[string]any{
+		"time":    "2024-01-01T12:00:00Z",
+		"message": "critical: Vessel: overheating",
+	}}, d["events"])
+}
+
+func TestAutomaticDumps(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crashes")
+	var logs []string
+	b, setTime := newTestBlackbox(Config{Dir: dir, Logf: func(format string, args ...any) {
+		logs = append(logs, format)
+	}})
+
+	require.NoError(t, b.Watch(nil))
+	_, err := os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+
+	failure := errors.New("lithobraking")
+	require.Equal(t, failure, b.Watch(failure))
+	setTime(time.Second)
+	b.OnAbort(spacecenter.AbortCondition{Name: "descending"}, spacecenter.AbortSample{})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "blackbox-20240101-120000.000.json", entries[0].Name())
+	require.Equal(t, "blackbox-20240101-120001.000.json", entries[1].Name())
+	require.Equal(t, []string{"Dumped blackbox to %s", "Dumped blackbox to %s"}, logs)
+
+	f, err := os.Open(filepath.Join(dir, entries[1].Name()))
+	require.NoError(t, err)
+	defer f.Close()
+	var d Dump
+	require.NoError(t, json.NewDecoder(f).Decode(&d))
+	require.Equal(t, "abort: descending", d.Reason)
+	require.Len(t, d.Events, 2)
+	require.Equal(t, "error: lithobraking", d.Events[0].Message)
+}
@@ -2,10 +2,12 @@ package krpcgo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
-	"net"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/atburke/krpc-go/types"
 	"github.com/golang/protobuf/proto"
@@ -16,11 +18,52 @@ import (
 type KRPCClient struct {
 	mu sync.Mutex
 	KRPCClientConfig
-	conn net.Conn
+	conn io.ReadWriteCloser
 	*StreamClient
 	clientIdentifier [16]byte
+
+	hooksMu     sync.Mutex
+	closeHooks  []func()
+	callHooks   []func(CallInfo)
+	switchHooks []func()
+
+	// pause queues calls while the game is paused, if set.
+	pauseMu sync.Mutex
+	pause   *pauser
+
+	// scheduler orders calls by priority, if set.
+	schedulerMu sync.Mutex
+	scheduler   *callScheduler
+
+	// coalescer shares the results of identical calls, if set.
+	coalesceMu sync.Mutex
+	coalescer  *coalescer
+
+	// tracked are the streams added through the client, by the ids the
+	// client knows them by, for adding them again after switching servers.
+	streamsMu    sync.Mutex
+	tracked      map[uint64]*trackedStream
+	nextStreamID uint64
+	// runCtx is the context the stream client runs with.
+	runCtx context.Context
+
+	// connectMu guards the connection state of lazy clients.
+	connectMu  sync.Mutex
+	connected  bool
+	closed     bool
+	connecting *connectAttempt
 }
 
+// Game is the game that the kRPC server is running in.
+type Game string
+
+const (
+	// GameKSP1 is Kerbal Space Program.
+	GameKSP1 Game = "ksp1"
+	// GameKSP2 is Kerbal Space Program 2.
+	GameKSP2 Game = "ksp2"
+)
+
 // KRPCClientConfig is the config for a kRPC client.
 type KRPCClientConfig struct {
 	// Host is the kRPC server host. Defaults to "localhost".
@@ -34,6 +77,16 @@ type KRPCClientConfig struct {
 	// RPCOnly will only set up the RPC client (and not the stream client) when enabled.
 	// Disabled by default.
 	RPCOnly bool
+	// Game is the game the server is running in. Defaults to GameKSP1. The
+	// KSP2 server doesn't always provide a stream server, so in KSP2 mode the
+	// client falls back to RPC only if it can't connect to one.
+	Game Game
+	// Transport opens the client's connections. Defaults to TCPTransport.
+	Transport Transport
+	// Lazy makes the client connect on its first call, so that Connect
+	// needn't be called and the client can be created and handed out before
+	// the server is up. Disabled by default.
+	Lazy bool
 }
 
 // SetDefaults sets the config defaults.
@@ -59,6 +112,16 @@ func (cfg *KRPCClientConfig) SetDefaults() {
 			cfg.ClientName = "krpc-go"
 		}
 	}
+	if cfg.Transport == nil {
+		cfg.Transport = TCPTransport{}
+	}
+	if cfg.Game == "" {
+		if game, ok := os.LookupEnv("KRPC_GAME"); ok {
+			cfg.Game = Game(game)
+		} else {
+			cfg.Game = GameKSP1
+		}
+	}
 }
 
 // NewKRPCClient creates a new client.
@@ -75,22 +138,44 @@ func DefaultKRPCClient() *KRPCClient {
 	return NewKRPCClient(KRPCClientConfig{})
 }
 
-// Connect connects to a kRPC server.
+// Connect connects to a kRPC server. The connections are dialed with ctx,
+// and the stream client runs until ctx is done.
 func (c *KRPCClient) Connect(ctx context.Context) error {
-	if err := c.connectRPC(); err != nil {
+	if err := c.connectRPC(ctx); err != nil {
 		return tracerr.Wrap(err)
 	}
 	if !c.RPCOnly {
-		if err := c.connectStream(ctx); err != nil {
+		err := c.connectStream(ctx)
+		switch {
+		case errors.Is(err, ErrNoStreamServer):
+			c.RPCOnly = true
+		case err != nil && c.Game == GameKSP2:
+			fmt.Fprintf(os.Stderr, "Stream server unavailable, continuing without streams: %v\n", err)
+			c.RPCOnly = true
+		case err != nil:
 			return tracerr.Wrap(err)
 		}
 	}
+	c.connectMu.Lock()
+	c.connected = true
+	c.connectMu.Unlock()
 	return nil
 }
 
+// StreamsAvailable returns true if the client is connected to a stream
+// server. A lazy client connects first if it hasn't yet.
+func (c *KRPCClient) StreamsAvailable() bool {
+	if c.Lazy {
+		if err := c.ensureConnected(); err != nil {
+			return false
+		}
+	}
+	return c.StreamClient != nil
+}
+
 // connectRPC performs the kRPC connection handshake with the RPC server.
-func (c *KRPCClient) connectRPC() error {
-	conn, err := net.Dial("tcp", net.JoinHostPort(c.Host, c.RPCPort))
+func (c *KRPCClient) connectRPC(ctx context.Context) error {
+	conn, err := c.Transport.DialRPC(ctx, c.KRPCClientConfig)
 	if err != nil {
 		return tracerr.Wrap(err)
 	}
@@ -126,9 +211,21 @@ func (c *KRPCClient) connectRPC() error {
 
 // connectStream creates a new stream from a kRPC client.
 func (c *KRPCClient) connectStream(ctx context.Context) error {
-	conn, err := net.Dial("tcp", net.JoinHostPort(c.Host, c.StreamPort))
+	conn, err := c.dialStream(ctx)
 	if err != nil {
-		tracerr.Wrap(err)
+		return tracerr.Wrap(err)
+	}
+	c.runCtx = ctx
+	c.StreamClient = NewStreamClient(conn)
+	go c.StreamClient.Run(ctx)
+	return nil
+}
+
+// dialStream performs the kRPC connection handshake with the stream server.
+func (c *KRPCClient) dialStream(ctx context.Context) (io.ReadWriteCloser, error) {
+	conn, err := c.Transport.DialStream(ctx, c.KRPCClientConfig)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
 	}
 
 	request := types.ConnectionRequest{
@@ -137,36 +234,89 @@ func (c *KRPCClient) connectStream(ctx context.Context) error {
 	}
 	out, err := proto.Marshal(&request)
 	if err != nil {
-		tracerr.Wrap(err)
+		conn.Close()
+		return nil, tracerr.Wrap(err)
 	}
 	if err := send(conn, out); err != nil {
-		tracerr.Wrap(err)
+		conn.Close()
+		return nil, tracerr.Wrap(err)
 	}
 	in, err := receive(conn)
 	if err != nil {
-		tracerr.Wrap(err)
+		conn.Close()
+		return nil, tracerr.Wrap(err)
 	}
 
 	var resp types.ConnectionResponse
 	if err := proto.Unmarshal(in, &resp); err != nil {
-		tracerr.Wrap(err)
+		conn.Close()
+		return nil, tracerr.Wrap(err)
 	}
 	if resp.Status != types.ConnectionResponse_OK {
-		tracerr.Errorf(resp.Message)
+		conn.Close()
+		return nil, tracerr.Errorf(resp.Message)
 	}
 
-	c.StreamClient = NewStreamClient(conn)
-	go c.StreamClient.Run(ctx)
-	return nil
+	return conn, nil
+}
+
+// OnClose registers a function to be called when the client is closed. Hooks
+// are called in reverse order of registration, before the client's
+// connections are closed, so they can still make calls to clean up objects
+// they created on the server.
+func (c *KRPCClient) OnClose(f func()) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.closeHooks = append(c.closeHooks, f)
+}
+
+// CallInfo describes a batch of procedure calls, for OnCall hooks.
+type CallInfo struct {
+	Calls []*types.ProcedureCall
+	// Results are the calls' results, or nil if the batch failed. Each
+	// result may hold its own error.
+	Results []*types.ProcedureResult
+	Err     error
+	Started time.Time
+	// Duration is how long the server took to answer.
+	Duration time.Duration
+}
+
+// OnCall registers a function to be called after every batch of procedure
+// calls, for logging and tracing. Hooks are called in order of
+// registration, from the goroutine that made the calls, and must not make
+// calls themselves.
+func (c *KRPCClient) OnCall(f func(CallInfo)) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.callHooks = append(c.callHooks, f)
 }
 
 // Close closes the client.
 func (c *KRPCClient) Close() error {
+	c.connectMu.Lock()
+	c.closed = true
+	c.connectMu.Unlock()
+
+	c.hooksMu.Lock()
+	hooks := c.closeHooks
+	c.closeHooks = nil
+	c.hooksMu.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+
 	var errors []error
 	if c.StreamClient != nil {
-		errors = append(errors, c.StreamClient.Close())
+		if err := c.StreamClient.Close(); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil {
+			errors = append(errors, err)
+		}
 	}
-	errors = append(errors, c.conn.Close())
 	if len(errors) > 0 {
 		return tracerr.Errorf("Failed to close connection(s): %v", errors)
 	}
@@ -227,6 +377,87 @@ func readMessageLength(r io.Reader) (uint64, error) {
 
 // CallMultiple performs a batch of procedure calls to the rpc server.
 func (c *KRPCClient) CallMultiple(calls []*types.ProcedureCall) ([]*types.ProcedureResult, error) {
+	if c.Lazy {
+		if err := c.ensureConnected(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+
+	c.pauseMu.Lock()
+	p := c.pause
+	c.pauseMu.Unlock()
+	if p != nil {
+		if err := p.wait(calls); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+
+	call := c.callMultiple
+	c.coalesceMu.Lock()
+	co := c.coalescer
+	c.coalesceMu.Unlock()
+	if co != nil {
+		call = func(calls []*types.ProcedureCall) ([]*types.ProcedureResult, error) {
+			return co.do(calls, c.callMultiple)
+		}
+	}
+
+	c.hooksMu.Lock()
+	hooks := c.callHooks
+	c.hooksMu.Unlock()
+	if len(hooks) == 0 {
+		return call(calls)
+	}
+
+	started := time.Now()
+	results, err := call(calls)
+	info := CallInfo{
+		Calls:    calls,
+		Results:  results,
+		Err:      err,
+		Started:  started,
+		Duration: time.Since(started),
+	}
+	for _, f := range hooks {
+		f(info)
+	}
+	return results, err
+}
+
+// callMultiple sends a batch of procedure calls and waits for the results.
+func (c *KRPCClient) callMultiple(calls []*types.ProcedureCall) ([]*types.ProcedureResult, error) {
+	// Calls that add or change streams are kept track of, so that the
+	// streams can be added again after switching servers.
+	streamCalls := hasStreamCalls(calls)
+	if streamCalls {
+		c.streamsMu.Lock()
+		defer c.streamsMu.Unlock()
+	}
+
+	c.schedulerMu.Lock()
+	scheduler := c.scheduler
+	c.schedulerMu.Unlock()
+	if scheduler != nil {
+		scheduler.acquire(scheduler.priority(calls))
+		defer scheduler.release()
+	}
+
+	if !streamCalls {
+		return c.roundTrip(calls)
+	}
+	results, err := c.roundTrip(c.toServerIDs(calls))
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := c.trackStreams(calls, results); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return results, nil
+}
+
+// roundTrip sends a batch of procedure calls to the server as they are and
+// waits for the results.
+func (c *KRPCClient) roundTrip(calls []*types.ProcedureCall) ([]*types.ProcedureResult, error) {
 	req := &types.Request{
 		Calls: calls,
 	}
@@ -2,8 +2,11 @@ package krpcgo
 
 import (
 	"bytes"
+	"net"
+	"os"
 	"testing"
 
+	"github.com/atburke/krpc-go/types"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/require"
 )
@@ -20,3 +23,83 @@ func FuzzReadMessageLength(f *testing.F) {
 		require.Equal(t, i, l)
 	})
 }
+
+func TestSetDefaultsGame(t *testing.T) {
+	t.Setenv("KRPC_GAME", "")
+	os.Unsetenv("KRPC_GAME")
+	cfg := KRPCClientConfig{}
+	cfg.SetDefaults()
+	require.Equal(t, GameKSP1, cfg.Game)
+
+	t.Setenv("KRPC_GAME", "ksp2")
+	cfg = KRPCClientConfig{}
+	cfg.SetDefaults()
+	require.Equal(t, GameKSP2, cfg.Game)
+
+	cfg = KRPCClientConfig{Game: GameKSP1}
+	cfg.SetDefaults()
+	require.Equal(t, GameKSP1, cfg.Game)
+}
+
+func TestCloseHooks(t *testing.T) {
+	client := DefaultKRPCClient()
+	var calls []int
+	client.OnClose(func() { calls = append(calls, 1) })
+	client.OnClose(func() { calls = append(calls, 2) })
+
+	require.NoError(t, client.Close())
+	require.Equal(t, []int{2, 1}, calls)
+
+	// Hooks only run once.
+	require.NoError(t, client.Close())
+	require.Equal(t, []int{2, 1}, calls)
+}
+
+func TestCallHooks(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	go func() {
+		for {
+			in, err := receive(serverConn)
+			if err != nil {
+				return
+			}
+			var req types.Request
+			if proto.Unmarshal(in, &req) != nil {
+				return
+			}
+			resp := &types.Response{}
+			for range req.Calls {
+				resp.Results = append(resp.Results, &types.ProcedureResult{Value: []byte{1}})
+			}
+			out, _ := proto.Marshal(resp)
+			if send(serverConn, out) != nil {
+				return
+			}
+		}
+	}()
+
+	client := DefaultKRPCClient()
+	client.conn = clientConn
+	var infos []CallInfo
+	client.OnCall(func(info CallInfo) { infos = append(infos, info) })
+
+	call := &types.ProcedureCall{Service: "SpaceCenter", Procedure: "get_UT"}
+	_, err := client.Call(call)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, []*types.ProcedureCall{call}, infos[0].Calls)
+	require.Len(t, infos[0].Results, 1)
+	require.Equal(t, []byte{1}, infos[0].Results[0].Value)
+	require.NoError(t, infos[0].Err)
+	require.False(t, infos[0].Started.IsZero())
+
+	// Failed calls are passed on too.
+	serverConn.Close()
+	_, err = client.Call(call)
+	require.Error(t, err)
+	require.Len(t, infos, 2)
+	require.Nil(t, infos[1].Results)
+	require.Error(t, infos[1].Err)
+}
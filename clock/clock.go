@@ -0,0 +1,283 @@
+// Package clock relates the game's universal time to the wall clock, so that
+// Go timers can be set for moments in game time. The game's time runs at the
+// time warp rate, or slower when the game can't keep up, and stops while it
+// is paused, so the mapping is estimated continuously from the UT the server
+// reports.
+//
+//	c, err := clock.New(client, clock.Config{})
+//	if d, ok := c.Until(burnUT); ok {
+//		timer := time.NewTimer(d)
+//		...
+//	}
+package clock
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// Config configures a Clock.
+type Config struct {
+	// Window is how much recent wall-clock time the rate of UT is estimated
+	// over. Longer windows smooth out jitter in when updates arrive, but
+	// take longer to follow the game slowing down. Defaults to 2s.
+	Window time.Duration
+	// Tolerance is how far, in wall-clock time at the current rate, the UT
+	// may stray from the estimate before the estimate starts over, e.g.
+	// after a quickload or a change in warp. Defaults to 250ms.
+	Tolerance time.Duration
+	// StaleAfter is how long without an update before the game is taken to
+	// be paused. The UT updates every physics frame while the game runs.
+	// Defaults to 1s.
+	StaleAfter time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.Window == 0 {
+		cfg.Window = 2 * time.Second
+	}
+	if cfg.Tolerance == 0 {
+		cfg.Tolerance = 250 * time.Millisecond
+	}
+	if cfg.StaleAfter == 0 {
+		cfg.StaleAfter = time.Second
+	}
+}
+
+// observation is a UT and when it was seen.
+type observation struct {
+	ut float64
+	t  time.Time
+}
+
+// Clock estimates the mapping between the game's universal time and the
+// wall clock.
+type Clock struct {
+	cfg Config
+	now func() time.Time
+
+	mu sync.Mutex
+	// samples are the observations in the window, oldest first.
+	samples []observation
+	// warp is the warp rate the server last reported, which stands in for
+	// the rate until there are enough samples to estimate it.
+	warp    float64
+	rate    float64
+	changed chan struct{}
+
+	streams []interface{ Close() error }
+	done    chan struct{}
+	once    sync.Once
+}
+
+// newClock creates a clock that isn't fed by a server.
+func newClock(cfg Config) *Clock {
+	cfg.SetDefaults()
+	return &Clock{
+		cfg:     cfg,
+		now:     time.Now,
+		warp:    1,
+		rate:    1,
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// New creates a new Clock, following the UT and warp rate by stream until it
+// is closed. It waits for the first UT, so conversions are available as soon
+// as it returns. The clock is closed when the client is.
+func New(client *krpcgo.KRPCClient, cfg Config) (*Clock, error) {
+	if !client.StreamsAvailable() {
+		return nil, tracerr.Errorf("A clock needs a stream connection")
+	}
+	sc := spacecenter.New(client)
+	c := newClock(cfg)
+	ut, err := sc.UT()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	warp, err := sc.WarpRate()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	c.setWarp(float64(warp))
+	c.observe(ut, c.now())
+
+	utStream, err := sc.UTStream()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	warpStream, err := sc.WarpRateStream()
+	if err != nil {
+		_ = utStream.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	c.streams = append(c.streams, utStream, warpStream)
+	go func() {
+		for {
+			select {
+			case <-c.done:
+				return
+			case ut := <-utStream.C:
+				c.observe(ut, c.now())
+			case warp := <-warpStream.C:
+				c.setWarp(float64(warp))
+			}
+		}
+	}()
+	client.OnClose(func() { _ = c.Close() })
+	return c, nil
+}
+
+// notify wakes anyone waiting on Changed. The lock must be held.
+func (c *Clock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// setWarp notes a change in the warp rate, which starts the estimate over.
+func (c *Clock) setWarp(warp float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if warp == c.warp {
+		return
+	}
+	c.warp = warp
+	c.rate = warp
+	if n := len(c.samples); n > 0 {
+		c.samples = c.samples[n-1:]
+	}
+	c.notify()
+}
+
+// observe notes the UT seen at a time.
+func (c *Clock) observe(ut float64, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n := len(c.samples); n > 0 {
+		last := c.samples[n-1]
+		predicted := last.ut + c.rate*t.Sub(last.t).Seconds()
+		if t.Sub(last.t) > c.cfg.StaleAfter || math.Abs(ut-predicted) > math.Max(c.rate, 1)*c.cfg.Tolerance.Seconds() {
+			// The game was paused, or the UT jumped: what came before
+			// says nothing about the rate now.
+			c.samples = nil
+			c.rate = c.warp
+			c.notify()
+		}
+	}
+	c.samples = append(c.samples, observation{ut: ut, t: t})
+	for len(c.samples) > 2 && t.Sub(c.samples[0].t) > c.cfg.Window {
+		c.samples = c.samples[1:]
+	}
+	first, last := c.samples[0], c.samples[len(c.samples)-1]
+	if dt := last.t.Sub(first.t).Seconds(); dt > 0 {
+		rate := (last.ut - first.ut) / dt
+		// Small changes are jitter; only wake waiters for real ones.
+		if math.Abs(rate-c.rate) > 0.01*math.Max(c.rate, 1) {
+			c.rate = rate
+			c.notify()
+		} else {
+			c.rate = rate
+		}
+	}
+}
+
+// paused reports whether the game seems to be paused at t. The lock must be
+// held.
+func (c *Clock) paused(t time.Time) bool {
+	return len(c.samples) == 0 || t.Sub(c.samples[len(c.samples)-1].t) > c.cfg.StaleAfter
+}
+
+// Rate returns the number of seconds of UT that pass per second of wall
+// clock time, or zero while the game is paused.
+func (c *Clock) Rate() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.paused(c.now()) {
+		return 0
+	}
+	return c.rate
+}
+
+// Paused reports whether the game seems to be paused, because the UT hasn't
+// changed for a while.
+func (c *Clock) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused(c.now())
+}
+
+// UTAt returns the estimated UT at a wall-clock time. While the game is
+// paused, it is the last UT seen.
+func (c *Clock) UTAt(t time.Time) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.samples) == 0 {
+		return 0
+	}
+	last := c.samples[len(c.samples)-1]
+	if c.paused(c.now()) {
+		return last.ut
+	}
+	return last.ut + c.rate*t.Sub(last.t).Seconds()
+}
+
+// UT returns the estimated current UT.
+func (c *Clock) UT() float64 {
+	return c.UTAt(c.now())
+}
+
+// TimeAt returns the estimated wall-clock time at a UT. It returns false if
+// the time can't be estimated because the game is paused.
+func (c *Clock) TimeAt(ut float64) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.paused(c.now()) || c.rate <= 0 {
+		return time.Time{}, false
+	}
+	last := c.samples[len(c.samples)-1]
+	return last.t.Add(time.Duration((ut - last.ut) / c.rate * float64(time.Second))), true
+}
+
+// Until returns the estimated wall-clock time until a UT, which is negative
+// if it has passed. It returns false if the game is paused. The estimate
+// only holds while the rate stays the same, so timers set from it should be
+// reset when Changed fires.
+func (c *Clock) Until(ut float64) (time.Duration, bool) {
+	t, ok := c.TimeAt(ut)
+	if !ok {
+		return 0, false
+	}
+	return t.Sub(c.now()), true
+}
+
+// Changed returns a channel that is closed the next time the estimate
+// changes enough to move a timer, e.g. when the warp rate changes, the game
+// resumes after a pause, or the UT jumps. Pausing the game stops updates
+// rather than sending one, so a timer set before a pause fires early, and
+// whatever it was waiting for should check the UT first.
+func (c *Clock) Changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.changed
+}
+
+// Close stops following the UT. Conversions keep using the last estimate.
+func (c *Clock) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.done)
+		for _, s := range c.streams {
+			if closeErr := s.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	})
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,153 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock returns a clock whose time is controlled by the returned
+// function, which moves it on by a duration.
+func testClock() (*Clock, func(time.Duration) time.Time) {
+	c := newClock(Config{})
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	return c, func(d time.Duration) time.Time {
+		now = now.Add(d)
+		return now
+	}
+}
+
+// closed reports whether a channel is closed.
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestClockRealTime(t *testing.T) {
+	c, advance := testClock()
+	c.observe(1000, advance(0))
+	for i := 0; i < 10; i++ {
+		c.observe(1000+float64(i+1)*0.1, advance(100*time.Millisecond))
+	}
+	require.InDelta(t, 1, c.Rate(), 1e-9)
+	require.InDelta(t, 1001, c.UT(), 1e-9)
+	advance(50 * time.Millisecond)
+	require.InDelta(t, 1001.05, c.UT(), 1e-9)
+
+	d, ok := c.Until(1011)
+	require.True(t, ok)
+	require.Equal(t, 9950*time.Millisecond, d)
+	d, ok = c.Until(1000)
+	require.True(t, ok)
+	require.Equal(t, -1050*time.Millisecond, d)
+}
+
+func TestClockSlowGame(t *testing.T) {
+	c, advance := testClock()
+	c.observe(0, advance(0))
+	changed := c.Changed()
+	// The game only manages half speed.
+	for i := 0; i < 30; i++ {
+		c.observe(float64(i+1)*0.05, advance(100*time.Millisecond))
+	}
+	require.True(t, closed(changed))
+	require.InDelta(t, 0.5, c.Rate(), 1e-9)
+	at, ok := c.TimeAt(2.5)
+	require.True(t, ok)
+	require.Equal(t, c.now().Add(2*time.Second), at)
+}
+
+func TestClockWarp(t *testing.T) {
+	c, advance := testClock()
+	c.observe(0, advance(0))
+	c.observe(0.1, advance(100*time.Millisecond))
+	changed := c.Changed()
+
+	c.setWarp(100)
+	require.True(t, closed(changed))
+	require.Equal(t, 100.0, c.Rate())
+	require.InDelta(t, 0.1, c.UT(), 1e-9)
+	d, ok := c.Until(100.1)
+	require.True(t, ok)
+	require.Equal(t, time.Second, d)
+
+	// The estimate follows the game from there.
+	changed = c.Changed()
+	for i := 0; i < 10; i++ {
+		c.observe(0.1+float64(i+1)*8, advance(100*time.Millisecond))
+	}
+	require.True(t, closed(changed))
+	require.InDelta(t, 80, c.Rate(), 1e-9)
+}
+
+func TestClockPause(t *testing.T) {
+	c, advance := testClock()
+	c.observe(0, advance(0))
+	c.observe(0.5, advance(500*time.Millisecond))
+	require.False(t, c.Paused())
+
+	advance(2 * time.Second)
+	require.True(t, c.Paused())
+	require.Zero(t, c.Rate())
+	require.Equal(t, 0.5, c.UT())
+	_, ok := c.Until(10)
+	require.False(t, ok)
+
+	// Resuming starts the estimate over.
+	changed := c.Changed()
+	c.observe(0.6, advance(0))
+	require.True(t, closed(changed))
+	require.False(t, c.Paused())
+	require.Equal(t, 1.0, c.Rate())
+	require.InDelta(t, 0.6, c.UT(), 1e-9)
+}
+
+func TestClockJump(t *testing.T) {
+	c, advance := testClock()
+	c.observe(100, advance(0))
+	c.observe(100.1, advance(100*time.Millisecond))
+	changed := c.Changed()
+
+	// A quickload takes the UT back.
+	c.observe(50, advance(100*time.Millisecond))
+	require.True(t, closed(changed))
+	require.Equal(t, 1.0, c.Rate())
+	require.InDelta(t, 50, c.UT(), 1e-9)
+
+	// Jitter doesn't.
+	changed = c.Changed()
+	c.observe(50.1005, advance(100*time.Millisecond))
+	require.False(t, closed(changed))
+}
+
+func TestNew(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	server.Return("SpaceCenter", "get_WarpRate", float32(1))
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	c, err := New(client, Config{})
+	require.NoError(t, err)
+	require.InDelta(t, 1000, c.UT(), 1)
+
+	changed := c.Changed()
+	server.Feed("SpaceCenter", "get_WarpRate", float32(50))
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		require.FailNow(t, "warp change wasn't noticed")
+	}
+	require.Equal(t, 50.0, c.Rate())
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,105 @@
+// Command krpc-top shows the active vessel's telemetry and health alerts on
+// the terminal, for watching a mission from a machine without a browser.
+//
+// It connects to the kRPC server given by the KRPC_HOST and KRPC_PORT
+// environment variables, or localhost by default. Press Ctrl-C to quit.
+//
+// Usage:
+//
+//	krpc-top [-channels altitude,orbit.apoapsis] [-interval 1s]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/dashboard"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/telemetry"
+)
+
+func main() {
+	channelNames := flag.String("channels", "", "comma-separated channels to show (default all)")
+	interval := flag.Duration("interval", 0, "how often to sample (default 1s)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, *channelNames, telemetry.RecorderConfig{Interval: *interval}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// selectChannels returns the named channels, with their capacities, or every
+// channel if none are named.
+func selectChannels(channels []telemetry.Channel, names string) ([]telemetry.Channel, error) {
+	if names == "" {
+		return channels, nil
+	}
+	byName := map[string]telemetry.Channel{}
+	for _, c := range channels {
+		byName[c.Name] = c
+	}
+	var selected []telemetry.Channel
+	for _, name := range strings.Split(names, ",") {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown channel %q", name)
+		}
+		selected = append(selected, c)
+		if capacity, ok := byName[name+".max"]; ok {
+			selected = append(selected, capacity)
+		}
+	}
+	return selected, nil
+}
+
+func run(ctx context.Context, channelNames string, cfg telemetry.RecorderConfig) error {
+	client := krpcgo.DefaultKRPCClient()
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sc := spacecenter.New(client)
+	vessel, err := sc.ActiveVessel()
+	if err != nil {
+		return err
+	}
+	if cfg.Vessel, err = vessel.Name(); err != nil {
+		return err
+	}
+	channels, err := dashboard.VesselChannels(vessel)
+	if err != nil {
+		return err
+	}
+	if channels, err = selectChannels(channels, channelNames); err != nil {
+		return err
+	}
+	cfg.UT = sc.UT
+
+	recorder := telemetry.NewRecorder(cfg, channels...)
+	term := dashboard.NewTerminal(os.Stdout, recorder.Metadata(), dashboard.TerminalConfig{})
+	recorder.AddWriter(term)
+
+	var bridge alert.Bridge
+	bridge.Add(term)
+	health := spacecenter.NewHealthMonitor(vessel, spacecenter.HealthConfig{Bridge: &bridge})
+	go func() {
+		if err := health.Run(ctx); err != nil && ctx.Err() == nil {
+			_ = term.Event("health monitor stopped: %v", err)
+		}
+	}()
+
+	if err := recorder.Run(ctx); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/gateway"
+)
+
+// arguments are procedure arguments by name, set by -arg flags.
+type arguments map[string]any
+
+func (a arguments) String() string {
+	return ""
+}
+
+// Set adds an argument given as name=value. The value is read as JSON if it
+// can be, and as a string otherwise, e.g. 1234, true, [1, 2] or Kerbal X.
+func (a arguments) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", s)
+	}
+	d := json.NewDecoder(strings.NewReader(value))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil || d.More() {
+		v = value
+	}
+	a[name] = v
+	return nil
+}
+
+// parseProcedure parses a command's flags and the service and procedure it
+// is given, which flags can come before or after.
+func parseProcedure(flags *flag.FlagSet, args []string) (service, procedure string, err error) {
+	if err := flags.Parse(args); err != nil {
+		return "", "", err
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return "", "", fmt.Errorf("expected a service and a procedure")
+	}
+	service, procedure = flags.Arg(0), flags.Arg(1)
+	if err := flags.Parse(flags.Args()[2:]); err != nil {
+		return "", "", err
+	}
+	if flags.NArg() > 0 {
+		return "", "", fmt.Errorf("unexpected arguments %q; give procedure arguments with -arg", flags.Args())
+	}
+	return service, procedure, nil
+}
+
+// call calls a procedure and prints its result as JSON.
+func call(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("call", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: krpcgo call <service> <procedure> [-arg name=value]...")
+		fmt.Fprintln(flags.Output(), "\nFor example: krpcgo call SpaceCenter Vessel_get_Name -arg this=1234")
+		flags.PrintDefaults()
+	}
+	procArgs := arguments{}
+	flags.Var(procArgs, "arg", "a procedure argument, as name=value (repeatable)")
+	timeout := flags.Duration("timeout", 5*time.Second, "how long to wait for the server")
+	service, procedure, err := parseProcedure(flags, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	return callProcedure(ctx, krpcgo.KRPCClientConfig{RPCOnly: true}, service, procedure, procArgs, out)
+}
+
+// callProcedure connects to a server, calls a procedure and prints its
+// result.
+func callProcedure(ctx context.Context, cfg krpcgo.KRPCClientConfig, service, procedure string, args arguments, out io.Writer) error {
+	client := krpcgo.NewKRPCClient(cfg)
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	g, err := gateway.New(client, gateway.Config{Services: []string{service}})
+	if err != nil {
+		return err
+	}
+	result, err := g.Call(service, procedure, args)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", b)
+	return err
+}
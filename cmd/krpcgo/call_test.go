@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArguments(t *testing.T) {
+	args := arguments{}
+	for _, s := range []string{"this=1234", "name=Kerbal X", "flag=true", "list=[1, 2]", "quoted=\"7\"", "empty="} {
+		require.NoError(t, args.Set(s))
+	}
+	require.Equal(t, arguments{
+		"this":   json.Number("1234"),
+		"name":   "Kerbal X",
+		"flag":   true,
+		"list":   []any{json.Number("1"), json.Number("2")},
+		"quoted": "7",
+		"empty":  "",
+	}, args)
+	require.Error(t, args.Set("1234"))
+}
+
+func TestCallProcedure(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	vessel := &types.Type{Code: types.Type_CLASS, Service: "SpaceCenter", Name: "Vessel"}
+	server.Return("KRPC", "GetServices", &types.Services{Services: []*types.Service{{
+		Name: "SpaceCenter",
+		Procedures: []*types.Procedure{{
+			Name:       "Vessel_get_Name",
+			Parameters: []*types.Parameter{{Name: "this", Type: vessel}},
+			ReturnType: &types.Type{Code: types.Type_STRING},
+		}},
+	}}})
+	server.Handle("SpaceCenter", "Vessel_get_Name", func(call *krpctest.Call) (interface{}, error) {
+		var id uint64
+		if err := call.Arg(0, &id); err != nil {
+			return nil, err
+		}
+		require.Equal(t, uint64(1234), id)
+		return "Kerbal X", nil
+	})
+
+	cfg := server.ClientConfig()
+	cfg.RPCOnly = true
+	var out bytes.Buffer
+	args := arguments{}
+	require.NoError(t, args.Set("this=1234"))
+	require.NoError(t, callProcedure(context.Background(), cfg, "SpaceCenter", "Vessel_get_Name", args, &out))
+	require.Equal(t, "\"Kerbal X\"\n", out.String())
+
+	err := callProcedure(context.Background(), cfg, "SpaceCenter", "Vessel_get_Name", arguments{}, &out)
+	require.ErrorContains(t, err, "Missing argument")
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/atburke/krpc-go/conformance"
+)
+
+// checkConformance checks that the server speaks the protocol as expected.
+func checkConformance(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	timeout := flags.Duration("timeout", 5*time.Second, "how long to wait for stream updates and events")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	return runConformance(ctx, conformance.Config{Timeout: *timeout}, out)
+}
+
+// runConformance runs the conformance checks and writes the report,
+// failing if any check failed.
+func runConformance(ctx context.Context, cfg conformance.Config, out io.Writer) error {
+	report := conformance.Run(ctx, cfg)
+	if err := report.Write(out); err != nil {
+		return err
+	}
+	if n := report.Count(conformance.Fail); n > 0 {
+		return fmt.Errorf("%d checks failed", n)
+	}
+	return nil
+}
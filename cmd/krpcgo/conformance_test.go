@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/conformance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConformance(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	var out bytes.Buffer
+	cfg := conformance.Config{Client: krpcgo.KRPCClientConfig{Host: host, RPCPort: port, StreamPort: port}}
+	require.EqualError(t, runConformance(context.Background(), cfg, &out), "1 checks failed")
+	require.Contains(t, out.String(), "FAIL handshake: connect")
+	require.Contains(t, out.String(), "0 passed, 1 failed, 0 skipped\n")
+}
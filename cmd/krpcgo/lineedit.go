@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// lineEditor reads lines from a terminal in raw mode, echoing them, with
+// tab completion. Only the end of the line can be edited.
+type lineEditor struct {
+	in  *bufio.Reader
+	out io.Writer
+	// complete returns the completions of the word at the end of a line,
+	// and where the word starts.
+	complete func(line string) (int, []string)
+}
+
+// commonPrefix returns the longest prefix of all of the strings.
+func commonPrefix(ss []string) string {
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// completeLine completes the word at the end of a line as far as it can,
+// listing the candidates if it can't be completed any further.
+func (e *lineEditor) completeLine(prompt, line string) string {
+	start, candidates := e.complete(line)
+	if len(candidates) == 0 {
+		return line
+	}
+	completed := line[:start] + commonPrefix(candidates)
+	if completed != line {
+		fmt.Fprint(e.out, completed[len(line):])
+		return completed
+	}
+	fmt.Fprintf(e.out, "\n%v\n%v%v", strings.Join(candidates, "  "), prompt, line)
+	return line
+}
+
+// readLine reads a line, returning io.EOF if Ctrl-D is pressed on an empty
+// line. Ctrl-C discards the line.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	var line []rune
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\n")
+			return "", nil
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\n")
+				return "", io.EOF
+			}
+		case 127, '\b':
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				fmt.Fprint(e.out, "\b \b")
+			}
+		case '\t':
+			line = []rune(e.completeLine(prompt, string(line)))
+		case 27: // Escape sequences, such as arrow keys, aren't supported.
+			if b, err := e.in.ReadByte(); err == nil && b == '[' {
+				_, _ = e.in.ReadByte()
+			}
+		default:
+			if r >= ' ' {
+				line = append(line, r)
+				fmt.Fprint(e.out, string(r))
+			}
+		}
+	}
+}
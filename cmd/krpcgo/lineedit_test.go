@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineEditor(t *testing.T) {
+	complete := func(line string) (int, []string) {
+		start := strings.LastIndex(line, " ") + 1
+		var candidates []string
+		for _, c := range []string{"SpaceCenter.UT", "SpaceCenter.WarpTo", "vessel"} {
+			if strings.HasPrefix(c, line[start:]) {
+				candidates = append(candidates, c)
+			}
+		}
+		return start, candidates
+	}
+	tests := []struct {
+		name     string
+		in       string
+		line     string
+		err      error
+		expected string
+	}{
+		{name: "typing", in: "v.Name\r", line: "v.Name", expected: "> v.Name\n"},
+		{name: "backspace", in: "v.Nx\x7fame\r", line: "v.Name", expected: "> v.Nx\b \bame\n"},
+		{name: "complete", in: "x = ve\t\r", line: "x = vessel", expected: "> x = vessel\n"},
+		{
+			name:     "list candidates",
+			in:       "Sp\t\t\r",
+			line:     "SpaceCenter.",
+			expected: "> SpaceCenter.\nSpaceCenter.UT  SpaceCenter.WarpTo\n> SpaceCenter.\n",
+		},
+		{name: "arrow keys ignored", in: "a\x1b[Db\r", line: "ab", expected: "> ab\n"},
+		{name: "ctrl-c", in: "abc\x03", line: "", expected: "> abc^C\n"},
+		{name: "ctrl-d", in: "\x04", err: io.EOF, expected: "> \n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			e := &lineEditor{in: bufio.NewReader(strings.NewReader(tc.in)), out: &out, complete: complete}
+			line, err := e.readLine("> ")
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.line, line)
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+}
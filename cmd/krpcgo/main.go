@@ -0,0 +1,78 @@
+// Command krpcgo provides tools for working with a kRPC server.
+//
+// It connects to the kRPC server given by the KRPC_HOST and KRPC_PORT
+// environment variables, or localhost by default.
+//
+// Usage:
+//
+//	krpcgo <command> [flags]
+//
+// The commands are:
+//
+//	call         call a procedure and print its result as JSON
+//	conformance  check that the server speaks the protocol as expected
+//	repl         an interactive shell for calling procedures
+//	services     list the services, their procedures and documentation
+//	status       print the server's version, load and connected clients
+//	watch        stream a procedure's result and print its values
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+)
+
+// command is a krpcgo command.
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string, out io.Writer) error
+}
+
+var commands = map[string]command{
+	"call":        {summary: "call a procedure and print its result as JSON", run: call},
+	"conformance": {summary: "check that the server speaks the protocol as expected", run: checkConformance},
+	"repl":        {summary: "an interactive shell for calling procedures", run: repl},
+	"services":    {summary: "list the services, their procedures and documentation", run: services},
+	"status":      {summary: "print the server's version, load and connected clients", run: status},
+	"watch":       {summary: "stream a procedure's result and print its values", run: watch},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: krpcgo <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %v\n", name, commands[name].summary)
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "krpcgo: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := cmd.run(ctx, flag.Args()[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "krpcgo %v: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
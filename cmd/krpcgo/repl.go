@@ -0,0 +1,600 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/gateway"
+	"github.com/atburke/krpc-go/types"
+)
+
+const replHelp = `Expressions:
+  SpaceCenter.UT                    call a procedure or read a property
+  SpaceCenter.WarpTo(1000)          call a procedure with arguments
+  v = SpaceCenter.ActiveVessel      keep a result, e.g. an object, in a variable
+  v.Name                            read a property of an object
+  v.Flight(v.ReferenceFrame).Speed  chain calls on the objects they return
+  v.Control.Throttle = 0.5          set a property
+Arguments are JSON values, variables or expressions. The last result is _.
+
+Commands:
+  help   show this help
+  vars   list the variables
+  exit   quit (or Ctrl-D)
+
+Press Tab to complete services, procedures and variables.
+`
+
+// value is a value in a REPL session: a JSON value, as the gateway takes and
+// gives them, and its kRPC type if it is known.
+type value struct {
+	v any
+	t *types.Type
+}
+
+// String formats a value for printing, with the type of objects.
+func (v value) String() string {
+	b, err := json.Marshal(v.v)
+	if err != nil {
+		return fmt.Sprint(v.v)
+	}
+	if v.t != nil && v.t.Code == types.Type_CLASS && v.v != nil {
+		return fmt.Sprintf("%s (%v)", b, gateway.TypeName(v.t))
+	}
+	return string(b)
+}
+
+// session is the state of a REPL session.
+type session struct {
+	g *gateway.Gateway
+	// procedures are the procedures of each service, by name.
+	procedures map[string]map[string]*types.Procedure
+	// classes are the names of each service's classes.
+	classes map[string][]string
+	vars    map[string]value
+}
+
+// newSession creates a session calling procedures through a gateway.
+func newSession(g *gateway.Gateway) *session {
+	s := &session{
+		g:          g,
+		procedures: map[string]map[string]*types.Procedure{},
+		classes:    map[string][]string{},
+		vars:       map[string]value{},
+	}
+	for _, service := range g.Services() {
+		procs := map[string]*types.Procedure{}
+		for _, p := range service.Procedures {
+			procs[p.Name] = p
+		}
+		s.procedures[service.Name] = procs
+		for _, c := range service.Classes {
+			s.classes[service.Name] = append(s.classes[service.Name], c.Name)
+		}
+	}
+	return s
+}
+
+// jsonValue converts a result from the gateway into a JSON value as the
+// gateway takes them, with numbers as json.Number.
+func jsonValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(strings.NewReader(string(b)))
+	d.UseNumber()
+	var out any
+	return out, d.Decode(&out)
+}
+
+// object returns the service and class of a variable holding an object.
+func (s *session) object(name string) (service, class string, ok bool) {
+	v, ok := s.vars[name]
+	if !ok || v.t == nil || v.t.Code != types.Type_CLASS || v.v == nil {
+		return "", "", false
+	}
+	return v.t.Service, v.t.Name, true
+}
+
+// resolve finds the procedure that a member of a service or object names,
+// trying each of the procedure names in turn.
+func (s *session) resolve(target value, targetName, member string, names []string) (string, *types.Procedure, error) {
+	var service string
+	if target.t != nil && target.t.Code == types.Type_CLASS {
+		service = target.t.Service
+	} else {
+		service = targetName
+	}
+	procs, ok := s.procedures[service]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown service or object %q", targetName)
+	}
+	for _, name := range names {
+		if p, ok := procs[name]; ok {
+			return service, p, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%v has no %q", targetName, member)
+}
+
+// call calls a member of a service or object: a procedure, or a property if
+// there are no arguments.
+func (s *session) call(target value, targetName, member string, args []value) (value, error) {
+	var names []string
+	if target.t != nil && target.t.Code == types.Type_CLASS {
+		if target.v == nil {
+			return value{}, fmt.Errorf("%v is null", targetName)
+		}
+		class := target.t.Name
+		names = []string{class + "_" + member, class + "_static_" + member}
+		if len(args) == 0 {
+			names = append(names, class+"_get_"+member)
+		}
+		args = append([]value{target}, args...)
+	} else {
+		names = []string{member}
+		if len(args) == 0 {
+			names = append(names, "get_"+member)
+		}
+	}
+	service, p, err := s.resolve(target, targetName, member, names)
+	if err != nil {
+		return value{}, err
+	}
+	// Static methods don't take the object.
+	if strings.Contains(p.Name, "_static_") {
+		args = args[1:]
+	}
+	return s.invoke(service, p, args)
+}
+
+// set sets a property of a service or object.
+func (s *session) set(target value, targetName, member string, v value) error {
+	var names []string
+	args := []value{v}
+	if target.t != nil && target.t.Code == types.Type_CLASS {
+		if target.v == nil {
+			return fmt.Errorf("%v is null", targetName)
+		}
+		names = []string{target.t.Name + "_set_" + member}
+		args = []value{target, v}
+	} else {
+		names = []string{"set_" + member}
+	}
+	service, p, err := s.resolve(target, targetName, member, names)
+	if err != nil {
+		return err
+	}
+	_, err = s.invoke(service, p, args)
+	return err
+}
+
+// invoke calls a procedure with arguments by position.
+func (s *session) invoke(service string, p *types.Procedure, args []value) (value, error) {
+	if len(args) > len(p.Parameters) {
+		return value{}, fmt.Errorf("%v takes at most %d arguments, got %d", p.Name, len(p.Parameters), len(args))
+	}
+	named := map[string]any{}
+	for i, arg := range args {
+		named[p.Parameters[i].Name] = arg.v
+	}
+	result, err := s.g.Call(service, p.Name, named)
+	if err != nil {
+		return value{}, err
+	}
+	v, err := jsonValue(result)
+	if err != nil {
+		return value{}, err
+	}
+	return value{v: v, t: p.ReturnType}, nil
+}
+
+// parser parses an expression in a line.
+type parser struct {
+	s    *session
+	line string
+	i    int
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.line) && p.line[p.i] == ' ' {
+		p.i++
+	}
+}
+
+// next returns the next character, after any spaces, or 0 at the end.
+func (p *parser) next() byte {
+	p.skipSpace()
+	if p.i >= len(p.line) {
+		return 0
+	}
+	return p.line[p.i]
+}
+
+// ident parses an identifier, or returns nothing if there isn't one.
+func (p *parser) ident() string {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.line) {
+		c := rune(p.line[p.i])
+		if c != '_' && !unicode.IsLetter(c) && !(p.i > start && unicode.IsDigit(c)) {
+			break
+		}
+		p.i++
+	}
+	return p.line[start:p.i]
+}
+
+// literal parses a JSON value.
+func (p *parser) literal() (value, error) {
+	d := json.NewDecoder(strings.NewReader(p.line[p.i:]))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return value{}, fmt.Errorf("invalid value at column %d: %v", p.i+1, err)
+	}
+	p.i += int(d.InputOffset())
+	return value{v: v}, nil
+}
+
+// args parses the arguments of a call, after the opening parenthesis.
+func (p *parser) args() ([]value, error) {
+	var args []value
+	if p.next() == ')' {
+		p.i++
+		return args, nil
+	}
+	for {
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		switch p.next() {
+		case ',':
+			p.i++
+		case ')':
+			p.i++
+			return args, nil
+		default:
+			return nil, fmt.Errorf("expected , or ) at column %d", p.i+1)
+		}
+	}
+}
+
+// primary parses a JSON value, variable or service name, returning the name
+// it was given by, if any.
+func (p *parser) primary() (value, string, error) {
+	c := p.next()
+	switch {
+	case c == 0:
+		return value{}, "", fmt.Errorf("expected an expression")
+	case c == '"' || c == '[' || c == '{' || c == '-' || (c >= '0' && c <= '9'):
+		v, err := p.literal()
+		return v, "", err
+	}
+	name := p.ident()
+	switch name {
+	case "":
+		return value{}, "", fmt.Errorf("unexpected %q at column %d", c, p.i+1)
+	case "true", "false":
+		return value{v: name == "true"}, "", nil
+	case "null":
+		return value{}, "", nil
+	}
+	if v, ok := p.s.vars[name]; ok {
+		return v, name, nil
+	}
+	if _, ok := p.s.procedures[name]; ok {
+		return value{}, name, nil
+	}
+	return value{}, "", fmt.Errorf("unknown service or variable %q", name)
+}
+
+// member parses the member after a dot, and its arguments if it is called.
+func (p *parser) member() (string, []value, error) {
+	p.i++
+	member := p.ident()
+	if member == "" {
+		return "", nil, fmt.Errorf("expected a name at column %d", p.i+1)
+	}
+	if p.next() != '(' {
+		return member, nil, nil
+	}
+	p.i++
+	args, err := p.args()
+	return member, args, err
+}
+
+// expr parses and evaluates an expression.
+func (p *parser) expr() (value, error) {
+	v, name, err := p.primary()
+	if err != nil {
+		return value{}, err
+	}
+	if _, isService := p.s.procedures[name]; isService && p.next() != '.' {
+		if _, isVar := p.s.vars[name]; !isVar {
+			return value{}, fmt.Errorf("%v is a service; call one of its procedures", name)
+		}
+	}
+	for p.next() == '.' {
+		member, args, err := p.member()
+		if err != nil {
+			return value{}, err
+		}
+		if v, err = p.s.call(v, name, member, args); err != nil {
+			return value{}, err
+		}
+		name = name + "." + member
+	}
+	return v, nil
+}
+
+// assign parses and performs an assignment, if the line is one.
+func (p *parser) assign() (bool, error) {
+	eq := strings.Index(p.line, "=")
+	if eq < 0 || strings.HasPrefix(p.line[eq:], "==") {
+		return false, nil
+	}
+	lhs := parser{s: p.s, line: p.line[:eq]}
+	name := lhs.ident()
+	if name == "" {
+		return false, nil
+	}
+	// Everything up to the last member is evaluated to find the target.
+	var path []string
+	for lhs.next() == '.' {
+		lhs.i++
+		member := lhs.ident()
+		if member == "" {
+			return false, nil
+		}
+		path = append(path, member)
+	}
+	if lhs.next() != 0 {
+		return false, nil
+	}
+
+	p.i = eq + 1
+	v, err := p.expr()
+	if err != nil {
+		return true, err
+	}
+	if c := p.next(); c != 0 {
+		return true, fmt.Errorf("unexpected %q at column %d", c, p.i+1)
+	}
+	if len(path) == 0 {
+		p.s.vars[name] = v
+		return true, nil
+	}
+	target := parser{s: p.s, line: p.line[:strings.LastIndex(p.line[:eq], ".")]}
+	t, targetName, err := target.primary()
+	if err != nil {
+		return true, err
+	}
+	for _, member := range path[:len(path)-1] {
+		if t, err = p.s.call(t, targetName, member, nil); err != nil {
+			return true, err
+		}
+		targetName += "." + member
+	}
+	return true, p.s.set(t, targetName, path[len(path)-1], v)
+}
+
+// eval evaluates a line, returning what to print.
+func (s *session) eval(line string) (string, error) {
+	line = strings.TrimSpace(line)
+	switch line {
+	case "":
+		return "", nil
+	case "help":
+		return replHelp, nil
+	case "vars":
+		var names []string
+		for name := range s.vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var b strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&b, "%v = %v\n", name, s.vars[name])
+		}
+		return b.String(), nil
+	}
+
+	p := &parser{s: s, line: line}
+	if ok, err := p.assign(); ok {
+		return "", err
+	}
+	p.i = 0
+	v, err := p.expr()
+	if err != nil {
+		return "", err
+	}
+	if c := p.next(); c != 0 {
+		return "", fmt.Errorf("unexpected %q at column %d", c, p.i+1)
+	}
+	s.vars["_"] = v
+	if v.t == nil && v.v == nil {
+		return "", nil
+	}
+	return v.String() + "\n", nil
+}
+
+// memberNames returns the names a service's or class's procedures can be
+// called by.
+func (s *session) memberNames(service, class string) []string {
+	var names []string
+	isClassProc := func(name string) bool {
+		for _, c := range s.classes[service] {
+			if strings.HasPrefix(name, c+"_") {
+				return true
+			}
+		}
+		return false
+	}
+	for name := range s.procedures[service] {
+		if class != "" {
+			if !strings.HasPrefix(name, class+"_") {
+				continue
+			}
+			name = strings.TrimPrefix(name, class+"_")
+			name = strings.TrimPrefix(name, "static_")
+		} else if isClassProc(name) {
+			continue
+		}
+		if strings.HasPrefix(name, "set_") {
+			continue
+		}
+		names = append(names, strings.TrimPrefix(name, "get_"))
+	}
+	return names
+}
+
+// complete returns the completions of the word at the end of a line, and
+// where the word starts.
+func (s *session) complete(line string) (int, []string) {
+	start := len(line)
+	for start > 0 {
+		c := rune(line[start-1])
+		if c != '_' && c != '.' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			break
+		}
+		start--
+	}
+	word := line[start:]
+
+	var candidates []string
+	if dot := strings.LastIndex(word, "."); dot >= 0 {
+		head, tail := word[:dot], word[dot+1:]
+		var names []string
+		if !strings.Contains(head, ".") {
+			if service, class, ok := s.object(head); ok {
+				names = s.memberNames(service, class)
+			} else if _, ok := s.procedures[head]; ok {
+				names = s.memberNames(head, "")
+			}
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, tail) {
+				candidates = append(candidates, head+"."+name)
+			}
+		}
+	} else {
+		names := []string{"help", "vars", "exit"}
+		for name := range s.procedures {
+			names = append(names, name)
+		}
+		for name := range s.vars {
+			names = append(names, name)
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, word) {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+	sort.Strings(candidates)
+	return start, candidates
+}
+
+// lineReader reads lines of input, showing a prompt.
+type lineReader interface {
+	readLine(prompt string) (string, error)
+}
+
+// plainReader reads lines without editing, e.g. from a pipe.
+type plainReader struct {
+	r *bufio.Reader
+}
+
+func (r plainReader) readLine(string) (string, error) {
+	line, err := r.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// runREPL reads and evaluates lines until the input ends, exit is entered
+// or the context is canceled.
+func runREPL(ctx context.Context, s *session, r lineReader, out io.Writer) error {
+	type result struct {
+		line string
+		err  error
+	}
+	next := make(chan string)
+	lines := make(chan result)
+	go func() {
+		for prompt := range next {
+			line, err := r.readLine(prompt)
+			lines <- result{line: line, err: err}
+		}
+	}()
+	defer close(next)
+
+	for {
+		select {
+		case next <- "krpc> ":
+		case <-ctx.Done():
+			return nil
+		}
+		var res result
+		select {
+		case res = <-lines:
+		case <-ctx.Done():
+			return nil
+		}
+		if res.err == io.EOF {
+			return nil
+		}
+		if res.err != nil {
+			return res.err
+		}
+		if line := strings.TrimSpace(res.line); line == "exit" || line == "quit" {
+			return nil
+		}
+		output, err := s.eval(res.line)
+		if err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			continue
+		}
+		fmt.Fprint(out, output)
+	}
+}
+
+// repl runs an interactive shell for calling procedures.
+func repl(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("repl", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{RPCOnly: true})
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+	g, err := gateway.New(client, gateway.Config{})
+	if err != nil {
+		return err
+	}
+	s := newSession(g)
+
+	var r lineReader = plainReader{r: bufio.NewReader(os.Stdin)}
+	if restore, err := makeRaw(int(os.Stdin.Fd())); err == nil {
+		defer restore()
+		r = &lineEditor{in: bufio.NewReader(os.Stdin), out: out, complete: s.complete}
+		fmt.Fprintln(out, "Type help for help.")
+	}
+	return runREPL(ctx, s, r, out)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/atburke/krpc-go/gateway"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession returns a session with a server that has a vessel, 7, whose
+// control, 8, has a throttle.
+func testSession(t *testing.T) (*session, *krpctest.Server) {
+	t.Helper()
+	server := krpctest.NewServer()
+	t.Cleanup(func() { server.Close() })
+	class := func(name string) *types.Type {
+		return &types.Type{Code: types.Type_CLASS, Service: "SpaceCenter", Name: name}
+	}
+	float := &types.Type{Code: types.Type_FLOAT}
+	this := func(name string) *types.Parameter {
+		return &types.Parameter{Name: "this", Type: class(name)}
+	}
+	server.Return("KRPC", "GetServices", &types.Services{Services: []*types.Service{{
+		Name: "SpaceCenter",
+		Procedures: []*types.Procedure{
+			{Name: "get_UT", ReturnType: &types.Type{Code: types.Type_DOUBLE}},
+			{Name: "get_ActiveVessel", ReturnType: class("Vessel")},
+			{Name: "WarpTo", Parameters: []*types.Parameter{{Name: "ut", Type: &types.Type{Code: types.Type_DOUBLE}}}},
+			{Name: "Vessel_get_Name", Parameters: []*types.Parameter{this("Vessel")}, ReturnType: &types.Type{Code: types.Type_STRING}},
+			{Name: "Vessel_get_Control", Parameters: []*types.Parameter{this("Vessel")}, ReturnType: class("Control")},
+			{Name: "Control_get_Throttle", Parameters: []*types.Parameter{this("Control")}, ReturnType: float},
+			{Name: "Control_set_Throttle", Parameters: []*types.Parameter{this("Control"), {Name: "value", Type: float}}},
+		},
+		Classes: []*types.Class{{Name: "Vessel"}, {Name: "Control"}},
+	}}})
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(7))
+	server.Return("SpaceCenter", "WarpTo", nil)
+	server.Return("SpaceCenter", "Vessel_get_Name", "Kerbal X")
+	server.Return("SpaceCenter", "Vessel_get_Control", uint64(8))
+	var throttle float32
+	server.Handle("SpaceCenter", "Control_get_Throttle", func(*krpctest.Call) (interface{}, error) {
+		return throttle, nil
+	})
+	server.Handle("SpaceCenter", "Control_set_Throttle", func(call *krpctest.Call) (interface{}, error) {
+		return nil, call.Arg(1, &throttle)
+	})
+
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	g, err := gateway.New(client, gateway.Config{})
+	require.NoError(t, err)
+	return newSession(g), server
+}
+
+func TestSessionEval(t *testing.T) {
+	s, server := testSession(t)
+	tests := []struct {
+		line     string
+		expected string
+		err      string
+	}{
+		{line: "SpaceCenter.UT", expected: "1000\n"},
+		{line: "SpaceCenter.get_UT()", expected: "1000\n"},
+		{line: "_", expected: "1000\n"},
+		{line: "SpaceCenter.WarpTo(1200)"},
+		{line: "v = SpaceCenter.ActiveVessel"},
+		{line: "v", expected: "7 (SpaceCenter.Vessel)\n"},
+		{line: "v.Name", expected: "\"Kerbal X\"\n"},
+		{line: "v.Control.Throttle = 0.5"},
+		{line: "SpaceCenter.ActiveVessel.Control.Throttle", expected: "0.5\n"},
+		{line: "vars", expected: "_ = 0.5\nv = 7 (SpaceCenter.Vessel)\n"},
+		{line: "SpaceCenter", err: "is a service"},
+		{line: "MechJeb.Land()", err: "unknown service or variable"},
+		{line: "v.Explode()", err: "has no \"Explode\""},
+		{line: "SpaceCenter.WarpTo(1, 2)", err: "at most 1 arguments"},
+		{line: "SpaceCenter.WarpTo(1", err: "expected , or )"},
+		{line: "SpaceCenter.UT 2", err: "unexpected '2'"},
+	}
+	for _, tc := range tests {
+		out, err := s.eval(tc.line)
+		if tc.err != "" {
+			require.ErrorContains(t, err, tc.err, tc.line)
+			continue
+		}
+		require.NoError(t, err, tc.line)
+		require.Equal(t, tc.expected, out, tc.line)
+	}
+
+	var warp float64
+	for _, call := range server.Calls() {
+		if call.Procedure == "WarpTo" {
+			require.NoError(t, call.Arg(0, &warp))
+		}
+	}
+	require.Equal(t, 1200.0, warp)
+}
+
+func TestSessionComplete(t *testing.T) {
+	s, _ := testSession(t)
+	_, err := s.eval("vessel = SpaceCenter.ActiveVessel")
+	require.NoError(t, err)
+	tests := []struct {
+		line       string
+		start      int
+		candidates []string
+	}{
+		{line: "Sp", start: 0, candidates: []string{"SpaceCenter"}},
+		{line: "x = ve", start: 4, candidates: []string{"vessel"}},
+		{line: "SpaceCenter.", start: 0, candidates: []string{"SpaceCenter.ActiveVessel", "SpaceCenter.UT", "SpaceCenter.WarpTo"}},
+		{line: "foo(vessel.", start: 4, candidates: []string{"vessel.Control", "vessel.Name"}},
+		{line: "vessel.Na", start: 0, candidates: []string{"vessel.Name"}},
+		{line: "nothing.", start: 0},
+	}
+	for _, tc := range tests {
+		start, candidates := s.complete(tc.line)
+		require.Equal(t, tc.start, start, tc.line)
+		require.Equal(t, tc.candidates, candidates, tc.line)
+	}
+}
+
+func TestRunREPL(t *testing.T) {
+	s, _ := testSession(t)
+	in := "v = SpaceCenter.ActiveVessel\nv.Name\nv.Nope\nexit\nSpaceCenter.UT\n"
+	var out bytes.Buffer
+	require.NoError(t, runREPL(context.Background(), s, plainReader{r: bufio.NewReader(strings.NewReader(in))}, &out))
+	require.Equal(t, "\"Kerbal X\"\nerror: v has no \"Nope\"\n", out.String())
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/gateway"
+	"github.com/atburke/krpc-go/internal"
+	"github.com/atburke/krpc-go/lib/utils"
+	"github.com/atburke/krpc-go/types"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// services lists the server's services.
+func services(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("services", flag.ContinueOnError)
+	service := flags.String("service", "", "only list this service")
+	grep := flags.String("grep", "", "only list what has this in its name or documentation")
+	timeout := flags.Duration("timeout", 5*time.Second, "how long to wait for the server")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	return printServices(ctx, krpcgo.KRPCClientConfig{RPCOnly: true}, *service, *grep, out)
+}
+
+// printServices connects to a server and lists its services.
+func printServices(ctx context.Context, cfg krpcgo.KRPCClientConfig, service, grep string, out io.Writer) error {
+	client := krpcgo.NewKRPCClient(cfg)
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	services, err := internal.NewBasicKRPC(client).GetServices()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, s := range services.Services {
+		if service != "" && !strings.EqualFold(s.Name, service) {
+			continue
+		}
+		found = true
+		writeService(out, s, grep)
+	}
+	if service != "" && !found {
+		return fmt.Errorf("no service %q", service)
+	}
+	return nil
+}
+
+// docs returns the text of a documentation string, or nothing if it has
+// none.
+func docs(doc string) string {
+	s, err := utils.ParseXMLDocumentation(doc, "")
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// entry is a listed class, enumeration or procedure.
+type entry struct {
+	title string
+	docs  string
+}
+
+// matches checks if an entry has a string in its title or documentation,
+// ignoring case.
+func (e entry) matches(grep string) bool {
+	grep = strings.ToLower(grep)
+	return strings.Contains(strings.ToLower(e.title), grep) || strings.Contains(strings.ToLower(e.docs), grep)
+}
+
+// write writes the entry, with its documentation wrapped and indented.
+func (e entry) write(out io.Writer, indent string) {
+	fmt.Fprintf(out, "%v%v\n", indent, e.title)
+	if e.docs == "" {
+		return
+	}
+	for _, line := range strings.Split(wordwrap.WrapString(e.docs, uint(76-len(indent))), "\n") {
+		fmt.Fprintf(out, "%v    %v\n", indent, line)
+	}
+}
+
+// procedureTitle describes a procedure's signature.
+func procedureTitle(p *types.Procedure) string {
+	params := make([]string, len(p.Parameters))
+	for i, param := range p.Parameters {
+		params[i] = param.Name + ": " + gateway.TypeName(param.Type)
+		if param.DefaultValue != nil {
+			params[i] += " (optional)"
+		}
+	}
+	title := p.Name + "(" + strings.Join(params, ", ") + ")"
+	if p.ReturnType != nil {
+		title += " -> " + gateway.TypeName(p.ReturnType)
+		if p.ReturnIsNullable {
+			title += " (nullable)"
+		}
+	}
+	return title
+}
+
+// writeService lists a service's classes, enumerations and procedures, or
+// only those matching grep if it is set. A service with nothing matching
+// isn't listed.
+func writeService(out io.Writer, s *types.Service, grep string) {
+	var classes, enums, procedures []entry
+	for _, c := range s.Classes {
+		classes = append(classes, entry{title: c.Name, docs: docs(c.Documentation)})
+	}
+	for _, e := range s.Enumerations {
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = v.Name
+		}
+		enums = append(enums, entry{title: e.Name + ": " + strings.Join(values, ", "), docs: docs(e.Documentation)})
+	}
+	for _, p := range s.Procedures {
+		procedures = append(procedures, entry{title: procedureTitle(p), docs: docs(p.Documentation)})
+	}
+
+	filter := func(entries []entry) []entry {
+		if grep == "" {
+			return entries
+		}
+		var matched []entry
+		for _, e := range entries {
+			if e.matches(grep) {
+				matched = append(matched, e)
+			}
+		}
+		return matched
+	}
+	classes, enums, procedures = filter(classes), filter(enums), filter(procedures)
+	if grep != "" && len(classes)+len(enums)+len(procedures) == 0 {
+		return
+	}
+
+	entry{title: s.Name, docs: docs(s.Documentation)}.write(out, "")
+	for _, section := range []struct {
+		name    string
+		entries []entry
+	}{
+		{name: "Classes", entries: classes},
+		{name: "Enumerations", entries: enums},
+		{name: "Procedures", entries: procedures},
+	} {
+		if len(section.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n  %v:\n", section.name)
+		for _, e := range section.entries {
+			e.write(out, "    ")
+		}
+	}
+	fmt.Fprintln(out)
+}
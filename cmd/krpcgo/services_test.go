@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintServices(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	port := &types.Type{Code: types.Type_CLASS, Service: "SpaceCenter", Name: "DockingPort"}
+	server.Return("KRPC", "GetServices", &types.Services{Services: []*types.Service{
+		{
+			Name:          "SpaceCenter",
+			Documentation: "<doc><summary>The space center.</summary></doc>",
+			Procedures: []*types.Procedure{
+				{
+					Name:          "get_UT",
+					ReturnType:    &types.Type{Code: types.Type_DOUBLE},
+					Documentation: "<doc><summary>The current universal time.</summary></doc>",
+				},
+				{
+					Name: "DockingPort_Undock",
+					Parameters: []*types.Parameter{
+						{Name: "this", Type: port},
+						{Name: "force", Type: &types.Type{Code: types.Type_BOOL}, DefaultValue: []byte{0}},
+					},
+					ReturnType:       &types.Type{Code: types.Type_CLASS, Service: "SpaceCenter", Name: "Vessel"},
+					ReturnIsNullable: true,
+					Documentation:    "<doc><summary>Undocks the port.</summary></doc>",
+				},
+			},
+			Classes: []*types.Class{{Name: "DockingPort", Documentation: "<doc><summary>A docking port.</summary></doc>"}},
+			Enumerations: []*types.Enumeration{{
+				Name:   "DockingPortState",
+				Values: []*types.EnumerationValue{{Name: "Ready"}, {Name: "Docked", Value: 1}},
+			}},
+		},
+		{Name: "KRPC", Procedures: []*types.Procedure{{Name: "GetStatus"}}},
+	}})
+	cfg := server.ClientConfig()
+	cfg.RPCOnly = true
+
+	var out bytes.Buffer
+	require.NoError(t, printServices(context.Background(), cfg, "spacecenter", "docking", &out))
+	require.Equal(t, `SpaceCenter
+    The space center.
+
+  Classes:
+    DockingPort
+        A docking port.
+
+  Enumerations:
+    DockingPortState: Ready, Docked
+
+  Procedures:
+    DockingPort_Undock(this: SpaceCenter.DockingPort, force: bool (optional)) -> SpaceCenter.Vessel (nullable)
+        Undocks the port.
+
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, printServices(context.Background(), cfg, "", "status", &out))
+	require.Equal(t, "KRPC\n\n  Procedures:\n    GetStatus()\n\n", out.String())
+
+	require.Error(t, printServices(context.Background(), cfg, "MechJeb", "", &out))
+}
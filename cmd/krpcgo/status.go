@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/types"
+)
+
+// status prints the server's status and connected clients.
+func status(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	timeout := flags.Duration("timeout", 5*time.Second, "how long to wait for the server")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	return printStatus(ctx, krpcgo.KRPCClientConfig{RPCOnly: true}, out)
+}
+
+// printStatus connects to a server and prints its status.
+func printStatus(ctx context.Context, cfg krpcgo.KRPCClientConfig, out io.Writer) error {
+	client := krpcgo.NewKRPCClient(cfg)
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	k := krpc.New(client)
+	st, err := k.GetStatus()
+	if err != nil {
+		return err
+	}
+	tuples, err := k.Clients()
+	if err != nil {
+		return err
+	}
+	var clients []types.ConnectedClient
+	for _, t := range tuples {
+		clients = append(clients, types.ConnectedClientFromTuple(t))
+	}
+	writeStatus(out, client.Host+":"+client.RPCPort, st, clients)
+	return nil
+}
+
+// writeStatus writes a server's status.
+func writeStatus(out io.Writer, server string, st *types.Status, clients []types.ConnectedClient) {
+	fmt.Fprintf(out, "Server:   %v\n", server)
+	fmt.Fprintf(out, "Version:  %v\n", st.Version)
+	fmt.Fprintf(out, "RPCs:     %.1f/s (%d executed)\n", st.RpcRate, st.RpcsExecuted)
+	fmt.Fprintf(out, "Streams:  %d, %.1f RPCs/s (%d executed)\n", st.StreamRpcs, st.StreamRpcRate, st.StreamRpcsExecuted)
+	fmt.Fprintf(out, "Traffic:  %.1f KB/s in, %.1f KB/s out\n", st.BytesReadRate/1024, st.BytesWrittenRate/1024)
+	fmt.Fprintf(out, "Clients:  %d\n", len(clients))
+	for _, c := range clients {
+		name := c.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		fmt.Fprintf(out, "  %-20v %v\n", name, c.Address)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintStatus(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	server.Return("KRPC", "GetStatus", &types.Status{
+		Version:            "0.5.2",
+		RpcRate:            42,
+		RpcsExecuted:       1000,
+		StreamRpcs:         3,
+		StreamRpcRate:      90,
+		StreamRpcsExecuted: 5000,
+		BytesReadRate:      2048,
+		BytesWrittenRate:   4096,
+	})
+	server.Return("KRPC", "get_Clients", []types.Tuple3[[]byte, string, string]{
+		types.NewTuple3(make([]byte, 16), "krpcgo", "127.0.0.1:50123"),
+		types.NewTuple3(make([]byte, 16), "", "10.0.0.2:50124"),
+	})
+
+	cfg := server.ClientConfig()
+	cfg.RPCOnly = true
+	var out bytes.Buffer
+	require.NoError(t, printStatus(context.Background(), cfg, &out))
+	require.Equal(t, "Server:   "+cfg.Host+":"+cfg.RPCPort+`
+Version:  0.5.2
+RPCs:     42.0/s (1000 executed)
+Streams:  3, 90.0 RPCs/s (5000 executed)
+Traffic:  2.0 KB/s in, 4.0 KB/s out
+Clients:  2
+  krpcgo               127.0.0.1:50123
+  (unnamed)            10.0.0.2:50124
+`, out.String())
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// ioctlTermios gets or sets a terminal's attributes.
+func ioctlTermios(fd int, request uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw puts a terminal into raw mode, so that keys are read as they are
+// pressed without being echoed, and returns a function that restores it. It
+// fails if fd isn't a terminal.
+func makeRaw(fd int) (func(), error) {
+	var old syscall.Termios
+	if err := ioctlTermios(fd, syscall.TCGETS, &old); err != nil {
+		return nil, err
+	}
+	raw := old
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctlTermios(fd, syscall.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+	return func() { _ = ioctlTermios(fd, syscall.TCSETS, &old) }, nil
+}
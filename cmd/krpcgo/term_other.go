@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// makeRaw isn't supported on this system, so input is read a line at a time
+// without completion.
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported")
+}
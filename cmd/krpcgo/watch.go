@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/gateway"
+	"github.com/atburke/krpc-go/krpc"
+)
+
+// valueWriter writes stream values as they arrive.
+type valueWriter interface {
+	write(t time.Time, v any) error
+}
+
+// textWriter writes a value per line, after the time it arrived.
+type textWriter struct {
+	out io.Writer
+}
+
+func (w textWriter) write(t time.Time, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.out, "%v  %s\n", t.Format("15:04:05.000"), b)
+	return err
+}
+
+// jsonWriter writes a JSON object per line, with the time and value.
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w jsonWriter) write(t time.Time, v any) error {
+	return w.enc.Encode(map[string]any{"time": t.Format(time.RFC3339Nano), "value": v})
+}
+
+// csvWriter writes CSV rows of the time and value, after a header. Values
+// other than strings are written as JSON.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (w *csvWriter) write(t time.Time, v any) error {
+	if !w.header {
+		w.header = true
+		if err := w.w.Write([]string{"time", "value"}); err != nil {
+			return err
+		}
+	}
+	s, ok := v.(string)
+	if !ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		s = string(b)
+	}
+	if err := w.w.Write([]string{t.Format(time.RFC3339Nano), s}); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// newValueWriter creates a writer for a format.
+func newValueWriter(format string, out io.Writer) (valueWriter, error) {
+	switch format {
+	case "text":
+		return textWriter{out: out}, nil
+	case "json":
+		return jsonWriter{enc: json.NewEncoder(out)}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(out)}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q; use text, json or csv", format)
+}
+
+// watch streams a procedure's result and prints its values until
+// interrupted.
+func watch(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: krpcgo watch <service> <procedure> [-arg name=value]... [-rate hz] [-format text|json|csv]")
+		fmt.Fprintln(flags.Output(), "\nFor example: krpcgo watch SpaceCenter get_UT -rate 1")
+		flags.PrintDefaults()
+	}
+	procArgs := arguments{}
+	flags.Var(procArgs, "arg", "a procedure argument, as name=value (repeatable)")
+	rate := flags.Float64("rate", 0, "the most updates per second (default every change)")
+	format := flags.String("format", "text", "how to print values: text, json or csv")
+	service, procedure, err := parseProcedure(flags, args)
+	if err != nil {
+		return err
+	}
+	w, err := newValueWriter(*format, out)
+	if err != nil {
+		return err
+	}
+	return watchProcedure(ctx, krpcgo.KRPCClientConfig{}, service, procedure, procArgs, float32(*rate), w)
+}
+
+// watchProcedure connects to a server and writes the values of a stream of
+// a procedure until the context is canceled.
+func watchProcedure(ctx context.Context, cfg krpcgo.KRPCClientConfig, service, procedure string, args arguments, rate float32, w valueWriter) error {
+	client := krpcgo.NewKRPCClient(cfg)
+	if err := client.Connect(ctx); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	g, err := gateway.New(client, gateway.Config{Services: []string{service}})
+	if err != nil {
+		return err
+	}
+	stream, err := g.Stream(service, procedure, args)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	if rate > 0 {
+		if err := krpc.New(client).SetStreamRate(stream.ID, rate); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v := <-stream.C:
+			if err, ok := v.(error); ok {
+				return err
+			}
+			if err := w.write(time.Now(), v); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueWriters(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 600000000, time.UTC)
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "text", expected: "03:04:05.600  1.5\n03:04:05.600  [1,\"a\"]\n03:04:05.600  \"b, c\"\n"},
+		{
+			format: "json",
+			expected: `{"time":"2026-01-02T03:04:05.6Z","value":1.5}
+{"time":"2026-01-02T03:04:05.6Z","value":[1,"a"]}
+{"time":"2026-01-02T03:04:05.6Z","value":"b, c"}
+`,
+		},
+		{
+			format: "csv",
+			expected: `time,value
+2026-01-02T03:04:05.6Z,1.5
+2026-01-02T03:04:05.6Z,"[1,""a""]"
+2026-01-02T03:04:05.6Z,"b, c"
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			var out bytes.Buffer
+			w, err := newValueWriter(tc.format, &out)
+			require.NoError(t, err)
+			for _, v := range []any{1.5, []any{json.Number("1"), "a"}, "b, c"} {
+				require.NoError(t, w.write(at, v))
+			}
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+	_, err := newValueWriter("xml", nil)
+	require.Error(t, err)
+}
+
+// chanWriter sends the values written to it on a channel.
+type chanWriter chan any
+
+func (w chanWriter) write(_ time.Time, v any) error {
+	w <- v
+	return nil
+}
+
+func TestWatchProcedure(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	server.Return("KRPC", "GetServices", &types.Services{Services: []*types.Service{{
+		Name: "SpaceCenter",
+		Procedures: []*types.Procedure{{
+			Name:       "get_UT",
+			ReturnType: &types.Type{Code: types.Type_DOUBLE},
+		}},
+	}}})
+	server.Return("SpaceCenter", "get_UT", 0.0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values := make(chanWriter, 100)
+	done := make(chan error)
+	go func() {
+		done <- watchProcedure(ctx, server.ClientConfig(), "SpaceCenter", "get_UT", arguments{}, 10, values)
+	}()
+
+	// Feed until the stream is being watched, since updates sent before
+	// then are dropped.
+	ut := 0.0
+	var got []any
+	for len(got) < 2 {
+		ut++
+		server.Feed("SpaceCenter", "get_UT", ut)
+		select {
+		case v := <-values:
+			got = append(got, v)
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	require.Less(t, got[0], got[1])
+	cancel()
+	require.NoError(t, <-done)
+}
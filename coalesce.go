@@ -0,0 +1,110 @@
+package krpcgo
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// CoalesceConfig configures which calls are coalesced.
+type CoalesceConfig struct {
+	// Procedures are the read-only procedures whose calls can be coalesced,
+	// as "Service.Procedure", where a trailing "*" matches any procedure
+	// with the same prefix, as for PriorityConfig. Defaults to every
+	// property getter.
+	Procedures []string
+}
+
+// flight is a batch of calls on its way to the server, whose results are
+// shared by every caller that made it.
+type flight struct {
+	// done is closed when the results are in.
+	done    chan struct{}
+	results []*types.ProcedureResult
+	err     error
+}
+
+// coalescer shares the results of identical batches of calls made at the
+// same time.
+type coalescer struct {
+	cfg CoalesceConfig
+
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// newCoalescer creates a coalescer for a config.
+func newCoalescer(cfg CoalesceConfig) *coalescer {
+	return &coalescer{cfg: cfg, flights: make(map[string]*flight)}
+}
+
+// isGetter checks if a call is to a property getter, which is read-only.
+func isGetter(call *types.ProcedureCall) bool {
+	return strings.HasPrefix(call.Procedure, "get_") || strings.Contains(call.Procedure, "_get_")
+}
+
+// key returns the key that identical batches of calls share, and whether
+// the batch can be coalesced at all, which it can if every call in it is
+// read-only.
+func (co *coalescer) key(calls []*types.ProcedureCall) (string, bool) {
+	for _, call := range calls {
+		if co.cfg.Procedures == nil && !isGetter(call) {
+			return "", false
+		}
+		if co.cfg.Procedures != nil && !matchProcedure(co.cfg.Procedures, call) {
+			return "", false
+		}
+	}
+	b, err := proto.Marshal(&types.Request{Calls: calls})
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// do makes a batch of calls with a function, unless an identical batch is
+// already being made, in which case it waits for that batch's results.
+func (co *coalescer) do(calls []*types.ProcedureCall, call func([]*types.ProcedureCall) ([]*types.ProcedureResult, error)) ([]*types.ProcedureResult, error) {
+	key, ok := co.key(calls)
+	if !ok {
+		return call(calls)
+	}
+	co.mu.Lock()
+	if f, ok := co.flights[key]; ok {
+		co.mu.Unlock()
+		<-f.done
+		if f.err != nil {
+			return nil, tracerr.Wrap(f.err)
+		}
+		return append([]*types.ProcedureResult(nil), f.results...), nil
+	}
+	f := &flight{done: make(chan struct{})}
+	co.flights[key] = f
+	co.mu.Unlock()
+
+	f.results, f.err = call(calls)
+	co.mu.Lock()
+	delete(co.flights, key)
+	co.mu.Unlock()
+	close(f.done)
+	return f.results, f.err
+}
+
+// CoalesceCalls makes identical batches of read-only calls that are made at
+// the same time share a single call to the server, so that components
+// that poll the same values independently don't each add to the load on
+// it. A batch that is made while an identical one is waiting for its
+// results gets those results rather than being sent, so it may see values
+// from slightly earlier than it was made.
+func (c *KRPCClient) CoalesceCalls(cfg CoalesceConfig) error {
+	c.coalesceMu.Lock()
+	defer c.coalesceMu.Unlock()
+	if c.coalescer != nil {
+		return tracerr.Errorf("Calls are already coalesced")
+	}
+	c.coalescer = newCoalescer(cfg)
+	return nil
+}
@@ -0,0 +1,82 @@
+package krpcgo
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalescerKey(t *testing.T) {
+	ut := call("SpaceCenter", "get_UT")
+	name := call("SpaceCenter", "Vessel_get_Name")
+	throttle := call("SpaceCenter", "Control_set_Throttle")
+
+	co := newCoalescer(CoalesceConfig{})
+	key, ok := co.key([]*types.ProcedureCall{ut, name})
+	require.True(t, ok)
+	other, ok := co.key([]*types.ProcedureCall{name, ut})
+	require.True(t, ok)
+	require.NotEqual(t, key, other)
+	_, ok = co.key([]*types.ProcedureCall{ut, throttle})
+	require.False(t, ok)
+
+	co = newCoalescer(CoalesceConfig{Procedures: []string{"SpaceCenter.get_*"}})
+	_, ok = co.key([]*types.ProcedureCall{ut})
+	require.True(t, ok)
+	_, ok = co.key([]*types.ProcedureCall{name})
+	require.False(t, ok)
+}
+
+func TestCoalescer(t *testing.T) {
+	co := newCoalescer(CoalesceConfig{})
+	var sent int32
+	release := make(chan struct{})
+	send := func(calls []*types.ProcedureCall) ([]*types.ProcedureResult, error) {
+		atomic.AddInt32(&sent, 1)
+		<-release
+		return []*types.ProcedureResult{{Value: []byte(calls[0].Procedure)}}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]*types.ProcedureResult, 5)
+	for i := range results {
+		procedure := "get_UT"
+		if i == 4 {
+			procedure = "Control_set_Throttle"
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = co.do([]*types.ProcedureCall{call("SpaceCenter", procedure)}, send)
+		}(i)
+	}
+	// Let the calls get under way.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// The getter calls share one, but the setter call is made anyway.
+	require.Equal(t, int32(2), atomic.LoadInt32(&sent))
+	for i, r := range results {
+		require.Len(t, r, 1)
+		if i < 4 {
+			require.Equal(t, "get_UT", string(r[0].Value))
+		}
+	}
+	require.Empty(t, co.flights)
+
+	// Once the results are in, the next call is sent.
+	_, err := co.do([]*types.ProcedureCall{call("SpaceCenter", "get_UT")}, send)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&sent))
+}
+
+func TestCoalesceCalls(t *testing.T) {
+	client := NewKRPCClient(KRPCClientConfig{})
+	require.NoError(t, client.CoalesceCalls(CoalesceConfig{}))
+	require.Error(t, client.CoalesceCalls(CoalesceConfig{}))
+}
@@ -0,0 +1,88 @@
+package krpcgo
+
+import (
+	"context"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Number is a type that can be compared with a threshold.
+type Number interface {
+	~int32 | ~int64 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// Condition checks a stream value. Conditions may keep state between calls,
+// so each should only be used with one stream.
+type Condition[T any] func(T) bool
+
+// WaitFor waits until a value received on a stream meets a condition, and
+// returns that value.
+func WaitFor[T any](ctx context.Context, s *Stream[T], cond Condition[T]) (T, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, tracerr.Wrap(ctx.Err())
+		case v := <-s.C:
+			if cond(v) {
+				return v, nil
+			}
+		}
+	}
+}
+
+// Above is met by values at or above a threshold.
+func Above[T Number](threshold T) Condition[T] {
+	return func(v T) bool {
+		return v >= threshold
+	}
+}
+
+// Below is met by values at or below a threshold.
+func Below[T Number](threshold T) Condition[T] {
+	return func(v T) bool {
+		return v <= threshold
+	}
+}
+
+// CrossedAbove is met once a value rises from below a threshold to at or
+// above it, and stays met after that.
+func CrossedAbove[T Number](threshold T) Condition[T] {
+	return crossed(func(v T) bool { return v < threshold })
+}
+
+// CrossedBelow is met once a value falls from above a threshold to at or
+// below it, and stays met after that. For example, dynamic pressure crossing
+// below a threshold on the way up means the vessel is past max Q.
+func CrossedBelow[T Number](threshold T) Condition[T] {
+	return crossed(func(v T) bool { return v > threshold })
+}
+
+// crossed is met once a value has been on one side of a threshold and then
+// isn't.
+func crossed[T any](before func(T) bool) Condition[T] {
+	var armed, met bool
+	return func(v T) bool {
+		if met {
+			return true
+		}
+		if before(v) {
+			armed = true
+		} else if armed {
+			met = true
+		}
+		return met
+	}
+}
+
+// All is met when all the conditions are met by the same value.
+func All[T any](conds ...Condition[T]) Condition[T] {
+	return func(v T) bool {
+		met := true
+		// Check every condition so stateful ones see every value.
+		for _, cond := range conds {
+			met = cond(v) && met
+		}
+		return met
+	}
+}
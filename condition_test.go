@@ -0,0 +1,55 @@
+package krpcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runCondition[T any](cond Condition[T], values []T) []bool {
+	var met []bool
+	for _, v := range values {
+		met = append(met, cond(v))
+	}
+	return met
+}
+
+func TestConditions(t *testing.T) {
+	values := []float64{0, 5, 10, 15, 10, 5, 0}
+	tcs := []struct {
+		name     string
+		cond     Condition[float64]
+		expected []bool
+	}{
+		{name: "above", cond: Above(10.0), expected: []bool{false, false, true, true, true, false, false}},
+		{name: "below", cond: Below(5.0), expected: []bool{true, true, false, false, false, true, true}},
+		{name: "crossed above", cond: CrossedAbove(10.0), expected: []bool{false, false, true, true, true, true, true}},
+		{name: "crossed below", cond: CrossedBelow(5.0), expected: []bool{false, false, false, false, false, true, true}},
+		{name: "all", cond: All(Above(5.0), CrossedAbove(15.0)), expected: []bool{false, false, false, true, true, true, false}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, runCondition(tc.cond, values))
+		})
+	}
+
+	// Starting past the threshold doesn't count as crossing it.
+	require.Equal(t, []bool{false, false}, runCondition(CrossedBelow(5.0), []float64{0, 1}))
+}
+
+func TestWaitFor(t *testing.T) {
+	s := &Stream[int32]{C: make(chan int32, 10)}
+	for i := int32(0); i < 10; i++ {
+		s.C <- i
+	}
+	v, err := WaitFor(context.Background(), s, Above[int32](5))
+	require.NoError(t, err)
+	require.Equal(t, int32(5), v)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = WaitFor(ctx, &Stream[int32]{C: make(chan int32)}, Above[int32](5))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
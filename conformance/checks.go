@@ -0,0 +1,489 @@
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// typeCodes are the value types checked, in the order they are reported.
+var typeCodes = []types.Type_TypeCode{
+	types.Type_DOUBLE,
+	types.Type_FLOAT,
+	types.Type_SINT32,
+	types.Type_SINT64,
+	types.Type_UINT32,
+	types.Type_UINT64,
+	types.Type_BOOL,
+	types.Type_STRING,
+	types.Type_BYTES,
+	types.Type_CLASS,
+	types.Type_ENUMERATION,
+	types.Type_EVENT,
+	types.Type_PROCEDURE_CALL,
+	types.Type_STREAM,
+	types.Type_STATUS,
+	types.Type_SERVICES,
+	types.Type_TUPLE,
+	types.Type_LIST,
+	types.Type_SET,
+	types.Type_DICTIONARY,
+}
+
+// prototype returns the Go type that a value of a kRPC type decodes as,
+// for encode.Validate.
+func prototype(t *types.Type) (reflect.Type, error) {
+	switch t.Code {
+	case types.Type_DOUBLE:
+		return reflect.TypeOf(float64(0)), nil
+	case types.Type_FLOAT:
+		return reflect.TypeOf(float32(0)), nil
+	case types.Type_SINT32, types.Type_ENUMERATION:
+		return reflect.TypeOf(int32(0)), nil
+	case types.Type_SINT64:
+		return reflect.TypeOf(int64(0)), nil
+	case types.Type_UINT32:
+		return reflect.TypeOf(uint32(0)), nil
+	case types.Type_UINT64, types.Type_CLASS:
+		return reflect.TypeOf(uint64(0)), nil
+	case types.Type_BOOL:
+		return reflect.TypeOf(false), nil
+	case types.Type_STRING:
+		return reflect.TypeOf(""), nil
+	case types.Type_BYTES:
+		return reflect.TypeOf([]byte(nil)), nil
+	case types.Type_EVENT:
+		return reflect.TypeOf(&types.Event{}), nil
+	case types.Type_PROCEDURE_CALL:
+		return reflect.TypeOf(&types.ProcedureCall{}), nil
+	case types.Type_STREAM:
+		return reflect.TypeOf(&types.Stream{}), nil
+	case types.Type_STATUS:
+		return reflect.TypeOf(&types.Status{}), nil
+	case types.Type_SERVICES:
+		return reflect.TypeOf(&types.Services{}), nil
+	case types.Type_TUPLE:
+		var fields []reflect.StructField
+		for i, item := range t.Types {
+			ft, err := prototype(item)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, reflect.StructField{Name: fmt.Sprintf("Item%d", i), Type: ft})
+		}
+		return reflect.StructOf(fields), nil
+	case types.Type_LIST, types.Type_SET:
+		if len(t.Types) != 1 {
+			return nil, tracerr.Errorf("%v has %d element types", t.Code, len(t.Types))
+		}
+		elem, err := prototype(t.Types[0])
+		if err != nil {
+			return nil, err
+		}
+		if t.Code == types.Type_LIST {
+			return reflect.SliceOf(elem), nil
+		}
+		if !elem.Comparable() {
+			return nil, tracerr.Errorf("Sets of %v aren't supported", t.Types[0].Code)
+		}
+		return reflect.MapOf(elem, reflect.TypeOf(struct{}{})), nil
+	case types.Type_DICTIONARY:
+		if len(t.Types) != 2 {
+			return nil, tracerr.Errorf("DICTIONARY has %d element types", len(t.Types))
+		}
+		key, err := prototype(t.Types[0])
+		if err != nil {
+			return nil, err
+		}
+		value, err := prototype(t.Types[1])
+		if err != nil {
+			return nil, err
+		}
+		if !key.Comparable() {
+			return nil, tracerr.Errorf("Dictionaries keyed by %v aren't supported", t.Types[0].Code)
+		}
+		return reflect.MapOf(key, value), nil
+	}
+	return nil, tracerr.Errorf("Unknown type code %v", t.Code)
+}
+
+// isServerError checks if an error is one the server sent in answer to a
+// call, rather than a problem with the connection.
+func isServerError(err error) bool {
+	var e *types.Error
+	return errors.As(err, &e)
+}
+
+// call makes a procedure call.
+func (s *suite) call(service, procedure string, args ...[]byte) (*types.ProcedureResult, error) {
+	pc := &types.ProcedureCall{Service: service, Procedure: procedure}
+	for i, arg := range args {
+		pc.Arguments = append(pc.Arguments, &types.Argument{Position: uint32(i), Value: arg})
+	}
+	result, err := s.client.Call(pc)
+	return result, tracerr.Wrap(err)
+}
+
+// receive waits for a stream update.
+func (s *suite) receive(c <-chan []byte) ([]byte, error) {
+	select {
+	case b := <-c:
+		return b, nil
+	case <-time.After(s.cfg.Timeout):
+		return nil, tracerr.Errorf("No update within %v", s.cfg.Timeout)
+	case <-s.ctx.Done():
+		return nil, tracerr.Wrap(s.ctx.Err())
+	}
+}
+
+// rawHandshake opens a connection to a port, sends a connection request
+// and returns the response.
+func (s *suite) rawHandshake(port string, req *types.ConnectionRequest) (*types.ConnectionResponse, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(s.ctx, "tcp", net.JoinHostPort(s.cfg.Client.Host, port))
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.cfg.Timeout)); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if _, err := conn.Write(append(proto.EncodeVarint(uint64(len(b))), b...)); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	r := bufio.NewReader(conn)
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	b = make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var resp types.ConnectionResponse
+	return &resp, tracerr.Wrap(proto.Unmarshal(b, &resp))
+}
+
+// checkHandshake checks the client's identity and that bad connection
+// requests are refused.
+func (s *suite) checkHandshake() {
+	k := krpc.New(s.client)
+	s.check("handshake", "client name", func() error {
+		name, err := k.GetClientName()
+		if err != nil {
+			return err
+		}
+		if name != s.cfg.Client.ClientName {
+			return tracerr.Errorf("Got %q, want %q", name, s.cfg.Client.ClientName)
+		}
+		return nil
+	})
+	var id []byte
+	s.check("handshake", "client identifier", func() error {
+		var err error
+		if id, err = k.GetClientID(); err != nil {
+			return err
+		}
+		if len(id) != 16 {
+			return tracerr.Errorf("Got %d bytes, want 16", len(id))
+		}
+		return nil
+	})
+	s.check("handshake", "wrong connection type", func() error {
+		resp, err := s.rawHandshake(s.cfg.Client.RPCPort, &types.ConnectionRequest{
+			Type:             types.ConnectionRequest_STREAM,
+			ClientIdentifier: id,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Status != types.ConnectionResponse_WRONG_TYPE {
+			return tracerr.Errorf("Got status %v, want %v", resp.Status, types.ConnectionResponse_WRONG_TYPE)
+		}
+		return nil
+	})
+	s.check("handshake", "unknown client identifier", func() error {
+		resp, err := s.rawHandshake(s.cfg.Client.StreamPort, &types.ConnectionRequest{
+			Type:             types.ConnectionRequest_STREAM,
+			ClientIdentifier: bytes.Repeat([]byte{0xff}, 16),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Status == types.ConnectionResponse_OK {
+			return tracerr.Errorf("The stream connection was accepted")
+		}
+		return nil
+	})
+}
+
+// isGetter checks if a procedure can be called to check the encoding of
+// its result: it has no parameters or side effects.
+func isGetter(service string, p *types.Procedure) bool {
+	if len(p.Parameters) > 0 || p.ReturnType == nil || p.ReturnType.Code == types.Type_NONE {
+		return false
+	}
+	return strings.HasPrefix(p.Name, "get_") || (service == "KRPC" && strings.HasPrefix(p.Name, "Get"))
+}
+
+// procedures returns a count of procedures.
+func procedures(n int) string {
+	if n == 1 {
+		return "1 procedure"
+	}
+	return fmt.Sprintf("%d procedures", n)
+}
+
+// coverage is how well a type's encoding has been checked.
+type coverage struct {
+	// checked are the procedures whose results were valid.
+	checked int
+	// unavailable are the procedures that failed on the server, e.g.
+	// because the game isn't in flight.
+	unavailable int
+	// failure describes the first invalid result.
+	failure string
+}
+
+// checkEncoding calls every getter and checks that its result decodes as
+// the type the server says it returns.
+func (s *suite) checkEncoding() {
+	var services *types.Services
+	s.check("encoding", "services", func() error {
+		var err error
+		if services, err = krpc.New(s.client).GetServices(); err != nil {
+			return err
+		}
+		return pass("%d services", len(services.Services))
+	})
+	if services == nil {
+		return
+	}
+
+	coverages := map[types.Type_TypeCode]*coverage{}
+	for _, code := range typeCodes {
+		coverages[code] = &coverage{}
+	}
+	for _, service := range services.Services {
+		for _, p := range service.Procedures {
+			if !isGetter(service.Name, p) {
+				continue
+			}
+			c, ok := coverages[p.ReturnType.Code]
+			if !ok || c.failure != "" {
+				continue
+			}
+			name := service.Name + "." + p.Name
+			t, err := prototype(p.ReturnType)
+			if err != nil {
+				c.failure = fmt.Sprintf("%v: %v", name, tracerr.Unwrap(err))
+				continue
+			}
+			result, err := s.call(service.Name, p.Name)
+			switch {
+			case isServerError(err):
+				c.unavailable++
+			case err != nil:
+				c.failure = fmt.Sprintf("%v: %v", name, tracerr.Unwrap(err))
+			default:
+				if err := encode.Validate(result.Value, reflect.Zero(t).Interface()); err != nil {
+					c.failure = fmt.Sprintf("%v: %v", name, tracerr.Unwrap(err))
+				} else {
+					c.checked++
+				}
+			}
+		}
+	}
+
+	for _, code := range typeCodes {
+		c := coverages[code]
+		s.check("encoding", code.String(), func() error {
+			switch {
+			case c.failure != "":
+				return errors.New(c.failure)
+			case c.checked > 0:
+				return pass("%v", procedures(c.checked))
+			case c.unavailable > 0:
+				return skip("%v failed on the server", procedures(c.unavailable))
+			}
+			return skip("no getter returns one")
+		})
+	}
+}
+
+// checkArguments checks that the server accepts arguments of each type
+// that a server-side expression can be built from.
+func (s *suite) checkArguments() {
+	e := krpc.NewExpression(0, s.client)
+	nonNil := func(x *krpc.Expression, err error) error {
+		if err != nil {
+			return err
+		}
+		if x == nil {
+			return tracerr.Errorf("Got a null expression")
+		}
+		return nil
+	}
+	constant := func(i int32) *krpc.Expression {
+		x, _ := e.ConstantInt(i)
+		return x
+	}
+	s.check("arguments", "DOUBLE", func() error { return nonNil(e.ConstantDouble(1.5)) })
+	s.check("arguments", "FLOAT", func() error { return nonNil(e.ConstantFloat(1.5)) })
+	s.check("arguments", "SINT32", func() error { return nonNil(e.ConstantInt(-7)) })
+	s.check("arguments", "BOOL", func() error { return nonNil(e.ConstantBool(true)) })
+	s.check("arguments", "STRING", func() error { return nonNil(e.ConstantString("Jebediah Kerman ✓")) })
+	s.check("arguments", "PROCEDURE_CALL", func() error {
+		return nonNil(e.Call(&types.ProcedureCall{Service: "KRPC", Procedure: "GetClientName"}))
+	})
+	s.check("arguments", "LIST", func() error {
+		return nonNil(e.CreateList([]*krpc.Expression{constant(1), constant(2)}))
+	})
+	s.check("arguments", "SET", func() error {
+		return nonNil(e.CreateSet([]*krpc.Expression{constant(1), constant(2)}))
+	})
+	s.check("arguments", "TUPLE", func() error {
+		return nonNil(e.CreateTuple([]*krpc.Expression{constant(1), constant(2)}))
+	})
+	s.check("arguments", "DICTIONARY", func() error {
+		return nonNil(e.CreateDictionary([]*krpc.Expression{constant(1)}, []*krpc.Expression{constant(2)}))
+	})
+}
+
+// checkStreams checks that a stream can be added, started, changed and
+// removed.
+func (s *suite) checkStreams() {
+	if !s.client.StreamsAvailable() {
+		s.check("streams", "connect", func() error { return skip("the client has no stream connection") })
+		return
+	}
+	k := krpc.New(s.client)
+	var id uint64
+	s.check("streams", "add", func() error {
+		st, err := k.AddStream(&types.ProcedureCall{Service: "KRPC", Procedure: "GetClientName"}, false)
+		if err != nil {
+			return err
+		}
+		id = st.Id
+		return nil
+	})
+	if id == 0 {
+		return
+	}
+	s.check("streams", "start and receive", func() error {
+		raw := s.client.GetStream(id)
+		defer raw.Close()
+		if err := k.StartStream(id); err != nil {
+			return err
+		}
+		b, err := s.receive(raw.C)
+		if err != nil {
+			return err
+		}
+		var name string
+		if err := encode.Unmarshal(b, &name); err != nil {
+			return err
+		}
+		if name != s.cfg.Client.ClientName {
+			return tracerr.Errorf("Got %q, want %q", name, s.cfg.Client.ClientName)
+		}
+		return nil
+	})
+	s.check("streams", "set rate", func() error { return k.SetStreamRate(id, 10) })
+	s.check("streams", "remove", func() error { return k.RemoveStream(id) })
+}
+
+// checkEvents checks that an event fires.
+func (s *suite) checkEvents() {
+	if !s.client.StreamsAvailable() {
+		s.check("events", "connect", func() error { return skip("the client has no stream connection") })
+		return
+	}
+	k := krpc.New(s.client)
+	var event types.Event
+	s.check("events", "add", func() error {
+		x, err := krpc.NewExpression(0, s.client).ConstantBool(true)
+		if err != nil {
+			return err
+		}
+		arg, err := encode.Marshal(x)
+		if err != nil {
+			return err
+		}
+		result, err := s.call("KRPC", "AddEvent", arg)
+		if err != nil {
+			return err
+		}
+		return encode.Unmarshal(result.Value, &event)
+	})
+	if event.Stream == nil {
+		return
+	}
+	id := event.Stream.Id
+	s.check("events", "fire", func() error {
+		raw := s.client.GetStream(id)
+		defer raw.Close()
+		if err := k.StartStream(id); err != nil {
+			return err
+		}
+		b, err := s.receive(raw.C)
+		if err != nil {
+			return err
+		}
+		var fired bool
+		if err := encode.Unmarshal(b, &fired); err != nil {
+			return err
+		}
+		if !fired {
+			return tracerr.Errorf("The event's stream sent false")
+		}
+		return nil
+	})
+	_ = k.RemoveStream(id)
+}
+
+// checkErrors checks that bad calls get errors from the server, rather than
+// bad results or a closed connection.
+func (s *suite) checkErrors() {
+	expectError := func(err error) error {
+		var e *types.Error
+		switch {
+		case err == nil:
+			return tracerr.Errorf("The call succeeded")
+		case !errors.As(err, &e):
+			return err
+		case e.Name == "" && e.Description == "":
+			return tracerr.Errorf("The error has no name or description")
+		}
+		return pass("%v", e.Name)
+	}
+	s.check("errors", "unknown procedure", func() error {
+		_, err := s.call("KRPC", "NoSuchProcedure")
+		return expectError(err)
+	})
+	s.check("errors", "unknown service", func() error {
+		_, err := s.call("NoSuchService", "NoSuchProcedure")
+		return expectError(err)
+	})
+	s.check("errors", "missing argument", func() error {
+		_, err := s.call("KRPC", "Expression_static_ConstantInt")
+		return expectError(err)
+	})
+}
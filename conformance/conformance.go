@@ -0,0 +1,206 @@
+// Package conformance checks that a kRPC server speaks the protocol the way
+// this module expects: the connection handshake, the encoding of every
+// value type, streams, events and errors. It is meant for running against
+// new server versions and forks, such as those for KSP2:
+//
+//	report := conformance.Run(ctx, conformance.Config{})
+//	report.Write(os.Stdout)
+//
+// or from the shell with `krpcgo conformance`. It only uses the KRPC
+// service and getters without side effects, so it is safe to run against a
+// game in progress.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	// Pass means the server behaved as expected.
+	Pass Status = iota
+	// Fail means the server is incompatible.
+	Fail
+	// Skip means the check couldn't be made, e.g. because the server
+	// has no procedure that returns a type.
+	Skip
+)
+
+// String returns the status as it is shown in reports.
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "PASS"
+	case Fail:
+		return "FAIL"
+	case Skip:
+		return "SKIP"
+	}
+	return "UNKNOWN"
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	// Category groups related checks, e.g. "handshake" or "streams".
+	Category string
+	Name     string
+	Status   Status
+	// Detail explains a failure or skip, or gives more information about
+	// a pass.
+	Detail string
+}
+
+// Report is the outcome of a conformance run.
+type Report struct {
+	// Server is the address of the server that was checked.
+	Server  string
+	Results []Result
+}
+
+// Count returns the number of checks with a status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Write writes the report in a human-readable form.
+func (r *Report) Write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Server: %v\n", r.Server)
+	for _, result := range r.Results {
+		fmt.Fprintf(&b, "%v %v: %v", result.Status, result.Category, result.Name)
+		if result.Detail != "" {
+			fmt.Fprintf(&b, " (%v)", result.Detail)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d passed, %d failed, %d skipped\n", r.Count(Pass), r.Count(Fail), r.Count(Skip))
+	_, err := io.WriteString(w, b.String())
+	return tracerr.Wrap(err)
+}
+
+// Config configures a conformance run.
+type Config struct {
+	// Client is the config of the client to check the server with.
+	Client krpcgo.KRPCClientConfig
+	// Timeout is how long to wait for stream updates and events. Defaults
+	// to 5 seconds.
+	Timeout time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	cfg.Client.SetDefaults()
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+}
+
+// skipped is returned by a check that couldn't be made.
+type skipped struct {
+	reason string
+}
+
+// Error returns the reason for the skip.
+func (s *skipped) Error() string {
+	return s.reason
+}
+
+// skip returns an error that skips a check.
+func skip(format string, args ...interface{}) error {
+	return &skipped{reason: fmt.Sprintf(format, args...)}
+}
+
+// passed is returned by a check that passed with details to report.
+type passed struct {
+	detail string
+}
+
+// Error returns the details.
+func (p *passed) Error() string {
+	return p.detail
+}
+
+// pass returns an error that passes a check with details.
+func pass(format string, args ...interface{}) error {
+	return &passed{detail: fmt.Sprintf(format, args...)}
+}
+
+// suite is a conformance run in progress.
+type suite struct {
+	ctx    context.Context
+	cfg    Config
+	client *krpcgo.KRPCClient
+	report *Report
+}
+
+// check runs a check and records its result. A check fails if it returns
+// an error, unless the error is from skip or pass.
+func (s *suite) check(category, name string, f func() error) {
+	result := Result{Category: category, Name: name}
+	err := f()
+	var sk *skipped
+	var p *passed
+	switch {
+	case err == nil:
+		result.Status = Pass
+	case errors.As(err, &sk):
+		result.Status = Skip
+		result.Detail = sk.reason
+	case errors.As(err, &p):
+		result.Status = Pass
+		result.Detail = p.detail
+	default:
+		result.Status = Fail
+		result.Detail = tracerr.Unwrap(err).Error()
+	}
+	s.report.Results = append(s.report.Results, result)
+}
+
+// Run checks the server a client with the config would connect to. Checks
+// that can't be made because of an earlier failure, such as everything
+// after a failed connection, are left out of the report.
+func Run(ctx context.Context, cfg Config) *Report {
+	cfg.SetDefaults()
+	s := &suite{
+		ctx:    ctx,
+		cfg:    cfg,
+		client: krpcgo.NewKRPCClient(cfg.Client),
+		report: &Report{Server: cfg.Client.Host + ":" + cfg.Client.RPCPort},
+	}
+	connected := false
+	s.check("handshake", "connect", func() error {
+		if err := s.client.Connect(ctx); err != nil {
+			return err
+		}
+		connected = true
+		return nil
+	})
+	if !connected {
+		return s.report
+	}
+	defer s.client.Close()
+
+	s.checkHandshake()
+	s.checkEncoding()
+	s.checkArguments()
+	s.checkStreams()
+	s.checkEvents()
+	s.checkErrors()
+	return s.report
+}
@@ -0,0 +1,167 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// conformingServer returns a server that passes the checks it can: it has
+// no events, and a getter that returns the wrong type.
+func conformingServer(t *testing.T) *krpctest.Server {
+	t.Helper()
+	server := krpctest.NewServer()
+	t.Cleanup(func() { server.Close() })
+	require.NoError(t, server.Start())
+
+	typ := func(code types.Type_TypeCode, elems ...*types.Type) *types.Type {
+		return &types.Type{Code: code, Types: elems}
+	}
+	getter := func(name string, t *types.Type) *types.Procedure {
+		return &types.Procedure{Name: name, ReturnType: t}
+	}
+	server.Return("KRPC", "GetServices", &types.Services{Services: []*types.Service{
+		{Name: "KRPC", Procedures: []*types.Procedure{
+			getter("GetClientID", typ(types.Type_BYTES)),
+			getter("GetClientName", typ(types.Type_STRING)),
+			getter("GetStatus", typ(types.Type_STATUS)),
+			getter("get_Clients", typ(types.Type_LIST, typ(types.Type_TUPLE, typ(types.Type_BYTES), typ(types.Type_STRING), typ(types.Type_STRING)))),
+			getter("get_CurrentGameScene", typ(types.Type_ENUMERATION)),
+			getter("get_Paused", typ(types.Type_BOOL)),
+			{Name: "SetStreamRate", Parameters: []*types.Parameter{{Name: "id", Type: typ(types.Type_UINT64)}}},
+		}},
+		{Name: "SpaceCenter", Procedures: []*types.Procedure{
+			getter("get_UT", typ(types.Type_DOUBLE)),
+			getter("get_WarpRate", typ(types.Type_FLOAT)),
+			getter("get_ActiveVessel", typ(types.Type_CLASS)),
+		}},
+	}})
+	server.Return("KRPC", "GetClientName", "krpctest")
+	server.Return("KRPC", "GetStatus", &types.Status{Version: "0.5.2"})
+	server.Return("KRPC", "get_Clients", []types.Tuple3[[]byte, string, string]{
+		types.NewTuple3(make([]byte, 16), "krpctest", "127.0.0.1:50000"),
+	})
+	server.Return("KRPC", "get_CurrentGameScene", int32(1))
+	server.Return("KRPC", "get_Paused", false)
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	// Wrongly encoded as a double.
+	server.Return("SpaceCenter", "get_WarpRate", 1.0)
+	server.Handle("SpaceCenter", "get_ActiveVessel", func(*krpctest.Call) (interface{}, error) {
+		return nil, errors.New("no active vessel")
+	})
+	for _, name := range []string{
+		"ConstantDouble", "ConstantFloat", "ConstantBool", "ConstantString", "Call",
+		"CreateList", "CreateSet", "CreateTuple", "CreateDictionary",
+	} {
+		server.Return("KRPC", "Expression_static_"+name, uint64(1))
+	}
+	server.Handle("KRPC", "Expression_static_ConstantInt", func(call *krpctest.Call) (interface{}, error) {
+		var i int32
+		if err := call.Arg(0, &i); err != nil {
+			return nil, err
+		}
+		return uint64(1), nil
+	})
+	// The event's stream doesn't exist, so it can't be started.
+	server.Return("KRPC", "AddEvent", &types.Event{Stream: &types.Stream{Id: 99}})
+	return server
+}
+
+func TestRun(t *testing.T) {
+	server := conformingServer(t)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// New streams are sent on the next update.
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				server.Update()
+			}
+		}
+	}()
+
+	report := Run(context.Background(), Config{Client: server.ClientConfig(), Timeout: 500 * time.Millisecond})
+	statuses := map[string]Status{}
+	for _, r := range report.Results {
+		statuses[r.Category+": "+r.Name] = r.Status
+	}
+	require.Equal(t, map[string]Status{
+		"handshake: connect":                   Pass,
+		"handshake: client name":               Pass,
+		"handshake: client identifier":         Pass,
+		"handshake: wrong connection type":     Pass,
+		"handshake: unknown client identifier": Pass,
+		"encoding: services":                   Pass,
+		"encoding: DOUBLE":                     Pass,
+		"encoding: FLOAT":                      Fail,
+		"encoding: SINT32":                     Skip,
+		"encoding: SINT64":                     Skip,
+		"encoding: UINT32":                     Skip,
+		"encoding: UINT64":                     Skip,
+		"encoding: BOOL":                       Pass,
+		"encoding: STRING":                     Pass,
+		"encoding: BYTES":                      Pass,
+		"encoding: CLASS":                      Skip,
+		"encoding: ENUMERATION":                Pass,
+		"encoding: EVENT":                      Skip,
+		"encoding: PROCEDURE_CALL":             Skip,
+		"encoding: STREAM":                     Skip,
+		"encoding: STATUS":                     Pass,
+		"encoding: SERVICES":                   Skip,
+		"encoding: TUPLE":                      Skip,
+		"encoding: LIST":                       Pass,
+		"encoding: SET":                        Skip,
+		"encoding: DICTIONARY":                 Skip,
+		"arguments: DOUBLE":                    Pass,
+		"arguments: FLOAT":                     Pass,
+		"arguments: SINT32":                    Pass,
+		"arguments: BOOL":                      Pass,
+		"arguments: STRING":                    Pass,
+		"arguments: PROCEDURE_CALL":            Pass,
+		"arguments: LIST":                      Pass,
+		"arguments: SET":                       Pass,
+		"arguments: TUPLE":                     Pass,
+		"arguments: DICTIONARY":                Pass,
+		"streams: add":                         Pass,
+		"streams: start and receive":           Pass,
+		"streams: set rate":                    Pass,
+		"streams: remove":                      Pass,
+		"events: add":                          Pass,
+		"events: fire":                         Fail,
+		"errors: unknown procedure":            Pass,
+		"errors: unknown service":              Pass,
+		"errors: missing argument":             Pass,
+	}, statuses)
+	require.Equal(t, 2, report.Count(Fail))
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	require.Contains(t, out.String(), "FAIL encoding: FLOAT (SpaceCenter.get_WarpRate: invalid float32 at value, byte 4: 4 unexpected trailing bytes)\n")
+	require.Contains(t, out.String(), "SKIP encoding: CLASS (1 procedure failed on the server)\n")
+	require.Contains(t, out.String(), "PASS errors: unknown procedure (ProcedureNotFound)\n")
+	require.Contains(t, out.String(), "31 passed, 2 failed, 12 skipped\n")
+}
+
+func TestRunWithoutServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	report := Run(context.Background(), Config{Client: krpcgo.KRPCClientConfig{Host: host, RPCPort: port, StreamPort: port}})
+	require.Len(t, report.Results, 1)
+	require.Equal(t, "connect", report.Results[0].Name)
+	require.Equal(t, Fail, report.Results[0].Status)
+}
@@ -0,0 +1,113 @@
+// Package control provides feedback controllers for flying vessels, as used
+// by the autopilots in the spacecenter package.
+package control
+
+import "math"
+
+// Gains are the gains of a PID controller.
+type Gains struct {
+	// Kp is the proportional gain.
+	Kp float64
+	// Ki is the integral gain.
+	Ki float64
+	// Kd is the derivative gain.
+	Kd float64
+	// Kf is the feedforward gain, applied to the setpoint.
+	Kf float64
+}
+
+// PID is a PID controller with a clamped output. While the output is
+// clamped, the integral term stops accumulating, so it doesn't wind up.
+//
+// PID isn't safe for concurrent use.
+type PID struct {
+	gains Gains
+	min   float64
+	max   float64
+	// DerivativeOnMeasurement takes the derivative of the measurement
+	// instead of the error, making a PI-D controller. This stops the
+	// derivative term from kicking when the setpoint changes.
+	DerivativeOnMeasurement bool
+
+	integral float64
+	last     float64
+	started  bool
+	output   float64
+}
+
+// New creates a new PID controller with its output clamped between min and
+// max.
+func New(gains Gains, min, max float64) *PID {
+	return &PID{gains: gains, min: min, max: max}
+}
+
+// Gains returns the controller's gains.
+func (p *PID) Gains() Gains {
+	return p.gains
+}
+
+// SetGains retunes the controller. The integral is rescaled so that the
+// integral term, and so the output, doesn't jump.
+func (p *PID) SetGains(gains Gains) {
+	if gains.Ki == 0 {
+		p.integral = 0
+	} else {
+		p.integral *= p.gains.Ki / gains.Ki
+	}
+	p.gains = gains
+}
+
+// SetLimits changes the output limits.
+func (p *PID) SetLimits(min, max float64) {
+	p.min = min
+	p.max = max
+}
+
+// Output returns the last output of the controller.
+func (p *PID) Output() float64 {
+	return p.output
+}
+
+// Update returns the controller's output for a setpoint and measurement, dt
+// seconds after the last update.
+func (p *PID) Update(setpoint, measurement, dt float64) float64 {
+	err := setpoint - measurement
+	tracked := err
+	if p.DerivativeOnMeasurement {
+		tracked = -measurement
+	}
+	return p.update(err, tracked, p.gains.Kf*setpoint, dt)
+}
+
+// UpdateError returns the controller's output for an error, dt seconds after
+// the last update. It is useful when the error isn't simply the difference
+// between the setpoint and measurement, such as for angles that wrap around.
+// There is no feedforward, and the derivative is always of the error.
+func (p *PID) UpdateError(err, dt float64) float64 {
+	return p.update(err, err, 0, dt)
+}
+
+func (p *PID) update(err, tracked, feedforward, dt float64) float64 {
+	var derivative float64
+	if p.started && dt > 0 {
+		derivative = (tracked - p.last) / dt
+	}
+	p.last = tracked
+	p.started = true
+
+	integral := p.integral + err*dt
+	out := feedforward + p.gains.Kp*err + p.gains.Ki*integral + p.gains.Kd*derivative
+	p.output = math.Max(p.min, math.Min(p.max, out))
+	if p.output == out {
+		p.integral = integral
+	}
+	return p.output
+}
+
+// Reset clears the controller's history.
+func (p *PID) Reset() {
+	p.integral = 0
+	p.last = 0
+	p.started = false
+	p.output = 0
+}
@@ -0,0 +1,97 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIDTerms(t *testing.T) {
+	tcs := []struct {
+		name     string
+		gains    Gains
+		errs     []float64
+		expected []float64
+	}{
+		{name: "proportional", gains: Gains{Kp: 2}, errs: []float64{1, 3, -2}, expected: []float64{2, 6, -4}},
+		{name: "integral", gains: Gains{Ki: 1}, errs: []float64{1, 1, -3}, expected: []float64{1, 2, -1}},
+		// There is no derivative on the first update.
+		{name: "derivative", gains: Gains{Kd: 1}, errs: []float64{5, 3, 3}, expected: []float64{0, -2, 0}},
+		{name: "clamped", gains: Gains{Kp: 100}, errs: []float64{1, -1}, expected: []float64{10, -10}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.gains, -10, 10)
+			for i, err := range tc.errs {
+				require.InDelta(t, tc.expected[i], p.UpdateError(err, 1), 1e-9)
+			}
+			require.InDelta(t, tc.expected[len(tc.expected)-1], p.Output(), 1e-9)
+		})
+	}
+}
+
+func TestPIDAntiWindup(t *testing.T) {
+	p := New(Gains{Ki: 1}, -1, 1)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, 1.0, p.UpdateError(5, 1))
+	}
+	// The integral didn't build up while saturated, so it recovers at once.
+	require.Less(t, p.UpdateError(-1, 1), 1.0)
+}
+
+func TestPIDFeedforward(t *testing.T) {
+	p := New(Gains{Kp: 1, Kf: 0.5}, -100, 100)
+	require.InDelta(t, 5+2, p.Update(10, 8, 1), 1e-9)
+	// No feedforward without a setpoint.
+	require.InDelta(t, 2, p.UpdateError(2, 1), 1e-9)
+}
+
+func TestPIDDerivativeOnMeasurement(t *testing.T) {
+	onError := New(Gains{Kd: 1}, -100, 100)
+	onMeasurement := New(Gains{Kd: 1}, -100, 100)
+	onMeasurement.DerivativeOnMeasurement = true
+	onError.Update(0, 0, 1)
+	onMeasurement.Update(0, 0, 1)
+
+	// A setpoint change kicks the derivative on error only.
+	require.InDelta(t, 10, onError.Update(10, 0, 1), 1e-9)
+	require.InDelta(t, 0, onMeasurement.Update(10, 0, 1), 1e-9)
+
+	// Both oppose the measurement moving.
+	require.InDelta(t, -2, onError.Update(10, 2, 1), 1e-9)
+	require.InDelta(t, -2, onMeasurement.Update(10, 2, 1), 1e-9)
+}
+
+func TestPIDBumplessRetuning(t *testing.T) {
+	p := New(Gains{Ki: 1}, -100, 100)
+	for i := 0; i < 5; i++ {
+		p.UpdateError(2, 1)
+	}
+	require.InDelta(t, 10, p.Output(), 1e-9)
+
+	p.SetGains(Gains{Ki: 4})
+	require.Equal(t, Gains{Ki: 4}, p.Gains())
+	// The output carries on from where it was.
+	require.InDelta(t, 10, p.UpdateError(0, 1), 1e-9)
+	require.InDelta(t, 14, p.UpdateError(1, 1), 1e-9)
+
+	p.SetGains(Gains{Kp: 1})
+	require.InDelta(t, 1, p.UpdateError(1, 1), 1e-9)
+}
+
+func TestPIDReset(t *testing.T) {
+	p := New(Gains{Ki: 1, Kd: 1}, -100, 100)
+	p.UpdateError(5, 1)
+	p.UpdateError(5, 1)
+	p.Reset()
+	require.Equal(t, 0.0, p.Output())
+	require.InDelta(t, 1, p.UpdateError(1, 1), 1e-9)
+}
+
+func TestPIDSetLimits(t *testing.T) {
+	p := New(Gains{Kp: 1}, -1, 1)
+	require.Equal(t, 1.0, p.UpdateError(5, 1))
+	p.SetLimits(0, 3)
+	require.Equal(t, 3.0, p.UpdateError(5, 1))
+	require.Equal(t, 0.0, p.UpdateError(-5, 1))
+}
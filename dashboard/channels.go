@@ -0,0 +1,91 @@
+package dashboard
+
+import (
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// float32Channel makes a channel from a float32 getter.
+func float32Channel(name, unit string, read func() (float32, error)) telemetry.Channel {
+	return telemetry.Channel{Name: name, Unit: unit, Read: func() (float64, error) {
+		v, err := read()
+		return float64(v), tracerr.Wrap(err)
+	}}
+}
+
+// VesselChannels returns channels for the dashboard's standard panels: an
+// overview of the vessel, its orbit, and the amount and capacity of each
+// resource it carries. Resources are named
+// "resources.<name>" and "resources.<name>.max", which the page shows as a
+// gauge.
+//
+// Resources are found when VesselChannels is called, so call it again after
+// docking or staging changes what the vessel carries.
+func VesselChannels(vessel *spacecenter.Vessel) ([]telemetry.Channel, error) {
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	resources, err := vessel.Resources()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names, err := resources.Names()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	// flight returns the vessel's flight in the frame of the body it is
+	// orbiting, which changes as it moves between spheres of influence.
+	flight := func() (*spacecenter.Flight, error) {
+		body, err := orbit.Body()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		rf, err := body.ReferenceFrame()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		f, err := vessel.Flight(rf)
+		return f, tracerr.Wrap(err)
+	}
+	flightChannel := func(name, unit string, read func(*spacecenter.Flight) (float64, error)) telemetry.Channel {
+		return telemetry.Channel{Name: name, Unit: unit, Read: func() (float64, error) {
+			f, err := flight()
+			if err != nil {
+				return 0, tracerr.Wrap(err)
+			}
+			v, err := read(f)
+			return v, tracerr.Wrap(err)
+		}}
+	}
+
+	channels := []telemetry.Channel{
+		{Name: "met", Unit: "s", Read: vessel.MET},
+		flightChannel("altitude", "m", (*spacecenter.Flight).MeanAltitude),
+		flightChannel("speed", "m/s", (*spacecenter.Flight).Speed),
+		flightChannel("vertical_speed", "m/s", (*spacecenter.Flight).VerticalSpeed),
+		float32Channel("mass", "kg", vessel.Mass),
+		float32Channel("thrust", "N", vessel.Thrust),
+		{Name: "orbit.apoapsis", Unit: "m", Read: orbit.ApoapsisAltitude},
+		{Name: "orbit.periapsis", Unit: "m", Read: orbit.PeriapsisAltitude},
+		{Name: "orbit.time_to_apoapsis", Unit: "s", Read: orbit.TimeToApoapsis},
+		{Name: "orbit.time_to_periapsis", Unit: "s", Read: orbit.TimeToPeriapsis},
+		{Name: "orbit.period", Unit: "s", Read: orbit.Period},
+		{Name: "orbit.eccentricity", Read: orbit.Eccentricity},
+		{Name: "orbit.inclination", Unit: "°", Read: func() (float64, error) {
+			v, err := orbit.Inclination()
+			return units.Degrees(v), tracerr.Wrap(err)
+		}},
+	}
+	for _, name := range names {
+		name := name
+		channels = append(channels,
+			float32Channel("resources."+name, "", func() (float32, error) { return resources.Amount(name) }),
+			float32Channel("resources."+name+".max", "", func() (float32, error) { return resources.Max(name) }),
+		)
+	}
+	return channels, nil
+}
@@ -0,0 +1,182 @@
+// Package dashboard serves a web page showing a vessel's telemetry as it is
+// recorded, for keeping an eye on long unattended missions.
+//
+// A Dashboard is a telemetry.Writer, so add it to a telemetry.Recorder, and
+// an http.Handler, so serve it:
+//
+//	channels, err := dashboard.VesselChannels(vessel)
+//	...
+//	recorder := telemetry.NewRecorder(telemetry.RecorderConfig{Vessel: name}, channels...)
+//	d := dashboard.New(recorder.Metadata(), dashboard.Config{})
+//	recorder.AddWriter(d)
+//	go http.ListenAndServe(":8080", d)
+//	err = recorder.Run(ctx)
+//
+// The page shows the mission phase set with Recorder.SetPhase and each
+// channel, grouped into panels.
+//
+// A Terminal shows the same panels on a terminal, with sparklines and
+// gauges, for machines without a browser. The krpc-top command runs one for
+// the active vessel.
+package dashboard
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/atburke/krpc-go/telemetry"
+)
+
+//go:embed ui
+var ui embed.FS
+
+// Panel is a group of channels shown together.
+type Panel struct {
+	Title string `json:"title"`
+	// Channels are the names of the channels to show, in order.
+	Channels []string `json:"channels"`
+}
+
+// Config configures a Dashboard.
+type Config struct {
+	// Title is the page's title. It defaults to the vessel's name, or
+	// "krpc-go" if the recording has none.
+	Title string
+	// Panels are the panels to show. By default, channels are grouped by
+	// the part of their name before the first dot, so "orbit.apoapsis" is
+	// shown in an "Orbit" panel, and channels without a dot are shown in an
+	// "Overview" panel. Channels left out of every panel are shown in an
+	// "Other" panel.
+	Panels []Panel
+	// Live configures the stream of records to the page.
+	Live telemetry.LiveConfig
+}
+
+// panelTitle returns the title of the default panel for a channel.
+func panelTitle(channel string) string {
+	group, _, found := strings.Cut(channel, ".")
+	if !found || group == "" {
+		return "Overview"
+	}
+	return strings.ToUpper(group[:1]) + group[1:]
+}
+
+// defaultPanels groups channels by the prefix of their names, keeping the
+// channels' order.
+func defaultPanels(channels []telemetry.ChannelInfo) []Panel {
+	var panels []Panel
+	index := map[string]int{}
+	for _, c := range channels {
+		title := panelTitle(c.Name)
+		i, ok := index[title]
+		if !ok {
+			i = len(panels)
+			index[title] = i
+			panels = append(panels, Panel{Title: title})
+		}
+		panels[i].Channels = append(panels[i].Channels, c.Name)
+	}
+	return panels
+}
+
+// layout describes the page, for the UI to build itself from.
+type layout struct {
+	Title    string                  `json:"title"`
+	Vessel   string                  `json:"vessel,omitempty"`
+	Channels []telemetry.ChannelInfo `json:"channels"`
+	Panels   []Panel                 `json:"panels"`
+}
+
+// newLayout returns the page's layout, adding any channels left out of the
+// panels to an "Other" panel and leaving out unknown channels.
+func newLayout(meta telemetry.Metadata, cfg Config) layout {
+	l := layout{Title: cfg.Title, Vessel: meta.Vessel, Channels: meta.Channels}
+	if l.Title == "" {
+		l.Title = meta.Vessel
+	}
+	if l.Title == "" {
+		l.Title = "krpc-go"
+	}
+	panels := cfg.Panels
+	if panels == nil {
+		panels = defaultPanels(meta.Channels)
+	}
+
+	known := map[string]bool{}
+	for _, c := range meta.Channels {
+		known[c.Name] = true
+	}
+	shown := map[string]bool{}
+	for _, p := range panels {
+		panel := Panel{Title: p.Title, Channels: []string{}}
+		for _, name := range p.Channels {
+			if known[name] {
+				panel.Channels = append(panel.Channels, name)
+				shown[name] = true
+			}
+		}
+		l.Panels = append(l.Panels, panel)
+	}
+	other := Panel{Title: "Other"}
+	for _, c := range meta.Channels {
+		if !shown[c.Name] {
+			other.Channels = append(other.Channels, c.Name)
+		}
+	}
+	if len(other.Channels) > 0 {
+		l.Panels = append(l.Panels, other)
+	}
+	return l
+}
+
+// Dashboard serves the dashboard page and streams records to it. It serves:
+//
+//	/          the page
+//	/layout    the page's title, channels and panels as JSON
+//	/live/...  the records, as served by a telemetry.LiveServer
+type Dashboard struct {
+	layout layout
+	live   *telemetry.LiveServer
+	mux    *http.ServeMux
+}
+
+// New creates a new Dashboard.
+func New(meta telemetry.Metadata, cfg Config) *Dashboard {
+	d := &Dashboard{
+		layout: newLayout(meta, cfg),
+		live:   telemetry.NewLiveServer(meta, cfg.Live),
+		mux:    http.NewServeMux(),
+	}
+	static, err := fs.Sub(ui, "ui")
+	if err != nil {
+		panic(err)
+	}
+	d.mux.Handle("/", http.FileServer(http.FS(static)))
+	d.mux.HandleFunc("/layout", d.serveLayout)
+	d.mux.Handle("/live/", http.StripPrefix("/live", d.live))
+	return d
+}
+
+// ServeHTTP serves the dashboard.
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mux.ServeHTTP(w, r)
+}
+
+func (d *Dashboard) serveLayout(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(d.layout)
+}
+
+// Write sends a record to the open pages.
+func (d *Dashboard) Write(rec telemetry.Record) error {
+	return d.live.Write(rec)
+}
+
+// Close disconnects the open pages. The page itself is still served, but
+// shows that the recording has finished.
+func (d *Dashboard) Close() error {
+	return d.live.Close()
+}
@@ -0,0 +1,119 @@
+package dashboard
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func testMetadata() telemetry.Metadata {
+	return telemetry.Metadata{
+		Vessel: "Kerbal X",
+		Channels: []telemetry.ChannelInfo{
+			{Name: "altitude", Unit: "m"},
+			{Name: "orbit.apoapsis", Unit: "m"},
+			{Name: "resources.LiquidFuel"},
+			{Name: "orbit.periapsis", Unit: "m"},
+			{Name: "resources.LiquidFuel.max"},
+		},
+		Started: time.Unix(1000, 0).UTC(),
+	}
+}
+
+func TestDefaultPanels(t *testing.T) {
+	require.Equal(t, []Panel{
+		{Title: "Overview", Channels: []string{"altitude"}},
+		{Title: "Orbit", Channels: []string{"orbit.apoapsis", "orbit.periapsis"}},
+		{Title: "Resources", Channels: []string{"resources.LiquidFuel", "resources.LiquidFuel.max"}},
+	}, defaultPanels(testMetadata().Channels))
+}
+
+func TestNewLayout(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            Config
+		expectedTitle  string
+		expectedPanels []Panel
+	}{
+		{
+			name:          "defaults",
+			expectedTitle: "Kerbal X",
+			expectedPanels: []Panel{
+				{Title: "Overview", Channels: []string{"altitude"}},
+				{Title: "Orbit", Channels: []string{"orbit.apoapsis", "orbit.periapsis"}},
+				{Title: "Resources", Channels: []string{"resources.LiquidFuel", "resources.LiquidFuel.max"}},
+			},
+		},
+		{
+			name: "custom panels",
+			cfg: Config{
+				Title: "Mun landing",
+				Panels: []Panel{
+					{Title: "Orbit", Channels: []string{"orbit.periapsis", "nope", "altitude"}},
+					{Title: "Empty"},
+				},
+			},
+			expectedTitle: "Mun landing",
+			expectedPanels: []Panel{
+				{Title: "Orbit", Channels: []string{"orbit.periapsis", "altitude"}},
+				{Title: "Empty", Channels: []string{}},
+				{Title: "Other", Channels: []string{"orbit.apoapsis", "resources.LiquidFuel", "resources.LiquidFuel.max"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newLayout(testMetadata(), tc.cfg)
+			require.Equal(t, tc.expectedTitle, l.Title)
+			require.Equal(t, tc.expectedPanels, l.Panels)
+		})
+	}
+
+	require.Equal(t, "krpc-go", newLayout(telemetry.Metadata{}, Config{}).Title)
+}
+
+func TestDashboard(t *testing.T) {
+	d := New(testMetadata(), Config{})
+	server := httptest.NewServer(d)
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/")
+	require.NoError(t, err)
+	page, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(page), "<title>krpc-go dashboard</title>")
+
+	resp, err = http.Get(server.URL + "/layout")
+	require.NoError(t, err)
+	var l layout
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&l))
+	resp.Body.Close()
+	require.Equal(t, newLayout(testMetadata(), Config{}), l)
+
+	require.NoError(t, d.Write(telemetry.Record{
+		Time:   time.Unix(2000, 0).UTC(),
+		Phase:  "coast",
+		Values: []float64{100, 80000, 10, 70000, 20},
+	}))
+	resp, err = http.Get(server.URL + "/live/events?channels=altitude")
+	require.NoError(t, err)
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, `data: {"altitude":100,"phase":"coast","time":"1970-01-01T00:33:20Z","ut":0,"vessel":"Kerbal X"}`+"\n", line)
+
+	require.NoError(t, d.Close())
+	resp, err = http.Get(server.URL + "/live/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusGone, resp.StatusCode)
+}
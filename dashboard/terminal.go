@@ -0,0 +1,307 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/ztrue/tracerr"
+)
+
+// ANSI escape sequences for drawing the terminal dashboard.
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// sparkBlocks are the bars of a sparkline, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Range is the range of values a gauge shows.
+type Range struct {
+	Min, Max float64
+}
+
+// TerminalConfig configures a Terminal.
+type TerminalConfig struct {
+	// Title is shown at the top. It defaults to the vessel's name.
+	Title string
+	// Panels are the panels to show, as for a Dashboard.
+	Panels []Panel
+	// Gauges shows channels as gauges over the given ranges, rather than as
+	// sparklines. Channels with a matching ".max" channel, such as
+	// resources, are always shown as gauges filled up to their maximum.
+	Gauges map[string]Range
+	// History is how many samples each sparkline shows.
+	History int
+	// Events is how many recent events to show.
+	Events int
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *TerminalConfig) SetDefaults() {
+	if cfg.History == 0 {
+		cfg.History = 30
+	}
+	if cfg.Events == 0 {
+		cfg.Events = 8
+	}
+}
+
+// terminalEvent is a line in the events list.
+type terminalEvent struct {
+	time    time.Time
+	message string
+}
+
+// Terminal draws a dashboard on a terminal with ANSI escape codes, for
+// machines without a browser. It is a telemetry.Writer, redrawing on every
+// record, and an alert.Sink, listing alerts with the mission's other recent
+// events. Phase changes are listed as they are recorded.
+type Terminal struct {
+	w      io.Writer
+	cfg    TerminalConfig
+	layout layout
+	// index maps channel names to their index in a record.
+	index map[string]int
+
+	mu      sync.Mutex
+	history [][]float64
+	last    *telemetry.Record
+	events  []terminalEvent
+	drawn   bool
+}
+
+// NewTerminal creates a new Terminal drawing to w, usually os.Stdout.
+func NewTerminal(w io.Writer, meta telemetry.Metadata, cfg TerminalConfig) *Terminal {
+	cfg.SetDefaults()
+	t := &Terminal{
+		w:       w,
+		cfg:     cfg,
+		layout:  newLayout(meta, Config{Title: cfg.Title, Panels: cfg.Panels}),
+		index:   map[string]int{},
+		history: make([][]float64, len(meta.Channels)),
+	}
+	for i, c := range meta.Channels {
+		t.index[c.Name] = i
+	}
+	return t
+}
+
+// addEvent adds an event, dropping the oldest once there are too many.
+// t.mu must be held.
+func (t *Terminal) addEvent(at time.Time, message string) {
+	t.events = append(t.events, terminalEvent{time: at, message: message})
+	if len(t.events) > t.cfg.Events {
+		t.events = t.events[len(t.events)-t.cfg.Events:]
+	}
+}
+
+// Event adds a line to the list of recent events and redraws.
+func (t *Terminal) Event(format string, args ...any) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.addEvent(time.Now(), fmt.Sprintf(format, args...))
+	return t.draw()
+}
+
+// Alert lists an alert with the recent events.
+func (t *Terminal) Alert(a alert.Alert) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	at := a.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	t.addEvent(at, fmt.Sprintf("%-8s %s", a.Severity, a.Message))
+	_ = t.draw()
+}
+
+// Write records a sample and redraws.
+func (t *Terminal) Write(rec telemetry.Record) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if rec.Phase != "" && (t.last == nil || t.last.Phase != rec.Phase) {
+		t.addEvent(rec.Time, "phase: "+rec.Phase)
+	}
+	for i, v := range rec.Values {
+		h := append(t.history[i], v)
+		if len(h) > t.cfg.History {
+			h = h[len(h)-t.cfg.History:]
+		}
+		t.history[i] = h
+	}
+	t.last = &rec
+	return t.draw()
+}
+
+// Close shows the cursor again, leaving the last frame on the screen.
+func (t *Terminal) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.drawn {
+		return nil
+	}
+	_, err := io.WriteString(t.w, showCursor)
+	return tracerr.Wrap(err)
+}
+
+// draw redraws the screen. t.mu must be held.
+func (t *Terminal) draw() error {
+	prefix := clearScreen
+	if !t.drawn {
+		prefix = hideCursor + prefix
+		t.drawn = true
+	}
+	_, err := io.WriteString(t.w, prefix+t.render())
+	return tracerr.Wrap(err)
+}
+
+// render returns the dashboard as text. t.mu must be held.
+func (t *Terminal) render() string {
+	var b strings.Builder
+	header := t.layout.Title
+	if t.last != nil {
+		if t.last.Phase != "" {
+			header += "   phase: " + t.last.Phase
+		}
+		if t.last.UT != 0 {
+			header += "   UT " + formatDuration(t.last.UT)
+		}
+	}
+	b.WriteString(header + "\n")
+	b.WriteString(strings.Repeat("─", 72) + "\n")
+
+	for _, p := range t.layout.Panels {
+		b.WriteString(strings.ToUpper(p.Title) + "\n")
+		for _, name := range p.Channels {
+			if strings.HasSuffix(name, ".max") {
+				if _, ok := t.index[strings.TrimSuffix(name, ".max")]; ok {
+					continue
+				}
+			}
+			b.WriteString(t.renderChannel(p.Title, name) + "\n")
+		}
+	}
+
+	b.WriteString("EVENTS\n")
+	for _, e := range t.events {
+		fmt.Fprintf(&b, "  %s  %s\n", e.time.Format("15:04:05"), e.message)
+	}
+	return b.String()
+}
+
+// renderChannel returns a channel's line: its name, latest value and a
+// gauge or sparkline.
+func (t *Terminal) renderChannel(panel, name string) string {
+	i := t.index[name]
+	unit := t.layout.Channels[i].Unit
+	label := strings.TrimPrefix(name, strings.ToLower(panel)+".")
+	value := math.NaN()
+	if t.last != nil {
+		value = t.last.Values[i]
+	}
+
+	if maxIndex, ok := t.index[name+".max"]; ok {
+		capacity := math.NaN()
+		if t.last != nil {
+			capacity = t.last.Values[maxIndex]
+		}
+		text := formatValue(value, unit) + " / " + formatValue(capacity, unit)
+		return fmt.Sprintf("  %-20s %21s  %s", label, text, gauge(value, Range{Max: capacity}, t.cfg.History))
+	}
+	if r, ok := t.cfg.Gauges[name]; ok {
+		return fmt.Sprintf("  %-20s %21s  %s", label, formatValue(value, unit), gauge(value, r, t.cfg.History))
+	}
+	return fmt.Sprintf("  %-20s %21s  %s", label, formatValue(value, unit), sparkline(t.history[i]))
+}
+
+// sparkline draws values as a row of bars scaled between their minimum and
+// maximum. Missing values are left blank.
+func sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			b.WriteRune(' ')
+		case hi == lo:
+			b.WriteRune(sparkBlocks[0])
+		default:
+			level := int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+			b.WriteRune(sparkBlocks[level])
+		}
+	}
+	return b.String()
+}
+
+// gauge draws a bar width characters wide, filled to show where a value
+// lies in a range, followed by the percentage.
+func gauge(value float64, r Range, width int) string {
+	fraction := (value - r.Min) / (r.Max - r.Min)
+	if math.IsNaN(fraction) || math.IsInf(fraction, 0) {
+		return strings.Repeat("░", width)
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	filled := int(math.Round(fraction * float64(width)))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + fmt.Sprintf(" %3.0f%%", fraction*100)
+}
+
+// formatDuration formats seconds as Kerbin days, which are six hours long,
+// and hh:mm:ss.
+func formatDuration(s float64) string {
+	sign := ""
+	if s < 0 {
+		sign = "-"
+		s = -s
+	}
+	total := int64(s)
+	days, hours, minutes, seconds := total/21600, total%21600/3600, total%3600/60, total%60
+	if days > 0 {
+		return fmt.Sprintf("%s%dd %02d:%02d:%02d", sign, days, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+}
+
+// formatValue formats a value for display, in the same way as the web
+// dashboard: durations as times, large distances in km or Mm, and fewer
+// decimal places for larger values.
+func formatValue(v float64, unit string) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "-"
+	}
+	if unit == "s" {
+		return formatDuration(v)
+	}
+	if unit == "m" && math.Abs(v) >= 1e4 {
+		v /= 1000
+		unit = "km"
+		if math.Abs(v) >= 1e5 {
+			v /= 1000
+			unit = "Mm"
+		}
+	}
+	digits := 4
+	if math.Abs(v) >= 100 {
+		digits = 0
+	} else if math.Abs(v) >= 1 {
+		digits = 2
+	}
+	s := fmt.Sprintf("%.*f", digits, v)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
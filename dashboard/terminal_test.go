@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparkline(t *testing.T) {
+	require.Equal(t, "", sparkline(nil))
+	require.Equal(t, "▁▁", sparkline([]float64{3, 3}))
+	require.Equal(t, "▁▄█ ", sparkline([]float64{0, 50, 100, math.NaN()}))
+}
+
+func TestGauge(t *testing.T) {
+	require.Equal(t, "█████░░░░░  50%", gauge(5, Range{Max: 10}, 10))
+	require.Equal(t, "██████████ 100%", gauge(20, Range{Max: 10}, 10))
+	require.Equal(t, "░░░░░░░░░░   0%", gauge(-5, Range{Min: 0, Max: 10}, 10))
+	require.Equal(t, "░░░░", gauge(1, Range{}, 4))
+	require.Equal(t, "░░░░", gauge(math.NaN(), Range{Max: 1}, 4))
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value    float64
+		unit     string
+		expected string
+	}{
+		{value: 0.5, expected: "0.5000"},
+		{value: 12.345, unit: "m/s", expected: "12.35 m/s"},
+		{value: 9999, unit: "m", expected: "9999 m"},
+		{value: 12345, unit: "m", expected: "12.35 km"},
+		{value: 123456789, unit: "m", expected: "123 Mm"},
+		{value: 3725, unit: "s", expected: "01:02:05"},
+		{value: 21600 + 61, unit: "s", expected: "1d 00:01:01"},
+		{value: -90, unit: "s", expected: "-00:01:30"},
+		{value: math.NaN(), unit: "m", expected: "-"},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.expected, formatValue(tc.value, tc.unit))
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	var out bytes.Buffer
+	term := NewTerminal(&out, testMetadata(), TerminalConfig{History: 4, Events: 2})
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, phase := range []string{"launch", "ascent", "ascent"} {
+		require.NoError(t, term.Write(telemetry.Record{
+			Time:   start.Add(time.Duration(i) * time.Second),
+			UT:     3600,
+			Phase:  phase,
+			Values: []float64{float64(i * 1000), 80000, 100, 70000, 200},
+		}))
+	}
+	term.Alert(alert.Alert{Severity: alert.Warning, Message: "Vessel: low charge", Time: start.Add(5 * time.Second)})
+	require.NoError(t, term.Close())
+
+	frames := strings.Split(out.String(), clearScreen)
+	require.Len(t, frames, 5)
+	require.Equal(t, hideCursor, frames[0])
+	require.True(t, strings.HasSuffix(frames[4], showCursor))
+	require.Equal(t, strings.Join([]string{
+		"Kerbal X   phase: ascent   UT 01:00:00",
+		strings.Repeat("─", 72),
+		"OVERVIEW",
+		"  altitude                            2000 m  ▁▄█",
+		"ORBIT",
+		"  apoapsis                          80.00 km  ▁▁▁",
+		"  periapsis                         70.00 km  ▁▁▁",
+		"RESOURCES",
+		"  LiquidFuel                       100 / 200  ██░░  50%",
+		"EVENTS",
+		"  12:00:01  phase: ascent",
+		"  12:00:05  warning  Vessel: low charge",
+		showCursor,
+	}, "\n"), frames[4])
+}
+
+func TestTerminalGauges(t *testing.T) {
+	var out bytes.Buffer
+	term := NewTerminal(&out, testMetadata(), TerminalConfig{
+		History: 4,
+		Panels:  []Panel{{Title: "Flight", Channels: []string{"altitude"}}},
+		Gauges:  map[string]Range{"altitude": {Max: 4000}},
+	})
+	require.NoError(t, term.Write(telemetry.Record{Values: []float64{1000, 0, 0, 0, 0}}))
+	require.Contains(t, out.String(), "\nFLIGHT\n  altitude                            1000 m  █░░░  25%\nOTHER\n")
+}
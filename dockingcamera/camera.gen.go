@@ -0,0 +1,124 @@
+package dockingcamera
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Camera - a Docking Camera.
+type Camera struct {
+	service.BaseClass
+}
+
+// NewCamera creates a new Camera.
+func NewCamera(id uint64, client *krpcgo.KRPCClient) *Camera {
+	c := &Camera{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Part - get the part containing this camera.
+//
+// Allowed game scenes: any.
+func (s *Camera) Part() (*spacecenter.Part, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.Part
+	request := &types.ProcedureCall{
+		Procedure: "Camera_get_Part",
+		Service:   "DockingCamera",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Image - get an image. Returns an empty byte array on failure.
+//
+// Allowed game scenes: any.
+func (s *Camera) Image() ([]byte, error) {
+	var err error
+	var argBytes []byte
+	var vv []byte
+	request := &types.ProcedureCall{
+		Procedure: "Camera_get_Image",
+		Service:   "DockingCamera",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ImageStream - get an image. Returns an empty byte array on failure.
+//
+// Allowed game scenes: any.
+func (s *Camera) ImageStream() (*krpcgo.Stream[[]byte], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Camera_get_Image",
+		Service:   "DockingCamera",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []byte {
+		var value []byte
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
@@ -0,0 +1,98 @@
+package dockingcamera
+
+import (
+	"bytes"
+	"image"
+	// Register the formats that camera images may be encoded in.
+	_ "image/jpeg"
+	_ "image/png"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Frame is an image from a camera, along with where the camera was when the
+// image was retrieved.
+type Frame struct {
+	// Image is the decoded camera image.
+	Image image.Image
+	// Position is the position of the camera in the requested reference
+	// frame.
+	Position types.Vector3D
+	// Direction is the direction the camera is facing in the requested
+	// reference frame.
+	Direction types.Vector3D
+	// Rotation is the rotation of the camera in the requested reference
+	// frame.
+	Rotation types.Quaternion
+}
+
+// decodeImage decodes the raw image data returned by the Camera API.
+func decodeImage(data []byte) (image.Image, error) {
+	// The Camera API returns an empty image on failure.
+	if len(data) == 0 {
+		return nil, tracerr.Errorf("Camera returned an empty image")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, tracerr.Wrap(err)
+}
+
+// DecodedImage gets the current image from the camera and decodes it.
+func (s *Camera) DecodedImage() (image.Image, error) {
+	data, err := s.Image()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	img, err := decodeImage(data)
+	return img, tracerr.Wrap(err)
+}
+
+// DecodedImageStream streams decoded images from the camera. Images that
+// fail to decode are sent as nil.
+func (s *Camera) DecodedImageStream() (*krpcgo.Stream[image.Image], error) {
+	raw, err := s.ImageStream()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return krpcgo.MapStream(raw, func(data []byte) image.Image {
+		img, err := decodeImage(data)
+		if err != nil {
+			return nil
+		}
+		return img
+	}), nil
+}
+
+// Capture gets the current image from the camera along with the camera's
+// position and orientation in a reference frame, for use in vision-assisted
+// docking.
+func (s *Camera) Capture(referenceFrame *spacecenter.ReferenceFrame) (*Frame, error) {
+	part, err := s.Part()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	img, err := s.DecodedImage()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	position, err := part.Position(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	direction, err := part.Direction(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rotation, err := part.Rotation(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &Frame{
+		Image:     img,
+		Position:  types.Vector3DFromTuple(position),
+		Direction: types.Vector3DFromTuple(direction),
+		Rotation:  types.QuaternionFromTuple(rotation),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package dockingcamera
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	src.Set(1, 2, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	img, err := decodeImage(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, src.Bounds(), img.Bounds())
+	r, _, _, _ := img.At(1, 2).RGBA()
+	require.Equal(t, uint32(0xffff), r)
+
+	_, err = decodeImage(nil)
+	require.Error(t, err)
+	_, err = decodeImage([]byte("not an image"))
+	require.Error(t, err)
+}
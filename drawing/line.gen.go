@@ -0,0 +1,743 @@
+package drawing
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Line - a line. Created using <see cref="M:Drawing.AddLine" />.
+type Line struct {
+	service.BaseClass
+}
+
+// NewLine creates a new Line.
+func NewLine(id uint64, client *krpcgo.KRPCClient) *Line {
+	c := &Line{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Remove - remove the object.
+//
+// Allowed game scenes: any.
+func (s *Line) Remove() error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_Remove",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// Start - start position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) Start() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Start",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// StartStream - start position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) StartStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Start",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetStart - start position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) SetStart(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_Start",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// StartProp - returns a handle to the Start property.
+func (s *Line) StartProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Start, s.SetStart, s.StartStream)
+}
+
+// End - end position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) End() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_End",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// EndStream - end position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) EndStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_End",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetEnd - end position of the line.
+//
+// Allowed game scenes: any.
+func (s *Line) SetEnd(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_End",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// EndProp - returns a handle to the End property.
+func (s *Line) EndProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.End, s.SetEnd, s.EndStream)
+}
+
+// Color - set the color
+//
+// Allowed game scenes: any.
+func (s *Line) Color() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ColorStream - set the color
+//
+// Allowed game scenes: any.
+func (s *Line) ColorStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetColor - set the color
+//
+// Allowed game scenes: any.
+func (s *Line) SetColor(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ColorProp - returns a handle to the Color property.
+func (s *Line) ColorProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Color, s.SetColor, s.ColorStream)
+}
+
+// Thickness - set the thickness
+//
+// Allowed game scenes: any.
+func (s *Line) Thickness() (float32, error) {
+	var err error
+	var argBytes []byte
+	var vv float32
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Thickness",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ThicknessStream - set the thickness
+//
+// Allowed game scenes: any.
+func (s *Line) ThicknessStream() (*krpcgo.Stream[float32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Thickness",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float32 {
+		var value float32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetThickness - set the thickness
+//
+// Allowed game scenes: any.
+func (s *Line) SetThickness(value float32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_Thickness",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ThicknessProp - returns a handle to the Thickness property.
+func (s *Line) ThicknessProp() *krpcgo.Property[float32] {
+	return krpcgo.NewProperty(s.Thickness, s.SetThickness, s.ThicknessStream)
+}
+
+// ReferenceFrame - reference frame for the positions of the object.
+//
+// Allowed game scenes: any.
+func (s *Line) ReferenceFrame() (*spacecenter.ReferenceFrame, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.ReferenceFrame
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_ReferenceFrame",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetReferenceFrame - reference frame for the positions of the object.
+//
+// Allowed game scenes: any.
+func (s *Line) SetReferenceFrame(value *spacecenter.ReferenceFrame) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_ReferenceFrame",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ReferenceFrameProp - returns a handle to the ReferenceFrame property.
+func (s *Line) ReferenceFrameProp() *krpcgo.Property[*spacecenter.ReferenceFrame] {
+	return krpcgo.NewProperty(s.ReferenceFrame, s.SetReferenceFrame, nil)
+}
+
+// Visible - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Line) Visible() (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// VisibleStream - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Line) VisibleStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetVisible - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Line) SetVisible(value bool) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// VisibleProp - returns a handle to the Visible property.
+func (s *Line) VisibleProp() *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(s.Visible, s.SetVisible, s.VisibleStream)
+}
+
+// Material - material used to render the object. Creates the material from a
+// shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Line) Material() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// MaterialStream - material used to render the object. Creates the material
+// from a shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Line) MaterialStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_get_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetMaterial - material used to render the object. Creates the material from a
+// shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Line) SetMaterial(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Line_set_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// MaterialProp - returns a handle to the Material property.
+func (s *Line) MaterialProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Material, s.SetMaterial, s.MaterialStream)
+}
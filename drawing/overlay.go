@@ -0,0 +1,278 @@
+package drawing
+
+import (
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/lib/service"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Drawable is an object drawn by the Drawing service: a *Line, *Polygon, or
+// *Text.
+type Drawable interface {
+	service.Class
+	Remove() error
+}
+
+// Overlay keeps track of the objects it draws so that they can be updated
+// together and removed when they are no longer needed. Every object in an
+// overlay is removed when the overlay is closed, or when the client it was
+// created with is closed.
+type Overlay struct {
+	mu      sync.Mutex
+	client  *krpcgo.KRPCClient
+	drawing *Drawing
+	objects map[uint64]Drawable
+	closed  bool
+}
+
+// NewOverlay creates a new Overlay.
+func NewOverlay(client *krpcgo.KRPCClient) *Overlay {
+	o := &Overlay{
+		client:  client,
+		drawing: New(client),
+		objects: make(map[uint64]Drawable),
+	}
+	client.OnClose(func() {
+		// There's nobody to report this error to, and the server removes
+		// a client's objects when it disconnects anyway.
+		_ = o.Close()
+	})
+	return o
+}
+
+// track adds an object to the overlay, removing it again if the overlay has
+// been closed since the object was created.
+func (o *Overlay) track(obj Drawable) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return tracerr.Wrap(obj.Remove())
+	}
+	o.objects[obj.ID_internal()] = obj
+	return nil
+}
+
+func (o *Overlay) checkOpen() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return tracerr.Errorf("Overlay is closed")
+	}
+	return nil
+}
+
+// AddLine draws a line between two points.
+func (o *Overlay) AddLine(start, end types.Vector3D, referenceFrame *spacecenter.ReferenceFrame) (*Line, error) {
+	if err := o.checkOpen(); err != nil {
+		return nil, err
+	}
+	line, err := o.drawing.AddLine(start.Tuple(), end.Tuple(), referenceFrame, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return line, tracerr.Wrap(o.track(line))
+}
+
+// AddDirection draws a line from the center of mass of the active vessel in a
+// direction.
+func (o *Overlay) AddDirection(direction types.Vector3D, referenceFrame *spacecenter.ReferenceFrame, length float32) (*Line, error) {
+	if err := o.checkOpen(); err != nil {
+		return nil, err
+	}
+	line, err := o.drawing.AddDirectionFromCom(direction.Tuple(), referenceFrame, length, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return line, tracerr.Wrap(o.track(line))
+}
+
+// AddPolygon draws a polygon.
+func (o *Overlay) AddPolygon(vertices []types.Vector3D, referenceFrame *spacecenter.ReferenceFrame) (*Polygon, error) {
+	if err := o.checkOpen(); err != nil {
+		return nil, err
+	}
+	polygon, err := o.drawing.AddPolygon(vectorTuples(vertices), referenceFrame, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return polygon, tracerr.Wrap(o.track(polygon))
+}
+
+// AddText draws text at a position.
+func (o *Overlay) AddText(text string, position types.Vector3D, rotation types.Quaternion, referenceFrame *spacecenter.ReferenceFrame) (*Text, error) {
+	if err := o.checkOpen(); err != nil {
+		return nil, err
+	}
+	t, err := o.drawing.AddText(text, referenceFrame, position.Tuple(), rotation.Tuple(), true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return t, tracerr.Wrap(o.track(t))
+}
+
+// Len returns the number of objects in the overlay.
+func (o *Overlay) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.objects)
+}
+
+// Remove removes an object from the overlay.
+func (o *Overlay) Remove(obj Drawable) error {
+	o.mu.Lock()
+	delete(o.objects, obj.ID_internal())
+	o.mu.Unlock()
+	return tracerr.Wrap(obj.Remove())
+}
+
+// Clear removes every object in the overlay. Objects drawn outside the
+// overlay are left alone.
+func (o *Overlay) Clear() error {
+	o.mu.Lock()
+	objects := o.objects
+	o.objects = make(map[uint64]Drawable)
+	o.mu.Unlock()
+
+	var errors []error
+	for _, obj := range objects {
+		if err := obj.Remove(); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 {
+		return tracerr.Errorf("Failed to remove %v object(s): %v", len(errors), errors)
+	}
+	return nil
+}
+
+// Close removes every object in the overlay. The overlay can't be used after
+// it has been closed.
+func (o *Overlay) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	o.mu.Unlock()
+	return tracerr.Wrap(o.Clear())
+}
+
+// Update applies a batch of changes to objects in the overlay in a single
+// round trip, which keeps per-frame updates of many objects cheap.
+func (o *Overlay) Update(f func(b *Batch)) error {
+	if err := o.checkOpen(); err != nil {
+		return err
+	}
+	b := &Batch{}
+	f(b)
+	if b.err != nil {
+		return tracerr.Wrap(b.err)
+	}
+	if len(b.calls) == 0 {
+		return nil
+	}
+	results, err := o.client.CallMultiple(b.calls)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return tracerr.Wrap(result.Error)
+		}
+	}
+	return nil
+}
+
+// Batch collects changes to drawn objects. See [Overlay.Update].
+type Batch struct {
+	calls []*types.ProcedureCall
+	err   error
+}
+
+// set queues a call to a property setter.
+func (b *Batch) set(className string, obj service.Class, property string, value interface{}) {
+	if b.err != nil {
+		return
+	}
+	call := &types.ProcedureCall{
+		Procedure: className + "_set_" + property,
+		Service:   "Drawing",
+	}
+	for i, arg := range []interface{}{obj, value} {
+		argBytes, err := encode.Marshal(arg)
+		if err != nil {
+			b.err = err
+			return
+		}
+		call.Arguments = append(call.Arguments, &types.Argument{
+			Position: uint32(i),
+			Value:    argBytes,
+		})
+	}
+	b.calls = append(b.calls, call)
+}
+
+// className returns the class of a drawn object.
+func (b *Batch) className(obj Drawable) string {
+	switch obj.(type) {
+	case *Line:
+		return "Line"
+	case *Polygon:
+		return "Polygon"
+	case *Text:
+		return "Text"
+	}
+	if b.err == nil {
+		b.err = tracerr.Errorf("Unknown drawable %T", obj)
+	}
+	return ""
+}
+
+// SetLine moves both ends of a line.
+func (b *Batch) SetLine(line *Line, start, end types.Vector3D) {
+	b.set("Line", line, "Start", start.Tuple())
+	b.set("Line", line, "End", end.Tuple())
+}
+
+// SetVertices sets the vertices of a polygon.
+func (b *Batch) SetVertices(polygon *Polygon, vertices []types.Vector3D) {
+	b.set("Polygon", polygon, "Vertices", vectorTuples(vertices))
+}
+
+// SetContent sets the content of a text object.
+func (b *Batch) SetContent(text *Text, content string) {
+	b.set("Text", text, "Content", content)
+}
+
+// SetPosition moves a text object.
+func (b *Batch) SetPosition(text *Text, position types.Vector3D) {
+	b.set("Text", text, "Position", position.Tuple())
+}
+
+// SetColor sets the color of an object.
+func (b *Batch) SetColor(obj Drawable, color types.Color[float64]) {
+	if name := b.className(obj); name != "" {
+		b.set(name, obj, "Color", color.Tuple())
+	}
+}
+
+// SetVisible sets whether an object is visible.
+func (b *Batch) SetVisible(obj Drawable, visible bool) {
+	if name := b.className(obj); name != "" {
+		b.set(name, obj, "Visible", visible)
+	}
+}
+
+func vectorTuples(vectors []types.Vector3D) []types.Tuple3[float64, float64, float64] {
+	tuples := make([]types.Tuple3[float64, float64, float64], len(vectors))
+	for i, v := range vectors {
+		tuples[i] = v.Tuple()
+	}
+	return tuples
+}
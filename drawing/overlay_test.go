@@ -0,0 +1,47 @@
+package drawing
+
+import (
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	line := NewLine(1, nil)
+	text := NewText(2, nil)
+
+	b := &Batch{}
+	b.SetLine(line, types.NewVector3D(0, 0, 0), types.NewVector3D(1, 2, 3))
+	b.SetContent(text, "hello")
+	b.SetVisible(text, false)
+	require.NoError(t, b.err)
+
+	var procedures []string
+	for _, call := range b.calls {
+		require.Equal(t, "Drawing", call.Service)
+		require.Len(t, call.Arguments, 2)
+		procedures = append(procedures, call.Procedure)
+	}
+	require.Equal(t, []string{"Line_set_Start", "Line_set_End", "Text_set_Content", "Text_set_Visible"}, procedures)
+
+	var end types.Tuple3[float64, float64, float64]
+	require.NoError(t, encode.Unmarshal(b.calls[1].Arguments[1].Value, &end))
+	require.Equal(t, types.NewVector3D(1, 2, 3), types.Vector3DFromTuple(end))
+}
+
+func TestOverlayClose(t *testing.T) {
+	client := krpcgo.DefaultKRPCClient()
+	o := NewOverlay(client)
+
+	// Nothing is sent for an empty batch.
+	require.NoError(t, o.Update(func(b *Batch) {}))
+
+	require.NoError(t, o.Close())
+	require.NoError(t, o.Close())
+	require.Error(t, o.Update(func(b *Batch) {}))
+	_, err := o.AddLine(types.Vector3D{}, types.Vector3D{}, nil)
+	require.Error(t, err)
+}
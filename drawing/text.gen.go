@@ -0,0 +1,1512 @@
+package drawing
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	ui "github.com/atburke/krpc-go/ui"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Text - text. Created using <see cref="M:Drawing.AddText" />.
+type Text struct {
+	service.BaseClass
+}
+
+// NewText creates a new Text.
+func NewText(id uint64, client *krpcgo.KRPCClient) *Text {
+	c := &Text{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// AvailableFonts - a list of all available fonts.
+//
+// Allowed game scenes: any.
+func (s *Text) AvailableFonts() ([]string, error) {
+	var err error
+	var vv []string
+	request := &types.ProcedureCall{
+		Procedure: "Text_static_AvailableFonts",
+		Service:   "Drawing",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AvailableFontsStream - a list of all available fonts.
+//
+// Allowed game scenes: any.
+func (s *Text) AvailableFontsStream() (*krpcgo.Stream[[]string], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "Text_static_AvailableFonts",
+		Service:   "Drawing",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []string {
+		var value []string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Remove - remove the object.
+//
+// Allowed game scenes: any.
+func (s *Text) Remove() error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_Remove",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// Position - position of the text.
+//
+// Allowed game scenes: any.
+func (s *Text) Position() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Position",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// PositionStream - position of the text.
+//
+// Allowed game scenes: any.
+func (s *Text) PositionStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Position",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetPosition - position of the text.
+//
+// Allowed game scenes: any.
+func (s *Text) SetPosition(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Position",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// PositionProp - returns a handle to the Position property.
+func (s *Text) PositionProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Position, s.SetPosition, s.PositionStream)
+}
+
+// Rotation - rotation of the text as a quaternion.
+//
+// Allowed game scenes: any.
+func (s *Text) Rotation() (types.Tuple4[float64, float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple4[float64, float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Rotation",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// RotationStream - rotation of the text as a quaternion.
+//
+// Allowed game scenes: any.
+func (s *Text) RotationStream() (*krpcgo.Stream[types.Tuple4[float64, float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Rotation",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple4[float64, float64, float64, float64] {
+		var value types.Tuple4[float64, float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetRotation - rotation of the text as a quaternion.
+//
+// Allowed game scenes: any.
+func (s *Text) SetRotation(value types.Tuple4[float64, float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Rotation",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// RotationProp - returns a handle to the Rotation property.
+func (s *Text) RotationProp() *krpcgo.Property[types.Tuple4[float64, float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Rotation, s.SetRotation, s.RotationStream)
+}
+
+// Content - the text string
+//
+// Allowed game scenes: any.
+func (s *Text) Content() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Content",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ContentStream - the text string
+//
+// Allowed game scenes: any.
+func (s *Text) ContentStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Content",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetContent - the text string
+//
+// Allowed game scenes: any.
+func (s *Text) SetContent(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Content",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ContentProp - returns a handle to the Content property.
+func (s *Text) ContentProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Content, s.SetContent, s.ContentStream)
+}
+
+// Font - name of the font
+//
+// Allowed game scenes: any.
+func (s *Text) Font() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Font",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// FontStream - name of the font
+//
+// Allowed game scenes: any.
+func (s *Text) FontStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Font",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetFont - name of the font
+//
+// Allowed game scenes: any.
+func (s *Text) SetFont(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Font",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// FontProp - returns a handle to the Font property.
+func (s *Text) FontProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Font, s.SetFont, s.FontStream)
+}
+
+// Size - font size.
+//
+// Allowed game scenes: any.
+func (s *Text) Size() (int32, error) {
+	var err error
+	var argBytes []byte
+	var vv int32
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Size",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// SizeStream - font size.
+//
+// Allowed game scenes: any.
+func (s *Text) SizeStream() (*krpcgo.Stream[int32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Size",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) int32 {
+		var value int32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetSize - font size.
+//
+// Allowed game scenes: any.
+func (s *Text) SetSize(value int32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Size",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// SizeProp - returns a handle to the Size property.
+func (s *Text) SizeProp() *krpcgo.Property[int32] {
+	return krpcgo.NewProperty(s.Size, s.SetSize, s.SizeStream)
+}
+
+// CharacterSize - character size.
+//
+// Allowed game scenes: any.
+func (s *Text) CharacterSize() (float32, error) {
+	var err error
+	var argBytes []byte
+	var vv float32
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_CharacterSize",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// CharacterSizeStream - character size.
+//
+// Allowed game scenes: any.
+func (s *Text) CharacterSizeStream() (*krpcgo.Stream[float32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_CharacterSize",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float32 {
+		var value float32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetCharacterSize - character size.
+//
+// Allowed game scenes: any.
+func (s *Text) SetCharacterSize(value float32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_CharacterSize",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// CharacterSizeProp - returns a handle to the CharacterSize property.
+func (s *Text) CharacterSizeProp() *krpcgo.Property[float32] {
+	return krpcgo.NewProperty(s.CharacterSize, s.SetCharacterSize, s.CharacterSizeStream)
+}
+
+// Style - font style.
+//
+// Allowed game scenes: any.
+func (s *Text) Style() (ui.FontStyle, error) {
+	var err error
+	var argBytes []byte
+	var vv ui.FontStyle
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Style",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// StyleStream - font style.
+//
+// Allowed game scenes: any.
+func (s *Text) StyleStream() (*krpcgo.Stream[ui.FontStyle], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Style",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) ui.FontStyle {
+		var value ui.FontStyle
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetStyle - font style.
+//
+// Allowed game scenes: any.
+func (s *Text) SetStyle(value ui.FontStyle) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Style",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// StyleProp - returns a handle to the Style property.
+func (s *Text) StyleProp() *krpcgo.Property[ui.FontStyle] {
+	return krpcgo.NewProperty(s.Style, s.SetStyle, s.StyleStream)
+}
+
+// Alignment - alignment.
+//
+// Allowed game scenes: any.
+func (s *Text) Alignment() (ui.TextAlignment, error) {
+	var err error
+	var argBytes []byte
+	var vv ui.TextAlignment
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Alignment",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AlignmentStream - alignment.
+//
+// Allowed game scenes: any.
+func (s *Text) AlignmentStream() (*krpcgo.Stream[ui.TextAlignment], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Alignment",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) ui.TextAlignment {
+		var value ui.TextAlignment
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetAlignment - alignment.
+//
+// Allowed game scenes: any.
+func (s *Text) SetAlignment(value ui.TextAlignment) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Alignment",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// AlignmentProp - returns a handle to the Alignment property.
+func (s *Text) AlignmentProp() *krpcgo.Property[ui.TextAlignment] {
+	return krpcgo.NewProperty(s.Alignment, s.SetAlignment, s.AlignmentStream)
+}
+
+// LineSpacing - line spacing.
+//
+// Allowed game scenes: any.
+func (s *Text) LineSpacing() (float32, error) {
+	var err error
+	var argBytes []byte
+	var vv float32
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_LineSpacing",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// LineSpacingStream - line spacing.
+//
+// Allowed game scenes: any.
+func (s *Text) LineSpacingStream() (*krpcgo.Stream[float32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_LineSpacing",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float32 {
+		var value float32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetLineSpacing - line spacing.
+//
+// Allowed game scenes: any.
+func (s *Text) SetLineSpacing(value float32) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_LineSpacing",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// LineSpacingProp - returns a handle to the LineSpacing property.
+func (s *Text) LineSpacingProp() *krpcgo.Property[float32] {
+	return krpcgo.NewProperty(s.LineSpacing, s.SetLineSpacing, s.LineSpacingStream)
+}
+
+// Anchor - anchor.
+//
+// Allowed game scenes: any.
+func (s *Text) Anchor() (ui.TextAnchor, error) {
+	var err error
+	var argBytes []byte
+	var vv ui.TextAnchor
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Anchor",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AnchorStream - anchor.
+//
+// Allowed game scenes: any.
+func (s *Text) AnchorStream() (*krpcgo.Stream[ui.TextAnchor], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Anchor",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) ui.TextAnchor {
+		var value ui.TextAnchor
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetAnchor - anchor.
+//
+// Allowed game scenes: any.
+func (s *Text) SetAnchor(value ui.TextAnchor) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Anchor",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// AnchorProp - returns a handle to the Anchor property.
+func (s *Text) AnchorProp() *krpcgo.Property[ui.TextAnchor] {
+	return krpcgo.NewProperty(s.Anchor, s.SetAnchor, s.AnchorStream)
+}
+
+// Color - set the color
+//
+// Allowed game scenes: any.
+func (s *Text) Color() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ColorStream - set the color
+//
+// Allowed game scenes: any.
+func (s *Text) ColorStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetColor - set the color
+//
+// Allowed game scenes: any.
+func (s *Text) SetColor(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Color",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ColorProp - returns a handle to the Color property.
+func (s *Text) ColorProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Color, s.SetColor, s.ColorStream)
+}
+
+// ReferenceFrame - reference frame for the positions of the object.
+//
+// Allowed game scenes: any.
+func (s *Text) ReferenceFrame() (*spacecenter.ReferenceFrame, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.ReferenceFrame
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_ReferenceFrame",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetReferenceFrame - reference frame for the positions of the object.
+//
+// Allowed game scenes: any.
+func (s *Text) SetReferenceFrame(value *spacecenter.ReferenceFrame) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_ReferenceFrame",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ReferenceFrameProp - returns a handle to the ReferenceFrame property.
+func (s *Text) ReferenceFrameProp() *krpcgo.Property[*spacecenter.ReferenceFrame] {
+	return krpcgo.NewProperty(s.ReferenceFrame, s.SetReferenceFrame, nil)
+}
+
+// Visible - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Text) Visible() (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// VisibleStream - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Text) VisibleStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetVisible - whether the object is visible.
+//
+// Allowed game scenes: any.
+func (s *Text) SetVisible(value bool) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Visible",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// VisibleProp - returns a handle to the Visible property.
+func (s *Text) VisibleProp() *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(s.Visible, s.SetVisible, s.VisibleStream)
+}
+
+// Material - material used to render the object. Creates the material from a
+// shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Text) Material() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// MaterialStream - material used to render the object. Creates the material
+// from a shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Text) MaterialStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_get_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetMaterial - material used to render the object. Creates the material from a
+// shader with the given name.
+//
+// Allowed game scenes: any.
+func (s *Text) SetMaterial(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Text_set_Material",
+		Service:   "Drawing",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// MaterialProp - returns a handle to the Material property.
+func (s *Text) MaterialProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Material, s.SetMaterial, s.MaterialStream)
+}
@@ -0,0 +1,381 @@
+// Package expr builds typed kRPC server-side expressions, so that checks
+// over collections, such as "is there less than 100 liquid fuel left in the
+// parts tagged X", are evaluated by the server every frame rather than with
+// a call per part:
+//
+//	b := expr.NewBuilder(client)
+//	amounts := expr.ListOf(b, expr.Call[float64](b, amountCalls...)...)
+//	low := expr.LessThan(b, expr.Sum(b, amounts), expr.Const(b, 100.0))
+//	err := b.WaitUntil(ctx, low)
+//
+// Building an expression takes a call for each of its parts, but only once;
+// the first error is kept by the builder and returned by Err, Event or
+// WaitUntil, so expressions can be built without checking every step. kRPC
+// can only evaluate an expression as the condition of an event, so
+// expressions are built up into a bool and waited on.
+//
+// Simple conditions on the values of the generated API's getters can also be
+// declared as Predicates and compiled, rather than built up step by step.
+//
+// Functions, as taken by Where and Select, can only have parameters of the
+// basic types (float64, float32, int32, bool and string), so collections of
+// objects can only be counted, not filtered or mapped.
+package expr
+
+import (
+	"context"
+	"fmt"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Basic are the types that constants and function parameters can have.
+type Basic interface {
+	float64 | float32 | int32 | bool | string
+}
+
+// Numeric are the types that can be added and compared.
+type Numeric interface {
+	float64 | float32 | int32
+}
+
+// Expr is a server-side expression with a value of type T.
+type Expr[T any] struct {
+	x *krpc.Expression
+}
+
+// Expression returns the underlying expression, which is nil if building
+// it failed.
+func (e Expr[T]) Expression() *krpc.Expression {
+	return e.x
+}
+
+// Builder builds expressions.
+type Builder struct {
+	client *krpcgo.KRPCClient
+	// statics calls the Expression and Type classes' static procedures.
+	statics *krpc.Expression
+	types   *krpc.Type
+	err     error
+	// params counts the function parameters made, to name them.
+	params int
+}
+
+// NewBuilder creates a new Builder.
+func NewBuilder(client *krpcgo.KRPCClient) *Builder {
+	return &Builder{
+		client:  client,
+		statics: krpc.NewExpression(0, client),
+		types:   krpc.NewType(0, client),
+	}
+}
+
+// Err returns the first error from building expressions, if any.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// build makes a part of an expression, unless an earlier part failed.
+func build[T any](b *Builder, f func() (*krpc.Expression, error)) Expr[T] {
+	if b.err != nil {
+		return Expr[T]{}
+	}
+	x, err := f()
+	if err != nil {
+		b.err = tracerr.Wrap(err)
+		return Expr[T]{}
+	}
+	return Expr[T]{x: x}
+}
+
+// expressions returns the underlying expressions.
+func expressions[T any](es []Expr[T]) []*krpc.Expression {
+	xs := make([]*krpc.Expression, len(es))
+	for i, e := range es {
+		xs[i] = e.x
+	}
+	return xs
+}
+
+// Const makes a constant.
+func Const[T Basic](b *Builder, v T) Expr[T] {
+	return build[T](b, func() (*krpc.Expression, error) {
+		switch v := any(v).(type) {
+		case float64:
+			return b.statics.ConstantDouble(v)
+		case float32:
+			return b.statics.ConstantFloat(v)
+		case int32:
+			return b.statics.ConstantInt(v)
+		case bool:
+			return b.statics.ConstantBool(v)
+		case string:
+			return b.statics.ConstantString(v)
+		}
+		return nil, tracerr.Errorf("Unsupported constant type %T", v)
+	})
+}
+
+// Call makes an expression for each of a number of procedure calls, whose
+// results must have type T.
+func Call[T any](b *Builder, calls ...*types.ProcedureCall) []Expr[T] {
+	es := make([]Expr[T], len(calls))
+	for i, call := range calls {
+		call := call
+		es[i] = build[T](b, func() (*krpc.Expression, error) {
+			return b.statics.Call(call)
+		})
+	}
+	return es
+}
+
+// ListOf makes a list of expressions.
+func ListOf[T any](b *Builder, elements ...Expr[T]) Expr[[]T] {
+	return build[[]T](b, func() (*krpc.Expression, error) {
+		return b.statics.CreateList(expressions(elements))
+	})
+}
+
+// Count counts the elements of a collection.
+func Count[T any](b *Builder, list Expr[[]T]) Expr[int32] {
+	return build[int32](b, func() (*krpc.Expression, error) {
+		return b.statics.Count(list.x)
+	})
+}
+
+// Sum adds up the elements of a collection.
+func Sum[T Numeric](b *Builder, list Expr[[]T]) Expr[T] {
+	return build[T](b, func() (*krpc.Expression, error) {
+		return b.statics.Sum(list.x)
+	})
+}
+
+// Min finds the smallest element of a collection.
+func Min[T Numeric](b *Builder, list Expr[[]T]) Expr[T] {
+	return build[T](b, func() (*krpc.Expression, error) {
+		return b.statics.Min(list.x)
+	})
+}
+
+// Max finds the largest element of a collection.
+func Max[T Numeric](b *Builder, list Expr[[]T]) Expr[T] {
+	return build[T](b, func() (*krpc.Expression, error) {
+		return b.statics.Max(list.x)
+	})
+}
+
+// Average finds the mean of the elements of a collection.
+func Average[T Numeric](b *Builder, list Expr[[]T]) Expr[float64] {
+	return build[float64](b, func() (*krpc.Expression, error) {
+		return b.statics.Average(list.x)
+	})
+}
+
+// Contains checks if a collection contains a value.
+func Contains[T any](b *Builder, list Expr[[]T], value Expr[T]) Expr[bool] {
+	return build[bool](b, func() (*krpc.Expression, error) {
+		return b.statics.Contains(list.x, value.x)
+	})
+}
+
+// parameterType returns the server-side type of T.
+func parameterType[T Basic](b *Builder) (*krpc.Type, error) {
+	var zero T
+	switch any(zero).(type) {
+	case float64:
+		return b.types.Double()
+	case float32:
+		return b.types.Float()
+	case int32:
+		return b.types.Int()
+	case bool:
+		return b.types.Bool()
+	case string:
+		return b.types.String()
+	}
+	return nil, tracerr.Errorf("Unsupported parameter type %T", zero)
+}
+
+// function makes a function of one parameter, whose body f builds.
+func function[T Basic, U any](b *Builder, f func(Expr[T]) Expr[U]) Expr[func(T) U] {
+	b.params++
+	name := fmt.Sprintf("x%d", b.params)
+	param := build[T](b, func() (*krpc.Expression, error) {
+		t, err := parameterType[T](b)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return b.statics.Parameter(name, t)
+	})
+	body := f(param)
+	return build[func(T) U](b, func() (*krpc.Expression, error) {
+		return b.statics.Function([]*krpc.Expression{param.x}, body.x)
+	})
+}
+
+// Where keeps the elements of a list that meet a condition.
+func Where[T Basic](b *Builder, list Expr[[]T], cond func(Expr[T]) Expr[bool]) Expr[[]T] {
+	f := function(b, cond)
+	return build[[]T](b, func() (*krpc.Expression, error) {
+		return b.statics.Where(list.x, f.x)
+	})
+}
+
+// Select maps each element of a list.
+func Select[T Basic, U any](b *Builder, list Expr[[]T], m func(Expr[T]) Expr[U]) Expr[[]U] {
+	f := function(b, m)
+	return build[[]U](b, func() (*krpc.Expression, error) {
+		return b.statics.Select(list.x, f.x)
+	})
+}
+
+// Any checks if any element of a list meets a condition.
+func Any[T Basic](b *Builder, list Expr[[]T], cond func(Expr[T]) Expr[bool]) Expr[bool] {
+	f := function(b, cond)
+	return build[bool](b, func() (*krpc.Expression, error) {
+		return b.statics.Any(list.x, f.x)
+	})
+}
+
+// All checks if every element of a list meets a condition.
+func All[T Basic](b *Builder, list Expr[[]T], cond func(Expr[T]) Expr[bool]) Expr[bool] {
+	f := function(b, cond)
+	return build[bool](b, func() (*krpc.Expression, error) {
+		return b.statics.All(list.x, f.x)
+	})
+}
+
+// binary makes an expression from two others with a static procedure.
+func binary[T, U any](b *Builder, op func(x, y *krpc.Expression) (*krpc.Expression, error), x, y Expr[T]) Expr[U] {
+	return build[U](b, func() (*krpc.Expression, error) {
+		return op(x.x, y.x)
+	})
+}
+
+// Add adds two numbers.
+func Add[T Numeric](b *Builder, x, y Expr[T]) Expr[T] {
+	return binary[T, T](b, b.statics.Add, x, y)
+}
+
+// Subtract subtracts y from x.
+func Subtract[T Numeric](b *Builder, x, y Expr[T]) Expr[T] {
+	return binary[T, T](b, b.statics.Subtract, x, y)
+}
+
+// Multiply multiplies two numbers.
+func Multiply[T Numeric](b *Builder, x, y Expr[T]) Expr[T] {
+	return binary[T, T](b, b.statics.Multiply, x, y)
+}
+
+// Divide divides x by y.
+func Divide[T Numeric](b *Builder, x, y Expr[T]) Expr[T] {
+	return binary[T, T](b, b.statics.Divide, x, y)
+}
+
+// Equal checks if two values are equal.
+func Equal[T Basic](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.Equal, x, y)
+}
+
+// NotEqual checks if two values are different.
+func NotEqual[T Basic](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.NotEqual, x, y)
+}
+
+// LessThan checks if x is less than y.
+func LessThan[T Numeric](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.LessThan, x, y)
+}
+
+// GreaterThan checks if x is greater than y.
+func GreaterThan[T Numeric](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.GreaterThan, x, y)
+}
+
+// LessThanOrEqual checks if x is at most y.
+func LessThanOrEqual[T Numeric](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.LessThanOrEqual, x, y)
+}
+
+// GreaterThanOrEqual checks if x is at least y.
+func GreaterThanOrEqual[T Numeric](b *Builder, x, y Expr[T]) Expr[bool] {
+	return binary[T, bool](b, b.statics.GreaterThanOrEqual, x, y)
+}
+
+// And checks if both conditions are met.
+func And(b *Builder, x, y Expr[bool]) Expr[bool] {
+	return binary[bool, bool](b, b.statics.And, x, y)
+}
+
+// Or checks if either condition is met.
+func Or(b *Builder, x, y Expr[bool]) Expr[bool] {
+	return binary[bool, bool](b, b.statics.Or, x, y)
+}
+
+// Not negates a condition.
+func Not(b *Builder, x Expr[bool]) Expr[bool] {
+	return build[bool](b, func() (*krpc.Expression, error) {
+		return b.statics.Not(x.x)
+	})
+}
+
+// Event makes an event that the server fires once a condition is met. The
+// stream sends true when it fires.
+func (b *Builder) Event(cond Expr[bool]) (*krpcgo.Stream[bool], error) {
+	if b.err != nil {
+		return nil, tracerr.Wrap(b.err)
+	}
+	if !b.client.StreamsAvailable() {
+		return nil, tracerr.Errorf("Events need a stream connection")
+	}
+	// The generated AddEvent drops the event, so it is called directly.
+	arg, err := encode.Marshal(cond.x)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	result, err := b.client.Call(&types.ProcedureCall{
+		Service:   "KRPC",
+		Procedure: "AddEvent",
+		Arguments: []*types.Argument{{Position: 0, Value: arg}},
+	})
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var event types.Event
+	if err := encode.Unmarshal(result.Value, &event); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if event.Stream == nil {
+		return nil, tracerr.Errorf("The server returned an event without a stream")
+	}
+	k := krpc.New(b.client)
+	id := event.Stream.Id
+	stream := krpcgo.MapStream(b.client.GetStream(id), func(b []byte) bool {
+		var fired bool
+		encode.Unmarshal(b, &fired)
+		return fired
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(k.RemoveStream(id))
+	})
+	if err := k.StartStream(id); err != nil {
+		_ = stream.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	return stream, nil
+}
+
+// WaitUntil waits until a condition is met.
+func (b *Builder) WaitUntil(ctx context.Context, cond Expr[bool]) error {
+	stream, err := b.Event(cond)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer stream.Close()
+	_, err = krpcgo.WaitFor(ctx, stream, func(fired bool) bool { return fired })
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,133 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// expressionServer answers the Expression and Type static procedures with
+// new object IDs.
+func expressionServer() *krpctest.Server {
+	server := krpctest.NewServer()
+	var next uint64
+	newID := func(*krpctest.Call) (interface{}, error) {
+		next++
+		return next, nil
+	}
+	for _, procedure := range []string{
+		"Expression_static_ConstantDouble",
+		"Expression_static_Call",
+		"Expression_static_CreateList",
+		"Expression_static_Sum",
+		"Expression_static_Count",
+		"Expression_static_Parameter",
+		"Expression_static_Function",
+		"Expression_static_Where",
+		"Expression_static_LessThan",
+		"Expression_static_GreaterThan",
+		"Expression_static_And",
+		"Type_static_Double",
+	} {
+		server.Handle("KRPC", procedure, newID)
+	}
+	return server
+}
+
+// procedures lists the procedures called on the server.
+func procedures(server *krpctest.Server) []string {
+	var names []string
+	for _, call := range server.Calls() {
+		names = append(names, call.Procedure)
+	}
+	return names
+}
+
+func TestBuild(t *testing.T) {
+	server := expressionServer()
+	defer server.Close()
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	b := NewBuilder(client)
+	amounts := ListOf(b, Call[float64](b,
+		&types.ProcedureCall{Service: "SpaceCenter", Procedure: "Resources_Amount"},
+		&types.ProcedureCall{Service: "SpaceCenter", Procedure: "Resources_Amount"},
+	)...)
+	full := Where(b, amounts, func(x Expr[float64]) Expr[bool] {
+		return GreaterThan(b, x, Const(b, 10.0))
+	})
+	cond := And(b,
+		LessThan(b, Sum(b, amounts), Const(b, 100.0)),
+		LessThan(b, Count(b, full), Const(b, int32(2))),
+	)
+	// There is no handler for ConstantInt.
+	require.Nil(t, cond.Expression())
+	require.Error(t, b.Err())
+
+	require.Equal(t, []string{
+		"Expression_static_Call",
+		"Expression_static_Call",
+		"Expression_static_CreateList",
+		"Type_static_Double",
+		"Expression_static_Parameter",
+		"Expression_static_ConstantDouble",
+		"Expression_static_GreaterThan",
+		"Expression_static_Function",
+		"Expression_static_Where",
+		"Expression_static_Sum",
+		"Expression_static_ConstantDouble",
+		"Expression_static_LessThan",
+		"Expression_static_Count",
+		"Expression_static_ConstantInt",
+	}, procedures(server))
+
+	// Nothing more is built after an error.
+	Const(b, 1.0)
+	require.Len(t, server.Calls(), 14)
+	_, err = b.Event(cond)
+	require.Error(t, err)
+}
+
+func TestWaitUntil(t *testing.T) {
+	server := expressionServer()
+	defer server.Close()
+	server.Return("Test", "Fired", false)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The test server can't evaluate expressions, so a stream of another
+	// call stands in for the event's.
+	st, err := krpc.New(client).AddStream(&types.ProcedureCall{Service: "Test", Procedure: "Fired"}, false)
+	require.NoError(t, err)
+	server.Handle("KRPC", "AddEvent", func(call *krpctest.Call) (interface{}, error) {
+		var x krpc.Expression
+		if err := call.Arg(0, &x); err != nil {
+			return nil, err
+		}
+		if x.ID_internal() != 3 {
+			return nil, errors.New("wrong expression")
+		}
+		return &types.Event{Stream: &types.Stream{Id: st.Id}}, nil
+	})
+
+	b := NewBuilder(client)
+	cond := GreaterThan(b, Const(b, 1.0), Const(b, 0.0))
+	require.NoError(t, b.Err())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		server.Feed("Test", "Fired", true)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, b.WaitUntil(ctx, cond))
+}
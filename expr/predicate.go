@@ -0,0 +1,193 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Getter is a call to a procedure, usually a property getter, whose result
+// has type T. Getters describe values without fetching them, so that they
+// can be used in predicates.
+type Getter[T any] struct {
+	call *types.ProcedureCall
+	err  error
+}
+
+// Get makes a Getter for a procedure of the generated API, with its
+// arguments, e.g. for a Flight's mean altitude:
+//
+//	altitude := expr.Get[float64]("SpaceCenter", "Flight_get_MeanAltitude", flight)
+//
+// The result type must match the procedure's: float64 for doubles, float32
+// for floats and int32 for ints.
+func Get[T any](service, procedure string, args ...interface{}) Getter[T] {
+	call := &types.ProcedureCall{Service: service, Procedure: procedure}
+	for i, arg := range args {
+		argBytes, err := encode.Marshal(arg)
+		if err != nil {
+			return Getter[T]{err: tracerr.Wrap(err)}
+		}
+		call.Arguments = append(call.Arguments, &types.Argument{Position: uint32(i), Value: argBytes})
+	}
+	return Getter[T]{call: call}
+}
+
+// Call returns the getter's procedure call.
+func (g Getter[T]) Call() (*types.ProcedureCall, error) {
+	return g.call, g.err
+}
+
+// String returns the getter's procedure name.
+func (g Getter[T]) String() string {
+	if g.call == nil {
+		return "?"
+	}
+	return g.call.Service + "." + g.call.Procedure
+}
+
+// Value makes an expression for the value of a getter.
+func Value[T any](b *Builder, g Getter[T]) Expr[T] {
+	return build[T](b, func() (*krpc.Expression, error) {
+		if g.err != nil {
+			return nil, g.err
+		}
+		return b.statics.Call(g.call)
+	})
+}
+
+// Predicate is a condition on the values of getters, declared in Go and
+// compiled to an expression, so that the server checks it every frame:
+//
+//	b := expr.NewBuilder(client)
+//	high := expr.AnyOf(
+//		expr.Above(altitude, 70000.0),
+//		expr.Is(situation, int32(spacecenter.VesselSituation_Orbiting)),
+//	)
+//	err := b.WaitUntil(ctx, b.Compile(high))
+//
+// Enums are compared as the int32 the server sends.
+type Predicate struct {
+	compile func(b *Builder) Expr[bool]
+	desc    string
+}
+
+// String describes the predicate.
+func (p Predicate) String() string {
+	return p.desc
+}
+
+// Compile builds the predicate's expression.
+func (b *Builder) Compile(p Predicate) Expr[bool] {
+	return p.compile(b)
+}
+
+// compare makes a predicate comparing a getter with a constant.
+func compare[T Basic](g Getter[T], op string, v T, cmp func(b *Builder, x, y Expr[T]) Expr[bool]) Predicate {
+	return Predicate{
+		compile: func(b *Builder) Expr[bool] {
+			return cmp(b, Value(b, g), Const(b, v))
+		},
+		desc: fmt.Sprintf("%v %v %v", g, op, v),
+	}
+}
+
+// Above checks if a getter's value is greater than v.
+func Above[T Numeric](g Getter[T], v T) Predicate {
+	return compare(g, ">", v, GreaterThan[T])
+}
+
+// Below checks if a getter's value is less than v.
+func Below[T Numeric](g Getter[T], v T) Predicate {
+	return compare(g, "<", v, LessThan[T])
+}
+
+// AtLeast checks if a getter's value is at least v.
+func AtLeast[T Numeric](g Getter[T], v T) Predicate {
+	return compare(g, ">=", v, GreaterThanOrEqual[T])
+}
+
+// AtMost checks if a getter's value is at most v.
+func AtMost[T Numeric](g Getter[T], v T) Predicate {
+	return compare(g, "<=", v, LessThanOrEqual[T])
+}
+
+// Between checks if a getter's value is from lo to hi, inclusive.
+func Between[T Numeric](g Getter[T], lo, hi T) Predicate {
+	return AllOf(AtLeast(g, lo), AtMost(g, hi))
+}
+
+// Is checks if a getter's value is v.
+func Is[T Basic](g Getter[T], v T) Predicate {
+	return compare(g, "==", v, Equal[T])
+}
+
+// IsNot checks if a getter's value isn't v.
+func IsNot[T Basic](g Getter[T], v T) Predicate {
+	return compare(g, "!=", v, NotEqual[T])
+}
+
+// True checks if a boolean getter's value is true.
+func True(g Getter[bool]) Predicate {
+	return Predicate{
+		compile: func(b *Builder) Expr[bool] {
+			return Value(b, g)
+		},
+		desc: g.String(),
+	}
+}
+
+// describe joins the descriptions of predicates.
+func describe(ps []Predicate, sep string) string {
+	descs := make([]string, len(ps))
+	for i, p := range ps {
+		descs[i] = p.desc
+	}
+	return "(" + strings.Join(descs, sep) + ")"
+}
+
+// combine makes a predicate that folds others together, or is always v if
+// there are none.
+func combine(ps []Predicate, sep string, v bool, op func(b *Builder, x, y Expr[bool]) Expr[bool]) Predicate {
+	return Predicate{
+		compile: func(b *Builder) Expr[bool] {
+			if len(ps) == 0 {
+				return Const(b, v)
+			}
+			x := ps[0].compile(b)
+			for _, p := range ps[1:] {
+				x = op(b, x, p.compile(b))
+			}
+			return x
+		},
+		desc: describe(ps, sep),
+	}
+}
+
+// AllOf checks if every predicate holds. It holds if there are none.
+func AllOf(ps ...Predicate) Predicate {
+	return combine(ps, " && ", true, And)
+}
+
+// AnyOf checks if any predicate holds. It doesn't hold if there are none.
+func AnyOf(ps ...Predicate) Predicate {
+	return combine(ps, " || ", false, Or)
+}
+
+// Negate checks if a predicate doesn't hold.
+func Negate(p Predicate) Predicate {
+	desc := p.desc
+	if !strings.HasPrefix(desc, "(") {
+		desc = "(" + desc + ")"
+	}
+	return Predicate{
+		compile: func(b *Builder) Expr[bool] {
+			return Not(b, p.compile(b))
+		},
+		desc: "!" + desc,
+	}
+}
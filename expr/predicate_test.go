@@ -0,0 +1,96 @@
+package expr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	g := Get[float64]("SpaceCenter", "Flight_get_MeanAltitude", uint64(5))
+	call, err := g.Call()
+	require.NoError(t, err)
+	require.Equal(t, "SpaceCenter.Flight_get_MeanAltitude", g.String())
+	require.Len(t, call.Arguments, 1)
+	c := krpctest.Call{Args: [][]byte{call.Arguments[0].Value}}
+	var id uint64
+	require.NoError(t, c.Arg(0, &id))
+	require.Equal(t, uint64(5), id)
+
+	_, err = Get[float64]("SpaceCenter", "Flight_get_MeanAltitude", make(chan int)).Call()
+	require.Error(t, err)
+}
+
+func TestPredicateString(t *testing.T) {
+	altitude := Get[float64]("SpaceCenter", "Flight_get_MeanAltitude")
+	situation := Get[int32]("SpaceCenter", "Vessel_get_Situation")
+	p := AnyOf(
+		Between(altitude, 100.0, 200.0),
+		Negate(Is(situation, 3)),
+	)
+	require.Equal(t, "((SpaceCenter.Flight_get_MeanAltitude >= 100 && SpaceCenter.Flight_get_MeanAltitude <= 200) || !(SpaceCenter.Vessel_get_Situation == 3))", p.String())
+}
+
+func TestCompile(t *testing.T) {
+	server := expressionServer()
+	defer server.Close()
+	var next uint64 = 100
+	for _, procedure := range []string{
+		"Expression_static_GreaterThanOrEqual",
+		"Expression_static_LessThanOrEqual",
+		"Expression_static_ConstantBool",
+		"Expression_static_ConstantInt",
+		"Expression_static_Equal",
+		"Expression_static_Not",
+		"Expression_static_Or",
+	} {
+		server.Handle("KRPC", procedure, func(*krpctest.Call) (interface{}, error) {
+			next++
+			return next, nil
+		})
+	}
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	b := NewBuilder(client)
+	altitude := Get[float64]("SpaceCenter", "Flight_get_MeanAltitude", uint64(5))
+	cond := b.Compile(AnyOf(
+		Between(altitude, 100.0, 200.0),
+		Negate(Is(Get[int32]("SpaceCenter", "Vessel_get_Situation"), 3)),
+	))
+	require.NoError(t, b.Err())
+	require.NotNil(t, cond.Expression())
+	require.Equal(t, []string{
+		"Expression_static_Call",
+		"Expression_static_ConstantDouble",
+		"Expression_static_GreaterThanOrEqual",
+		"Expression_static_Call",
+		"Expression_static_ConstantDouble",
+		"Expression_static_LessThanOrEqual",
+		"Expression_static_And",
+		"Expression_static_Call",
+		"Expression_static_ConstantInt",
+		"Expression_static_Equal",
+		"Expression_static_Not",
+		"Expression_static_Or",
+	}, procedures(server))
+
+	// The getter's call is passed to the server as is.
+	var call types.ProcedureCall
+	calls := server.Calls()
+	require.NoError(t, calls[0].Arg(0, &call))
+	require.Equal(t, "Flight_get_MeanAltitude", call.Procedure)
+
+	empty := b.Compile(AllOf())
+	require.NoError(t, b.Err())
+	require.NotNil(t, empty.Expression())
+	require.Equal(t, "Expression_static_ConstantBool", procedures(server)[12])
+
+	// Errors making getters are kept by the builder.
+	b.Compile(True(Get[bool]("SpaceCenter", "Vessel_get_Recoverable", make(chan int))))
+	require.Error(t, b.Err())
+}
@@ -0,0 +1,1318 @@
+// Package far provides methods to invoke procedures in the FAR service.
+//
+// From service docs: provides access to the aerodynamic model of Ferram
+// Aerospace Research.
+package far
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// FAR - provides access to the aerodynamic model of Ferram Aerospace Research.
+type FAR struct {
+	Client *krpcgo.KRPCClient
+}
+
+// New creates a new FAR.
+func New(client *krpcgo.KRPCClient) *FAR {
+	return &FAR{Client: client}
+}
+
+// Available checks if the FAR service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "FAR")
+}
+
+// Available - whether Ferram Aerospace Research is installed.
+//
+// Allowed game scenes: any.
+func (s *FAR) Available() (bool, error) {
+	var err error
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "FAR",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AvailableStream - whether Ferram Aerospace Research is installed.
+//
+// Allowed game scenes: any.
+func (s *FAR) AvailableStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "FAR",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// MachNumber - the Mach number of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) MachNumber(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "MachNumber",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// MachNumberStream - the Mach number of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) MachNumberStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "MachNumber",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// IndicatedAirspeed - the indicated airspeed of a vessel, in meters per second.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) IndicatedAirspeed(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "IndicatedAirspeed",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// IndicatedAirspeedStream - the indicated airspeed of a vessel, in meters per
+// second.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) IndicatedAirspeedStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "IndicatedAirspeed",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// DynamicPressure - the dynamic pressure acting on a vessel, in Pascals.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) DynamicPressure(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "DynamicPressure",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// DynamicPressureStream - the dynamic pressure acting on a vessel, in Pascals.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) DynamicPressureStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "DynamicPressure",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// AngleOfAttack - the angle of attack of a vessel, in degrees.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AngleOfAttack(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "AngleOfAttack",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AngleOfAttackStream - the angle of attack of a vessel, in degrees.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AngleOfAttackStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "AngleOfAttack",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Sideslip - the sideslip angle of a vessel, in degrees.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) Sideslip(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Sideslip",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// SideslipStream - the sideslip angle of a vessel, in degrees.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SideslipStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Sideslip",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// StallFraction - the fraction of a vessel's lifting surfaces that are stalled,
+// between 0 and 1.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) StallFraction(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "StallFraction",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// StallFractionStream - the fraction of a vessel's lifting surfaces that are
+// stalled, between 0 and 1.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) StallFractionStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "StallFraction",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// LiftCoefficient - the lift coefficient of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) LiftCoefficient(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "LiftCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// LiftCoefficientStream - the lift coefficient of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) LiftCoefficientStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "LiftCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// DragCoefficient - the drag coefficient of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) DragCoefficient(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "DragCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// DragCoefficientStream - the drag coefficient of a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) DragCoefficientStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "DragCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// ReferenceArea - the reference area used for a vessel's aerodynamic
+// coefficients, in square meters.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) ReferenceArea(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "ReferenceArea",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ReferenceAreaStream - the reference area used for a vessel's aerodynamic
+// coefficients, in square meters.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) ReferenceAreaStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "ReferenceArea",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// BallisticCoefficient - the ballistic coefficient of a vessel, in kilograms
+// per square meter.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) BallisticCoefficient(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "BallisticCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// BallisticCoefficientStream - the ballistic coefficient of a vessel, in
+// kilograms per square meter.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) BallisticCoefficientStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "BallisticCoefficient",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// TerminalVelocity - an estimate of the terminal velocity of a vessel, in
+// meters per second.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) TerminalVelocity(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "TerminalVelocity",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TerminalVelocityStream - an estimate of the terminal velocity of a vessel, in
+// meters per second.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) TerminalVelocityStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "TerminalVelocity",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SpecificExcessPower - the specific excess power of a vessel, in watts per
+// kilogram.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SpecificExcessPower(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "SpecificExcessPower",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// SpecificExcessPowerStream - the specific excess power of a vessel, in watts
+// per kilogram.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SpecificExcessPowerStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "SpecificExcessPower",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// ThrustSpecificFuelConsumption - the thrust specific fuel consumption of a
+// vessel's engines.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) ThrustSpecificFuelConsumption(vessel *spacecenter.Vessel) (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "ThrustSpecificFuelConsumption",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ThrustSpecificFuelConsumptionStream - the thrust specific fuel consumption of
+// a vessel's engines.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) ThrustSpecificFuelConsumptionStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "ThrustSpecificFuelConsumption",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// AerodynamicForce - the total aerodynamic force acting on a vessel, in
+// Newtons, in the given reference frame.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AerodynamicForce(vessel *spacecenter.Vessel, referenceFrame *spacecenter.ReferenceFrame) (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "AerodynamicForce",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(referenceFrame)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AerodynamicForceStream - the total aerodynamic force acting on a vessel, in
+// Newtons, in the given reference frame.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AerodynamicForceStream(vessel *spacecenter.Vessel, referenceFrame *spacecenter.ReferenceFrame) (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "AerodynamicForce",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// AerodynamicTorque - the total aerodynamic torque acting on a vessel, in
+// Newton meters, in the given reference frame.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AerodynamicTorque(vessel *spacecenter.Vessel, referenceFrame *spacecenter.ReferenceFrame) (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "AerodynamicTorque",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(referenceFrame)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AerodynamicTorqueStream - the total aerodynamic torque acting on a vessel, in
+// Newton meters, in the given reference frame.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) AerodynamicTorqueStream(vessel *spacecenter.Vessel, referenceFrame *spacecenter.ReferenceFrame) (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "AerodynamicTorque",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// FlapSetting - the current flap setting of a vessel, from 0 (retracted) to 3
+// (fully deployed).
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) FlapSetting(vessel *spacecenter.Vessel) (int32, error) {
+	var err error
+	var argBytes []byte
+	var vv int32
+	request := &types.ProcedureCall{
+		Procedure: "FlapSetting",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// FlapSettingStream - the current flap setting of a vessel, from 0 (retracted)
+// to 3 (fully deployed).
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) FlapSettingStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[int32], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "FlapSetting",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) int32 {
+		var value int32
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// IncreaseFlapDeflection - increase the flap setting of a vessel by one step.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) IncreaseFlapDeflection(vessel *spacecenter.Vessel) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "IncreaseFlapDeflection",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// DecreaseFlapDeflection - decrease the flap setting of a vessel by one step.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) DecreaseFlapDeflection(vessel *spacecenter.Vessel) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "DecreaseFlapDeflection",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// SpoilerSetting - whether a vessel's spoilers are deployed.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SpoilerSetting(vessel *spacecenter.Vessel) (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "SpoilerSetting",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// SpoilerSettingStream - whether a vessel's spoilers are deployed.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SpoilerSettingStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "SpoilerSetting",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetSpoilers - deploy or retract a vessel's spoilers.
+//
+// Allowed game scenes: FLIGHT.
+func (s *FAR) SetSpoilers(vessel *spacecenter.Vessel, deployed bool) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "SetSpoilers",
+		Service:   "FAR",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(deployed)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,440 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// codec converts between JSON values, as decoded with UseNumber, and kRPC's
+// protobuf format, using the types described by the server.
+//
+// Values map to JSON as follows:
+//   - Numbers and booleans are JSON numbers and booleans. Doubles and floats
+//     that aren't finite are returned as null.
+//   - Bytes are base64 strings.
+//   - Class instances are their IDs, with null for no instance.
+//   - Enumeration values are their names, although numbers are accepted.
+//   - Tuples, lists and sets are arrays.
+//   - Dictionaries are objects. Keys other than strings and enumeration
+//     values are written as JSON, e.g. "3" or "true".
+//   - Messages such as procedure calls use the protobuf JSON mapping.
+type codec struct {
+	// enums maps each enumeration's qualified name, e.g.
+	// "SpaceCenter.SASMode", to its values.
+	enums map[string][]*types.EnumerationValue
+}
+
+// newCodec creates a codec for the given services.
+func newCodec(services *types.Services) *codec {
+	c := &codec{enums: map[string][]*types.EnumerationValue{}}
+	for _, s := range services.Services {
+		for _, e := range s.Enumerations {
+			c.enums[s.Name+"."+e.Name] = e.Values
+		}
+	}
+	return c
+}
+
+// TypeName returns a readable name for a type, e.g. "List(SpaceCenter.Part)",
+// or "None" for no type.
+func TypeName(t *types.Type) string {
+	if t == nil {
+		return "None"
+	}
+	switch t.Code {
+	case types.Type_CLASS, types.Type_ENUMERATION:
+		return t.Service + "." + t.Name
+	case types.Type_TUPLE, types.Type_LIST, types.Type_SET, types.Type_DICTIONARY:
+		names := make([]string, len(t.Types))
+		for i, sub := range t.Types {
+			names[i] = TypeName(sub)
+		}
+		name := strings.ToLower(t.Code.String())
+		return strings.ToUpper(name[:1]) + name[1:] + "(" + strings.Join(names, ", ") + ")"
+	}
+	return strings.ToLower(t.Code.String())
+}
+
+// number parses a JSON number.
+func number(v any) (json.Number, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return "", tracerr.Errorf("Expected a number, got %v", v)
+	}
+	return n, nil
+}
+
+// integer parses a JSON number as an integer of the given size.
+func integer(v any, bits int) (int64, error) {
+	n, err := number(v)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	i, err := strconv.ParseInt(string(n), 10, bits)
+	return i, tracerr.Wrap(err)
+}
+
+// unsigned parses a JSON number as an unsigned integer of the given size.
+func unsigned(v any, bits int) (uint64, error) {
+	n, err := number(v)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	u, err := strconv.ParseUint(string(n), 10, bits)
+	return u, tracerr.Wrap(err)
+}
+
+// array checks that a value is a JSON array.
+func array(v any) ([]any, error) {
+	a, ok := v.([]any)
+	if !ok {
+		return nil, tracerr.Errorf("Expected an array, got %v", v)
+	}
+	return a, nil
+}
+
+// message returns an empty message for a message type.
+func message(code types.Type_TypeCode) proto.Message {
+	switch code {
+	case types.Type_PROCEDURE_CALL:
+		return &types.ProcedureCall{}
+	case types.Type_STREAM:
+		return &types.Stream{}
+	case types.Type_STATUS:
+		return &types.Status{}
+	case types.Type_SERVICES:
+		return &types.Services{}
+	case types.Type_EVENT:
+		return &types.Event{}
+	}
+	return nil
+}
+
+// encode converts a JSON value to a value of the given type.
+func (c *codec) encode(t *types.Type, v any) ([]byte, error) {
+	var value any
+	var err error
+	switch t.Code {
+	case types.Type_DOUBLE, types.Type_FLOAT:
+		var n json.Number
+		if n, err = number(v); err == nil {
+			var f float64
+			if f, err = n.Float64(); err == nil {
+				value = f
+				if t.Code == types.Type_FLOAT {
+					value = float32(f)
+				}
+			}
+		}
+	case types.Type_SINT32:
+		var i int64
+		i, err = integer(v, 32)
+		value = int32(i)
+	case types.Type_SINT64:
+		value, err = integer(v, 64)
+	case types.Type_UINT32:
+		var u uint64
+		u, err = unsigned(v, 32)
+		value = uint32(u)
+	case types.Type_UINT64:
+		value, err = unsigned(v, 64)
+	case types.Type_BOOL:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, tracerr.Errorf("Expected a boolean, got %v", v)
+		}
+		value = b
+	case types.Type_STRING:
+		s, ok := v.(string)
+		if !ok {
+			return nil, tracerr.Errorf("Expected a string, got %v", v)
+		}
+		value = s
+	case types.Type_BYTES:
+		s, ok := v.(string)
+		if !ok {
+			return nil, tracerr.Errorf("Expected a base64 string, got %v", v)
+		}
+		value, err = base64.StdEncoding.DecodeString(s)
+	case types.Type_CLASS:
+		if v == nil {
+			value = uint64(0)
+		} else {
+			value, err = unsigned(v, 64)
+		}
+	case types.Type_ENUMERATION:
+		value, err = c.encodeEnum(t, v)
+	case types.Type_TUPLE, types.Type_LIST, types.Type_SET:
+		return c.encodeCollection(t, v)
+	case types.Type_DICTIONARY:
+		return c.encodeDictionary(t, v)
+	default:
+		m := message(t.Code)
+		if m == nil {
+			return nil, tracerr.Errorf("Unsupported type %v", TypeName(t))
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if err := protojson.Unmarshal(raw, proto.MessageV2(m)); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		value = m
+	}
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	b, err := encode.Marshal(value)
+	return b, tracerr.Wrap(err)
+}
+
+// encodeEnum converts an enumeration value's name or number to its value.
+func (c *codec) encodeEnum(t *types.Type, v any) (int32, error) {
+	if name, ok := v.(string); ok {
+		for _, value := range c.enums[t.Service+"."+t.Name] {
+			if value.Name == name {
+				return value.Value, nil
+			}
+		}
+		return 0, tracerr.Errorf("Unknown %v value %q", TypeName(t), name)
+	}
+	i, err := integer(v, 32)
+	return int32(i), tracerr.Wrap(err)
+}
+
+// encodeCollection converts a JSON array to a tuple, list or set.
+func (c *codec) encodeCollection(t *types.Type, v any) ([]byte, error) {
+	a, err := array(v)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if t.Code == types.Type_TUPLE && len(a) != len(t.Types) {
+		return nil, tracerr.Errorf("Expected %d tuple items, got %d", len(t.Types), len(a))
+	}
+	items := make([][]byte, len(a))
+	for i, item := range a {
+		itemType := t.Types[0]
+		if t.Code == types.Type_TUPLE {
+			itemType = t.Types[i]
+		}
+		if items[i], err = c.encode(itemType, item); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	var m proto.Message
+	switch t.Code {
+	case types.Type_TUPLE:
+		m = &types.Tuple{Items: items}
+	case types.Type_LIST:
+		m = &types.List{Items: items}
+	default:
+		m = &types.Set{Items: items}
+	}
+	b, err := proto.Marshal(m)
+	return b, tracerr.Wrap(err)
+}
+
+// encodeDictionary converts a JSON object to a dictionary.
+func (c *codec) encodeDictionary(t *types.Type, v any) ([]byte, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, tracerr.Errorf("Expected an object, got %v", v)
+	}
+	// Sort the keys so that the same object always encodes the same way.
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var dict types.Dictionary
+	for _, k := range keys {
+		var key any = k
+		if code := t.Types[0].Code; code != types.Type_STRING && code != types.Type_ENUMERATION {
+			d := json.NewDecoder(strings.NewReader(k))
+			d.UseNumber()
+			if err := d.Decode(&key); err != nil {
+				return nil, tracerr.Errorf("Invalid dictionary key %q", k)
+			}
+		}
+		keyBytes, err := c.encode(t.Types[0], key)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		valueBytes, err := c.encode(t.Types[1], obj[k])
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		dict.Entries = append(dict.Entries, &types.DictionaryEntry{Key: keyBytes, Value: valueBytes})
+	}
+	b, err := proto.Marshal(&dict)
+	return b, tracerr.Wrap(err)
+}
+
+// finite returns a float, or nil if it isn't finite.
+func finite(f float64) any {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return f
+}
+
+// decode converts a value of the given type to a JSON value.
+func (c *codec) decode(t *types.Type, b []byte) (any, error) {
+	if t == nil || t.Code == types.Type_NONE {
+		return nil, nil
+	}
+	var err error
+	switch t.Code {
+	case types.Type_DOUBLE:
+		var f float64
+		err = encode.Unmarshal(b, &f)
+		return finite(f), tracerr.Wrap(err)
+	case types.Type_FLOAT:
+		var f float32
+		err = encode.Unmarshal(b, &f)
+		return finite(float64(f)), tracerr.Wrap(err)
+	case types.Type_SINT32:
+		var i int32
+		err = encode.Unmarshal(b, &i)
+		return i, tracerr.Wrap(err)
+	case types.Type_SINT64:
+		var i int64
+		err = encode.Unmarshal(b, &i)
+		return i, tracerr.Wrap(err)
+	case types.Type_UINT32:
+		var u uint32
+		err = encode.Unmarshal(b, &u)
+		return u, tracerr.Wrap(err)
+	case types.Type_UINT64:
+		var u uint64
+		err = encode.Unmarshal(b, &u)
+		return u, tracerr.Wrap(err)
+	case types.Type_BOOL:
+		var v bool
+		err = encode.Unmarshal(b, &v)
+		return v, tracerr.Wrap(err)
+	case types.Type_STRING:
+		var s string
+		err = encode.Unmarshal(b, &s)
+		return s, tracerr.Wrap(err)
+	case types.Type_BYTES:
+		var v []byte
+		err = encode.Unmarshal(b, &v)
+		return base64.StdEncoding.EncodeToString(v), tracerr.Wrap(err)
+	case types.Type_CLASS:
+		var id uint64
+		if err := encode.Unmarshal(b, &id); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if id == 0 {
+			return nil, nil
+		}
+		return id, nil
+	case types.Type_ENUMERATION:
+		var i int32
+		if err := encode.Unmarshal(b, &i); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		for _, value := range c.enums[t.Service+"."+t.Name] {
+			if value.Value == i {
+				return value.Name, nil
+			}
+		}
+		return i, nil
+	case types.Type_TUPLE, types.Type_LIST, types.Type_SET:
+		return c.decodeCollection(t, b)
+	case types.Type_DICTIONARY:
+		return c.decodeDictionary(t, b)
+	}
+	m := message(t.Code)
+	if m == nil {
+		return nil, tracerr.Errorf("Unsupported type %v", TypeName(t))
+	}
+	if err := proto.Unmarshal(b, m); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	raw, err := protojson.Marshal(proto.MessageV2(m))
+	return json.RawMessage(raw), tracerr.Wrap(err)
+}
+
+// decodeCollection converts a tuple, list or set to a JSON array.
+func (c *codec) decodeCollection(t *types.Type, b []byte) (any, error) {
+	var items [][]byte
+	switch t.Code {
+	case types.Type_TUPLE:
+		var tuple types.Tuple
+		if err := proto.Unmarshal(b, &tuple); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if len(tuple.Items) != len(t.Types) {
+			return nil, tracerr.Errorf("Expected %d tuple items, got %d", len(t.Types), len(tuple.Items))
+		}
+		items = tuple.Items
+	case types.Type_LIST:
+		var list types.List
+		if err := proto.Unmarshal(b, &list); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		items = list.Items
+	default:
+		var set types.Set
+		if err := proto.Unmarshal(b, &set); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		items = set.Items
+	}
+	a := make([]any, len(items))
+	for i, item := range items {
+		itemType := t.Types[0]
+		if t.Code == types.Type_TUPLE {
+			itemType = t.Types[i]
+		}
+		v, err := c.decode(itemType, item)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		a[i] = v
+	}
+	return a, nil
+}
+
+// decodeDictionary converts a dictionary to a JSON object.
+func (c *codec) decodeDictionary(t *types.Type, b []byte) (any, error) {
+	var dict types.Dictionary
+	if err := proto.Unmarshal(b, &dict); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	obj := make(map[string]any, len(dict.Entries))
+	for _, entry := range dict.Entries {
+		key, err := c.decode(t.Types[0], entry.Key)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		value, err := c.decode(t.Types[1], entry.Value)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		// Strings and enumeration names are used as they are.
+		k, ok := key.(string)
+		if !ok {
+			raw, err := json.Marshal(key)
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			k = string(raw)
+		}
+		obj[k] = value
+	}
+	return obj, nil
+}
@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func valueType(code types.Type_TypeCode, sub ...*types.Type) *types.Type {
+	return &types.Type{Code: code, Types: sub}
+}
+
+var sasModeType = &types.Type{Code: types.Type_ENUMERATION, Service: "SpaceCenter", Name: "SASMode"}
+
+var vesselType = &types.Type{Code: types.Type_CLASS, Service: "SpaceCenter", Name: "Vessel"}
+
+func testServices() *types.Services {
+	return &types.Services{Services: []*types.Service{{
+		Name: "SpaceCenter",
+		Enumerations: []*types.Enumeration{{
+			Name: "SASMode",
+			Values: []*types.EnumerationValue{
+				{Name: "StabilityAssist", Value: 0},
+				{Name: "Prograde", Value: 3},
+			},
+		}},
+		Procedures: []*types.Procedure{
+			{Name: "get_UT", ReturnType: valueType(types.Type_DOUBLE)},
+			{Name: "get_ActiveVessel", ReturnType: vesselType},
+			{Name: "Vessel_get_Name", Parameters: []*types.Parameter{{Name: "this", Type: vesselType}}, ReturnType: valueType(types.Type_STRING)},
+			{
+				Name: "WarpTo",
+				Parameters: []*types.Parameter{
+					{Name: "ut", Type: valueType(types.Type_DOUBLE)},
+					{Name: "max_rails_rate", Type: valueType(types.Type_FLOAT), DefaultValue: []byte{0, 0, 0x80, 0x3f}},
+				},
+			},
+		},
+	}, {
+		Name:       "KRPC",
+		Procedures: []*types.Procedure{{Name: "GetStatus"}},
+	}}}
+}
+
+// parseJSON decodes JSON as the gateway does.
+func parseJSON(t *testing.T, s string) any {
+	var v any
+	require.NoError(t, decodeJSON(strings.NewReader(s), &v))
+	return v
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	c := newCodec(testServices())
+	tests := []struct {
+		name     string
+		typ      *types.Type
+		json     string
+		expected any
+	}{
+		{name: "double", typ: valueType(types.Type_DOUBLE), json: "1.5", expected: 1.5},
+		{name: "float", typ: valueType(types.Type_FLOAT), json: "0.25", expected: 0.25},
+		{name: "sint32", typ: valueType(types.Type_SINT32), json: "-7", expected: int32(-7)},
+		{name: "sint64", typ: valueType(types.Type_SINT64), json: "-9000000000", expected: int64(-9000000000)},
+		{name: "uint32", typ: valueType(types.Type_UINT32), json: "7", expected: uint32(7)},
+		{name: "uint64", typ: valueType(types.Type_UINT64), json: "18446744073709551615", expected: uint64(math.MaxUint64)},
+		{name: "bool", typ: valueType(types.Type_BOOL), json: "true", expected: true},
+		{name: "string", typ: valueType(types.Type_STRING), json: `"Jeb"`, expected: "Jeb"},
+		{name: "bytes", typ: valueType(types.Type_BYTES), json: `"AQI="`, expected: "AQI="},
+		{name: "class", typ: vesselType, json: "42", expected: uint64(42)},
+		{name: "null class", typ: vesselType, json: "null", expected: nil},
+		{name: "enum by name", typ: sasModeType, json: `"Prograde"`, expected: "Prograde"},
+		{name: "enum by value", typ: sasModeType, json: "3", expected: "Prograde"},
+		{
+			name:     "tuple",
+			typ:      valueType(types.Type_TUPLE, valueType(types.Type_DOUBLE), valueType(types.Type_STRING)),
+			json:     `[1, "a"]`,
+			expected: []any{1.0, "a"},
+		},
+		{
+			name:     "list of classes",
+			typ:      valueType(types.Type_LIST, vesselType),
+			json:     `[1, 2]`,
+			expected: []any{uint64(1), uint64(2)},
+		},
+		{
+			name:     "set",
+			typ:      valueType(types.Type_SET, valueType(types.Type_STRING)),
+			json:     `["a"]`,
+			expected: []any{"a"},
+		},
+		{
+			name:     "string dictionary",
+			typ:      valueType(types.Type_DICTIONARY, valueType(types.Type_STRING), valueType(types.Type_SINT32)),
+			json:     `{"a": 1, "b": 2}`,
+			expected: map[string]any{"a": int32(1), "b": int32(2)},
+		},
+		{
+			name:     "integer dictionary",
+			typ:      valueType(types.Type_DICTIONARY, valueType(types.Type_UINT32), valueType(types.Type_BOOL)),
+			json:     `{"3": true}`,
+			expected: map[string]any{"3": true},
+		},
+		{
+			name:     "enum dictionary",
+			typ:      valueType(types.Type_DICTIONARY, sasModeType, valueType(types.Type_BOOL)),
+			json:     `{"Prograde": true}`,
+			expected: map[string]any{"Prograde": true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := c.encode(tc.typ, parseJSON(t, tc.json))
+			require.NoError(t, err)
+			v, err := c.decode(tc.typ, b)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestCodecMatchesEncode(t *testing.T) {
+	c := newCodec(testServices())
+	b, err := c.encode(valueType(types.Type_LIST, valueType(types.Type_SINT32)), parseJSON(t, "[1, -2]"))
+	require.NoError(t, err)
+	expected, err := encode.Marshal([]int32{1, -2})
+	require.NoError(t, err)
+	require.Equal(t, expected, b)
+}
+
+func TestCodecMessages(t *testing.T) {
+	c := newCodec(testServices())
+	typ := valueType(types.Type_PROCEDURE_CALL)
+	b, err := c.encode(typ, parseJSON(t, `{"service": "SpaceCenter", "procedure": "get_UT"}`))
+	require.NoError(t, err)
+	v, err := c.decode(typ, b)
+	require.NoError(t, err)
+	var call map[string]any
+	require.NoError(t, json.Unmarshal(v.(json.RawMessage), &call))
+	require.Equal(t, map[string]any{"service": "SpaceCenter", "procedure": "get_UT"}, call)
+}
+
+func TestCodecErrors(t *testing.T) {
+	c := newCodec(testServices())
+	tests := []struct {
+		name string
+		typ  *types.Type
+		json string
+	}{
+		{name: "string for number", typ: valueType(types.Type_DOUBLE), json: `"1"`},
+		{name: "sint32 overflow", typ: valueType(types.Type_SINT32), json: "3000000000"},
+		{name: "negative uint", typ: valueType(types.Type_UINT32), json: "-1"},
+		{name: "fractional int", typ: valueType(types.Type_SINT32), json: "1.5"},
+		{name: "unknown enum", typ: sasModeType, json: `"Sideways"`},
+		{name: "short tuple", typ: valueType(types.Type_TUPLE, valueType(types.Type_BOOL), valueType(types.Type_BOOL)), json: "[true]"},
+		{name: "object for list", typ: valueType(types.Type_LIST, valueType(types.Type_BOOL)), json: "{}"},
+		{name: "bad key", typ: valueType(types.Type_DICTIONARY, valueType(types.Type_UINT32), valueType(types.Type_BOOL)), json: `{"x": true}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.encode(tc.typ, parseJSON(t, tc.json))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeNonFinite(t *testing.T) {
+	c := newCodec(testServices())
+	b, err := encode.Marshal(math.NaN())
+	require.NoError(t, err)
+	v, err := c.decode(valueType(types.Type_DOUBLE), b)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestTypeName(t *testing.T) {
+	require.Equal(t, "None", TypeName(nil))
+	require.Equal(t, "double", TypeName(valueType(types.Type_DOUBLE)))
+	require.Equal(t, "List(SpaceCenter.Vessel)", TypeName(valueType(types.Type_LIST, vesselType)))
+	require.Equal(t, "Dictionary(string, SpaceCenter.SASMode)", TypeName(valueType(types.Type_DICTIONARY, valueType(types.Type_STRING), sasModeType)))
+}
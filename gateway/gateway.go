@@ -0,0 +1,353 @@
+// Package gateway serves kRPC procedure calls over HTTP with JSON arguments
+// and results, so that tools that can't speak kRPC's protocol, such as shell
+// scripts, can reach the game through a Go process.
+//
+// Procedures are called at /call/{service}/{procedure}, using the names the
+// server gives them, e.g. /call/SpaceCenter/get_UT or
+// /call/SpaceCenter/Vessel_get_Name. Arguments can be POSTed as a JSON array
+// of positional arguments or an object of named arguments, or passed as
+// query parameters, whose values are read as JSON if they can be and as
+// strings otherwise. Arguments with defaults can be left out. Results are
+// returned as {"result": ...} and errors as {"error": ...}.
+//
+// Only property getters can be called with GET. Everything else must be
+// POSTed with a Content-Type of application/json, so that a web page can't
+// drive the game through a local gateway with a plain link or form.
+//
+// /services lists the services, their procedures and the procedures'
+// parameter and return types.
+//
+// GRPCServer serves the same procedures over gRPC, with protobuf definitions
+// made from the services.
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/internal"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// maxBodySize is the largest request body the gateway reads, in bytes.
+const maxBodySize = 1 << 20
+
+// caller performs procedure calls.
+type caller interface {
+	Call(call *types.ProcedureCall) (*types.ProcedureResult, error)
+}
+
+// Config configures a Gateway.
+type Config struct {
+	// Services, if set, limits the gateway to the named services.
+	Services []string
+}
+
+// Gateway maps HTTP requests to kRPC procedure calls.
+type Gateway struct {
+	client caller
+	// krpc is the client, if the gateway was created with New, for streams.
+	krpc       *krpcgo.KRPCClient
+	codec      *codec
+	procedures map[string]*types.Procedure
+	services   []*types.Service
+	mux        *http.ServeMux
+}
+
+// New creates a new Gateway, loading the services from the server.
+func New(client *krpcgo.KRPCClient, cfg Config) (*Gateway, error) {
+	services, err := internal.NewBasicKRPC(client).GetServices()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	g := newGateway(client, services, cfg)
+	g.krpc = client
+	return g, nil
+}
+
+// newGateway creates a gateway for the given services.
+func newGateway(client caller, services *types.Services, cfg Config) *Gateway {
+	g := &Gateway{
+		client:     client,
+		codec:      newCodec(services),
+		procedures: map[string]*types.Procedure{},
+		mux:        http.NewServeMux(),
+	}
+	allowed := map[string]bool{}
+	for _, name := range cfg.Services {
+		allowed[name] = true
+	}
+	for _, s := range services.Services {
+		if len(allowed) > 0 && !allowed[s.Name] {
+			continue
+		}
+		g.services = append(g.services, s)
+		for _, p := range s.Procedures {
+			g.procedures[s.Name+"/"+p.Name] = p
+		}
+	}
+	g.mux.HandleFunc("/services", g.serveServices)
+	g.mux.HandleFunc("/call/", g.serveCall)
+	return g
+}
+
+// Services returns the services the gateway serves.
+func (g *Gateway) Services() []*types.Service {
+	return g.services
+}
+
+// ServeHTTP serves the gateway.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// reply writes a JSON response.
+func reply(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// replyError writes an error response.
+func replyError(w http.ResponseWriter, status int, err error) {
+	reply(w, status, map[string]any{"error": tracerr.Unwrap(err).Error()})
+}
+
+// parameterInfo describes a parameter in the service listing.
+type parameterInfo struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// procedureInfo describes a procedure in the service listing.
+type procedureInfo struct {
+	Name       string          `json:"name"`
+	Parameters []parameterInfo `json:"parameters"`
+	Returns    string          `json:"returns"`
+}
+
+// serviceInfo describes a service in the service listing.
+type serviceInfo struct {
+	Name       string          `json:"name"`
+	Procedures []procedureInfo `json:"procedures"`
+}
+
+func (g *Gateway) serveServices(w http.ResponseWriter, r *http.Request) {
+	services := []serviceInfo{}
+	for _, s := range g.services {
+		info := serviceInfo{Name: s.Name, Procedures: []procedureInfo{}}
+		for _, p := range s.Procedures {
+			proc := procedureInfo{Name: p.Name, Parameters: []parameterInfo{}, Returns: TypeName(p.ReturnType)}
+			for _, param := range p.Parameters {
+				proc.Parameters = append(proc.Parameters, parameterInfo{
+					Name:     param.Name,
+					Type:     TypeName(param.Type),
+					Optional: param.DefaultValue != nil,
+				})
+			}
+			info.Procedures = append(info.Procedures, proc)
+		}
+		services = append(services, info)
+	}
+	reply(w, http.StatusOK, services)
+}
+
+// decodeJSON decodes JSON, keeping numbers exact.
+func decodeJSON(r io.Reader, v any) error {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return tracerr.Wrap(d.Decode(v))
+}
+
+// requestArguments reads a request's arguments, by name or position.
+func requestArguments(r *http.Request, p *types.Procedure) (map[string]any, error) {
+	args := map[string]any{}
+	for name, values := range r.URL.Query() {
+		var v any
+		if err := decodeJSON(strings.NewReader(values[0]), &v); err != nil {
+			v = values[0]
+		}
+		args[name] = v
+	}
+	if r.Method != http.MethodPost {
+		return args, nil
+	}
+	var body any
+	if err := decodeJSON(r.Body, &body); err != nil {
+		if errors.Is(tracerr.Unwrap(err), io.EOF) {
+			return args, nil
+		}
+		return nil, tracerr.Wrap(err)
+	}
+	switch b := body.(type) {
+	case map[string]any:
+		for name, v := range b {
+			args[name] = v
+		}
+	case []any:
+		if len(b) > len(p.Parameters) {
+			return nil, tracerr.Errorf("Expected at most %d arguments, got %d", len(p.Parameters), len(b))
+		}
+		for i, v := range b {
+			args[p.Parameters[i].Name] = v
+		}
+	default:
+		return nil, tracerr.Errorf("Expected an array or object of arguments")
+	}
+	return args, nil
+}
+
+// buildCall converts arguments to a procedure call.
+func (g *Gateway) buildCall(service string, p *types.Procedure, args map[string]any) (*types.ProcedureCall, error) {
+	call := &types.ProcedureCall{Service: service, Procedure: p.Name}
+	for i, param := range p.Parameters {
+		v, ok := args[param.Name]
+		if !ok {
+			if param.DefaultValue == nil {
+				return nil, tracerr.Errorf("Missing argument %q", param.Name)
+			}
+			continue
+		}
+		delete(args, param.Name)
+		b, err := g.codec.encode(param.Type, v)
+		if err != nil {
+			return nil, tracerr.Errorf("Invalid argument %q: %v", param.Name, tracerr.Unwrap(err))
+		}
+		call.Arguments = append(call.Arguments, &types.Argument{Position: uint32(i), Value: b})
+	}
+	for name := range args {
+		return nil, tracerr.Errorf("Unknown argument %q", name)
+	}
+	return call, nil
+}
+
+// procedureCall looks up a procedure and converts arguments to a call of it.
+func (g *Gateway) procedureCall(service, procedure string, args map[string]any) (*types.ProcedureCall, *types.Procedure, error) {
+	p, ok := g.procedures[service+"/"+procedure]
+	if !ok {
+		return nil, nil, tracerr.Errorf("Unknown procedure %q", service+"/"+procedure)
+	}
+	call, err := g.buildCall(service, p, args)
+	if err != nil {
+		return nil, nil, tracerr.Wrap(err)
+	}
+	return call, p, nil
+}
+
+// Call calls a procedure with arguments by name, given as JSON values decoded
+// with UseNumber, and returns the result as a JSON value. Arguments with
+// defaults can be left out.
+func (g *Gateway) Call(service, procedure string, args map[string]any) (any, error) {
+	call, p, err := g.procedureCall(service, procedure, args)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	result, err := g.client.Call(call)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	v, err := g.codec.decode(p.ReturnType, result.Value)
+	return v, tracerr.Wrap(err)
+}
+
+// Stream streams a procedure's result, with arguments as for Call. Values
+// are JSON values, or errors for any that can't be decoded. The gateway must
+// have been created with New, by a client with streams.
+func (g *Gateway) Stream(service, procedure string, args map[string]any) (*krpcgo.Stream[any], error) {
+	if g.krpc == nil || !g.krpc.StreamsAvailable() {
+		return nil, tracerr.Errorf("Streams are not available")
+	}
+	call, p, err := g.procedureCall(service, procedure, args)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	k := krpc.New(g.krpc)
+	st, err := k.AddStream(call, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	stream := krpcgo.MapStream(g.krpc.GetStream(st.Id), func(b []byte) any {
+		v, err := g.codec.decode(p.ReturnType, b)
+		if err != nil {
+			return err
+		}
+		return v
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(k.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// isGetter reports whether a procedure is a property getter, which only
+// reads from the game.
+func isGetter(procedure string) bool {
+	return strings.HasPrefix(procedure, "get_") || strings.Contains(procedure, "_get_")
+}
+
+func (g *Gateway) serveCall(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		replyError(w, http.StatusMethodNotAllowed, tracerr.Errorf("Use GET or POST"))
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/call/")
+	p, ok := g.procedures[path]
+	if !ok {
+		replyError(w, http.StatusNotFound, tracerr.Errorf("Unknown procedure %q", path))
+		return
+	}
+	if r.Method == http.MethodGet && !isGetter(p.Name) {
+		w.Header().Set("Allow", "POST")
+		replyError(w, http.StatusMethodNotAllowed, tracerr.Errorf("Use POST to call %v", p.Name))
+		return
+	}
+	if r.Method == http.MethodPost {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			replyError(w, http.StatusUnsupportedMediaType, tracerr.Errorf("Use a Content-Type of application/json"))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+	service := strings.SplitN(path, "/", 2)[0]
+	args, err := requestArguments(r, p)
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(tracerr.Unwrap(err), &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		replyError(w, status, err)
+		return
+	}
+	call, err := g.buildCall(service, p, args)
+	if err != nil {
+		replyError(w, http.StatusBadRequest, err)
+		return
+	}
+	result, err := g.client.Call(call)
+	if err != nil {
+		var krpcErr *types.Error
+		if errors.As(err, &krpcErr) {
+			reply(w, http.StatusUnprocessableEntity, map[string]any{"error": krpcErr.Error()})
+			return
+		}
+		replyError(w, http.StatusBadGateway, err)
+		return
+	}
+	v, err := g.codec.decode(p.ReturnType, result.Value)
+	if err != nil {
+		replyError(w, http.StatusBadGateway, err)
+		return
+	}
+	reply(w, http.StatusOK, map[string]any{"result": v})
+}
@@ -0,0 +1,186 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ztrue/tracerr"
+)
+
+// fakeCaller records calls and answers them with fixed results.
+type fakeCaller struct {
+	calls   []*types.ProcedureCall
+	results map[string]any
+}
+
+func (c *fakeCaller) Call(call *types.ProcedureCall) (*types.ProcedureResult, error) {
+	c.calls = append(c.calls, call)
+	result, ok := c.results[call.Procedure]
+	if !ok {
+		return nil, tracerr.Wrap(&types.Error{Service: "SpaceCenter", Name: "InvalidOperationException", Description: "Nope"})
+	}
+	if result == nil {
+		return &types.ProcedureResult{}, nil
+	}
+	b, err := encode.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ProcedureResult{Value: b}, nil
+}
+
+func newTestGateway(t *testing.T, cfg Config) (*fakeCaller, *httptest.Server) {
+	caller := &fakeCaller{results: map[string]any{
+		"get_UT":           1234.5,
+		"get_ActiveVessel": uint64(7),
+		"Vessel_get_Name":  "Kerbal X",
+		"GetStatus":        nil,
+	}}
+	server := httptest.NewServer(newGateway(caller, testServices(), cfg))
+	t.Cleanup(server.Close)
+	return caller, server
+}
+
+// request makes a request and decodes the JSON response. POSTed bodies are
+// sent as JSON.
+func request(t *testing.T, method, url, body string) (int, map[string]any) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var out map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return resp.StatusCode, out
+}
+
+func TestGatewayCalls(t *testing.T) {
+	caller, server := newTestGateway(t, Config{})
+
+	status, out := request(t, http.MethodGet, server.URL+"/call/SpaceCenter/get_UT", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, map[string]any{"result": 1234.5}, out)
+
+	status, out = request(t, http.MethodGet, server.URL+"/call/SpaceCenter/get_ActiveVessel", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, map[string]any{"result": 7.0}, out)
+
+	for _, tc := range []struct{ method, url, body string }{
+		{http.MethodPost, "/call/SpaceCenter/Vessel_get_Name", "[7]"},
+		{http.MethodPost, "/call/SpaceCenter/Vessel_get_Name", `{"this": 7}`},
+		{http.MethodGet, "/call/SpaceCenter/Vessel_get_Name?this=7", ""},
+	} {
+		status, out = request(t, tc.method, server.URL+tc.url, tc.body)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, map[string]any{"result": "Kerbal X"}, out)
+		call := caller.calls[len(caller.calls)-1]
+		require.Equal(t, "SpaceCenter", call.Service)
+		require.Equal(t, "Vessel_get_Name", call.Procedure)
+		require.Len(t, call.Arguments, 1)
+		require.Equal(t, []byte{7}, call.Arguments[0].Value)
+	}
+
+	status, out = request(t, http.MethodPost, server.URL+"/call/KRPC/GetStatus", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, map[string]any{"result": nil}, out)
+}
+
+func TestGatewayCall(t *testing.T) {
+	caller := &fakeCaller{results: map[string]any{"Vessel_get_Name": "Kerbal X"}}
+	g := newGateway(caller, testServices(), Config{})
+
+	v, err := g.Call("SpaceCenter", "Vessel_get_Name", map[string]any{"this": json.Number("7")})
+	require.NoError(t, err)
+	require.Equal(t, "Kerbal X", v)
+	require.Equal(t, []byte{7}, caller.calls[0].Arguments[0].Value)
+
+	_, err = g.Call("SpaceCenter", "Explode", nil)
+	require.Error(t, err)
+	_, err = g.Call("SpaceCenter", "Vessel_get_Name", map[string]any{})
+	require.Error(t, err)
+	_, err = g.Call("SpaceCenter", "get_UT", nil)
+	var krpcErr *types.Error
+	require.ErrorAs(t, err, &krpcErr)
+}
+
+func TestGatewayDefaults(t *testing.T) {
+	caller, server := newTestGateway(t, Config{})
+	status, out := request(t, http.MethodPost, server.URL+"/call/SpaceCenter/WarpTo", `{"ut": 100}`)
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+	require.Equal(t, "SpaceCenter - InvalidOperationException: Nope", out["error"])
+	call := caller.calls[0]
+	require.Len(t, call.Arguments, 1)
+	require.Equal(t, uint32(0), call.Arguments[0].Position)
+}
+
+func TestGatewayErrors(t *testing.T) {
+	_, server := newTestGateway(t, Config{Services: []string{"SpaceCenter"}})
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "unknown procedure", method: http.MethodGet, path: "/call/SpaceCenter/Explode", expectedStatus: http.StatusNotFound},
+		{name: "filtered service", method: http.MethodGet, path: "/call/KRPC/GetStatus", expectedStatus: http.StatusNotFound},
+		{name: "missing argument", method: http.MethodPost, path: "/call/SpaceCenter/Vessel_get_Name", expectedStatus: http.StatusBadRequest},
+		{name: "unknown argument", method: http.MethodPost, path: "/call/SpaceCenter/get_UT", body: `{"x": 1}`, expectedStatus: http.StatusBadRequest},
+		{name: "too many arguments", method: http.MethodPost, path: "/call/SpaceCenter/get_UT", body: `[1]`, expectedStatus: http.StatusBadRequest},
+		{name: "wrong type", method: http.MethodPost, path: "/call/SpaceCenter/Vessel_get_Name", body: `["x"]`, expectedStatus: http.StatusBadRequest},
+		{name: "bad JSON", method: http.MethodPost, path: "/call/SpaceCenter/Vessel_get_Name", body: `[`, expectedStatus: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodDelete, path: "/call/SpaceCenter/get_UT", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "GET non-getter", method: http.MethodGet, path: "/call/SpaceCenter/WarpTo?ut=100", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "body too large", method: http.MethodPost, path: "/call/SpaceCenter/Vessel_get_Name", body: "[" + strings.Repeat(" ", maxBodySize) + "7]", expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, out := request(t, tc.method, server.URL+tc.path, tc.body)
+			require.Equal(t, tc.expectedStatus, status)
+			require.NotEmpty(t, out["error"])
+		})
+	}
+}
+
+func TestGatewayContentType(t *testing.T) {
+	caller, server := newTestGateway(t, Config{})
+	// A form post, as a web page could make, is refused.
+	resp, err := http.Post(server.URL+"/call/SpaceCenter/WarpTo", "application/x-www-form-urlencoded", strings.NewReader("ut=100"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	require.Empty(t, caller.calls)
+
+	resp, err = http.Post(server.URL+"/call/SpaceCenter/Vessel_get_Name", "application/json; charset=utf-8", strings.NewReader("[7]"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGatewayServices(t *testing.T) {
+	_, server := newTestGateway(t, Config{Services: []string{"SpaceCenter"}})
+	resp, err := http.Get(server.URL + "/services")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var services []serviceInfo
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&services))
+	require.Len(t, services, 1)
+	require.Equal(t, "SpaceCenter", services[0].Name)
+	require.Equal(t, procedureInfo{
+		Name: "WarpTo",
+		Parameters: []parameterInfo{
+			{Name: "ut", Type: "double"},
+			{Name: "max_rails_rate", Type: "float", Optional: true},
+		},
+		Returns: "None",
+	}, services[0].Procedures[3])
+}
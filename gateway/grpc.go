@@ -0,0 +1,246 @@
+package gateway
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/internal"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// gRPC status codes.
+const (
+	grpcOK              = 0
+	grpcUnknown         = 2
+	grpcInvalidArgument = 3
+	grpcUnimplemented   = 12
+	grpcInternal        = 13
+	grpcUnavailable     = 14
+)
+
+// maxGRPCMessage limits the size of request messages.
+const maxGRPCMessage = 4 << 20
+
+// grpcMethod is a procedure served over gRPC.
+type grpcMethod struct {
+	service   string
+	procedure *types.Procedure
+	input     protoreflect.MessageDescriptor
+	output    protoreflect.MessageDescriptor
+}
+
+// GRPCServer serves kRPC procedures as gRPC methods, so that any language's
+// gRPC tooling can call them. The protobuf definitions are made from the
+// server's service definitions: ProtoFiles returns them as .proto source to
+// generate clients from. Each service is in a package named
+// krpc.<service>, e.g. krpc.spacecenter.SpaceCenter/get_UT.
+//
+// Class instances are passed as their IDs, enumeration values as their
+// numbers and kRPC messages, such as procedure calls, as their encoded
+// bytes. Parameters with defaults are optional fields.
+//
+// gRPC needs HTTP/2, which net/http only serves over TLS, so serve it with
+// http.Server.ServeTLS or ListenAndServeTLS. Compressed messages aren't
+// supported.
+type GRPCServer struct {
+	client  caller
+	methods map[string]*grpcMethod
+	schemas []*protoSchema
+}
+
+// NewGRPCServer creates a new GRPCServer, loading the services from the
+// server.
+func NewGRPCServer(client *krpcgo.KRPCClient, cfg Config) (*GRPCServer, error) {
+	services, err := internal.NewBasicKRPC(client).GetServices()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	s, err := newGRPCServer(client, services, cfg)
+	return s, tracerr.Wrap(err)
+}
+
+// newGRPCServer creates a gRPC server for the given services.
+func newGRPCServer(client caller, services *types.Services, cfg Config) (*GRPCServer, error) {
+	s := &GRPCServer{client: client, methods: map[string]*grpcMethod{}}
+	allowed := map[string]bool{}
+	for _, name := range cfg.Services {
+		allowed[name] = true
+	}
+	for _, service := range services.Services {
+		if len(allowed) > 0 && !allowed[service.Name] {
+			continue
+		}
+		schema := newProtoSchema(service)
+		file, err := protodesc.NewFile(schema.file, nil)
+		if err != nil {
+			return nil, tracerr.Errorf("Invalid schema for %v: %v", service.Name, err)
+		}
+		s.schemas = append(s.schemas, schema)
+		sd := file.Services().Get(0)
+		for _, p := range service.Procedures {
+			md := sd.Methods().ByName(protoreflect.Name(p.Name))
+			s.methods["/"+string(sd.FullName())+"/"+p.Name] = &grpcMethod{
+				service:   service.Name,
+				procedure: p,
+				input:     md.Input(),
+				output:    md.Output(),
+			}
+		}
+	}
+	return s, nil
+}
+
+// ProtoFiles returns the .proto source for each service, by file name.
+func (s *GRPCServer) ProtoFiles() map[string]string {
+	files := map[string]string{}
+	for _, schema := range s.schemas {
+		files[schema.file.GetName()] = schema.source()
+	}
+	return files
+}
+
+// grpcError is an error with a gRPC status code.
+type grpcError struct {
+	code    int
+	message string
+}
+
+func (e *grpcError) Error() string {
+	return fmt.Sprintf("gRPC status %d: %s", e.code, e.message)
+}
+
+// grpcErrorf creates a gRPC error.
+func grpcErrorf(code int, format string, args ...any) error {
+	return &grpcError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// percentEncode encodes a status message as gRPC requires.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// readGRPCMessage reads a length-prefixed message.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "reading message: %v", err)
+	}
+	if header[0] != 0 {
+		return nil, grpcErrorf(grpcUnimplemented, "compressed messages aren't supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxGRPCMessage {
+		return nil, grpcErrorf(grpcInvalidArgument, "message too large: %d bytes", size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "reading message: %v", err)
+	}
+	return b, nil
+}
+
+// appendGRPCMessage appends a length-prefixed message.
+func appendGRPCMessage(b, message []byte) []byte {
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(message)))
+	return append(b, message...)
+}
+
+// call performs a method call.
+func (s *GRPCServer) call(m *grpcMethod, body io.Reader) ([]byte, error) {
+	b, err := readGRPCMessage(body)
+	if err != nil {
+		return nil, err
+	}
+	input := dynamicpb.NewMessage(m.input)
+	if err := protov2.Unmarshal(b, input); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "decoding request: %v", err)
+	}
+
+	call := &types.ProcedureCall{Service: m.service, Procedure: m.procedure.Name}
+	for i, param := range m.procedure.Parameters {
+		fd := m.input.Fields().ByNumber(protoreflect.FieldNumber(i + 1))
+		if param.DefaultValue != nil && !input.Has(fd) {
+			continue
+		}
+		arg, err := fromField(param.Type, input, fd)
+		if err != nil {
+			return nil, grpcErrorf(grpcInvalidArgument, "encoding %s: %v", param.Name, tracerr.Unwrap(err))
+		}
+		call.Arguments = append(call.Arguments, &types.Argument{Position: uint32(i), Value: arg})
+	}
+
+	result, err := s.client.Call(call)
+	if err != nil {
+		var krpcErr *types.Error
+		if errors.As(err, &krpcErr) {
+			return nil, grpcErrorf(grpcUnknown, "%v", krpcErr)
+		}
+		return nil, grpcErrorf(grpcUnavailable, "%v", tracerr.Unwrap(err))
+	}
+
+	output := dynamicpb.NewMessage(m.output)
+	if fd := m.output.Fields().ByNumber(1); fd != nil {
+		if err := toField(m.procedure.ReturnType, output, fd, result.Value); err != nil {
+			return nil, grpcErrorf(grpcInternal, "decoding result: %v", tracerr.Unwrap(err))
+		}
+	}
+	out, err := protov2.Marshal(output)
+	if err != nil {
+		return nil, grpcErrorf(grpcInternal, "encoding response: %v", err)
+	}
+	return out, nil
+}
+
+// ServeHTTP serves gRPC requests.
+func (s *GRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "expected a gRPC request over HTTP/2", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc+proto")
+
+	var out []byte
+	var err error
+	if m, ok := s.methods[r.URL.Path]; ok {
+		out, err = s.call(m, r.Body)
+	} else {
+		err = grpcErrorf(grpcUnimplemented, "unknown method %s", r.URL.Path)
+	}
+	if err != nil {
+		// Reply with only headers, as gRPC allows for errors.
+		var gerr *grpcError
+		if !errors.As(err, &gerr) {
+			gerr = &grpcError{code: grpcInternal, message: err.Error()}
+		}
+		w.Header().Set("Grpc-Status", strconv.Itoa(gerr.code))
+		w.Header().Set("Grpc-Message", percentEncode(gerr.message))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(appendGRPCMessage(nil, out))
+	w.Header().Set("Grpc-Status", strconv.Itoa(grpcOK))
+	w.Header().Set("Grpc-Message", "")
+}
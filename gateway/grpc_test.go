@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func newTestGRPCServer(t *testing.T) (*fakeCaller, *GRPCServer, *httptest.Server) {
+	caller := &fakeCaller{results: map[string]any{
+		"get_UT":          1234.5,
+		"Vessel_get_Name": "Kerbal X",
+	}}
+	s, err := newGRPCServer(caller, testServices(), Config{})
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(s)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return caller, s, server
+}
+
+// grpcCall makes a gRPC call, returning the response message bytes and the
+// status.
+func grpcCall(t *testing.T, server *httptest.Server, method string, body []byte) ([]byte, string, string) {
+	req, err := http.NewRequest(http.MethodPost, server.URL+method, bytes.NewReader(appendGRPCMessage(nil, body)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	status, message := resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	if status == "" {
+		status, message = resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+	}
+	if len(b) == 0 {
+		return nil, status, message
+	}
+	out, err := readGRPCMessage(bytes.NewReader(b))
+	require.NoError(t, err)
+	return out, status, message
+}
+
+// newRequest makes a request message for a method.
+func newRequest(t *testing.T, s *GRPCServer, method string) *dynamicpb.Message {
+	m, ok := s.methods[method]
+	require.True(t, ok)
+	return dynamicpb.NewMessage(m.input)
+}
+
+func TestGRPCServerCalls(t *testing.T) {
+	caller, s, server := newTestGRPCServer(t)
+
+	out, status, _ := grpcCall(t, server, "/krpc.spacecenter.SpaceCenter/get_UT", nil)
+	require.Equal(t, "0", status)
+	response := dynamicpb.NewMessage(s.methods["/krpc.spacecenter.SpaceCenter/get_UT"].output)
+	require.NoError(t, proto.Unmarshal(out, response))
+	require.Equal(t, 1234.5, response.Get(response.Descriptor().Fields().ByName("result")).Float())
+
+	request := newRequest(t, s, "/krpc.spacecenter.SpaceCenter/Vessel_get_Name")
+	request.Set(request.Descriptor().Fields().ByName("this"), protoreflect.ValueOfUint64(7))
+	b, err := proto.Marshal(request)
+	require.NoError(t, err)
+	out, status, _ = grpcCall(t, server, "/krpc.spacecenter.SpaceCenter/Vessel_get_Name", b)
+	require.Equal(t, "0", status)
+	response = dynamicpb.NewMessage(s.methods["/krpc.spacecenter.SpaceCenter/Vessel_get_Name"].output)
+	require.NoError(t, proto.Unmarshal(out, response))
+	require.Equal(t, "Kerbal X", response.Get(response.Descriptor().Fields().ByName("result")).String())
+	call := caller.calls[len(caller.calls)-1]
+	require.Len(t, call.Arguments, 1)
+	require.Equal(t, []byte{7}, call.Arguments[0].Value)
+}
+
+func TestGRPCServerDefaults(t *testing.T) {
+	caller, s, server := newTestGRPCServer(t)
+	method := "/krpc.spacecenter.SpaceCenter/WarpTo"
+
+	request := newRequest(t, s, method)
+	request.Set(request.Descriptor().Fields().ByName("ut"), protoreflect.ValueOfFloat64(100))
+	b, err := proto.Marshal(request)
+	require.NoError(t, err)
+	_, status, message := grpcCall(t, server, method, b)
+	require.Equal(t, "2", status)
+	require.Equal(t, "SpaceCenter - InvalidOperationException: Nope", message)
+	require.Len(t, caller.calls[0].Arguments, 1)
+
+	// Setting an optional parameter to zero still passes it.
+	request.Set(request.Descriptor().Fields().ByName("max_rails_rate"), protoreflect.ValueOfFloat32(0))
+	b, err = proto.Marshal(request)
+	require.NoError(t, err)
+	grpcCall(t, server, method, b)
+	require.Len(t, caller.calls[1].Arguments, 2)
+	expected, err := encode.Marshal(float32(0))
+	require.NoError(t, err)
+	require.Equal(t, expected, caller.calls[1].Arguments[1].Value)
+}
+
+func TestGRPCServerErrors(t *testing.T) {
+	_, _, server := newTestGRPCServer(t)
+
+	_, status, _ := grpcCall(t, server, "/krpc.spacecenter.SpaceCenter/Explode", nil)
+	require.Equal(t, "12", status)
+
+	_, status, _ = grpcCall(t, server, "/krpc.spacecenter.SpaceCenter/get_UT", []byte{0xff})
+	require.Equal(t, "3", status)
+
+	resp, err := server.Client().Post(server.URL+"/krpc.spacecenter.SpaceCenter/get_UT", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+}
+
+func TestPercentEncode(t *testing.T) {
+	require.Equal(t, "Nope", percentEncode("Nope"))
+	require.Equal(t, "100%25 sure%0A%C3%A9", percentEncode("100% sure\né"))
+}
+
+func TestProtoFiles(t *testing.T) {
+	_, s, _ := newTestGRPCServer(t)
+	files := s.ProtoFiles()
+	require.Len(t, files, 2)
+	require.Contains(t, files["spacecenter.proto"], "  rpc WarpTo(WarpToRequest) returns (WarpToResponse);\n")
+	require.Contains(t, files["krpc.proto"], "package krpc.krpc;\n")
+}
@@ -0,0 +1,272 @@
+package gateway
+
+import (
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The functions here convert between kRPC's protobuf format and the messages
+// described by a protoSchema. A field's kRPC type decides its shape in the
+// schema, so both are walked together.
+
+// fromField encodes a message field as a value of the given type.
+func fromField(t *types.Type, m protoreflect.Message, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	switch {
+	case fd.IsMap():
+		var dict types.Dictionary
+		var err error
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entry := &types.DictionaryEntry{}
+			if entry.Key, err = fromValue(t.Types[0], k.Value()); err != nil {
+				return false
+			}
+			if entry.Value, err = fromElement(t.Types[1], v); err != nil {
+				return false
+			}
+			dict.Entries = append(dict.Entries, entry)
+			return true
+		})
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		b, err := proto.Marshal(&dict)
+		return b, tracerr.Wrap(err)
+	case t.Code == types.Type_DICTIONARY:
+		var dict types.Dictionary
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			entry := list.Get(i).Message()
+			fields := entry.Descriptor().Fields()
+			key, err := fromField(t.Types[0], entry, fields.ByNumber(1))
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			value, err := fromField(t.Types[1], entry, fields.ByNumber(2))
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			dict.Entries = append(dict.Entries, &types.DictionaryEntry{Key: key, Value: value})
+		}
+		b, err := proto.Marshal(&dict)
+		return b, tracerr.Wrap(err)
+	case fd.IsList():
+		list := m.Get(fd).List()
+		items := make([][]byte, list.Len())
+		for i := range items {
+			item, err := fromElement(t.Types[0], list.Get(i))
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			items[i] = item
+		}
+		var b []byte
+		var err error
+		if t.Code == types.Type_SET {
+			b, err = proto.Marshal(&types.Set{Items: items})
+		} else {
+			b, err = proto.Marshal(&types.List{Items: items})
+		}
+		return b, tracerr.Wrap(err)
+	}
+	return fromElement(t, m.Get(fd))
+}
+
+// fromElement encodes a single value, unwrapping collections.
+func fromElement(t *types.Type, v protoreflect.Value) ([]byte, error) {
+	if isCollection(t) {
+		wrapper := v.Message()
+		return fromField(t, wrapper, wrapper.Descriptor().Fields().ByNumber(1))
+	}
+	return fromValue(t, v)
+}
+
+// fromValue encodes a value that isn't a collection.
+func fromValue(t *types.Type, v protoreflect.Value) ([]byte, error) {
+	var value any
+	switch t.Code {
+	case types.Type_DOUBLE:
+		value = v.Float()
+	case types.Type_FLOAT:
+		value = float32(v.Float())
+	case types.Type_SINT32, types.Type_ENUMERATION:
+		value = int32(v.Int())
+	case types.Type_SINT64:
+		value = v.Int()
+	case types.Type_UINT32:
+		value = uint32(v.Uint())
+	case types.Type_UINT64, types.Type_CLASS:
+		value = v.Uint()
+	case types.Type_BOOL:
+		value = v.Bool()
+	case types.Type_STRING:
+		value = v.String()
+	case types.Type_TUPLE:
+		m := v.Message()
+		var tuple types.Tuple
+		for i, itemType := range t.Types {
+			item, err := fromField(itemType, m, m.Descriptor().Fields().ByNumber(protoreflect.FieldNumber(i+1)))
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			tuple.Items = append(tuple.Items, item)
+		}
+		b, err := proto.Marshal(&tuple)
+		return b, tracerr.Wrap(err)
+	case types.Type_BYTES:
+		value = v.Bytes()
+	default:
+		// Messages are passed through as they are.
+		return v.Bytes(), nil
+	}
+	b, err := encode.Marshal(value)
+	return b, tracerr.Wrap(err)
+}
+
+// toField decodes a value of the given type into a message field.
+func toField(t *types.Type, m protoreflect.Message, fd protoreflect.FieldDescriptor, b []byte) error {
+	switch {
+	case fd.IsMap():
+		var dict types.Dictionary
+		if err := proto.Unmarshal(b, &dict); err != nil {
+			return tracerr.Wrap(err)
+		}
+		mp := m.Mutable(fd).Map()
+		for _, entry := range dict.Entries {
+			key, err := toValue(t.Types[0], nil, entry.Key)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			value, err := toElement(t.Types[1], mp.NewValue, entry.Value)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			mp.Set(key.MapKey(), value)
+		}
+		return nil
+	case t.Code == types.Type_DICTIONARY:
+		var dict types.Dictionary
+		if err := proto.Unmarshal(b, &dict); err != nil {
+			return tracerr.Wrap(err)
+		}
+		list := m.Mutable(fd).List()
+		for _, entry := range dict.Entries {
+			v := list.NewElement()
+			fields := v.Message().Descriptor().Fields()
+			if err := toField(t.Types[0], v.Message(), fields.ByNumber(1), entry.Key); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := toField(t.Types[1], v.Message(), fields.ByNumber(2), entry.Value); err != nil {
+				return tracerr.Wrap(err)
+			}
+			list.Append(v)
+		}
+		return nil
+	case fd.IsList():
+		var items [][]byte
+		if t.Code == types.Type_SET {
+			var set types.Set
+			if err := proto.Unmarshal(b, &set); err != nil {
+				return tracerr.Wrap(err)
+			}
+			items = set.Items
+		} else {
+			var list types.List
+			if err := proto.Unmarshal(b, &list); err != nil {
+				return tracerr.Wrap(err)
+			}
+			items = list.Items
+		}
+		list := m.Mutable(fd).List()
+		for _, item := range items {
+			v, err := toElement(t.Types[0], list.NewElement, item)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			list.Append(v)
+		}
+		return nil
+	}
+	v, err := toElement(t, func() protoreflect.Value { return m.NewField(fd) }, b)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	m.Set(fd, v)
+	return nil
+}
+
+// toElement decodes a single value, wrapping collections. newMessage makes
+// an empty message for the value if it is one.
+func toElement(t *types.Type, newMessage func() protoreflect.Value, b []byte) (protoreflect.Value, error) {
+	if isCollection(t) {
+		v := newMessage()
+		wrapper := v.Message()
+		err := toField(t, wrapper, wrapper.Descriptor().Fields().ByNumber(1), b)
+		return v, tracerr.Wrap(err)
+	}
+	return toValue(t, newMessage, b)
+}
+
+// toValue decodes a value that isn't a collection.
+func toValue(t *types.Type, newMessage func() protoreflect.Value, b []byte) (protoreflect.Value, error) {
+	var err error
+	var v protoreflect.Value
+	switch t.Code {
+	case types.Type_DOUBLE:
+		var f float64
+		err = encode.Unmarshal(b, &f)
+		v = protoreflect.ValueOfFloat64(f)
+	case types.Type_FLOAT:
+		var f float32
+		err = encode.Unmarshal(b, &f)
+		v = protoreflect.ValueOfFloat32(f)
+	case types.Type_SINT32, types.Type_ENUMERATION:
+		var i int32
+		err = encode.Unmarshal(b, &i)
+		v = protoreflect.ValueOfInt32(i)
+	case types.Type_SINT64:
+		var i int64
+		err = encode.Unmarshal(b, &i)
+		v = protoreflect.ValueOfInt64(i)
+	case types.Type_UINT32:
+		var u uint32
+		err = encode.Unmarshal(b, &u)
+		v = protoreflect.ValueOfUint32(u)
+	case types.Type_UINT64, types.Type_CLASS:
+		var u uint64
+		err = encode.Unmarshal(b, &u)
+		v = protoreflect.ValueOfUint64(u)
+	case types.Type_BOOL:
+		var x bool
+		err = encode.Unmarshal(b, &x)
+		v = protoreflect.ValueOfBool(x)
+	case types.Type_STRING:
+		var s string
+		err = encode.Unmarshal(b, &s)
+		v = protoreflect.ValueOfString(s)
+	case types.Type_BYTES:
+		var x []byte
+		err = encode.Unmarshal(b, &x)
+		v = protoreflect.ValueOfBytes(x)
+	case types.Type_TUPLE:
+		var tuple types.Tuple
+		if err := proto.Unmarshal(b, &tuple); err != nil {
+			return v, tracerr.Wrap(err)
+		}
+		if len(tuple.Items) != len(t.Types) {
+			return v, tracerr.Errorf("Expected %d tuple items, got %d", len(t.Types), len(tuple.Items))
+		}
+		v = newMessage()
+		m := v.Message()
+		for i, item := range tuple.Items {
+			if err := toField(t.Types[i], m, m.Descriptor().Fields().ByNumber(protoreflect.FieldNumber(i+1)), item); err != nil {
+				return v, tracerr.Wrap(err)
+			}
+		}
+	default:
+		v = protoreflect.ValueOfBytes(b)
+	}
+	return v, tracerr.Wrap(err)
+}
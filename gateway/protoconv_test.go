@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProtoConvRoundTrip(t *testing.T) {
+	service := collectionService()
+	file, err := protodesc.NewFile(newProtoSchema(service).file, nil)
+	require.NoError(t, err)
+
+	tuple, err := encode.Marshal(&types.Tuple{Items: [][]byte{
+		mustMarshal(t, 1.5),
+		mustMarshal(t, []string{"a", "b"}),
+	}})
+	require.NoError(t, err)
+	tests := []struct {
+		procedure string
+		value     []byte
+	}{
+		{procedure: "Grid", value: mustMarshal(t, [][]int32{{1, 2}, {}, {-3}})},
+		{procedure: "Crews", value: mustMarshal(t, map[string][]uint64{"a": {1, 2}})},
+		{procedure: "Altitudes", value: mustMarshal(t, map[float64]string{100.5: "low"})},
+		{procedure: "Pair", value: tuple},
+	}
+	for _, tc := range tests {
+		t.Run(tc.procedure, func(t *testing.T) {
+			var p *types.Procedure
+			for _, proc := range service.Procedures {
+				if proc.Name == tc.procedure {
+					p = proc
+				}
+			}
+			md := file.Services().Get(0).Methods().ByName(protoreflect.Name(tc.procedure))
+			m := dynamicpb.NewMessage(md.Input())
+			fd := md.Input().Fields().ByNumber(1)
+			require.NoError(t, toField(p.Parameters[0].Type, m, fd, tc.value))
+
+			// Round trip through the wire format too.
+			b, err := proto.Marshal(m)
+			require.NoError(t, err)
+			m = dynamicpb.NewMessage(md.Input())
+			require.NoError(t, proto.Unmarshal(b, m))
+
+			value, err := fromField(p.Parameters[0].Type, m, fd)
+			require.NoError(t, err)
+			require.Equal(t, tc.value, value)
+		})
+	}
+}
+
+func TestProtoConvScalars(t *testing.T) {
+	file, err := protodesc.NewFile(newProtoSchema(testServices().Services[0]).file, nil)
+	require.NoError(t, err)
+	md := file.Services().Get(0).Methods().ByName("Vessel_get_Name").Input()
+	m := dynamicpb.NewMessage(md)
+	fd := md.Fields().ByName("this")
+	require.NoError(t, toField(vesselType, m, fd, mustMarshal(t, uint64(42))))
+	require.Equal(t, uint64(42), m.Get(fd).Uint())
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	b, err := encode.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
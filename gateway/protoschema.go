@@ -0,0 +1,324 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Protobuf types for kRPC's value types. Class instances are their IDs and
+// enumeration values their numbers. Messages such as procedure calls are
+// their kRPC protobuf encoding.
+var protoScalars = map[types.Type_TypeCode]descriptorpb.FieldDescriptorProto_Type{
+	types.Type_DOUBLE:         descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	types.Type_FLOAT:          descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	types.Type_SINT32:         descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	types.Type_SINT64:         descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+	types.Type_UINT32:         descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	types.Type_UINT64:         descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	types.Type_BOOL:           descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	types.Type_STRING:         descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	types.Type_BYTES:          descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	types.Type_CLASS:          descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	types.Type_ENUMERATION:    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	types.Type_EVENT:          descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	types.Type_PROCEDURE_CALL: descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	types.Type_STREAM:         descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	types.Type_STATUS:         descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	types.Type_SERVICES:       descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+}
+
+// isCollection reports whether a type becomes a repeated or map field.
+func isCollection(t *types.Type) bool {
+	switch t.Code {
+	case types.Type_LIST, types.Type_SET, types.Type_DICTIONARY:
+		return true
+	}
+	return false
+}
+
+// isMapKey reports whether a type can be a protobuf map key.
+func isMapKey(t *types.Type) bool {
+	switch t.Code {
+	case types.Type_STRING, types.Type_SINT32, types.Type_SINT64, types.Type_UINT32,
+		types.Type_UINT64, types.Type_BOOL, types.Type_CLASS, types.Type_ENUMERATION:
+		return true
+	}
+	return false
+}
+
+// isMap reports whether a dictionary type becomes a map field, rather than a
+// list of entries.
+func isMap(t *types.Type) bool {
+	return t.Code == types.Type_DICTIONARY && isMapKey(t.Types[0])
+}
+
+// messageName returns the name of the message made for a type, e.g.
+// "Tuple_double_string" for "Tuple(double, string)".
+func messageName(t *types.Type) string {
+	var b strings.Builder
+	underscore := false
+	for _, c := range TypeName(t) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(c)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	return b.String()
+}
+
+// mapEntryName returns the name of a map field's entry message, as protoc
+// names it.
+func mapEntryName(field string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range field {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(c))
+			upper = false
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String() + "Entry"
+}
+
+// protoSchema builds a protobuf file describing a kRPC service. Each
+// procedure becomes an RPC taking a message with a field per parameter and
+// returning a message with a result field.
+//
+// Tuples become messages with fields item1, item2 and so on, lists and sets
+// become repeated fields, and dictionaries become maps, or repeated entry
+// messages if their keys can't be map keys. Collections inside collections
+// are wrapped in messages with a single value field.
+type protoSchema struct {
+	file *descriptorpb.FileDescriptorProto
+	// messages holds the top-level messages made for types, by name.
+	messages map[string]bool
+}
+
+// protoPackage returns the protobuf package for a service.
+func protoPackage(service string) string {
+	return "krpc." + strings.ToLower(service)
+}
+
+// newProtoSchema builds the schema for a service.
+func newProtoSchema(s *types.Service) *protoSchema {
+	ps := &protoSchema{
+		file: &descriptorpb.FileDescriptorProto{
+			Name:    proto.String(strings.ToLower(s.Name) + ".proto"),
+			Package: proto.String(protoPackage(s.Name)),
+			Syntax:  proto.String("proto3"),
+		},
+		messages: map[string]bool{},
+	}
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String(s.Name)}
+	for _, p := range s.Procedures {
+		request := &descriptorpb.DescriptorProto{Name: proto.String(p.Name + "Request")}
+		for i, param := range p.Parameters {
+			f := ps.field(request, param.Name, int32(i+1), param.Type)
+			// Parameters with defaults are optional, so that leaving them out
+			// can be told apart from passing zero.
+			if param.DefaultValue != nil && f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED && f.TypeName == nil {
+				f.Proto3Optional = proto.Bool(true)
+				f.OneofIndex = proto.Int32(int32(len(request.OneofDecl)))
+				request.OneofDecl = append(request.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + param.Name)})
+			}
+		}
+		response := &descriptorpb.DescriptorProto{Name: proto.String(p.Name + "Response")}
+		if p.ReturnType != nil && p.ReturnType.Code != types.Type_NONE {
+			ps.field(response, "result", 1, p.ReturnType)
+		}
+		ps.file.MessageType = append(ps.file.MessageType, request, response)
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(p.Name),
+			InputType:  proto.String(ps.qualify(request.GetName())),
+			OutputType: proto.String(ps.qualify(response.GetName())),
+		})
+	}
+	ps.file.Service = []*descriptorpb.ServiceDescriptorProto{service}
+	return ps
+}
+
+// qualify returns the fully qualified name of a top-level message.
+func (ps *protoSchema) qualify(name string) string {
+	return "." + ps.file.GetPackage() + "." + name
+}
+
+// newField creates a singular field.
+func newField(name string, number int32) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+// field adds a field of the given type to a message.
+func (ps *protoSchema) field(parent *descriptorpb.DescriptorProto, name string, number int32, t *types.Type) *descriptorpb.FieldDescriptorProto {
+	f := newField(name, number)
+	switch {
+	case isMap(t):
+		entry := &descriptorpb.DescriptorProto{
+			Name:    proto.String(mapEntryName(name)),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+		ps.elementField(entry, "key", 1, t.Types[0])
+		ps.elementField(entry, "value", 2, t.Types[1])
+		parent.NestedType = append(parent.NestedType, entry)
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		f.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		f.TypeName = proto.String(ps.qualify(parent.GetName()) + "." + entry.GetName())
+	case t.Code == types.Type_DICTIONARY:
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		ps.setMessage(f, ps.entryMessage(t))
+	case t.Code == types.Type_LIST || t.Code == types.Type_SET:
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		ps.setElement(f, t.Types[0])
+	default:
+		ps.setElement(f, t)
+	}
+	parent.Field = append(parent.Field, f)
+	return f
+}
+
+// elementField adds a field holding a single value of a type, wrapping
+// collections in messages since they can't be nested directly.
+func (ps *protoSchema) elementField(parent *descriptorpb.DescriptorProto, name string, number int32, t *types.Type) {
+	f := newField(name, number)
+	ps.setElement(f, t)
+	parent.Field = append(parent.Field, f)
+}
+
+// setElement sets a field to hold single values of a type.
+func (ps *protoSchema) setElement(f *descriptorpb.FieldDescriptorProto, t *types.Type) {
+	switch {
+	case isCollection(t):
+		ps.setMessage(f, ps.wrapperMessage(t))
+	case t.Code == types.Type_TUPLE:
+		ps.setMessage(f, ps.tupleMessage(t))
+	default:
+		scalar, ok := protoScalars[t.Code]
+		if !ok {
+			scalar = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+		}
+		f.Type = scalar.Enum()
+	}
+}
+
+// setMessage sets a field to hold a top-level message.
+func (ps *protoSchema) setMessage(f *descriptorpb.FieldDescriptorProto, name string) {
+	f.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	f.TypeName = proto.String(ps.qualify(name))
+}
+
+// addMessage adds a top-level message for a type, if it hasn't been added.
+// build fills in the message's fields.
+func (ps *protoSchema) addMessage(name string, build func(*descriptorpb.DescriptorProto)) string {
+	if !ps.messages[name] {
+		ps.messages[name] = true
+		m := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+		build(m)
+		ps.file.MessageType = append(ps.file.MessageType, m)
+	}
+	return name
+}
+
+// tupleMessage returns the message for a tuple type.
+func (ps *protoSchema) tupleMessage(t *types.Type) string {
+	return ps.addMessage(messageName(t), func(m *descriptorpb.DescriptorProto) {
+		for i, item := range t.Types {
+			ps.field(m, fmt.Sprintf("item%d", i+1), int32(i+1), item)
+		}
+	})
+}
+
+// wrapperMessage returns the message wrapping a collection type.
+func (ps *protoSchema) wrapperMessage(t *types.Type) string {
+	return ps.addMessage(messageName(t), func(m *descriptorpb.DescriptorProto) {
+		ps.field(m, "value", 1, t)
+	})
+}
+
+// entryMessage returns the entry message for a dictionary whose keys can't
+// be map keys.
+func (ps *protoSchema) entryMessage(t *types.Type) string {
+	return ps.addMessage(messageName(t)+"_Entry", func(m *descriptorpb.DescriptorProto) {
+		ps.elementField(m, "key", 1, t.Types[0])
+		ps.elementField(m, "value", 2, t.Types[1])
+	})
+}
+
+// protoTypeName returns the type of a field as written in a .proto file.
+func protoTypeName(f *descriptorpb.FieldDescriptorProto, pkg string) string {
+	if f.TypeName != nil {
+		return strings.TrimPrefix(f.GetTypeName(), "."+pkg+".")
+	}
+	return strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+}
+
+// writeMessage writes a message as .proto source.
+func writeMessage(b *strings.Builder, m *descriptorpb.DescriptorProto, pkg, indent string) {
+	fmt.Fprintf(b, "%smessage %s {\n", indent, m.GetName())
+	entries := map[string]*descriptorpb.DescriptorProto{}
+	for _, nested := range m.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			entries[m.GetName()+"."+nested.GetName()] = nested
+		} else {
+			writeMessage(b, nested, pkg, indent+"  ")
+		}
+	}
+	for _, f := range m.Field {
+		typeName := protoTypeName(f, pkg)
+		label := ""
+		if entry, ok := entries[typeName]; ok {
+			typeName = fmt.Sprintf("map<%s, %s>", protoTypeName(entry.Field[0], pkg), protoTypeName(entry.Field[1], pkg))
+		} else if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			label = "repeated "
+		} else if f.GetProto3Optional() {
+			label = "optional "
+		}
+		fmt.Fprintf(b, "%s  %s%s %s = %d;\n", indent, label, typeName, f.GetName(), f.GetNumber())
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// source returns the schema as .proto source, for generating clients.
+func (ps *protoSchema) source() string {
+	var b strings.Builder
+	pkg := ps.file.GetPackage()
+	fmt.Fprintf(&b, "// Generated by krpc-go from the kRPC %s service.\n\n", ps.file.Service[0].GetName())
+	fmt.Fprintf(&b, "syntax = \"proto3\";\n\npackage %s;\n", pkg)
+	for _, s := range ps.file.Service {
+		fmt.Fprintf(&b, "\nservice %s {\n", s.GetName())
+		for _, m := range s.Method {
+			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s);\n", m.GetName(),
+				strings.TrimPrefix(m.GetInputType(), "."+pkg+"."),
+				strings.TrimPrefix(m.GetOutputType(), "."+pkg+"."))
+		}
+		b.WriteString("}\n")
+	}
+	messages := append([]*descriptorpb.DescriptorProto{}, ps.file.MessageType...)
+	// Keep the procedures' messages first, then the messages made for types.
+	sort.SliceStable(messages, func(i, j int) bool {
+		return !ps.messages[messages[i].GetName()] && ps.messages[messages[j].GetName()]
+	})
+	for _, m := range messages {
+		b.WriteString("\n")
+		writeMessage(&b, m, pkg, "")
+	}
+	return b.String()
+}
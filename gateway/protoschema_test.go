@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// collectionService has procedures taking and returning nested collections.
+func collectionService() *types.Service {
+	listOfLists := valueType(types.Type_LIST, valueType(types.Type_LIST, valueType(types.Type_SINT32)))
+	stringToList := valueType(types.Type_DICTIONARY, valueType(types.Type_STRING), valueType(types.Type_LIST, vesselType))
+	doubleKeys := valueType(types.Type_DICTIONARY, valueType(types.Type_DOUBLE), valueType(types.Type_STRING))
+	tuple := valueType(types.Type_TUPLE, valueType(types.Type_DOUBLE), valueType(types.Type_LIST, valueType(types.Type_STRING)))
+	return &types.Service{
+		Name: "Collections",
+		Procedures: []*types.Procedure{
+			{Name: "Grid", Parameters: []*types.Parameter{{Name: "cells", Type: listOfLists}}, ReturnType: listOfLists},
+			{Name: "Crews", Parameters: []*types.Parameter{{Name: "crews", Type: stringToList}}, ReturnType: stringToList},
+			{Name: "Altitudes", Parameters: []*types.Parameter{{Name: "names", Type: doubleKeys}}, ReturnType: doubleKeys},
+			{
+				Name: "Pair",
+				Parameters: []*types.Parameter{
+					{Name: "pair", Type: tuple},
+					{Name: "mode", Type: sasModeType, DefaultValue: []byte{0}},
+				},
+				ReturnType: tuple,
+			},
+		},
+	}
+}
+
+func TestMessageName(t *testing.T) {
+	require.Equal(t, "double", messageName(valueType(types.Type_DOUBLE)))
+	require.Equal(t, "Tuple_double_string", messageName(valueType(types.Type_TUPLE, valueType(types.Type_DOUBLE), valueType(types.Type_STRING))))
+	require.Equal(t, "List_SpaceCenter_Vessel", messageName(valueType(types.Type_LIST, vesselType)))
+}
+
+func TestMapEntryName(t *testing.T) {
+	require.Equal(t, "CrewsEntry", mapEntryName("crews"))
+	require.Equal(t, "MaxRailsRateEntry", mapEntryName("max_rails_rate"))
+}
+
+func TestProtoSchemaIsValid(t *testing.T) {
+	services := append(testServices().Services, collectionService())
+	for _, s := range services {
+		t.Run(s.Name, func(t *testing.T) {
+			schema := newProtoSchema(s)
+			file, err := protodesc.NewFile(schema.file, nil)
+			require.NoError(t, err)
+			require.Equal(t, len(s.Procedures), file.Services().Get(0).Methods().Len())
+		})
+	}
+}
+
+func TestProtoSchemaSource(t *testing.T) {
+	source := newProtoSchema(collectionService()).source()
+	for _, line := range []string{
+		"package krpc.collections;",
+		"  rpc Grid(GridRequest) returns (GridResponse);",
+		"  repeated List_sint32 cells = 1;",
+		"  map<string, List_SpaceCenter_Vessel> crews = 1;",
+		"  repeated Dictionary_double_string_Entry names = 1;",
+		"  Tuple_double_List_string pair = 1;",
+		"  optional int32 mode = 2;",
+		"message List_sint32 {",
+		"  repeated sint32 value = 1;",
+		"  repeated string item2 = 2;",
+	} {
+		require.Contains(t, strings.Split(source, "\n"), line)
+	}
+}
@@ -0,0 +1,210 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// BridgeConfig configures a Bridge.
+type BridgeConfig struct {
+	// Interval is how often moved axes are sent to the game. Axes can move
+	// far faster than is worth sending, so only their latest positions are
+	// sent. Defaults to 50ms.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *BridgeConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 50 * time.Millisecond
+	}
+}
+
+// Bridge drives a vessel's controls from an input device.
+type Bridge struct {
+	device  Device
+	mapping Mapping
+	cfg     BridgeConfig
+
+	// axes are the latest control values of the mapping's axes, and sent
+	// whether they have been sent since they last changed. Axes that haven't
+	// moved are never sent, so that starting a bridge doesn't reset the
+	// controls.
+	axes []float64
+	sent []bool
+	// held are the buttons held down, by index.
+	held map[int]bool
+}
+
+// NewBridge creates a new Bridge.
+func NewBridge(device Device, mapping Mapping, cfg BridgeConfig) *Bridge {
+	cfg.SetDefaults()
+	b := &Bridge{
+		device:  device,
+		mapping: mapping,
+		cfg:     cfg,
+		axes:    make([]float64, len(mapping.Axes)),
+		sent:    make([]bool, len(mapping.Axes)),
+		held:    map[int]bool{},
+	}
+	for i := range b.sent {
+		b.sent[i] = true
+	}
+	return b
+}
+
+// handle applies an event. Axis values are kept to be sent by flush.
+func (b *Bridge) handle(e Event) error {
+	switch e.Kind {
+	case AxisEvent:
+		for i, a := range b.mapping.Axes {
+			if a.Index == e.Index {
+				if v := a.value(e.Value); v != b.axes[i] {
+					b.axes[i] = v
+					b.sent[i] = false
+				}
+			}
+		}
+	case ButtonEvent:
+		pressed := e.Value != 0
+		if pressed == b.held[e.Index] {
+			return nil
+		}
+		b.held[e.Index] = pressed
+		for _, button := range b.mapping.Buttons {
+			if button.Index != e.Index {
+				continue
+			}
+			if err := b.button(button, pressed); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+// button applies a button being pressed or released.
+func (b *Bridge) button(button Button, pressed bool) error {
+	if pressed && button.Press != nil {
+		if err := button.Press(); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if pressed && button.Toggle != nil {
+		on, err := button.Toggle.Get()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := button.Toggle.Set(!on); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if button.Hold != nil {
+		if err := button.Hold.Set(pressed); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if button.Axis != nil {
+		value := button.Value
+		if !pressed {
+			value = 0
+		}
+		if err := button.Axis.Set(value); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// flush sends the axes that have moved.
+func (b *Bridge) flush() error {
+	for i, a := range b.mapping.Axes {
+		if b.sent[i] {
+			continue
+		}
+		if err := a.Control.Set(float32(b.axes[i])); err != nil {
+			return tracerr.Wrap(err)
+		}
+		b.sent[i] = true
+	}
+	return nil
+}
+
+// center sends zero for every axis but throttles, and releases any buttons
+// held down, so that the vessel isn't left steering when the bridge stops.
+func (b *Bridge) center() error {
+	for i, a := range b.mapping.Axes {
+		if !a.Throttle {
+			b.axes[i] = 0
+			b.sent[i] = false
+		}
+	}
+	for index, held := range b.held {
+		if !held {
+			continue
+		}
+		b.held[index] = false
+		for _, button := range b.mapping.Buttons {
+			if button.Index == index {
+				if err := b.button(button, false); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+		}
+	}
+	return b.flush()
+}
+
+// Run reads the device and drives the controls until the context is
+// canceled or reading or controlling fails. The device is closed, the axes
+// other than throttles centered, and held buttons released, when it returns.
+func (b *Bridge) Run(ctx context.Context) error {
+	events := make(chan Event)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			e, err := b.device.Read()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case events <- e:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(b.cfg.Interval)
+	defer ticker.Stop()
+	var err error
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case err = <-readErr:
+			break loop
+		case e := <-events:
+			if err = b.handle(e); err != nil {
+				break loop
+			}
+		case <-ticker.C:
+			if err = b.flush(); err != nil {
+				break loop
+			}
+		}
+	}
+	if closeErr := b.device.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if centerErr := b.center(); centerErr != nil && err == nil {
+		err = centerErr
+	}
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,148 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice replays events, then waits to be closed.
+type fakeDevice struct {
+	events chan Event
+	closed chan struct{}
+}
+
+func newFakeDevice() *fakeDevice {
+	return &fakeDevice{events: make(chan Event), closed: make(chan struct{})}
+}
+
+func (d *fakeDevice) Read() (Event, error) {
+	select {
+	case e := <-d.events:
+		return e, nil
+	case <-d.closed:
+		return Event{}, io.EOF
+	}
+}
+
+func (d *fakeDevice) Close() error {
+	close(d.closed)
+	return nil
+}
+
+// controls records the values set on fake properties.
+type controls struct {
+	floats map[string][]float32
+	bools  map[string]bool
+}
+
+func (c *controls) float(name string) *krpcgo.Property[float32] {
+	return krpcgo.NewProperty(
+		func() (float32, error) { return 0, nil },
+		func(v float32) error {
+			c.floats[name] = append(c.floats[name], v)
+			return nil
+		},
+		nil,
+	)
+}
+
+func (c *controls) bool(name string) *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(
+		func() (bool, error) { return c.bools[name], nil },
+		func(v bool) error {
+			c.bools[name] = v
+			return nil
+		},
+		nil,
+	)
+}
+
+func TestBridgeHandle(t *testing.T) {
+	c := &controls{floats: map[string][]float32{}, bools: map[string]bool{}}
+	staged := 0
+	b := NewBridge(newFakeDevice(), Mapping{
+		Axes: []Axis{
+			{Index: 0, Control: c.float("pitch")},
+			{Index: 1, Control: c.float("throttle"), Throttle: true},
+			{Index: 2, Control: c.float("yaw"), Deadzone: 0.5},
+		},
+		Buttons: []Button{
+			{Index: 0, Press: func() error {
+				staged++
+				return nil
+			}},
+			{Index: 1, Toggle: c.bool("sas")},
+			{Index: 2, Hold: c.bool("brakes")},
+			{Index: 3, Axis: c.float("right"), Value: -1},
+		},
+	}, BridgeConfig{})
+
+	// Only the latest position of moved axes is sent.
+	require.NoError(t, b.handle(Event{Kind: AxisEvent, Index: 0, Value: 0.25}))
+	require.NoError(t, b.handle(Event{Kind: AxisEvent, Index: 0, Value: 0.5}))
+	require.NoError(t, b.handle(Event{Kind: AxisEvent, Index: 1, Value: 1}))
+	require.NoError(t, b.handle(Event{Kind: AxisEvent, Index: 2, Value: 0.1}))
+	require.NoError(t, b.flush())
+	require.NoError(t, b.flush())
+	require.Equal(t, map[string][]float32{"pitch": {0.5}, "throttle": {1}}, c.floats)
+
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 0, Value: 1}))
+	// Repeated presses without a release are ignored.
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 0, Value: 1}))
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 0, Value: 0}))
+	require.Equal(t, 1, staged)
+
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 1, Value: 1}))
+	require.True(t, c.bools["sas"])
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 1, Value: 0}))
+	require.True(t, c.bools["sas"])
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 1, Value: 1}))
+	require.False(t, c.bools["sas"])
+
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 2, Value: 1}))
+	require.True(t, c.bools["brakes"])
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 2, Value: 0}))
+	require.False(t, c.bools["brakes"])
+
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 3, Value: 1}))
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 3, Value: 0}))
+	require.Equal(t, []float32{-1, 0}, c.floats["right"])
+
+	// Stopping centers the axes, but leaves the throttle, and releases held
+	// buttons.
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 2, Value: 1}))
+	require.NoError(t, b.handle(Event{Kind: ButtonEvent, Index: 3, Value: 1}))
+	require.NoError(t, b.center())
+	require.Equal(t, map[string][]float32{"pitch": {0.5, 0}, "throttle": {1}, "yaw": {0}, "right": {-1, 0, -1, 0}}, c.floats)
+	require.False(t, c.bools["brakes"])
+}
+
+func TestBridgeRun(t *testing.T) {
+	c := &controls{floats: map[string][]float32{}, bools: map[string]bool{}}
+	device := newFakeDevice()
+	failing := errors.New("no vessel")
+	b := NewBridge(device, Mapping{
+		Axes:    []Axis{{Index: 0, Control: c.float("roll")}},
+		Buttons: []Button{{Index: 0, Press: func() error { return failing }}},
+	}, BridgeConfig{})
+
+	done := make(chan error)
+	go func() { done <- b.Run(context.Background()) }()
+	device.events <- Event{Kind: AxisEvent, Index: 0, Value: -1}
+	device.events <- Event{Kind: ButtonEvent, Index: 0, Value: 1}
+	require.ErrorIs(t, <-done, failing)
+	// The device was closed, and the roll centered whether or not it had
+	// been sent.
+	_, err := device.Read()
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, float32(0), c.floats["roll"][len(c.floats["roll"])-1])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, NewBridge(newFakeDevice(), Mapping{}, BridgeConfig{}).Run(ctx))
+}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// ActionGroup returns a property for one of a vessel's action groups, 0 to
+// 9, for use as a button's Toggle or Hold.
+func ActionGroup(control *spacecenter.Control, group uint32) *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(
+		func() (bool, error) { return control.GetActionGroup(group) },
+		func(on bool) error { return control.SetActionGroup(group, on) },
+		nil,
+	)
+}
+
+// Stage returns a function that activates the vessel's next stage, for use
+// as a button's Press.
+func Stage(control *spacecenter.Control) func() error {
+	return func() error {
+		_, err := control.ActivateNextStage()
+		return tracerr.Wrap(err)
+	}
+}
+
+// Axes and buttons of an Xbox-style gamepad under the Linux joystick API.
+const (
+	GamepadLeftX = iota
+	GamepadLeftY
+	GamepadLeftTrigger
+	GamepadRightX
+	GamepadRightY
+	GamepadRightTrigger
+)
+
+const (
+	GamepadA = iota
+	GamepadB
+	GamepadX
+	GamepadY
+	GamepadLeftBumper
+	GamepadRightBumper
+	GamepadBack
+	GamepadStart
+)
+
+// Gamepad returns a mapping for an Xbox-style gamepad:
+//
+//	left stick      pitch and roll
+//	right stick     yaw, and forward and back with RCS
+//	right trigger   throttle
+//	A               stage
+//	B               toggle SAS
+//	X               toggle RCS
+//	Y               toggle gear
+//	left bumper     brakes, while held
+//	right bumper    toggle lights
+//	back and start  action groups 1 and 2
+func Gamepad(control *spacecenter.Control) Mapping {
+	stick := func(index int, prop *krpcgo.Property[float32], invert bool) Axis {
+		return Axis{Index: index, Control: prop, Deadzone: 0.1, Curve: Expo(0.3), Invert: invert}
+	}
+	return Mapping{
+		Axes: []Axis{
+			// Pushing the stick forward reads as negative, and pitches down.
+			stick(GamepadLeftY, control.PitchProp(), false),
+			stick(GamepadLeftX, control.RollProp(), false),
+			stick(GamepadRightX, control.YawProp(), false),
+			stick(GamepadRightY, control.ForwardProp(), true),
+			{Index: GamepadRightTrigger, Control: control.ThrottleProp(), Deadzone: 0.02, Throttle: true},
+		},
+		Buttons: []Button{
+			{Index: GamepadA, Press: Stage(control)},
+			{Index: GamepadB, Toggle: control.SASProp()},
+			{Index: GamepadX, Toggle: control.RCSProp()},
+			{Index: GamepadY, Toggle: control.GearProp()},
+			{Index: GamepadLeftBumper, Hold: control.BrakesProp()},
+			{Index: GamepadRightBumper, Toggle: control.LightsProp()},
+			{Index: GamepadBack, Toggle: ActionGroup(control, 1)},
+			{Index: GamepadStart, Toggle: ActionGroup(control, 2)},
+		},
+	}
+}
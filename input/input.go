@@ -0,0 +1,127 @@
+// Package input maps local input devices, such as joysticks, gamepads and
+// keyboards, to a vessel's controls, for hardware cockpits driven from Go
+// and for taking over by hand during scripted missions (see Teleoperate).
+//
+// A Device reports the movements of its axes and presses of its buttons as
+// Events, and a Mapping says which controls they drive. A Bridge reads a
+// device and applies its mapping:
+//
+//	js, err := input.OpenJoystick("/dev/input/js0")
+//	bridge := input.NewBridge(js, input.Gamepad(control), input.BridgeConfig{})
+//	err = bridge.Run(ctx)
+package input
+
+import (
+	"math"
+
+	krpcgo "github.com/atburke/krpc-go"
+)
+
+// EventKind is the kind of an input event.
+type EventKind int
+
+const (
+	// AxisEvent is an axis moving.
+	AxisEvent EventKind = iota
+	// ButtonEvent is a button being pressed or released.
+	ButtonEvent
+)
+
+// Event is a change to one of a device's axes or buttons.
+type Event struct {
+	Kind EventKind
+	// Index is the number of the axis or button.
+	Index int
+	// Value is the axis' position between -1 and 1, or 1 when a button is
+	// pressed and 0 when it is released.
+	Value float64
+}
+
+// Device is a source of input events.
+type Device interface {
+	// Read waits for the next event.
+	Read() (Event, error)
+	// Close closes the device, stopping any Read in progress.
+	Close() error
+}
+
+// Curve shapes an axis' response. It takes and returns values between -1
+// and 1.
+type Curve func(float64) float64
+
+// Linear is a curve that passes values through unchanged.
+func Linear(v float64) float64 {
+	return v
+}
+
+// Expo is a curve that softens the response around the center, for fine
+// control, while still reaching the ends. expo is between 0, which is
+// linear, and 1, which is a cubic.
+func Expo(expo float64) Curve {
+	return func(v float64) float64 {
+		return (1-expo)*v + expo*v*v*v
+	}
+}
+
+// Axis maps an axis to a control, e.g. pitch or throttle.
+type Axis struct {
+	// Index is the number of the axis.
+	Index   int
+	Control *krpcgo.Property[float32]
+	// Deadzone is the distance from the center, between 0 and 1, within
+	// which the axis reads as zero, so that a stick at rest doesn't drift.
+	// The rest of the travel is rescaled to start from zero.
+	Deadzone float64
+	// Curve, if set, shapes the response after the deadzone.
+	Curve  Curve
+	Invert bool
+	// Throttle maps the axis' full travel to between 0 and 1, rather than
+	// -1 and 1, for throttle levers and triggers. The deadzone is then at
+	// the bottom of the travel.
+	Throttle bool
+}
+
+// value returns the control value for an axis position.
+func (a Axis) value(v float64) float64 {
+	if a.Invert {
+		v = -v
+	}
+	if a.Throttle {
+		v = (v + 1) / 2
+	}
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= a.Deadzone {
+		return 0
+	}
+	v = math.Min((v-a.Deadzone)/(1-a.Deadzone), 1)
+	if a.Curve != nil {
+		v = a.Curve(v)
+	}
+	return sign * v
+}
+
+// Button maps a button to an action. Any of its fields can be set, and they
+// are applied in order.
+type Button struct {
+	// Index is the number of the button.
+	Index int
+	// Press, if set, is called when the button is pressed, e.g. to stage.
+	Press func() error
+	// Toggle, if set, is flipped when the button is pressed, e.g. SAS.
+	Toggle *krpcgo.Property[bool]
+	// Hold, if set, is on while the button is held down, e.g. brakes.
+	Hold *krpcgo.Property[bool]
+	// Axis, if set, is set to Value while the button is held down and back
+	// to zero when it is released, e.g. to pitch with a key.
+	Axis  *krpcgo.Property[float32]
+	Value float32
+}
+
+// Mapping is a set of axes and buttons to apply to a vessel's controls.
+type Mapping struct {
+	Axes    []Axis
+	Buttons []Button
+}
@@ -0,0 +1,42 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAxisValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		axis     Axis
+		input    float64
+		expected float64
+	}{
+		{name: "linear", axis: Axis{}, input: -0.5, expected: -0.5},
+		{name: "inverted", axis: Axis{Invert: true}, input: -0.5, expected: 0.5},
+		{name: "in the deadzone", axis: Axis{Deadzone: 0.2}, input: -0.15, expected: 0},
+		{name: "past the deadzone", axis: Axis{Deadzone: 0.2}, input: 0.6, expected: 0.5},
+		{name: "end of travel", axis: Axis{Deadzone: 0.2}, input: -1, expected: -1},
+		{name: "curve", axis: Axis{Curve: Expo(1)}, input: -0.5, expected: -0.125},
+		{name: "curve after the deadzone", axis: Axis{Deadzone: 0.5, Curve: Expo(1)}, input: 0.75, expected: 0.125},
+		{name: "throttle", axis: Axis{Throttle: true}, input: 0, expected: 0.5},
+		{name: "throttle at rest", axis: Axis{Throttle: true, Deadzone: 0.1}, input: -0.9, expected: 0},
+		{name: "throttle full", axis: Axis{Throttle: true, Deadzone: 0.1}, input: 1, expected: 1},
+		{name: "inverted throttle", axis: Axis{Throttle: true, Invert: true}, input: -1, expected: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.expected, tc.axis.value(tc.input), 1e-9)
+		})
+	}
+}
+
+func TestExpo(t *testing.T) {
+	curve := Expo(0.5)
+	require.Equal(t, 1.0, curve(1))
+	require.Equal(t, -1.0, curve(-1))
+	require.Equal(t, 0.0, curve(0))
+	require.InDelta(t, 0.5*0.5+0.5*0.125, curve(0.5), 1e-9)
+	require.Equal(t, 0.3, Linear(0.3))
+}
@@ -0,0 +1,74 @@
+package input
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Event types of the Linux joystick API.
+const (
+	jsEventButton = 0x01
+	jsEventAxis   = 0x02
+	// jsEventInit marks the events sent on opening a device with the
+	// initial state of its axes and buttons.
+	jsEventInit = 0x80
+)
+
+// jsEventSize is the size of a js_event: a uint32 timestamp, an int16 value,
+// and a uint8 type and number.
+const jsEventSize = 8
+
+// Joystick is a joystick or gamepad read through the Linux joystick API,
+// e.g. /dev/input/js0. Opening it reports the initial position of each axis
+// and button before any changes.
+type Joystick struct {
+	r io.ReadCloser
+}
+
+// OpenJoystick opens a joystick device.
+func OpenJoystick(path string) (*Joystick, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &Joystick{r: f}, nil
+}
+
+// decodeJSEvent decodes a js_event, returning false for unknown types.
+func decodeJSEvent(b []byte) (Event, bool) {
+	value := int16(binary.LittleEndian.Uint16(b[4:6]))
+	index := int(b[7])
+	switch b[6] &^ jsEventInit {
+	case jsEventButton:
+		e := Event{Kind: ButtonEvent, Index: index}
+		if value != 0 {
+			e.Value = 1
+		}
+		return e, true
+	case jsEventAxis:
+		return Event{Kind: AxisEvent, Index: index, Value: math.Max(float64(value)/math.MaxInt16, -1)}, true
+	}
+	return Event{}, false
+}
+
+// Read waits for the next event.
+func (j *Joystick) Read() (Event, error) {
+	b := make([]byte, jsEventSize)
+	for {
+		if _, err := io.ReadFull(j.r, b); err != nil {
+			return Event{}, tracerr.Wrap(err)
+		}
+		if e, ok := decodeJSEvent(b); ok {
+			return e, nil
+		}
+	}
+}
+
+// Close closes the device.
+func (j *Joystick) Close() error {
+	return tracerr.Wrap(j.r.Close())
+}
@@ -0,0 +1,43 @@
+package input
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoystick(t *testing.T) {
+	var buf bytes.Buffer
+	for _, e := range [][]byte{
+		// Initial state of button 1 and axis 2.
+		{0, 0, 0, 0, 1, 0, jsEventButton | jsEventInit, 1},
+		{0, 0, 0, 0, 0x00, 0x80, jsEventAxis | jsEventInit, 2},
+		// An unknown event type is skipped.
+		{1, 0, 0, 0, 0, 0, 0x04, 0},
+		{2, 0, 0, 0, 0xff, 0x7f, jsEventAxis, 0},
+		{3, 0, 0, 0, 0, 0, jsEventButton, 1},
+	} {
+		buf.Write(e)
+	}
+	j := &Joystick{r: io.NopCloser(&buf)}
+	var events []Event
+	for {
+		e, err := j.Read()
+		if err != nil {
+			break
+		}
+		events = append(events, e)
+	}
+	require.Equal(t, []Event{
+		{Kind: ButtonEvent, Index: 1, Value: 1},
+		{Kind: AxisEvent, Index: 2, Value: -1},
+		{Kind: AxisEvent, Index: 0, Value: 1},
+		{Kind: ButtonEvent, Index: 1, Value: 0},
+	}, events)
+	require.NoError(t, j.Close())
+
+	_, err := OpenJoystick("/nonexistent/js0")
+	require.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package input
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"time"
+	"unicode"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Keys that aren't characters, as button indexes for a Keyboard. Other
+// keys' indexes are their characters, e.g. 'w', with letters in lower case.
+const (
+	KeyUp = -(iota + 1)
+	KeyDown
+	KeyRight
+	KeyLeft
+	KeyEscape
+)
+
+// KeyboardConfig configures a Keyboard.
+type KeyboardConfig struct {
+	// HoldTime is how long after a key's last press or repeat it counts as
+	// released. Terminals don't report keys being released, so a key held
+	// down shows up as its repeats, and this must be longer than the delay
+	// before a key starts repeating. Defaults to 600ms.
+	HoldTime time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *KeyboardConfig) SetDefaults() {
+	if cfg.HoldTime == 0 {
+		cfg.HoldTime = 600 * time.Millisecond
+	}
+}
+
+// Keyboard is a device whose buttons are the keys typed on a terminal. One
+// key is held at a time: pressing another releases it.
+type Keyboard struct {
+	cfg     KeyboardConfig
+	keys    chan int
+	err     error
+	closed  chan struct{}
+	restore func() error
+
+	// held is the key held down, and releaseAt when it counts as released.
+	held      int
+	holding   bool
+	releaseAt time.Time
+	// queue holds events to return before reading more keys.
+	queue []Event
+}
+
+// NewKeyboard creates a keyboard reading keys from r. The terminal should be
+// in raw mode, or keys only arrive at the end of each line.
+func NewKeyboard(r io.Reader, cfg KeyboardConfig) *Keyboard {
+	cfg.SetDefaults()
+	k := &Keyboard{
+		cfg:    cfg,
+		keys:   make(chan int),
+		closed: make(chan struct{}),
+	}
+	go k.readKeys(bufio.NewReader(r))
+	return k
+}
+
+// OpenKeyboard puts the terminal on standard input into raw mode and reads
+// keys from it. Closing the keyboard restores the terminal. Ctrl-C still
+// interrupts the program.
+func OpenKeyboard(cfg KeyboardConfig) (*Keyboard, error) {
+	restore, err := makeRaw(os.Stdin.Fd())
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	k := NewKeyboard(os.Stdin, cfg)
+	k.restore = restore
+	return k, nil
+}
+
+// readKey reads a key, decoding the escape sequences of the arrow keys. It
+// returns false for keys it doesn't know.
+func readKey(r *bufio.Reader) (int, bool, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return 0, false, tracerr.Wrap(err)
+	}
+	if c != 0x1b {
+		return int(unicode.ToLower(c)), true, nil
+	}
+	// A lone escape is the escape key. Sequences arrive all at once, so
+	// they're already buffered.
+	if r.Buffered() == 0 {
+		return KeyEscape, true, nil
+	}
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, false, tracerr.Wrap(err)
+	}
+	if b != '[' && b != 'O' {
+		return 0, false, nil
+	}
+	// Skip any parameters, e.g. for modifiers, up to the final byte.
+	for {
+		b, err = r.ReadByte()
+		if err != nil {
+			return 0, false, tracerr.Wrap(err)
+		}
+		if b >= 0x40 && b <= 0x7e {
+			break
+		}
+	}
+	switch b {
+	case 'A':
+		return KeyUp, true, nil
+	case 'B':
+		return KeyDown, true, nil
+	case 'C':
+		return KeyRight, true, nil
+	case 'D':
+		return KeyLeft, true, nil
+	}
+	return 0, false, nil
+}
+
+// readKeys sends keys to the keys channel until reading fails.
+func (k *Keyboard) readKeys(r *bufio.Reader) {
+	for {
+		key, ok, err := readKey(r)
+		if err != nil {
+			k.err = err
+			close(k.keys)
+			return
+		}
+		if !ok {
+			continue
+		}
+		select {
+		case k.keys <- key:
+		case <-k.closed:
+			return
+		}
+	}
+}
+
+// press handles a key being typed, returning the events it causes.
+func (k *Keyboard) press(key int, now time.Time) []Event {
+	var events []Event
+	if k.holding {
+		if k.held == key {
+			// A repeat of the held key.
+			k.releaseAt = now.Add(k.cfg.HoldTime)
+			return nil
+		}
+		events = append(events, Event{Kind: ButtonEvent, Index: k.held})
+	}
+	k.held, k.holding = key, true
+	k.releaseAt = now.Add(k.cfg.HoldTime)
+	return append(events, Event{Kind: ButtonEvent, Index: key, Value: 1})
+}
+
+// Read waits for the next key to be pressed or released.
+func (k *Keyboard) Read() (Event, error) {
+	for len(k.queue) == 0 {
+		var release <-chan time.Time
+		var timer *time.Timer
+		if k.holding {
+			timer = time.NewTimer(time.Until(k.releaseAt))
+			release = timer.C
+		}
+		var err error
+		select {
+		case <-k.closed:
+			err = io.EOF
+		case key, ok := <-k.keys:
+			if !ok {
+				err = k.err
+				break
+			}
+			k.queue = k.press(key, time.Now())
+		case <-release:
+			k.holding = false
+			k.queue = []Event{{Kind: ButtonEvent, Index: k.held}}
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return Event{}, tracerr.Wrap(err)
+		}
+	}
+	e := k.queue[0]
+	k.queue = k.queue[1:]
+	return e, nil
+}
+
+// Close stops reading keys and restores the terminal.
+func (k *Keyboard) Close() error {
+	select {
+	case <-k.closed:
+		return nil
+	default:
+	}
+	close(k.closed)
+	if k.restore != nil {
+		return tracerr.Wrap(k.restore())
+	}
+	return nil
+}
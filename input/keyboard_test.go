@@ -0,0 +1,66 @@
+package input
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("wW \x1b[A\x1b[1;5D\x1b[5~é\x1bOB"))
+	var keys []int
+	for {
+		key, ok, err := readKey(r)
+		if err != nil {
+			break
+		}
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	require.Equal(t, []int{'w', 'w', ' ', KeyUp, KeyLeft, 'é', KeyDown}, keys)
+
+	key, ok, err := readKey(bufio.NewReader(strings.NewReader("\x1b")))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, KeyEscape, key)
+}
+
+func TestKeyboardPress(t *testing.T) {
+	k := &Keyboard{cfg: KeyboardConfig{HoldTime: time.Second}}
+	now := time.Now()
+	require.Equal(t, []Event{{Kind: ButtonEvent, Index: 'w', Value: 1}}, k.press('w', now))
+	// Repeats keep the key held.
+	require.Empty(t, k.press('w', now.Add(500*time.Millisecond)))
+	require.Equal(t, now.Add(1500*time.Millisecond), k.releaseAt)
+	// Another key releases it.
+	require.Equal(t, []Event{
+		{Kind: ButtonEvent, Index: 'w'},
+		{Kind: ButtonEvent, Index: 'a', Value: 1},
+	}, k.press('a', now.Add(time.Second)))
+}
+
+func TestKeyboard(t *testing.T) {
+	r, w := io.Pipe()
+	k := NewKeyboard(r, KeyboardConfig{HoldTime: 20 * time.Millisecond})
+	go func() {
+		_, _ = w.Write([]byte("w"))
+	}()
+	e, err := k.Read()
+	require.NoError(t, err)
+	require.Equal(t, Event{Kind: ButtonEvent, Index: 'w', Value: 1}, e)
+	// Without repeats, the key is released after the hold time.
+	e, err = k.Read()
+	require.NoError(t, err)
+	require.Equal(t, Event{Kind: ButtonEvent, Index: 'w'}, e)
+
+	require.NoError(t, w.Close())
+	_, err = k.Read()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, k.Close())
+	require.NoError(t, k.Close())
+}
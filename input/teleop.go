@@ -0,0 +1,95 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// Nudge returns a function that moves a control by a step, keeping it
+// between min and max, for use as a button's Press.
+func Nudge(control *krpcgo.Property[float32], step, min, max float32) func() error {
+	return func() error {
+		v, err := control.Get()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		v = float32(math.Max(float64(min), math.Min(float64(max), float64(v+step))))
+		return tracerr.Wrap(control.Set(v))
+	}
+}
+
+// TeleopKeys describes the keys of Teleop's mapping.
+const TeleopKeys = `w/s pitch   a/d yaw   q/e roll
+h/n forward/back   i/k up/down   j/l left/right
+z full throttle   x cut throttle   +/- throttle by 10%
+space stage   t SAS   r RCS   g gear   b brakes (hold)   u lights
+1-9, 0 action groups   Esc stop`
+
+// Teleop returns a mapping from a Keyboard's keys to a vessel's controls,
+// like the game's own keys. Axis keys are at full deflection while held.
+// See TeleopKeys for the keys.
+func Teleop(control *spacecenter.Control) Mapping {
+	throttle := control.ThrottleProp()
+	keyAxis := func(key rune, prop *krpcgo.Property[float32], value float32) Button {
+		return Button{Index: int(key), Axis: prop, Value: value}
+	}
+	m := Mapping{Buttons: []Button{
+		keyAxis('w', control.PitchProp(), -1),
+		keyAxis('s', control.PitchProp(), 1),
+		keyAxis('a', control.YawProp(), -1),
+		keyAxis('d', control.YawProp(), 1),
+		keyAxis('q', control.RollProp(), -1),
+		keyAxis('e', control.RollProp(), 1),
+		keyAxis('h', control.ForwardProp(), 1),
+		keyAxis('n', control.ForwardProp(), -1),
+		keyAxis('i', control.UpProp(), 1),
+		keyAxis('k', control.UpProp(), -1),
+		keyAxis('j', control.RightProp(), -1),
+		keyAxis('l', control.RightProp(), 1),
+		{Index: 'z', Press: func() error { return tracerr.Wrap(throttle.Set(1)) }},
+		{Index: 'x', Press: func() error { return tracerr.Wrap(throttle.Set(0)) }},
+		{Index: '+', Press: Nudge(throttle, 0.1, 0, 1)},
+		{Index: '=', Press: Nudge(throttle, 0.1, 0, 1)},
+		{Index: '-', Press: Nudge(throttle, -0.1, 0, 1)},
+		{Index: ' ', Press: Stage(control)},
+		{Index: 't', Toggle: control.SASProp()},
+		{Index: 'r', Toggle: control.RCSProp()},
+		{Index: 'g', Toggle: control.GearProp()},
+		{Index: 'b', Hold: control.BrakesProp()},
+		{Index: 'u', Toggle: control.LightsProp()},
+	}}
+	for group := uint32(0); group <= 9; group++ {
+		m.Buttons = append(m.Buttons, Button{Index: int('0' + group), Toggle: ActionGroup(control, group)})
+	}
+	return m
+}
+
+// errStopped stops a teleoperation bridge when escape is pressed.
+var errStopped = errors.New("teleoperation stopped")
+
+// Teleoperate hands a vessel's controls to the keyboard on standard input,
+// e.g. for a quick manual correction during a scripted mission, until
+// escape is pressed or the context is canceled. The keys are written to w
+// first. The steering axes are centered when it returns, but the throttle is
+// left as it is.
+func Teleoperate(ctx context.Context, control *spacecenter.Control, w io.Writer) error {
+	keyboard, err := OpenKeyboard(KeyboardConfig{})
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	fmt.Fprintf(w, "Teleoperation:\n%s\n", TeleopKeys)
+	m := Teleop(control)
+	m.Buttons = append(m.Buttons, Button{Index: KeyEscape, Press: func() error { return errStopped }})
+	err = NewBridge(keyboard, m, BridgeConfig{}).Run(ctx)
+	if errors.Is(err, errStopped) {
+		return nil
+	}
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,28 @@
+package input
+
+import (
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNudge(t *testing.T) {
+	var throttle float32 = 0.95
+	prop := krpcgo.NewProperty(
+		func() (float32, error) { return throttle, nil },
+		func(v float32) error {
+			throttle = v
+			return nil
+		},
+		nil,
+	)
+	up, down := Nudge(prop, 0.1, 0, 1), Nudge(prop, -0.5, 0, 1)
+	require.NoError(t, up())
+	require.Equal(t, float32(1), throttle)
+	require.NoError(t, down())
+	require.Equal(t, float32(0.5), throttle)
+	require.NoError(t, down())
+	require.NoError(t, down())
+	require.Equal(t, float32(0), throttle)
+}
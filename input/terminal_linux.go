@@ -0,0 +1,32 @@
+package input
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/ztrue/tracerr"
+)
+
+// makeRaw puts a terminal into raw mode, so that keys are read as they're
+// typed without being echoed, and returns a function restoring it. Signals
+// such as Ctrl-C are left on.
+func makeRaw(fd uintptr) (func() error, error) {
+	var old syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&old))); errno != 0 {
+		return nil, tracerr.Errorf("Standard input isn't a terminal: %v", errno)
+	}
+	raw := old
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.IEXTEN
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&raw))); errno != 0 {
+		return nil, tracerr.Wrap(errno)
+	}
+	return func() error {
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&old))); errno != 0 {
+			return tracerr.Wrap(errno)
+		}
+		return nil
+	}, nil
+}
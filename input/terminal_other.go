@@ -0,0 +1,10 @@
+//go:build !linux
+
+package input
+
+import "github.com/ztrue/tracerr"
+
+// makeRaw puts a terminal into raw mode. It is only supported on Linux.
+func makeRaw(fd uintptr) (func() error, error) {
+	return nil, tracerr.Errorf("Reading keys from a terminal isn't supported on this platform")
+}
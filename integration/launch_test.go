@@ -7,9 +7,10 @@ import (
 	"time"
 
 	krpcgo "github.com/atburke/krpc-go"
-	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/integrationtest"
 	"github.com/atburke/krpc-go/spacecenter"
 	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/warp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,15 +19,10 @@ import (
 // from https://krpc.github.io/krpc/tutorials/launch-into-orbit.html. This
 // function is tested with the Kerbal X starting on the KSC launchpad.
 func TestLaunch(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background())
-	t.Cleanup(cancel)
-
-	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{})
-	require.NoError(t, client.Connect(ctx))
-	t.Logf("Connected to %s:%s", client.Host, client.RPCPort)
+	client := integrationtest.Connect(t, krpcgo.KRPCClientConfig{})
 
-	krpcService := krpc.New(client)
 	// TODO: SetPaused causes problems with current mod version if called while at space center :(
+	// krpcService := krpc.New(client)
 	// require.NoError(t, krpcService.SetPaused(false))
 	// t.Cleanup(func() {
 	// 	require.NoError(t, krpcService.SetPaused(true))
@@ -36,71 +32,35 @@ func TestLaunch(t *testing.T) {
 	sc := spacecenter.New(client)
 	t.Log("Loading Space Center")
 	require.NoError(t, sc.LoadSpaceCenter())
-	t.Log("Requesting list of launchable vessels")
-	vv, err := sc.LaunchableVessels("VAB")
-	require.NoError(t, err)
-	require.Contains(t, vv, "Kerbal X", "Current game doesn't have Kerbal X avaiable")
-
-	// TODO: would be nice if kRPC had a way to get the whole roster
-	k, err := sc.GetKerbal("Tester Kerman")
-	require.NoError(t, err)
-	if k.ID_internal() == 0 { //null :)
-		t.Log("Creating Tester Kerman")
-		require.NoError(t, sc.CreateKerbal("Tester Kerman", "Pilot", true))
-	}
-	_, err = sc.GetKerbal("Tester Kerman")
-	require.NoError(t, err)
+	integrationtest.EnsureKerbal(t, sc, "Tester Kerman", "Pilot")
 
 	t.Log("Loading Kerbal X on the Launch Pad")
 	require.NoError(t, sc.LaunchVessel("VAB", "Kerbal X", "LaunchPad", true, []string{"Tester Kerman"}, ""))
 
 	t.Log("Switching back to Space Center leaving vessel on pad")
 	require.NoError(t, sc.LoadSpaceCenter())
-
-	k, err = sc.GetKerbal("Tester2 Kerman")
-	require.NoError(t, err)
-	if k.ID_internal() == 0 { //null :)
-		t.Log("Creating Tester2 Kerman")
-		require.NoError(t, sc.CreateKerbal("Tester2 Kerman", "Pilot", true))
-	}
-	_, err = sc.GetKerbal("Tester2 Kerman")
-	require.NoError(t, err)
+	integrationtest.EnsureKerbal(t, sc, "Tester2 Kerman", "Pilot")
 
 	t.Log("Loading Kerbal X on the Launch Pad again, expecting an error")
 	require.Error(t, sc.LaunchVessel("VAB", "Kerbal X", "LaunchPad", false, []string{"Tester2 Kerman"}, ""),
 		"Expected an error due to launch pad not being clear")
 
-	t.Log("Loading Kerbal X on the Launch Pad again again")
-	require.NoError(t, sc.LaunchVessel("VAB", "Kerbal X", "LaunchPad", true, []string{"Tester2 Kerman"}, ""))
-
-	gamescene, err := krpcService.CurrentGameScene()
-	require.NoError(t, err)
-	require.Equal(t, krpc.GameScene_Flight, gamescene, "Expected to be Flight scene")
-
-	vessel, err := sc.ActiveVessel()
-	require.NoError(t, err)
+	vessel := integrationtest.LaunchFromVAB(t, sc, "Kerbal X", "Tester2 Kerman")
+	// Cancelled before the flight is reverted, to stop autostaging first.
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
 	vesselName, err := vessel.Name()
 	require.NoError(t, err)
 	t.Logf("Current vessel name: %s", vesselName)
 
 	t.Log("Setting up for launch")
-	rf, err := vessel.SurfaceReferenceFrame()
-	require.NoError(t, err)
-	flight, err := vessel.Flight(rf)
-	require.NoError(t, err)
 	orbit, err := vessel.Orbit()
 	require.NoError(t, err)
 
-	altitudeStream, err := flight.MeanAltitudeStream()
-	require.NoError(t, err)
 	apoapsisStream, err := orbit.ApoapsisAltitudeStream()
 	require.NoError(t, err)
-	qStream, err := flight.DynamicPressureStream()
-	require.NoError(t, err)
 	t.Cleanup(func() {
-		require.NoError(t, altitudeStream.Close())
 		require.NoError(t, apoapsisStream.Close())
-		require.NoError(t, qStream.Close())
 	})
 
 	control, err := vessel.Control()
@@ -119,92 +79,28 @@ func TestLaunch(t *testing.T) {
 	require.NoError(t, autopilot.TargetPitchAndHeading(90.0, 90))
 
 	// Autostaging
+	stager := spacecenter.NewStager(vessel, spacecenter.StagingConfig{})
+	stager.OnStage = func(stage int32) {
+		t.Logf("current stage is %v", stage)
+	}
 	go func() {
-		t.Log("Autostaging gofunc starting")
+		t.Log("Autostaging starting")
 		defer t.Log("Autostaging finished")
-		stage, err := control.CurrentStage()
-		require.NoError(t, err)
-
-		for {
-			t.Logf("current stage is %v\n", stage)
-			resources, err := vessel.ResourcesInDecoupleStage(stage-1, false)
-			require.NoError(t, err)
-			amountStream, err := resources.AmountStream("LiquidFuel")
-			require.NoError(t, err)
-
-		readAmount:
-			for {
-				select {
-				case amount := <-amountStream.C:
-					if amount < 0.1 {
-						_, err = control.ActivateNextStage()
-						require.NoError(t, amountStream.Close())
-						require.NoError(t, err)
-						stage--
-						if stage == 0 {
-							return
-						}
-						break readAmount
-					}
-				case <-ctx.Done():
-					return
-				}
-			}
-
+		if err := stager.Run(ctx); err != nil && ctx.Err() == nil {
+			t.Errorf("Autostaging failed: %v", err)
 		}
 	}()
 
-	turnStartAltitude := 250.0
-	turnEndAltitude := 45000.0
 	targetAltitude := 150000.0
-
-	turnAngle := 0.0
+	ascent := spacecenter.NewAscent(vessel, spacecenter.AscentConfig{
+		TargetAltitude: targetAltitude,
+		Pitch:          spacecenter.GravityTurn(250, 45000),
+		Throttle:       spacecenter.ThrottlePlan{MaxQ: 20000},
+	})
+	t.Logf("Ascending until apoapsis >= %0.2f", targetAltitude)
+	require.NoError(t, ascent.Run(ctx))
 	var apoapsis float64
 
-	limitingThrottle := false
-
-	t.Logf("Waiting for appoapsis >= %0.2f", 0.9*targetAltitude)
-	for apoapsis < 0.9*targetAltitude {
-		select {
-		// Manage heading
-		case altitude := <-altitudeStream.C:
-			if altitude < turnStartAltitude || altitude > turnEndAltitude {
-				continue
-			}
-			frac := (altitude - turnStartAltitude) / (turnEndAltitude - turnStartAltitude)
-			newTurnAngle := frac * 90
-			if math.Abs(newTurnAngle-turnAngle) > 0.5 {
-				turnAngle = newTurnAngle
-				require.NoError(t, autopilot.TargetPitchAndHeading(float32(90-turnAngle), 90))
-			}
-		case apoapsis = <-apoapsisStream.C:
-
-			// Lazy Q limiting
-		case q := <-qStream.C:
-			if q >= 20000 && !limitingThrottle {
-				limitingThrottle = true
-				require.NoError(t, control.SetThrottle(0.5))
-			} else if q < 20000 && limitingThrottle {
-				limitingThrottle = false
-				require.NoError(t, control.SetThrottle(1.0))
-			}
-		case <-ctx.Done():
-			return
-		}
-	}
-	t.Logf("Appoapsis hit target: %0.2f", apoapsis)
-
-	t.Log("Fine tunning appoapsis approach")
-	require.NoError(t, control.SetThrottle(0.25))
-	for apoapsis < targetAltitude {
-		select {
-		case apoapsis = <-apoapsisStream.C:
-		case <-ctx.Done():
-			return
-		}
-	}
-	require.NoError(t, control.SetThrottle(0))
-
 	t.Log("Coasting to edge of the atmosphere")
 	for apoapsis < 70500 {
 		select {
@@ -235,16 +131,10 @@ func TestLaunch(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Log("Calculating burn time")
-	f, err := vessel.AvailableThrust()
-	require.NoError(t, err)
-	rawISP, err := vessel.SpecificImpulse()
-	require.NoError(t, err)
-	isp := float64(rawISP * 9.82)
-	m0, err := vessel.Mass()
+	estimate, err := spacecenter.EstimateVesselBurn(vessel, deltaV)
 	require.NoError(t, err)
-	m1 := float64(m0) / math.Exp(deltaV/isp)
-	flowRate := float64(f) / isp
-	burnTime := (float64(m0) - m1) / flowRate
+	require.Zero(t, estimate.Shortfall)
+	burnTime := estimate.Duration
 
 	t.Log("Orienting ship")
 	require.NoError(t, control.SetRCS(true))
@@ -259,9 +149,10 @@ func TestLaunch(t *testing.T) {
 	require.NoError(t, err)
 	timeToApoapsis, err = orbit.TimeToApoapsis()
 	require.NoError(t, err)
-	burnUT := ut + timeToApoapsis - (burnTime / 2)
+	burnUT := ut + timeToApoapsis - estimate.HalfDuration
 	leadTime := float64(5)
-	require.NoError(t, sc.WarpTo(burnUT-leadTime, 10, 1))
+	warper := warp.New(sc, vessel, warp.Config{MaxRailsFactor: 2, MaxPhysicsFactor: -1})
+	require.NoError(t, warper.WarpTo(ctx, burnUT-leadTime))
 
 	t.Log("Executing burn")
 	timeToApoapsisStream, err := orbit.TimeToApoapsisStream()
@@ -269,7 +160,7 @@ func TestLaunch(t *testing.T) {
 	t.Cleanup(func() {
 		require.NoError(t, timeToApoapsisStream.Close())
 	})
-	for timeToApoapsis-(burnTime/2) > 0 {
+	for timeToApoapsis-estimate.HalfDuration > 0 {
 		select {
 		case timeToApoapsis = <-timeToApoapsisStream.C:
 		case <-ctx.Done():
@@ -0,0 +1,112 @@
+// Package integrationtest provides helpers for tests against a running game,
+// such as those in this module's integration directory:
+//
+//	func TestOrbit(t *testing.T) {
+//		client := integrationtest.Connect(t, krpcgo.KRPCClientConfig{})
+//		sc := spacecenter.New(client)
+//		integrationtest.EnsureKerbal(t, sc, "Tester Kerman", "Pilot")
+//		vessel := integrationtest.LaunchFromVAB(t, sc, "Kerbal X", "Tester Kerman")
+//		...
+//	}
+//
+// Tests are skipped when there is no server to connect to, so they can live
+// alongside unit tests.
+package integrationtest
+
+import (
+	"context"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// Connect connects to the server, skipping the test if there isn't one. The
+// client is closed when the test finishes.
+func Connect(t testing.TB, cfg krpcgo.KRPCClientConfig) *krpcgo.KRPCClient {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	client := krpcgo.NewKRPCClient(cfg)
+	if err := client.Connect(ctx); err != nil {
+		t.Skipf("No kRPC server at %s:%s: %v", client.Host, client.RPCPort, err)
+	}
+	t.Cleanup(func() { client.Close() })
+	t.Logf("Connected to %s:%s", client.Host, client.RPCPort)
+	return client
+}
+
+// EnsureKerbal creates a kerbal with a job, such as "Pilot", if there isn't
+// one with the name already.
+func EnsureKerbal(t testing.TB, sc *spacecenter.SpaceCenter, name, job string) {
+	t.Helper()
+	kerbal, err := sc.GetKerbal(name)
+	require.NoError(t, err)
+	if kerbal != nil {
+		return
+	}
+	t.Logf("Creating %s", name)
+	require.NoError(t, sc.CreateKerbal(name, job, true))
+	kerbal, err = sc.GetKerbal(name)
+	require.NoError(t, err)
+	require.NotNil(t, kerbal, "%s wasn't created", name)
+}
+
+// LaunchFromVAB launches a vessel from the VAB onto the launch pad with a
+// crew, recovering anything already on the pad, and returns it once the
+// game is in flight. The flight is reverted to launch when the test
+// finishes, so that it doesn't clutter the save; see RevertOnCleanup.
+func LaunchFromVAB(t testing.TB, sc *spacecenter.SpaceCenter, name string, crew ...string) *spacecenter.Vessel {
+	t.Helper()
+	vessels, err := sc.LaunchableVessels("VAB")
+	require.NoError(t, err)
+	require.Contains(t, vessels, name, "Current game doesn't have %s available", name)
+
+	t.Logf("Loading %s on the launch pad", name)
+	require.NoError(t, sc.LaunchVessel("VAB", name, "LaunchPad", true, crew, ""))
+	RevertOnCleanup(t, sc)
+	scene, err := krpc.New(sc.Client).CurrentGameScene()
+	require.NoError(t, err)
+	require.Equal(t, krpc.GameScene_Flight, scene, "Expected to be in the flight scene")
+
+	vessel, err := sc.ActiveVessel()
+	require.NoError(t, err)
+	return vessel
+}
+
+// RevertOnCleanup reverts the current flight to launch when the test
+// finishes, if it can be reverted.
+func RevertOnCleanup(t testing.TB, sc *spacecenter.SpaceCenter) {
+	t.Cleanup(func() {
+		ok, err := sc.CanRevertToLaunch()
+		if err != nil {
+			t.Errorf("Failed to check if the flight can be reverted: %v", err)
+			return
+		}
+		if !ok {
+			return
+		}
+		t.Log("Reverting to launch")
+		if err := sc.RevertToLaunch(); err != nil {
+			t.Errorf("Failed to revert to launch: %v", err)
+		}
+	})
+}
+
+// RestoreScenario restores a saved scenario, skipping the test if it hasn't
+// been saved, and waits for the game to settle. Whether it has been saved
+// can only be checked if the scenarios have the save game's folder. Handles
+// from before restoring are stale; use the active vessel in the result.
+func RestoreScenario(t testing.TB, scenarios *spacecenter.Scenarios, name string) *spacecenter.Reload {
+	t.Helper()
+	ok, err := scenarios.Exists(name)
+	if err == nil && !ok {
+		t.Skipf("Scenario %q hasn't been saved", name)
+	}
+	t.Logf("Restoring scenario %s", name)
+	r, err := scenarios.RestoreAndResolve(context.Background(), name, spacecenter.ReloadConfig{})
+	require.NoError(t, err)
+	return r
+}
@@ -0,0 +1,135 @@
+package integrationtest
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// procedures returns the procedures called on a server, in order.
+func procedures(server *krpctest.Server) []string {
+	var names []string
+	for _, call := range server.Calls() {
+		names = append(names, call.Procedure)
+	}
+	return names
+}
+
+func TestConnect(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	client := Connect(t, server.ClientConfig())
+	ut, err := spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+}
+
+func TestConnectSkips(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	connected := false
+	t.Run("no server", func(t *testing.T) {
+		Connect(t, krpcgo.KRPCClientConfig{Host: host, RPCPort: port, StreamPort: port})
+		connected = true
+	})
+	require.False(t, connected)
+}
+
+func TestEnsureKerbal(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	created := false
+	server.Handle("SpaceCenter", "GetKerbal", func(*krpctest.Call) (interface{}, error) {
+		if created {
+			return uint64(5), nil
+		}
+		return uint64(0), nil
+	})
+	server.Handle("SpaceCenter", "CreateKerbal", func(call *krpctest.Call) (interface{}, error) {
+		created = true
+		return nil, nil
+	})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc := spacecenter.New(client)
+
+	EnsureKerbal(t, sc, "Tester Kerman", "Pilot")
+	EnsureKerbal(t, sc, "Tester Kerman", "Pilot")
+	require.Equal(t, []string{"GetKerbal", "CreateKerbal", "GetKerbal", "GetKerbal"}, procedures(server))
+	var name, job string
+	require.NoError(t, server.Calls()[1].Arg(0, &name))
+	require.NoError(t, server.Calls()[1].Arg(1, &job))
+	require.Equal(t, "Tester Kerman", name)
+	require.Equal(t, "Pilot", job)
+}
+
+func TestLaunchFromVAB(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "LaunchableVessels", []string{"Kerbal X"})
+	server.Return("SpaceCenter", "LaunchVessel", nil)
+	server.Return("KRPC", "get_CurrentGameScene", int32(1))
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(7))
+	server.Return("SpaceCenter", "CanRevertToLaunch", true)
+	server.Return("SpaceCenter", "RevertToLaunch", nil)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc := spacecenter.New(client)
+
+	t.Run("launch", func(t *testing.T) {
+		vessel := LaunchFromVAB(t, sc, "Kerbal X", "Tester Kerman")
+		require.Equal(t, uint64(7), vessel.ID_internal())
+	})
+	require.Equal(t, []string{
+		"LaunchableVessels", "LaunchVessel", "get_CurrentGameScene", "get_ActiveVessel",
+		"CanRevertToLaunch", "RevertToLaunch",
+	}, procedures(server))
+	var crew []string
+	require.NoError(t, server.Calls()[1].Arg(4, &crew))
+	require.Equal(t, []string{"Tester Kerman"}, crew)
+}
+
+func TestRestoreScenario(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "Load", nil)
+	server.Return("KRPC", "get_CurrentGameScene", krpc.GameScene_SpaceCenter)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc := spacecenter.New(client)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scenario-orbit.sfs"), nil, 0o644))
+	scenarios := spacecenter.NewScenarios(sc, spacecenter.ScenariosConfig{Dir: dir})
+	restored := false
+	t.Run("missing", func(t *testing.T) {
+		RestoreScenario(t, scenarios, "landed")
+		restored = true
+	})
+	require.False(t, restored)
+
+	r := RestoreScenario(t, scenarios, "orbit")
+	require.Equal(t, krpc.GameScene_SpaceCenter, r.Scene)
+	// The game is checked until it has settled after loading.
+	require.Equal(t, []string{"Load", "get_CurrentGameScene", "get_CurrentGameScene", "get_CurrentGameScene"}, procedures(server))
+	var name string
+	require.NoError(t, server.Calls()[0].Arg(0, &name))
+	require.Equal(t, "scenario-orbit", name)
+}
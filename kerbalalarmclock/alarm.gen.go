@@ -0,0 +1,1189 @@
+package kerbalalarmclock
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Alarm - represents an alarm. Obtained by calling <see
+// cref="M:KerbalAlarmClock.Alarms" />, <see
+// cref="M:KerbalAlarmClock.AlarmWithName" /> or <see
+// cref="M:KerbalAlarmClock.AlarmsWithType" />.
+type Alarm struct {
+	service.BaseClass
+}
+
+// NewAlarm creates a new Alarm.
+func NewAlarm(id uint64, client *krpcgo.KRPCClient) *Alarm {
+	c := &Alarm{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Remove - removes the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Remove() error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_Remove",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// Action - the action that the alarm triggers.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Action() (AlarmAction, error) {
+	var err error
+	var argBytes []byte
+	var vv AlarmAction
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Action",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ActionStream - the action that the alarm triggers.
+//
+// Allowed game scenes: any.
+func (s *Alarm) ActionStream() (*krpcgo.Stream[AlarmAction], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Action",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) AlarmAction {
+		var value AlarmAction
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetAction - the action that the alarm triggers.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetAction(value AlarmAction) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Action",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ActionProp - returns a handle to the Action property.
+func (s *Alarm) ActionProp() *krpcgo.Property[AlarmAction] {
+	return krpcgo.NewProperty(s.Action, s.SetAction, s.ActionStream)
+}
+
+// Margin - the number of seconds before the event that the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Margin() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Margin",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// MarginStream - the number of seconds before the event that the alarm will
+// fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) MarginStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Margin",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetMargin - the number of seconds before the event that the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetMargin(value float64) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Margin",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// MarginProp - returns a handle to the Margin property.
+func (s *Alarm) MarginProp() *krpcgo.Property[float64] {
+	return krpcgo.NewProperty(s.Margin, s.SetMargin, s.MarginStream)
+}
+
+// Time - the time at which the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Time() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Time",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TimeStream - the time at which the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) TimeStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Time",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetTime - the time at which the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetTime(value float64) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Time",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// TimeProp - returns a handle to the Time property.
+func (s *Alarm) TimeProp() *krpcgo.Property[float64] {
+	return krpcgo.NewProperty(s.Time, s.SetTime, s.TimeStream)
+}
+
+// Type - the type of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Type() (AlarmType, error) {
+	var err error
+	var argBytes []byte
+	var vv AlarmType
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Type",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TypeStream - the type of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) TypeStream() (*krpcgo.Stream[AlarmType], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Type",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) AlarmType {
+		var value AlarmType
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// ID - the unique identifier for the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) ID() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_ID",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// IDStream - the unique identifier for the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) IDStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_ID",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Name - the short name of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Name() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Name",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// NameStream - the short name of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) NameStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Name",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetName - the short name of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetName(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Name",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// NameProp - returns a handle to the Name property.
+func (s *Alarm) NameProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Name, s.SetName, s.NameStream)
+}
+
+// Notes - the long description of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Notes() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Notes",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// NotesStream - the long description of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) NotesStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Notes",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetNotes - the long description of the alarm.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetNotes(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Notes",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// NotesProp - returns a handle to the Notes property.
+func (s *Alarm) NotesProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.Notes, s.SetNotes, s.NotesStream)
+}
+
+// Remaining - the number of seconds until the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Remaining() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Remaining",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// RemainingStream - the number of seconds until the alarm will fire.
+//
+// Allowed game scenes: any.
+func (s *Alarm) RemainingStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Remaining",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Repeat - whether the alarm will be repeated after it has fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Repeat() (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Repeat",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// RepeatStream - whether the alarm will be repeated after it has fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) RepeatStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Repeat",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetRepeat - whether the alarm will be repeated after it has fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetRepeat(value bool) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Repeat",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// RepeatProp - returns a handle to the Repeat property.
+func (s *Alarm) RepeatProp() *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(s.Repeat, s.SetRepeat, s.RepeatStream)
+}
+
+// RepeatPeriod - the time delay to automatically create an alarm after it has
+// fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) RepeatPeriod() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_RepeatPeriod",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// RepeatPeriodStream - the time delay to automatically create an alarm after it
+// has fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) RepeatPeriodStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_RepeatPeriod",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetRepeatPeriod - the time delay to automatically create an alarm after it
+// has fired.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetRepeatPeriod(value float64) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_RepeatPeriod",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// RepeatPeriodProp - returns a handle to the RepeatPeriod property.
+func (s *Alarm) RepeatPeriodProp() *krpcgo.Property[float64] {
+	return krpcgo.NewProperty(s.RepeatPeriod, s.SetRepeatPeriod, s.RepeatPeriodStream)
+}
+
+// Vessel - the vessel that the alarm is attached to.
+//
+// Allowed game scenes: any.
+func (s *Alarm) Vessel() (*spacecenter.Vessel, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.Vessel
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_Vessel",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetVessel - the vessel that the alarm is attached to.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetVessel(value *spacecenter.Vessel) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_Vessel",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// VesselProp - returns a handle to the Vessel property.
+func (s *Alarm) VesselProp() *krpcgo.Property[*spacecenter.Vessel] {
+	return krpcgo.NewProperty(s.Vessel, s.SetVessel, nil)
+}
+
+// XferOriginBody - the celestial body the vessel is departing from.
+//
+// Allowed game scenes: any.
+func (s *Alarm) XferOriginBody() (*spacecenter.CelestialBody, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.CelestialBody
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_XferOriginBody",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetXferOriginBody - the celestial body the vessel is departing from.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetXferOriginBody(value *spacecenter.CelestialBody) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_XferOriginBody",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// XferOriginBodyProp - returns a handle to the XferOriginBody property.
+func (s *Alarm) XferOriginBodyProp() *krpcgo.Property[*spacecenter.CelestialBody] {
+	return krpcgo.NewProperty(s.XferOriginBody, s.SetXferOriginBody, nil)
+}
+
+// XferTargetBody - the celestial body the vessel is arriving at.
+//
+// Allowed game scenes: any.
+func (s *Alarm) XferTargetBody() (*spacecenter.CelestialBody, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.CelestialBody
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_get_XferTargetBody",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetXferTargetBody - the celestial body the vessel is arriving at.
+//
+// Allowed game scenes: any.
+func (s *Alarm) SetXferTargetBody(value *spacecenter.CelestialBody) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Alarm_set_XferTargetBody",
+		Service:   "KerbalAlarmClock",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// XferTargetBodyProp - returns a handle to the XferTargetBody property.
+func (s *Alarm) XferTargetBodyProp() *krpcgo.Property[*spacecenter.CelestialBody] {
+	return krpcgo.NewProperty(s.XferTargetBody, s.SetXferTargetBody, nil)
+}
@@ -0,0 +1,202 @@
+// Package kos provides methods to invoke procedures in the KOS service.
+//
+// From service docs: provides access to kOS processors, so that kOS scripts can
+// be run and their variables read and written.
+package kos
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// ProcessorMode - the power state of a kOS processor.
+type ProcessorMode int32
+
+const (
+	// The processor is turned off.
+	ProcessorMode_Off ProcessorMode = 0
+	// The processor is powered and ready.
+	ProcessorMode_Ready ProcessorMode = 1
+	// The processor is out of electric charge.
+	ProcessorMode_Starved ProcessorMode = 2
+)
+
+func (v ProcessorMode) Value() int32 {
+	return int32(v)
+}
+func (v *ProcessorMode) SetValue(val int32) {
+	*v = ProcessorMode(val)
+}
+
+// KOS - provides access to kOS processors, so that kOS scripts can be run and
+// their variables read and written.
+type KOS struct {
+	Client *krpcgo.KRPCClient
+}
+
+// New creates a new KOS.
+func New(client *krpcgo.KRPCClient) *KOS {
+	return &KOS{Client: client}
+}
+
+// Available checks if the KOS service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "KOS")
+}
+
+// Available - whether kOS is installed.
+//
+// Allowed game scenes: any.
+func (s *KOS) Available() (bool, error) {
+	var err error
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "KOS",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AvailableStream - whether kOS is installed.
+//
+// Allowed game scenes: any.
+func (s *KOS) AvailableStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Available",
+		Service:   "KOS",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Processors - get all kOS processors on a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *KOS) Processors(vessel *spacecenter.Vessel) ([]*Processor, error) {
+	var err error
+	var argBytes []byte
+	var vv []*Processor
+	request := &types.ProcedureCall{
+		Procedure: "Processors",
+		Service:   "KOS",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	for _, v := range vv {
+		v.Client = s.Client
+	}
+	return vv, nil
+}
+
+// ProcessorsStream - get all kOS processors on a vessel.
+//
+// Allowed game scenes: FLIGHT.
+func (s *KOS) ProcessorsStream(vessel *spacecenter.Vessel) (*krpcgo.Stream[[]*Processor], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Processors",
+		Service:   "KOS",
+	}
+	argBytes, err = encode.Marshal(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []*Processor {
+		var value []*Processor
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Processor - get the kOS processor for a part, or nil if the part isn't a kOS
+// processor.
+//
+// Allowed game scenes: FLIGHT.
+func (s *KOS) Processor(part *spacecenter.Part) (*Processor, error) {
+	var err error
+	var argBytes []byte
+	var vv Processor
+	request := &types.ProcedureCall{
+		Procedure: "Processor",
+		Service:   "KOS",
+	}
+	argBytes, err = encode.Marshal(part)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
@@ -0,0 +1,65 @@
+package kos
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// impactVariable is the kOS global that holds the predicted impact position.
+const impactVariable = "krpcgo_impact"
+
+// impactTrigger is a kOS trigger that keeps impactVariable up to date with
+// the Trajectories addon's prediction, as "lat,lng", or "" if there isn't one.
+const impactTrigger = `WHEN TRUE THEN { ` +
+	`IF ADDONS:TR:AVAILABLE AND ADDONS:TR:HASIMPACT { ` +
+	`SET ` + impactVariable + ` TO ADDONS:TR:IMPACTPOS:LAT + "," + ADDONS:TR:IMPACTPOS:LNG. ` +
+	`} ELSE { SET ` + impactVariable + ` TO "". } ` +
+	`RETURN TRUE. }`
+
+// TrajectoriesPredictor predicts landing positions with the Trajectories mod,
+// which kRPC can't reach directly, through kOS's Trajectories addon. It
+// implements [spacecenter.LandingPredictor].
+type TrajectoriesPredictor struct {
+	processor *Processor
+}
+
+// NewTrajectoriesPredictor starts tracking the Trajectories prediction on a
+// kOS processor. The processor must be idle, since the tracking runs as a
+// trigger from its terminal.
+func NewTrajectoriesPredictor(processor *Processor) (*TrajectoriesPredictor, error) {
+	if err := processor.Execute(impactTrigger); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &TrajectoriesPredictor{processor: processor}, nil
+}
+
+// PredictLanding gets the latest predicted landing position. Returns false if
+// Trajectories isn't installed or doesn't predict an impact.
+func (t *TrajectoriesPredictor) PredictLanding() (spacecenter.LandingPrediction, bool, error) {
+	value, err := t.processor.GetVariable(impactVariable)
+	if err != nil {
+		return spacecenter.LandingPrediction{}, false, tracerr.Wrap(err)
+	}
+	prediction, ok := parseImpact(value)
+	return prediction, ok, nil
+}
+
+// parseImpact parses an impact position formatted as "lat,lng".
+func parseImpact(value string) (spacecenter.LandingPrediction, bool) {
+	lat, lng, found := strings.Cut(strings.Trim(value, `" `), ",")
+	if !found {
+		return spacecenter.LandingPrediction{}, false
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return spacecenter.LandingPrediction{}, false
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return spacecenter.LandingPrediction{}, false
+	}
+	return spacecenter.LandingPrediction{Latitude: latitude, Longitude: longitude}, true
+}
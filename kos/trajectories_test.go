@@ -0,0 +1,28 @@
+package kos
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImpact(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected spacecenter.LandingPrediction
+		ok       bool
+	}{
+		{value: "-0.0972,-74.5577", expected: spacecenter.LandingPrediction{Latitude: -0.0972, Longitude: -74.5577}, ok: true},
+		{value: `"12.5, 30"`, expected: spacecenter.LandingPrediction{Latitude: 12.5, Longitude: 30}, ok: true},
+		{value: ""},
+		{value: "nope,1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			prediction, ok := parseImpact(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, prediction)
+		})
+	}
+}
@@ -0,0 +1,1957 @@
+package krpc
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Expression - a server side expression.
+type Expression struct {
+	service.BaseClass
+}
+
+// NewExpression creates a new Expression.
+func NewExpression(id uint64, client *krpcgo.KRPCClient) *Expression {
+	c := &Expression{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// ConstantDouble - a constant value of double precision floating point type.
+//
+// Allowed game scenes: any.
+func (s *Expression) ConstantDouble(value float64) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ConstantDouble",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ConstantFloat - a constant value of single precision floating point type.
+//
+// Allowed game scenes: any.
+func (s *Expression) ConstantFloat(value float32) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ConstantFloat",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ConstantInt - a constant value of integer type.
+//
+// Allowed game scenes: any.
+func (s *Expression) ConstantInt(value int32) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ConstantInt",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ConstantBool - a constant value of boolean type.
+//
+// Allowed game scenes: any.
+func (s *Expression) ConstantBool(value bool) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ConstantBool",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ConstantString - a constant value of string type.
+//
+// Allowed game scenes: any.
+func (s *Expression) ConstantString(value string) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ConstantString",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Call - an RPC call.
+//
+// Allowed game scenes: any.
+func (s *Expression) Call(call *types.ProcedureCall) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Call",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(call)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Equal - equality comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) Equal(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Equal",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// NotEqual - inequality comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) NotEqual(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_NotEqual",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// GreaterThan - greater than numerical comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) GreaterThan(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_GreaterThan",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// GreaterThanOrEqual - greater than or equal numerical comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) GreaterThanOrEqual(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_GreaterThanOrEqual",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// LessThan - less than numerical comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) LessThan(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_LessThan",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// LessThanOrEqual - less than or equal numerical comparison.
+//
+// Allowed game scenes: any.
+func (s *Expression) LessThanOrEqual(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_LessThanOrEqual",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// And - boolean and operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) And(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_And",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Or - boolean or operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) Or(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Or",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ExclusiveOr - boolean exclusive-or operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) ExclusiveOr(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ExclusiveOr",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Not - boolean negation operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) Not(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Not",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Add - numerical addition.
+//
+// Allowed game scenes: any.
+func (s *Expression) Add(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Add",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Subtract - numerical subtraction.
+//
+// Allowed game scenes: any.
+func (s *Expression) Subtract(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Subtract",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Multiply - numerical multiplication.
+//
+// Allowed game scenes: any.
+func (s *Expression) Multiply(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Multiply",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Divide - numerical division.
+//
+// Allowed game scenes: any.
+func (s *Expression) Divide(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Divide",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Modulo - numerical modulo operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) Modulo(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Modulo",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Power - numerical power operator.
+//
+// Allowed game scenes: any.
+func (s *Expression) Power(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Power",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// LeftShift - bitwise left shift.
+//
+// Allowed game scenes: any.
+func (s *Expression) LeftShift(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_LeftShift",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// RightShift - bitwise right shift.
+//
+// Allowed game scenes: any.
+func (s *Expression) RightShift(arg0 *Expression, arg1 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_RightShift",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg0)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Cast - perform a cast to the given type.
+//
+// Allowed game scenes: any.
+func (s *Expression) Cast(arg *Expression, t *Type) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Cast",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(t)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Parameter - a named parameter of type double.
+//
+// Allowed game scenes: any.
+func (s *Expression) Parameter(name string, t *Type) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Parameter",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(name)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(t)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Function - a function.
+//
+// Allowed game scenes: any.
+func (s *Expression) Function(parameters []*Expression, body *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Function",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(parameters)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(body)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Invoke - a function call.
+//
+// Allowed game scenes: any.
+func (s *Expression) Invoke(function *Expression, args map[string]*Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Invoke",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(function)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(args)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// CreateTuple - construct a tuple.
+//
+// Allowed game scenes: any.
+func (s *Expression) CreateTuple(elements []*Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_CreateTuple",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(elements)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// CreateList - construct a list.
+//
+// Allowed game scenes: any.
+func (s *Expression) CreateList(values []*Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_CreateList",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(values)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// CreateSet - construct a set.
+//
+// Allowed game scenes: any.
+func (s *Expression) CreateSet(values []*Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_CreateSet",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(values)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// CreateDictionary - construct a dictionary, from a list of corresponding keys
+// and values.
+//
+// Allowed game scenes: any.
+func (s *Expression) CreateDictionary(keys []*Expression, values []*Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_CreateDictionary",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(keys)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(values)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ToList - convert a collection to a list.
+//
+// Allowed game scenes: any.
+func (s *Expression) ToList(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ToList",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ToSet - convert a collection to a set.
+//
+// Allowed game scenes: any.
+func (s *Expression) ToSet(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_ToSet",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Get - access an element in a tuple, list or dictionary.
+//
+// Allowed game scenes: any.
+func (s *Expression) Get(arg *Expression, index *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Get",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(index)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Count - number of elements in a collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Count(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Count",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Sum - sum all elements of a collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Sum(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Sum",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Max - maximum of all elements in a collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Max(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Max",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Min - minimum of all elements in a collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Min(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Min",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Average - minimum of all elements in a collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Average(arg *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Average",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Select - run a function on every element in the collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Select(arg *Expression, f *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Select",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(f)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Where - run a function on every element in the collection.
+//
+// Allowed game scenes: any.
+func (s *Expression) Where(arg *Expression, f *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Where",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(f)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Contains - determine if a collection contains a value.
+//
+// Allowed game scenes: any.
+func (s *Expression) Contains(arg *Expression, value *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Contains",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Aggregate - applies an accumulator function over a sequence.
+//
+// Allowed game scenes: any.
+func (s *Expression) Aggregate(arg *Expression, f *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Aggregate",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(f)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// AggregateWithSeed - applies an accumulator function over a sequence, with a
+// given seed.
+//
+// Allowed game scenes: any.
+func (s *Expression) AggregateWithSeed(arg *Expression, seed *Expression, f *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_AggregateWithSeed",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(seed)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(f)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x2),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Concat - concatenate two sequences.
+//
+// Allowed game scenes: any.
+func (s *Expression) Concat(arg1 *Expression, arg2 *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Concat",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg1)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(arg2)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// OrderBy - order a collection using a key function.
+//
+// Allowed game scenes: any.
+func (s *Expression) OrderBy(arg *Expression, key *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_OrderBy",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(key)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// All - determine whether all items in a collection satisfy a boolean
+// predicate.
+//
+// Allowed game scenes: any.
+func (s *Expression) All(arg *Expression, predicate *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_All",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(predicate)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Any - determine whether any item in a collection satisfies a boolean
+// predicate.
+//
+// Allowed game scenes: any.
+func (s *Expression) Any(arg *Expression, predicate *Expression) (*Expression, error) {
+	var err error
+	var argBytes []byte
+	var vv Expression
+	request := &types.ProcedureCall{
+		Procedure: "Expression_static_Any",
+		Service:   "KRPC",
+	}
+	argBytes, err = encode.Marshal(arg)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(predicate)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
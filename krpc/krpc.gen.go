@@ -7,7 +7,6 @@ package krpc
 import (
 	krpcgo "github.com/atburke/krpc-go"
 	encode "github.com/atburke/krpc-go/lib/encode"
-	service "github.com/atburke/krpc-go/lib/service"
 	types "github.com/atburke/krpc-go/types"
 	tracerr "github.com/ztrue/tracerr"
 )
@@ -101,30 +100,6 @@ func (v *GameScene) SetValue(val int32) {
 	*v = GameScene(val)
 }
 
-// Expression - a server side expression.
-type Expression struct {
-	service.BaseClass
-}
-
-// NewExpression creates a new Expression.
-func NewExpression(id uint64, client *krpcgo.KRPCClient) *Expression {
-	c := &Expression{BaseClass: service.BaseClass{Client: client}}
-	c.SetID_internal(id)
-	return c
-}
-
-// Type - a server side expression.
-type Type struct {
-	service.BaseClass
-}
-
-// NewType creates a new Type.
-func NewType(id uint64, client *krpcgo.KRPCClient) *Type {
-	c := &Type{BaseClass: service.BaseClass{Client: client}}
-	c.SetID_internal(id)
-	return c
-}
-
 // KRPC - main kRPC service, used by clients to interact with basic server
 // functionality.
 type KRPC struct {
@@ -590,2061 +565,7 @@ func (s *KRPC) SetPaused(value bool) error {
 	return nil
 }
 
-// ConstantDouble - a constant value of double precision floating point type.
-//
-// Allowed game scenes: any.
-func (s *Expression) ConstantDouble() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ConstantDouble",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ConstantFloat - a constant value of single precision floating point type.
-//
-// Allowed game scenes: any.
-func (s *Expression) ConstantFloat() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ConstantFloat",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ConstantInt - a constant value of integer type.
-//
-// Allowed game scenes: any.
-func (s *Expression) ConstantInt() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ConstantInt",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ConstantBool - a constant value of boolean type.
-//
-// Allowed game scenes: any.
-func (s *Expression) ConstantBool() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ConstantBool",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ConstantString - a constant value of string type.
-//
-// Allowed game scenes: any.
-func (s *Expression) ConstantString() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ConstantString",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Call - an RPC call.
-//
-// Allowed game scenes: any.
-func (s *Expression) Call() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Call",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Equal - equality comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) Equal(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Equal",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// NotEqual - inequality comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) NotEqual(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_NotEqual",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// GreaterThan - greater than numerical comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) GreaterThan(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_GreaterThan",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// GreaterThanOrEqual - greater than or equal numerical comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) GreaterThanOrEqual(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_GreaterThanOrEqual",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// LessThan - less than numerical comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) LessThan(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_LessThan",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// LessThanOrEqual - less than or equal numerical comparison.
-//
-// Allowed game scenes: any.
-func (s *Expression) LessThanOrEqual(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_LessThanOrEqual",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// And - boolean and operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) And(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_And",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Or - boolean or operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) Or(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Or",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ExclusiveOr - boolean exclusive-or operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) ExclusiveOr(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ExclusiveOr",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Not - boolean negation operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) Not() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Not",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Add - numerical addition.
-//
-// Allowed game scenes: any.
-func (s *Expression) Add(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Add",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Subtract - numerical subtraction.
-//
-// Allowed game scenes: any.
-func (s *Expression) Subtract(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Subtract",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Multiply - numerical multiplication.
-//
-// Allowed game scenes: any.
-func (s *Expression) Multiply(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Multiply",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Divide - numerical division.
-//
-// Allowed game scenes: any.
-func (s *Expression) Divide(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Divide",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Modulo - numerical modulo operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) Modulo(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Modulo",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Power - numerical power operator.
-//
-// Allowed game scenes: any.
-func (s *Expression) Power(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Power",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// LeftShift - bitwise left shift.
-//
-// Allowed game scenes: any.
-func (s *Expression) LeftShift(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_LeftShift",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// RightShift - bitwise right shift.
-//
-// Allowed game scenes: any.
-func (s *Expression) RightShift(arg1 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_RightShift",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg1)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Cast - perform a cast to the given type.
-//
-// Allowed game scenes: any.
-func (s *Expression) Cast(t *Type) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Cast",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(t)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Parameter - a named parameter of type double.
-//
-// Allowed game scenes: any.
-func (s *Expression) Parameter(t *Type) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Parameter",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(t)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Function - a function.
-//
-// Allowed game scenes: any.
-func (s *Expression) Function(body *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Function",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(body)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Invoke - a function call.
-//
-// Allowed game scenes: any.
-func (s *Expression) Invoke(args map[string]*Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Invoke",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(args)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// CreateTuple - construct a tuple.
-//
-// Allowed game scenes: any.
-func (s *Expression) CreateTuple() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_CreateTuple",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// CreateList - construct a list.
-//
-// Allowed game scenes: any.
-func (s *Expression) CreateList() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_CreateList",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// CreateSet - construct a set.
-//
-// Allowed game scenes: any.
-func (s *Expression) CreateSet() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_CreateSet",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// CreateDictionary - construct a dictionary, from a list of corresponding keys
-// and values.
-//
-// Allowed game scenes: any.
-func (s *Expression) CreateDictionary(values []*Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_CreateDictionary",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(values)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ToList - convert a collection to a list.
-//
-// Allowed game scenes: any.
-func (s *Expression) ToList() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ToList",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// ToSet - convert a collection to a set.
-//
-// Allowed game scenes: any.
-func (s *Expression) ToSet() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_ToSet",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Get - access an element in a tuple, list or dictionary.
-//
-// Allowed game scenes: any.
-func (s *Expression) Get(index *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Get",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(index)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Count - number of elements in a collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Count() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Count",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Sum - sum all elements of a collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Sum() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Sum",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Max - maximum of all elements in a collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Max() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Max",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Min - minimum of all elements in a collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Min() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Min",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Average - minimum of all elements in a collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Average() (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Average",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Select - run a function on every element in the collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Select(f *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Select",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(f)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Where - run a function on every element in the collection.
-//
-// Allowed game scenes: any.
-func (s *Expression) Where(f *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Where",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(f)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Contains - determine if a collection contains a value.
-//
-// Allowed game scenes: any.
-func (s *Expression) Contains(value *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Contains",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(value)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Aggregate - applies an accumulator function over a sequence.
-//
-// Allowed game scenes: any.
-func (s *Expression) Aggregate(f *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Aggregate",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(f)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// AggregateWithSeed - applies an accumulator function over a sequence, with a
-// given seed.
-//
-// Allowed game scenes: any.
-func (s *Expression) AggregateWithSeed(seed *Expression, f *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_AggregateWithSeed",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(seed)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(f)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x2),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Concat - concatenate two sequences.
-//
-// Allowed game scenes: any.
-func (s *Expression) Concat(arg2 *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Concat",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(arg2)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// OrderBy - order a collection using a key function.
-//
-// Allowed game scenes: any.
-func (s *Expression) OrderBy(key *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_OrderBy",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(key)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// All - determine whether all items in a collection satisfy a boolean
-// predicate.
-//
-// Allowed game scenes: any.
-func (s *Expression) All(predicate *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_All",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(predicate)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Any - determine whether any item in a collection satisfies a boolean
-// predicate.
-//
-// Allowed game scenes: any.
-func (s *Expression) Any(predicate *Expression) (*Expression, error) {
-	var err error
-	var argBytes []byte
-	var vv Expression
-	request := &types.ProcedureCall{
-		Procedure: "Expression_static_Any",
-		Service:   "KRPC",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	argBytes, err = encode.Marshal(predicate)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x1),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Double - double type.
-//
-// Allowed game scenes: any.
-func (s *Type) Double() (*Type, error) {
-	var err error
-	var vv Type
-	request := &types.ProcedureCall{
-		Procedure: "Type_static_Double",
-		Service:   "KRPC",
-	}
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Float - float type.
-//
-// Allowed game scenes: any.
-func (s *Type) Float() (*Type, error) {
-	var err error
-	var vv Type
-	request := &types.ProcedureCall{
-		Procedure: "Type_static_Float",
-		Service:   "KRPC",
-	}
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Int - int type.
-//
-// Allowed game scenes: any.
-func (s *Type) Int() (*Type, error) {
-	var err error
-	var vv Type
-	request := &types.ProcedureCall{
-		Procedure: "Type_static_Int",
-		Service:   "KRPC",
-	}
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Bool - bool type.
-//
-// Allowed game scenes: any.
-func (s *Type) Bool() (*Type, error) {
-	var err error
-	var vv Type
-	request := &types.ProcedureCall{
-		Procedure: "Type_static_Bool",
-		Service:   "KRPC",
-	}
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// String - string type.
-//
-// Allowed game scenes: any.
-func (s *Type) String() (*Type, error) {
-	var err error
-	var vv Type
-	request := &types.ProcedureCall{
-		Procedure: "Type_static_String",
-		Service:   "KRPC",
-	}
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
+// PausedProp - returns a handle to the Paused property.
+func (s *KRPC) PausedProp() *krpcgo.Property[bool] {
+	return krpcgo.NewProperty(s.Paused, s.SetPaused, s.PausedStream)
 }
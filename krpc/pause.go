@@ -0,0 +1,82 @@
+package krpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// pausableScenes are the scenes where SetPaused behaves. Elsewhere, such as
+// at the space center, it can leave the game in a state where it can't be
+// unpaused.
+var pausableScenes = map[GameScene]struct{}{
+	GameScene_Flight: {},
+}
+
+// Pausing is retried this many times, this far apart, since the game only
+// applies it on the next frame and sometimes not at all.
+var (
+	pauseAttempts      = 5
+	pauseRetryInterval = 100 * time.Millisecond
+)
+
+// ErrNotPausable is returned by Pause and Resume in scenes where the game
+// can't safely be paused.
+type ErrNotPausable struct {
+	Scene GameScene
+}
+
+// Error returns a human-readable error.
+func (err ErrNotPausable) Error() string {
+	return fmt.Sprintf("The game can't be paused in the %v scene", err.Scene)
+}
+
+// Pause pauses the game, like SetPaused(true), but checks that the current
+// scene can be paused first and waits until the game is paused. It returns
+// ErrNotPausable outside the flight scene.
+func (s *KRPC) Pause() error {
+	return tracerr.Wrap(s.setPausedSafely(true))
+}
+
+// Resume unpauses the game, like SetPaused(false), with the same checks as
+// Pause.
+func (s *KRPC) Resume() error {
+	return tracerr.Wrap(s.setPausedSafely(false))
+}
+
+// setPausedSafely sets whether the game is paused, retrying until it is.
+func (s *KRPC) setPausedSafely(paused bool) error {
+	scene, err := s.CurrentGameScene()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if _, ok := pausableScenes[scene]; !ok {
+		return tracerr.Wrap(ErrNotPausable{Scene: scene})
+	}
+	for i := 0; i < pauseAttempts; i++ {
+		current, err := s.Paused()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if current == paused {
+			return nil
+		}
+		if err := s.SetPaused(paused); err != nil {
+			return tracerr.Wrap(err)
+		}
+		time.Sleep(pauseRetryInterval)
+	}
+	current, err := s.Paused()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if current != paused {
+		action := "unpause"
+		if paused {
+			action = "pause"
+		}
+		return tracerr.Errorf("Game didn't %v after %d attempts", action, pauseAttempts)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package krpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/stretchr/testify/require"
+)
+
+// pauseServer returns a KRPC service for a server in a scene, whose pause
+// state changes only if it obeys SetPaused.
+func pauseServer(t *testing.T, scene GameScene, obeys bool) (*KRPC, *krpctest.Server) {
+	t.Helper()
+	attempts, interval := pauseAttempts, pauseRetryInterval
+	pauseRetryInterval = time.Millisecond
+	t.Cleanup(func() { pauseAttempts, pauseRetryInterval = attempts, interval })
+
+	server := krpctest.NewServer()
+	t.Cleanup(func() { server.Close() })
+	server.Return("KRPC", "get_CurrentGameScene", scene)
+	server.Return("KRPC", "get_Paused", false)
+	server.Handle("KRPC", "set_Paused", func(call *krpctest.Call) (interface{}, error) {
+		var paused bool
+		if err := encode.Unmarshal(call.Args[0], &paused); err != nil {
+			return nil, err
+		}
+		if obeys {
+			server.Return("KRPC", "get_Paused", paused)
+		}
+		return nil, nil
+	})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return New(client), server
+}
+
+// setPausedCalls counts the calls to SetPaused.
+func setPausedCalls(server *krpctest.Server) int {
+	n := 0
+	for _, call := range server.Calls() {
+		if call.Procedure == "set_Paused" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestPauseAndResume(t *testing.T) {
+	k, server := pauseServer(t, GameScene_Flight, true)
+	require.NoError(t, k.Pause())
+	paused, err := k.Paused()
+	require.NoError(t, err)
+	require.True(t, paused)
+	// Pausing a paused game does nothing.
+	require.NoError(t, k.Pause())
+	require.Equal(t, 1, setPausedCalls(server))
+
+	require.NoError(t, k.Resume())
+	paused, err = k.Paused()
+	require.NoError(t, err)
+	require.False(t, paused)
+}
+
+func TestPauseNotPausable(t *testing.T) {
+	k, server := pauseServer(t, GameScene_SpaceCenter, true)
+	err := k.Pause()
+	var notPausable ErrNotPausable
+	require.True(t, errors.As(err, &notPausable))
+	require.Equal(t, GameScene_SpaceCenter, notPausable.Scene)
+	require.Equal(t, "The game can't be paused in the space center scene", notPausable.Error())
+	require.Zero(t, setPausedCalls(server))
+}
+
+func TestPauseRetries(t *testing.T) {
+	k, server := pauseServer(t, GameScene_Flight, false)
+	require.Error(t, k.Pause())
+	require.Equal(t, pauseAttempts, setPausedCalls(server))
+}
@@ -0,0 +1,202 @@
+package krpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// sceneNames are the names of the game scenes.
+var sceneNames = map[GameScene]string{
+	GameScene_SpaceCenter:     "space center",
+	GameScene_Flight:          "flight",
+	GameScene_TrackingStation: "tracking station",
+	GameScene_EditorVAB:       "VAB",
+	GameScene_EditorSPH:       "SPH",
+}
+
+// String returns the name of the scene.
+func (v GameScene) String() string {
+	if name, ok := sceneNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", int32(v))
+}
+
+// IsEditor checks if the scene is one of the vessel editors.
+func (v GameScene) IsEditor() bool {
+	return v == GameScene_EditorVAB || v == GameScene_EditorSPH
+}
+
+// SceneChange is a change of game scene.
+type SceneChange struct {
+	From GameScene
+	To   GameScene
+}
+
+// sceneCallback is a callback for the scene changes it matches.
+type sceneCallback struct {
+	match func(SceneChange) bool
+	f     func(SceneChange)
+}
+
+// SceneWatcher streams the current game scene and calls callbacks when it
+// changes, so that long-running code can react to the player leaving the
+// flight scene rather than failing in the middle of a call:
+//
+//	w, err := krpc.NewSceneWatcher(client)
+//	...
+//	w.OnLeave(krpc.GameScene_Flight, func(krpc.SceneChange) { supervisor.Pause() })
+//	w.OnFlight(func(krpc.SceneChange) { supervisor.Resume() })
+//
+// Callbacks run on the watcher's goroutine, one at a time, in the order they
+// were registered.
+type SceneWatcher struct {
+	scene *krpcgo.Stream[GameScene]
+
+	mu        sync.Mutex
+	current   GameScene
+	callbacks []sceneCallback
+	// changed is closed and replaced whenever the scene changes.
+	changed chan struct{}
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewSceneWatcher creates a new SceneWatcher. The watcher is closed when the
+// client is.
+func NewSceneWatcher(client *krpcgo.KRPCClient) (*SceneWatcher, error) {
+	if !client.StreamsAvailable() {
+		return nil, tracerr.Errorf("Watching the game scene needs a stream connection")
+	}
+	k := New(client)
+	current, err := k.CurrentGameScene()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	scene, err := k.CurrentGameSceneStream()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	w := &SceneWatcher{
+		scene:   scene,
+		current: current,
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	client.OnClose(func() { _ = w.Close() })
+	return w, nil
+}
+
+func (w *SceneWatcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case scene := <-w.scene.C:
+			change, callbacks := w.set(scene)
+			for _, cb := range callbacks {
+				cb(change)
+			}
+		}
+	}
+}
+
+// set records the current scene, returning the change and the callbacks to
+// call for it, if it changed.
+func (w *SceneWatcher) set(scene GameScene) (SceneChange, []func(SceneChange)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	change := SceneChange{From: w.current, To: scene}
+	if scene == w.current {
+		return change, nil
+	}
+	w.current = scene
+	close(w.changed)
+	w.changed = make(chan struct{})
+	var callbacks []func(SceneChange)
+	for _, cb := range w.callbacks {
+		if cb.match(change) {
+			callbacks = append(callbacks, cb.f)
+		}
+	}
+	return change, callbacks
+}
+
+// Scene returns the current game scene.
+func (w *SceneWatcher) Scene() GameScene {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// on registers a callback for the scene changes that match.
+func (w *SceneWatcher) on(match func(SceneChange) bool, f func(SceneChange)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, sceneCallback{match: match, f: f})
+}
+
+// OnChange registers a callback for every scene change.
+func (w *SceneWatcher) OnChange(f func(SceneChange)) {
+	w.on(func(SceneChange) bool { return true }, f)
+}
+
+// OnEnter registers a callback for entering a scene.
+func (w *SceneWatcher) OnEnter(scene GameScene, f func(SceneChange)) {
+	w.on(func(c SceneChange) bool { return c.To == scene }, f)
+}
+
+// OnLeave registers a callback for leaving a scene.
+func (w *SceneWatcher) OnLeave(scene GameScene, f func(SceneChange)) {
+	w.on(func(c SceneChange) bool { return c.From == scene }, f)
+}
+
+// OnFlight registers a callback for entering the flight scene.
+func (w *SceneWatcher) OnFlight(f func(SceneChange)) {
+	w.OnEnter(GameScene_Flight, f)
+}
+
+// OnSpaceCenter registers a callback for returning to the space center.
+func (w *SceneWatcher) OnSpaceCenter(f func(SceneChange)) {
+	w.OnEnter(GameScene_SpaceCenter, f)
+}
+
+// OnEditor registers a callback for opening either of the vessel editors.
+func (w *SceneWatcher) OnEditor(f func(SceneChange)) {
+	w.on(func(c SceneChange) bool { return c.To.IsEditor() && !c.From.IsEditor() }, f)
+}
+
+// Wait waits until the game is in a scene.
+func (w *SceneWatcher) Wait(ctx context.Context, scene GameScene) error {
+	for {
+		w.mu.Lock()
+		current, changed := w.current, w.changed
+		w.mu.Unlock()
+		if current == scene {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-w.done:
+			return tracerr.Errorf("Scene watcher closed while waiting for the %v scene", scene)
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		}
+	}
+}
+
+// Close stops the watcher. Its callbacks are no longer called.
+func (w *SceneWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.scene.Close()
+	})
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,79 @@
+package krpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSceneString(t *testing.T) {
+	require.Equal(t, "space center", GameScene_SpaceCenter.String())
+	require.Equal(t, "unknown (7)", GameScene(7).String())
+	require.True(t, GameScene_EditorSPH.IsEditor())
+	require.False(t, GameScene_Flight.IsEditor())
+}
+
+func TestSceneWatcherSet(t *testing.T) {
+	w := &SceneWatcher{current: GameScene_SpaceCenter, changed: make(chan struct{})}
+	var calls []string
+	record := func(name string) func(SceneChange) {
+		return func(SceneChange) { calls = append(calls, name) }
+	}
+	w.OnChange(record("change"))
+	w.OnFlight(record("flight"))
+	w.OnSpaceCenter(record("space center"))
+	w.OnEditor(record("editor"))
+	w.OnLeave(GameScene_Flight, record("left flight"))
+
+	run := func(scene GameScene) {
+		calls = nil
+		change, callbacks := w.set(scene)
+		for _, cb := range callbacks {
+			cb(change)
+		}
+	}
+	run(GameScene_SpaceCenter)
+	require.Empty(t, calls)
+	run(GameScene_EditorVAB)
+	require.Equal(t, []string{"change", "editor"}, calls)
+	run(GameScene_Flight)
+	require.Equal(t, []string{"change", "flight"}, calls)
+	run(GameScene_SpaceCenter)
+	require.Equal(t, []string{"change", "space center", "left flight"}, calls)
+	require.Equal(t, GameScene_SpaceCenter, w.Scene())
+}
+
+func TestSceneWatcher(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("KRPC", "get_CurrentGameScene", GameScene_SpaceCenter)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	w, err := NewSceneWatcher(client)
+	require.NoError(t, err)
+	defer w.Close()
+	require.Equal(t, GameScene_SpaceCenter, w.Scene())
+	changes := make(chan SceneChange, 1)
+	w.OnChange(func(c SceneChange) { changes <- c })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, w.Wait(ctx, GameScene_Flight), context.DeadlineExceeded)
+
+	server.Feed("KRPC", "get_CurrentGameScene", GameScene_Flight)
+	select {
+	case c := <-changes:
+		require.Equal(t, SceneChange{From: GameScene_SpaceCenter, To: GameScene_Flight}, c)
+	case <-time.After(time.Second):
+		require.FailNow(t, "scene change not seen")
+	}
+	require.NoError(t, w.Wait(context.Background(), GameScene_Flight))
+
+	require.NoError(t, w.Close())
+	require.Error(t, w.Wait(context.Background(), GameScene_EditorVAB))
+}
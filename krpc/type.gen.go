@@ -0,0 +1,148 @@
+package krpc
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Type - a server side expression.
+type Type struct {
+	service.BaseClass
+}
+
+// NewType creates a new Type.
+func NewType(id uint64, client *krpcgo.KRPCClient) *Type {
+	c := &Type{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Double - double type.
+//
+// Allowed game scenes: any.
+func (s *Type) Double() (*Type, error) {
+	var err error
+	var vv Type
+	request := &types.ProcedureCall{
+		Procedure: "Type_static_Double",
+		Service:   "KRPC",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Float - float type.
+//
+// Allowed game scenes: any.
+func (s *Type) Float() (*Type, error) {
+	var err error
+	var vv Type
+	request := &types.ProcedureCall{
+		Procedure: "Type_static_Float",
+		Service:   "KRPC",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Int - int type.
+//
+// Allowed game scenes: any.
+func (s *Type) Int() (*Type, error) {
+	var err error
+	var vv Type
+	request := &types.ProcedureCall{
+		Procedure: "Type_static_Int",
+		Service:   "KRPC",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// Bool - bool type.
+//
+// Allowed game scenes: any.
+func (s *Type) Bool() (*Type, error) {
+	var err error
+	var vv Type
+	request := &types.ProcedureCall{
+		Procedure: "Type_static_Bool",
+		Service:   "KRPC",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// String - string type.
+//
+// Allowed game scenes: any.
+func (s *Type) String() (*Type, error) {
+	var err error
+	var vv Type
+	request := &types.ProcedureCall{
+		Procedure: "Type_static_String",
+		Service:   "KRPC",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
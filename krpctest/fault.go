@@ -0,0 +1,325 @@
+package krpctest
+
+import (
+	"bufio"
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// FaultConfig describes how a FaultProxy degrades the link to the server.
+// The zero value passes everything through untouched.
+type FaultConfig struct {
+	// Latency delays every RPC response and stream update, by up to Jitter
+	// more at random.
+	Latency time.Duration
+	Jitter  time.Duration
+	// DropRate is the chance, between 0 and 1, that a stream update is
+	// lost. RPC messages are never lost, since kRPC has no way to recover
+	// them; use KillAfter or Kill to lose the connection instead.
+	DropRate float64
+	// ReorderRate is the chance, between 0 and 1, that a stream update is
+	// held back and sent after the next one. A held update is sent anyway
+	// if the next one takes longer than ReorderHold to arrive, or the
+	// connection closes.
+	ReorderRate float64
+	// KillAfter, if set, closes each connection this long after it was
+	// opened.
+	KillAfter time.Duration
+}
+
+// ReorderHold is the longest a FaultProxy holds back a stream update
+// waiting for the next one.
+const ReorderHold = 100 * time.Millisecond
+
+// FaultStats counts the faults a FaultProxy has injected.
+type FaultStats struct {
+	// Delayed, Dropped and Reordered count messages.
+	Delayed   int
+	Dropped   int
+	Reordered int
+	// Killed counts connections.
+	Killed int
+}
+
+// FaultProxy is a proxy between a client and a kRPC server, real or from
+// this package, that injects latency, loses and reorders stream updates,
+// and kills connections, so that code can be tested against a degraded
+// link:
+//
+//	proxy := krpctest.NewFaultProxy(server.ClientConfig(), krpctest.FaultConfig{
+//		Latency:  50 * time.Millisecond,
+//		DropRate: 0.1,
+//	}, 1)
+//	defer proxy.Close()
+//	client, err := proxy.Client(ctx)
+//
+// The faults can be changed while clients are connected, e.g. to lose the
+// link halfway through a burn.
+type FaultProxy struct {
+	l        listeners
+	upstream krpcgo.KRPCClientConfig
+
+	mu    sync.Mutex
+	cfg   FaultConfig
+	rand  *rand.Rand
+	stats FaultStats
+	// links are the open connections.
+	links map[*link]struct{}
+}
+
+// NewFaultProxy creates a new FaultProxy for the server a client with the
+// given config would connect to. The faults are random, seeded with seed so
+// that a failing run can be repeated.
+func NewFaultProxy(upstream krpcgo.KRPCClientConfig, cfg FaultConfig, seed int64) *FaultProxy {
+	upstream.SetDefaults()
+	return &FaultProxy{
+		upstream: upstream,
+		cfg:      cfg,
+		rand:     rand.New(rand.NewSource(seed)),
+		links:    map[*link]struct{}{},
+	}
+}
+
+// SetFaults changes the faults injected from now on.
+func (p *FaultProxy) SetFaults(cfg FaultConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+}
+
+// Stats returns the faults injected so far.
+func (p *FaultProxy) Stats() FaultStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// Kill closes every open connection, as if the link went down. Clients can
+// connect again afterwards.
+func (p *FaultProxy) Kill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for l := range p.links {
+		l.close()
+		p.stats.Killed++
+	}
+	p.links = map[*link]struct{}{}
+}
+
+// link is a client's connection to the proxy and the proxy's connection to
+// the server for it.
+type link struct {
+	client net.Conn
+	server net.Conn
+}
+
+// close closes both connections.
+func (l *link) close() {
+	l.client.Close()
+	l.server.Close()
+}
+
+// track remembers an open link, scheduling it to be killed if the config
+// says so. It returns a function that closes and forgets the link.
+func (p *FaultProxy) track(l *link) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.links[l] = struct{}{}
+	var timer *time.Timer
+	if p.cfg.KillAfter > 0 {
+		timer = time.AfterFunc(p.cfg.KillAfter, func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if _, ok := p.links[l]; ok {
+				l.close()
+				delete(p.links, l)
+				p.stats.Killed++
+			}
+		})
+	}
+	return func() {
+		if timer != nil {
+			timer.Stop()
+		}
+		p.mu.Lock()
+		delete(p.links, l)
+		p.mu.Unlock()
+		l.close()
+	}
+}
+
+// chance returns true with probability rate.
+func (p *FaultProxy) chance(rate float64) bool {
+	return rate > 0 && p.rand.Float64() < rate
+}
+
+// delay waits for the configured latency.
+func (p *FaultProxy) delay() {
+	p.mu.Lock()
+	d := p.cfg.Latency
+	if p.cfg.Jitter > 0 {
+		d += time.Duration(p.rand.Int63n(int64(p.cfg.Jitter)))
+	}
+	if d > 0 {
+		p.stats.Delayed++
+	}
+	p.mu.Unlock()
+	time.Sleep(d)
+}
+
+// dial connects to the server.
+func (p *FaultProxy) dial(port string) (net.Conn, error) {
+	conn, err := net.Dial("tcp", net.JoinHostPort(p.upstream.Host, port))
+	return conn, tracerr.Wrap(err)
+}
+
+// serveRPC proxies a client's RPC connection, delaying responses.
+func (p *FaultProxy) serveRPC(conn net.Conn) {
+	up, err := p.dial(p.upstream.RPCPort)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer p.track(&link{client: conn, server: up})()
+
+	cr, ur := bufio.NewReader(conn), bufio.NewReader(up)
+	if _, err := handshake(cr, conn, ur, up); err != nil {
+		return
+	}
+	for {
+		req, err := readRaw(cr)
+		if err != nil {
+			return
+		}
+		if writeRaw(up, req) != nil {
+			return
+		}
+		resp, err := readRaw(ur)
+		if err != nil {
+			return
+		}
+		p.delay()
+		if writeRaw(conn, resp) != nil {
+			return
+		}
+	}
+}
+
+// serveStream proxies a client's stream connection, delaying, dropping and
+// reordering updates.
+func (p *FaultProxy) serveStream(conn net.Conn) {
+	up, err := p.dial(p.upstream.StreamPort)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer p.track(&link{client: conn, server: up})()
+
+	cr, ur := bufio.NewReader(conn), bufio.NewReader(up)
+	if _, err := handshake(cr, conn, ur, up); err != nil {
+		return
+	}
+	// held is an update held back to be sent after the next one, or when
+	// flushHeld fires. writeMu serializes writes to the client between the
+	// two.
+	var (
+		writeMu   sync.Mutex
+		held      []byte
+		flushHeld *time.Timer
+	)
+	flush := func() {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if held != nil {
+			writeRaw(conn, held)
+			held = nil
+		}
+	}
+	defer func() {
+		if flushHeld != nil {
+			flushHeld.Stop()
+		}
+		flush()
+	}()
+	for {
+		update, err := readRaw(ur)
+		if err != nil {
+			return
+		}
+		writeMu.Lock()
+		holding := held != nil
+		writeMu.Unlock()
+		p.mu.Lock()
+		drop := p.chance(p.cfg.DropRate)
+		reorder := !holding && !drop && p.chance(p.cfg.ReorderRate)
+		if drop {
+			p.stats.Dropped++
+		}
+		if reorder {
+			p.stats.Reordered++
+		}
+		p.mu.Unlock()
+		if drop {
+			continue
+		}
+		if reorder {
+			writeMu.Lock()
+			held = update
+			writeMu.Unlock()
+			if flushHeld == nil {
+				flushHeld = time.AfterFunc(ReorderHold, flush)
+			} else {
+				flushHeld.Reset(ReorderHold)
+			}
+			continue
+		}
+		p.delay()
+		writeMu.Lock()
+		err = writeRaw(conn, update)
+		if err == nil && held != nil {
+			err = writeRaw(conn, held)
+			held = nil
+		}
+		writeMu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Start starts listening on local ports, if the proxy isn't already.
+func (p *FaultProxy) Start() error {
+	return p.l.start(p.serveRPC, p.serveStream)
+}
+
+// ClientConfig returns the config for a client of the proxy, for code under
+// test that creates its own client. The proxy must be started.
+func (p *FaultProxy) ClientConfig() krpcgo.KRPCClientConfig {
+	return p.l.clientConfig()
+}
+
+// Client starts the proxy if needed and returns a client connected to the
+// server through it.
+func (p *FaultProxy) Client(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	if err := p.Start(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	client, err := p.l.connect(ctx)
+	return client, tracerr.Wrap(err)
+}
+
+// Close stops the proxy and closes its connections.
+func (p *FaultProxy) Close() error {
+	p.mu.Lock()
+	for l := range p.links {
+		l.close()
+	}
+	p.mu.Unlock()
+	return tracerr.Wrap(p.l.close())
+}
@@ -0,0 +1,165 @@
+package krpctest
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// faultProxy returns a server and a proxy in front of it with the given
+// faults.
+func faultProxy(t *testing.T, cfg FaultConfig) (*Server, *FaultProxy) {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(func() { server.Close() })
+	require.NoError(t, server.Start())
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	proxy := NewFaultProxy(server.ClientConfig(), cfg, 1)
+	t.Cleanup(func() { proxy.Close() })
+	require.NoError(t, proxy.Start())
+	return server, proxy
+}
+
+// rawUTStream connects to a proxy and streams the UT, returning a function
+// that reads the stream's updates from the connection directly, so that
+// none are dropped by the client for arriving too quickly.
+func rawUTStream(t *testing.T, proxy *FaultProxy) func() float64 {
+	t.Helper()
+	cfg := proxy.ClientConfig()
+	cfg.RPCOnly = true
+	client := krpcgo.NewKRPCClient(cfg)
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(func() { client.Close() })
+	k := krpc.New(client)
+	id, err := k.GetClientID()
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(cfg.Host, cfg.StreamPort))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	r := bufio.NewReader(conn)
+	require.NoError(t, writeMessage(conn, &types.ConnectionRequest{Type: types.ConnectionRequest_STREAM, ClientIdentifier: id}))
+	var resp types.ConnectionResponse
+	require.NoError(t, readMessage(r, &resp))
+	require.Equal(t, types.ConnectionResponse_OK, resp.Status)
+	_, err = k.AddStream(&types.ProcedureCall{Service: "SpaceCenter", Procedure: "get_UT"}, true)
+	require.NoError(t, err)
+
+	return func() float64 {
+		t.Helper()
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		var update types.StreamUpdate
+		require.NoError(t, readMessage(r, &update))
+		var ut float64
+		require.NoError(t, encode.Unmarshal(update.Results[0].Result.Value, &ut))
+		return ut
+	}
+}
+
+func TestFaultProxyPassThrough(t *testing.T) {
+	server, proxy := faultProxy(t, FaultConfig{})
+	next := rawUTStream(t, proxy)
+	for i := 1.0; i <= 3; i++ {
+		server.Feed("SpaceCenter", "get_UT", i)
+		require.Equal(t, i, next())
+	}
+	require.Equal(t, FaultStats{}, proxy.Stats())
+}
+
+func TestFaultProxyLatency(t *testing.T) {
+	_, proxy := faultProxy(t, FaultConfig{Latency: 30 * time.Millisecond, Jitter: 10 * time.Millisecond})
+	client, err := proxy.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	started := time.Now()
+	ut, err := spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+	require.GreaterOrEqual(t, time.Since(started), 30*time.Millisecond)
+	require.Equal(t, 1, proxy.Stats().Delayed)
+}
+
+func TestFaultProxyDrop(t *testing.T) {
+	server, proxy := faultProxy(t, FaultConfig{DropRate: 1})
+	next := rawUTStream(t, proxy)
+	for i := 1.0; i <= 3; i++ {
+		server.Feed("SpaceCenter", "get_UT", i)
+	}
+	require.Eventually(t, func() bool { return proxy.Stats().Dropped == 3 }, time.Second, time.Millisecond)
+
+	proxy.SetFaults(FaultConfig{})
+	server.Feed("SpaceCenter", "get_UT", 4.0)
+	require.Equal(t, 4.0, next())
+}
+
+func TestFaultProxyReorder(t *testing.T) {
+	server, proxy := faultProxy(t, FaultConfig{ReorderRate: 1})
+	next := rawUTStream(t, proxy)
+	for i := 1.0; i <= 4; i++ {
+		server.Feed("SpaceCenter", "get_UT", i)
+	}
+	var uts []float64
+	for i := 0; i < 4; i++ {
+		uts = append(uts, next())
+	}
+	require.Equal(t, []float64{2, 1, 4, 3}, uts)
+	require.Equal(t, 2, proxy.Stats().Reordered)
+
+	// A held update is sent anyway if no other follows it.
+	server.Feed("SpaceCenter", "get_UT", 5.0)
+	start := time.Now()
+	require.Equal(t, 5.0, next())
+	require.GreaterOrEqual(t, time.Since(start), ReorderHold)
+	require.Equal(t, 3, proxy.Stats().Reordered)
+}
+
+func TestFaultProxyKill(t *testing.T) {
+	_, proxy := faultProxy(t, FaultConfig{})
+	client, err := proxy.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = spacecenter.New(client).UT()
+	require.NoError(t, err)
+
+	proxy.Kill()
+	_, err = spacecenter.New(client).UT()
+	require.Error(t, err)
+	require.Equal(t, 2, proxy.Stats().Killed)
+
+	// Clients can reconnect, until the connections are killed again.
+	proxy.SetFaults(FaultConfig{KillAfter: 50 * time.Millisecond})
+	client, err = proxy.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return proxy.Stats().Killed == 4 }, time.Second, time.Millisecond)
+	_, err = spacecenter.New(client).UT()
+	require.Error(t, err)
+}
+
+func TestFaultProxyServerDown(t *testing.T) {
+	server := NewServer()
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+	require.NoError(t, server.Close())
+
+	proxy := NewFaultProxy(cfg, FaultConfig{}, 1)
+	defer proxy.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// The client is hung up on rather than left waiting.
+	_, err := proxy.Client(ctx)
+	require.Error(t, err)
+	require.NoError(t, ctx.Err())
+}
@@ -0,0 +1,61 @@
+package krpctest
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// StreamUpdate is a stream message sent by the server.
+type StreamUpdate struct {
+	// Time is when the update arrived, since the session started.
+	Time time.Duration `json:"time"`
+	// Message is the encoded types.StreamUpdate.
+	Message []byte `json:"message"`
+}
+
+// Exchange is a request sent by the client and the server's response.
+type Exchange struct {
+	// Time is when the request was sent, since the session started.
+	Time time.Duration `json:"time"`
+	// Request is the encoded types.Request.
+	Request []byte `json:"request"`
+	// Response is the encoded types.Response.
+	Response []byte `json:"response"`
+	// StreamUpdates are the stream messages that arrived after the response
+	// and before the next exchange.
+	StreamUpdates []StreamUpdate `json:"stream_updates,omitempty"`
+}
+
+// Fixture is a recorded client session: every message between the client
+// and the server, as they were sent.
+type Fixture struct {
+	Started time.Time `json:"started"`
+	// ClientIdentifier is the identifier the server gave the client.
+	ClientIdentifier []byte     `json:"client_identifier"`
+	Exchanges        []Exchange `json:"exchanges"`
+}
+
+// LoadFixture reads a fixture written by Save.
+func LoadFixture(path string) (*Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &f, nil
+}
+
+// Save writes the fixture to a file as JSON.
+func (f *Fixture) Save(path string) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(os.WriteFile(path, b, 0o644))
+}
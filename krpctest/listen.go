@@ -0,0 +1,164 @@
+package krpctest
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// readRaw reads a length-prefixed message without decoding it.
+func readRaw(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return b, nil
+}
+
+// writeRaw writes an encoded message with its length prefix.
+func writeRaw(w io.Writer, b []byte) error {
+	_, err := w.Write(append(proto.EncodeVarint(uint64(len(b))), b...))
+	return tracerr.Wrap(err)
+}
+
+// readMessage reads a length-prefixed message.
+func readMessage(r *bufio.Reader, m proto.Message) error {
+	b, err := readRaw(r)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(proto.Unmarshal(b, m))
+}
+
+// writeMessage writes a length-prefixed message.
+func writeMessage(w io.Writer, m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(writeRaw(w, b))
+}
+
+// listeners accepts RPC and stream connections on local ports, for the
+// servers in this package.
+type listeners struct {
+	wg sync.WaitGroup
+
+	mu     sync.Mutex
+	rpc    net.Listener
+	stream net.Listener
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// start starts accepting connections, if it isn't already, handling each
+// with serveRPC or serveStream.
+func (l *listeners) start(serveRPC, serveStream func(net.Conn)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return tracerr.Errorf("Server is closed")
+	}
+	if l.rpc != nil {
+		return nil
+	}
+	rpc, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	stream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		rpc.Close()
+		return tracerr.Wrap(err)
+	}
+	l.rpc, l.stream = rpc, stream
+	l.conns = map[net.Conn]struct{}{}
+	go l.accept(rpc, serveRPC)
+	go l.accept(stream, serveStream)
+	return nil
+}
+
+// accept accepts connections until the listener is closed.
+func (l *listeners) accept(ln net.Listener, serve func(net.Conn)) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		l.mu.Lock()
+		if l.closed {
+			l.mu.Unlock()
+			conn.Close()
+			return
+		}
+		l.conns[conn] = struct{}{}
+		l.wg.Add(1)
+		l.mu.Unlock()
+		go func() {
+			defer l.wg.Done()
+			defer l.drop(conn)
+			serve(conn)
+		}()
+	}
+}
+
+// drop closes a connection and forgets it.
+func (l *listeners) drop(conn net.Conn) {
+	l.mu.Lock()
+	delete(l.conns, conn)
+	l.mu.Unlock()
+	conn.Close()
+}
+
+// clientConfig returns the config for a client of the listeners.
+func (l *listeners) clientConfig() krpcgo.KRPCClientConfig {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cfg := krpcgo.KRPCClientConfig{ClientName: "krpctest", Game: krpcgo.GameKSP1}
+	if l.rpc != nil {
+		cfg.Host, cfg.RPCPort, _ = net.SplitHostPort(l.rpc.Addr().String())
+		_, cfg.StreamPort, _ = net.SplitHostPort(l.stream.Addr().String())
+	}
+	return cfg
+}
+
+// connect returns a client connected to the listeners, with streams.
+func (l *listeners) connect(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	client := krpcgo.NewKRPCClient(l.clientConfig())
+	if err := client.Connect(ctx); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return client, nil
+}
+
+// close stops accepting connections, closes the open ones and waits for
+// them to be done with.
+func (l *listeners) close() error {
+	l.mu.Lock()
+	l.closed = true
+	var err error
+	for _, ln := range []net.Listener{l.rpc, l.stream} {
+		if ln != nil {
+			if closeErr := ln.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	}
+	for conn := range l.conns {
+		conn.Close()
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+	return tracerr.Wrap(err)
+}
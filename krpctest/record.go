@@ -0,0 +1,207 @@
+package krpctest
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// Recorder is a proxy between a client and a real kRPC server that records
+// the session as a Fixture, so that it can be served back by a Replayer.
+// It records one client: connect the code under test to it, run it, then
+// save the fixture.
+//
+//	rec := krpctest.NewRecorder(krpcgo.KRPCClientConfig{})
+//	client, err := rec.Client(ctx)
+//	err = runMission(ctx, client)
+//	err = rec.Fixture().Save("testdata/mission.json")
+type Recorder struct {
+	l        listeners
+	upstream krpcgo.KRPCClientConfig
+	now      func() time.Time
+
+	mu      sync.Mutex
+	fixture Fixture
+	// connected is set once a client has connected, since only one is
+	// recorded.
+	connected bool
+	// conns are the connections to the server, closed with the recorder.
+	conns map[net.Conn]struct{}
+}
+
+// NewRecorder creates a new Recorder for the server a client with the given
+// config would connect to.
+func NewRecorder(upstream krpcgo.KRPCClientConfig) *Recorder {
+	upstream.SetDefaults()
+	return &Recorder{upstream: upstream, now: time.Now, conns: map[net.Conn]struct{}{}}
+}
+
+// dial connects to the server.
+func (r *Recorder) dial(port string) (net.Conn, error) {
+	conn, err := net.Dial("tcp", net.JoinHostPort(r.upstream.Host, port))
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	r.mu.Lock()
+	r.conns[conn] = struct{}{}
+	r.mu.Unlock()
+	return conn, nil
+}
+
+// handshake passes a connection request from the client to the server and
+// the response back, returning the response.
+func handshake(client *bufio.Reader, clientConn net.Conn, server *bufio.Reader, serverConn net.Conn) (*types.ConnectionResponse, error) {
+	req, err := readRaw(client)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := writeRaw(serverConn, req); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	resp, err := readRaw(server)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := writeRaw(clientConn, resp); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var cr types.ConnectionResponse
+	return &cr, tracerr.Wrap(proto.Unmarshal(resp, &cr))
+}
+
+// serveRPC proxies and records the client's RPC connection.
+func (r *Recorder) serveRPC(conn net.Conn) {
+	r.mu.Lock()
+	connected := r.connected
+	r.connected = true
+	r.mu.Unlock()
+	if connected {
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_MALFORMED_MESSAGE,
+			Message: "The recorder only records one client",
+		})
+		return
+	}
+	up, err := r.dial(r.upstream.RPCPort)
+	if err != nil {
+		return
+	}
+	defer up.Close()
+
+	cr, ur := bufio.NewReader(conn), bufio.NewReader(up)
+	started := r.now()
+	resp, err := handshake(cr, conn, ur, up)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	r.fixture.Started = started
+	r.fixture.ClientIdentifier = resp.ClientIdentifier
+	r.mu.Unlock()
+
+	for {
+		req, err := readRaw(cr)
+		if err != nil {
+			return
+		}
+		sent := r.now().Sub(started)
+		if writeRaw(up, req) != nil {
+			return
+		}
+		resp, err := readRaw(ur)
+		if err != nil {
+			return
+		}
+		if writeRaw(conn, resp) != nil {
+			return
+		}
+		r.mu.Lock()
+		r.fixture.Exchanges = append(r.fixture.Exchanges, Exchange{Time: sent, Request: req, Response: resp})
+		r.mu.Unlock()
+	}
+}
+
+// serveStream proxies and records the client's stream connection.
+func (r *Recorder) serveStream(conn net.Conn) {
+	up, err := r.dial(r.upstream.StreamPort)
+	if err != nil {
+		return
+	}
+	defer up.Close()
+
+	cr, ur := bufio.NewReader(conn), bufio.NewReader(up)
+	if _, err := handshake(cr, conn, ur, up); err != nil {
+		return
+	}
+	for {
+		update, err := readRaw(ur)
+		if err != nil {
+			return
+		}
+		if writeRaw(conn, update) != nil {
+			return
+		}
+		r.mu.Lock()
+		// Streams are added by requests, so there is always an exchange
+		// before the first update.
+		if n := len(r.fixture.Exchanges); n > 0 {
+			ex := &r.fixture.Exchanges[n-1]
+			ex.StreamUpdates = append(ex.StreamUpdates, StreamUpdate{
+				Time:    r.now().Sub(r.fixture.Started),
+				Message: update,
+			})
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Start starts listening on local ports, if the recorder isn't already.
+func (r *Recorder) Start() error {
+	return r.l.start(r.serveRPC, r.serveStream)
+}
+
+// ClientConfig returns the config for the client to record, for code that
+// creates its own client. The recorder must be started.
+func (r *Recorder) ClientConfig() krpcgo.KRPCClientConfig {
+	return r.l.clientConfig()
+}
+
+// Client starts the recorder if needed and returns a client connected to
+// the server through it.
+func (r *Recorder) Client(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	if err := r.Start(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	client, err := r.l.connect(ctx)
+	return client, tracerr.Wrap(err)
+}
+
+// Fixture returns what has been recorded so far.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.fixture
+	f.Exchanges = make([]Exchange, len(r.fixture.Exchanges))
+	for i, ex := range r.fixture.Exchanges {
+		ex.StreamUpdates = append([]StreamUpdate(nil), ex.StreamUpdates...)
+		f.Exchanges[i] = ex
+	}
+	return &f
+}
+
+// Close stops the recorder and closes its connections.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	for conn := range r.conns {
+		conn.Close()
+	}
+	r.mu.Unlock()
+	return tracerr.Wrap(r.l.close())
+}
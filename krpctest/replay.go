@@ -0,0 +1,298 @@
+package krpctest
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// ReplayConfig configures a Replayer.
+type ReplayConfig struct {
+	// Speed is how much faster than they were recorded stream updates are
+	// sent back, e.g. 10 to replay a ten minute flight in a minute. Requests
+	// are always answered straight away. Defaults to 1.
+	Speed float64
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ReplayConfig) SetDefaults() {
+	if cfg.Speed == 0 {
+		cfg.Speed = 1
+	}
+}
+
+// requestKey identifies a request by its calls.
+func requestKey(b []byte) (string, error) {
+	var req types.Request
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return "", tracerr.Wrap(err)
+	}
+	keys := make([]string, len(req.Calls))
+	for i, call := range req.Calls {
+		keys[i] = callKey(convertCall(call))
+	}
+	return strings.Join(keys, "|"), nil
+}
+
+// recorded are the exchanges with the same request, in the order they were
+// made.
+type recorded struct {
+	exchanges []int
+	next      int
+}
+
+// Replayer is a kRPC server that serves back a session recorded by a
+// Recorder, so that the code that made it can be tested against the
+// server's real behavior without the game. A request is answered with the
+// response to the same request in the recording; a request made several
+// times is answered with each of the responses in turn, and then the last
+// one again. The first time an exchange is replayed, the stream updates
+// that followed it are sent, with their recorded spacing, from just after
+// the response. Requests that
+// weren't recorded fail.
+type Replayer struct {
+	l       listeners
+	cfg     ReplayConfig
+	fixture *Fixture
+
+	mu      sync.Mutex
+	answers map[string]*recorded
+	// replayed holds the exchanges whose stream updates have been sent.
+	replayed map[int]bool
+	// stream is the client's stream connection.
+	stream net.Conn
+	// pending are the stream updates queued for sendUpdates, which wake
+	// signals.
+	pending []queuedUpdate
+	wake    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// streamSettle is the least time between replaying an exchange and sending
+// the stream updates that followed it. The client only starts listening to
+// a stream after the request adding it returns, and drops updates that
+// arrive before then.
+const streamSettle = 10 * time.Millisecond
+
+// queuedUpdate is a stream update waiting to be sent.
+type queuedUpdate struct {
+	// wait is how long to wait before sending it.
+	wait    time.Duration
+	message []byte
+}
+
+// NewReplayer creates a new Replayer serving back a fixture.
+func NewReplayer(f *Fixture, cfg ReplayConfig) (*Replayer, error) {
+	cfg.SetDefaults()
+	r := &Replayer{
+		cfg:      cfg,
+		fixture:  f,
+		answers:  map[string]*recorded{},
+		replayed: map[int]bool{},
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for i, ex := range f.Exchanges {
+		key, err := requestKey(ex.Request)
+		if err != nil {
+			return nil, tracerr.Errorf("Exchange %d has an invalid request: %v", i, err)
+		}
+		a, ok := r.answers[key]
+		if !ok {
+			a = &recorded{}
+			r.answers[key] = a
+		}
+		a.exchanges = append(a.exchanges, i)
+	}
+	go r.sendUpdates()
+	return r, nil
+}
+
+// answer returns the recorded exchange for a request, or -1 if it wasn't
+// recorded.
+func (r *Replayer) answer(req []byte) int {
+	key, err := requestKey(req)
+	if err != nil {
+		return -1
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.answers[key]
+	if !ok {
+		return -1
+	}
+	i := a.exchanges[a.next]
+	if a.next < len(a.exchanges)-1 {
+		a.next++
+	}
+	return i
+}
+
+// queue queues the stream updates that followed an exchange, the first time
+// it is replayed.
+func (r *Replayer) queue(i int) {
+	r.mu.Lock()
+	if r.replayed[i] {
+		r.mu.Unlock()
+		return
+	}
+	r.replayed[i] = true
+	ex := r.fixture.Exchanges[i]
+	last := ex.Time
+	for j, u := range ex.StreamUpdates {
+		wait := time.Duration(float64(u.Time-last) / r.cfg.Speed)
+		if j == 0 && wait < streamSettle {
+			wait = streamSettle
+		}
+		last = u.Time
+		r.pending = append(r.pending, queuedUpdate{wait: wait, message: u.Message})
+	}
+	r.mu.Unlock()
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sendUpdates sends queued stream updates to the client, in order.
+func (r *Replayer) sendUpdates() {
+	for {
+		r.mu.Lock()
+		var u queuedUpdate
+		ok := len(r.pending) > 0
+		if ok {
+			u = r.pending[0]
+			r.pending = r.pending[1:]
+		}
+		r.mu.Unlock()
+		if !ok {
+			select {
+			case <-r.done:
+				return
+			case <-r.wake:
+			}
+			continue
+		}
+		if u.wait > 0 {
+			timer := time.NewTimer(u.wait)
+			select {
+			case <-timer.C:
+			case <-r.done:
+				timer.Stop()
+				return
+			}
+		}
+		r.mu.Lock()
+		conn := r.stream
+		r.mu.Unlock()
+		if conn != nil {
+			_ = writeRaw(conn, u.message)
+		}
+	}
+}
+
+// serveRPC replays responses to the client's requests.
+func (r *Replayer) serveRPC(conn net.Conn) {
+	cr := bufio.NewReader(conn)
+	var req types.ConnectionRequest
+	if readMessage(cr, &req) != nil {
+		return
+	}
+	if req.Type != types.ConnectionRequest_RPC {
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_WRONG_TYPE,
+			Message: "Expected an RPC connection",
+		})
+		return
+	}
+	if writeMessage(conn, &types.ConnectionResponse{
+		Status:           types.ConnectionResponse_OK,
+		ClientIdentifier: r.fixture.ClientIdentifier,
+	}) != nil {
+		return
+	}
+
+	for {
+		req, err := readRaw(cr)
+		if err != nil {
+			return
+		}
+		i := r.answer(req)
+		if i < 0 {
+			err = writeMessage(conn, &types.Response{Error: &types.Error{
+				Name:        "NotRecorded",
+				Description: "the request wasn't recorded",
+			}})
+		} else {
+			err = writeRaw(conn, r.fixture.Exchanges[i].Response)
+		}
+		if err != nil {
+			return
+		}
+		if i >= 0 {
+			r.queue(i)
+		}
+	}
+}
+
+// serveStream accepts the client's stream connection, which updates are
+// sent on by sendUpdates.
+func (r *Replayer) serveStream(conn net.Conn) {
+	cr := bufio.NewReader(conn)
+	var req types.ConnectionRequest
+	if readMessage(cr, &req) != nil {
+		return
+	}
+	if req.Type != types.ConnectionRequest_STREAM {
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_WRONG_TYPE,
+			Message: "Expected a stream connection",
+		})
+		return
+	}
+	// Updates only follow requests, so none are sent before the response.
+	r.mu.Lock()
+	r.stream = conn
+	r.mu.Unlock()
+	if writeMessage(conn, &types.ConnectionResponse{Status: types.ConnectionResponse_OK}) != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, cr)
+}
+
+// Start starts serving on local ports, if the replayer isn't already.
+func (r *Replayer) Start() error {
+	return r.l.start(r.serveRPC, r.serveStream)
+}
+
+// ClientConfig returns the config for a client of the replayer, for code
+// under test that creates its own client. The replayer must be started.
+func (r *Replayer) ClientConfig() krpcgo.KRPCClientConfig {
+	return r.l.clientConfig()
+}
+
+// Client starts the replayer if needed and returns a client connected to
+// it, with streams.
+func (r *Replayer) Client(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	if err := r.Start(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	client, err := r.l.connect(ctx)
+	return client, tracerr.Wrap(err)
+}
+
+// Close stops the replayer and closes its connections.
+func (r *Replayer) Close() error {
+	r.once.Do(func() { close(r.done) })
+	return tracerr.Wrap(r.l.close())
+}
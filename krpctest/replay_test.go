@@ -0,0 +1,108 @@
+package krpctest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// script is code under test: it reads the UT, then waits for two updates
+// of it by stream. feed is called before each update is waited for.
+func script(t *testing.T, client *krpcgo.KRPCClient, feed func(i int)) []float64 {
+	t.Helper()
+	sc := spacecenter.New(client)
+	ut, err := sc.UT()
+	require.NoError(t, err)
+	uts := []float64{ut}
+	stream, err := sc.UTStream()
+	require.NoError(t, err)
+	for i := 1; i <= 2; i++ {
+		feed(i)
+		uts = append(uts, receive(t, stream))
+	}
+	name, err := sc.ActiveVessel()
+	require.NoError(t, err)
+	require.Nil(t, name)
+	require.NoError(t, stream.Close())
+	return uts
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(0))
+
+	rec := NewRecorder(server.ClientConfig())
+	client, err := rec.Client(context.Background())
+	require.NoError(t, err)
+	recorded := script(t, client, func(i int) {
+		// Space the updates out like the game's frames, since the client
+		// drops updates that arrive while it is busy with the last one.
+		time.Sleep(20 * time.Millisecond)
+		server.Feed("SpaceCenter", "get_UT", 1000+float64(i))
+	})
+	require.Equal(t, []float64{1000, 1001, 1002}, recorded)
+	client.Close()
+	require.NoError(t, rec.Close())
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, rec.Fixture().Save(path))
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	require.True(t, rec.Fixture().Started.Equal(fixture.Started))
+	require.Equal(t, rec.Fixture().ClientIdentifier, fixture.ClientIdentifier)
+	require.Equal(t, rec.Fixture().Exchanges, fixture.Exchanges)
+
+	replayer, err := NewReplayer(fixture, ReplayConfig{})
+	require.NoError(t, err)
+	defer replayer.Close()
+	client, err = replayer.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, recorded, script(t, client, func(int) {}))
+
+	// Requests that weren't recorded fail.
+	_, err = spacecenter.New(client).WarpRate()
+	var e *types.Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "NotRecorded", e.Name)
+}
+
+func TestReplayRepeatedRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+
+	rec := NewRecorder(server.ClientConfig())
+	client, err := rec.Client(context.Background())
+	require.NoError(t, err)
+	sc := spacecenter.New(client)
+	for _, ut := range []float64{10, 20, 30} {
+		server.Return("SpaceCenter", "get_UT", ut)
+		_, err := sc.UT()
+		require.NoError(t, err)
+	}
+	client.Close()
+	require.NoError(t, rec.Close())
+
+	replayer, err := NewReplayer(rec.Fixture(), ReplayConfig{})
+	require.NoError(t, err)
+	defer replayer.Close()
+	client, err = replayer.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc = spacecenter.New(client)
+	for _, expected := range []float64{10, 20, 30, 30} {
+		ut, err := sc.UT()
+		require.NoError(t, err)
+		require.Equal(t, expected, ut)
+	}
+}
@@ -0,0 +1,516 @@
+// Package krpctest provides an in-memory kRPC server for tests, so that code
+// using a client can be tested without the game. The server speaks the kRPC
+// protocol, including streams, and answers procedure calls with handlers
+// set by the test:
+//
+//	server := krpctest.NewServer()
+//	defer server.Close()
+//	server.Return("SpaceCenter", "get_UT", 1000.0)
+//	client, err := server.Client(ctx)
+//	ut, err := spacecenter.New(client).UT()
+//
+// Streams are answered by the same handlers. Feed changes a procedure's
+// value and sends it to the streams of that procedure, and Update sends
+// every stream whose value has changed, as the game does each frame. A new
+// stream gets its first value on the next Feed or Update rather than
+// straight away, so that no values arrive before the client is listening.
+//
+// For tests against the game's real behavior, a Recorder captures a session
+// with a real server as a Fixture, and a Replayer serves it back. For
+// testing autopilots in a closed loop, a Sim simulates a vessel in flight.
+// A FaultProxy in front of any of them degrades the link, for testing how
+// code copes with latency, lost updates and dropped connections.
+package krpctest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// Call is a procedure call received by the server.
+type Call struct {
+	Service   string
+	Procedure string
+	// Args are the call's encoded arguments, by position.
+	Args [][]byte
+}
+
+// Arg decodes the argument at position i into v, which must be a pointer,
+// as for encode.Unmarshal.
+func (c *Call) Arg(i int, v interface{}) error {
+	if i >= len(c.Args) {
+		return tracerr.Errorf("%s.%s has no argument %d", c.Service, c.Procedure, i)
+	}
+	return tracerr.Wrap(encode.Unmarshal(c.Args[i], v))
+}
+
+// Raw is a result that is already encoded.
+type Raw []byte
+
+// Handler answers a procedure call. Its result is encoded with
+// encode.Marshal, unless it is Raw; nil is no result, for procedures that
+// don't return anything. An error fails the call; a *types.Error is sent as
+// it is, and other errors are sent as their message.
+type Handler func(call *Call) (interface{}, error)
+
+// client is a client connected to the server.
+type client struct {
+	id [16]byte
+
+	// writeMu guards writes to the stream connection.
+	writeMu sync.Mutex
+	// stream is the client's stream connection, once it has connected one.
+	stream net.Conn
+	// streams are the client's streams, by call.
+	streams map[string]*stream
+}
+
+// stream is a client's stream of a procedure call.
+type stream struct {
+	id      uint64
+	key     string
+	call    *Call
+	started bool
+	// rate is the update rate the client asked for, in Hz, or 0 for
+	// unlimited. Updates aren't actually limited.
+	rate float32
+	// last is the last result sent, if sent is set.
+	last *types.ProcedureResult
+	sent bool
+}
+
+// Server is an in-memory kRPC server.
+type Server struct {
+	l listeners
+
+	mu         sync.Mutex
+	handlers   map[string]Handler
+	calls      []Call
+	clients    map[[16]byte]*client
+	nextClient uint64
+	nextStream uint64
+}
+
+// NewServer creates a new Server with no handlers. Start it with Start, or
+// use Client.
+func NewServer() *Server {
+	return &Server{
+		handlers: map[string]Handler{},
+		clients:  map[[16]byte]*client{},
+	}
+}
+
+// procedureKey identifies a procedure.
+func procedureKey(service, procedure string) string {
+	return service + "." + procedure
+}
+
+// callKey identifies a call by its procedure and arguments.
+func callKey(call *Call) string {
+	parts := []string{call.Service, call.Procedure}
+	for _, arg := range call.Args {
+		parts = append(parts, hex.EncodeToString(arg))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Handle sets the handler for a procedure, replacing any it had.
+func (s *Server) Handle(service, procedure string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[procedureKey(service, procedure)] = h
+}
+
+// Return makes a procedure return v, whatever its arguments.
+func (s *Server) Return(service, procedure string, v interface{}) {
+	s.Handle(service, procedure, func(*Call) (interface{}, error) {
+		return v, nil
+	})
+}
+
+// Feed makes a procedure return v, like Return, and sends v to its streams.
+func (s *Server) Feed(service, procedure string, v interface{}) {
+	s.Return(service, procedure, v)
+	s.update(procedureKey(service, procedure))
+}
+
+// Update sends the current value of every stream whose value has changed
+// since it was last sent, e.g. after changing what a handler returns.
+func (s *Server) Update() {
+	s.update("")
+}
+
+// Calls returns the procedure calls received so far, in order, not
+// counting those made by the client to manage its streams.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// convertCall converts a call from its protocol message.
+func convertCall(pc *types.ProcedureCall) *Call {
+	call := &Call{Service: pc.Service, Procedure: pc.Procedure}
+	for _, arg := range pc.Arguments {
+		for int(arg.Position) >= len(call.Args) {
+			call.Args = append(call.Args, nil)
+		}
+		call.Args[arg.Position] = arg.Value
+	}
+	return call
+}
+
+// errorResult returns a failed result.
+func errorResult(call *Call, name, description string) *types.ProcedureResult {
+	return &types.ProcedureResult{Error: &types.Error{Service: call.Service, Name: name, Description: description}}
+}
+
+// evaluate answers a call with its handler.
+func (s *Server) evaluate(call *Call) *types.ProcedureResult {
+	s.mu.Lock()
+	h, ok := s.handlers[procedureKey(call.Service, call.Procedure)]
+	s.mu.Unlock()
+	if !ok {
+		return errorResult(call, "ProcedureNotFound", "no handler for "+call.Service+"."+call.Procedure)
+	}
+	v, err := h(call)
+	if err != nil {
+		if e, ok := err.(*types.Error); ok {
+			return &types.ProcedureResult{Error: e}
+		}
+		return errorResult(call, "HandlerError", err.Error())
+	}
+	if v == nil {
+		return &types.ProcedureResult{}
+	}
+	if raw, ok := v.(Raw); ok {
+		return &types.ProcedureResult{Value: raw}
+	}
+	b, err := encode.Marshal(v)
+	if err != nil {
+		return errorResult(call, "EncodingError", err.Error())
+	}
+	return &types.ProcedureResult{Value: b}
+}
+
+// answer answers a call from a client, handling the KRPC service's stream
+// procedures itself.
+func (s *Server) answer(c *client, pc *types.ProcedureCall) *types.ProcedureResult {
+	call := convertCall(pc)
+	if call.Service == "KRPC" {
+		switch call.Procedure {
+		case "GetClientID":
+			b, _ := encode.Marshal(c.id[:])
+			return &types.ProcedureResult{Value: b}
+		case "AddStream":
+			return s.addStream(c, call)
+		case "StartStream":
+			return s.startStream(c, call)
+		case "RemoveStream":
+			return s.removeStream(c, call)
+		case "SetStreamRate":
+			return s.setStreamRate(c, call)
+		}
+	}
+	s.mu.Lock()
+	s.calls = append(s.calls, *call)
+	s.mu.Unlock()
+	return s.evaluate(call)
+}
+
+// addStream adds a stream of a call, or returns the client's existing
+// stream of the same call.
+func (s *Server) addStream(c *client, call *Call) *types.ProcedureResult {
+	var pc types.ProcedureCall
+	var start bool
+	if err := call.Arg(0, &pc); err != nil {
+		return errorResult(call, "ArgumentError", err.Error())
+	}
+	if len(call.Args) > 1 {
+		if err := call.Arg(1, &start); err != nil {
+			return errorResult(call, "ArgumentError", err.Error())
+		}
+	}
+	streamed := convertCall(&pc)
+	key := callKey(streamed)
+
+	s.mu.Lock()
+	if c.stream == nil {
+		s.mu.Unlock()
+		return errorResult(call, "InvalidOperationException", "the client has no stream connection")
+	}
+	st, ok := c.streams[key]
+	if !ok {
+		s.nextStream++
+		st = &stream{id: s.nextStream, key: key, call: streamed}
+		c.streams[key] = st
+	}
+	st.started = st.started || start
+	s.mu.Unlock()
+
+	b, err := encode.Marshal(&types.Stream{Id: st.id})
+	if err != nil {
+		return errorResult(call, "EncodingError", err.Error())
+	}
+	return &types.ProcedureResult{Value: b}
+}
+
+// findStream returns the client's stream with the ID in a call's first
+// argument.
+func (s *Server) findStream(c *client, call *Call) (*stream, *types.ProcedureResult) {
+	var id uint64
+	if err := call.Arg(0, &id); err != nil {
+		return nil, errorResult(call, "ArgumentError", err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range c.streams {
+		if st.id == id {
+			return st, nil
+		}
+	}
+	return nil, errorResult(call, "ArgumentException", "no such stream")
+}
+
+// startStream starts sending a stream.
+func (s *Server) startStream(c *client, call *Call) *types.ProcedureResult {
+	st, errResult := s.findStream(c, call)
+	if errResult != nil {
+		return errResult
+	}
+	s.mu.Lock()
+	st.started = true
+	s.mu.Unlock()
+	return &types.ProcedureResult{}
+}
+
+// setStreamRate records the rate a client asked for for a stream.
+func (s *Server) setStreamRate(c *client, call *Call) *types.ProcedureResult {
+	st, errResult := s.findStream(c, call)
+	if errResult != nil {
+		return errResult
+	}
+	var rate float32
+	if err := call.Arg(1, &rate); err != nil {
+		return errorResult(call, "ArgumentError", err.Error())
+	}
+	s.mu.Lock()
+	st.rate = rate
+	s.mu.Unlock()
+	return &types.ProcedureResult{}
+}
+
+// StreamRates returns the update rates, in Hz, that clients have set for
+// their streams of a procedure, with 0 meaning unlimited.
+func (s *Server) StreamRates(service, procedure string) []float32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var rates []float32
+	for _, c := range s.clients {
+		for _, st := range c.streams {
+			if st.call.Service == service && st.call.Procedure == procedure {
+				rates = append(rates, st.rate)
+			}
+		}
+	}
+	return rates
+}
+
+// removeStream stops and removes a stream.
+func (s *Server) removeStream(c *client, call *Call) *types.ProcedureResult {
+	st, errResult := s.findStream(c, call)
+	if errResult != nil {
+		return errResult
+	}
+	s.mu.Lock()
+	delete(c.streams, st.key)
+	s.mu.Unlock()
+	return &types.ProcedureResult{}
+}
+
+// sameResult reports whether two results are the same.
+func sameResult(a, b *types.ProcedureResult) bool {
+	if (a.Error == nil) != (b.Error == nil) {
+		return false
+	}
+	if a.Error != nil {
+		return proto.Equal(a.Error, b.Error)
+	}
+	return bytes.Equal(a.Value, b.Value)
+}
+
+// sendStream evaluates a stream's call and sends the result if it has
+// changed. Streams of procedures without handlers aren't sent, since the
+// client would read their errors as zero values.
+func (s *Server) sendStream(c *client, st *stream) {
+	s.mu.Lock()
+	_, ok := s.handlers[procedureKey(st.call.Service, st.call.Procedure)]
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	result := s.evaluate(st.call)
+	s.mu.Lock()
+	if !st.started || (st.sent && sameResult(st.last, result)) {
+		s.mu.Unlock()
+		return
+	}
+	st.last, st.sent = result, true
+	conn := c.stream
+	s.mu.Unlock()
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = writeMessage(conn, &types.StreamUpdate{Results: []*types.StreamResult{{Id: st.id, Result: result}}})
+}
+
+// update sends the streams of a procedure, or all streams if procedure is
+// empty, whose values have changed.
+func (s *Server) update(procedure string) {
+	type pending struct {
+		c  *client
+		st *stream
+	}
+	var streams []pending
+	s.mu.Lock()
+	for _, c := range s.clients {
+		for _, st := range c.streams {
+			if procedure == "" || procedureKey(st.call.Service, st.call.Procedure) == procedure {
+				streams = append(streams, pending{c, st})
+			}
+		}
+	}
+	s.mu.Unlock()
+	for _, p := range streams {
+		s.sendStream(p.c, p.st)
+	}
+}
+
+// serveRPC handles a client's RPC connection.
+func (s *Server) serveRPC(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	var req types.ConnectionRequest
+	if readMessage(r, &req) != nil {
+		return
+	}
+	if req.Type != types.ConnectionRequest_RPC {
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_WRONG_TYPE,
+			Message: "Expected an RPC connection",
+		})
+		return
+	}
+
+	s.mu.Lock()
+	s.nextClient++
+	c := &client{streams: map[string]*stream{}}
+	binary.BigEndian.PutUint64(c.id[8:], s.nextClient)
+	s.clients[c.id] = c
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c.id)
+		s.mu.Unlock()
+	}()
+
+	if writeMessage(conn, &types.ConnectionResponse{
+		Status:           types.ConnectionResponse_OK,
+		ClientIdentifier: c.id[:],
+	}) != nil {
+		return
+	}
+	for {
+		var request types.Request
+		if readMessage(r, &request) != nil {
+			return
+		}
+		resp := &types.Response{}
+		for _, call := range request.Calls {
+			resp.Results = append(resp.Results, s.answer(c, call))
+		}
+		if writeMessage(conn, resp) != nil {
+			return
+		}
+	}
+}
+
+// serveStream handles a client's stream connection. Updates are written by
+// whatever sends them, so this only waits for the connection to close.
+func (s *Server) serveStream(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	var req types.ConnectionRequest
+	if readMessage(r, &req) != nil {
+		return
+	}
+	var id [16]byte
+	copy(id[:], req.ClientIdentifier)
+	s.mu.Lock()
+	c, ok := s.clients[id]
+	s.mu.Unlock()
+	switch {
+	case req.Type != types.ConnectionRequest_STREAM:
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_WRONG_TYPE,
+			Message: "Expected a stream connection",
+		})
+		return
+	case !ok:
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_MALFORMED_MESSAGE,
+			Message: "Unknown client identifier",
+		})
+		return
+	}
+
+	// The client may add streams as soon as it has the response, so the
+	// connection is set first, and held until the response is written.
+	c.writeMu.Lock()
+	s.mu.Lock()
+	c.stream = conn
+	s.mu.Unlock()
+	err := writeMessage(conn, &types.ConnectionResponse{Status: types.ConnectionResponse_OK})
+	c.writeMu.Unlock()
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// Start starts serving on local ports, if the server isn't already.
+func (s *Server) Start() error {
+	return s.l.start(s.serveRPC, s.serveStream)
+}
+
+// ClientConfig returns the config for a client of the server, for code
+// under test that creates its own client. The server must be started.
+func (s *Server) ClientConfig() krpcgo.KRPCClientConfig {
+	return s.l.clientConfig()
+}
+
+// Client starts the server if needed and returns a client connected to it,
+// with streams.
+func (s *Server) Client(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	if err := s.Start(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	client, err := s.l.connect(ctx)
+	return client, tracerr.Wrap(err)
+}
+
+// Close stops the server and closes its connections.
+func (s *Server) Close() error {
+	return tracerr.Wrap(s.l.close())
+}
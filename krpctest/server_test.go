@@ -0,0 +1,143 @@
+package krpctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// connect returns a client of a new server, closing both when the test
+// ends.
+func connect(t *testing.T) (*Server, *krpcgo.KRPCClient) {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(func() { require.NoError(t, server.Close()) })
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestCall(t *testing.T) {
+	server, client := connect(t)
+	sc := spacecenter.New(client)
+
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	ut, err := sc.UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+
+	// Objects are their handles.
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(7))
+	server.Handle("SpaceCenter", "Vessel_get_Name", func(call *Call) (interface{}, error) {
+		var vessel spacecenter.Vessel
+		if err := call.Arg(0, &vessel); err != nil {
+			return nil, err
+		}
+		if vessel.ID_internal() != 7 {
+			return nil, errors.New("no such vessel")
+		}
+		return "Kerbal X", nil
+	})
+	vessel, err := sc.ActiveVessel()
+	require.NoError(t, err)
+	name, err := vessel.Name()
+	require.NoError(t, err)
+	require.Equal(t, "Kerbal X", name)
+
+	calls := server.Calls()
+	require.Len(t, calls, 3)
+	require.Equal(t, "Vessel_get_Name", calls[2].Procedure)
+}
+
+func TestCallErrors(t *testing.T) {
+	server, client := connect(t)
+	sc := spacecenter.New(client)
+
+	_, err := sc.UT()
+	var e *types.Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "ProcedureNotFound", e.Name)
+
+	server.Handle("SpaceCenter", "get_UT", func(*Call) (interface{}, error) {
+		return nil, errors.New("not in flight")
+	})
+	_, err = sc.UT()
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "not in flight", e.Description)
+
+	server.Handle("SpaceCenter", "get_UT", func(*Call) (interface{}, error) {
+		return nil, &types.Error{Service: "SpaceCenter", Name: "InvalidOperationException", Description: "paused"}
+	})
+	_, err = sc.UT()
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "InvalidOperationException", e.Name)
+}
+
+// receive waits for a value from a stream.
+func receive[T any](t *testing.T, s *krpcgo.Stream[T]) T {
+	t.Helper()
+	select {
+	case v := <-s.C:
+		return v
+	case <-time.After(time.Second):
+		require.FailNow(t, "no stream update")
+	}
+	var zero T
+	return zero
+}
+
+func TestStream(t *testing.T) {
+	server, client := connect(t)
+	sc := spacecenter.New(client)
+
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	ut, err := sc.UTStream()
+	require.NoError(t, err)
+	warp, err := sc.WarpRateStream()
+	require.NoError(t, err)
+
+	server.Update()
+	require.Equal(t, 1000.0, receive(t, ut))
+	server.Feed("SpaceCenter", "get_WarpRate", float32(4))
+	require.Equal(t, float32(4), receive(t, warp))
+	server.Feed("SpaceCenter", "get_UT", 1001.0)
+	require.Equal(t, 1001.0, receive(t, ut))
+
+	// Unchanged values aren't sent again.
+	server.Update()
+	select {
+	case v := <-ut.C:
+		require.FailNow(t, "unexpected update", "got %v", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Streams of the same call are shared.
+	again, err := sc.UTStream()
+	require.NoError(t, err)
+	require.Equal(t, ut.ID, again.ID)
+
+	require.NoError(t, ut.Close())
+	require.NoError(t, warp.Close())
+	require.Empty(t, server.Calls(), "stream management calls aren't recorded")
+}
+
+func TestClientConfig(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+	server.Return("SpaceCenter", "get_UT", 5.0)
+
+	client := krpcgo.NewKRPCClient(server.ClientConfig())
+	require.NoError(t, client.Connect(context.Background()))
+	defer client.Close()
+	ut, err := spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Equal(t, 5.0, ut)
+}
@@ -0,0 +1,337 @@
+package krpctest
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+)
+
+// SimConfig configures a Sim. Quantities are in SI units, and vectors are in
+// the body's non-rotating reference frame, with the body at the origin.
+type SimConfig struct {
+	// UT is the universal time the simulation starts at.
+	UT float64
+	// Mu and Radius are the body's gravitational parameter and radius.
+	// Default to Kerbin's.
+	Mu     float64
+	Radius float64
+	// Name is the vessel's name. Defaults to "Sim".
+	Name string
+	// Mass is the vessel's mass, which stays the same. Defaults to 10 t.
+	Mass float64
+	// MaxThrust is the vessel's thrust at full throttle. Defaults to 200 kN.
+	MaxThrust float64
+	// AngularAcceleration is how fast, in radians per second squared, full
+	// pitch, yaw or roll input turns the vessel. Defaults to 0.5.
+	AngularAcceleration float64
+	// Position and Velocity are the vessel's initial state. Default to a
+	// circular orbit 100 km up, over the x-axis and heading along the
+	// y-axis.
+	Position types.Vector3D
+	Velocity types.Vector3D
+	// Rotation is the vessel's initial rotation. Defaults to pointing
+	// prograde in the default orbit.
+	Rotation types.Quaternion
+	// Frame is the game time each step of Run covers, and the wall time
+	// between them. Defaults to 20ms.
+	Frame time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *SimConfig) SetDefaults() {
+	if cfg.Mu == 0 {
+		cfg.Mu = 3.5316e12
+	}
+	if cfg.Radius == 0 {
+		cfg.Radius = 600000
+	}
+	if cfg.Name == "" {
+		cfg.Name = "Sim"
+	}
+	if cfg.Mass == 0 {
+		cfg.Mass = 10000
+	}
+	if cfg.MaxThrust == 0 {
+		cfg.MaxThrust = 200000
+	}
+	if cfg.AngularAcceleration == 0 {
+		cfg.AngularAcceleration = 0.5
+	}
+	if cfg.Position == (types.Vector3D{}) {
+		r := cfg.Radius + 100000
+		cfg.Position = types.NewVector3D(r, 0, 0)
+		if cfg.Velocity == (types.Vector3D{}) {
+			cfg.Velocity = types.NewVector3D(0, math.Sqrt(cfg.Mu/r), 0)
+		}
+	}
+	if cfg.Rotation == (types.Quaternion{}) {
+		cfg.Rotation = types.IdentityQuaternion()
+	}
+	if cfg.Frame == 0 {
+		cfg.Frame = 20 * time.Millisecond
+	}
+}
+
+// The handles of the simulated objects. Every reference frame is the same
+// one, the body's non-rotating frame.
+const (
+	simVessel uint64 = iota + 1
+	simControl
+	simFlight
+	simOrbit
+	simBody
+	simFrame
+)
+
+// simMaxStep is the longest time integrated in one go.
+const simMaxStep = 0.02
+
+// Sim is a simulated SpaceCenter with one vessel, served by a Server, for
+// testing autopilots in a closed loop without the game:
+//
+//	server := krpctest.NewServer()
+//	sim := krpctest.NewSim(server, krpctest.SimConfig{})
+//	go sim.Run(ctx)
+//	client, err := server.Client(ctx)
+//	// Fly the active vessel with the client.
+//
+// The vessel is a point mass orbiting a spherical body without an
+// atmosphere, pushed along its forward axis by its thrust and turned by its
+// pitch, yaw and roll inputs. It rests on the body's surface if it comes
+// down. The body doesn't rotate, and every reference frame is its
+// non-rotating frame, so for example surface and orbital speeds are the
+// same. Only the procedures a Sim handles are simulated; others can be
+// handled by the test as usual.
+type Sim struct {
+	server *Server
+	cfg    SimConfig
+
+	mu       sync.Mutex
+	ut       float64
+	position types.Vector3D
+	velocity types.Vector3D
+	rotation types.Quaternion
+	// angularVelocity is in the vessel's reference frame, in radians per
+	// second.
+	angularVelocity types.Vector3D
+	throttle        float32
+	pitch           float32
+	yaw             float32
+	roll            float32
+}
+
+// NewSim creates a new Sim and sets the server's handlers for the
+// procedures it simulates.
+func NewSim(server *Server, cfg SimConfig) *Sim {
+	cfg.SetDefaults()
+	s := &Sim{
+		server:   server,
+		cfg:      cfg,
+		ut:       cfg.UT,
+		position: cfg.Position,
+		velocity: cfg.Velocity,
+		rotation: cfg.Rotation.Normalize(),
+	}
+	s.handle()
+	return s
+}
+
+// value returns a handler returning a value of the simulation's state.
+func (s *Sim) value(f func() interface{}) Handler {
+	return func(*Call) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return f(), nil
+	}
+}
+
+// input returns handlers getting and setting a control input.
+func (s *Sim) input(v *float32) (get, set Handler) {
+	get = s.value(func() interface{} { return *v })
+	set = func(call *Call) (interface{}, error) {
+		var x float32
+		if err := call.Arg(1, &x); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		*v = x
+		return nil, nil
+	}
+	return get, set
+}
+
+// handle sets the server's handlers.
+func (s *Sim) handle() {
+	const sc = "SpaceCenter"
+	handles := map[string]uint64{
+		"get_ActiveVessel":                            simVessel,
+		"Vessel_get_Control":                          simControl,
+		"Vessel_Flight":                               simFlight,
+		"Vessel_get_Orbit":                            simOrbit,
+		"Orbit_get_Body":                              simBody,
+		"Vessel_get_ReferenceFrame":                   simFrame,
+		"Vessel_get_OrbitalReferenceFrame":            simFrame,
+		"Vessel_get_SurfaceReferenceFrame":            simFrame,
+		"CelestialBody_get_ReferenceFrame":            simFrame,
+		"CelestialBody_get_NonRotatingReferenceFrame": simFrame,
+	}
+	for procedure, handle := range handles {
+		s.server.Return(sc, procedure, handle)
+	}
+	s.server.Return(sc, "Vessel_get_Name", s.cfg.Name)
+	s.server.Return(sc, "Vessel_get_Mass", float32(s.cfg.Mass))
+	s.server.Return(sc, "Vessel_get_AvailableThrust", float32(s.cfg.MaxThrust))
+	s.server.Return(sc, "Vessel_get_MaxThrust", float32(s.cfg.MaxThrust))
+	s.server.Return(sc, "CelestialBody_get_GravitationalParameter", float32(s.cfg.Mu))
+	s.server.Return(sc, "CelestialBody_get_EquatorialRadius", float32(s.cfg.Radius))
+
+	values := map[string]func() interface{}{
+		"get_UT":            func() interface{} { return s.ut },
+		"Vessel_get_Thrust": func() interface{} { return float32(s.thrust()) },
+		"Vessel_Position":   func() interface{} { return s.position.Tuple() },
+		"Vessel_Velocity":   func() interface{} { return s.velocity.Tuple() },
+		"Vessel_Rotation":   func() interface{} { return s.rotation.Tuple() },
+		"Vessel_Direction": func() interface{} {
+			return s.rotation.Rotate(types.NewVector3D(0, 1, 0)).Tuple()
+		},
+		"Vessel_AngularVelocity": func() interface{} {
+			return s.rotation.Rotate(s.angularVelocity).Tuple()
+		},
+		"Flight_get_MeanAltitude":    func() interface{} { return s.altitude() },
+		"Flight_get_SurfaceAltitude": func() interface{} { return s.altitude() },
+		"Flight_get_Speed":           func() interface{} { return s.velocity.Length() },
+		"Flight_get_VerticalSpeed": func() interface{} {
+			return s.velocity.Dot(s.position.Scale(1 / s.position.Length()))
+		},
+		"Orbit_get_Apoapsis": func() interface{} {
+			a, e := s.elements()
+			return a * (1 + e)
+		},
+		"Orbit_get_Periapsis": func() interface{} {
+			a, e := s.elements()
+			return a * (1 - e)
+		},
+		"Orbit_get_ApoapsisAltitude": func() interface{} {
+			a, e := s.elements()
+			return a*(1+e) - s.cfg.Radius
+		},
+		"Orbit_get_PeriapsisAltitude": func() interface{} {
+			a, e := s.elements()
+			return a*(1-e) - s.cfg.Radius
+		},
+		"Orbit_get_SemiMajorAxis": func() interface{} {
+			a, _ := s.elements()
+			return a
+		},
+		"Orbit_get_Eccentricity": func() interface{} {
+			_, e := s.elements()
+			return e
+		},
+	}
+	for procedure, f := range values {
+		s.server.Handle(sc, procedure, s.value(f))
+	}
+
+	inputs := map[string]*float32{
+		"Throttle": &s.throttle,
+		"Pitch":    &s.pitch,
+		"Yaw":      &s.yaw,
+		"Roll":     &s.roll,
+	}
+	for name, v := range inputs {
+		get, set := s.input(v)
+		s.server.Handle(sc, "Control_get_"+name, get)
+		s.server.Handle(sc, "Control_set_"+name, set)
+	}
+}
+
+// clamp limits x to [lo, hi].
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
+
+// thrust returns the vessel's thrust.
+func (s *Sim) thrust() float64 {
+	return clamp(float64(s.throttle), 0, 1) * s.cfg.MaxThrust
+}
+
+// altitude returns the vessel's altitude above the body's surface.
+func (s *Sim) altitude() float64 {
+	return s.position.Length() - s.cfg.Radius
+}
+
+// elements returns the semi-major axis and eccentricity of the vessel's
+// orbit.
+func (s *Sim) elements() (a, e float64) {
+	r := s.position.Length()
+	v := s.velocity.Length()
+	h := s.position.Cross(s.velocity)
+	ev := s.velocity.Cross(h).Scale(1 / s.cfg.Mu).Add(s.position.Scale(-1 / r))
+	a = -s.cfg.Mu / (v*v - 2*s.cfg.Mu/r)
+	return a, ev.Length()
+}
+
+// step integrates the simulation over dt seconds.
+func (s *Sim) step(dt float64) {
+	// The vessel's x-axis points right, y-axis forwards and z-axis down, so
+	// positive inputs turn it the negative way around them.
+	inputs := types.NewVector3D(
+		-clamp(float64(s.pitch), -1, 1),
+		-clamp(float64(s.roll), -1, 1),
+		-clamp(float64(s.yaw), -1, 1),
+	)
+	s.angularVelocity = s.angularVelocity.Add(inputs.Scale(s.cfg.AngularAcceleration * dt))
+	if w := s.angularVelocity.Length(); w > 0 {
+		turn := types.QuaternionFromAxisAngle(s.angularVelocity.Scale(1/w), w*dt)
+		s.rotation = s.rotation.Mul(turn).Normalize()
+	}
+
+	r := s.position.Length()
+	gravity := s.position.Scale(-s.cfg.Mu / (r * r * r))
+	forward := s.rotation.Rotate(types.NewVector3D(0, 1, 0))
+	thrust := forward.Scale(s.thrust() / s.cfg.Mass)
+	s.velocity = s.velocity.Add(gravity.Add(thrust).Scale(dt))
+	s.position = s.position.Add(s.velocity.Scale(dt))
+
+	// Rest on the surface rather than falling through it.
+	if r := s.position.Length(); r < s.cfg.Radius {
+		up := s.position.Scale(1 / r)
+		s.position = up.Scale(s.cfg.Radius)
+		if vs := s.velocity.Dot(up); vs < 0 {
+			s.velocity = s.velocity.Add(up.Scale(-vs))
+		}
+	}
+	s.ut += dt
+}
+
+// Step advances the simulation by dt seconds of game time, then sends the
+// changed values to the server's streams.
+func (s *Sim) Step(dt float64) {
+	s.mu.Lock()
+	for dt > 0 {
+		h := math.Min(dt, simMaxStep)
+		s.step(h)
+		dt -= h
+	}
+	s.mu.Unlock()
+	s.server.Update()
+}
+
+// Run steps the simulation in real time, a frame at a time, until the
+// context is canceled.
+func (s *Sim) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Frame)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Step(s.cfg.Frame.Seconds())
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package krpctest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// connectSim returns a new Sim and its active vessel, through a client.
+func connectSim(t *testing.T, cfg SimConfig) (*Sim, *spacecenter.Vessel) {
+	t.Helper()
+	server, client := connect(t)
+	sim := NewSim(server, cfg)
+	vessel, err := spacecenter.New(client).ActiveVessel()
+	require.NoError(t, err)
+	require.NotNil(t, vessel)
+	return sim, vessel
+}
+
+func TestSimOrbit(t *testing.T) {
+	sim, vessel := connectSim(t, SimConfig{})
+	orbit, err := vessel.Orbit()
+	require.NoError(t, err)
+	apoapsis, err := orbit.ApoapsisAltitude()
+	require.NoError(t, err)
+	require.InDelta(t, 100000, apoapsis, 1)
+	periapsis, err := orbit.PeriapsisAltitude()
+	require.NoError(t, err)
+	require.InDelta(t, 100000, periapsis, 1)
+
+	// After one period, the vessel is back where it started.
+	r := sim.cfg.Position.Length()
+	sim.Step(2 * math.Pi * math.Sqrt(r*r*r/sim.cfg.Mu))
+	frame, err := vessel.ReferenceFrame()
+	require.NoError(t, err)
+	position, err := vessel.Position(frame)
+	require.NoError(t, err)
+	require.InDelta(t, 0, types.Vector3DFromTuple(position).Add(sim.cfg.Position.Scale(-1)).Length(), 2000)
+	apoapsis, err = orbit.ApoapsisAltitude()
+	require.NoError(t, err)
+	require.InDelta(t, 100000, apoapsis, 1000)
+}
+
+func TestSimThrust(t *testing.T) {
+	sim, vessel := connectSim(t, SimConfig{})
+	ctrl, err := vessel.Control()
+	require.NoError(t, err)
+	require.NoError(t, ctrl.SetThrottle(1))
+	orbit, err := vessel.Orbit()
+	require.NoError(t, err)
+
+	// Burning prograde for ten seconds adds 200 m/s and raises the
+	// apoapsis, but not the periapsis.
+	speed := sim.cfg.Velocity.Length()
+	sim.Step(10)
+	require.NoError(t, ctrl.SetThrottle(0))
+	frame, err := vessel.ReferenceFrame()
+	require.NoError(t, err)
+	flight, err := vessel.Flight(frame)
+	require.NoError(t, err)
+	newSpeed, err := flight.Speed()
+	require.NoError(t, err)
+	require.InDelta(t, speed+200, newSpeed, 5)
+	apoapsis, err := orbit.ApoapsisAltitude()
+	require.NoError(t, err)
+	require.Greater(t, apoapsis, 200000.0)
+	periapsis, err := orbit.PeriapsisAltitude()
+	require.NoError(t, err)
+	require.InDelta(t, 100000, periapsis, 5000)
+}
+
+func TestSimLanded(t *testing.T) {
+	sim, vessel := connectSim(t, SimConfig{
+		Position: types.NewVector3D(601000, 0, 0),
+		Velocity: types.NewVector3D(0, 0, 0),
+	})
+	sim.Step(60)
+	frame, err := vessel.ReferenceFrame()
+	require.NoError(t, err)
+	flight, err := vessel.Flight(frame)
+	require.NoError(t, err)
+	altitude, err := flight.MeanAltitude()
+	require.NoError(t, err)
+	require.InDelta(t, 0, altitude, 1e-6)
+	verticalSpeed, err := flight.VerticalSpeed()
+	require.NoError(t, err)
+	require.InDelta(t, 0, verticalSpeed, 1e-6)
+}
+
+func TestSimAttitudeController(t *testing.T) {
+	sim, vessel := connectSim(t, SimConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sim.Run(ctx)
+
+	frame, err := vessel.ReferenceFrame()
+	require.NoError(t, err)
+	attitude := spacecenter.NewAttitudeController(vessel, frame, spacecenter.AttitudeConfig{})
+	attitude.SetTargetDirection(types.NewVector3D(1, 0, 1))
+	done := make(chan error)
+	go func() { done <- attitude.Run(ctx) }()
+
+	// The controller turns the vessel from prograde to the target.
+	require.Eventually(t, func() bool {
+		return attitude.Error() < 2
+	}, 20*time.Second, 50*time.Millisecond)
+	direction, err := vessel.Direction(frame)
+	require.NoError(t, err)
+	angle := types.Vector3DFromTuple(direction).AngleBetween(types.NewVector3D(1, 0, 1))
+	require.Less(t, angle, 3*math.Pi/180)
+
+	cancel()
+	require.NoError(t, <-done)
+}
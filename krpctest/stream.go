@@ -0,0 +1,85 @@
+package krpctest
+
+import (
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+)
+
+// Stream is a stream whose values are sent by the test, for testing code
+// that reads streams, such as control loops, without a server:
+//
+//	altitude := krpctest.NewStream[float64]()
+//	go loop.Run(ctx, altitude.Stream)
+//	altitude.Emit(123.4)
+//
+// The services' types are structs rather than interfaces, so they can't be
+// mocked; code that takes streams or properties as arguments can be tested
+// with these and krpcgo.NewProperty, and anything else against a Server.
+type Stream[T any] struct {
+	*krpcgo.Stream[T]
+
+	mu     sync.Mutex
+	closed chan struct{}
+}
+
+// NewStream creates a new Stream. Its Stream can't be cloned.
+func NewStream[T any]() *Stream[T] {
+	s := &Stream[T]{
+		Stream: &krpcgo.Stream[T]{C: make(chan T)},
+		closed: make(chan struct{}),
+	}
+	s.AddCloser(func() error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-s.closed:
+		default:
+			close(s.closed)
+		}
+		return nil
+	})
+	return s
+}
+
+// Emit sends values in turn, each once the code under test has received
+// the last, so that it sees them all. It returns false if the stream was
+// closed first.
+func (s *Stream[T]) Emit(values ...T) bool {
+	for _, v := range values {
+		select {
+		case s.C <- v:
+		case <-s.closed:
+			return false
+		}
+	}
+	return true
+}
+
+// EmitWithin is Emit, but gives up after timeout, e.g. because the code
+// under test stopped reading, and returns false.
+func (s *Stream[T]) EmitWithin(timeout time.Duration, values ...T) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, v := range values {
+		select {
+		case s.C <- v:
+		case <-s.closed:
+			return false
+		case <-timer.C:
+			return false
+		}
+	}
+	return true
+}
+
+// Closed reports whether the code under test closed the stream.
+func (s *Stream[T]) Closed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,35 @@
+package krpctest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEmit(t *testing.T) {
+	s := NewStream[float64]()
+	received := make(chan float64, 3)
+	go func() {
+		for v := range s.C {
+			received <- v
+			if len(received) == 3 {
+				s.Close()
+				return
+			}
+		}
+	}()
+
+	require.True(t, s.Emit(1, 2, 3))
+	require.Equal(t, 1.0, <-received)
+	require.Equal(t, 2.0, <-received)
+	require.Equal(t, 3.0, <-received)
+	require.Eventually(t, s.Closed, time.Second, time.Millisecond)
+	require.False(t, s.Emit(4))
+}
+
+func TestStreamEmitWithin(t *testing.T) {
+	s := NewStream[string]()
+	require.False(t, s.EmitWithin(10*time.Millisecond, "nobody is listening"))
+	require.False(t, s.Closed())
+}
@@ -0,0 +1,528 @@
+package spacecenter
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Orbit - describes an orbit.
+type Orbit struct {
+	service.BaseClass
+}
+
+// NewOrbit creates a new Orbit.
+func NewOrbit(id uint64, client *krpcgo.KRPCClient) *Orbit {
+	c := &Orbit{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Body - the celestial body (e.g. planet or moon) around which the object is
+// orbiting.
+//
+// Allowed game scenes: any.
+func (s *Orbit) Body() (*CelestialBody, error) {
+	var err error
+	var argBytes []byte
+	var vv CelestialBody
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Body",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// ApoapsisAltitude - the apoapsis of the orbit, in meters, above the sea level
+// of the body being orbited.
+//
+// Allowed game scenes: any.
+func (s *Orbit) ApoapsisAltitude() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_ApoapsisAltitude",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ApoapsisAltitudeStream - the apoapsis of the orbit, in meters, above the sea
+// level of the body being orbited.
+//
+// Allowed game scenes: any.
+func (s *Orbit) ApoapsisAltitudeStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_ApoapsisAltitude",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// PeriapsisAltitude - the periapsis of the orbit, in meters, above the sea
+// level of the body being orbited.
+//
+// Allowed game scenes: any.
+func (s *Orbit) PeriapsisAltitude() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_PeriapsisAltitude",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// PeriapsisAltitudeStream - the periapsis of the orbit, in meters, above the
+// sea level of the body being orbited.
+//
+// Allowed game scenes: any.
+func (s *Orbit) PeriapsisAltitudeStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_PeriapsisAltitude",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Eccentricity - the <a
+// href="https://en.wikipedia.org/wiki/Orbital_eccentricity">eccentricity</a> of
+// the orbit.
+//
+// Allowed game scenes: any.
+func (s *Orbit) Eccentricity() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Eccentricity",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// EccentricityStream - the <a
+// href="https://en.wikipedia.org/wiki/Orbital_eccentricity">eccentricity</a> of
+// the orbit.
+//
+// Allowed game scenes: any.
+func (s *Orbit) EccentricityStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Eccentricity",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Inclination - the <a
+// href="https://en.wikipedia.org/wiki/Orbital_inclination">inclination</a> of
+// the orbit, in radians.
+//
+// Allowed game scenes: any.
+func (s *Orbit) Inclination() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Inclination",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// InclinationStream - the <a
+// href="https://en.wikipedia.org/wiki/Orbital_inclination">inclination</a> of
+// the orbit, in radians.
+//
+// Allowed game scenes: any.
+func (s *Orbit) InclinationStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Inclination",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Period - the orbital period, in seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) Period() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Period",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// PeriodStream - the orbital period, in seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) PeriodStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_Period",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// TimeToApoapsis - the time until the object reaches apoapsis, in seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) TimeToApoapsis() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_TimeToApoapsis",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TimeToApoapsisStream - the time until the object reaches apoapsis, in
+// seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) TimeToApoapsisStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_TimeToApoapsis",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// TimeToPeriapsis - the time until the object reaches periapsis, in seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) TimeToPeriapsis() (float64, error) {
+	var err error
+	var argBytes []byte
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_TimeToPeriapsis",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TimeToPeriapsisStream - the time until the object reaches periapsis, in
+// seconds.
+//
+// Allowed game scenes: any.
+func (s *Orbit) TimeToPeriapsisStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Orbit_get_TimeToPeriapsis",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
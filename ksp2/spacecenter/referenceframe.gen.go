@@ -0,0 +1,21 @@
+package spacecenter
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	service "github.com/atburke/krpc-go/lib/service"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// ReferenceFrame - represents a reference frame for positions, rotations and
+// velocities.
+type ReferenceFrame struct {
+	service.BaseClass
+}
+
+// NewReferenceFrame creates a new ReferenceFrame.
+func NewReferenceFrame(id uint64, client *krpcgo.KRPCClient) *ReferenceFrame {
+	c := &ReferenceFrame{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
@@ -0,0 +1,287 @@
+// Package spacecenter provides methods to invoke procedures in the SpaceCenter
+// service.
+//
+// From service docs: provides functionality to interact with Kerbal Space
+// Program 2. This includes controlling the active vessel, managing its
+// resources, planning maneuver nodes and auto-piloting.
+package spacecenter
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// SpaceCenter - provides functionality to interact with Kerbal Space Program 2.
+// This includes controlling the active vessel, managing its resources, planning
+// maneuver nodes and auto-piloting.
+type SpaceCenter struct {
+	Client *krpcgo.KRPCClient
+}
+
+// New creates a new SpaceCenter.
+func New(client *krpcgo.KRPCClient) *SpaceCenter {
+	return &SpaceCenter{Client: client}
+}
+
+// Available checks if the SpaceCenter service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "SpaceCenter")
+}
+
+// ActiveVessel - the currently active vessel.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) ActiveVessel() (*Vessel, error) {
+	var err error
+	var vv Vessel
+	request := &types.ProcedureCall{
+		Procedure: "get_ActiveVessel",
+		Service:   "SpaceCenter",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetActiveVessel - the currently active vessel.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) SetActiveVessel(value *Vessel) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "set_ActiveVessel",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ActiveVesselProp - returns a handle to the ActiveVessel property.
+func (s *SpaceCenter) ActiveVesselProp() *krpcgo.Property[*Vessel] {
+	return krpcgo.NewProperty(s.ActiveVessel, s.SetActiveVessel, nil)
+}
+
+// Vessels - a list of all the vessels in the game.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) Vessels() ([]*Vessel, error) {
+	var err error
+	var vv []*Vessel
+	request := &types.ProcedureCall{
+		Procedure: "get_Vessels",
+		Service:   "SpaceCenter",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	for _, v := range vv {
+		v.Client = s.Client
+	}
+	return vv, nil
+}
+
+// VesselsStream - a list of all the vessels in the game.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) VesselsStream() (*krpcgo.Stream[[]*Vessel], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Vessels",
+		Service:   "SpaceCenter",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []*Vessel {
+		var value []*Vessel
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Bodies - a dictionary of all celestial bodies (planets, moons, etc.) in the
+// game, keyed by the name of the body.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) Bodies() (map[string]*CelestialBody, error) {
+	var err error
+	var vv map[string]*CelestialBody
+	request := &types.ProcedureCall{
+		Procedure: "get_Bodies",
+		Service:   "SpaceCenter",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// BodiesStream - a dictionary of all celestial bodies (planets, moons, etc.) in
+// the game, keyed by the name of the body.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) BodiesStream() (*krpcgo.Stream[map[string]*CelestialBody], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Bodies",
+		Service:   "SpaceCenter",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) map[string]*CelestialBody {
+		var value map[string]*CelestialBody
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// UT - the current universal time in seconds.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) UT() (float64, error) {
+	var err error
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "get_UT",
+		Service:   "SpaceCenter",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// UTStream - the current universal time in seconds.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) UTStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_UT",
+		Service:   "SpaceCenter",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// G - the value of the <a
+// href="https://en.wikipedia.org/wiki/Gravitational_constant">gravitational
+// constant</a> G in N(m/kg)^2.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) G() (float64, error) {
+	var err error
+	var vv float64
+	request := &types.ProcedureCall{
+		Procedure: "get_G",
+		Service:   "SpaceCenter",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// GStream - the value of the <a
+// href="https://en.wikipedia.org/wiki/GStreamravitational_constant">gravitational
+// constant</a> GStream in N(m/kg)^2.
+//
+// Allowed game scenes: any.
+func (s *SpaceCenter) GStream() (*krpcgo.Stream[float64], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_G",
+		Service:   "SpaceCenter",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) float64 {
+		var value float64
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
@@ -0,0 +1,220 @@
+package krpcgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// pingCall is the call Ping makes. Getting the client's ID does no work on
+// the server beyond answering.
+var pingCall = &types.ProcedureCall{Service: "KRPC", Procedure: "GetClientID"}
+
+// pingResult is the outcome of a ping.
+type pingResult struct {
+	d   time.Duration
+	err error
+}
+
+// startPing pings the server in the background, sending the result on the
+// returned channel once the server answers.
+func (c *KRPCClient) startPing() <-chan pingResult {
+	done := make(chan pingResult, 1)
+	go func() {
+		started := time.Now()
+		_, err := c.Call(pingCall)
+		done <- pingResult{time.Since(started), err}
+	}()
+	return done
+}
+
+// Ping makes a cheap call to the server and returns how long it took to
+// answer. If ctx is done first, Ping returns without waiting for the answer.
+func (c *KRPCClient) Ping(ctx context.Context) (time.Duration, error) {
+	select {
+	case <-ctx.Done():
+		return 0, tracerr.Wrap(ctx.Err())
+	case r := <-c.startPing():
+		return r.d, tracerr.Wrap(r.err)
+	}
+}
+
+// LatencyConfig configures a LatencyEstimator.
+type LatencyConfig struct {
+	// Interval is how often to ping the server. Defaults to 1 second.
+	Interval time.Duration
+	// Timeout is how long to wait for each ping. Defaults to 5 seconds.
+	Timeout time.Duration
+	// Smoothing is the weight, between 0 and 1, that each sample is given
+	// in the moving averages. Defaults to 0.2.
+	Smoothing float64
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *LatencyConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Smoothing == 0 {
+		cfg.Smoothing = 0.2
+	}
+}
+
+// LatencyMetrics describe the round-trip latency to the server.
+type LatencyMetrics struct {
+	// Last is the latest sample.
+	Last time.Duration
+	// Mean is the moving average of the samples.
+	Mean time.Duration
+	// Jitter is the moving average of how far samples are from the mean.
+	Jitter time.Duration
+	// Min and Max are the smallest and largest samples.
+	Min time.Duration
+	Max time.Duration
+	// Samples is the number of successful pings, and Failures the number
+	// that failed or timed out.
+	Samples  int
+	Failures int
+	// Updated is when the latest ping finished.
+	Updated time.Time
+}
+
+// TickInterval suggests how often a control loop should make calls so that
+// it doesn't outpace the server: the mean latency plus twice the jitter, or
+// floor if that is longer.
+func (m LatencyMetrics) TickInterval(floor time.Duration) time.Duration {
+	d := m.Mean + 2*m.Jitter
+	if d < floor {
+		return floor
+	}
+	return d
+}
+
+// LatencyEstimator pings the server in the background to estimate its
+// responsiveness.
+type LatencyEstimator struct {
+	client *KRPCClient
+	cfg    LatencyConfig
+
+	mu      sync.Mutex
+	metrics LatencyMetrics
+
+	// pending is a ping that timed out but hasn't been answered yet.
+	pending <-chan pingResult
+}
+
+// NewLatencyEstimator creates a new latency estimator. Call Run to start
+// pinging the server.
+func NewLatencyEstimator(client *KRPCClient, cfg LatencyConfig) *LatencyEstimator {
+	cfg.SetDefaults()
+	return &LatencyEstimator{client: client, cfg: cfg}
+}
+
+// Metrics returns the latency measured so far.
+func (e *LatencyEstimator) Metrics() LatencyMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.metrics
+}
+
+// record adds a sample to the metrics.
+func (e *LatencyEstimator) record(d time.Duration, at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	m := &e.metrics
+	m.Last = d
+	m.Updated = at
+	if m.Samples == 0 {
+		m.Mean, m.Min, m.Max = d, d, d
+	} else {
+		deviation := d - m.Mean
+		if deviation < 0 {
+			deviation = -deviation
+		}
+		m.Mean += time.Duration(e.cfg.Smoothing * float64(d-m.Mean))
+		m.Jitter += time.Duration(e.cfg.Smoothing * float64(deviation-m.Jitter))
+		if d < m.Min {
+			m.Min = d
+		}
+		if d > m.Max {
+			m.Max = d
+		}
+	}
+	m.Samples++
+}
+
+// fail counts a failed ping.
+func (e *LatencyEstimator) fail(at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metrics.Failures++
+	e.metrics.Updated = at
+}
+
+// ping pings the server once and records the result. If an earlier ping
+// is still waiting for an answer, it does nothing, so that calls don't pile
+// up on a stalled server. It returns an error only if the connection is
+// closed.
+func (e *LatencyEstimator) ping(ctx context.Context) error {
+	if e.pending != nil {
+		select {
+		case r := <-e.pending:
+			// Already counted as a failure when it timed out.
+			e.pending = nil
+			if errors.Is(r.err, io.EOF) || errors.Is(r.err, net.ErrClosed) {
+				return tracerr.Wrap(r.err)
+			}
+		default:
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout)
+	defer cancel()
+	pending := e.client.startPing()
+	var d time.Duration
+	var err error
+	select {
+	case <-ctx.Done():
+		e.pending = pending
+		err = tracerr.Wrap(ctx.Err())
+	case r := <-pending:
+		d, err = r.d, r.err
+	}
+	switch {
+	case err == nil:
+		e.record(d, time.Now())
+	case errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed):
+		return tracerr.Wrap(err)
+	case ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded):
+		// Cancelled rather than timed out, which isn't the server's fault.
+	default:
+		e.fail(time.Now())
+	}
+	return nil
+}
+
+// Run pings the server until ctx is done or the connection is closed.
+func (e *LatencyEstimator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := e.ping(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package krpcgo
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// pingServer answers calls on conn after a delay, recording the procedures
+// called.
+func pingServer(conn net.Conn, delay time.Duration, procedures chan<- string) {
+	for {
+		in, err := receive(conn)
+		if err != nil {
+			return
+		}
+		var req types.Request
+		if proto.Unmarshal(in, &req) != nil {
+			return
+		}
+		resp := &types.Response{}
+		for _, call := range req.Calls {
+			procedures <- call.Service + "." + call.Procedure
+			resp.Results = append(resp.Results, &types.ProcedureResult{Value: []byte{1}})
+		}
+		time.Sleep(delay)
+		out, _ := proto.Marshal(resp)
+		if send(conn, out) != nil {
+			return
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	procedures := make(chan string, 10)
+	go pingServer(serverConn, 20*time.Millisecond, procedures)
+
+	client := DefaultKRPCClient()
+	client.conn = clientConn
+	d, err := client.Ping(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, d, 20*time.Millisecond)
+	require.Equal(t, "KRPC.GetClientID", <-procedures)
+
+	// A ping that takes too long is abandoned.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = client.Ping(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLatencyMetrics(t *testing.T) {
+	e := NewLatencyEstimator(nil, LatencyConfig{Smoothing: 0.5})
+	now := time.Now()
+	for _, d := range []time.Duration{10, 20, 10} {
+		e.record(d*time.Millisecond, now)
+	}
+	e.fail(now)
+	m := e.Metrics()
+	require.Equal(t, LatencyMetrics{
+		Last:     10 * time.Millisecond,
+		Mean:     12500 * time.Microsecond,
+		Jitter:   5 * time.Millisecond,
+		Min:      10 * time.Millisecond,
+		Max:      20 * time.Millisecond,
+		Samples:  3,
+		Failures: 1,
+		Updated:  now,
+	}, m)
+	require.Equal(t, 22500*time.Microsecond, m.TickInterval(0))
+	require.Equal(t, time.Second, m.TickInterval(time.Second))
+}
+
+func TestLatencyEstimatorRun(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		procedures := make(chan string)
+		go func() {
+			for range procedures {
+			}
+		}()
+		pingServer(conn, 0, procedures)
+	}()
+	clientConn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+
+	client := DefaultKRPCClient()
+	client.conn = clientConn
+	e := NewLatencyEstimator(client, LatencyConfig{Interval: time.Millisecond})
+	done := make(chan error)
+	go func() { done <- e.Run(context.Background()) }()
+	require.Eventually(t, func() bool { return e.Metrics().Samples >= 3 }, time.Second, time.Millisecond)
+
+	// Closing the client stops the estimator.
+	require.NoError(t, client.Close())
+	require.ErrorIs(t, <-done, net.ErrClosed)
+	require.Zero(t, e.Metrics().Failures)
+}
+
+func TestLatencyEstimatorStalled(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	procedures := make(chan string, 100)
+	go pingServer(serverConn, 200*time.Millisecond, procedures)
+
+	client := DefaultKRPCClient()
+	client.conn = clientConn
+	e := NewLatencyEstimator(client, LatencyConfig{Interval: time.Millisecond, Timeout: 5 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, e.Run(ctx), context.DeadlineExceeded)
+
+	// While the first ping is unanswered, no more are started.
+	require.Equal(t, 1, e.Metrics().Failures)
+	require.Len(t, procedures, 1)
+}
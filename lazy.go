@@ -0,0 +1,51 @@
+package krpcgo
+
+import (
+	"context"
+
+	"github.com/ztrue/tracerr"
+)
+
+// connectAttempt is a lazy client's connection attempt, shared by the calls
+// waiting on it.
+type connectAttempt struct {
+	// done is closed when the attempt is over.
+	done chan struct{}
+	err  error
+}
+
+// ensureConnected connects a lazy client if it isn't already. Concurrent
+// callers share a single attempt, and if it fails they all get its error,
+// and the next call tries again.
+func (c *KRPCClient) ensureConnected() error {
+	c.connectMu.Lock()
+	if c.closed {
+		c.connectMu.Unlock()
+		return tracerr.Errorf("Client is closed")
+	}
+	if c.connected {
+		c.connectMu.Unlock()
+		return nil
+	}
+	if attempt := c.connecting; attempt != nil {
+		c.connectMu.Unlock()
+		<-attempt.done
+		return attempt.err
+	}
+	attempt := &connectAttempt{done: make(chan struct{})}
+	c.connecting = attempt
+	c.connectMu.Unlock()
+
+	// The streams run until the client is closed.
+	attempt.err = c.Connect(context.Background())
+	if attempt.err != nil && c.conn != nil {
+		// Don't leave a half-made connection behind for the next attempt.
+		c.conn.Close()
+		c.conn = nil
+	}
+	c.connectMu.Lock()
+	c.connecting = nil
+	c.connectMu.Unlock()
+	close(attempt.done)
+	return tracerr.Wrap(attempt.err)
+}
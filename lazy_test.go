@@ -0,0 +1,112 @@
+package krpcgo_test
+
+import (
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// countingForwarder forwards connections to an address, counting them.
+func countingForwarder(t *testing.T, addr string) (string, *int32) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	var count int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&count, 1)
+			upstream, err := net.Dial("tcp", addr)
+			if err != nil {
+				conn.Close()
+				continue
+			}
+			go func() {
+				_, _ = io.Copy(upstream, conn)
+				upstream.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(conn, upstream)
+				conn.Close()
+			}()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	return port, &count
+}
+
+func TestLazyConnect(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+	var rpcCount, streamCount *int32
+	cfg.RPCPort, rpcCount = countingForwarder(t, net.JoinHostPort(cfg.Host, cfg.RPCPort))
+	cfg.StreamPort, streamCount = countingForwarder(t, net.JoinHostPort(cfg.Host, cfg.StreamPort))
+	cfg.Lazy = true
+
+	client := krpcgo.NewKRPCClient(cfg)
+	sc := spacecenter.New(client)
+	require.Zero(t, atomic.LoadInt32(rpcCount))
+
+	// Concurrent first calls share one connection.
+	var wg sync.WaitGroup
+	uts := make([]float64, 10)
+	errs := make([]error, 10)
+	for i := range uts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uts[i], errs[i] = sc.UT()
+		}(i)
+	}
+	wg.Wait()
+	for i := range uts {
+		require.NoError(t, errs[i])
+		require.Equal(t, 1000.0, uts[i])
+	}
+	require.True(t, client.StreamsAvailable())
+	require.Equal(t, int32(1), atomic.LoadInt32(rpcCount))
+	require.Equal(t, int32(1), atomic.LoadInt32(streamCount))
+	require.Len(t, server.Calls(), 10)
+
+	require.NoError(t, client.Close())
+	_, err := sc.UT()
+	require.Error(t, err)
+}
+
+func TestLazyConnectRetries(t *testing.T) {
+	// Nothing is listening yet, so the first call fails.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, ln.Close())
+	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{Host: "127.0.0.1", RPCPort: port, Lazy: true})
+	defer client.Close()
+	sc := spacecenter.New(client)
+	_, err = sc.UT()
+	require.Error(t, err)
+	require.False(t, client.StreamsAvailable())
+
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+	client.Host, client.RPCPort, client.StreamPort = cfg.Host, cfg.RPCPort, cfg.StreamPort
+	ut, err := sc.UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+}
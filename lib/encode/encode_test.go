@@ -123,3 +123,31 @@ func TestMarshalAndUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkMarshal(b *testing.B) {
+	v := map[string][]types.Tuple3[float64, float64, float64]{
+		"a": {types.NewTuple3(1.0, 2.0, 3.0), types.NewTuple3(4.0, 5.0, 6.0)},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data, err := Marshal(map[string][]types.Tuple3[float64, float64, float64]{
+		"a": {types.NewTuple3(1.0, 2.0, 3.0), types.NewTuple3(4.0, 5.0, 6.0)},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v map[string][]types.Tuple3[float64, float64, float64]
+		if err := Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
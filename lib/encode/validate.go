@@ -0,0 +1,220 @@
+package encode
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/atburke/krpc-go/lib/service"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// ValidationError is where and why a payload doesn't decode as a type.
+type ValidationError struct {
+	// Path is where in the value the error is, e.g. "[2].value.1" for the
+	// second field of the tuple that is the value of the third entry of a
+	// dictionary, or empty for the value itself.
+	Path string
+	// Offset is the position of the error in the bytes of the value at
+	// Path.
+	Offset int
+	// Type is the type expected at Path.
+	Type reflect.Type
+	// Reason describes the error.
+	Reason string
+}
+
+// Error returns a human-readable error.
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "value"
+	}
+	return fmt.Sprintf("invalid %v at %v, byte %d: %v", e.Type, path, e.Offset, e.Reason)
+}
+
+var (
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	classType        = reflect.TypeOf((*service.Class)(nil)).Elem()
+	settableEnumType = reflect.TypeOf((*service.SettableEnum)(nil)).Elem()
+	bytesType        = reflect.TypeOf([]byte(nil))
+)
+
+// Validate checks that a payload decodes as the type of prototype, which
+// may be a value of the type or a pointer to one, without decoding it. It
+// is stricter than Unmarshal, which ignores trailing bytes and values out
+// of range, so it is useful for diagnosing mismatches with an unfamiliar
+// server or service. An invalid payload gets a *ValidationError saying
+// where the problem is.
+func Validate(b []byte, prototype interface{}) error {
+	t := reflect.TypeOf(prototype)
+	if t == nil {
+		return tracerr.Errorf("Validate needs a prototype")
+	}
+	if t.Kind() == reflect.Pointer && !isSpecial(t) {
+		t = t.Elem()
+	}
+	if err := validate(b, t, ""); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// isSpecial checks if a pointer type is decoded by Unmarshal as a whole,
+// rather than as what it points to.
+func isSpecial(t reflect.Type) bool {
+	return t.Implements(protoMessageType) || t.Implements(classType) || t.Implements(settableEnumType)
+}
+
+// invalid returns a ValidationError.
+func invalid(path string, offset int, t reflect.Type, format string, args ...interface{}) error {
+	return &ValidationError{Path: path, Offset: offset, Type: t, Reason: fmt.Sprintf(format, args...)}
+}
+
+// trailing checks that a scalar value used all of its bytes.
+func trailing(buf *proto.Buffer, b []byte, path string, t reflect.Type) error {
+	if n := len(buf.Unread()); n > 0 {
+		return invalid(path, len(b)-n, t, "%d unexpected trailing bytes", n)
+	}
+	return nil
+}
+
+// join extends a path.
+func join(path, elem string) string {
+	if path == "" || elem[0] == '[' {
+		return path + elem
+	}
+	return path + "." + elem
+}
+
+// validate checks that a payload decodes as type t, which is a pointer
+// only if it is special.
+func validate(b []byte, t reflect.Type, path string) error {
+	pt := t
+	if t.Kind() != reflect.Pointer {
+		pt = reflect.PointerTo(t)
+	}
+	switch {
+	case pt.Implements(protoMessageType):
+		m := reflect.New(pt.Elem()).Interface().(proto.Message)
+		if err := proto.Unmarshal(b, m); err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		return nil
+	case pt.Implements(classType):
+		return validate(b, reflect.TypeOf(uint64(0)), path)
+	case pt.Implements(settableEnumType):
+		return validate(b, reflect.TypeOf(int32(0)), path)
+	case t == bytesType:
+		buf := proto.NewBuffer(b)
+		if _, err := buf.DecodeRawBytes(false); err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		return trailing(buf, b, path, t)
+	}
+
+	buf := proto.NewBuffer(b)
+	switch t.Kind() {
+	case reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Bool:
+		u, err := buf.DecodeVarint()
+		if err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		switch t.Kind() {
+		case reflect.Int32, reflect.Uint32:
+			// Zigzag encoding keeps 32-bit values within 32 bits.
+			if u > math.MaxUint32 {
+				return invalid(path, 0, t, "%d is out of range", u)
+			}
+		case reflect.Bool:
+			if u > 1 {
+				return invalid(path, 0, t, "%d is not a bool", u)
+			}
+		}
+		return trailing(buf, b, path, t)
+	case reflect.Float32:
+		if _, err := buf.DecodeFixed32(); err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		return trailing(buf, b, path, t)
+	case reflect.Float64:
+		if _, err := buf.DecodeFixed64(); err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		return trailing(buf, b, path, t)
+	case reflect.String:
+		s, err := buf.DecodeRawBytes(false)
+		if err != nil {
+			return invalid(path, 0, t, "%v", err)
+		}
+		if !utf8.Valid(s) {
+			return invalid(path, 0, t, "not valid UTF-8")
+		}
+		return trailing(buf, b, path, t)
+	case reflect.Slice:
+		var list types.List
+		if err := proto.Unmarshal(b, &list); err != nil {
+			return invalid(path, 0, t, "not a list: %v", err)
+		}
+		for i, item := range list.Items {
+			if err := validate(item, element(t.Elem()), join(path, fmt.Sprintf("[%d]", i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if isEmptyStruct(t.Elem()) {
+			var set types.Set
+			if err := proto.Unmarshal(b, &set); err != nil {
+				return invalid(path, 0, t, "not a set: %v", err)
+			}
+			for i, item := range set.Items {
+				if err := validate(item, t.Key(), join(path, fmt.Sprintf("[%d]", i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		var dict types.Dictionary
+		if err := proto.Unmarshal(b, &dict); err != nil {
+			return invalid(path, 0, t, "not a dictionary: %v", err)
+		}
+		for i, entry := range dict.Entries {
+			entryPath := join(path, fmt.Sprintf("[%d]", i))
+			if err := validate(entry.Key, t.Key(), join(entryPath, "key")); err != nil {
+				return err
+			}
+			if err := validate(entry.Value, element(t.Elem()), join(entryPath, "value")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		var tuple types.Tuple
+		if err := proto.Unmarshal(b, &tuple); err != nil {
+			return invalid(path, 0, t, "not a tuple: %v", err)
+		}
+		if len(tuple.Items) != t.NumField() {
+			return invalid(path, 0, t, "%d elements instead of %d", len(tuple.Items), t.NumField())
+		}
+		for i, item := range tuple.Items {
+			if err := validate(item, element(t.Field(i).Type), join(path, fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return invalid(path, 0, t, "unsupported type")
+}
+
+// element returns the type to validate a collection's elements as, which
+// like Unmarshal looks through pointers to types that aren't special.
+func element(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer && !isSpecial(t) {
+		return t.Elem()
+	}
+	return t
+}
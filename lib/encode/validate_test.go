@@ -0,0 +1,89 @@
+package encode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	mustMarshal := func(v interface{}) []byte {
+		b, err := Marshal(v)
+		require.NoError(t, err)
+		return b
+	}
+	tests := []struct {
+		name      string
+		b         []byte
+		prototype interface{}
+		invalid   bool
+		path      string
+		offset    int
+	}{
+		{name: "float64", b: mustMarshal(3.5), prototype: float64(0)},
+		{name: "pointer prototype", b: mustMarshal(3.5), prototype: new(float64)},
+		{name: "class", b: mustMarshal(uint64(7)), prototype: &testClass{}},
+		{name: "enum", b: mustMarshal(int32(2)), prototype: a},
+		{name: "proto message", b: mustMarshal(&types.Status{Version: "0.5.2"}), prototype: &types.Status{}},
+		{
+			name:      "collections",
+			b:         mustMarshal(map[string][]types.Tuple2[float32, bool]{"a": {types.NewTuple2(float32(1), true)}}),
+			prototype: map[string][]types.Tuple2[float32, bool]{},
+		},
+		{name: "list of classes", b: mustMarshal([]uint64{1, 2}), prototype: []*testClass{}},
+		{
+			name:      "double decoded as float",
+			invalid:   true,
+			b:         mustMarshal(3.5),
+			prototype: float32(0),
+			offset:    4,
+		},
+		{
+			name:      "truncated",
+			invalid:   true,
+			b:         mustMarshal(3.5)[:5],
+			prototype: float64(0),
+		},
+		{
+			name:      "bool out of range",
+			invalid:   true,
+			b:         mustMarshal(uint64(2)),
+			prototype: false,
+		},
+		{
+			name:      "tuple with too few elements",
+			invalid:   true,
+			b:         mustMarshal(types.NewTuple2(1.0, 2.0)),
+			prototype: types.Tuple3[float64, float64, float64]{},
+		},
+		{
+			name:      "wrong element type",
+			invalid:   true,
+			b:         mustMarshal([]string{"a", "b"}),
+			prototype: []float64{},
+			path:      "[0]",
+		},
+		{
+			name:      "wrong dictionary value",
+			invalid:   true,
+			b:         mustMarshal(map[string]types.Tuple2[float64, string]{"a": types.NewTuple2(1.0, "x")}),
+			prototype: map[string]types.Tuple2[float64, float64]{},
+			path:      "[0].value.1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.b, tc.prototype)
+			if !tc.invalid {
+				require.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr), err)
+			require.Equal(t, tc.path, verr.Path, verr.Error())
+			require.Equal(t, tc.offset, verr.Offset, verr.Error())
+		})
+	}
+}
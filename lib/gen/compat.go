@@ -0,0 +1,150 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/atburke/krpc-go/lib/utils"
+	"github.com/atburke/krpc-go/types"
+	"github.com/dave/jennifer/jen"
+	"github.com/ztrue/tracerr"
+)
+
+// Shim describes a procedure that was renamed or re-signatured between kRPC
+// releases. When the old procedure is missing from a service but the new one
+// is present, a deprecated alias is generated under the old name that calls
+// the new procedure, so code written against the old name keeps compiling.
+type Shim struct {
+	// Service is the service the procedure belongs to.
+	Service string `json:"service"`
+	// Old is the old procedure name, e.g. "Vessel_get_OldName".
+	Old string `json:"old"`
+	// New is the current procedure name, e.g. "Vessel_get_NewName".
+	New string `json:"new"`
+	// Defaults holds values for parameters of the new procedure that the old
+	// procedure didn't have, keyed by parameter name.
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+	// Version is the kRPC version that made the change.
+	Version string `json:"version,omitempty"`
+}
+
+// ShimsFile is the file (relative to the repository root) that holds the
+// checked-in compatibility shims.
+const ShimsFile = "lib/gen/shims.json"
+
+// LoadShims loads a JSON-encoded list of shims from a file.
+func LoadShims(path string) ([]Shim, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var shims []Shim
+	if err := json.Unmarshal(data, &shims); err != nil {
+		return nil, tracerr.Errorf("Failed to parse %v: %v", path, err)
+	}
+	for _, shim := range shims {
+		// JSON numbers decode as floats, which wouldn't compile as integer
+		// arguments.
+		for name, value := range shim.Defaults {
+			if f, ok := value.(float64); ok && f == math.Trunc(f) {
+				shim.Defaults[name] = int(f)
+			}
+		}
+	}
+	return shims, nil
+}
+
+// GetMethodName gets the name of the Go method generated for a procedure.
+func GetMethodName(procedureName string) string {
+	switch GetProcedureType(procedureName) {
+	case ServiceGetter, ClassGetter:
+		propName, _ := GetPropertyName(procedureName)
+		return propName
+	case ServiceSetter, ClassSetter:
+		propName, _ := GetPropertyName(procedureName)
+		return "Set" + propName
+	default:
+		return GetProcedureName(procedureName)
+	}
+}
+
+// GenerateShim generates a deprecated alias for a renamed procedure.
+func GenerateShim(f *jen.File, serviceName string, shim Shim, procedure *types.Procedure) error {
+	if shim.New != procedure.Name {
+		return tracerr.Errorf("Shim for %q does not target procedure %q", shim.Old, procedure.Name)
+	}
+	receiver := serviceName
+	className, err := GetClassName(procedure.Name)
+	isClass := err == nil
+	// Static class methods don't take an instance of the class.
+	isInstance := isClass && GetProcedureType(procedure.Name) != StaticClassMethod
+	if isClass {
+		receiver = className
+		if oldClassName, err := GetClassName(shim.Old); err != nil || oldClassName != className {
+			return tracerr.Errorf("Shim for %q must stay on class %v", shim.Old, className)
+		}
+	}
+
+	pkg := getServicePackage(serviceName)
+	var params, args []jen.Code
+	for i, param := range procedure.Parameters {
+		if i == 0 && isInstance {
+			continue
+		}
+		name := utils.SanitizeIdentifier(param.Name)
+		if value, ok := shim.Defaults[param.Name]; ok {
+			args = append(args, jen.Lit(value))
+			continue
+		}
+		params = append(params, jen.Id(name).Add(GetGoType(param.Type, WithPackage(pkg))))
+		args = append(args, jen.Id(name))
+	}
+
+	oldName := GetMethodName(shim.Old)
+	newName := GetMethodName(procedure.Name)
+	shimDocs := func(oldName, newName string) (string, error) {
+		docs, err := utils.ParseXMLDocumentation(procedure.Documentation, oldName+" - ")
+		if err != nil {
+			return "", tracerr.Wrap(err)
+		}
+		deprecation := fmt.Sprintf("Deprecated: use %v instead.", newName)
+		if shim.Version != "" {
+			deprecation = fmt.Sprintf("Deprecated: renamed to %v in kRPC %v.", newName, shim.Version)
+		}
+		return fmt.Sprintf("%v\n\n%v", docs, deprecation), nil
+	}
+	docs, err := shimDocs(oldName, newName)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	var retType jen.Code = jen.Error()
+	returnType := GetGoType(procedure.ReturnType, WithPackage(pkg))
+	if returnType != nil {
+		retType = jen.Parens(jen.List(returnType, jen.Error()))
+	}
+	f.Comment(WrapDocComment(docs))
+	f.Func().Params(
+		jen.Id("s").Op("*").Id(receiver),
+	).Id(oldName).Params(params...).Add(retType).Block(
+		jen.Return(jen.Id("s").Dot(newName).Call(args...)),
+	)
+
+	// Alias the stream as well, if there is one.
+	if returnType != nil && !isPointerType(procedure.ReturnType.Code) {
+		streamDocs, err := shimDocs(oldName+"Stream", newName+"Stream")
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		streamType := jen.Op("*").Qual(krpcPkg, "Stream").Types(returnType)
+		f.Comment(WrapDocComment(streamDocs))
+		f.Func().Params(
+			jen.Id("s").Op("*").Id(receiver),
+		).Id(oldName + "Stream").Params(params...).Parens(jen.List(streamType, jen.Error())).Block(
+			jen.Return(jen.Id("s").Dot(newName + "Stream").Call(args...)),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DefinitionsDir is the directory (relative to the repository root) that
+// holds checked-in service definitions. These are used to generate services
+// provided by mods that may not be installed on the server the generator
+// connects to.
+const DefinitionsDir = "lib/gen/definitions"
+
+// LoadDefinitions loads every service definition (a JSON-encoded
+// [types.Service]) in a directory, sorted by file name.
+func LoadDefinitions(dir string) ([]*types.Service, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	sort.Strings(paths)
+
+	var services []*types.Service
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		var service types.Service
+		if err := protojson.Unmarshal(data, &service); err != nil {
+			return nil, tracerr.Errorf("Failed to parse %v: %v", path, err)
+		}
+		services = append(services, &service)
+	}
+	return services, nil
+}
+
+// MergeDefinitions adds the services in extra that aren't already in
+// services. Definitions reported by a live server always take precedence.
+func MergeDefinitions(services []*types.Service, extra []*types.Service) []*types.Service {
+	known := make(map[string]struct{})
+	for _, service := range services {
+		known[service.Name] = struct{}{}
+	}
+	for _, service := range extra {
+		if _, ok := known[service.Name]; !ok {
+			services = append(services, service)
+		}
+	}
+	return services
+}
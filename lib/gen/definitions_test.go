@@ -0,0 +1,35 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/dave/jennifer/jen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefinitions(t *testing.T) {
+	services, err := LoadDefinitions("definitions")
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	for _, service := range services {
+		service := service
+		t.Run(service.Name, func(t *testing.T) {
+			require.NotEmpty(t, service.Procedures)
+			// Every checked-in definition should generate cleanly.
+			f := jen.NewFile("gentest")
+			require.NoError(t, GenerateService(service, func(string) *jen.File { return f }))
+		})
+	}
+}
+
+func TestMergeDefinitions(t *testing.T) {
+	live := []*types.Service{{Name: "KRPC"}, {Name: "SpaceCenter", Documentation: "live"}}
+	extra := []*types.Service{{Name: "SpaceCenter", Documentation: "checked in"}, {Name: "KOS"}}
+
+	merged := MergeDefinitions(live, extra)
+	require.Len(t, merged, 3)
+	require.Equal(t, "live", merged[1].Documentation)
+	require.Equal(t, "KOS", merged[2].Name)
+}
@@ -2,6 +2,7 @@
 package gen
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -14,6 +15,9 @@ import (
 
 const DocsLineLength = 77 // line length of 80 minus "// "
 
+// GeneratedWarning is the comment that marks generated files.
+const GeneratedWarning = "Code generated by gen_services.go. DO NOT EDIT."
+
 func WrapDocComment(s string) string {
 	wrapped := wordwrap.WrapString(s, DocsLineLength)
 	inputLines := strings.Split(wrapped, "\n")
@@ -24,8 +28,31 @@ func WrapDocComment(s string) string {
 	return strings.Join(outputLines, "\n")
 }
 
-// GenerateService generates a service.
-func GenerateService(f *jen.File, service *types.Service) error {
+// GenerateServiceConfig configures how a service is generated.
+type GenerateServiceConfig struct {
+	// Shims are the compatibility shims to apply to the service.
+	Shims []Shim
+}
+
+type GenerateServiceOption func(*GenerateServiceConfig)
+
+// WithShims generates deprecated aliases for the given shims.
+func WithShims(shims []Shim) GenerateServiceOption {
+	return func(cfg *GenerateServiceConfig) {
+		cfg.Shims = shims
+	}
+}
+
+// GenerateService generates a service. Each class is generated into its own
+// file; fileFor is called with a class name to get the file for that class,
+// or with an empty string to get the file for the rest of the service.
+func GenerateService(service *types.Service, fileFor func(className string) *jen.File, opts ...GenerateServiceOption) error {
+	var cfg GenerateServiceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	f := fileFor("")
 	for _, exception := range service.Exceptions {
 		if err := GenerateException(f, exception); err != nil {
 			return tracerr.Wrap(err)
@@ -37,7 +64,7 @@ func GenerateService(f *jen.File, service *types.Service) error {
 		}
 	}
 	for _, class := range service.Classes {
-		if err := GenerateClass(f, class); err != nil {
+		if err := GenerateClass(fileFor(class.Name), class); err != nil {
 			return tracerr.Wrap(err)
 		}
 	}
@@ -61,10 +88,96 @@ func GenerateService(f *jen.File, service *types.Service) error {
 		})),
 	)
 
+	// The KRPC service is always available, so it doesn't need a probe.
+	if service.Name != "KRPC" {
+		f.Comment(WrapDocComment(fmt.Sprintf("Available checks if the %v service is available on the server.", service.Name)))
+		f.Func().Id("Available").Params(
+			jen.Id("client").Op("*").Qual(krpcPkg, "KRPCClient"),
+		).Params(jen.Bool(), jen.Error()).Block(
+			jen.Return(jen.Qual(servicePkg, "Available").Call(jen.Id("client"), jen.Lit(service.Name))),
+		)
+	}
+
+	procedures := make(map[string]*types.Procedure)
+	for _, procedure := range service.Procedures {
+		procedures[procedure.Name] = procedure
+	}
 	for _, procedure := range service.Procedures {
-		if err := GenerateProcedure(f, service.Name, procedure); err != nil {
+		// Class methods go in the class's file.
+		className, _ := GetClassName(procedure.Name)
+		pf := fileFor(className)
+		if err := GenerateProcedure(pf, service.Name, procedure); err != nil {
+			return tracerr.Wrap(err)
+		}
+		// Generate a property accessor once both halves of a get/set pair
+		// exist.
+		getterName, err := GetPropertyGetterName(procedure.Name)
+		if err != nil {
+			continue
+		}
+		if getter, ok := procedures[getterName]; ok {
+			if err := GenerateProperty(pf, service.Name, getter, procedure); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+
+	// Generate aliases for procedures that were renamed, unless the server
+	// still has the old one.
+	for _, shim := range cfg.Shims {
+		if shim.Service != service.Name {
+			continue
+		}
+		procedure, ok := procedures[shim.New]
+		if _, hasOld := procedures[shim.Old]; !ok || hasOld {
+			continue
+		}
+		className, _ := GetClassName(procedure.Name)
+		if err := GenerateShim(fileFor(className), service.Name, shim, procedure); err != nil {
 			return tracerr.Wrap(err)
 		}
 	}
 	return nil
 }
+
+// GenerateServiceFiles generates a service's package, returning the contents
+// of each of its files by file name.
+func GenerateServiceFiles(service *types.Service, opts ...GenerateServiceOption) (map[string][]byte, error) {
+	packageName := strings.ToLower(service.Name)
+	serviceDocs, err := utils.ParseXMLDocumentation(service.Documentation, "From service docs: ")
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	files := make(map[string]*jen.File)
+	fileFor := func(className string) *jen.File {
+		fileName := GetFileName(service.Name, className)
+		if f, ok := files[fileName]; ok {
+			return f
+		}
+		f := jen.NewFile(packageName)
+		if className == "" {
+			f.PackageComment(WrapDocComment(fmt.Sprintf(
+				"Package %v provides methods to invoke procedures in the %v service.\n\n%v",
+				packageName, service.Name, serviceDocs,
+			)))
+		}
+		f.Comment(GeneratedWarning)
+		f.Line()
+		files[fileName] = f
+		return f
+	}
+	if err := GenerateService(service, fileFor, opts...); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	out := make(map[string][]byte, len(files))
+	for fileName, f := range files {
+		var buf bytes.Buffer
+		if err := f.Render(&buf); err != nil {
+			return nil, tracerr.Errorf("Failed to render %v: %v", fileName, err)
+		}
+		out[fileName] = buf.Bytes()
+	}
+	return out, nil
+}
@@ -4,25 +4,37 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	krpcgo "github.com/atburke/krpc-go"
 	"github.com/atburke/krpc-go/internal"
 	"github.com/atburke/krpc-go/lib/gen"
-	"github.com/atburke/krpc-go/lib/utils"
-	"github.com/dave/jennifer/jen"
 )
 
-const genWarning = "Code generated by gen_services.go. DO NOT EDIT."
-
 func main() {
+	ksp2 := flag.Bool("ksp2", false, "Generate services for the KSP2 kRPC server")
+	flag.Parse()
+
 	ctx := context.Background()
-	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{
+	cfg := krpcgo.KRPCClientConfig{
 		RPCOnly: true,
-	})
+	}
+	outDir := "."
+	definitionsDir := gen.DefinitionsDir
+	if *ksp2 {
+		// KSP2 services live in their own packages, apart from the shared
+		// KRPC service.
+		cfg.Game = krpcgo.GameKSP2
+		gen.PackageRoot += "/ksp2"
+		outDir = "ksp2"
+		definitionsDir = filepath.Join(definitionsDir, "ksp2")
+	}
+	client := krpcgo.NewKRPCClient(cfg)
 	if err := client.Connect(ctx); err != nil {
 		log.Fatalf("Failed to connect to server. Is KSP running with a kRPC server?\n%v", err)
 	}
@@ -31,33 +43,44 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	definitions, err := gen.LoadDefinitions(definitionsDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	shims, err := gen.LoadShims(gen.ShimsFile)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	for _, service := range services.Services {
-		serviceName := strings.ToLower(service.Name)
-		serviceDocs, err := utils.ParseXMLDocumentation(service.Documentation, "From service docs: ")
-		if err != nil {
-			log.Fatal(err)
+	for _, service := range gen.MergeDefinitions(services.Services, definitions) {
+		if *ksp2 && service.Name == "KRPC" {
+			continue
 		}
-
-		f := jen.NewFile(serviceName)
-		f.PackageComment(gen.WrapDocComment(fmt.Sprintf(
-			"Package %v provides methods to invoke procedures in the %v service.\n\n%v",
-			serviceName, service.Name, serviceDocs,
-		)))
+		serviceDir := filepath.Join(outDir, strings.ToLower(service.Name))
 		fmt.Printf("Generating service %q\n", service.Name)
-		f.Comment(genWarning)
-		f.Line()
-
-		if err := gen.GenerateService(f, service); err != nil {
+		files, err := gen.GenerateServiceFiles(service, gen.WithShims(shims))
+		if err != nil {
 			log.Fatal(err)
 		}
-		dest := fmt.Sprintf("%v/%v.gen.go", serviceName, serviceName)
-		if err := os.MkdirAll(serviceName, os.ModeDir|0755); err != nil {
+		if err := os.MkdirAll(serviceDir, os.ModeDir|0755); err != nil {
 			log.Fatal(err)
 		}
-		fmt.Printf("Writing service definition to %v\n", dest)
-		if err := f.Save(dest); err != nil {
+		// Clear out old files so that removed classes don't linger.
+		oldFiles, err := filepath.Glob(filepath.Join(serviceDir, "*.gen.go"))
+		if err != nil {
 			log.Fatal(err)
 		}
+		for _, oldFile := range oldFiles {
+			if err := os.Remove(oldFile); err != nil {
+				log.Fatal(err)
+			}
+		}
+		for fileName, contents := range files {
+			dest := filepath.Join(serviceDir, fileName)
+			fmt.Printf("Writing service definition to %v\n", dest)
+			if err := os.WriteFile(dest, contents, 0644); err != nil {
+				log.Fatal(err)
+			}
+		}
 	}
 }
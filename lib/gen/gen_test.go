@@ -3,6 +3,9 @@ package gen
 import (
 	"bytes"
 	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/atburke/krpc-go/types"
@@ -109,7 +112,7 @@ type Test struct {
 // NewTest creates a new Test.
 func NewTest(id uint64, client *krpcgo.KRPCClient) *Test {
 	c := &Test{BaseClass: service.BaseClass{Client: client}}
-	c.SetID(id)
+	c.SetID_internal(id)
 	return c
 }
 `
@@ -223,3 +226,301 @@ func TestGenerateException(t *testing.T) {
 	require.NoError(t, f.Render(&out))
 	require.Equal(t, string(expectedOut), out.String())
 }
+
+const testProperty = `
+package gentest
+
+import krpcgo "github.com/atburke/krpc-go"
+
+// MyPropertyProp - returns a handle to the MyProperty property.
+func (s *MyClass) MyPropertyProp() *krpcgo.Property[float64] {
+	return krpcgo.NewProperty(s.MyProperty, s.SetMyProperty, s.MyPropertyStream)
+}
+`
+
+func TestGenerateProperty(t *testing.T) {
+	expectedOut, err := format.Source([]byte(testProperty))
+	require.NoError(t, err)
+
+	this := &types.Parameter{
+		Name: "this",
+		Type: &types.Type{
+			Code:    types.Type_CLASS,
+			Service: "MyService",
+			Name:    "MyClass",
+		},
+	}
+	getter := &types.Procedure{
+		Name:       "MyClass_get_MyProperty",
+		Parameters: []*types.Parameter{this},
+		ReturnType: &types.Type{Code: types.Type_DOUBLE},
+	}
+	setter := &types.Procedure{
+		Name: "MyClass_set_MyProperty",
+		Parameters: []*types.Parameter{
+			this,
+			{Name: "value", Type: &types.Type{Code: types.Type_DOUBLE}},
+		},
+	}
+
+	getterName, err := GetPropertyGetterName(setter.Name)
+	require.NoError(t, err)
+	require.Equal(t, getter.Name, getterName)
+
+	f := jen.NewFile("gentest")
+	require.NoError(t, GenerateProperty(f, "MyService", getter, setter))
+
+	var out bytes.Buffer
+	require.NoError(t, f.Render(&out))
+	require.Equal(t, string(expectedOut), out.String())
+}
+
+func TestGenerateServiceFiles(t *testing.T) {
+	service := &types.Service{
+		Name:          "MyService",
+		Documentation: "<summary>A test service.</summary>",
+		Classes: []*types.Class{
+			{Name: "MyClass", Documentation: "<summary>A test class.</summary>"},
+		},
+		Procedures: []*types.Procedure{
+			{
+				Name:          "MyProcedure",
+				Documentation: "<summary>A service procedure.</summary>",
+			},
+			{
+				Name:          "MyClass_Method",
+				Documentation: "<summary>A class method.</summary>",
+				Parameters: []*types.Parameter{
+					{
+						Name: "this",
+						Type: &types.Type{Code: types.Type_CLASS, Service: "MyService", Name: "MyClass"},
+					},
+				},
+			},
+		},
+	}
+
+	files := make(map[string]*jen.File)
+	fileFor := func(className string) *jen.File {
+		fileName := GetFileName(service.Name, className)
+		if _, ok := files[fileName]; !ok {
+			files[fileName] = jen.NewFile("gentest")
+		}
+		return files[fileName]
+	}
+	require.NoError(t, GenerateService(service, fileFor))
+	require.Len(t, files, 2)
+
+	render := func(fileName string) string {
+		f, ok := files[fileName]
+		require.True(t, ok, "missing file %v", fileName)
+		var out bytes.Buffer
+		require.NoError(t, f.Render(&out))
+		return out.String()
+	}
+	serviceFile := render("myservice.gen.go")
+	require.Contains(t, serviceFile, "func (s *MyService) MyProcedure() error")
+	require.NotContains(t, serviceFile, "MyClass")
+
+	classFile := render("myclass.gen.go")
+	require.Contains(t, classFile, "type MyClass struct")
+	require.Contains(t, classFile, "func (s *MyClass) Method() error")
+}
+
+func TestGenerateStaticClassMethod(t *testing.T) {
+	procedure := &types.Procedure{
+		Name:          "MyClass_static_Create",
+		Documentation: "<summary>A static method.</summary>",
+		Parameters: []*types.Parameter{
+			{Name: "name", Type: &types.Type{Code: types.Type_STRING}},
+			{Name: "size", Type: &types.Type{Code: types.Type_SINT32}},
+		},
+	}
+
+	f := jen.NewFile("gentest")
+	require.NoError(t, GenerateProcedure(f, "MyService", procedure))
+	var out bytes.Buffer
+	require.NoError(t, f.Render(&out))
+
+	// Every parameter is passed through; the receiver only provides the client.
+	require.Contains(t, out.String(), "func (s *MyClass) Create(name string, size int32) error")
+	require.Contains(t, out.String(), "encode.Marshal(name)")
+	require.NotContains(t, out.String(), "encode.Marshal(s)")
+}
+
+func TestGetFileName(t *testing.T) {
+	require.Equal(t, "spacecenter.gen.go", GetFileName("SpaceCenter", ""))
+	require.Equal(t, "vessel.gen.go", GetFileName("SpaceCenter", "Vessel"))
+	require.Equal(t, "krpc_class.gen.go", GetFileName("KRPC", "KRPC"))
+}
+
+func TestGetServicePackage(t *testing.T) {
+	require.Equal(t, "github.com/atburke/krpc-go/spacecenter", getServicePackage("SpaceCenter"))
+
+	defer func(root string) { PackageRoot = root }(PackageRoot)
+	PackageRoot = "github.com/atburke/krpc-go/ksp2"
+	require.Equal(t, "github.com/atburke/krpc-go/ksp2/spacecenter", getServicePackage("SpaceCenter"))
+	// KRPC is shared between games.
+	require.Equal(t, "github.com/atburke/krpc-go/krpc", getServicePackage("KRPC"))
+}
+
+const testShim = `
+package gentest
+
+import krpcgo "github.com/atburke/krpc-go"
+
+// OldMethod - test shim generation.
+//
+// Deprecated: renamed to NewMethod in kRPC 0.5.0.
+func (s *MyClass) OldMethod(param1 string) (float64, error) {
+	return s.NewMethod(param1, true)
+}
+
+// OldMethodStream - test shim generation.
+//
+// Deprecated: renamed to NewMethodStream in kRPC 0.5.0.
+func (s *MyClass) OldMethodStream(param1 string) (*krpcgo.Stream[float64], error) {
+	return s.NewMethodStream(param1, true)
+}
+`
+
+func TestGenerateShim(t *testing.T) {
+	expectedOut, err := format.Source([]byte(testShim))
+	require.NoError(t, err)
+
+	procedure := &types.Procedure{
+		Name:          "MyClass_NewMethod",
+		Documentation: "<summary>Test shim generation.</summary>",
+		Parameters: []*types.Parameter{
+			{
+				Name: "this",
+				Type: &types.Type{Code: types.Type_CLASS, Service: "MyService", Name: "MyClass"},
+			},
+			{Name: "param1", Type: &types.Type{Code: types.Type_STRING}},
+			{Name: "param2", Type: &types.Type{Code: types.Type_BOOL}},
+		},
+		ReturnType: &types.Type{Code: types.Type_DOUBLE},
+	}
+	shim := Shim{
+		Service:  "MyService",
+		Old:      "MyClass_OldMethod",
+		New:      "MyClass_NewMethod",
+		Defaults: map[string]interface{}{"param2": true},
+		Version:  "0.5.0",
+	}
+
+	f := jen.NewFile("gentest")
+	require.NoError(t, GenerateShim(f, "MyService", shim, procedure))
+
+	var out bytes.Buffer
+	require.NoError(t, f.Render(&out))
+	require.Equal(t, string(expectedOut), out.String())
+
+	// Shims can't move a procedure to a different class.
+	shim.Old = "OtherClass_OldMethod"
+	require.Error(t, GenerateShim(jen.NewFile("gentest"), "MyService", shim, procedure))
+}
+
+func TestGenerateStaticShim(t *testing.T) {
+	procedure := &types.Procedure{
+		Name:          "MyClass_static_Create",
+		Documentation: "<summary>A static method.</summary>",
+		Parameters: []*types.Parameter{
+			{Name: "name", Type: &types.Type{Code: types.Type_STRING}},
+			{Name: "size", Type: &types.Type{Code: types.Type_SINT32}},
+		},
+	}
+	shim := Shim{Service: "MyService", Old: "MyClass_static_Make", New: "MyClass_static_Create"}
+
+	f := jen.NewFile("gentest")
+	require.NoError(t, GenerateShim(f, "MyService", shim, procedure))
+	var out bytes.Buffer
+	require.NoError(t, f.Render(&out))
+
+	// Static methods keep their first parameter.
+	require.Contains(t, out.String(), "func (s *MyClass) Make(name string, size int32) error")
+	require.Contains(t, out.String(), "return s.Create(name, size)")
+}
+
+func TestGenerateServiceShims(t *testing.T) {
+	classType := &types.Type{Code: types.Type_CLASS, Service: "MyService", Name: "MyClass"}
+	docs := "<summary>Test shims.</summary>"
+	service := &types.Service{
+		Name:          "MyService",
+		Documentation: docs,
+		Classes: []*types.Class{
+			{Name: "MyClass", Documentation: docs},
+		},
+		Procedures: []*types.Procedure{
+			{
+				Name:          "MyClass_NewMethod",
+				Documentation: docs,
+				Parameters:    []*types.Parameter{{Name: "this", Type: classType}},
+			},
+			{
+				Name:          "MyClass_Kept",
+				Documentation: docs,
+				Parameters:    []*types.Parameter{{Name: "this", Type: classType}},
+			},
+			{
+				Name:          "MyClass_KeptRenamed",
+				Documentation: docs,
+				Parameters:    []*types.Parameter{{Name: "this", Type: classType}},
+			},
+		},
+	}
+	shims := []Shim{
+		{Service: "MyService", Old: "MyClass_OldMethod", New: "MyClass_NewMethod"},
+		// The server still has the old procedure.
+		{Service: "MyService", Old: "MyClass_Kept", New: "MyClass_KeptRenamed"},
+		// The new procedure doesn't exist.
+		{Service: "MyService", Old: "MyClass_Gone", New: "MyClass_Missing"},
+		// Another service's shim.
+		{Service: "OtherService", Old: "MyClass_Other", New: "MyClass_NewMethod"},
+	}
+
+	generate := func(opts ...GenerateServiceOption) string {
+		f := jen.NewFile("gentest")
+		require.NoError(t, GenerateService(service, func(string) *jen.File { return f }, opts...))
+		var out bytes.Buffer
+		require.NoError(t, f.Render(&out))
+		return out.String()
+	}
+
+	out := generate(WithShims(shims))
+	require.Contains(t, out, "func (s *MyClass) OldMethod() error")
+	require.Contains(t, out, "return s.NewMethod()")
+	require.Equal(t, 1, strings.Count(out, "func (s *MyClass) Kept() error"))
+	require.NotContains(t, out, "Gone")
+	require.NotContains(t, out, "Other")
+
+	// Without shims, no aliases are generated.
+	require.NotContains(t, generate(), "OldMethod")
+}
+
+func TestLoadShims(t *testing.T) {
+	// The checked-in shims should always parse.
+	_, err := LoadShims("shims.json")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "shims.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{
+		"service": "MyService",
+		"old": "MyClass_OldMethod",
+		"new": "MyClass_NewMethod",
+		"defaults": {"count": 2, "scale": 0.5, "enabled": true},
+		"version": "0.5.0"
+	}]`), 0644))
+	shims, err := LoadShims(path)
+	require.NoError(t, err)
+	require.Equal(t, []Shim{{
+		Service:  "MyService",
+		Old:      "MyClass_OldMethod",
+		New:      "MyClass_NewMethod",
+		Defaults: map[string]interface{}{"count": 2, "scale": 0.5, "enabled": true},
+		Version:  "0.5.0",
+	}}, shims)
+
+	_, err = LoadShims(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Run with -update to rewrite the golden files after an intended change to
+// the generator, then review the diff.
+var update = flag.Bool("update", false, "update the golden files")
+
+const (
+	goldenServicesDir = "testdata/services"
+	goldenDir         = "testdata/golden"
+)
+
+// TestGolden generates the services in testdata/services and compares every
+// file with its golden copy in testdata/golden.
+func TestGolden(t *testing.T) {
+	services, err := LoadDefinitions(goldenServicesDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, services)
+
+	for _, service := range services {
+		service := service
+		t.Run(service.Name, func(t *testing.T) {
+			files, err := GenerateServiceFiles(service)
+			require.NoError(t, err)
+			dir := filepath.Join(goldenDir, strings.ToLower(service.Name))
+
+			if *update {
+				require.NoError(t, os.RemoveAll(dir))
+				require.NoError(t, os.MkdirAll(dir, 0755))
+				for fileName, contents := range files {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, fileName), contents, 0644))
+				}
+			}
+
+			paths, err := filepath.Glob(filepath.Join(dir, "*.gen.go"))
+			require.NoError(t, err)
+			var golden []string
+			for _, path := range paths {
+				golden = append(golden, filepath.Base(path))
+			}
+			var generated []string
+			for fileName := range files {
+				generated = append(generated, fileName)
+			}
+			sort.Strings(generated)
+			require.Equal(t, golden, generated, "generated files differ from %v; run the tests with -update", dir)
+
+			for _, fileName := range generated {
+				expected, err := os.ReadFile(filepath.Join(dir, fileName))
+				require.NoError(t, err)
+				require.Equal(t, string(expected), string(files[fileName]),
+					"%v differs from its golden file; run the tests with -update", fileName)
+			}
+		})
+	}
+}
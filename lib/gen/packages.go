@@ -10,6 +10,29 @@ const (
 	tracerrPkg = "github.com/ztrue/tracerr"
 )
 
+// PackageRoot is the import path that service packages are generated under.
+var PackageRoot = krpcPkg
+
 func getServicePackage(serviceName string) string {
-	return "github.com/atburke/krpc-go/" + strings.ToLower(serviceName)
+	// The KRPC service is the same for every server, so all services share
+	// one package for it.
+	if serviceName == "KRPC" {
+		return krpcPkg + "/krpc"
+	}
+	return PackageRoot + "/" + strings.ToLower(serviceName)
+}
+
+// GetFileName gets the name of the generated file for a class in a service.
+// An empty class name gets the file for the service itself.
+func GetFileName(serviceName, className string) string {
+	serviceFile := strings.ToLower(serviceName)
+	if className == "" {
+		return serviceFile + ".gen.go"
+	}
+	classFile := strings.ToLower(className)
+	// Don't let a class clobber its service's file.
+	if classFile == serviceFile {
+		classFile += "_class"
+	}
+	return classFile + ".gen.go"
 }
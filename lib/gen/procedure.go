@@ -100,11 +100,13 @@ func generateProcedureBody(serviceName string, procedure *types.Procedure) (func
 
 	// Marshal arguments
 	_, err := GetClassName(procedure.Name)
-	isClass := err == nil
+	// Static class methods don't take an instance of the class, so the
+	// receiver is only used for its client.
+	isInstance := err == nil && GetProcedureType(procedure.Name) != StaticClassMethod
 	for i, param := range procedure.Parameters {
 		param.Name = utils.SanitizeIdentifier(param.Name)
-		// If this is any kind of class method, use the class itself as the first param
-		if i == 0 && isClass {
+		// If this is a class method, use the class itself as the first param
+		if i == 0 && isInstance {
 			param.Name = "s"
 		} else {
 			paramType := GetGoType(param.Type, WithPackage(pkg))
@@ -236,11 +238,13 @@ func generateStreamBody(serviceName string, procedure *types.Procedure) (funcBod
 
 	// Marshal arguments
 	_, err := GetClassName(procedure.Name)
-	isClass := err == nil
+	// Static class methods don't take an instance of the class, so the
+	// receiver is only used for its client.
+	isInstance := err == nil && GetProcedureType(procedure.Name) != StaticClassMethod
 	for i, param := range procedure.Parameters {
 		param.Name = utils.SanitizeIdentifier(param.Name)
-		// If this is any kind of class method, use the class itself as the first param
-		if i == 0 && isClass {
+		// If this is a class method, use the class itself as the first param
+		if i == 0 && isInstance {
 			param.Name = "s"
 		}
 
@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/dave/jennifer/jen"
+	"github.com/ztrue/tracerr"
+)
+
+// GetPropertyGetterName gets the name of the getter procedure that pairs with
+// a setter procedure. Returns an error if the procedure is not a setter.
+func GetPropertyGetterName(setterName string) (string, error) {
+	propName, err := GetPropertyName(setterName)
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+	switch GetProcedureType(setterName) {
+	case ServiceSetter:
+		return "get_" + propName, nil
+	case ClassSetter:
+		className, err := GetClassName(setterName)
+		if err != nil {
+			return "", tracerr.Wrap(err)
+		}
+		return fmt.Sprintf("%v_get_%v", className, propName), nil
+	default:
+		return "", tracerr.Errorf("Procedure %q is not a setter", setterName)
+	}
+}
+
+// GenerateProperty generates a property accessor for a getter/setter pair.
+func GenerateProperty(f *jen.File, serviceName string, getter, setter *types.Procedure) error {
+	propName, err := GetPropertyName(getter.Name)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	receiver := serviceName
+	if GetProcedureType(getter.Name) == ClassGetter {
+		if receiver, err = GetClassName(getter.Name); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+
+	propType := GetGoType(getter.ReturnType, WithPackage(getServicePackage(serviceName)))
+	if propType == nil {
+		return tracerr.Errorf("Getter %q does not return a value", getter.Name)
+	}
+
+	// Class values aren't streamed, so there's no stream function to use.
+	var streamFunc jen.Code = jen.Nil()
+	if !isPointerType(getter.ReturnType.Code) {
+		streamFunc = jen.Id("s").Dot(propName + "Stream")
+	}
+
+	accessorName := propName + "Prop"
+	f.Comment(fmt.Sprintf("%v - returns a handle to the %v property.", accessorName, propName))
+	f.Func().Params(
+		jen.Id("s").Op("*").Id(receiver),
+	).Id(accessorName).Params().Op("*").Qual(krpcPkg, "Property").Types(propType).Block(
+		jen.Return(jen.Qual(krpcPkg, "NewProperty").Call(
+			jen.Id("s").Dot(propName),
+			jen.Id("s").Dot("Set"+propName),
+			streamFunc,
+		)),
+	)
+	return nil
+}
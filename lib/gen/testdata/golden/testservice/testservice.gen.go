@@ -0,0 +1,244 @@
+// Package testservice provides methods to invoke procedures in the TestService
+// service.
+//
+// From service docs: a service covering what the generator supports, for golden
+// file tests.
+package testservice
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// ErrWidgetBroken - the widget is broken.
+type ErrWidgetBroken struct {
+	msg string
+}
+
+// NewErrWidgetBroken creates a new ErrWidgetBroken.
+func NewErrWidgetBroken(msg string) *ErrWidgetBroken {
+	return &ErrWidgetBroken{msg: msg}
+}
+
+// Error returns a human-readable error.
+func (err ErrWidgetBroken) Error() string {
+	return err.msg
+}
+
+// Mode - a mode.
+type Mode int32
+
+const (
+	// Nothing happens.
+	Mode_Off Mode = 0
+	// Something happens.
+	Mode_On Mode = 1
+)
+
+func (v Mode) Value() int32 {
+	return int32(v)
+}
+func (v *Mode) SetValue(val int32) {
+	*v = Mode(val)
+}
+
+// TestService - a service covering what the generator supports, for golden file
+// tests.
+type TestService struct {
+	Client *krpcgo.KRPCClient
+}
+
+// New creates a new TestService.
+func New(client *krpcgo.KRPCClient) *TestService {
+	return &TestService{Client: client}
+}
+
+// Available checks if the TestService service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "TestService")
+}
+
+// Reset - resets every widget.
+//
+// Allowed game scenes: any.
+func (s *TestService) Reset() error {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "Reset",
+		Service:   "TestService",
+	}
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// Mode - the current mode.
+//
+// Allowed game scenes: any.
+func (s *TestService) Mode() (Mode, error) {
+	var err error
+	var vv Mode
+	request := &types.ProcedureCall{
+		Procedure: "get_Mode",
+		Service:   "TestService",
+	}
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ModeStream - the current mode.
+//
+// Allowed game scenes: any.
+func (s *TestService) ModeStream() (*krpcgo.Stream[Mode], error) {
+	var err error
+	request := &types.ProcedureCall{
+		Procedure: "get_Mode",
+		Service:   "TestService",
+	}
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) Mode {
+		var value Mode
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetMode - the current mode.
+//
+// Allowed game scenes: any.
+func (s *TestService) SetMode(value Mode) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "set_Mode",
+		Service:   "TestService",
+	}
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ModeProp - returns a handle to the Mode property.
+func (s *TestService) ModeProp() *krpcgo.Property[Mode] {
+	return krpcgo.NewProperty(s.Mode, s.SetMode, s.ModeStream)
+}
+
+// FindWidgets - finds the widgets with all of the given tags, within the given
+// limits.
+//
+// Allowed game scenes: FLIGHT, EDITOR_VAB.
+func (s *TestService) FindWidgets(tags map[string]struct{}, limits map[string]float32) ([]*Widget, error) {
+	var err error
+	var argBytes []byte
+	var vv []*Widget
+	request := &types.ProcedureCall{
+		Procedure: "FindWidgets",
+		Service:   "TestService",
+	}
+	argBytes, err = encode.Marshal(tags)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(limits)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	for _, v := range vv {
+		v.Client = s.Client
+	}
+	return vv, nil
+}
+
+// FindWidgetsStream - finds the widgets with all of the given tags, within the
+// given limits.
+//
+// Allowed game scenes: FLIGHT, EDITOR_VAB.
+func (s *TestService) FindWidgetsStream(tags map[string]struct{}, limits map[string]float32) (*krpcgo.Stream[[]*Widget], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "FindWidgets",
+		Service:   "TestService",
+	}
+	argBytes, err = encode.Marshal(tags)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(limits)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) []*Widget {
+		var value []*Widget
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
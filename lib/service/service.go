@@ -1,7 +1,13 @@
 // Package service provides some definitions needed to generate services.
 package service
 
-import krpcgo "github.com/atburke/krpc-go"
+import (
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/internal"
+	"github.com/ztrue/tracerr"
+)
 
 type Enum interface {
 	Value() int32
@@ -35,3 +41,68 @@ func (c *BaseClass) ID_internal() uint64 {
 func (c *BaseClass) SetID_internal(id uint64) {
 	c.id = id
 }
+
+// serviceCache holds the names of the services on each client's server,
+// since they don't change while the client is connected to it.
+var serviceCache = struct {
+	sync.Mutex
+	names map[*krpcgo.KRPCClient]map[string]struct{}
+	// hooked are the clients with hooks to clear their entries.
+	hooked map[*krpcgo.KRPCClient]struct{}
+}{
+	names:  map[*krpcgo.KRPCClient]map[string]struct{}{},
+	hooked: map[*krpcgo.KRPCClient]struct{}{},
+}
+
+// serviceNames gets the names of the services on a client's server, asking
+// the server only the first time.
+func serviceNames(client *krpcgo.KRPCClient) (map[string]struct{}, error) {
+	serviceCache.Lock()
+	names, ok := serviceCache.names[client]
+	serviceCache.Unlock()
+	if ok {
+		return names, nil
+	}
+
+	services, err := internal.NewBasicKRPC(client).GetServices()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names = make(map[string]struct{}, len(services.Services))
+	for _, s := range services.Services {
+		names[s.Name] = struct{}{}
+	}
+
+	serviceCache.Lock()
+	serviceCache.names[client] = names
+	_, hooked := serviceCache.hooked[client]
+	serviceCache.hooked[client] = struct{}{}
+	serviceCache.Unlock()
+	if !hooked {
+		// A different server can have different services.
+		client.OnSwitch(func() {
+			serviceCache.Lock()
+			defer serviceCache.Unlock()
+			delete(serviceCache.names, client)
+		})
+		client.OnClose(func() {
+			serviceCache.Lock()
+			defer serviceCache.Unlock()
+			delete(serviceCache.names, client)
+			delete(serviceCache.hooked, client)
+		})
+	}
+	return names, nil
+}
+
+// Available checks if a service is available on the kRPC server. Services
+// provided by mods are only available when the mod is installed. The list of
+// services is fetched once per client and server.
+func Available(client *krpcgo.KRPCClient, serviceName string) (bool, error) {
+	names, err := serviceNames(client)
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	_, ok := names[serviceName]
+	return ok, nil
+}
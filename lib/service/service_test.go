@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/lib/service"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailable(t *testing.T) {
+	services := &types.Services{Services: []*types.Service{{Name: "KRPC"}, {Name: "SpaceCenter"}}}
+	tests := []struct {
+		name        string
+		service     string
+		err         error
+		expected    bool
+		expectedErr bool
+	}{
+		{name: "present", service: "SpaceCenter", expected: true},
+		{name: "absent", service: "FAR", expected: false},
+		{name: "server error", service: "SpaceCenter", err: errors.New("no services"), expectedErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := krpctest.NewServer()
+			defer server.Close()
+			server.Handle("KRPC", "GetServices", func(*krpctest.Call) (interface{}, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return services, nil
+			})
+			client, err := server.Client(context.Background())
+			require.NoError(t, err)
+			defer client.Close()
+
+			available, err := service.Available(client, tc.service)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, available)
+		})
+	}
+}
+
+func TestAvailableCached(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	var calls atomic.Int32
+	var fail atomic.Bool
+	fail.Store(true)
+	server.Handle("KRPC", "GetServices", func(*krpctest.Call) (interface{}, error) {
+		calls.Add(1)
+		if fail.Load() {
+			return nil, errors.New("no services")
+		}
+		return &types.Services{Services: []*types.Service{{Name: "KRPC"}, {Name: "FAR"}}}, nil
+	})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Failures aren't cached.
+	_, err = service.Available(client, "FAR")
+	require.Error(t, err)
+	fail.Store(false)
+
+	for _, name := range []string{"FAR", "KRPC", "kOS"} {
+		_, err := service.Available(client, name)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), calls.Load())
+
+	// Each client asks its own server.
+	other, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer other.Close()
+	available, err := service.Available(other, "FAR")
+	require.NoError(t, err)
+	require.True(t, available)
+	require.Equal(t, int32(3), calls.Load())
+}
@@ -15,18 +15,6 @@ import (
 
 // Code generated by gen_services.go. DO NOT EDIT.
 
-// Laser - a LaserDist laser.
-type Laser struct {
-	service.BaseClass
-}
-
-// NewLaser creates a new Laser.
-func NewLaser(id uint64, client *krpcgo.KRPCClient) *Laser {
-	c := &Laser{BaseClass: service.BaseClass{Client: client}}
-	c.SetID_internal(id)
-	return c
-}
-
 // LiDAR - laserDist service.
 type LiDAR struct {
 	Client *krpcgo.KRPCClient
@@ -37,6 +25,11 @@ func New(client *krpcgo.KRPCClient) *LiDAR {
 	return &LiDAR{Client: client}
 }
 
+// Available checks if the LiDAR service is available on the server.
+func Available(client *krpcgo.KRPCClient) (bool, error) {
+	return service.Available(client, "LiDAR")
+}
+
 // Laser - get a LaserDist part.
 //
 // Allowed game scenes: any.
@@ -117,103 +110,3 @@ func (s *LiDAR) AvailableStream() (*krpcgo.Stream[bool], error) {
 	})
 	return stream, nil
 }
-
-// Part - get the part containing this LiDAR.
-//
-// Allowed game scenes: any.
-func (s *Laser) Part() (*spacecenter.Part, error) {
-	var err error
-	var argBytes []byte
-	var vv spacecenter.Part
-	request := &types.ProcedureCall{
-		Procedure: "Laser_get_Part",
-		Service:   "LiDAR",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return &vv, tracerr.Wrap(err)
-	}
-	if vv.ID_internal() == 0 {
-		return nil, nil
-	}
-	vv.Client = s.Client
-	return &vv, nil
-}
-
-// Cloud - get the point cloud from the LiDAR. Returns an empty list on failure.
-//
-// Allowed game scenes: any.
-func (s *Laser) Cloud() ([]float64, error) {
-	var err error
-	var argBytes []byte
-	var vv []float64
-	request := &types.ProcedureCall{
-		Procedure: "Laser_get_Cloud",
-		Service:   "LiDAR",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return vv, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	result, err := s.Client.Call(request)
-	if err != nil {
-		return vv, tracerr.Wrap(err)
-	}
-	err = encode.Unmarshal(result.Value, &vv)
-	if err != nil {
-		return vv, tracerr.Wrap(err)
-	}
-	return vv, nil
-}
-
-// CloudStream - get the point cloud from the LiDAR. Returns an empty list on
-// failure.
-//
-// Allowed game scenes: any.
-func (s *Laser) CloudStream() (*krpcgo.Stream[[]float64], error) {
-	var err error
-	var argBytes []byte
-	request := &types.ProcedureCall{
-		Procedure: "Laser_get_Cloud",
-		Service:   "LiDAR",
-	}
-	argBytes, err = encode.Marshal(s)
-	if err != nil {
-		return nil, tracerr.Wrap(err)
-	}
-	request.Arguments = append(request.Arguments, &types.Argument{
-		Position: uint32(0x0),
-		Value:    argBytes,
-	})
-	krpc := krpc.New(s.Client)
-	st, err := krpc.AddStream(request, true)
-	if err != nil {
-		return nil, tracerr.Wrap(err)
-	}
-	rawStream := s.Client.GetStream(st.Id)
-	stream := krpcgo.MapStream(rawStream, func(b []byte) []float64 {
-		var value []float64
-		encode.Unmarshal(b, &value)
-		return value
-	})
-	stream.AddCloser(func() error {
-		return tracerr.Wrap(krpc.RemoveStream(st.Id))
-	})
-	return stream, nil
-}
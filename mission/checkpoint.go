@@ -0,0 +1,66 @@
+package mission
+
+import (
+	"context"
+	"strings"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// Checkpointer saves and restores the game at the start of each phase.
+type Checkpointer interface {
+	// Save saves a checkpoint for a phase.
+	Save(phase string) error
+	// Restore reverts the game to a phase's checkpoint and returns the vessel
+	// to carry on the mission with.
+	Restore(ctx context.Context, phase string) (*spacecenter.Vessel, error)
+}
+
+// GameCheckpoints keeps checkpoints as named saves in the current save game's
+// folder.
+type GameCheckpoints struct {
+	SpaceCenter *spacecenter.SpaceCenter
+	// Prefix is added to the start of each save's name, to tell missions
+	// apart. It defaults to "mission".
+	Prefix string
+	// Reload configures waiting for the game to settle after loading a
+	// checkpoint.
+	Reload spacecenter.ReloadConfig
+}
+
+// SaveName returns the name of a phase's save.
+func (c GameCheckpoints) SaveName(phase string) string {
+	prefix := c.Prefix
+	if prefix == "" {
+		prefix = "mission"
+	}
+	// Keep the name safe to use as a file name.
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, phase)
+	return prefix + "-" + safe
+}
+
+// Save saves the game for a phase.
+func (c GameCheckpoints) Save(phase string) error {
+	return tracerr.Wrap(c.SpaceCenter.Save(c.SaveName(phase)))
+}
+
+// Restore loads a phase's save, waits for the game to settle, and returns
+// the active vessel. Loading a save replaces every vessel object, so the old
+// ones can't be used afterwards.
+func (c GameCheckpoints) Restore(ctx context.Context, phase string) (*spacecenter.Vessel, error) {
+	r, err := c.SpaceCenter.LoadNamed(ctx, c.SaveName(phase), c.Reload)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if r.ActiveVessel == nil {
+		return nil, tracerr.Errorf("Checkpoint %q didn't load into flight", c.SaveName(phase))
+	}
+	return r.ActiveVessel, nil
+}
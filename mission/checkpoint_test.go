@@ -0,0 +1,109 @@
+package mission
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCheckpoints records checkpoint calls.
+type fakeCheckpoints struct {
+	mu       sync.Mutex
+	saved    []string
+	restored []string
+}
+
+func (c *fakeCheckpoints) Save(phase string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.saved = append(c.saved, phase)
+	return nil
+}
+
+func (c *fakeCheckpoints) Restore(ctx context.Context, phase string) (*spacecenter.Vessel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.restored = append(c.restored, phase)
+	return nil, nil
+}
+
+// failing returns an action that fails a number of times before succeeding.
+func failing(times int) Action {
+	var mu sync.Mutex
+	return func(context.Context, *spacecenter.Vessel) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if times > 0 {
+			times--
+			return fmt.Errorf("failed")
+		}
+		return nil
+	}
+}
+
+func TestCheckpointRetry(t *testing.T) {
+	tcs := []struct {
+		name     string
+		failures int
+		retries  int
+		ok       bool
+	}{
+		{name: "no failures", retries: 2, ok: true},
+		{name: "retried", failures: 2, retries: 2, ok: true},
+		{name: "out of retries", failures: 3, retries: 2},
+		{name: "retries disabled", failures: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			checkpoints := &fakeCheckpoints{}
+			cfg := testConfig()
+			cfg.Checkpoints = checkpoints
+			cfg.Retries = tc.retries
+			err := runMission(t, cfg,
+				Phase{Name: "launch"},
+				Phase{Name: "dock", Action: failing(tc.failures)},
+			)
+			restores := tc.failures
+			if tc.ok {
+				require.NoError(t, err)
+				require.Equal(t, []string{"launch", "dock"}, checkpoints.saved)
+			} else {
+				require.Error(t, err)
+				restores = tc.retries
+			}
+			require.Len(t, checkpoints.restored, restores)
+			for _, phase := range checkpoints.restored {
+				require.Equal(t, "dock", phase)
+			}
+		})
+	}
+}
+
+func TestCheckpointUnhandledAbort(t *testing.T) {
+	checkpoints := &fakeCheckpoints{}
+	cfg := testConfig()
+	cfg.Checkpoints = checkpoints
+	cfg.Retries = 1
+	err := runMission(t, cfg, Phase{Name: "launch", Abort: always(true)})
+	require.ErrorContains(t, err, "aborted")
+	require.Equal(t, []string{"launch"}, checkpoints.restored)
+}
+
+func TestSaveName(t *testing.T) {
+	tcs := []struct {
+		prefix, phase, expected string
+	}{
+		{phase: "launch", expected: "mission-launch"},
+		{prefix: "duna", phase: "capture burn", expected: "duna-capture_burn"},
+		{prefix: "mun", phase: "land/return", expected: "mun-land_return"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.expected, func(t *testing.T) {
+			require.Equal(t, tc.expected, GameCheckpoints{Prefix: tc.prefix}.SaveName(tc.phase))
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Package mission runs a vessel through a sequence of named phases, such as
+// launch, circularize and deorbit, each with its own entry conditions,
+// action, success and abort criteria, and transitions.
+package mission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// Check is a condition on a vessel, such as reaching an altitude.
+type Check func(vessel *spacecenter.Vessel) (bool, error)
+
+// Action is what a phase does. It should return once its work is done, or
+// when the context is canceled. Actions may be run again after a pause or
+// when a mission is resumed, so they should cope with being restarted.
+type Action func(ctx context.Context, vessel *spacecenter.Vessel) error
+
+// Phase is one step of a mission.
+type Phase struct {
+	// Name identifies the phase. It must be unique within a mission.
+	Name string
+	// Entry, if set, is waited for before the phase's action starts.
+	Entry Check
+	// Action, if set, is run once the phase has been entered.
+	Action Action
+	// Success, if set, ends the phase once it's true, canceling the action
+	// if it's still running. Otherwise the phase succeeds when its action
+	// returns.
+	Success Check
+	// Abort, if set, aborts the phase once it's true.
+	Abort Check
+	// Next is the phase to go to on success. If empty, it's the following
+	// phase, or the end of the mission after the last one.
+	Next string
+	// OnAbort is the phase to go to if the phase is aborted. If empty, an
+	// abort ends the mission with an error.
+	OnAbort string
+}
+
+// Config configures a Runner.
+type Config struct {
+	// Interval is how often checks are evaluated.
+	Interval time.Duration
+	// Logf, if set, is used to log the mission's progress. It has the same
+	// signature as log.Printf and testing.T's Logf.
+	Logf func(format string, args ...any)
+	// Store, if set, saves the mission's progress after every phase so that
+	// it can be resumed.
+	Store Store
+	// Checkpoints, if set, saves the game as each phase is entered, so that
+	// a failed phase can be retried from where it started.
+	Checkpoints Checkpointer
+	// Retries is how many times a failed phase is retried from its
+	// checkpoint before the mission fails. It needs Checkpoints.
+	Retries int
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 250 * time.Millisecond
+	}
+	if cfg.Logf == nil {
+		cfg.Logf = func(string, ...any) {}
+	}
+}
+
+// outcome is how a phase ended.
+type outcome int
+
+const (
+	succeeded outcome = iota
+	aborted
+	paused
+)
+
+// Runner runs a mission's phases against a vessel.
+type Runner struct {
+	vessel *spacecenter.Vessel
+	cfg    Config
+	phases []Phase
+	index  map[string]int
+
+	mu      sync.Mutex
+	paused  bool
+	changed chan struct{}
+	current string
+	// OnPhase, if set, is called with each phase as it's entered.
+	OnPhase func(Phase)
+}
+
+// New creates a new Runner. It checks that phase names are unique and that
+// every transition leads to a phase.
+func New(vessel *spacecenter.Vessel, cfg Config, phases ...Phase) (*Runner, error) {
+	cfg.SetDefaults()
+	if len(phases) == 0 {
+		return nil, tracerr.Errorf("Mission has no phases")
+	}
+	index := make(map[string]int, len(phases))
+	for i, p := range phases {
+		if p.Name == "" {
+			return nil, tracerr.Errorf("Phase %d has no name", i)
+		}
+		if _, ok := index[p.Name]; ok {
+			return nil, tracerr.Errorf("Duplicate phase %q", p.Name)
+		}
+		index[p.Name] = i
+	}
+	for _, p := range phases {
+		for _, next := range []string{p.Next, p.OnAbort} {
+			if _, ok := index[next]; next != "" && !ok {
+				return nil, tracerr.Errorf("Phase %q leads to unknown phase %q", p.Name, next)
+			}
+		}
+	}
+	return &Runner{
+		vessel:  vessel,
+		cfg:     cfg,
+		phases:  phases,
+		index:   index,
+		changed: make(chan struct{}),
+	}, nil
+}
+
+// Phase returns the name of the current phase, or an empty string if the
+// mission isn't running.
+func (r *Runner) Phase() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Pause pauses the mission. The current phase's action is canceled, and the
+// phase starts again from its entry conditions on Resume.
+func (r *Runner) Pause() {
+	r.setPaused(true)
+}
+
+// Resume resumes a paused mission.
+func (r *Runner) Resume() {
+	r.setPaused(false)
+}
+
+// Paused checks whether the mission is paused.
+func (r *Runner) Paused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused
+}
+
+func (r *Runner) setPaused(p bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paused == p {
+		return
+	}
+	r.paused = p
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// pauseState returns whether the mission is paused, and a channel that is
+// closed when that changes.
+func (r *Runner) pauseState() (bool, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused, r.changed
+}
+
+func (r *Runner) setCurrent(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = name
+}
+
+// next returns the phase that follows a phase on success.
+func (r *Runner) next(p Phase) string {
+	if p.Next != "" {
+		return p.Next
+	}
+	if i := r.index[p.Name] + 1; i < len(r.phases) {
+		return r.phases[i].Name
+	}
+	return ""
+}
+
+// Run runs the mission until it's complete, starting from where the store
+// says it got to if there is a store. A finished mission's state is kept, so
+// running it again does nothing.
+func (r *Runner) Run(ctx context.Context) error {
+	defer r.setCurrent("")
+	state := State{Phase: r.phases[0].Name}
+	if r.cfg.Store != nil {
+		saved, err := r.cfg.Store.Load()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if saved != nil {
+			if _, ok := r.index[saved.Phase]; saved.Phase != "" && !ok {
+				return tracerr.Errorf("Saved mission is in unknown phase %q", saved.Phase)
+			}
+			state = *saved
+			r.cfg.Logf("Resuming mission at phase %q", state.Phase)
+		}
+	}
+
+	// checkpointed is whether the current phase has been saved, and retries
+	// is how many times it has been retried.
+	checkpointed, retries := false, 0
+	for state.Phase != "" {
+		if err := r.waitWhilePaused(ctx); err != nil {
+			return tracerr.Wrap(err)
+		}
+		phase := r.phases[r.index[state.Phase]]
+		r.setCurrent(phase.Name)
+		if r.cfg.Checkpoints != nil && !checkpointed {
+			if err := r.cfg.Checkpoints.Save(phase.Name); err != nil {
+				return tracerr.Wrap(err)
+			}
+			checkpointed = true
+		}
+		r.cfg.Logf("Entering phase %q", phase.Name)
+		if r.OnPhase != nil {
+			r.OnPhase(phase)
+		}
+		result, err := r.runPhase(ctx, phase)
+		if err == nil && result == aborted && phase.OnAbort == "" {
+			err = tracerr.Errorf("Mission aborted in phase %q", phase.Name)
+		}
+		if err != nil {
+			if ctx.Err() != nil || r.cfg.Checkpoints == nil || retries >= r.cfg.Retries {
+				return tracerr.Wrap(err)
+			}
+			retries++
+			r.cfg.Logf("Phase %q failed, retrying from checkpoint (%d of %d): %v", phase.Name, retries, r.cfg.Retries, err)
+			if r.vessel, err = r.cfg.Checkpoints.Restore(ctx, phase.Name); err != nil {
+				return tracerr.Wrap(err)
+			}
+			continue
+		}
+
+		switch result {
+		case paused:
+			r.cfg.Logf("Paused in phase %q", phase.Name)
+			continue
+		case aborted:
+			r.cfg.Logf("Aborted phase %q", phase.Name)
+			state.Phase = phase.OnAbort
+		case succeeded:
+			r.cfg.Logf("Completed phase %q", phase.Name)
+			state.Completed = append(state.Completed, phase.Name)
+			state.Phase = r.next(phase)
+		}
+		checkpointed, retries = false, 0
+		if r.cfg.Store != nil {
+			if err := r.cfg.Store.Save(state); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+	r.cfg.Logf("Mission complete")
+	return nil
+}
+
+// waitWhilePaused blocks until the mission isn't paused.
+func (r *Runner) waitWhilePaused(ctx context.Context) error {
+	for {
+		isPaused, changed := r.pauseState()
+		if !isPaused {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// check evaluates an optional check, which is false if unset.
+func (r *Runner) check(c Check) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	ok, err := c(r.vessel)
+	return ok, tracerr.Wrap(err)
+}
+
+// runPhase runs a phase until it succeeds, aborts or is paused. Errors from
+// the phase's checks or action are returned.
+func (r *Runner) runPhase(ctx context.Context, phase Phase) (outcome, error) {
+	isPaused, changed := r.pauseState()
+	if isPaused {
+		return paused, nil
+	}
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	// Wait to enter the phase. The phase can be aborted before it starts.
+	for {
+		if abort, err := r.check(phase.Abort); err != nil || abort {
+			return aborted, err
+		}
+		if phase.Entry == nil {
+			break
+		}
+		ok, err := r.check(phase.Entry)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return 0, tracerr.Wrap(ctx.Err())
+		case <-changed:
+			return paused, nil
+		case <-ticker.C:
+		}
+	}
+
+	actionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	if phase.Action != nil {
+		go func() {
+			done <- phase.Action(actionCtx, r.vessel)
+		}()
+	} else {
+		done <- nil
+	}
+	// finish cancels the action and waits for it to return.
+	finish := func(result outcome, err error) (outcome, error) {
+		cancel()
+		if done != nil {
+			<-done
+		}
+		return result, err
+	}
+
+	for {
+		if abort, err := r.check(phase.Abort); err != nil || abort {
+			return finish(aborted, err)
+		}
+		ok, err := r.check(phase.Success)
+		if err != nil {
+			return finish(0, err)
+		}
+		if ok {
+			return finish(succeeded, nil)
+		}
+		select {
+		case <-ctx.Done():
+			return finish(0, tracerr.Wrap(ctx.Err()))
+		case <-changed:
+			return finish(paused, nil)
+		case err := <-done:
+			done = nil
+			if err != nil {
+				return 0, tracerr.Wrap(err)
+			}
+			if phase.Success == nil {
+				return succeeded, nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
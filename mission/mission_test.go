@@ -0,0 +1,201 @@
+package mission
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps a mission's state in memory.
+type memoryStore struct {
+	mu    sync.Mutex
+	state *State
+	saves int
+}
+
+func (s *memoryStore) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *memoryStore) Save(state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = &state
+	s.saves++
+	return nil
+}
+
+// recorder records the phases that run.
+type recorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (r *recorder) action(name string) Action {
+	return func(ctx context.Context, vessel *spacecenter.Vessel) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.ran = append(r.ran, name)
+		return nil
+	}
+}
+
+func (r *recorder) phases() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ran...)
+}
+
+func always(ok bool) Check {
+	return func(*spacecenter.Vessel) (bool, error) {
+		return ok, nil
+	}
+}
+
+func testConfig() Config {
+	return Config{Interval: time.Millisecond}
+}
+
+func runMission(t *testing.T, cfg Config, phases ...Phase) error {
+	t.Helper()
+	r, err := New(nil, cfg, phases...)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return r.Run(ctx)
+}
+
+func TestNew(t *testing.T) {
+	tcs := []struct {
+		name   string
+		phases []Phase
+		ok     bool
+	}{
+		{name: "no phases"},
+		{name: "unnamed phase", phases: []Phase{{}}},
+		{name: "duplicate names", phases: []Phase{{Name: "a"}, {Name: "a"}}},
+		{name: "unknown next", phases: []Phase{{Name: "a", Next: "b"}}},
+		{name: "unknown abort", phases: []Phase{{Name: "a", OnAbort: "b"}}},
+		{name: "valid", phases: []Phase{{Name: "a", Next: "c"}, {Name: "b"}, {Name: "c", OnAbort: "b"}}, ok: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(nil, Config{}, tc.phases...)
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRunTransitions(t *testing.T) {
+	rec := &recorder{}
+	err := runMission(t, testConfig(),
+		Phase{Name: "launch", Action: rec.action("launch")},
+		Phase{Name: "ascent", Action: rec.action("ascent"), Next: "circularize"},
+		Phase{Name: "skipped", Action: rec.action("skipped")},
+		Phase{Name: "circularize", Action: rec.action("circularize"), Abort: always(true), OnAbort: "recover"},
+		Phase{Name: "done", Action: rec.action("done")},
+		Phase{Name: "recover", Action: rec.action("recover")},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"launch", "ascent", "recover"}, rec.phases())
+}
+
+func TestRunAbort(t *testing.T) {
+	err := runMission(t, testConfig(), Phase{Name: "launch", Abort: always(true)})
+	require.ErrorContains(t, err, `aborted in phase "launch"`)
+}
+
+func TestRunActionError(t *testing.T) {
+	err := runMission(t, testConfig(), Phase{
+		Name: "launch",
+		Action: func(context.Context, *spacecenter.Vessel) error {
+			return fmt.Errorf("engine failure")
+		},
+	})
+	require.ErrorContains(t, err, "engine failure")
+}
+
+func TestRunEntryAndSuccess(t *testing.T) {
+	var ticks atomic.Int32
+	var canceled atomic.Bool
+	err := runMission(t, testConfig(), Phase{
+		Name: "coast",
+		Entry: func(*spacecenter.Vessel) (bool, error) {
+			return ticks.Add(1) > 3, nil
+		},
+		Action: func(ctx context.Context, _ *spacecenter.Vessel) error {
+			<-ctx.Done()
+			canceled.Store(true)
+			return ctx.Err()
+		},
+		Success: func(*spacecenter.Vessel) (bool, error) {
+			return ticks.Add(1) > 10, nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, canceled.Load(), "action should be canceled once the phase succeeds")
+}
+
+func TestRunResume(t *testing.T) {
+	rec := &recorder{}
+	store := &memoryStore{state: &State{Phase: "circularize", Completed: []string{"launch"}}}
+	cfg := testConfig()
+	cfg.Store = store
+	err := runMission(t, cfg,
+		Phase{Name: "launch", Action: rec.action("launch")},
+		Phase{Name: "circularize", Action: rec.action("circularize")},
+		Phase{Name: "deorbit", Action: rec.action("deorbit")},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"circularize", "deorbit"}, rec.phases())
+	require.Equal(t, &State{Completed: []string{"launch", "circularize", "deorbit"}}, store.state)
+	require.Equal(t, 2, store.saves)
+
+	// A finished mission has nothing left to do.
+	require.NoError(t, runMission(t, cfg, Phase{Name: "launch", Action: rec.action("launch")}))
+	require.Len(t, rec.phases(), 2)
+}
+
+func TestPauseResume(t *testing.T) {
+	started := make(chan struct{}, 10)
+	r, err := New(nil, testConfig(), Phase{
+		Name: "burn",
+		Action: func(ctx context.Context, _ *spacecenter.Vessel) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return nil
+		},
+	}, Phase{Name: "done"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		result <- r.Run(ctx)
+	}()
+
+	<-started
+	require.Equal(t, "burn", r.Phase())
+	r.Pause()
+	require.True(t, r.Paused())
+	r.Resume()
+	// The phase starts again from the beginning.
+	<-started
+	require.Equal(t, "burn", r.Phase())
+	cancel()
+	require.Error(t, <-result)
+	require.Empty(t, r.Phase())
+}
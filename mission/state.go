@@ -0,0 +1,63 @@
+package mission
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/ztrue/tracerr"
+)
+
+// State is a mission's progress.
+type State struct {
+	// Phase is the phase to run next, or empty once the mission is complete.
+	Phase string `json:"phase"`
+	// Completed lists the phases that have succeeded, in order.
+	Completed []string `json:"completed,omitempty"`
+}
+
+// Store saves a mission's progress so that it can be resumed later, such as
+// after the program is restarted.
+type Store interface {
+	// Load returns the saved state, or nil if nothing has been saved.
+	Load() (*State, error)
+	// Save saves the state.
+	Save(State) error
+}
+
+// FileStore stores a mission's progress as JSON in a file.
+type FileStore struct {
+	Path string
+}
+
+// Load returns the state in the file, or nil if the file doesn't exist.
+func (s FileStore) Load() (*State, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &state, nil
+}
+
+// Save writes the state to the file, replacing it.
+func (s FileStore) Save(state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	// Write to a temporary file first so that a crash can't leave a
+	// half-written state behind.
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(os.Rename(tmp, s.Path))
+}
@@ -0,0 +1,21 @@
+package mission
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	store := FileStore{Path: filepath.Join(t.TempDir(), "mission.json")}
+	state, err := store.Load()
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	saved := State{Phase: "circularize", Completed: []string{"launch", "ascent"}}
+	require.NoError(t, store.Save(saved))
+	state, err = store.Load()
+	require.NoError(t, err)
+	require.Equal(t, &saved, state)
+}
@@ -0,0 +1,205 @@
+package krpcgo
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// ErrPaused is returned by calls that timed out waiting for the game to be
+// unpaused.
+var ErrPaused = errors.New("the game is paused")
+
+// PauseConfig configures how calls are queued while the game is paused.
+type PauseConfig struct {
+	// Allowed are the procedures that are valid while the game is paused,
+	// and are called straight away rather than queued, as
+	// "Service.Procedure", or "Service.*" for all of a service's
+	// procedures. The KRPC service's procedures are always allowed.
+	Allowed []string
+	// Timeout, if set, is how long a call waits for the game to be
+	// unpaused before failing with ErrPaused. By default calls wait for as
+	// long as the game is paused.
+	Timeout time.Duration
+}
+
+// pauser tracks whether the game is paused, for queueing calls.
+type pauser struct {
+	allowed map[string]struct{}
+	timeout time.Duration
+
+	mu     sync.Mutex
+	paused bool
+	// resumed is closed when the game is unpaused.
+	resumed chan struct{}
+}
+
+// newPauser creates a pauser for a config.
+func newPauser(cfg PauseConfig) *pauser {
+	p := &pauser{
+		allowed: map[string]struct{}{"KRPC.*": {}},
+		timeout: cfg.Timeout,
+	}
+	for _, name := range cfg.Allowed {
+		p.allowed[name] = struct{}{}
+	}
+	return p
+}
+
+// set records whether the game is paused, releasing queued calls when it
+// is unpaused.
+func (p *pauser) set(paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if paused == p.paused {
+		return
+	}
+	p.paused = paused
+	if paused {
+		p.resumed = make(chan struct{})
+	} else {
+		close(p.resumed)
+	}
+}
+
+// allows checks if a call can be made while the game is paused.
+func (p *pauser) allows(call *types.ProcedureCall) bool {
+	if _, ok := p.allowed[call.Service+".*"]; ok {
+		return true
+	}
+	_, ok := p.allowed[call.Service+"."+call.Procedure]
+	return ok
+}
+
+// wait waits until a batch of calls can be made: straight away if the game
+// isn't paused or every call is allowed while it is, and otherwise once
+// it is unpaused.
+func (p *pauser) wait(calls []*types.ProcedureCall) error {
+	p.mu.Lock()
+	paused, resumed := p.paused, p.resumed
+	p.mu.Unlock()
+	if !paused {
+		return nil
+	}
+	urgent := true
+	for _, call := range calls {
+		urgent = urgent && p.allows(call)
+	}
+	if urgent {
+		return nil
+	}
+	if p.timeout == 0 {
+		<-resumed
+		return nil
+	}
+	select {
+	case <-resumed:
+		return nil
+	case <-time.After(p.timeout):
+		return tracerr.Wrap(ErrPaused)
+	}
+}
+
+// Paused reports whether the game is paused, as last streamed by the
+// server. It is always false unless QueueWhilePaused has been called.
+func (c *KRPCClient) Paused() bool {
+	c.pauseMu.Lock()
+	p := c.pause
+	c.pauseMu.Unlock()
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// krpcCall makes a call to a procedure of the KRPC service with encoded
+// arguments.
+func (c *KRPCClient) krpcCall(procedure string, args ...[]byte) ([]byte, error) {
+	call := &types.ProcedureCall{Service: "KRPC", Procedure: procedure}
+	for i, arg := range args {
+		call.Arguments = append(call.Arguments, &types.Argument{Position: uint32(i), Value: arg})
+	}
+	result, err := c.Call(call)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return result.Value, nil
+}
+
+// decodeBool decodes a bool result.
+func decodeBool(b []byte) bool {
+	v, _ := proto.DecodeVarint(b)
+	return v != 0
+}
+
+// QueueWhilePaused makes calls wait while the game is paused, rather than
+// fail, so that a script doesn't spam errors while the player has the pause
+// menu open. The game's pause state is streamed, so the client must have a
+// stream connection. Calls to the KRPC service, and those the config
+// allows, are still made while the game is paused.
+func (c *KRPCClient) QueueWhilePaused(cfg PauseConfig) error {
+	if c.StreamClient == nil {
+		return tracerr.Errorf("Queueing calls while paused needs a stream connection")
+	}
+	c.pauseMu.Lock()
+	queued := c.pause != nil
+	c.pauseMu.Unlock()
+	if queued {
+		return tracerr.Errorf("Calls are already queued while paused")
+	}
+
+	// The stream is started once the client is listening to it and has the
+	// current state, so that no change is missed.
+	pausedCall, err := proto.Marshal(&types.ProcedureCall{Service: "KRPC", Procedure: "get_Paused"})
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	b, err := c.krpcCall("AddStream", pausedCall, proto.EncodeVarint(0))
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	var st types.Stream
+	if err := proto.Unmarshal(b, &st); err != nil {
+		return tracerr.Wrap(err)
+	}
+	stream := c.StreamClient.GetStream(st.Id)
+	b, err = c.krpcCall("get_Paused")
+	if err != nil {
+		stream.Close()
+		return tracerr.Wrap(err)
+	}
+	p := newPauser(cfg)
+	p.set(decodeBool(b))
+	if _, err := c.krpcCall("StartStream", proto.EncodeVarint(st.Id)); err != nil {
+		stream.Close()
+		return tracerr.Wrap(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case b := <-stream.C:
+				p.set(decodeBool(b))
+			case <-done:
+				return
+			}
+		}
+	}()
+	c.OnClose(func() {
+		close(done)
+		stream.Close()
+		// Release queued calls, which will fail on the closed connection.
+		p.set(false)
+	})
+	c.pauseMu.Lock()
+	c.pause = p
+	c.pauseMu.Unlock()
+	return nil
+}
@@ -0,0 +1,111 @@
+package krpcgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// pausedServer returns a server whose game is paused.
+func pausedServer(t *testing.T) *krpctest.Server {
+	t.Helper()
+	server := krpctest.NewServer()
+	t.Cleanup(func() { server.Close() })
+	server.Return("KRPC", "get_Paused", true)
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	server.Return("SpaceCenter", "get_WarpRate", float32(1))
+	return server
+}
+
+func TestQueueWhilePaused(t *testing.T) {
+	server := pausedServer(t)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	require.False(t, client.Paused())
+	require.NoError(t, client.QueueWhilePaused(krpcgo.PauseConfig{Allowed: []string{"SpaceCenter.get_WarpRate"}}))
+	require.True(t, client.Paused())
+	require.Error(t, client.QueueWhilePaused(krpcgo.PauseConfig{}))
+
+	sc := spacecenter.New(client)
+	done := make(chan float64)
+	go func() {
+		ut, _ := sc.UT()
+		done <- ut
+	}()
+
+	// Allowed calls and calls to the KRPC service are made while paused.
+	paused, err := krpc.New(client).Paused()
+	require.NoError(t, err)
+	require.True(t, paused)
+	_, err = sc.WarpRate()
+	require.NoError(t, err)
+	select {
+	case <-done:
+		require.FailNow(t, "call made while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	server.Feed("KRPC", "get_Paused", false)
+	select {
+	case ut := <-done:
+		require.Equal(t, 1000.0, ut)
+	case <-time.After(time.Second):
+		require.FailNow(t, "call not made after unpausing")
+	}
+	require.False(t, client.Paused())
+	_, err = sc.UT()
+	require.NoError(t, err)
+}
+
+func TestQueueWhilePausedTimeout(t *testing.T) {
+	server := pausedServer(t)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.QueueWhilePaused(krpcgo.PauseConfig{Timeout: 20 * time.Millisecond}))
+
+	_, err = spacecenter.New(client).UT()
+	require.ErrorIs(t, err, krpcgo.ErrPaused)
+	for _, call := range server.Calls() {
+		require.NotEqual(t, "get_UT", call.Procedure)
+	}
+}
+
+func TestQueueWhilePausedClose(t *testing.T) {
+	server := pausedServer(t)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, client.QueueWhilePaused(krpcgo.PauseConfig{}))
+
+	done := make(chan error)
+	go func() {
+		_, err := spacecenter.New(client).UT()
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, client.Close())
+	select {
+	case err := <-done:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "queued call not released by closing the client")
+	}
+}
+
+func TestQueueWhilePausedRPCOnly(t *testing.T) {
+	server := pausedServer(t)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+	cfg.RPCOnly = true
+	client := krpcgo.NewKRPCClient(cfg)
+	require.NoError(t, client.Connect(context.Background()))
+	defer client.Close()
+	require.Error(t, client.QueueWhilePaused(krpcgo.PauseConfig{}))
+}
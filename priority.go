@@ -0,0 +1,145 @@
+package krpcgo
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Priority is how urgently a call is made when the connection is busy.
+type Priority int
+
+const (
+	// PriorityLow is for bulk calls, such as telemetry, that can wait.
+	PriorityLow Priority = iota
+	// PriorityNormal is for calls that aren't configured otherwise.
+	PriorityNormal
+	// PriorityHigh is for calls that control the vessel.
+	PriorityHigh
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+// PriorityConfig configures which calls go first when the connection is
+// busy. Procedures are given as "Service.Procedure", and a trailing "*"
+// matches any procedure with the same prefix, e.g. "SpaceCenter.Control_*"
+// or "KRPC.*".
+type PriorityConfig struct {
+	// High are the procedures with high priority. Defaults to the vessel
+	// control and autopilot procedures.
+	High []string
+	// Low are the procedures with low priority.
+	Low []string
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *PriorityConfig) SetDefaults() {
+	if cfg.High == nil {
+		cfg.High = []string{"SpaceCenter.Control_*", "SpaceCenter.AutoPilot_*"}
+	}
+}
+
+// matchProcedure checks if a call matches any of the patterns.
+func matchProcedure(patterns []string, call *types.ProcedureCall) bool {
+	name := call.Service + "." + call.Procedure
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// callScheduler lets calls use the connection one batch at a time, highest
+// priority first, and in the order they were made within a priority.
+type callScheduler struct {
+	cfg PriorityConfig
+
+	mu   sync.Mutex
+	busy bool
+	// waiting are the batches waiting for the connection, by priority.
+	waiting [PriorityHigh + 1][]chan struct{}
+}
+
+// newCallScheduler creates a callScheduler for a config.
+func newCallScheduler(cfg PriorityConfig) *callScheduler {
+	cfg.SetDefaults()
+	return &callScheduler{cfg: cfg}
+}
+
+// priority returns the priority of a batch of calls, which is that of its
+// most urgent call.
+func (s *callScheduler) priority(calls []*types.ProcedureCall) Priority {
+	p := PriorityLow
+	for _, call := range calls {
+		switch {
+		case matchProcedure(s.cfg.High, call):
+			return PriorityHigh
+		case !matchProcedure(s.cfg.Low, call):
+			p = PriorityNormal
+		}
+	}
+	return p
+}
+
+// acquire waits for a batch's turn on the connection.
+func (s *callScheduler) acquire(p Priority) {
+	s.mu.Lock()
+	if !s.busy {
+		s.busy = true
+		s.mu.Unlock()
+		return
+	}
+	turn := make(chan struct{})
+	s.waiting[p] = append(s.waiting[p], turn)
+	s.mu.Unlock()
+	<-turn
+}
+
+// release hands the connection to the next batch.
+func (s *callScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for p := PriorityHigh; p >= PriorityLow; p-- {
+		if len(s.waiting[p]) > 0 {
+			turn := s.waiting[p][0]
+			s.waiting[p] = s.waiting[p][1:]
+			close(turn)
+			return
+		}
+	}
+	s.busy = false
+}
+
+// PrioritizeCalls makes calls wait for the connection in order of priority
+// rather than in the order they were made, so that control calls aren't
+// held up behind telemetry when several goroutines share the client. A call
+// that has been sent can't be interrupted, so a high priority call waits for
+// at most one other call. Low priority calls wait for as long as there are
+// others, so they should only be used for calls that can wait.
+func (c *KRPCClient) PrioritizeCalls(cfg PriorityConfig) error {
+	c.schedulerMu.Lock()
+	defer c.schedulerMu.Unlock()
+	if c.scheduler != nil {
+		return tracerr.Errorf("Calls are already prioritized")
+	}
+	c.scheduler = newCallScheduler(cfg)
+	return nil
+}
@@ -0,0 +1,65 @@
+package krpcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func call(service, procedure string) *types.ProcedureCall {
+	return &types.ProcedureCall{Service: service, Procedure: procedure}
+}
+
+func TestCallPriority(t *testing.T) {
+	s := newCallScheduler(PriorityConfig{Low: []string{"SpaceCenter.get_UT", "Telemetry.*"}})
+	throttle := call("SpaceCenter", "Control_set_Throttle")
+	ut := call("SpaceCenter", "get_UT")
+	telemetry := call("Telemetry", "Sample")
+	name := call("SpaceCenter", "Vessel_get_Name")
+
+	require.Equal(t, PriorityHigh, s.priority([]*types.ProcedureCall{throttle}))
+	require.Equal(t, PriorityLow, s.priority([]*types.ProcedureCall{ut, telemetry}))
+	require.Equal(t, PriorityNormal, s.priority([]*types.ProcedureCall{name}))
+	// A batch is as urgent as its most urgent call.
+	require.Equal(t, PriorityNormal, s.priority([]*types.ProcedureCall{ut, name}))
+	require.Equal(t, PriorityHigh, s.priority([]*types.ProcedureCall{ut, throttle}))
+	require.Equal(t, "high", PriorityHigh.String())
+}
+
+func TestCallScheduler(t *testing.T) {
+	s := newCallScheduler(PriorityConfig{})
+	s.acquire(PriorityLow)
+
+	order := make(chan string, 4)
+	wait := func(name string, p Priority) {
+		go func() {
+			s.acquire(p)
+			order <- name
+			s.release()
+		}()
+		// Let the batch start waiting, so the order is known.
+		time.Sleep(10 * time.Millisecond)
+	}
+	wait("low", PriorityLow)
+	wait("normal 1", PriorityNormal)
+	wait("high", PriorityHigh)
+	wait("normal 2", PriorityNormal)
+	s.release()
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, <-order)
+	}
+	require.Equal(t, []string{"high", "normal 1", "normal 2", "low"}, got)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.False(t, s.busy)
+}
+
+func TestPrioritizeCalls(t *testing.T) {
+	client := NewKRPCClient(KRPCClientConfig{})
+	require.NoError(t, client.PrioritizeCalls(PriorityConfig{}))
+	require.Error(t, client.PrioritizeCalls(PriorityConfig{}))
+}
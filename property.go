@@ -0,0 +1,47 @@
+package krpcgo
+
+import "github.com/ztrue/tracerr"
+
+// Property is a handle to a property of a kRPC object. It gives a uniform way
+// to get, set, and stream a property regardless of the service or class it
+// belongs to.
+type Property[T any] struct {
+	get    func() (T, error)
+	set    func(T) error
+	stream func() (*Stream[T], error)
+}
+
+// NewProperty creates a new property handle. stream may be nil if the
+// property cannot be streamed.
+func NewProperty[T any](get func() (T, error), set func(T) error, stream func() (*Stream[T], error)) *Property[T] {
+	return &Property[T]{
+		get:    get,
+		set:    set,
+		stream: stream,
+	}
+}
+
+// Get gets the value of the property.
+func (p *Property[T]) Get() (T, error) {
+	v, err := p.get()
+	return v, tracerr.Wrap(err)
+}
+
+// Set sets the value of the property.
+func (p *Property[T]) Set(value T) error {
+	return tracerr.Wrap(p.set(value))
+}
+
+// Streamable returns true if the property can be streamed.
+func (p *Property[T]) Streamable() bool {
+	return p.stream != nil
+}
+
+// Stream creates a stream of the property's value.
+func (p *Property[T]) Stream() (*Stream[T], error) {
+	if p.stream == nil {
+		return nil, tracerr.Errorf("Property cannot be streamed")
+	}
+	s, err := p.stream()
+	return s, tracerr.Wrap(err)
+}
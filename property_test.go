@@ -0,0 +1,26 @@
+package krpcgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProperty(t *testing.T) {
+	value := 1.0
+	get := func() (float64, error) { return value, nil }
+	set := func(v float64) error {
+		value = v
+		return nil
+	}
+
+	p := NewProperty(get, set, nil)
+	require.NoError(t, p.Set(5))
+	v, err := p.Get()
+	require.NoError(t, err)
+	require.Equal(t, 5.0, v)
+
+	require.False(t, p.Streamable())
+	_, err = p.Stream()
+	require.Error(t, err)
+}
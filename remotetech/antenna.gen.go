@@ -0,0 +1,493 @@
+package remotetech
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	spacecenter "github.com/atburke/krpc-go/spacecenter"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// Antenna - a RemoteTech antenna. Obtained by calling <see
+// cref="M:RemoteTech.Comms.Antennas" /> or <see cref="M:RemoteTech.Antenna" />.
+type Antenna struct {
+	service.BaseClass
+}
+
+// NewAntenna creates a new Antenna.
+func NewAntenna(id uint64, client *krpcgo.KRPCClient) *Antenna {
+	c := &Antenna{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Part - get the part containing this antenna.
+//
+// Allowed game scenes: any.
+func (s *Antenna) Part() (*spacecenter.Part, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.Part
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_Part",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// HasConnection - whether the antenna has a connection.
+//
+// Allowed game scenes: any.
+func (s *Antenna) HasConnection() (bool, error) {
+	var err error
+	var argBytes []byte
+	var vv bool
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_HasConnection",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// HasConnectionStream - whether the antenna has a connection.
+//
+// Allowed game scenes: any.
+func (s *Antenna) HasConnectionStream() (*krpcgo.Stream[bool], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_HasConnection",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) bool {
+		var value bool
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// Target - the object that the antenna is targetting. This property can be used
+// to set the target to <see cref="M:RemoteTech.Target.None" /> or <see
+// cref="M:RemoteTech.Target.ActiveVessel" />. To set the target to a celestial
+// body, ground station or vessel see <see
+// cref="M:RemoteTech.Antenna.TargetBody" />, <see
+// cref="M:RemoteTech.Antenna.TargetGroundStation" /> and <see
+// cref="M:RemoteTech.Antenna.TargetVessel" />.
+//
+// Allowed game scenes: any.
+func (s *Antenna) Target() (Target, error) {
+	var err error
+	var argBytes []byte
+	var vv Target
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_Target",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TargetStream - the object that the antenna is targetting. This property can
+// be used to set the target to <see cref="M:RemoteTech.TargetStream.None" /> or
+// <see cref="M:RemoteTech.TargetStream.ActiveVessel" />. To set the target to a
+// celestial body, ground station or vessel see <see
+// cref="M:RemoteTech.Antenna.TargetStreamBody" />, <see
+// cref="M:RemoteTech.Antenna.TargetStreamGroundStation" /> and <see
+// cref="M:RemoteTech.Antenna.TargetStreamVessel" />.
+//
+// Allowed game scenes: any.
+func (s *Antenna) TargetStream() (*krpcgo.Stream[Target], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_Target",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) Target {
+		var value Target
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetTarget - the object that the antenna is targetting. This property can be
+// used to set the target to <see cref="M:RemoteTech.Target.None" /> or <see
+// cref="M:RemoteTech.Target.ActiveVessel" />. To set the target to a celestial
+// body, ground station or vessel see <see
+// cref="M:RemoteTech.Antenna.TargetBody" />, <see
+// cref="M:RemoteTech.Antenna.TargetGroundStation" /> and <see
+// cref="M:RemoteTech.Antenna.TargetVessel" />.
+//
+// Allowed game scenes: any.
+func (s *Antenna) SetTarget(value Target) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_set_Target",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// TargetProp - returns a handle to the Target property.
+func (s *Antenna) TargetProp() *krpcgo.Property[Target] {
+	return krpcgo.NewProperty(s.Target, s.SetTarget, s.TargetStream)
+}
+
+// TargetBody - the celestial body the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) TargetBody() (*spacecenter.CelestialBody, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.CelestialBody
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_TargetBody",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetTargetBody - the celestial body the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) SetTargetBody(value *spacecenter.CelestialBody) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_set_TargetBody",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// TargetBodyProp - returns a handle to the TargetBody property.
+func (s *Antenna) TargetBodyProp() *krpcgo.Property[*spacecenter.CelestialBody] {
+	return krpcgo.NewProperty(s.TargetBody, s.SetTargetBody, nil)
+}
+
+// TargetGroundStation - the ground station the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) TargetGroundStation() (string, error) {
+	var err error
+	var argBytes []byte
+	var vv string
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_TargetGroundStation",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// TargetGroundStationStream - the ground station the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) TargetGroundStationStream() (*krpcgo.Stream[string], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_TargetGroundStation",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) string {
+		var value string
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetTargetGroundStation - the ground station the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) SetTargetGroundStation(value string) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_set_TargetGroundStation",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// TargetGroundStationProp - returns a handle to the TargetGroundStation property.
+func (s *Antenna) TargetGroundStationProp() *krpcgo.Property[string] {
+	return krpcgo.NewProperty(s.TargetGroundStation, s.SetTargetGroundStation, s.TargetGroundStationStream)
+}
+
+// TargetVessel - the vessel the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) TargetVessel() (*spacecenter.Vessel, error) {
+	var err error
+	var argBytes []byte
+	var vv spacecenter.Vessel
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_get_TargetVessel",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// SetTargetVessel - the vessel the antenna is targetting.
+//
+// Allowed game scenes: any.
+func (s *Antenna) SetTargetVessel(value *spacecenter.Vessel) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "Antenna_set_TargetVessel",
+		Service:   "RemoteTech",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// TargetVesselProp - returns a handle to the TargetVessel property.
+func (s *Antenna) TargetVesselProp() *krpcgo.Property[*spacecenter.Vessel] {
+	return krpcgo.NewProperty(s.TargetVessel, s.SetTargetVessel, nil)
+}
@@ -0,0 +1,99 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/ztrue/tracerr"
+)
+
+// PlayerConfig configures a Player.
+type PlayerConfig struct {
+	// Speed is how many times faster than the original flight to play, e.g.
+	// 10 to play a minute of records in six seconds. Zero plays the records
+	// as fast as the writers take them.
+	Speed float64
+}
+
+// Player plays a recording's records to writers, in place of a
+// telemetry.Recorder. Records are passed on unchanged, so writers see the
+// original times, UTs and phases, and given the same recording they always
+// see the same records in the same order.
+type Player struct {
+	rec   *Recording
+	cfg   PlayerConfig
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu      sync.Mutex
+	writers []telemetry.Writer
+}
+
+// NewPlayer creates a new Player.
+func NewPlayer(rec *Recording, cfg PlayerConfig) *Player {
+	return &Player{rec: rec, cfg: cfg, sleep: sleep}
+}
+
+// sleep waits for a duration or until the context is canceled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Metadata returns the recording's metadata, for creating writers.
+func (p *Player) Metadata() telemetry.Metadata {
+	return p.rec.Metadata
+}
+
+// AddWriter adds a writer. It is closed when playing stops.
+func (p *Player) AddWriter(w telemetry.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.writers = append(p.writers, w)
+}
+
+// play passes the records to the writers.
+func (p *Player) play(ctx context.Context, writers []telemetry.Writer) error {
+	for i, rec := range p.rec.Records {
+		if i > 0 && p.cfg.Speed > 0 {
+			gap := rec.Time.Sub(p.rec.Records[i-1].Time)
+			if gap > 0 {
+				if err := p.sleep(ctx, time.Duration(float64(gap)/p.cfg.Speed)); err != nil {
+					return nil
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		for _, w := range writers {
+			if err := w.Write(rec); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Run plays the recording until it ends or the context is canceled, then
+// closes the writers.
+func (p *Player) Run(ctx context.Context) error {
+	p.mu.Lock()
+	writers := p.writers
+	p.mu.Unlock()
+
+	err := p.play(ctx, writers)
+	for _, w := range writers {
+		if closeErr := w.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,76 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWriter keeps the records written to it.
+type memoryWriter struct {
+	records []telemetry.Record
+	closed  bool
+}
+
+func (w *memoryWriter) Write(rec telemetry.Record) error {
+	w.records = append(w.records, rec)
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+// newTestPlayer creates a player that records how long it sleeps for
+// instead of sleeping.
+func newTestPlayer(cfg PlayerConfig) (*Player, *[]time.Duration) {
+	p := NewPlayer(&Recording{Metadata: testMetadata(), Records: testRecords()}, cfg)
+	var sleeps []time.Duration
+	p.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+	return p, &sleeps
+}
+
+func TestPlayer(t *testing.T) {
+	tests := []struct {
+		name           string
+		speed          float64
+		expectedSleeps []time.Duration
+	}{
+		{name: "real time", speed: 1, expectedSleeps: []time.Duration{time.Second, 2 * time.Second}},
+		{name: "faster", speed: 4, expectedSleeps: []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}},
+		{name: "flat out", speed: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, sleeps := newTestPlayer(PlayerConfig{Speed: tc.speed})
+			require.Equal(t, testMetadata(), p.Metadata())
+			w1, w2 := &memoryWriter{}, &memoryWriter{}
+			p.AddWriter(w1)
+			p.AddWriter(w2)
+			require.NoError(t, p.Run(context.Background()))
+			require.Equal(t, testRecords(), w1.records)
+			require.Equal(t, testRecords(), w2.records)
+			require.True(t, w1.closed)
+			require.True(t, w2.closed)
+			require.Equal(t, tc.expectedSleeps, *sleeps)
+		})
+	}
+}
+
+func TestPlayerCanceled(t *testing.T) {
+	p, _ := newTestPlayer(PlayerConfig{Speed: 1})
+	w := &memoryWriter{}
+	p.AddWriter(w)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, p.Run(ctx))
+	require.Empty(t, w.records)
+	require.True(t, w.closed)
+}
@@ -0,0 +1,127 @@
+// Package replay plays back recorded flights, so that dashboards, alert
+// rules and mission logic can be run again against what actually happened.
+//
+// A Player feeds a recording's telemetry to telemetry.Writers at its
+// original pace, or faster, in place of a telemetry.Recorder. A Server
+// answers procedure calls with the results a blackbox recorded, so that
+// code using the generated services can be run against a past flight
+// without the game.
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/atburke/krpc-go/blackbox"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/ztrue/tracerr"
+)
+
+// Recording is a recorded flight's telemetry.
+type Recording struct {
+	Metadata telemetry.Metadata
+	Records  []telemetry.Record
+}
+
+// reservedFields are the fields of a record that aren't channels.
+var reservedFields = map[string]bool{"time": true, "ut": true, "vessel": true, "phase": true}
+
+// channelsFromFields guesses a recording's channels from a record's fields,
+// for recordings without metadata. They are sorted by name.
+func channelsFromFields(fields map[string]any) []telemetry.ChannelInfo {
+	var channels []telemetry.ChannelInfo
+	for name := range fields {
+		if !reservedFields[name] {
+			channels = append(channels, telemetry.ChannelInfo{Name: name})
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Name < channels[j].Name })
+	return channels
+}
+
+// recordFromFields reads a record from the fields of its JSON object, as
+// written by a telemetry.JSONLWriter. Missing and null channels are NaN.
+func recordFromFields(meta telemetry.Metadata, fields map[string]any) (telemetry.Record, error) {
+	rec := telemetry.Record{Values: make([]float64, len(meta.Channels))}
+	if s, ok := fields["time"].(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return rec, tracerr.Wrap(err)
+		}
+		rec.Time = t
+	}
+	if ut, ok := fields["ut"].(float64); ok {
+		rec.UT = ut
+	}
+	if phase, ok := fields["phase"].(string); ok {
+		rec.Phase = phase
+	}
+	for i, c := range meta.Channels {
+		switch v := fields[c.Name].(type) {
+		case float64:
+			rec.Values[i] = v
+		case nil:
+			rec.Values[i] = math.NaN()
+		default:
+			return rec, tracerr.Errorf("Channel %s has a non-numeric value %v", c.Name, v)
+		}
+	}
+	return rec, nil
+}
+
+// ReadJSONL reads a recording written by a telemetry.JSONLWriter. meta is
+// the recording's metadata, as written by telemetry.WriteMetadata; if it has
+// no channels, every field of the first line other than time, ut, vessel and
+// phase is taken to be one.
+func ReadJSONL(r io.Reader, meta telemetry.Metadata) (*Recording, error) {
+	rec := &Recording{Metadata: meta}
+	dec := json.NewDecoder(r)
+	for {
+		var fields map[string]any
+		if err := dec.Decode(&fields); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if len(rec.Records) == 0 && len(rec.Metadata.Channels) == 0 {
+			rec.Metadata.Channels = channelsFromFields(fields)
+			if vessel, ok := fields["vessel"].(string); ok && rec.Metadata.Vessel == "" {
+				rec.Metadata.Vessel = vessel
+			}
+		}
+		record, err := recordFromFields(rec.Metadata, fields)
+		if err != nil {
+			return nil, tracerr.Errorf("Record %d: %v", len(rec.Records)+1, err)
+		}
+		rec.Records = append(rec.Records, record)
+	}
+	if rec.Metadata.Started.IsZero() && len(rec.Records) > 0 {
+		rec.Metadata.Started = rec.Records[0].Time
+	}
+	return rec, nil
+}
+
+// ReadDump reads a blackbox dump, as written by Blackbox.Dump.
+func ReadDump(r io.Reader) (*blackbox.Dump, error) {
+	var d blackbox.Dump
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &d, nil
+}
+
+// FromDump returns the telemetry in a blackbox dump.
+func FromDump(d *blackbox.Dump) (*Recording, error) {
+	rec := &Recording{Metadata: d.Metadata}
+	for i, fields := range d.Records {
+		record, err := recordFromFields(d.Metadata, fields)
+		if err != nil {
+			return nil, tracerr.Errorf("Record %d: %v", i+1, err)
+		}
+		rec.Records = append(rec.Records, record)
+	}
+	return rec, nil
+}
@@ -0,0 +1,87 @@
+package replay
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/blackbox"
+	"github.com/atburke/krpc-go/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func testMetadata() telemetry.Metadata {
+	return telemetry.Metadata{
+		Vessel: "Kerbal X",
+		Channels: []telemetry.ChannelInfo{
+			{Name: "altitude", Unit: "m"},
+			{Name: "speed", Unit: "m/s"},
+		},
+		Started: start,
+	}
+}
+
+func testRecords() []telemetry.Record {
+	return []telemetry.Record{
+		{Time: start, UT: 100, Phase: "launch", Values: []float64{0, 0}},
+		{Time: start.Add(time.Second), UT: 101, Phase: "ascent", Values: []float64{50, 100}},
+		{Time: start.Add(3 * time.Second), UT: 103, Phase: "ascent", Values: []float64{400, 250}},
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	w := telemetry.NewJSONLWriter(&buf, testMetadata())
+	for _, rec := range testRecords() {
+		require.NoError(t, w.Write(rec))
+	}
+
+	rec, err := ReadJSONL(bytes.NewReader(buf.Bytes()), testMetadata())
+	require.NoError(t, err)
+	require.Equal(t, &Recording{Metadata: testMetadata(), Records: testRecords()}, rec)
+
+	// Without metadata, the channels are found from the first line.
+	rec, err = ReadJSONL(bytes.NewReader(buf.Bytes()), telemetry.Metadata{})
+	require.NoError(t, err)
+	require.Equal(t, telemetry.Metadata{
+		Vessel:   "Kerbal X",
+		Channels: []telemetry.ChannelInfo{{Name: "altitude"}, {Name: "speed"}},
+		Started:  start,
+	}, rec.Metadata)
+	require.Equal(t, testRecords(), rec.Records)
+}
+
+func TestReadJSONLValues(t *testing.T) {
+	rec, err := ReadJSONL(strings.NewReader(`{"time": "2024-01-01T12:00:00Z", "altitude": null}`), testMetadata())
+	require.NoError(t, err)
+	require.Len(t, rec.Records, 1)
+	require.True(t, math.IsNaN(rec.Records[0].Values[0]))
+	require.True(t, math.IsNaN(rec.Records[0].Values[1]))
+
+	_, err = ReadJSONL(strings.NewReader(`{"altitude": "high"}`), testMetadata())
+	require.Error(t, err)
+	_, err = ReadJSONL(strings.NewReader(`{"time": "noon"}`), testMetadata())
+	require.Error(t, err)
+	_, err = ReadJSONL(strings.NewReader(`{`), testMetadata())
+	require.Error(t, err)
+}
+
+func TestReadDump(t *testing.T) {
+	bb := blackbox.New(testMetadata(), blackbox.Config{Window: time.Duration(math.MaxInt64)})
+	for _, rec := range testRecords() {
+		require.NoError(t, bb.Write(rec))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, bb.Dump(&buf, "test"))
+
+	d, err := ReadDump(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "test", d.Reason)
+	rec, err := FromDump(d)
+	require.NoError(t, err)
+	require.Equal(t, &Recording{Metadata: testMetadata(), Records: testRecords()}, rec)
+}
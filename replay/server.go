@@ -0,0 +1,258 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/blackbox"
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// answers are the recorded results of one call, with the same procedure
+// and arguments, in the order they were made.
+type answers struct {
+	calls []blackbox.Call
+	next  int
+}
+
+// callKey identifies a call by its procedure and arguments.
+func callKey(service, procedure string, args [][]byte) string {
+	parts := []string{service, procedure}
+	for _, arg := range args {
+		parts = append(parts, hex.EncodeToString(arg))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Server is a kRPC server that answers procedure calls with the results of
+// the same calls in a blackbox dump, so that code that made them can be run
+// again without the game. A call with the same procedure and arguments as
+// several recorded calls is answered with each of their results in turn,
+// and then the last result again. Calls that weren't recorded fail.
+//
+// The server doesn't support streams, so clients must be RPC only.
+type Server struct {
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	answers  map[string]*answers
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+}
+
+// NewServer creates a new Server answering with the given calls. Start it
+// with Serve, or use Client.
+func NewServer(calls []blackbox.Call) *Server {
+	s := &Server{answers: map[string]*answers{}, conns: map[net.Conn]struct{}{}}
+	for _, c := range calls {
+		key := callKey(c.Service, c.Procedure, c.Arguments)
+		a, ok := s.answers[key]
+		if !ok {
+			a = &answers{}
+			s.answers[key] = a
+		}
+		a.calls = append(a.calls, c)
+	}
+	return s
+}
+
+// answer returns the result for a call.
+func (s *Server) answer(call *types.ProcedureCall) *types.ProcedureResult {
+	args := make([][]byte, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		args[i] = arg.Value
+	}
+	s.mu.Lock()
+	a, ok := s.answers[callKey(call.Service, call.Procedure, args)]
+	var c blackbox.Call
+	if ok {
+		c = a.calls[a.next]
+		if a.next < len(a.calls)-1 {
+			a.next++
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !ok:
+		return &types.ProcedureResult{Error: &types.Error{
+			Service:     call.Service,
+			Name:        "NotRecorded",
+			Description: "the call " + call.Service + "." + call.Procedure + " with these arguments wasn't recorded",
+		}}
+	case c.Error != "":
+		return &types.ProcedureResult{Error: &types.Error{Service: call.Service, Description: c.Error}}
+	}
+	return &types.ProcedureResult{Value: c.Result}
+}
+
+// readMessage reads a length-prefixed message.
+func readMessage(r *bufio.Reader, m proto.Message) error {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(proto.Unmarshal(b, m))
+}
+
+// writeMessage writes a length-prefixed message.
+func writeMessage(w io.Writer, m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	_, err = w.Write(append(proto.EncodeVarint(uint64(len(b))), b...))
+	return tracerr.Wrap(err)
+}
+
+// serveConn handles a client's connection.
+func (s *Server) serveConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	var req types.ConnectionRequest
+	if readMessage(r, &req) != nil {
+		return
+	}
+	if req.Type != types.ConnectionRequest_RPC {
+		_ = writeMessage(conn, &types.ConnectionResponse{
+			Status:  types.ConnectionResponse_WRONG_TYPE,
+			Message: "Replays don't support streams",
+		})
+		return
+	}
+	if writeMessage(conn, &types.ConnectionResponse{
+		Status:           types.ConnectionResponse_OK,
+		ClientIdentifier: make([]byte, 16),
+	}) != nil {
+		return
+	}
+
+	for {
+		var request types.Request
+		if readMessage(r, &request) != nil {
+			return
+		}
+		resp := &types.Response{}
+		for _, call := range request.Calls {
+			resp.Results = append(resp.Results, s.answer(call))
+		}
+		if writeMessage(conn, resp) != nil {
+			return
+		}
+	}
+}
+
+// listen sets the listener to accept connections from.
+func (s *Server) listen(l net.Listener) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return tracerr.Errorf("Server is closed")
+	}
+	if s.listener != nil {
+		return tracerr.Errorf("Server is already serving")
+	}
+	s.listener = l
+	return nil
+}
+
+// Serve accepts connections until the server is closed.
+func (s *Server) Serve(l net.Listener) error {
+	if err := s.listen(l); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return s.accept(l)
+}
+
+// accept accepts connections until the server is closed.
+func (s *Server) accept(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return tracerr.Wrap(err)
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go s.serveConn(conn)
+	}
+}
+
+// Client starts serving on a local port and returns a client connected to
+// it. Close the server when done; closing the client isn't enough.
+func (s *Server) Client(ctx context.Context) (*krpcgo.KRPCClient, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if err := s.listen(l); err != nil {
+		l.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	go func() { _ = s.accept(l) }()
+
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{
+		Host:       host,
+		RPCPort:    port,
+		ClientName: "replay",
+		RPCOnly:    true,
+		Game:       krpcgo.GameKSP1,
+	})
+	if err := client.Connect(ctx); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return client, nil
+}
+
+// Close stops the server and closes its connections.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,80 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/blackbox"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mustMarshal(t *testing.T, v any) []byte {
+	b, err := encode.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func TestServer(t *testing.T) {
+	vesselID := mustMarshal(t, uint64(7))
+	server := NewServer([]blackbox.Call{
+		{Service: "SpaceCenter", Procedure: "get_UT", Result: mustMarshal(t, 100.0)},
+		{Service: "SpaceCenter", Procedure: "get_ActiveVessel", Result: vesselID},
+		{Service: "SpaceCenter", Procedure: "get_UT", Result: mustMarshal(t, 101.0)},
+		{Service: "SpaceCenter", Procedure: "Vessel_get_Name", Arguments: [][]byte{vesselID}, Result: mustMarshal(t, "Kerbal X")},
+		{Service: "SpaceCenter", Procedure: "Vessel_get_MET", Arguments: [][]byte{vesselID}, Error: "SpaceCenter - InvalidOperationException: Nope"},
+	})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer server.Close()
+	sc := spacecenter.New(client)
+
+	// Repeated calls get each recorded result in turn, then the last again.
+	for _, expected := range []float64{100, 101, 101} {
+		ut, err := sc.UT()
+		require.NoError(t, err)
+		require.Equal(t, expected, ut)
+	}
+
+	vessel, err := sc.ActiveVessel()
+	require.NoError(t, err)
+	name, err := vessel.Name()
+	require.NoError(t, err)
+	require.Equal(t, "Kerbal X", name)
+
+	_, err = vessel.MET()
+	var krpcErr *types.Error
+	require.True(t, errors.As(err, &krpcErr))
+	require.Equal(t, "SpaceCenter - InvalidOperationException: Nope", krpcErr.Description)
+
+	_, err = vessel.Mass()
+	require.True(t, errors.As(err, &krpcErr))
+	require.Equal(t, "NotRecorded", krpcErr.Name)
+
+	require.NoError(t, server.Close())
+	_, err = sc.UT()
+	require.Error(t, err)
+}
+
+func TestServerRefusesStreams(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	_, err := server.Client(context.Background())
+	require.NoError(t, err)
+
+	// The server only serves one listener.
+	_, err = server.Client(context.Background())
+	require.Error(t, err)
+
+	host, port, err := net.SplitHostPort(server.listener.Addr().String())
+	require.NoError(t, err)
+	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{Host: host, RPCPort: port, StreamPort: port, Game: krpcgo.GameKSP1})
+	err = client.Connect(context.Background())
+	require.ErrorContains(t, err, "Replays don't support streams")
+	client.Close()
+}
@@ -0,0 +1,219 @@
+package rules
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Values are the latest values of an engine's inputs, by name.
+type Values map[string]float64
+
+// Condition is checked against the latest values of an engine's inputs. A
+// condition on an input that hasn't had a value yet isn't met.
+type Condition interface {
+	Met(Values) bool
+	// String describes the condition in alert messages, e.g.
+	// "periapsis < 70000".
+	String() string
+}
+
+// Threshold is met while an input is below, or above, a limit.
+type Threshold struct {
+	Input string
+	Limit float64
+	// Above is set for thresholds met by values above the limit.
+	Above bool
+}
+
+// Below is met while an input is below a limit.
+func Below(input string, limit float64) Threshold {
+	return Threshold{Input: input, Limit: limit}
+}
+
+// Above is met while an input is above a limit.
+func Above(input string, limit float64) Threshold {
+	return Threshold{Input: input, Limit: limit, Above: true}
+}
+
+// Met returns whether the input is past the limit.
+func (t Threshold) Met(v Values) bool {
+	x, ok := v[t.Input]
+	if !ok || math.IsNaN(x) {
+		return false
+	}
+	if t.Above {
+		return x > t.Limit
+	}
+	return x < t.Limit
+}
+
+// String describes the threshold.
+func (t Threshold) String() string {
+	op := " < "
+	if t.Above {
+		op = " > "
+	}
+	return t.Input + op + strconv.FormatFloat(t.Limit, 'g', -1, 64)
+}
+
+// flag is met while an input is non-zero.
+type flag string
+
+// Is is met while an input is non-zero, e.g. one made by FlagInput that is
+// true.
+func Is(input string) Condition {
+	return flag(input)
+}
+
+func (f flag) Met(v Values) bool {
+	x, ok := v[string(f)]
+	return ok && x != 0 && !math.IsNaN(x)
+}
+
+func (f flag) String() string {
+	return string(f)
+}
+
+// not is met while its condition isn't.
+type not struct {
+	cond Condition
+}
+
+// Not is met while a condition isn't. Like the condition, it isn't met
+// until the condition's inputs have values.
+func Not(cond Condition) Condition {
+	return not{cond}
+}
+
+func (n not) Met(v Values) bool {
+	for _, input := range inputs(n.cond) {
+		if _, ok := v[input]; !ok {
+			return false
+		}
+	}
+	return !n.cond.Met(v)
+}
+
+func (n not) String() string {
+	return "not " + group(n.cond)
+}
+
+// allOf is met while all its conditions are.
+type allOf []Condition
+
+// All is met while all the conditions are.
+func All(conds ...Condition) Condition {
+	return allOf(conds)
+}
+
+func (a allOf) Met(v Values) bool {
+	for _, cond := range a {
+		if !cond.Met(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOf) String() string {
+	return join(a, " and ")
+}
+
+// anyOf is met while any of its conditions are.
+type anyOf []Condition
+
+// Any is met while any of the conditions are.
+func Any(conds ...Condition) Condition {
+	return anyOf(conds)
+}
+
+func (a anyOf) Met(v Values) bool {
+	for _, cond := range a {
+		if cond.Met(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a anyOf) String() string {
+	return join(a, " or ")
+}
+
+// funcCondition is a condition checked by a function.
+type funcCondition struct {
+	desc   string
+	inputs []string
+	f      func(Values) bool
+}
+
+// Func is met while a function returns true. The function is only called
+// once all the named inputs have values, and desc describes it in alert
+// messages.
+func Func(desc string, f func(Values) bool, inputs ...string) Condition {
+	return funcCondition{desc: desc, inputs: inputs, f: f}
+}
+
+func (c funcCondition) Met(v Values) bool {
+	for _, input := range c.inputs {
+		if _, ok := v[input]; !ok {
+			return false
+		}
+	}
+	return c.f(v)
+}
+
+func (c funcCondition) String() string {
+	return c.desc
+}
+
+// inputs returns the inputs a condition reads.
+func inputs(cond Condition) []string {
+	switch c := cond.(type) {
+	case Threshold:
+		return []string{c.Input}
+	case flag:
+		return []string{string(c)}
+	case not:
+		return inputs(c.cond)
+	case allOf:
+		return conditionInputs(c)
+	case anyOf:
+		return conditionInputs(c)
+	case funcCondition:
+		return c.inputs
+	}
+	return nil
+}
+
+func conditionInputs(conds []Condition) []string {
+	var names []string
+	for _, cond := range conds {
+		names = append(names, inputs(cond)...)
+	}
+	return names
+}
+
+// group describes a condition, in brackets if it is made of others.
+func group(cond Condition) string {
+	switch c := cond.(type) {
+	case allOf:
+		if len(c) > 1 {
+			return "(" + c.String() + ")"
+		}
+	case anyOf:
+		if len(c) > 1 {
+			return "(" + c.String() + ")"
+		}
+	}
+	return cond.String()
+}
+
+func join(conds []Condition, sep string) string {
+	parts := make([]string, len(conds))
+	for i, cond := range conds {
+		parts[i] = group(cond)
+	}
+	return strings.Join(parts, sep)
+}
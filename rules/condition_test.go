@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditions(t *testing.T) {
+	values := Values{"periapsis": 65000, "thrust": 0, "landed": 1, "charge": math.NaN()}
+	tests := []struct {
+		name     string
+		cond     Condition
+		expected bool
+		desc     string
+	}{
+		{name: "below", cond: Below("periapsis", 70000), expected: true, desc: "periapsis < 70000"},
+		{name: "not below", cond: Below("periapsis", 60000), desc: "periapsis < 60000"},
+		{name: "above", cond: Above("periapsis", 6e4), expected: true, desc: "periapsis > 60000"},
+		{name: "at the limit", cond: Above("thrust", 0), desc: "thrust > 0"},
+		{name: "no value", cond: Below("altitude", 100), desc: "altitude < 100"},
+		{name: "not a number", cond: Below("charge", 0.1), desc: "charge < 0.1"},
+		{name: "flag", cond: Is("landed"), expected: true, desc: "landed"},
+		{name: "flag not set", cond: Is("thrust"), desc: "thrust"},
+		{name: "not", cond: Not(Above("thrust", 0)), expected: true, desc: "not thrust > 0"},
+		{name: "not without a value", cond: Not(Above("altitude", 0)), desc: "not altitude > 0"},
+		{
+			name:     "all",
+			cond:     All(Below("periapsis", 70000), Not(Above("thrust", 0))),
+			expected: true,
+			desc:     "periapsis < 70000 and not thrust > 0",
+		},
+		{
+			name: "any",
+			cond: Any(Is("thrust"), All(Is("landed"), Below("periapsis", 0))),
+			desc: "thrust or (landed and periapsis < 0)",
+		},
+		{
+			name:     "func",
+			cond:     Func("coasting", func(v Values) bool { return v["thrust"] == 0 }, "thrust"),
+			expected: true,
+			desc:     "coasting",
+		},
+		{
+			name: "func without a value",
+			cond: Func("falling", func(v Values) bool { return true }, "vertical_speed"),
+			desc: "falling",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.cond.Met(values))
+			require.Equal(t, tc.desc, tc.cond.String())
+		})
+	}
+}
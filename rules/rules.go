@@ -0,0 +1,313 @@
+// Package rules raises alerts when conditions over a vessel's streams are
+// met, such as a periapsis below 70km while coasting, or electric charge
+// below 10%. Rules are checked against the latest value of each of an
+// Engine's inputs, and their alerts are sent to callbacks or to an
+// alert.Bridge, which can pass them on to the in-game UI (ui.MessageSink),
+// a webhook (alert.Webhook) or anything else that takes alerts.
+//
+//	charge, _ := resources.AmountStream("ElectricCharge")
+//	engine := rules.New(rules.Config{Bridge: &bridge},
+//		rules.StreamInput("periapsis", periapsis),
+//		rules.StreamInput("thrust", thrust),
+//		rules.StreamInput("charge", krpcgo.MapStream(charge, func(v float32) float64 {
+//			return float64(v / capacity)
+//		})),
+//	)
+//	engine.Add(rules.Rule{
+//		Name:     "low periapsis",
+//		Severity: alert.Warning,
+//		When:     rules.Below("periapsis", 70000),
+//		While:    rules.Not(rules.Above("thrust", 0)),
+//		For:      2 * time.Second,
+//	}, rules.Rule{
+//		Name:     "low charge",
+//		Severity: alert.Critical,
+//		When:     rules.Below("charge", 0.1),
+//	})
+//	err := engine.Run(ctx)
+//
+// Triggers use the same conditions and inputs to run actions instead, such
+// as deploying antennas once above 70km.
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/alert"
+	"github.com/ztrue/tracerr"
+)
+
+// Input feeds an engine the values of a stream under a name that rules'
+// conditions refer to.
+type Input struct {
+	name   string
+	listen func(done <-chan struct{}, set func(float64))
+	close  func() error
+}
+
+// StreamInput creates an input from a numeric stream. Use krpcgo.MapStream
+// to scale a stream first, e.g. to turn an amount into a fraction. The
+// stream is closed when the engine stops.
+func StreamInput[T krpcgo.Number](name string, stream *krpcgo.Stream[T]) Input {
+	return Input{
+		name: name,
+		listen: func(done <-chan struct{}, set func(float64)) {
+			for {
+				select {
+				case <-done:
+					return
+				case v, ok := <-stream.C:
+					if !ok {
+						return
+					}
+					set(float64(v))
+				}
+			}
+		},
+		close: stream.Close,
+	}
+}
+
+// FlagInput creates an input from a boolean stream, which is 1 while the
+// stream is true and 0 while it is false, for use with Is. The stream is
+// closed when the engine stops.
+func FlagInput(name string, stream *krpcgo.Stream[bool]) Input {
+	return StreamInput(name, krpcgo.MapStream(stream, func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}))
+}
+
+// Rule raises an alert when its condition has been met for long enough, and
+// clears it once the condition has stopped being met for as long.
+type Rule struct {
+	// Name is the name of the rule's alerts, e.g. "low charge".
+	Name string
+	// Source is what the rule is about, e.g. the vessel's name.
+	Source   string
+	Severity alert.Severity
+	// When is the condition that raises the alert.
+	When Condition
+	// While, if set, must also be met for the alert to be raised, e.g. to
+	// only check periapsis while coasting. The alert clears when it isn't.
+	While Condition
+	// For is how long the conditions must be met before the alert is
+	// raised, and stop being met before it clears, so that readings
+	// flickering around a threshold don't raise a flurry of alerts.
+	For time.Duration
+	// Message, if set, replaces the generated message of raised alerts.
+	Message string
+	// OnAlert, if set, is called with the rule's alerts.
+	OnAlert func(alert.Alert)
+}
+
+// met returns whether the rule's conditions are met.
+func (r *Rule) met(v Values) bool {
+	return r.When.Met(v) && (r.While == nil || r.While.Met(v))
+}
+
+// alert creates an alert for the rule.
+func (r *Rule) alert(v Values, now time.Time, cleared bool) alert.Alert {
+	a := alert.Alert{
+		Name:     r.Name,
+		Source:   r.Source,
+		Severity: r.Severity,
+		Time:     now,
+		Cleared:  cleared,
+	}
+	if t, ok := r.When.(Threshold); ok {
+		a.Value, a.Threshold = v[t.Input], t.Limit
+	}
+	prefix := ""
+	if r.Source != "" {
+		prefix = r.Source + ": "
+	}
+	switch {
+	case cleared:
+		a.Severity = alert.Info
+		a.Message = fmt.Sprintf("%s%s cleared", prefix, r.Name)
+	case r.Message != "":
+		a.Message = r.Message
+	default:
+		a.Message = fmt.Sprintf("%s%s (%s)", prefix, r.Name, r.When)
+	}
+	return a
+}
+
+// ruleState is a rule and whether its alert is raised.
+type ruleState struct {
+	rule   Rule
+	active bool
+	// changing is set while the rule's conditions differ from active, since
+	// the time in since.
+	changing bool
+	since    time.Time
+	// raised is when the alert was raised.
+	raised time.Time
+}
+
+// Config configures an Engine.
+type Config struct {
+	// Interval is how often rules are checked between new values, so that
+	// they are raised or cleared once their For has passed. Defaults to
+	// 100ms.
+	Interval time.Duration
+	// Bridge, if set, is sent every alert.
+	Bridge *alert.Bridge
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// Engine checks rules against the latest values of its inputs.
+type Engine struct {
+	cfg    Config
+	inputs []Input
+	now    func() time.Time
+	// OnAlert, if set, is called with every alert. Alerts are sent one at a
+	// time, so callbacks and sinks mustn't call Set.
+	OnAlert func(alert.Alert)
+
+	// checkMu keeps alerts in order by letting one check run at a time.
+	checkMu sync.Mutex
+	mu      sync.Mutex
+	rules   []*ruleState
+	values  Values
+}
+
+// New creates a new Engine reading the given inputs.
+func New(cfg Config, inputs ...Input) *Engine {
+	cfg.SetDefaults()
+	return &Engine{cfg: cfg, inputs: inputs, now: time.Now, values: Values{}}
+}
+
+// Add adds rules. They can be added while the engine is running.
+func (e *Engine) Add(rules ...Rule) error {
+	for _, r := range rules {
+		if r.Name == "" {
+			return tracerr.Errorf("Rule has no name")
+		}
+		if r.When == nil {
+			return tracerr.Errorf("Rule %q has no condition", r.Name)
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range rules {
+		e.rules = append(e.rules, &ruleState{rule: r})
+	}
+	return nil
+}
+
+// Set sets the value of an input and checks the rules. Inputs given to New
+// are set as their streams' values arrive, but other values can be set too.
+func (e *Engine) Set(input string, value float64) {
+	e.mu.Lock()
+	e.values[input] = value
+	e.mu.Unlock()
+	e.check()
+}
+
+// Active returns the alerts that are raised, in the order the rules were
+// added.
+func (e *Engine) Active() []alert.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var active []alert.Alert
+	for _, s := range e.rules {
+		if s.active {
+			active = append(active, s.rule.alert(e.values, s.raised, false))
+		}
+	}
+	return active
+}
+
+// pending is an alert to send for a rule.
+type pending struct {
+	rule  *Rule
+	alert alert.Alert
+}
+
+// check checks the rules and sends alerts for those that have changed.
+func (e *Engine) check() {
+	e.checkMu.Lock()
+	defer e.checkMu.Unlock()
+	now := e.now()
+	var alerts []pending
+	e.mu.Lock()
+	for _, s := range e.rules {
+		met := s.rule.met(e.values)
+		if met == s.active {
+			s.changing = false
+			continue
+		}
+		if !s.changing {
+			s.changing = true
+			s.since = now
+		}
+		if now.Sub(s.since) < s.rule.For {
+			continue
+		}
+		s.active = met
+		s.changing = false
+		s.raised = now
+		alerts = append(alerts, pending{&s.rule, s.rule.alert(e.values, now, !met)})
+	}
+	e.mu.Unlock()
+
+	for _, p := range alerts {
+		if p.rule.OnAlert != nil {
+			p.rule.OnAlert(p.alert)
+		}
+		if e.OnAlert != nil {
+			e.OnAlert(p.alert)
+		}
+		if e.cfg.Bridge != nil {
+			e.cfg.Bridge.Alert(p.alert)
+		}
+	}
+}
+
+// Run reads the inputs and checks the rules until the context is canceled,
+// then closes the inputs' streams.
+func (e *Engine) Run(ctx context.Context) error {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, in := range e.inputs {
+		in := in
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			in.listen(done, func(v float64) { e.Set(in.name, v) })
+		}()
+	}
+
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			close(done)
+			wg.Wait()
+			var err error
+			for _, in := range e.inputs {
+				if closeErr := in.close(); closeErr != nil && err == nil {
+					err = closeErr
+				}
+			}
+			return tracerr.Wrap(err)
+		case <-ticker.C:
+			e.check()
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestEngine creates an engine whose clock is moved by hand, and returns
+// the alerts it sends.
+func newTestEngine(t *testing.T, rules ...Rule) (*Engine, *time.Time, *[]alert.Alert) {
+	var bridge alert.Bridge
+	e := New(Config{Bridge: &bridge})
+	now := start
+	e.now = func() time.Time { return now }
+	var alerts []alert.Alert
+	bridge.Add(alert.SinkFunc(func(a alert.Alert) {
+		alerts = append(alerts, a)
+	}))
+	require.NoError(t, e.Add(rules...))
+	return e, &now, &alerts
+}
+
+func TestEngine(t *testing.T) {
+	var ruleAlerts, engineAlerts []string
+	e, now, alerts := newTestEngine(t, Rule{
+		Name:     "low charge",
+		Source:   "Kerbal X",
+		Severity: alert.Critical,
+		When:     Below("charge", 0.1),
+		OnAlert: func(a alert.Alert) {
+			ruleAlerts = append(ruleAlerts, a.Message)
+		},
+	})
+	e.OnAlert = func(a alert.Alert) {
+		engineAlerts = append(engineAlerts, a.Message)
+	}
+
+	e.Set("charge", 0.5)
+	require.Empty(t, *alerts)
+	e.Set("charge", 0.05)
+	require.Equal(t, []alert.Alert{{
+		Name:      "low charge",
+		Source:    "Kerbal X",
+		Severity:  alert.Critical,
+		Message:   "Kerbal X: low charge (charge < 0.1)",
+		Value:     0.05,
+		Threshold: 0.1,
+		Time:      start,
+	}}, *alerts)
+	require.Equal(t, *alerts, e.Active())
+
+	// Alerts are only sent when rules change.
+	*now = now.Add(time.Second)
+	e.Set("charge", 0.04)
+	require.Len(t, *alerts, 1)
+
+	e.Set("charge", 0.2)
+	require.Equal(t, alert.Alert{
+		Name:      "low charge",
+		Source:    "Kerbal X",
+		Severity:  alert.Info,
+		Message:   "Kerbal X: low charge cleared",
+		Value:     0.2,
+		Threshold: 0.1,
+		Time:      start.Add(time.Second),
+		Cleared:   true,
+	}, (*alerts)[1])
+	require.Empty(t, e.Active())
+
+	expected := []string{"Kerbal X: low charge (charge < 0.1)", "Kerbal X: low charge cleared"}
+	require.Equal(t, expected, ruleAlerts)
+	require.Equal(t, expected, engineAlerts)
+}
+
+func TestEngineDebounce(t *testing.T) {
+	e, now, alerts := newTestEngine(t, Rule{
+		Name:     "low periapsis",
+		Severity: alert.Warning,
+		When:     Below("periapsis", 70000),
+		While:    Not(Above("thrust", 0)),
+		For:      2 * time.Second,
+		Message:  "Periapsis is in the atmosphere",
+	})
+	messages := func() []string {
+		var m []string
+		for _, a := range *alerts {
+			m = append(m, a.Message)
+		}
+		return m
+	}
+	e.Set("thrust", 0)
+
+	// A brief dip doesn't raise the alert.
+	e.Set("periapsis", 65000)
+	*now = now.Add(time.Second)
+	e.Set("periapsis", 75000)
+	*now = now.Add(2 * time.Second)
+	e.check()
+	require.Empty(t, *alerts)
+
+	// A long one does, once its time has passed even without new values.
+	e.Set("periapsis", 65000)
+	*now = now.Add(time.Second)
+	e.check()
+	require.Empty(t, *alerts)
+	*now = now.Add(time.Second)
+	e.check()
+	require.Equal(t, []string{"Periapsis is in the atmosphere"}, messages())
+
+	// Burning clears it, but only after a while.
+	e.Set("thrust", 200)
+	*now = now.Add(time.Second)
+	e.Set("thrust", 0)
+	*now = now.Add(time.Second)
+	e.Set("thrust", 200)
+	require.Len(t, *alerts, 1)
+	*now = now.Add(2 * time.Second)
+	e.check()
+	require.Equal(t, []string{"Periapsis is in the atmosphere", "low periapsis cleared"}, messages())
+}
+
+func TestEngineAdd(t *testing.T) {
+	e := New(Config{})
+	require.Error(t, e.Add(Rule{When: Is("landed")}))
+	require.Error(t, e.Add(Rule{Name: "landed"}))
+	require.NoError(t, e.Add(Rule{Name: "landed", When: Is("landed")}))
+}
+
+func TestEngineRun(t *testing.T) {
+	altitude := krpctest.NewStream[float64]()
+	e := New(Config{Interval: 10 * time.Millisecond}, StreamInput("altitude", altitude.Stream))
+	raised := make(chan alert.Alert, 1)
+	e.OnAlert = func(a alert.Alert) { raised <- a }
+	require.NoError(t, e.Add(Rule{Name: "too low", Severity: alert.Warning, When: Below("altitude", 100), For: 20 * time.Millisecond}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- e.Run(ctx) }()
+	altitude.Emit(50)
+	select {
+	case a := <-raised:
+		require.Equal(t, "too low (altitude < 100)", a.Message)
+	case <-time.After(time.Second):
+		t.Fatal("no alert")
+	}
+	cancel()
+	require.NoError(t, <-done)
+	require.True(t, altitude.Closed())
+}
@@ -0,0 +1,210 @@
+package rules
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Trigger runs an action when a condition becomes met, e.g. deploying
+// antennas and solar panels once above 70km:
+//
+//	triggers.Add(rules.Trigger{
+//		Name: "deploy",
+//		When: rules.Above("altitude", 70000),
+//		Do: func() error {
+//			if err := control.SetAntennas(true); err != nil {
+//				return err
+//			}
+//			return control.SetSolarPanels(true)
+//		},
+//	})
+type Trigger struct {
+	// Name identifies the trigger, e.g. "deploy".
+	Name string
+	// When is the condition that runs the action.
+	When Condition
+	// Do is the action.
+	Do func() error
+	// Repeat makes the trigger run its action each time the condition
+	// becomes met, rather than only the first time.
+	Repeat bool
+}
+
+// triggerState is a trigger and what it has seen.
+type triggerState struct {
+	trigger Trigger
+	// met is whether the condition was met when last checked, so that
+	// actions only run when it becomes met.
+	met bool
+	// fired is set once a one-shot trigger's action has succeeded.
+	fired bool
+	// failed is set while the last run of the action failed.
+	failed bool
+}
+
+// Triggers runs triggers' actions as their conditions become met. Triggers
+// outlive connections: after reconnecting, call Run again with inputs from
+// the new connection, and one-shot triggers that have fired won't fire
+// again, while those whose actions failed are retried.
+type Triggers struct {
+	// OnError, if set, is called with the errors of failed actions.
+	OnError func(name string, err error)
+
+	// checkMu keeps actions in order by letting one check run at a time.
+	checkMu  sync.Mutex
+	mu       sync.Mutex
+	triggers []*triggerState
+	values   Values
+}
+
+// NewTriggers creates a new, empty Triggers.
+func NewTriggers() *Triggers {
+	return &Triggers{values: Values{}}
+}
+
+// Add adds triggers. They can be added while the triggers are running.
+func (t *Triggers) Add(triggers ...Trigger) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := map[string]bool{}
+	for _, s := range t.triggers {
+		names[s.trigger.Name] = true
+	}
+	for _, tr := range triggers {
+		switch {
+		case tr.Name == "":
+			return tracerr.Errorf("Trigger has no name")
+		case tr.When == nil:
+			return tracerr.Errorf("Trigger %q has no condition", tr.Name)
+		case tr.Do == nil:
+			return tracerr.Errorf("Trigger %q has no action", tr.Name)
+		case names[tr.Name]:
+			return tracerr.Errorf("Trigger %q already exists", tr.Name)
+		}
+		names[tr.Name] = true
+	}
+	for _, tr := range triggers {
+		t.triggers = append(t.triggers, &triggerState{trigger: tr})
+	}
+	return nil
+}
+
+// Remove removes a trigger, returning false if there is no such trigger.
+func (t *Triggers) Remove(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, s := range t.triggers {
+		if s.trigger.Name == name {
+			t.triggers = append(t.triggers[:i], t.triggers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Armed returns the names of the triggers that can still fire, in the order
+// they were added.
+func (t *Triggers) Armed() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var names []string
+	for _, s := range t.triggers {
+		if !s.fired {
+			names = append(names, s.trigger.Name)
+		}
+	}
+	return names
+}
+
+// Set sets the value of an input and checks the triggers. Inputs given to
+// Run are set as their streams' values arrive, but other values can be set
+// too.
+func (t *Triggers) Set(input string, value float64) {
+	t.mu.Lock()
+	t.values[input] = value
+	t.mu.Unlock()
+	t.check()
+}
+
+// ready returns whether all of a condition's inputs have values. A trigger
+// isn't checked until they do, so that missing values, e.g. just after
+// reconnecting, don't count as the condition not being met.
+func ready(cond Condition, v Values) bool {
+	for _, input := range inputs(cond) {
+		if _, ok := v[input]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// check checks the triggers and runs the actions of those that have become
+// met.
+func (t *Triggers) check() {
+	t.checkMu.Lock()
+	defer t.checkMu.Unlock()
+	var fire []*triggerState
+	t.mu.Lock()
+	for _, s := range t.triggers {
+		if s.fired || !ready(s.trigger.When, t.values) {
+			continue
+		}
+		met := s.trigger.When.Met(t.values)
+		if met && !s.met {
+			fire = append(fire, s)
+		}
+		s.met = met
+	}
+	t.mu.Unlock()
+
+	for _, s := range fire {
+		err := s.trigger.Do()
+		t.mu.Lock()
+		s.failed = err != nil
+		if err == nil && !s.trigger.Repeat {
+			s.fired = true
+		}
+		t.mu.Unlock()
+		if err != nil && t.OnError != nil {
+			t.OnError(s.trigger.Name, err)
+		}
+	}
+}
+
+// Run reads the inputs and checks the triggers until the context is
+// canceled, then closes the inputs' streams. Values from earlier runs are
+// forgotten, and triggers whose actions failed are checked afresh, so that
+// they are retried if their conditions are still met.
+func (t *Triggers) Run(ctx context.Context, inputs ...Input) error {
+	t.mu.Lock()
+	t.values = Values{}
+	for _, s := range t.triggers {
+		if s.failed {
+			s.met = false
+		}
+	}
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, in := range inputs {
+		in := in
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			in.listen(done, func(v float64) { t.Set(in.name, v) })
+		}()
+	}
+	<-ctx.Done()
+	close(done)
+	wg.Wait()
+	var err error
+	for _, in := range inputs {
+		if closeErr := in.close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggers(t *testing.T) {
+	triggers := NewTriggers()
+	var deployed, staged int
+	require.NoError(t, triggers.Add(Trigger{
+		Name: "deploy",
+		When: Above("altitude", 70000),
+		Do:   func() error { deployed++; return nil },
+	}, Trigger{
+		Name:   "stage",
+		When:   Below("thrust", 1),
+		Do:     func() error { staged++; return nil },
+		Repeat: true,
+	}))
+
+	triggers.Set("altitude", 80000)
+	require.Equal(t, 1, deployed)
+	triggers.Set("altitude", 60000)
+	triggers.Set("altitude", 80000)
+	require.Equal(t, 1, deployed)
+	require.Equal(t, []string{"stage"}, triggers.Armed())
+
+	triggers.Set("thrust", 0)
+	triggers.Set("thrust", 0)
+	require.Equal(t, 1, staged)
+	triggers.Set("thrust", 10)
+	triggers.Set("thrust", 0)
+	require.Equal(t, 2, staged)
+
+	require.True(t, triggers.Remove("stage"))
+	require.False(t, triggers.Remove("stage"))
+	triggers.Set("thrust", 10)
+	triggers.Set("thrust", 0)
+	require.Equal(t, 2, staged)
+}
+
+func TestTriggersAdd(t *testing.T) {
+	triggers := NewTriggers()
+	do := func() error { return nil }
+	require.Error(t, triggers.Add(Trigger{When: Is("landed"), Do: do}))
+	require.Error(t, triggers.Add(Trigger{Name: "landed", Do: do}))
+	require.Error(t, triggers.Add(Trigger{Name: "landed", When: Is("landed")}))
+	require.NoError(t, triggers.Add(Trigger{Name: "landed", When: Is("landed"), Do: do}))
+	require.Error(t, triggers.Add(Trigger{Name: "landed", When: Is("landed"), Do: do}))
+}
+
+func TestTriggersReconnect(t *testing.T) {
+	triggers := NewTriggers()
+	var errs []string
+	triggers.OnError = func(name string, err error) { errs = append(errs, name) }
+	fired := make(chan string, 10)
+	var fail bool
+	require.NoError(t, triggers.Add(Trigger{
+		Name: "deploy",
+		When: Above("altitude", 70000),
+		Do:   func() error { fired <- "deploy"; return nil },
+	}, Trigger{
+		Name: "chutes",
+		When: Below("altitude", 5000),
+		Do: func() error {
+			fired <- "chutes"
+			if fail {
+				return errors.New("connection lost")
+			}
+			return nil
+		},
+	}, Trigger{
+		Name:   "warn",
+		When:   Above("altitude", 0),
+		Do:     func() error { fired <- "warn"; return nil },
+		Repeat: true,
+	}))
+	receive := func() string {
+		select {
+		case name := <-fired:
+			return name
+		case <-time.After(time.Second):
+			t.Fatal("no trigger fired")
+			return ""
+		}
+	}
+
+	run := func(values ...float64) {
+		altitude := krpctest.NewStream[float64]()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- triggers.Run(ctx, StreamInput("altitude", altitude.Stream)) }()
+		require.True(t, altitude.Emit(values...))
+		require.True(t, altitude.Emit(values[len(values)-1]))
+		cancel()
+		require.NoError(t, <-done)
+		require.True(t, altitude.Closed())
+	}
+
+	run(80000)
+	require.Equal(t, "deploy", receive())
+	require.Equal(t, "warn", receive())
+
+	// The one-shot trigger doesn't fire again, and the repeating trigger
+	// remembers its condition was met.
+	fail = true
+	run(80000, 4000)
+	require.Equal(t, "chutes", receive())
+	require.Equal(t, []string{"chutes"}, errs)
+
+	// The failed trigger is retried after reconnecting.
+	fail = false
+	run(4000)
+	require.Equal(t, "chutes", receive())
+	require.Empty(t, fired)
+	require.Equal(t, []string{"warn"}, triggers.Armed())
+}
@@ -0,0 +1,247 @@
+// Package scheduler runs Go functions at moments in game time, such as the
+// start of a burn, keeping to the universal time through time warp and
+// pauses.
+//
+//	c, err := clock.New(client, clock.Config{})
+//	s := scheduler.New(c, scheduler.Config{})
+//	s.At(burnUT-5, func(ut float64) { ... })
+//	s.Every(60, func(ut float64) { ... })
+//
+// Unlike package alarms, nothing is created in the game, so the game won't
+// stop warping for a scheduled function; stop warping in good time before
+// anything that needs precision.
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the scheduler the game's time. It is implemented by
+// *clock.Clock.
+type Clock interface {
+	// UT returns the estimated current UT.
+	UT() float64
+	// Until returns the estimated wall-clock time until a UT, or false if it
+	// can't be estimated.
+	Until(ut float64) (time.Duration, bool)
+	// Changed returns a channel that is closed when the estimate changes.
+	Changed() <-chan struct{}
+}
+
+// Config configures a Scheduler.
+type Config struct {
+	// MaxWait is the longest the scheduler sleeps before checking the UT
+	// again, which bounds how late a function runs if the game slows down
+	// or is paused without the clock noticing. Defaults to 1s.
+	MaxWait time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.MaxWait == 0 {
+		cfg.MaxWait = time.Second
+	}
+}
+
+// Task is a function scheduled to run at a UT.
+type Task struct {
+	fn func(ut float64)
+	// interval is the UT between runs of a repeating task, or zero.
+	interval float64
+
+	// mu is the scheduler's lock, which guards ut and done.
+	mu   *sync.Mutex
+	ut   float64
+	done bool
+}
+
+// Next returns the UT the task next runs at.
+func (t *Task) Next() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ut
+}
+
+// Done reports whether the task won't run again, because it ran once or
+// was canceled.
+func (t *Task) Done() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.done
+}
+
+// Cancel stops the task from running again. A run already in progress
+// finishes.
+func (t *Task) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = true
+}
+
+// Scheduler runs functions at universal times. Functions run on the
+// scheduler's goroutine, one at a time, so a slow one delays the rest.
+type Scheduler struct {
+	cfg   Config
+	clock Clock
+
+	mu    sync.Mutex
+	tasks []*Task
+	// wake tells the scheduler's goroutine that a task was added.
+	wake chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// New creates a new Scheduler that keeps time with a clock.
+func New(clock Clock, cfg Config) *Scheduler {
+	cfg.SetDefaults()
+	s := &Scheduler{
+		cfg:   cfg,
+		clock: clock,
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// add schedules a task.
+func (s *Scheduler) add(t *Task) *Task {
+	s.mu.Lock()
+	t.mu = &s.mu
+	s.tasks = append(s.tasks, t)
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return t
+}
+
+// At runs fn once the UT reaches ut, passing it the UT it ran at. If ut has
+// passed, fn runs straight away.
+func (s *Scheduler) At(ut float64, fn func(ut float64)) *Task {
+	return s.add(&Task{fn: fn, ut: ut})
+}
+
+// Every runs fn every interval seconds of UT, starting interval from now,
+// passing it the UT it ran at. Runs that are missed, e.g. while warping past
+// several intervals at once, are skipped rather than made up, so fn runs
+// once and then again at the next interval after that. It does nothing if
+// interval isn't positive.
+func (s *Scheduler) Every(interval float64, fn func(ut float64)) *Task {
+	t := &Task{fn: fn, interval: interval, ut: s.clock.UT() + interval}
+	if interval <= 0 || math.IsNaN(interval) {
+		t.done = true
+		t.mu = &s.mu
+		return t
+	}
+	return s.add(t)
+}
+
+// Pending returns the number of tasks that will run again.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, t := range s.tasks {
+		if !t.done {
+			n++
+		}
+	}
+	return n
+}
+
+// due returns the tasks due at ut in the order they're due, moving
+// repeating tasks on to their next run and dropping finished ones, and the
+// UT the next task is due at, or +Inf if there are none. The lock must be
+// held.
+func (s *Scheduler) due(ut float64) ([]*Task, float64) {
+	var due []*Task
+	for _, t := range s.tasks {
+		if !t.done && t.ut <= ut {
+			due = append(due, t)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].ut < due[j].ut
+	})
+	for _, t := range due {
+		if t.interval == 0 {
+			t.done = true
+			continue
+		}
+		// Skip to the first run after ut.
+		t.ut += t.interval * math.Max(1, math.Ceil((ut-t.ut)/t.interval))
+		if t.ut <= ut {
+			t.ut += t.interval
+		}
+	}
+	next := math.Inf(1)
+	pending := s.tasks[:0]
+	for _, t := range s.tasks {
+		if !t.done {
+			pending = append(pending, t)
+			next = math.Min(next, t.ut)
+		}
+	}
+	for i := len(pending); i < len(s.tasks); i++ {
+		s.tasks[i] = nil
+	}
+	s.tasks = pending
+	return due, next
+}
+
+func (s *Scheduler) run() {
+	for {
+		// Take the clock's change channel before reading the UT, so a change
+		// in between isn't missed.
+		changed := s.clock.Changed()
+		ut := s.clock.UT()
+		s.mu.Lock()
+		due, next := s.due(ut)
+		s.mu.Unlock()
+		for _, t := range due {
+			t.fn(ut)
+		}
+		if len(due) > 0 {
+			continue
+		}
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if !math.IsInf(next, 1) {
+			wait := s.cfg.MaxWait
+			if d, ok := s.clock.Until(next); ok && d < wait {
+				wait = d
+			}
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+		select {
+		case <-s.done:
+		case <-s.wake:
+		case <-changed:
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+	}
+}
+
+// Close stops the scheduler. Tasks that haven't run won't.
+func (s *Scheduler) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
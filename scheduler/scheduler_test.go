@@ -0,0 +1,190 @@
+package scheduler
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/clock"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Clock = (*clock.Clock)(nil)
+
+// fakeClock is a clock whose UT runs at a set rate from when it was set.
+type fakeClock struct {
+	mu      sync.Mutex
+	ut      float64
+	since   time.Time
+	rate    float64
+	changed chan struct{}
+}
+
+func newFakeClock(ut, rate float64) *fakeClock {
+	return &fakeClock{ut: ut, since: time.Now(), rate: rate, changed: make(chan struct{})}
+}
+
+func (c *fakeClock) now() float64 {
+	return c.ut + c.rate*time.Since(c.since).Seconds()
+}
+
+func (c *fakeClock) UT() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now()
+}
+
+func (c *fakeClock) Until(ut float64) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rate == 0 {
+		return 0, false
+	}
+	return time.Duration((ut - c.now()) / c.rate * float64(time.Second)), true
+}
+
+func (c *fakeClock) Changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.changed
+}
+
+// setRate changes the rate, as warping or pausing would.
+func (c *fakeClock) setRate(rate float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ut = c.now()
+	c.since = time.Now()
+	c.rate = rate
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func TestDue(t *testing.T) {
+	var mu sync.Mutex
+	s := &Scheduler{}
+	a := &Task{ut: 30, mu: &mu}
+	b := &Task{ut: 10, mu: &mu}
+	every := &Task{ut: 20, interval: 10, mu: &mu}
+	canceled := &Task{ut: 5, mu: &mu, done: true}
+	s.tasks = []*Task{a, b, every, canceled}
+
+	due, next := s.due(5)
+	require.Empty(t, due)
+	require.Equal(t, 10.0, next)
+	require.Len(t, s.tasks, 3)
+
+	due, next = s.due(30)
+	require.Equal(t, []*Task{b, every, a}, due)
+	require.Equal(t, 40.0, next)
+	require.True(t, a.done)
+	require.True(t, b.done)
+	require.False(t, every.done)
+	require.Equal(t, []*Task{every}, s.tasks)
+
+	// Missed runs are skipped.
+	due, next = s.due(75)
+	require.Equal(t, []*Task{every}, due)
+	require.Equal(t, 80.0, next)
+
+	due, next = s.due(79)
+	require.Empty(t, due)
+	require.Equal(t, 80.0, next)
+
+	every.Cancel()
+	due, next = s.due(100)
+	require.Empty(t, due)
+	require.True(t, math.IsInf(next, 1))
+	require.Empty(t, s.tasks)
+}
+
+func TestAt(t *testing.T) {
+	// A second of UT passes every millisecond.
+	c := newFakeClock(1000, 1000)
+	s := New(c, Config{})
+	defer s.Close()
+
+	ran := make(chan float64, 2)
+	later := s.At(1100, func(ut float64) { ran <- ut })
+	s.At(1050, func(ut float64) { ran <- ut })
+	past := s.At(0, func(ut float64) { ran <- ut })
+	require.Equal(t, 1100.0, later.Next())
+
+	for _, expected := range []float64{1000, 1050, 1100} {
+		select {
+		case ut := <-ran:
+			require.GreaterOrEqual(t, ut, expected)
+		case <-time.After(time.Second):
+			require.FailNow(t, "task didn't run", "expected a run at %v", expected)
+		}
+	}
+	require.True(t, past.Done())
+	require.True(t, later.Done())
+	require.Zero(t, s.Pending())
+}
+
+func TestAtWarp(t *testing.T) {
+	// At this rate the task would take an hour.
+	c := newFakeClock(0, 1)
+	s := New(c, Config{MaxWait: time.Hour})
+	defer s.Close()
+
+	ran := make(chan float64, 1)
+	s.At(3600, func(ut float64) { ran <- ut })
+	time.Sleep(10 * time.Millisecond)
+	c.setRate(100000)
+
+	select {
+	case ut := <-ran:
+		require.GreaterOrEqual(t, ut, 3600.0)
+	case <-time.After(time.Second):
+		require.FailNow(t, "task didn't run after warping")
+	}
+}
+
+func TestAtPaused(t *testing.T) {
+	c := newFakeClock(0, 0)
+	s := New(c, Config{MaxWait: 10 * time.Millisecond})
+	defer s.Close()
+
+	ran := make(chan float64, 1)
+	s.At(1, func(ut float64) { ran <- ut })
+	select {
+	case <-ran:
+		require.FailNow(t, "task ran while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.setRate(1000)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		require.FailNow(t, "task didn't run after resuming")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	c := newFakeClock(0, 1000)
+	s := New(c, Config{})
+	defer s.Close()
+
+	ran := make(chan float64, 10)
+	task := s.Every(10, func(ut float64) { ran <- ut })
+	var uts []float64
+	for len(uts) < 3 {
+		select {
+		case ut := <-ran:
+			uts = append(uts, ut)
+		case <-time.After(time.Second):
+			require.FailNow(t, "task didn't repeat")
+		}
+	}
+	task.Cancel()
+	for i := 1; i < len(uts); i++ {
+		require.Greater(t, uts[i], uts[i-1])
+	}
+	require.GreaterOrEqual(t, uts[0], 10.0)
+
+	require.True(t, s.Every(0, func(float64) {}).Done())
+}
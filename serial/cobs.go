@@ -0,0 +1,106 @@
+package serial
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// cobsEncode encodes data with consistent overhead byte stuffing, so that
+// it has no zero bytes. The frame delimiter isn't included.
+func cobsEncode(data []byte) []byte {
+	out := make([]byte, 1, len(data)+len(data)/254+2)
+	code, codeAt := byte(1), 0
+	for _, b := range data {
+		if b != 0 {
+			out = append(out, b)
+			code++
+		}
+		if b == 0 || code == 0xff {
+			out[codeAt] = code
+			codeAt = len(out)
+			out = append(out, 0)
+			code = 1
+		}
+	}
+	out[codeAt] = code
+	return out
+}
+
+// cobsDecode decodes a frame encoded by cobsEncode, without its delimiter.
+func cobsDecode(frame []byte) ([]byte, error) {
+	out := make([]byte, 0, len(frame))
+	for i := 0; i < len(frame); {
+		code := int(frame[i])
+		if code == 0 || i+code > len(frame) {
+			return nil, tracerr.Errorf("Invalid COBS frame")
+		}
+		out = append(out, frame[i+1:i+code]...)
+		i += code
+		if code < 0xff && i < len(frame) {
+			out = append(out, 0)
+		}
+	}
+	return out, nil
+}
+
+// cobsConn translates between the length-prefixed messages the client
+// sends and receives and COBS frames on a serial port.
+type cobsConn struct {
+	port io.ReadWriteCloser
+	r    *bufio.Reader
+	// written is what has been written of the next message to send.
+	written []byte
+	// unread is what hasn't been read of the last message received.
+	unread []byte
+}
+
+func newCOBSConn(port io.ReadWriteCloser) *cobsConn {
+	return &cobsConn{port: port, r: bufio.NewReader(port)}
+}
+
+// Write sends each complete message in what has been written as a frame.
+func (c *cobsConn) Write(p []byte) (int, error) {
+	c.written = append(c.written, p...)
+	for {
+		length, n := proto.DecodeVarint(c.written)
+		if n == 0 || uint64(len(c.written)-n) < length {
+			return len(p), nil
+		}
+		end := n + int(length)
+		frame := append(cobsEncode(c.written[n:end]), 0)
+		c.written = c.written[end:]
+		if _, err := c.port.Write(frame); err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+	}
+}
+
+// Read reads from the next frame received, prefixed with its length.
+func (c *cobsConn) Read(p []byte) (int, error) {
+	for len(c.unread) == 0 {
+		frame, err := c.r.ReadBytes(0)
+		if err != nil {
+			return 0, err
+		}
+		// Skip empty frames, which some senders use to resynchronize.
+		if len(frame) == 1 {
+			continue
+		}
+		msg, err := cobsDecode(frame[:len(frame)-1])
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		c.unread = append(proto.EncodeVarint(uint64(len(msg))), msg...)
+	}
+	n := copy(p, c.unread)
+	c.unread = c.unread[n:]
+	return n, nil
+}
+
+// Close closes the serial port.
+func (c *cobsConn) Close() error {
+	return tracerr.Wrap(c.port.Close())
+}
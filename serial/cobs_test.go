@@ -0,0 +1,70 @@
+package serial
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOBS(t *testing.T) {
+	long := bytes.Repeat([]byte{7}, 600)
+	tests := []struct {
+		name     string
+		data     []byte
+		expected []byte
+	}{
+		{name: "empty", data: []byte{}, expected: []byte{1}},
+		{name: "zero", data: []byte{0}, expected: []byte{1, 1}},
+		{name: "zeros", data: []byte{0, 0}, expected: []byte{1, 1, 1}},
+		{name: "mixed", data: []byte{0x11, 0x22, 0, 0x33}, expected: []byte{3, 0x11, 0x22, 2, 0x33}},
+		{name: "trailing zero", data: []byte{0x11, 0}, expected: []byte{2, 0x11, 1}},
+		{name: "long", data: long},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := cobsEncode(tc.data)
+			require.NotContains(t, encoded, byte(0))
+			if tc.expected != nil {
+				require.Equal(t, tc.expected, encoded)
+			}
+			decoded, err := cobsDecode(encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.data, decoded)
+		})
+	}
+
+	_, err := cobsDecode([]byte{5, 1})
+	require.Error(t, err)
+}
+
+func TestCOBSConn(t *testing.T) {
+	a, b := net.Pipe()
+	conn := newCOBSConn(a)
+	defer conn.Close()
+
+	// A message written in pieces is sent as one frame.
+	msg := []byte{1, 0, 2}
+	go func() {
+		_, _ = conn.Write(proto.EncodeVarint(uint64(len(msg))))
+		_, _ = conn.Write(msg[:1])
+		_, _ = conn.Write(msg[1:])
+	}()
+	frame := make([]byte, 5)
+	_, err := io.ReadFull(b, frame)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2, 1, 2, 2, 0}, frame)
+
+	// Frames received are read as length-prefixed messages, skipping empty
+	// frames.
+	go func() {
+		_, _ = b.Write([]byte{0, 2, 1, 2, 2, 0})
+	}()
+	received := make([]byte, 4)
+	_, err = io.ReadFull(conn, received)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 1, 0, 2}, received)
+}
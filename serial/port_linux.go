@@ -0,0 +1,63 @@
+//go:build linux && (amd64 || arm64 || 386 || arm)
+
+package serial
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/ztrue/tracerr"
+)
+
+// cbaud masks the speed bits of the control flags. Package syscall doesn't
+// define it.
+const cbaud = 0x100f
+
+// baudRates are the termios speeds for the supported baud rates.
+var baudRates = map[int]uint32{
+	1200:   syscall.B1200,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+	230400: syscall.B230400,
+	460800: syscall.B460800,
+	921600: syscall.B921600,
+}
+
+// openPort opens a serial port in raw mode, with 8 data bits, no parity and
+// one stop bit, as the SerialIO server uses.
+func openPort(device string, baud int) (io.ReadWriteCloser, error) {
+	speed, ok := baudRates[baud]
+	if !ok {
+		return nil, tracerr.Errorf("Unsupported baud rate %v", baud)
+	}
+	f, err := os.OpenFile(device, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var t syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		f.Close()
+		return nil, tracerr.Errorf("%v isn't a serial port: %v", device, errno)
+	}
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON | syscall.IXOFF
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB | syscall.CSTOPB | cbaud
+	t.Cflag |= syscall.CS8 | syscall.CREAD | syscall.CLOCAL | speed
+	t.Ispeed, t.Ospeed = speed, speed
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		f.Close()
+		return nil, tracerr.Wrap(errno)
+	}
+	return f, nil
+}
@@ -0,0 +1,16 @@
+//go:build !linux || !(amd64 || arm64 || 386 || arm)
+
+package serial
+
+import (
+	"io"
+	"runtime"
+
+	"github.com/ztrue/tracerr"
+)
+
+// openPort opens a serial port. It is only supported on Linux, on the
+// architectures whose termios layout and ioctls port_linux.go knows.
+func openPort(device string, baud int) (io.ReadWriteCloser, error) {
+	return nil, tracerr.Errorf("Serial ports aren't supported on %v/%v", runtime.GOOS, runtime.GOARCH)
+}
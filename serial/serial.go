@@ -0,0 +1,89 @@
+// Package serial connects clients to kRPC's SerialIO server, which serves
+// RPCs over a serial port, e.g. for flight controllers built on
+// microcontrollers:
+//
+//	client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{
+//		Transport: serial.NewTransport(serial.Config{Device: "/dev/ttyUSB0"}),
+//	})
+//
+// The SerialIO server has no stream server, so clients using it can't use
+// streams.
+package serial
+
+import (
+	"context"
+	"io"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// Framing is how messages are delimited on the serial line.
+type Framing int
+
+const (
+	// FramingLengthPrefixed prefixes each message with its length as a
+	// varint, as kRPC's own servers do.
+	FramingLengthPrefixed Framing = iota
+	// FramingCOBS encodes each message with consistent overhead byte
+	// stuffing and ends it with a zero byte, for links that need to find
+	// the start of the next message after losing bytes.
+	FramingCOBS
+)
+
+// Config is the config for a serial transport.
+type Config struct {
+	// Device is the serial port's device, e.g. "/dev/ttyUSB0".
+	Device string
+	// Baud is the baud rate, which must match the server's. Defaults to
+	// 9600, the SerialIO server's default.
+	Baud int
+	// Framing is how messages are delimited. Defaults to
+	// FramingLengthPrefixed.
+	Framing Framing
+}
+
+// SetDefaults sets the config defaults.
+func (cfg *Config) SetDefaults() {
+	if cfg.Baud == 0 {
+		cfg.Baud = 9600
+	}
+}
+
+// Transport is a krpcgo.Transport over a serial port.
+type Transport struct {
+	cfg Config
+	// open opens the serial port.
+	open func(device string, baud int) (io.ReadWriteCloser, error)
+}
+
+// NewTransport creates a serial transport.
+func NewTransport(cfg Config) *Transport {
+	cfg.SetDefaults()
+	return &Transport{cfg: cfg, open: openPort}
+}
+
+// DialRPC opens the serial port. The client's host and ports are ignored.
+func (t *Transport) DialRPC(context.Context, krpcgo.KRPCClientConfig) (io.ReadWriteCloser, error) {
+	if t.cfg.Device == "" {
+		return nil, tracerr.Errorf("No serial device given")
+	}
+	port, err := t.open(t.cfg.Device, t.cfg.Baud)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	switch t.cfg.Framing {
+	case FramingLengthPrefixed:
+		return port, nil
+	case FramingCOBS:
+		return newCOBSConn(port), nil
+	}
+	port.Close()
+	return nil, tracerr.Errorf("Unknown framing %v", t.cfg.Framing)
+}
+
+// DialStream returns krpcgo.ErrNoStreamServer, as the SerialIO server has
+// no stream server.
+func (t *Transport) DialStream(context.Context, krpcgo.KRPCClientConfig) (io.ReadWriteCloser, error) {
+	return nil, tracerr.Wrap(krpcgo.ErrNoStreamServer)
+}
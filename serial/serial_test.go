@@ -0,0 +1,61 @@
+package serial
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+
+	for _, framing := range []Framing{FramingLengthPrefixed, FramingCOBS} {
+		transport := NewTransport(Config{Device: "/dev/ttyTEST", Framing: framing})
+		require.Equal(t, 9600, transport.cfg.Baud)
+		// The serial port is stood in for by a pipe to the server's RPC
+		// port, translating frames if needed.
+		transport.open = func(device string, baud int) (io.ReadWriteCloser, error) {
+			require.Equal(t, "/dev/ttyTEST", device)
+			require.Equal(t, 9600, baud)
+			upstream, err := net.Dial("tcp", net.JoinHostPort(cfg.Host, cfg.RPCPort))
+			if err != nil {
+				return nil, err
+			}
+			port, line := net.Pipe()
+			var serverSide io.ReadWriteCloser = line
+			if framing == FramingCOBS {
+				serverSide = newCOBSConn(line)
+			}
+			go func() {
+				_, _ = io.Copy(upstream, serverSide)
+				upstream.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(serverSide, upstream)
+				serverSide.Close()
+			}()
+			return port, nil
+		}
+
+		client := krpcgo.NewKRPCClient(krpcgo.KRPCClientConfig{Transport: transport})
+		require.NoError(t, client.Connect(context.Background()))
+		require.False(t, client.StreamsAvailable())
+		ut, err := spacecenter.New(client).UT()
+		require.NoError(t, err)
+		require.Equal(t, 1000.0, ut)
+		require.NoError(t, client.Close())
+	}
+
+	_, err := NewTransport(Config{}).DialRPC(context.Background(), krpcgo.KRPCClientConfig{})
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// WriteCSV writes snapshots as CSV, one row each, with a column for every
+// value in any of them. Values a snapshot doesn't have are left empty.
+func WriteCSV(w io.Writer, snapshots []*Snapshot) error {
+	seen := map[string]bool{}
+	var names []string
+	for _, s := range snapshots {
+		for name := range s.Values {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	cw := csv.NewWriter(w)
+	header := append([]string{"label", "time", "ut", "vessel", "situation", "body"}, names...)
+	if err := cw.Write(header); err != nil {
+		return tracerr.Wrap(err)
+	}
+	for _, s := range snapshots {
+		row := []string{
+			s.Label,
+			s.Time.Format(time.RFC3339Nano),
+			strconv.FormatFloat(s.UT, 'f', -1, 64),
+			s.Vessel,
+			s.Situation,
+			s.Body,
+		}
+		for _, name := range names {
+			v, ok := s.Values[name]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		if err := cw.Write(row); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	cw.Flush()
+	return tracerr.Wrap(cw.Error())
+}
@@ -0,0 +1,22 @@
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, []*Snapshot{
+		{Label: "launch", Time: at, UT: 100, Vessel: "Kerbal X", Situation: "pre_launch", Body: "Kerbin", Values: map[string]float64{"altitude": 70.5, "resources.LiquidFuel": 360}},
+		{Label: "orbit, at last", Time: at.Add(time.Minute), UT: 160.25, Vessel: "Kerbal X", Situation: "orbiting", Body: "Kerbin", Values: map[string]float64{"altitude": 80000, "orbit.apoapsis": 81000}},
+	}))
+	require.Equal(t, `label,time,ut,vessel,situation,body,altitude,orbit.apoapsis,resources.LiquidFuel
+launch,2024-01-01T12:00:00Z,100,Kerbal X,pre_launch,Kerbin,70.5,,360
+"orbit, at last",2024-01-01T12:01:00Z,160.25,Kerbal X,orbiting,Kerbin,80000,81000,
+`, buf.String())
+}
@@ -0,0 +1,310 @@
+// Package snapshot captures a broad set of a vessel's values at once, such
+// as its altitude, orbit, resources and control state, for logging mission
+// milestones without recording telemetry the whole way.
+//
+//	snap, err := snapshot.Capture(vessel)
+//	snap.Label = "orbit reached"
+//	err = snap.WriteJSON(os.Stdout)
+package snapshot
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// Snapshot is a vessel's values at one moment.
+type Snapshot struct {
+	// Label, if set by the caller, names the moment, e.g. "orbit reached".
+	Label     string    `json:"label,omitempty"`
+	Time      time.Time `json:"time"`
+	UT        float64   `json:"ut"`
+	Vessel    string    `json:"vessel"`
+	Situation string    `json:"situation"`
+	// Body is the body the vessel is orbiting.
+	Body string `json:"body"`
+	// Values are the vessel's values, named like dashboard.VesselChannels'
+	// channels, e.g. "altitude", "orbit.apoapsis" and
+	// "resources.LiquidFuel". Values that aren't numbers are left out.
+	// Control flags, such as "control.sas", are 1 when set and 0 when not.
+	Values map[string]float64 `json:"values"`
+	// Errors holds why values couldn't be read, by name, e.g. for values
+	// that aren't available in the current scene.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Names returns the names of the snapshot's values, sorted.
+func (s *Snapshot) Names() []string {
+	names := make([]string, 0, len(s.Values))
+	for name := range s.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteJSON writes the snapshot as a line of JSON, so that snapshots can be
+// appended to the same log.
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	_, err = w.Write(append(b, '\n'))
+	return tracerr.Wrap(err)
+}
+
+// situationNames are the names of vessel situations in snapshots.
+var situationNames = map[spacecenter.VesselSituation]string{
+	spacecenter.VesselSituation_PreLaunch:  "pre_launch",
+	spacecenter.VesselSituation_Orbiting:   "orbiting",
+	spacecenter.VesselSituation_SubOrbital: "sub_orbital",
+	spacecenter.VesselSituation_Escaping:   "escaping",
+	spacecenter.VesselSituation_Flying:     "flying",
+	spacecenter.VesselSituation_Landed:     "landed",
+	spacecenter.VesselSituation_Splashed:   "splashed",
+	spacecenter.VesselSituation_Docked:     "docked",
+}
+
+// value is a number read by a capture.
+type value struct {
+	name string
+	call *types.ProcedureCall
+	// scale converts the value, e.g. from radians to degrees. Zero leaves
+	// it unchanged.
+	scale float64
+	// single is set for values sent as float32.
+	single bool
+	// flag is set for values sent as bools, which are 1 when true and 0
+	// when false.
+	flag bool
+}
+
+// decode decodes a value's result.
+func (v value) decode(b []byte) (float64, error) {
+	var f float64
+	switch {
+	case v.flag:
+		var set bool
+		if err := encode.Unmarshal(b, &set); err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		if set {
+			f = 1
+		}
+	case v.single:
+		var f32 float32
+		if err := encode.Unmarshal(b, &f32); err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		f = float64(f32)
+	default:
+		if err := encode.Unmarshal(b, &f); err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+	}
+	if v.scale != 0 {
+		f *= v.scale
+	}
+	return f, nil
+}
+
+// calls builds procedure calls, keeping the first error encoding their
+// arguments.
+type calls struct {
+	err error
+}
+
+// call makes a procedure call.
+func (b *calls) call(procedure string, args ...interface{}) *types.ProcedureCall {
+	c := &types.ProcedureCall{Service: "SpaceCenter", Procedure: procedure}
+	for i, arg := range args {
+		argBytes, err := encode.Marshal(arg)
+		if err != nil && b.err == nil {
+			b.err = err
+		}
+		c.Arguments = append(c.Arguments, &types.Argument{Position: uint32(i), Value: argBytes})
+	}
+	return c
+}
+
+// Capturer captures snapshots of a vessel, each in a single round trip to
+// the server. It finds the vessel's orbit, flight and resources when it is
+// created, so create a new one after the vessel changes sphere of
+// influence, docks or stages away resources.
+type Capturer struct {
+	client *krpcgo.KRPCClient
+	now    func() time.Time
+	// ut is the call for the UT, strings the calls for the vessel's name,
+	// situation and body, in that order, and values the calls for its
+	// numbers.
+	ut      *types.ProcedureCall
+	strings []*types.ProcedureCall
+	values  []value
+}
+
+// NewCapturer creates a new Capturer for a vessel.
+func NewCapturer(vessel *spacecenter.Vessel) (*Capturer, error) {
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rf, err := body.ReferenceFrame()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	flight, err := vessel.Flight(rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	resources, err := vessel.Resources()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	control, err := vessel.Control()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names, err := resources.Names()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	var b calls
+	c := &Capturer{
+		client: vessel.Client,
+		now:    time.Now,
+		ut:     b.call("get_UT"),
+		strings: []*types.ProcedureCall{
+			b.call("Vessel_get_Name", vessel),
+			b.call("Vessel_get_Situation", vessel),
+			b.call("CelestialBody_get_Name", body),
+		},
+	}
+	add := func(name string, object interface{}, class, property string, single bool) {
+		c.values = append(c.values, value{name: name, call: b.call(class+"_get_"+property, object), single: single})
+	}
+	add("met", vessel, "Vessel", "MET", false)
+	add("mass", vessel, "Vessel", "Mass", true)
+	add("dry_mass", vessel, "Vessel", "DryMass", true)
+	add("thrust", vessel, "Vessel", "Thrust", true)
+	add("available_thrust", vessel, "Vessel", "AvailableThrust", true)
+	add("specific_impulse", vessel, "Vessel", "SpecificImpulse", true)
+	add("altitude", flight, "Flight", "MeanAltitude", false)
+	add("surface_altitude", flight, "Flight", "SurfaceAltitude", false)
+	add("speed", flight, "Flight", "Speed", false)
+	add("vertical_speed", flight, "Flight", "VerticalSpeed", false)
+	add("horizontal_speed", flight, "Flight", "HorizontalSpeed", false)
+	add("latitude", flight, "Flight", "Latitude", false)
+	add("longitude", flight, "Flight", "Longitude", false)
+	add("g_force", flight, "Flight", "GForce", true)
+	add("dynamic_pressure", flight, "Flight", "DynamicPressure", true)
+	add("orbit.apoapsis", orbit, "Orbit", "ApoapsisAltitude", false)
+	add("orbit.periapsis", orbit, "Orbit", "PeriapsisAltitude", false)
+	add("orbit.time_to_apoapsis", orbit, "Orbit", "TimeToApoapsis", false)
+	add("orbit.time_to_periapsis", orbit, "Orbit", "TimeToPeriapsis", false)
+	add("orbit.period", orbit, "Orbit", "Period", false)
+	add("orbit.eccentricity", orbit, "Orbit", "Eccentricity", false)
+	add("orbit.semi_major_axis", orbit, "Orbit", "SemiMajorAxis", false)
+	add("orbit.speed", orbit, "Orbit", "Speed", false)
+	c.values = append(c.values, value{
+		name:  "orbit.inclination",
+		call:  b.call("Orbit_get_Inclination", orbit),
+		scale: units.DegreesPerRadian,
+	})
+	add("control.throttle", control, "Control", "Throttle", true)
+	for _, property := range []string{"SAS", "RCS", "Gear", "Brakes", "Lights"} {
+		c.values = append(c.values, value{
+			name: "control." + strings.ToLower(property),
+			call: b.call("Control_get_"+property, control),
+			flag: true,
+		})
+	}
+	for _, name := range names {
+		c.values = append(c.values,
+			value{name: "resources." + name, call: b.call("Resources_Amount", resources, name), single: true},
+			value{name: "resources." + name + ".max", call: b.call("Resources_Max", resources, name), single: true},
+		)
+	}
+	if b.err != nil {
+		return nil, tracerr.Wrap(b.err)
+	}
+	return c, nil
+}
+
+// Capture captures a snapshot in a single round trip. Values that can't be
+// read are noted in the snapshot's Errors rather than failing the capture.
+func (c *Capturer) Capture() (*Snapshot, error) {
+	batch := append([]*types.ProcedureCall{c.ut}, c.strings...)
+	for _, v := range c.values {
+		batch = append(batch, v.call)
+	}
+	results, err := c.client.CallMultiple(batch)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if len(results) != len(batch) {
+		return nil, tracerr.Errorf("Expected %d results, got %d", len(batch), len(results))
+	}
+
+	snap := &Snapshot{Time: c.now(), Values: map[string]float64{}}
+	// The UT, name, situation and body are needed to make sense of the
+	// rest, so they must be read.
+	for _, r := range results[:4] {
+		if r.Error != nil {
+			return nil, tracerr.Wrap(r.Error)
+		}
+	}
+	var situation spacecenter.VesselSituation
+	for i, target := range []interface{}{&snap.UT, &snap.Vessel, &situation, &snap.Body} {
+		if err := encode.Unmarshal(results[i].Value, target); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	snap.Situation = situationNames[situation]
+
+	for i, v := range c.values {
+		r := results[4+i]
+		if r.Error != nil {
+			if snap.Errors == nil {
+				snap.Errors = map[string]string{}
+			}
+			snap.Errors[v.name] = r.Error.Error()
+			continue
+		}
+		f, err := v.decode(r.Value)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			snap.Values[v.name] = f
+		}
+	}
+	return snap, nil
+}
+
+// Capture captures a snapshot of a vessel. Finding the vessel's orbit,
+// flight and resources takes a few round trips of its own, so to capture
+// the same vessel repeatedly, use a Capturer.
+func Capture(vessel *spacecenter.Vessel) (*Snapshot, error) {
+	c, err := NewCapturer(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	snap, err := c.Capture()
+	return snap, tracerr.Wrap(err)
+}
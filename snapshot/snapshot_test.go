@@ -0,0 +1,137 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/blackbox"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/replay"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	b, err := encode.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+// testCalls returns the calls made to capture a vessel, with its handles
+// as these ids.
+func testCalls(t *testing.T) []blackbox.Call {
+	vessel, orbit, body, rf, flight, resources := mustMarshal(t, uint64(1)), mustMarshal(t, uint64(2)),
+		mustMarshal(t, uint64(3)), mustMarshal(t, uint64(4)), mustMarshal(t, uint64(5)), mustMarshal(t, uint64(6))
+	control := mustMarshal(t, uint64(7))
+	call := func(procedure string, result interface{}, args ...[]byte) blackbox.Call {
+		return blackbox.Call{Service: "SpaceCenter", Procedure: procedure, Arguments: args, Result: mustMarshal(t, result)}
+	}
+	calls := []blackbox.Call{
+		call("get_ActiveVessel", uint64(1)),
+		call("Vessel_get_Orbit", uint64(2), vessel),
+		call("Orbit_get_Body", uint64(3), orbit),
+		call("CelestialBody_get_ReferenceFrame", uint64(4), body),
+		call("Vessel_Flight", uint64(5), vessel, rf),
+		call("Vessel_get_Resources", uint64(6), vessel),
+		call("Vessel_get_Control", uint64(7), vessel),
+		call("Resources_get_Names", []string{"LiquidFuel"}, resources),
+
+		call("get_UT", 12345.5),
+		call("Vessel_get_Name", "Kerbal X", vessel),
+		call("Vessel_get_Situation", spacecenter.VesselSituation_Orbiting, vessel),
+		call("CelestialBody_get_Name", "Kerbin", body),
+		call("Vessel_get_MET", 300.0, vessel),
+		call("Vessel_get_Mass", float32(5000), vessel),
+		call("Flight_get_MeanAltitude", 80000.0, flight),
+		call("Flight_get_GForce", float32(0.5), flight),
+		call("Orbit_get_ApoapsisAltitude", 81000.0, orbit),
+		call("Orbit_get_TimeToApoapsis", math.NaN(), orbit),
+		call("Orbit_get_Inclination", math.Pi/2, orbit),
+		call("Control_get_Throttle", float32(0.5), control),
+		call("Control_get_SAS", true, control),
+		call("Control_get_RCS", false, control),
+		call("Resources_Amount", float32(90), resources, mustMarshal(t, "LiquidFuel")),
+		call("Resources_Max", float32(360), resources, mustMarshal(t, "LiquidFuel")),
+	}
+	return append(calls, blackbox.Call{
+		Service:   "SpaceCenter",
+		Procedure: "Flight_get_DynamicPressure",
+		Arguments: [][]byte{flight},
+		Error:     "Not in atmosphere",
+	})
+}
+
+func TestCapture(t *testing.T) {
+	server := replay.NewServer(testCalls(t))
+	defer server.Close()
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	vessel, err := spacecenter.New(client).ActiveVessel()
+	require.NoError(t, err)
+
+	c, err := NewCapturer(vessel)
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	var batches int
+	client.OnCall(func(info krpcgo.CallInfo) { batches++ })
+	snap, err := c.Capture()
+	require.NoError(t, err)
+	require.Equal(t, 1, batches)
+
+	require.Equal(t, now, snap.Time)
+	require.Equal(t, 12345.5, snap.UT)
+	require.Equal(t, "Kerbal X", snap.Vessel)
+	require.Equal(t, "orbiting", snap.Situation)
+	require.Equal(t, "Kerbin", snap.Body)
+	require.Equal(t, map[string]float64{
+		"met":                      300,
+		"mass":                     5000,
+		"altitude":                 80000,
+		"g_force":                  0.5,
+		"orbit.apoapsis":           81000,
+		"orbit.inclination":        90,
+		"control.throttle":         0.5,
+		"control.sas":              1,
+		"control.rcs":              0,
+		"resources.LiquidFuel":     90,
+		"resources.LiquidFuel.max": 360,
+	}, snap.Values)
+	require.Contains(t, snap.Errors["dynamic_pressure"], "Not in atmosphere")
+	require.Contains(t, snap.Errors, "orbit.period")
+	require.NotContains(t, snap.Errors, "orbit.time_to_apoapsis")
+	require.Contains(t, snap.Errors, "control.gear")
+	require.Equal(t, []string{
+		"altitude", "control.rcs", "control.sas", "control.throttle", "g_force", "mass", "met",
+		"orbit.apoapsis", "orbit.inclination", "resources.LiquidFuel", "resources.LiquidFuel.max",
+	}, snap.Names())
+
+	snap, err = Capture(vessel)
+	require.NoError(t, err)
+	require.Equal(t, "Kerbal X", snap.Vessel)
+}
+
+func TestWriteJSON(t *testing.T) {
+	snap := &Snapshot{
+		Label:     "orbit reached",
+		Time:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		UT:        100,
+		Vessel:    "Kerbal X",
+		Situation: "orbiting",
+		Body:      "Kerbin",
+		Values:    map[string]float64{"altitude": 80000},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, snap.WriteJSON(&buf))
+	require.NoError(t, snap.WriteJSON(&buf))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var decoded Snapshot
+	require.NoError(t, json.Unmarshal(lines[1], &decoded))
+	require.Equal(t, *snap, decoded)
+}
@@ -0,0 +1,278 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// AbortSample is the state of a vessel that abort conditions are checked
+// against.
+type AbortSample struct {
+	// Altitude is the mean altitude, in meters.
+	Altitude float64
+	// VerticalSpeed is the vertical surface speed, in m/s.
+	VerticalSpeed float64
+	// Speed is the surface speed, in m/s.
+	Speed float64
+	// AttitudeError is the angle, in degrees, between the direction the
+	// vessel is pointing in and its surface velocity.
+	AttitudeError float64
+	// ControlAuthority is the smallest torque, in N m, available to turn the
+	// vessel either way in pitch or yaw.
+	ControlAuthority float64
+	// Heat is the highest temperature of any part, inside or on its skin, as
+	// a fraction of the most it can take.
+	Heat float64
+}
+
+// AbortCondition is a reason to abort.
+type AbortCondition struct {
+	Name string
+	// Check decides whether to abort.
+	Check func(AbortSample) bool
+}
+
+// LossOfControl aborts when the vessel has less than a torque, in N m, to
+// steer with.
+func LossOfControl(minTorque float64) AbortCondition {
+	return AbortCondition{
+		Name: "loss of control",
+		Check: func(s AbortSample) bool {
+			return s.ControlAuthority < minTorque
+		},
+	}
+}
+
+// Overheating aborts when any part's temperature passes a fraction of its
+// maximum.
+func Overheating(maxHeat float64) AbortCondition {
+	return AbortCondition{
+		Name: "overheating",
+		Check: func(s AbortSample) bool {
+			return s.Heat > maxHeat
+		},
+	}
+}
+
+// OffNominalAttitude aborts when the vessel points more than an angle, in
+// degrees, away from its direction of travel. It is only checked above a
+// speed, in m/s, since the direction of travel is meaningless on the pad.
+func OffNominalAttitude(maxError, minSpeed float64) AbortCondition {
+	return AbortCondition{
+		Name: "off-nominal attitude",
+		Check: func(s AbortSample) bool {
+			return s.Speed >= minSpeed && s.AttitudeError > maxError
+		},
+	}
+}
+
+// Descending aborts when the vessel is falling faster than a speed, in m/s,
+// below an altitude, in meters. It catches ascents that have stalled.
+func Descending(maxAltitude, minSpeed float64) AbortCondition {
+	return AbortCondition{
+		Name: "descending",
+		Check: func(s AbortSample) bool {
+			return s.Altitude < maxAltitude && s.VerticalSpeed < -minSpeed
+		},
+	}
+}
+
+// AbortConfig configures an AbortMonitor.
+type AbortConfig struct {
+	Conditions []AbortCondition
+	// Persistence is how long a condition must hold before aborting, so that
+	// brief glitches are ignored.
+	Persistence time.Duration
+	// NoActionGroup stops the monitor from triggering the abort action
+	// group, leaving the response to OnAbort.
+	NoActionGroup bool
+	// Interval is how often the conditions are checked.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *AbortConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 250 * time.Millisecond
+	}
+}
+
+// abortTracker keeps track of how long each condition has held.
+type abortTracker struct {
+	conditions  []AbortCondition
+	persistence time.Duration
+	since       []time.Time
+}
+
+func newAbortTracker(conditions []AbortCondition, persistence time.Duration) *abortTracker {
+	return &abortTracker{
+		conditions:  conditions,
+		persistence: persistence,
+		since:       make([]time.Time, len(conditions)),
+	}
+}
+
+// update checks a sample taken at a time, returning the first condition that
+// has held for long enough.
+func (t *abortTracker) update(sample AbortSample, now time.Time) (AbortCondition, bool) {
+	for i, c := range t.conditions {
+		if !c.Check(sample) {
+			t.since[i] = time.Time{}
+			continue
+		}
+		if t.since[i].IsZero() {
+			t.since[i] = now
+		}
+		if now.Sub(t.since[i]) >= t.persistence {
+			return c, true
+		}
+	}
+	return AbortCondition{}, false
+}
+
+// controlAuthority returns the smallest of the pitch and yaw torques in each
+// direction.
+func controlAuthority(positive, negative types.Vector3D) float64 {
+	// The vessel's x-axis is pitch and its z-axis is yaw.
+	return math.Min(
+		math.Min(math.Abs(positive.X), math.Abs(negative.X)),
+		math.Min(math.Abs(positive.Z), math.Abs(negative.Z)),
+	)
+}
+
+// AbortMonitor watches a vessel for signs that a flight has gone wrong, and
+// aborts it.
+type AbortMonitor struct {
+	vessel *Vessel
+	cfg    AbortConfig
+	// OnAbort, if set, is called with the condition and sample that caused
+	// an abort.
+	OnAbort func(AbortCondition, AbortSample)
+}
+
+// NewAbortMonitor creates a new AbortMonitor.
+func NewAbortMonitor(vessel *Vessel, cfg AbortConfig) *AbortMonitor {
+	cfg.SetDefaults()
+	return &AbortMonitor{vessel: vessel, cfg: cfg}
+}
+
+// sample gets the vessel's current state.
+func (m *AbortMonitor) sample(flight *Flight, parts *Parts) (AbortSample, error) {
+	var s AbortSample
+	var err error
+	if s.Altitude, err = flight.MeanAltitude(); err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	if s.VerticalSpeed, err = flight.VerticalSpeed(); err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	if s.Speed, err = flight.Speed(); err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	direction, err := flight.Direction()
+	if err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	velocity, err := flight.Velocity()
+	if err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	s.AttitudeError = units.Degrees(types.Vector3DFromTuple(direction).AngleBetween(types.Vector3DFromTuple(velocity)))
+	if math.IsNaN(s.AttitudeError) {
+		s.AttitudeError = 0
+	}
+
+	torque, err := m.vessel.AvailableTorque()
+	if err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	s.ControlAuthority = controlAuthority(types.Vector3DFromTuple(torque.A), types.Vector3DFromTuple(torque.B))
+
+	all, err := parts.All()
+	if err != nil {
+		return s, tracerr.Wrap(err)
+	}
+	for _, part := range all {
+		temperature, err := part.Temperature()
+		if err != nil {
+			return s, tracerr.Wrap(err)
+		}
+		maxTemperature, err := part.MaxTemperature()
+		if err != nil {
+			return s, tracerr.Wrap(err)
+		}
+		skin, err := part.SkinTemperature()
+		if err != nil {
+			return s, tracerr.Wrap(err)
+		}
+		maxSkin, err := part.MaxSkinTemperature()
+		if err != nil {
+			return s, tracerr.Wrap(err)
+		}
+		s.Heat = math.Max(s.Heat, math.Max(temperature/maxTemperature, skin/maxSkin))
+	}
+	return s, nil
+}
+
+// Run checks the abort conditions until one of them holds or the context is
+// canceled. On an abort, it triggers the abort action group, calls OnAbort
+// and returns.
+func (m *AbortMonitor) Run(ctx context.Context) error {
+	control, err := m.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	parts, err := m.vessel.Parts()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	orbit, err := m.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rf, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	flight, err := m.vessel.Flight(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	tracker := newAbortTracker(m.cfg.Conditions, m.cfg.Persistence)
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			sample, err := m.sample(flight, parts)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			condition, abort := tracker.update(sample, now)
+			if !abort {
+				continue
+			}
+			if !m.cfg.NoActionGroup {
+				if err := control.SetAbort(true); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+			if m.OnAbort != nil {
+				m.OnAbort(condition, sample)
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package spacecenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbortConditions(t *testing.T) {
+	tcs := []struct {
+		name      string
+		condition AbortCondition
+		sample    AbortSample
+		expected  bool
+	}{
+		{name: "control ok", condition: LossOfControl(1000), sample: AbortSample{ControlAuthority: 5000}},
+		{name: "control lost", condition: LossOfControl(1000), sample: AbortSample{ControlAuthority: 200}, expected: true},
+		{name: "cool", condition: Overheating(0.9), sample: AbortSample{Heat: 0.5}},
+		{name: "overheating", condition: Overheating(0.9), sample: AbortSample{Heat: 0.95}, expected: true},
+		{name: "on course", condition: OffNominalAttitude(10, 50), sample: AbortSample{Speed: 300, AttitudeError: 3}},
+		{name: "off course", condition: OffNominalAttitude(10, 50), sample: AbortSample{Speed: 300, AttitudeError: 25}, expected: true},
+		{name: "off course on the pad", condition: OffNominalAttitude(10, 50), sample: AbortSample{Speed: 1, AttitudeError: 90}},
+		{name: "climbing", condition: Descending(30000, 5), sample: AbortSample{Altitude: 1000, VerticalSpeed: 100}},
+		{name: "settling on the pad", condition: Descending(30000, 5), sample: AbortSample{Altitude: 70, VerticalSpeed: -0.5}},
+		{name: "falling", condition: Descending(30000, 5), sample: AbortSample{Altitude: 8000, VerticalSpeed: -40}, expected: true},
+		{name: "falling back from space", condition: Descending(30000, 5), sample: AbortSample{Altitude: 80000, VerticalSpeed: -40}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.condition.Check(tc.sample))
+		})
+	}
+}
+
+func TestAbortTracker(t *testing.T) {
+	start := time.Now()
+	tracker := newAbortTracker([]AbortCondition{Overheating(0.9), LossOfControl(1000)}, time.Second)
+	hot := AbortSample{Heat: 1, ControlAuthority: 5000}
+	ok := AbortSample{Heat: 0.1, ControlAuthority: 5000}
+
+	_, abort := tracker.update(hot, start)
+	require.False(t, abort)
+	// A brief glitch is ignored.
+	_, abort = tracker.update(ok, start.Add(500*time.Millisecond))
+	require.False(t, abort)
+	_, abort = tracker.update(hot, start.Add(time.Second))
+	require.False(t, abort)
+	condition, abort := tracker.update(hot, start.Add(2*time.Second))
+	require.True(t, abort)
+	require.Equal(t, "overheating", condition.Name)
+}
+
+func TestControlAuthority(t *testing.T) {
+	positive := types.NewVector3D(4000, 100, 3000)
+	negative := types.NewVector3D(-3500, -100, -2500)
+	require.Equal(t, 2500.0, controlAuthority(positive, negative))
+}
@@ -0,0 +1,329 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/control"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// AircraftGains are the gains of each of an aircraft autopilot's loops.
+type AircraftGains struct {
+	// Altitude turns altitude error (m) into a target pitch (degrees).
+	Altitude control.Gains
+	// Pitch turns pitch error (degrees) into elevator input.
+	Pitch control.Gains
+	// Heading turns heading error (degrees) into a target bank angle
+	// (degrees).
+	Heading control.Gains
+	// Roll turns bank angle error (degrees) into aileron input.
+	Roll control.Gains
+	// Speed turns speed error (m/s) into throttle.
+	Speed control.Gains
+}
+
+// AircraftConfig configures an AircraftAutopilot.
+type AircraftConfig struct {
+	// Interval is how often the control loops run.
+	Interval time.Duration
+	// MaxPitch is the steepest climb or dive to command, in degrees.
+	MaxPitch float64
+	// MaxBank is the steepest bank to command, in degrees.
+	MaxBank float64
+	// YawDamping is how much rudder to apply against sideslip, per degree.
+	YawDamping float64
+	Gains      AircraftGains
+}
+
+// SetDefaults sets the default values for any unset fields. The default
+// gains suit a small jet in stock aerodynamics.
+func (cfg *AircraftConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 50 * time.Millisecond
+	}
+	if cfg.MaxPitch == 0 {
+		cfg.MaxPitch = 15
+	}
+	if cfg.MaxBank == 0 {
+		cfg.MaxBank = 30
+	}
+	if cfg.YawDamping == 0 {
+		cfg.YawDamping = 0.05
+	}
+	if cfg.Gains == (AircraftGains{}) {
+		cfg.Gains = AircraftGains{
+			Altitude: control.Gains{Kp: 0.05, Ki: 0.002, Kd: 0.05},
+			Pitch:    control.Gains{Kp: 0.04, Ki: 0.01, Kd: 0.01},
+			Heading:  control.Gains{Kp: 1.5, Ki: 0, Kd: 0.2},
+			Roll:     control.Gains{Kp: 0.02, Ki: 0.002, Kd: 0.005},
+			Speed:    control.Gains{Kp: 0.05, Ki: 0.01, Kd: 0},
+		}
+	}
+}
+
+// AircraftTargets are what an aircraft autopilot holds.
+type AircraftTargets struct {
+	// Heading is the compass heading, in degrees.
+	Heading float64
+	// Altitude is the altitude above sea level, in meters.
+	Altitude float64
+	// Speed is the surface speed, in m/s.
+	Speed float64
+}
+
+// aircraftState is the measured state of an aircraft.
+type aircraftState struct {
+	pitch, heading, roll float64
+	altitude, speed      float64
+	sideslip             float64
+	received             aircraftFields
+}
+
+// aircraftFields is a set of aircraftState fields that have been streamed.
+type aircraftFields uint8
+
+const (
+	aircraftPitch aircraftFields = 1 << iota
+	aircraftHeading
+	aircraftRoll
+	aircraftSideslip
+	aircraftAltitude
+	aircraftSpeed
+
+	allAircraftFields = aircraftPitch | aircraftHeading | aircraftRoll |
+		aircraftSideslip | aircraftAltitude | aircraftSpeed
+)
+
+// ready reports whether every stream has delivered its first value.
+func (s aircraftState) ready() bool {
+	return s.received == allAircraftFields
+}
+
+// aircraftInputs are control inputs for an aircraft.
+type aircraftInputs struct {
+	pitch, roll, yaw, throttle float64
+}
+
+// aircraftLoops are the cascaded control loops of an aircraft autopilot.
+type aircraftLoops struct {
+	cfg      AircraftConfig
+	altitude *control.PID
+	pitch    *control.PID
+	heading  *control.PID
+	roll     *control.PID
+	speed    *control.PID
+}
+
+func newAircraftLoops(cfg AircraftConfig) *aircraftLoops {
+	l := &aircraftLoops{
+		cfg:      cfg,
+		altitude: control.New(cfg.Gains.Altitude, -cfg.MaxPitch, cfg.MaxPitch),
+		pitch:    control.New(cfg.Gains.Pitch, -1, 1),
+		heading:  control.New(cfg.Gains.Heading, -cfg.MaxBank, cfg.MaxBank),
+		roll:     control.New(cfg.Gains.Roll, -1, 1),
+		speed:    control.New(cfg.Gains.Speed, 0, 1),
+	}
+	// Don't kick when the targets change.
+	l.altitude.DerivativeOnMeasurement = true
+	l.speed.DerivativeOnMeasurement = true
+	return l
+}
+
+// setGains retunes the loops without bumping their outputs.
+func (l *aircraftLoops) setGains(gains AircraftGains) {
+	l.cfg.Gains = gains
+	l.altitude.SetGains(gains.Altitude)
+	l.pitch.SetGains(gains.Pitch)
+	l.heading.SetGains(gains.Heading)
+	l.roll.SetGains(gains.Roll)
+	l.speed.SetGains(gains.Speed)
+}
+
+// step runs the loops once. Positive roll is banking right.
+func (l *aircraftLoops) step(state aircraftState, targets AircraftTargets, dt float64) aircraftInputs {
+	targetPitch := l.altitude.Update(targets.Altitude, state.altitude, dt)
+	targetBank := l.heading.UpdateError(units.NormalizeBearing(targets.Heading-state.heading), dt)
+	return aircraftInputs{
+		pitch:    l.pitch.UpdateError(targetPitch-state.pitch, dt),
+		roll:     l.roll.UpdateError(targetBank-state.roll, dt),
+		yaw:      math.Max(-1, math.Min(1, -l.cfg.YawDamping*state.sideslip)),
+		throttle: l.speed.Update(targets.Speed, state.speed, dt),
+	}
+}
+
+// AircraftAutopilot flies a fixed-wing aircraft, holding a heading, altitude
+// and speed by driving the pitch, roll, yaw and throttle controls directly.
+// Altitude is held by commanding a pitch angle, and heading by commanding a
+// bank angle.
+type AircraftAutopilot struct {
+	vessel *Vessel
+
+	mu      sync.Mutex
+	loops   *aircraftLoops
+	targets AircraftTargets
+}
+
+// NewAircraftAutopilot creates a new AircraftAutopilot.
+func NewAircraftAutopilot(vessel *Vessel, cfg AircraftConfig) *AircraftAutopilot {
+	cfg.SetDefaults()
+	return &AircraftAutopilot{vessel: vessel, loops: newAircraftLoops(cfg)}
+}
+
+// Targets returns what the autopilot is holding.
+func (a *AircraftAutopilot) Targets() AircraftTargets {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.targets
+}
+
+// SetTargets changes what the autopilot holds. It may be called while the
+// autopilot is running.
+func (a *AircraftAutopilot) SetTargets(targets AircraftTargets) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.targets = targets
+}
+
+// Gains returns the gains of the autopilot's loops.
+func (a *AircraftAutopilot) Gains() AircraftGains {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.loops.cfg.Gains
+}
+
+// SetGains retunes the autopilot's loops. It may be called while the
+// autopilot is running.
+func (a *AircraftAutopilot) SetGains(gains AircraftGains) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.loops.setGains(gains)
+}
+
+// Run flies the aircraft until the context is canceled, then centers the
+// controls.
+func (a *AircraftAutopilot) Run(ctx context.Context) error {
+	control, err := a.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	orbit, err := a.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	bodyRF, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	surfaceRF, err := a.vessel.SurfaceReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	attitude, err := a.vessel.Flight(surfaceRF)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	motion, err := a.vessel.Flight(bodyRF)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	pitches, err := attitude.PitchStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer pitches.Close()
+	headings, err := attitude.HeadingStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer headings.Close()
+	rolls, err := attitude.RollStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer rolls.Close()
+	sideslips, err := motion.SideslipAngleStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer sideslips.Close()
+	altitudes, err := motion.MeanAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer altitudes.Close()
+	speeds, err := motion.SpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer speeds.Close()
+
+	defer func() {
+		_ = control.SetPitch(0)
+		_ = control.SetRoll(0)
+		_ = control.SetYaw(0)
+	}()
+
+	ticker := time.NewTicker(a.loops.cfg.Interval)
+	defer ticker.Stop()
+	var state aircraftState
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v := <-pitches.C:
+			state.pitch = float64(v)
+			state.received |= aircraftPitch
+		case v := <-headings.C:
+			state.heading = float64(v)
+			state.received |= aircraftHeading
+		case v := <-rolls.C:
+			state.roll = float64(v)
+			state.received |= aircraftRoll
+		case v := <-sideslips.C:
+			state.sideslip = float64(v)
+			state.received |= aircraftSideslip
+		case v := <-altitudes.C:
+			state.altitude = v
+			state.received |= aircraftAltitude
+		case v := <-speeds.C:
+			state.speed = v
+			state.received |= aircraftSpeed
+		case now := <-ticker.C:
+			dt := now.Sub(last).Seconds()
+			last = now
+			if !state.ready() {
+				// Stepping on zero values would wind up the integrators.
+				continue
+			}
+			a.mu.Lock()
+			inputs := a.loops.step(state, a.targets, dt)
+			a.mu.Unlock()
+			if err := setAircraftInputs(control, inputs); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
+
+func setAircraftInputs(control *Control, inputs aircraftInputs) error {
+	if err := control.SetPitch(float32(inputs.pitch)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := control.SetRoll(float32(inputs.roll)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := control.SetYaw(float32(inputs.yaw)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(control.SetThrottle(float32(inputs.throttle)))
+}
@@ -0,0 +1,63 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/control"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAircraftLoops(t *testing.T) {
+	cfg := AircraftConfig{}
+	cfg.SetDefaults()
+	targets := AircraftTargets{Heading: 90, Altitude: 5000, Speed: 200}
+
+	level := aircraftState{heading: 90, altitude: 5000, speed: 200}
+	inputs := newAircraftLoops(cfg).step(level, targets, 0.05)
+	require.InDelta(t, 0, inputs.pitch, 1e-9)
+	require.InDelta(t, 0, inputs.roll, 1e-9)
+	require.InDelta(t, 0, inputs.yaw, 1e-9)
+
+	low := level
+	low.altitude = 4000
+	require.Greater(t, newAircraftLoops(cfg).step(low, targets, 0.05).pitch, 0.0)
+
+	// Turning right the short way, across north.
+	left := level
+	left.heading = 350
+	right := targets
+	right.Heading = 10
+	require.Greater(t, newAircraftLoops(cfg).step(left, right, 0.05).roll, 0.0)
+
+	slow := level
+	slow.speed = 150
+	require.Greater(t, newAircraftLoops(cfg).step(slow, targets, 0.05).throttle, 0.0)
+	fast := level
+	fast.speed = 250
+	require.Equal(t, 0.0, newAircraftLoops(cfg).step(fast, targets, 0.05).throttle)
+
+	skidding := level
+	skidding.sideslip = 4
+	require.Less(t, newAircraftLoops(cfg).step(skidding, targets, 0.05).yaw, 0.0)
+}
+
+func TestAircraftSetGains(t *testing.T) {
+	cfg := AircraftConfig{}
+	cfg.SetDefaults()
+	a := NewAircraftAutopilot(nil, cfg)
+	gains := a.Gains()
+	gains.Speed = control.Gains{Kp: 1}
+	a.SetGains(gains)
+	require.Equal(t, gains, a.Gains())
+	require.Equal(t, control.Gains{Kp: 1}, a.loops.speed.Gains())
+}
+
+func TestAircraftStateReady(t *testing.T) {
+	var state aircraftState
+	for _, field := range []aircraftFields{aircraftPitch, aircraftHeading, aircraftRoll, aircraftSideslip, aircraftAltitude} {
+		state.received |= field
+		require.False(t, state.ready())
+	}
+	state.received |= aircraftSpeed
+	require.True(t, state.ready())
+}
@@ -0,0 +1,136 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// approachSteps is how many times the search window is sampled before
+// refining the closest approach.
+const approachSteps = 720
+
+// ClosestApproach is when two orbits come closest to each other.
+type ClosestApproach struct {
+	UT float64
+	// Distance is the distance between them, in meters.
+	Distance float64
+	// RelativePosition and RelativeVelocity are the target's position, in
+	// meters, and velocity, in m/s, relative to the vessel, in the body's
+	// non-rotating reference frame.
+	RelativePosition types.Vector3D
+	RelativeVelocity types.Vector3D
+}
+
+// Speed returns the relative speed at closest approach, in m/s.
+func (c ClosestApproach) Speed() float64 {
+	return c.RelativeVelocity.Length()
+}
+
+// relativeState returns one orbit's position and velocity relative to
+// another's at a time.
+func relativeState(orbit, target *KeplerOrbit, ut float64) ClosestApproach {
+	p1, v1 := orbit.StateAt(ut)
+	p2, v2 := target.StateAt(ut)
+	position := p2.Add(p1.Scale(-1))
+	return ClosestApproach{
+		UT:               ut,
+		Distance:         position.Length(),
+		RelativePosition: position,
+		RelativeVelocity: v2.Add(v1.Scale(-1)),
+	}
+}
+
+// goldenSection finds a minimum of f between lo and hi, to within a
+// tolerance.
+func goldenSection(f func(float64) float64, lo, hi, tolerance float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+	a, b := lo, hi
+	c, d := b-ratio*(b-a), a+ratio*(b-a)
+	fc, fd := f(c), f(d)
+	for b-a > tolerance {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
+
+// closestApproach searches between start and end for when two orbits come
+// closest. The window is sampled, then the best sample and any guesses, such
+// as the server's estimate, are refined.
+func closestApproach(orbit, target *KeplerOrbit, start, end float64, guesses ...float64) ClosestApproach {
+	distance := func(ut float64) float64 {
+		return relativeState(orbit, target, ut).Distance
+	}
+	step := (end - start) / approachSteps
+	best, bestDistance := start, distance(start)
+	for i := 1; i <= approachSteps; i++ {
+		ut := start + float64(i)*step
+		if d := distance(ut); d < bestDistance {
+			best, bestDistance = ut, d
+		}
+	}
+	candidates := append([]float64{best}, guesses...)
+	for _, c := range candidates {
+		if c < start || c > end {
+			continue
+		}
+		lo, hi := math.Max(start, c-step), math.Min(end, c+step)
+		ut := goldenSection(distance, lo, hi, 1e-3)
+		if d := distance(ut); d < bestDistance {
+			best, bestDistance = ut, d
+		}
+	}
+	return relativeState(orbit, target, best)
+}
+
+// FindClosestApproach finds when two orbits around the same body next come
+// closest to each other, searching one orbit ahead from ut. kRPC's estimate
+// is refined by propagating both orbits locally.
+func FindClosestApproach(orbit, target *Orbit, ut float64) (ClosestApproach, error) {
+	body, err := orbit.Body()
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+	targetBody, err := target.Body()
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+	if body.ID_internal() != targetBody.ID_internal() {
+		return ClosestApproach{}, tracerr.Errorf("Orbits are around different bodies")
+	}
+	rf, err := body.NonRotatingReferenceFrame()
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+	a, err := NewKeplerOrbit(orbit, rf)
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+	b, err := NewKeplerOrbit(target, rf)
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+	guess, err := orbit.TimeOfClosestApproach(target)
+	if err != nil {
+		return ClosestApproach{}, tracerr.Wrap(err)
+	}
+
+	window := math.Max(a.Period(), b.Period())
+	if math.IsInf(window, 0) {
+		window = math.Min(a.Period(), b.Period())
+	}
+	if math.IsInf(window, 0) {
+		// Neither orbit is closed, so stick near kRPC's estimate.
+		window = 2 * math.Abs(guess-ut)
+	}
+	return closestApproach(a, b, ut, ut+window, guess), nil
+}
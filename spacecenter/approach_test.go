@@ -0,0 +1,59 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldenSection(t *testing.T) {
+	f := func(x float64) float64 {
+		return (x - 2.5) * (x - 2.5)
+	}
+	require.InDelta(t, 2.5, goldenSection(f, 0, 10, 1e-6), 1e-5)
+	// A minimum at the edge of the range.
+	require.InDelta(t, 3, goldenSection(f, 3, 10, 1e-6), 1e-5)
+}
+
+func TestClosestApproach(t *testing.T) {
+	r := 700e3
+	period := 2 * math.Pi * math.Sqrt(r*r*r/kerbinMu)
+	speed := math.Sqrt(kerbinMu / r)
+
+	t.Run("same orbit", func(t *testing.T) {
+		// A target a little ahead on the same orbit stays the same distance
+		// away, moving in a slightly different direction.
+		vessel := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r}
+		target := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r, MeanAnomalyAtEpoch: 0.001}
+		approach := closestApproach(vessel, target, 0, period)
+		require.InDelta(t, 2*r*math.Sin(0.0005), approach.Distance, 1e-3)
+		require.InDelta(t, 2*speed*math.Sin(0.0005), approach.Speed(), 1e-6)
+	})
+
+	t.Run("crossing orbits", func(t *testing.T) {
+		// Two circular orbits at right angles cross over the line of nodes.
+		// The target starts a quarter orbit behind the crossing point and the
+		// vessel a bit further back, so they meet a little apart. They meet
+		// again half an orbit later, so only search until then.
+		vessel := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r, MeanAnomalyAtEpoch: -math.Pi/2 - 0.01}
+		target := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r, Inclination: math.Pi / 2, MeanAnomalyAtEpoch: -math.Pi / 2}
+		approach := closestApproach(vessel, target, 0, period/2)
+		require.Less(t, approach.Distance, 0.01*r)
+		require.InDelta(t, period/4, approach.UT, 0.01*period)
+		// They pass at right angles, both at orbital speed.
+		require.InDelta(t, math.Sqrt2*speed, approach.Speed(), 0.01*speed)
+		// The relative velocity is perpendicular to the separation at the
+		// closest point.
+		cos := approach.RelativePosition.Dot(approach.RelativeVelocity) / approach.Distance / approach.Speed()
+		require.InDelta(t, 0, cos, 1e-3)
+	})
+
+	t.Run("refines a guess", func(t *testing.T) {
+		vessel := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r}
+		target := &KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: 1.2 * r, Eccentricity: 1 - 1/1.2, MeanAnomalyAtEpoch: -0.2}
+		coarse := closestApproach(vessel, target, 0, period)
+		refined := closestApproach(vessel, target, 0, period, coarse.UT+5)
+		require.LessOrEqual(t, refined.Distance, coarse.Distance)
+	})
+}
@@ -0,0 +1,531 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// PitchKey is what a pitch program's table is indexed by.
+type PitchKey int
+
+const (
+	// PitchByAltitude indexes a pitch program by mean altitude, in meters.
+	PitchByAltitude PitchKey = iota
+	// PitchBySpeed indexes a pitch program by surface speed, in m/s.
+	PitchBySpeed
+)
+
+// PitchPoint is one entry in a pitch program.
+type PitchPoint struct {
+	// Key is the altitude or speed at which to hold the pitch.
+	Key float64
+	// Pitch is the pitch above the horizon, in degrees.
+	Pitch float64
+}
+
+// PitchProgram is a table of pitches to fly during an ascent. The pitch is
+// interpolated linearly between points, and held at the first and last
+// points outside of the table.
+type PitchProgram struct {
+	By     PitchKey
+	Points []PitchPoint
+}
+
+// GravityTurn returns a pitch program that flies straight up to a starting
+// altitude, then pitches over linearly to the horizon at an ending altitude.
+func GravityTurn(startAltitude, endAltitude float64) PitchProgram {
+	return PitchProgram{
+		By: PitchByAltitude,
+		Points: []PitchPoint{
+			{Key: startAltitude, Pitch: 90},
+			{Key: endAltitude, Pitch: 0},
+		},
+	}
+}
+
+// Pitch returns the pitch to fly at an altitude and speed.
+func (p PitchProgram) Pitch(altitude, speed float64) float64 {
+	if len(p.Points) == 0 {
+		return 90
+	}
+	key := altitude
+	if p.By == PitchBySpeed {
+		key = speed
+	}
+	points := p.Points
+	i := sort.Search(len(points), func(i int) bool { return points[i].Key > key })
+	if i == 0 {
+		return points[0].Pitch
+	}
+	if i == len(points) {
+		return points[len(points)-1].Pitch
+	}
+	a, b := points[i-1], points[i]
+	return a.Pitch + (b.Pitch-a.Pitch)*(key-a.Key)/(b.Key-a.Key)
+}
+
+// ThrottlePlan limits the throttle during an ascent. Solid rocket boosters
+// can't be throttled, so the liquid engines are throttled back further while
+// they burn to keep the vessel within the limits.
+type ThrottlePlan struct {
+	// MaxAcceleration is the most acceleration to allow, in m/s². Zero means
+	// no limit.
+	MaxAcceleration float64
+	// MaxQ is the most dynamic pressure to allow, in Pa. Above it, the thrust
+	// is scaled down in proportion. Zero means no limit.
+	MaxQ float64
+	// MinThrottle is the least throttle to command, between 0 and 1.
+	MinThrottle float64
+}
+
+// throttle returns the throttle for the liquid engines, given the thrust of
+// the boosters and the available thrust of the liquid engines, in N.
+func (p ThrottlePlan) throttle(mass, boosterThrust, liquidThrust, q float64) float64 {
+	if liquidThrust <= 0 {
+		return 1
+	}
+	desired := boosterThrust + liquidThrust
+	if p.MaxAcceleration > 0 {
+		desired = math.Min(desired, mass*p.MaxAcceleration)
+	}
+	if p.MaxQ > 0 && q > p.MaxQ {
+		desired = math.Min(desired, (boosterThrust+liquidThrust)*p.MaxQ/q)
+	}
+	return math.Max(p.MinThrottle, math.Min(1, (desired-boosterThrust)/liquidThrust))
+}
+
+// LaunchAzimuth returns the compass heading, in degrees, to launch at from a
+// latitude to reach an orbit with an inclination, both in degrees. The
+// body's rotation is accounted for, given the orbital speed and the body's
+// rotational speed at the equator, in m/s. If the inclination can't be
+// reached from the latitude, the azimuth for the closest inclination is
+// returned. Launching on the descending pass heads south instead of north.
+func LaunchAzimuth(latitude, inclination, orbitalSpeed, equatorialSpeed float64, descending bool) float64 {
+	lat := units.Radians(latitude)
+	// The inertial azimuth, measured from north.
+	sinBeta := math.Max(-1, math.Min(1, math.Cos(units.Radians(inclination))/math.Cos(lat)))
+	beta := math.Asin(sinBeta)
+	east := orbitalSpeed*math.Sin(beta) - equatorialSpeed*math.Cos(lat)
+	north := orbitalSpeed * math.Cos(beta)
+	if descending {
+		north = -north
+	}
+	return units.NormalizeHeading(units.Degrees(math.Atan2(east, north)))
+}
+
+// launchSpeeds returns the speed of a circular orbit at an altitude above a
+// body, and the speed of the body's surface at the equator, in m/s.
+func launchSpeeds(body *CelestialBody, altitude float64) (orbital, equatorial float64, err error) {
+	mu, err := body.GravitationalParameter()
+	if err != nil {
+		return 0, 0, tracerr.Wrap(err)
+	}
+	radius, err := body.EquatorialRadius()
+	if err != nil {
+		return 0, 0, tracerr.Wrap(err)
+	}
+	period, err := body.RotationalPeriod()
+	if err != nil {
+		return 0, 0, tracerr.Wrap(err)
+	}
+	orbital = math.Sqrt(float64(mu) / (float64(radius) + altitude))
+	equatorial = 2 * math.Pi * float64(radius) / float64(period)
+	return orbital, equatorial, nil
+}
+
+// LaunchWindow is a time to launch into the plane of an orbit.
+type LaunchWindow struct {
+	UT float64
+	// Descending is whether the launch site passes under the orbit heading
+	// south, so the vessel must launch southwards.
+	Descending bool
+	// Azimuth is the compass heading to launch at, in degrees.
+	Azimuth float64
+}
+
+// launchWindowWaits returns how long until a launch site rotates through an
+// orbital plane, sorted soonest first, and whether each pass is descending.
+// The site's position and velocity are in a non-rotating frame centered on
+// the body with its y-axis towards the north pole, and normal is the orbit's
+// angular momentum in that frame.
+func launchWindowWaits(site, velocity, normal types.Vector3D) ([]float64, []bool, error) {
+	north := types.NewVector3D(0, 1, 0)
+	parallel := north.Scale(site.Dot(north))
+	perpendicular := site.Add(parallel.Scale(-1))
+	radius := perpendicular.Length()
+	speed := velocity.Length()
+	if radius == 0 || speed == 0 {
+		return nil, nil, tracerr.Errorf("Launch site is on the rotation axis")
+	}
+	rate := speed / radius
+	ahead := velocity.Scale(radius / speed)
+
+	// The site is at parallel + perpendicular cos θ + ahead sin θ after
+	// turning θ, and in the plane when A + B cos θ + C sin θ = 0.
+	a, b, c := normal.Dot(parallel), normal.Dot(perpendicular), normal.Dot(ahead)
+	r := math.Hypot(b, c)
+	if r == 0 || math.Abs(a) > r {
+		return nil, nil, tracerr.Errorf("Launch site never passes under the orbit")
+	}
+	phi := math.Atan2(c, b)
+	offset := math.Acos(-a / r)
+
+	var waits []float64
+	var descending []bool
+	for _, theta := range []float64{phi + offset, phi - offset} {
+		theta = math.Mod(theta+4*math.Pi, 2*math.Pi)
+		position := parallel.Add(perpendicular.Scale(math.Cos(theta))).Add(ahead.Scale(math.Sin(theta)))
+		// The direction the orbit moves in at the site.
+		direction := normal.Cross(position)
+		waits = append(waits, theta/rate)
+		descending = append(descending, direction.Dot(north) < 0)
+	}
+	if waits[1] < waits[0] {
+		waits[0], waits[1] = waits[1], waits[0]
+		descending[0], descending[1] = descending[1], descending[0]
+	}
+	return waits, descending, nil
+}
+
+// LaunchWindows returns the next two times a landed vessel can launch into
+// the plane of an orbit around the body it is on, soonest first. altitude is
+// the altitude of the orbit to launch into, used for the azimuth.
+func LaunchWindows(vessel *Vessel, target *Orbit, altitude float64) ([]LaunchWindow, error) {
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	targetBody, err := target.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if body.ID_internal() != targetBody.ID_internal() {
+		return nil, tracerr.Errorf("Orbit is around a different body from the vessel")
+	}
+	rf, err := body.NonRotatingReferenceFrame()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	sc := New(vessel.Client)
+	ut, err := sc.UT()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	site, err := vessel.Position(rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	velocity, err := vessel.Velocity(rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	period, err := target.Period()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	p1, err := target.PositionAt(ut, rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	p2, err := target.PositionAt(ut+period/4, rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	normal := types.Vector3DFromTuple(p1).Cross(types.Vector3DFromTuple(p2))
+	waits, descending, err := launchWindowWaits(types.Vector3DFromTuple(site), types.Vector3DFromTuple(velocity), normal)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	inclination, err := target.Inclination()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	orbitalSpeed, equatorialSpeed, err := launchSpeeds(body, altitude)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	pos, err := vesselPosition(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	windows := make([]LaunchWindow, len(waits))
+	for i, wait := range waits {
+		windows[i] = LaunchWindow{
+			UT:         ut + wait,
+			Descending: descending[i],
+			Azimuth:    LaunchAzimuth(pos.latitude, units.Degrees(inclination), orbitalSpeed, equatorialSpeed, descending[i]),
+		}
+	}
+	return windows, nil
+}
+
+// AscentConfig configures an Ascent.
+type AscentConfig struct {
+	// TargetAltitude is the apoapsis altitude to raise, in meters.
+	TargetAltitude float64
+	// Inclination is the inclination to launch into, in degrees. Zero, or any
+	// inclination lower than the launch site's latitude, launches due east.
+	Inclination float64
+	// Descending launches southwards instead of northwards.
+	Descending bool
+	// Pitch is the pitch program to fly. Defaults to a gravity turn from 250
+	// m to 45 km.
+	Pitch PitchProgram
+	// Throttle is the throttle plan to fly.
+	Throttle ThrottlePlan
+	// ApproachDistance is how far below the target apoapsis, in meters, to
+	// start throttling down.
+	ApproachDistance float64
+	// Interval is how often to update the guidance.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *AscentConfig) SetDefaults() {
+	if cfg.TargetAltitude == 0 {
+		cfg.TargetAltitude = 80000
+	}
+	if len(cfg.Pitch.Points) == 0 {
+		cfg.Pitch = GravityTurn(250, 45000)
+	}
+	if cfg.Throttle.MinThrottle == 0 {
+		cfg.Throttle.MinThrottle = 0.05
+	}
+	if cfg.ApproachDistance == 0 {
+		cfg.ApproachDistance = 0.05 * cfg.TargetAltitude
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// approachThrottle limits the throttle as the apoapsis nears its target.
+func approachThrottle(throttle, apoapsis float64, cfg AscentConfig) float64 {
+	limit := (cfg.TargetAltitude - apoapsis) / cfg.ApproachDistance
+	return math.Max(cfg.Throttle.MinThrottle, math.Min(throttle, limit))
+}
+
+// Ascent flies a vessel from the launch pad until its apoapsis reaches a
+// target altitude, following a pitch program and throttle plan. It doesn't
+// stage or circularize.
+type Ascent struct {
+	vessel *Vessel
+	cfg    AscentConfig
+}
+
+// NewAscent creates a new Ascent.
+func NewAscent(vessel *Vessel, cfg AscentConfig) *Ascent {
+	cfg.SetDefaults()
+	return &Ascent{vessel: vessel, cfg: cfg}
+}
+
+// ascentFields is a set of streamed values an ascent has received.
+type ascentFields uint8
+
+const (
+	ascentAltitude ascentFields = 1 << iota
+	ascentSpeed
+	ascentPressure
+	ascentApoapsis
+	ascentMass
+	ascentThrust
+
+	allAscentFields = ascentAltitude | ascentSpeed | ascentPressure |
+		ascentApoapsis | ascentMass | ascentThrust
+)
+
+// boosters returns the vessel's solid rocket boosters.
+func boosters(vessel *Vessel) ([]*Engine, error) {
+	parts, err := vessel.Parts()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	engines, err := parts.Engines()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var boosters []*Engine
+	for _, engine := range engines {
+		// Despite its documentation, this follows KSP and is true for solid
+		// rocket boosters.
+		locked, err := engine.ThrottleLocked()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if locked {
+			boosters = append(boosters, engine)
+		}
+	}
+	return boosters, nil
+}
+
+// Heading returns the compass heading the ascent will fly, in degrees.
+func (a *Ascent) Heading() (float64, error) {
+	orbit, err := a.vessel.Orbit()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	orbitalSpeed, equatorialSpeed, err := launchSpeeds(body, a.cfg.TargetAltitude)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	pos, err := vesselPosition(a.vessel)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return LaunchAzimuth(pos.latitude, a.cfg.Inclination, orbitalSpeed, equatorialSpeed, a.cfg.Descending), nil
+}
+
+// Run flies the ascent until the apoapsis reaches the target altitude, then
+// cuts the throttle. The vessel should already have been launched, and the
+// autopilot is left engaged.
+func (a *Ascent) Run(ctx context.Context) error {
+	heading, err := a.Heading()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	control, err := a.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ap, err := a.vessel.AutoPilot()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	orbit, err := a.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rf, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	flight, err := a.vessel.Flight(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	altitudes, err := flight.MeanAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer altitudes.Close()
+	speeds, err := flight.SpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer speeds.Close()
+	pressures, err := flight.DynamicPressureStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer pressures.Close()
+	apoapses, err := orbit.ApoapsisAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer apoapses.Close()
+	masses, err := a.vessel.MassStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer masses.Close()
+	thrusts, err := a.vessel.AvailableThrustStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer thrusts.Close()
+	stages, err := control.CurrentStageStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer stages.Close()
+
+	srbs, err := boosters(a.vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.Engage(); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+	var altitude, speed, q, apoapsis, mass, thrust float64
+	var received ascentFields
+	lastPitch := math.NaN()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case altitude = <-altitudes.C:
+			received |= ascentAltitude
+		case speed = <-speeds.C:
+			received |= ascentSpeed
+		case v := <-pressures.C:
+			q = float64(v)
+			received |= ascentPressure
+		case apoapsis = <-apoapses.C:
+			received |= ascentApoapsis
+			if apoapsis >= a.cfg.TargetAltitude {
+				return tracerr.Wrap(control.SetThrottle(0))
+			}
+		case v := <-masses.C:
+			mass = float64(v)
+			received |= ascentMass
+		case v := <-thrusts.C:
+			thrust = float64(v)
+			received |= ascentThrust
+		case <-stages.C:
+			// Staging may have dropped boosters.
+			if srbs, err = boosters(a.vessel); err != nil {
+				return tracerr.Wrap(err)
+			}
+		case <-ticker.C:
+			if received != allAscentFields {
+				continue
+			}
+			pitch := a.cfg.Pitch.Pitch(altitude, speed)
+			if math.IsNaN(lastPitch) || math.Abs(pitch-lastPitch) > 0.1 {
+				if err := ap.TargetPitchAndHeading(float32(pitch), float32(heading)); err != nil {
+					return tracerr.Wrap(err)
+				}
+				lastPitch = pitch
+			}
+			// Read every booster's thrust in one round trip.
+			srbThrusts, err := batchGet[float32](a.vessel.Client, srbs, "Engine_get_Thrust")
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			var boosterThrust float64
+			for _, t := range srbThrusts {
+				boosterThrust += float64(t)
+			}
+			throttle := a.cfg.Throttle.throttle(mass, boosterThrust, thrust-boosterThrust, q)
+			throttle = approachThrottle(throttle, apoapsis, a.cfg)
+			if err := control.SetThrottle(float32(throttle)); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPitchProgram(t *testing.T) {
+	turn := GravityTurn(250, 45250)
+	speedTable := PitchProgram{
+		By: PitchBySpeed,
+		Points: []PitchPoint{
+			{Key: 50, Pitch: 90},
+			{Key: 100, Pitch: 80},
+			{Key: 1500, Pitch: 10},
+		},
+	}
+	tcs := []struct {
+		name     string
+		program  PitchProgram
+		altitude float64
+		speed    float64
+		expected float64
+	}{
+		{name: "empty", altitude: 10000, expected: 90},
+		{name: "before turn", program: turn, altitude: 100, expected: 90},
+		{name: "mid turn", program: turn, altitude: 22750, expected: 45},
+		{name: "after turn", program: turn, altitude: 70000, expected: 0},
+		{name: "by speed", program: speedTable, altitude: 1e6, speed: 75, expected: 85},
+		{name: "by speed, second segment", program: speedTable, speed: 800, expected: 45},
+		{name: "by speed, past the end", program: speedTable, speed: 2000, expected: 10},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.expected, tc.program.Pitch(tc.altitude, tc.speed), 1e-9)
+		})
+	}
+}
+
+func TestThrottlePlan(t *testing.T) {
+	plan := ThrottlePlan{MaxAcceleration: 20, MaxQ: 20000, MinThrottle: 0.1}
+	tcs := []struct {
+		name     string
+		mass     float64
+		booster  float64
+		liquid   float64
+		q        float64
+		expected float64
+	}{
+		{name: "unlimited", mass: 10000, liquid: 100000, expected: 1},
+		{name: "acceleration limited", mass: 1000, liquid: 100000, expected: 0.2},
+		{name: "boosters take up the limit", mass: 2000, booster: 30000, liquid: 100000, expected: 0.1},
+		{name: "q limited", mass: 10000, booster: 50000, liquid: 100000, q: 30000, expected: 0.5},
+		{name: "boosters only", mass: 10000, booster: 100000, expected: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.expected, plan.throttle(tc.mass, tc.booster, tc.liquid, tc.q), 1e-9)
+		})
+	}
+}
+
+func TestLaunchAzimuth(t *testing.T) {
+	// Due east from the equator, whatever the rotation.
+	require.InDelta(t, 90, LaunchAzimuth(0, 0, 2300, 175, false), 1e-9)
+	// Polar orbits head north, leaning west against the rotation.
+	polar := LaunchAzimuth(0, 90, 2300, 175, false)
+	require.Less(t, polar, 360.0)
+	require.Greater(t, polar, 355.0)
+	require.InDelta(t, 180-(polar-360), LaunchAzimuth(0, 90, 2300, 175, true), 1e-9)
+	// Without rotation, the inertial azimuth is exact.
+	require.InDelta(t, 45, LaunchAzimuth(0, 45, 2300, 0, false), 1e-9)
+	// Unreachable inclinations launch due east.
+	require.InDelta(t, 90, LaunchAzimuth(30, 10, 2300, 0, false), 1e-9)
+}
+
+func TestLaunchWindowWaits(t *testing.T) {
+	// A site on the equator at +x, rotating towards +z once every 2π seconds.
+	site := types.NewVector3D(1, 0, 0)
+	velocity := types.NewVector3D(0, 0, 1)
+	// A polar orbit in the x-y plane, which the site is in now and half a
+	// turn from now.
+	normal := types.NewVector3D(0, 0, 1)
+	waits, descending, err := launchWindowWaits(site, velocity, normal)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0, math.Pi}, waits, 1e-9)
+	require.NotEqual(t, descending[0], descending[1])
+
+	// A polar orbit in the y-z plane, a quarter turn away.
+	waits, _, err = launchWindowWaits(site, velocity, types.NewVector3D(1, 0, 0))
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{math.Pi / 2, 3 * math.Pi / 2}, waits, 1e-9)
+
+	// An equatorial orbit is never reachable from a site at 45° north.
+	north := types.NewVector3D(1, 1, 0)
+	_, _, err = launchWindowWaits(north, velocity, types.NewVector3D(0, 1, 0))
+	require.Error(t, err)
+}
+
+func TestApproachThrottle(t *testing.T) {
+	cfg := AscentConfig{TargetAltitude: 100000}
+	cfg.SetDefaults()
+	require.Equal(t, 1.0, approachThrottle(1, 50000, cfg))
+	require.InDelta(t, 0.5, approachThrottle(1, 97500, cfg), 1e-9)
+	require.Equal(t, 0.05, approachThrottle(1, 100000, cfg))
+}
@@ -0,0 +1,188 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/control"
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// AttitudeConfig configures an AttitudeController.
+type AttitudeConfig struct {
+	// Interval is how often the control loops run.
+	Interval time.Duration
+	// Pitch, Yaw and Roll turn the attitude error around each axis, in
+	// degrees, into control inputs.
+	Pitch control.Gains
+	Yaw   control.Gains
+	Roll  control.Gains
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *AttitudeConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 50 * time.Millisecond
+	}
+	defaults := control.Gains{Kp: 0.05, Ki: 0.005, Kd: 0.04}
+	if cfg.Pitch == (control.Gains{}) {
+		cfg.Pitch = defaults
+	}
+	if cfg.Yaw == (control.Gains{}) {
+		cfg.Yaw = defaults
+	}
+	if cfg.Roll == (control.Gains{}) {
+		cfg.Roll = defaults
+	}
+}
+
+// attitudeError returns the rotation, in degrees, needed around each of a
+// vessel's control axes to turn it from its current rotation to a target
+// rotation, both in the same reference frame. Each is positive in the
+// direction of a positive control input: pitching up, yawing right and
+// rolling right.
+func attitudeError(current, target types.Quaternion) (pitch, yaw, roll float64) {
+	// The rotation from current to target, in the vessel's reference frame.
+	axis, angle := current.Conjugate().Mul(target).AxisAngle()
+	e := axis.Scale(units.Degrees(angle))
+	// The vessel's x-axis points right, y-axis forwards and z-axis down, so
+	// positive rotations pitch down, roll left and yaw left.
+	return -e.X, -e.Z, -e.Y
+}
+
+// pointAt returns the rotation that points a vessel along a direction, by
+// turning it the shortest way from its current rotation.
+func pointAt(current types.Quaternion, direction types.Vector3D) types.Quaternion {
+	forward := current.Rotate(types.NewVector3D(0, 1, 0))
+	return types.QuaternionBetween(forward, direction).Mul(current)
+}
+
+// AttitudeController holds a vessel's attitude by driving its pitch, yaw and
+// roll inputs directly from the rotation error, without using the stock
+// autopilot. It can be steadier than the autopilot on unusual craft, with
+// suitably tuned gains.
+type AttitudeController struct {
+	vessel         *Vessel
+	referenceFrame *ReferenceFrame
+	cfg            AttitudeConfig
+
+	mu        sync.Mutex
+	rotation  *types.Quaternion
+	direction *types.Vector3D
+	err       float64
+}
+
+// NewAttitudeController creates a new AttitudeController, with targets in a
+// reference frame.
+func NewAttitudeController(vessel *Vessel, referenceFrame *ReferenceFrame, cfg AttitudeConfig) *AttitudeController {
+	cfg.SetDefaults()
+	return &AttitudeController{
+		vessel:         vessel,
+		referenceFrame: referenceFrame,
+		cfg:            cfg,
+		err:            math.NaN(),
+	}
+}
+
+// SetTargetRotation sets the rotation to hold.
+func (a *AttitudeController) SetTargetRotation(rotation types.Quaternion) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rotation = &rotation
+	a.direction = nil
+}
+
+// SetTargetDirection sets the direction to point the vessel in. Roll is left
+// free.
+func (a *AttitudeController) SetTargetDirection(direction types.Vector3D) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.direction = &direction
+	a.rotation = nil
+}
+
+// Error returns the latest angle, in degrees, between the vessel's rotation
+// and the target, or NaN if there is none yet.
+func (a *AttitudeController) Error() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// target returns the rotation to turn to from the current rotation, if
+// there is a target.
+func (a *AttitudeController) target(current types.Quaternion) (types.Quaternion, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case a.rotation != nil:
+		return *a.rotation, true
+	case a.direction != nil:
+		return pointAt(current, *a.direction), true
+	}
+	return types.Quaternion{}, false
+}
+
+// Run holds the target attitude until the context is canceled, then centers
+// the controls. The stock autopilot should be disengaged.
+func (a *AttitudeController) Run(ctx context.Context) error {
+	ctrl, err := a.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rotations, err := a.vessel.RotationStream(a.referenceFrame)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer rotations.Close()
+	defer func() {
+		_ = ctrl.SetPitch(0)
+		_ = ctrl.SetYaw(0)
+		_ = ctrl.SetRoll(0)
+	}()
+
+	pitch := control.New(a.cfg.Pitch, -1, 1)
+	yaw := control.New(a.cfg.Yaw, -1, 1)
+	roll := control.New(a.cfg.Roll, -1, 1)
+
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+	var current *types.Quaternion
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v := <-rotations.C:
+			q := types.QuaternionFromTuple(v)
+			current = &q
+		case now := <-ticker.C:
+			dt := now.Sub(last).Seconds()
+			last = now
+			if current == nil {
+				continue
+			}
+			target, ok := a.target(*current)
+			if !ok {
+				continue
+			}
+			ep, ey, er := attitudeError(*current, target)
+			a.mu.Lock()
+			a.err = math.Sqrt(ep*ep + ey*ey + er*er)
+			a.mu.Unlock()
+			if err := ctrl.SetPitch(float32(pitch.UpdateError(ep, dt))); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := ctrl.SetYaw(float32(yaw.UpdateError(ey, dt))); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := ctrl.SetRoll(float32(roll.UpdateError(er, dt))); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttitudeError(t *testing.T) {
+	turn := func(x, y, z float64) types.Quaternion {
+		return types.QuaternionFromAxisAngle(types.NewVector3D(x, y, z), units.Radians(10))
+	}
+	tilted := types.QuaternionFromAxisAngle(types.NewVector3D(1, 2, 3), 2)
+	tcs := []struct {
+		name     string
+		current  types.Quaternion
+		target   types.Quaternion
+		expected [3]float64
+	}{
+		{name: "on target", current: tilted, target: tilted},
+		// Nose (+y) towards up (-z).
+		{name: "pitch up", current: types.IdentityQuaternion(), target: turn(-1, 0, 0), expected: [3]float64{10, 0, 0}},
+		// Nose (+y) towards right (+x).
+		{name: "yaw right", current: types.IdentityQuaternion(), target: turn(0, 0, -1), expected: [3]float64{0, 10, 0}},
+		// Right wing (+x) towards down (+z).
+		{name: "roll right", current: types.IdentityQuaternion(), target: turn(0, -1, 0), expected: [3]float64{0, 0, 10}},
+		// Errors are relative to the vessel, not the reference frame.
+		{name: "pitch up when tilted", current: tilted, target: tilted.Mul(turn(-1, 0, 0)), expected: [3]float64{10, 0, 0}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pitch, yaw, roll := attitudeError(tc.current, tc.target)
+			require.InDeltaSlice(t, tc.expected[:], []float64{pitch, yaw, roll}, 1e-6)
+		})
+	}
+}
+
+func TestPointAt(t *testing.T) {
+	current := types.QuaternionFromAxisAngle(types.NewVector3D(1, 2, 3), 2)
+	direction := types.NewVector3D(-1, 0.5, 2)
+	target := pointAt(current, direction)
+	forward := target.Rotate(types.NewVector3D(0, 1, 0))
+	require.InDelta(t, 0, forward.AngleBetween(direction), 1e-6)
+
+	// Only pitch and yaw are needed to get there.
+	_, _, roll := attitudeError(current, target)
+	require.InDelta(t, 0, roll, 1e-6)
+	require.False(t, math.IsNaN(roll))
+}
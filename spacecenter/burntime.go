@@ -0,0 +1,295 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/ztrue/tracerr"
+)
+
+// standardGravity converts specific impulse in seconds into exhaust velocity,
+// in m/s².
+const standardGravity = 9.80665
+
+// BurnStage is the performance of a vessel while one stage is active.
+type BurnStage struct {
+	Stage int32
+	// Mass is the vessel's mass when the stage is activated, in kg.
+	Mass float64
+	// DryMass is the vessel's mass once the stage's fuel is spent, before the
+	// next stage is activated, in kg.
+	DryMass float64
+	// Thrust is the combined thrust of the stage's engines, in N.
+	Thrust float64
+	// SpecificImpulse is the combined specific impulse of the stage's
+	// engines, in s.
+	SpecificImpulse float64
+}
+
+// exhaustVelocity returns the stage's effective exhaust velocity, in m/s.
+func (s BurnStage) exhaustVelocity() float64 {
+	return s.SpecificImpulse * standardGravity
+}
+
+// DeltaV returns the delta-v the stage can provide, in m/s.
+func (s BurnStage) DeltaV() float64 {
+	if s.Thrust <= 0 || s.SpecificImpulse <= 0 || s.DryMass <= 0 {
+		return 0
+	}
+	return s.exhaustVelocity() * math.Log(s.Mass/s.DryMass)
+}
+
+// BurnSegment is the part of a burn made by one stage.
+type BurnSegment struct {
+	Stage int32
+	// DeltaV is the delta-v made by the stage, in m/s.
+	DeltaV float64
+	// Duration is how long the stage burns for, in seconds.
+	Duration float64
+}
+
+// BurnEstimate is the estimated duration of a burn at full throttle.
+type BurnEstimate struct {
+	// Duration is the total burn time, in seconds, not counting any time
+	// spent staging.
+	Duration float64
+	// HalfDuration is how long it takes to make half the delta-v, in seconds.
+	// Starting the burn this long before a maneuver node centers it on the
+	// node.
+	HalfDuration float64
+	// Segments are the burns made by each stage, in order.
+	Segments []BurnSegment
+	// Shortfall is the delta-v, in m/s, that the vessel doesn't have the fuel
+	// for. The rest of the estimate covers as much of the burn as it can.
+	Shortfall float64
+}
+
+// combineEngines returns the thrust and specific impulse of a cluster of
+// engines burning together. The combined specific impulse is the thrust
+// divided by the total weight flow, so weaker engines count for less.
+func combineEngines(thrusts, isps []float64) (thrust, isp float64) {
+	var flow float64
+	for i, f := range thrusts {
+		if f <= 0 || isps[i] <= 0 {
+			continue
+		}
+		thrust += f
+		flow += f / isps[i]
+	}
+	if flow == 0 {
+		return 0, 0
+	}
+	return thrust, thrust / flow
+}
+
+// burnSegments splits a burn across stages, returning the segments and the
+// delta-v left over once every stage is spent.
+func burnSegments(stages []BurnStage, deltaV float64) ([]BurnSegment, float64) {
+	var segments []BurnSegment
+	remaining := deltaV
+	for _, stage := range stages {
+		if remaining <= 0 {
+			break
+		}
+		available := stage.DeltaV()
+		if available <= 0 {
+			continue
+		}
+		dv := math.Min(remaining, available)
+		ve := stage.exhaustVelocity()
+		// The rocket equation gives the mass at the end of the segment, and
+		// the fuel burns at a constant rate.
+		end := stage.Mass / math.Exp(dv/ve)
+		segments = append(segments, BurnSegment{
+			Stage:    stage.Stage,
+			DeltaV:   dv,
+			Duration: (stage.Mass - end) * ve / stage.Thrust,
+		})
+		remaining -= dv
+	}
+	return segments, math.Max(0, remaining)
+}
+
+// EstimateBurn estimates how long a burn takes at full throttle, given the
+// vessel's stages in the order they'll be activated, starting with the
+// current one. Stages are dropped as their fuel runs out, splitting the burn.
+func EstimateBurn(stages []BurnStage, deltaV float64) BurnEstimate {
+	var estimate BurnEstimate
+	estimate.Segments, estimate.Shortfall = burnSegments(stages, deltaV)
+	for _, s := range estimate.Segments {
+		estimate.Duration += s.Duration
+	}
+	half, _ := burnSegments(stages, deltaV/2)
+	for _, s := range half {
+		estimate.HalfDuration += s.Duration
+	}
+	return estimate
+}
+
+// burnEngine is the vacuum performance of an engine.
+type burnEngine struct {
+	thrust      float64
+	isp         float64
+	propellants []string
+}
+
+// burnPart is what the burn estimate needs to know about a part.
+type burnPart struct {
+	stage         int32
+	decoupleStage int32
+	mass          float64
+	// resources maps the name of each resource the part holds to its mass,
+	// in kg.
+	resources map[string]float64
+	engine    *burnEngine
+}
+
+// burnStages works out the stages of a vessel from its parts, starting with
+// the current stage. A part is attached until its decouple stage is
+// activated, and an engine burns from its own stage onwards. Each stage is
+// assumed to burn the fuel in the parts dropped by the next one, or in all
+// the remaining parts for the last stage.
+func burnStages(parts []burnPart, current int32) []BurnStage {
+	var stages []BurnStage
+	for s := current; s >= 0; s-- {
+		stage := BurnStage{Stage: s}
+		var thrusts, isps []float64
+		propellants := make(map[string]bool)
+		for _, p := range parts {
+			if p.decoupleStage >= s {
+				continue
+			}
+			stage.Mass += p.mass
+			if p.engine != nil && p.stage >= s {
+				thrusts = append(thrusts, p.engine.thrust)
+				isps = append(isps, p.engine.isp)
+				for _, name := range p.engine.propellants {
+					propellants[name] = true
+				}
+			}
+		}
+		var fuel float64
+		for _, p := range parts {
+			if p.decoupleStage >= s || (s > 0 && p.decoupleStage != s-1) {
+				continue
+			}
+			for name, mass := range p.resources {
+				if propellants[name] {
+					fuel += mass
+				}
+			}
+		}
+		stage.DryMass = stage.Mass - fuel
+		stage.Thrust, stage.SpecificImpulse = combineEngines(thrusts, isps)
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// getBurnPart fetches the details of a part needed for a burn estimate.
+func getBurnPart(part *Part) (burnPart, error) {
+	var p burnPart
+	var err error
+	if p.stage, err = part.Stage(); err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	if p.decoupleStage, err = part.DecoupleStage(); err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	if p.mass, err = part.Mass(); err != nil {
+		return p, tracerr.Wrap(err)
+	}
+
+	resources, err := part.Resources()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	all, err := resources.All()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	p.resources = make(map[string]float64, len(all))
+	for _, r := range all {
+		name, err := r.Name()
+		if err != nil {
+			return p, tracerr.Wrap(err)
+		}
+		amount, err := r.Amount()
+		if err != nil {
+			return p, tracerr.Wrap(err)
+		}
+		density, err := r.Density()
+		if err != nil {
+			return p, tracerr.Wrap(err)
+		}
+		p.resources[name] += float64(amount * density)
+	}
+
+	engine, err := part.Engine()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	if engine == nil {
+		return p, nil
+	}
+	thrust, err := engine.MaxVacuumThrust()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	limit, err := engine.ThrustLimit()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	isp, err := engine.VacuumSpecificImpulse()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	propellants, err := engine.PropellantNames()
+	if err != nil {
+		return p, tracerr.Wrap(err)
+	}
+	p.engine = &burnEngine{
+		thrust:      float64(thrust * limit),
+		isp:         float64(isp),
+		propellants: propellants,
+	}
+	return p, nil
+}
+
+// VesselBurnStages returns the stages of a vessel, starting with the current
+// one, for estimating burns. Engines are assumed to run at their vacuum
+// performance, within their thrust limits.
+func VesselBurnStages(vessel *Vessel) ([]BurnStage, error) {
+	control, err := vessel.Control()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	current, err := control.CurrentStage()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	parts, err := vessel.Parts()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	all, err := parts.All()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	burnParts := make([]burnPart, len(all))
+	for i, part := range all {
+		if burnParts[i], err = getBurnPart(part); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return burnStages(burnParts, current), nil
+}
+
+// EstimateVesselBurn estimates how long a burn takes a vessel at full
+// throttle, staging as needed.
+func EstimateVesselBurn(vessel *Vessel, deltaV float64) (BurnEstimate, error) {
+	stages, err := VesselBurnStages(vessel)
+	if err != nil {
+		return BurnEstimate{}, tracerr.Wrap(err)
+	}
+	return EstimateBurn(stages, deltaV), nil
+}
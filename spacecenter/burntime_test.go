@@ -0,0 +1,107 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineEngines(t *testing.T) {
+	tcs := []struct {
+		name          string
+		thrusts, isps []float64
+		thrust, isp   float64
+	}{
+		{name: "no engines"},
+		{name: "single engine", thrusts: []float64{200e3}, isps: []float64{320}, thrust: 200e3, isp: 320},
+		{name: "matching cluster", thrusts: []float64{60e3, 60e3, 60e3}, isps: []float64{345, 345, 345}, thrust: 180e3, isp: 345},
+		{
+			// 100 kN at 300 s and 300 kN at 400 s: 400 / (1/3 + 3/4) kN/s.
+			name:    "mixed cluster",
+			thrusts: []float64{100e3, 300e3},
+			isps:    []float64{300, 400},
+			thrust:  400e3,
+			isp:     400 / (1.0/3 + 3.0/4),
+		},
+		{name: "engine without thrust", thrusts: []float64{0, 50e3}, isps: []float64{300, 250}, thrust: 50e3, isp: 250},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			thrust, isp := combineEngines(tc.thrusts, tc.isps)
+			require.InDelta(t, tc.thrust, thrust, 1e-6)
+			require.InDelta(t, tc.isp, isp, 1e-6)
+		})
+	}
+}
+
+func TestEstimateBurn(t *testing.T) {
+	upper := BurnStage{Stage: 1, Mass: 10000, DryMass: 5000, Thrust: 200e3, SpecificImpulse: 350}
+	lower := BurnStage{Stage: 0, Mass: 3000, DryMass: 2000, Thrust: 50e3, SpecificImpulse: 300}
+	upperDV := upper.DeltaV()
+	lowerDV := lower.DeltaV()
+
+	t.Run("single stage", func(t *testing.T) {
+		estimate := EstimateBurn([]BurnStage{upper}, 1000)
+		ve := 350 * standardGravity
+		end := 10000 / math.Exp(1000/ve)
+		require.InDelta(t, (10000-end)*ve/200e3, estimate.Duration, 1e-9)
+		require.Zero(t, estimate.Shortfall)
+		require.Len(t, estimate.Segments, 1)
+		// The vessel gets lighter, so the second half of the burn is quicker.
+		require.Greater(t, estimate.HalfDuration, estimate.Duration/2)
+	})
+
+	t.Run("split across stages", func(t *testing.T) {
+		estimate := EstimateBurn([]BurnStage{upper, lower}, upperDV+100)
+		require.Len(t, estimate.Segments, 2)
+		require.InDelta(t, upperDV, estimate.Segments[0].DeltaV, 1e-9)
+		require.InDelta(t, 100, estimate.Segments[1].DeltaV, 1e-9)
+		require.Equal(t, int32(0), estimate.Segments[1].Stage)
+		// The first stage burns all of its fuel.
+		require.InDelta(t, 5000*upper.exhaustVelocity()/upper.Thrust, estimate.Segments[0].Duration, 1e-9)
+		require.InDelta(t, estimate.Segments[0].Duration+estimate.Segments[1].Duration, estimate.Duration, 1e-9)
+		require.Zero(t, estimate.Shortfall)
+	})
+
+	t.Run("fuel limited", func(t *testing.T) {
+		estimate := EstimateBurn([]BurnStage{upper, lower}, upperDV+lowerDV+250)
+		require.InDelta(t, 250, estimate.Shortfall, 1e-9)
+		require.Len(t, estimate.Segments, 2)
+	})
+
+	t.Run("stage without engines", func(t *testing.T) {
+		empty := BurnStage{Stage: 2, Mass: 12000, DryMass: 12000}
+		estimate := EstimateBurn([]BurnStage{empty, upper}, 500)
+		require.Len(t, estimate.Segments, 1)
+		require.Equal(t, int32(1), estimate.Segments[0].Stage)
+	})
+}
+
+func TestBurnStages(t *testing.T) {
+	// A two stage rocket: a lower stage on stage 1, dropped by a decoupler
+	// on stage 0 to leave a small upper stage.
+	parts := []burnPart{
+		{stage: -1, decoupleStage: -1, mass: 1000},
+		{stage: -1, decoupleStage: -1, mass: 2000, resources: map[string]float64{"LiquidFuel": 800, "Oxidizer": 1000, "MonoPropellant": 100}},
+		{stage: 0, decoupleStage: -1, mass: 500, engine: &burnEngine{thrust: 60e3, isp: 345, propellants: []string{"LiquidFuel", "Oxidizer"}}},
+		{stage: 0, decoupleStage: 0, mass: 50},
+		{stage: -1, decoupleStage: 0, mass: 9000, resources: map[string]float64{"LiquidFuel": 3600, "Oxidizer": 4400}},
+		{stage: 1, decoupleStage: 0, mass: 1500, engine: &burnEngine{thrust: 200e3, isp: 320, propellants: []string{"LiquidFuel", "Oxidizer"}}},
+	}
+	stages := burnStages(parts, 1)
+	require.Len(t, stages, 2)
+
+	require.Equal(t, int32(1), stages[0].Stage)
+	require.InDelta(t, 14050, stages[0].Mass, 1e-9)
+	require.InDelta(t, 14050-8000, stages[0].DryMass, 1e-9)
+	require.InDelta(t, 200e3, stages[0].Thrust, 1e-9)
+	require.InDelta(t, 320, stages[0].SpecificImpulse, 1e-9)
+
+	require.Equal(t, int32(0), stages[1].Stage)
+	require.InDelta(t, 3500, stages[1].Mass, 1e-9)
+	// The monopropellant isn't burnt by the engine.
+	require.InDelta(t, 3500-1800, stages[1].DryMass, 1e-9)
+	require.InDelta(t, 60e3, stages[1].Thrust, 1e-9)
+	require.InDelta(t, 345, stages[1].SpecificImpulse, 1e-9)
+}
@@ -0,0 +1,200 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/ztrue/tracerr"
+)
+
+// CameraKeyframe is the state of the camera at a point in time.
+type CameraKeyframe struct {
+	// UT is the universal time of the keyframe, in seconds.
+	UT float64
+	// Pitch is the pitch of the camera, in degrees.
+	Pitch float64
+	// Heading is the heading of the camera, in degrees.
+	Heading float64
+	// Distance is the distance from the camera to the subject, in meters.
+	// Moving the camera closer or further away is the closest the Camera API
+	// has to changing the field of view.
+	Distance float64
+}
+
+// CameraPath is a smooth path through a set of keyframes.
+type CameraPath struct {
+	keyframes []CameraKeyframe
+}
+
+// NewCameraPath creates a new CameraPath. Keyframes may be given in any
+// order.
+func NewCameraPath(keyframes ...CameraKeyframe) *CameraPath {
+	kf := make([]CameraKeyframe, len(keyframes))
+	copy(kf, keyframes)
+	sort.SliceStable(kf, func(i, j int) bool {
+		return kf[i].UT < kf[j].UT
+	})
+	// Unwrap headings so that the camera always turns the short way around.
+	for i := 1; i < len(kf); i++ {
+		delta := math.Mod(kf[i].Heading-kf[i-1].Heading, 360)
+		if delta > 180 {
+			delta -= 360
+		} else if delta < -180 {
+			delta += 360
+		}
+		kf[i].Heading = kf[i-1].Heading + delta
+	}
+	return &CameraPath{keyframes: kf}
+}
+
+// Start returns the time of the first keyframe.
+func (p *CameraPath) Start() float64 {
+	if len(p.keyframes) == 0 {
+		return 0
+	}
+	return p.keyframes[0].UT
+}
+
+// End returns the time of the last keyframe.
+func (p *CameraPath) End() float64 {
+	if len(p.keyframes) == 0 {
+		return 0
+	}
+	return p.keyframes[len(p.keyframes)-1].UT
+}
+
+// At returns the interpolated camera state at a point in time. Times before
+// the first keyframe or after the last are clamped to the path.
+func (p *CameraPath) At(ut float64) CameraKeyframe {
+	kf := p.keyframes
+	switch {
+	case len(kf) == 0:
+		return CameraKeyframe{UT: ut}
+	case ut <= kf[0].UT:
+		return p.normalize(kf[0], ut)
+	case ut >= kf[len(kf)-1].UT:
+		return p.normalize(kf[len(kf)-1], ut)
+	}
+
+	// i is the index of the first keyframe after ut.
+	i := sort.Search(len(kf), func(i int) bool { return kf[i].UT > ut })
+	k0, k1, k2, k3 := kf[i-1], kf[i-1], kf[i], kf[i]
+	if i >= 2 {
+		k0 = kf[i-2]
+	}
+	if i+1 < len(kf) {
+		k3 = kf[i+1]
+	}
+	t := (ut - k1.UT) / (k2.UT - k1.UT)
+	return p.normalize(CameraKeyframe{
+		Pitch:    catmullRom(k0.Pitch, k1.Pitch, k2.Pitch, k3.Pitch, t),
+		Heading:  catmullRom(k0.Heading, k1.Heading, k2.Heading, k3.Heading, t),
+		Distance: catmullRom(k0.Distance, k1.Distance, k2.Distance, k3.Distance, t),
+	}, ut)
+}
+
+func (p *CameraPath) normalize(k CameraKeyframe, ut float64) CameraKeyframe {
+	k.UT = ut
+	k.Heading = math.Mod(k.Heading, 360)
+	if k.Heading < 0 {
+		k.Heading += 360
+	}
+	return k
+}
+
+// catmullRom interpolates between p1 and p2 using a Catmull-Rom spline, so
+// that the camera moves smoothly through each keyframe.
+func catmullRom(p0, p1, p2, p3, t float64) float64 {
+	t2 := t * t
+	t3 := t2 * t
+	return 0.5 * (2*p1 +
+		(p2-p0)*t +
+		(2*p0-5*p1+4*p2-p3)*t2 +
+		(3*p1-p0-3*p2+p3)*t3)
+}
+
+// CameraController moves the game camera along keyframed paths.
+type CameraController struct {
+	sc     *SpaceCenter
+	camera *Camera
+}
+
+// NewCameraController creates a new CameraController.
+func NewCameraController(sc *SpaceCenter) (*CameraController, error) {
+	camera, err := sc.Camera()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &CameraController{sc: sc, camera: camera}, nil
+}
+
+// Camera returns the camera being controlled.
+func (c *CameraController) Camera() *Camera {
+	return c.camera
+}
+
+// FollowVessel focuses the camera on a vessel.
+func (c *CameraController) FollowVessel(vessel *Vessel) error {
+	return tracerr.Wrap(c.camera.SetFocussedVessel(vessel))
+}
+
+// FollowPart focuses the camera on the vessel a part belongs to. The Camera
+// API can't focus on individual parts.
+func (c *CameraController) FollowPart(part *Part) error {
+	vessel, err := part.Vessel()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(c.FollowVessel(vessel))
+}
+
+// Apply moves the camera to a keyframe. The distance is clamped to the range
+// the camera allows.
+func (c *CameraController) Apply(k CameraKeyframe) error {
+	minDistance, err := c.camera.MinDistance()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	maxDistance, err := c.camera.MaxDistance()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	distance := math.Max(float64(minDistance), math.Min(float64(maxDistance), k.Distance))
+	if err := c.camera.SetPitch(float32(k.Pitch)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := c.camera.SetHeading(float32(k.Heading)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(c.camera.SetDistance(float32(distance)))
+}
+
+// Play moves the camera along a path as game time passes, returning once the
+// end of the path is reached or the context is done. Playback follows
+// universal time, so it keeps pace with time warp and stops while the game
+// is paused.
+func (c *CameraController) Play(ctx context.Context, path *CameraPath) error {
+	ut, err := c.sc.UTStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer ut.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case now := <-ut.C:
+			if now < path.Start() {
+				continue
+			}
+			if err := c.Apply(path.At(now)); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if now >= path.End() {
+				return nil
+			}
+		}
+	}
+}
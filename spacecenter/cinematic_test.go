@@ -0,0 +1,63 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCameraPath(t *testing.T) {
+	path := NewCameraPath(
+		CameraKeyframe{UT: 20, Pitch: 30, Heading: 10, Distance: 200},
+		CameraKeyframe{UT: 10, Pitch: 10, Heading: 350, Distance: 100},
+	)
+	require.Equal(t, 10.0, path.Start())
+	require.Equal(t, 20.0, path.End())
+
+	tests := []struct {
+		name     string
+		ut       float64
+		expected CameraKeyframe
+	}{
+		{
+			name:     "before start",
+			ut:       0,
+			expected: CameraKeyframe{UT: 0, Pitch: 10, Heading: 350, Distance: 100},
+		},
+		{
+			name:     "after end",
+			ut:       30,
+			expected: CameraKeyframe{UT: 30, Pitch: 30, Heading: 10, Distance: 200},
+		},
+		{
+			name:     "midpoint wraps heading the short way",
+			ut:       15,
+			expected: CameraKeyframe{UT: 15, Pitch: 20, Heading: 0, Distance: 150},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k := path.At(tc.ut)
+			require.Equal(t, tc.expected.UT, k.UT)
+			require.InDelta(t, tc.expected.Pitch, k.Pitch, 1e-9)
+			require.InDelta(t, tc.expected.Heading, k.Heading, 1e-9)
+			require.InDelta(t, tc.expected.Distance, k.Distance, 1e-9)
+		})
+	}
+}
+
+func TestCameraPathPassesThroughKeyframes(t *testing.T) {
+	keyframes := []CameraKeyframe{
+		{UT: 0, Pitch: 0, Heading: 0, Distance: 50},
+		{UT: 5, Pitch: 40, Heading: 90, Distance: 80},
+		{UT: 7, Pitch: -10, Heading: 180, Distance: 20},
+		{UT: 12, Pitch: 5, Heading: 270, Distance: 60},
+	}
+	path := NewCameraPath(keyframes...)
+	for _, k := range keyframes {
+		actual := path.At(k.UT)
+		require.InDelta(t, k.Pitch, actual.Pitch, 1e-9)
+		require.InDelta(t, k.Heading, actual.Heading, 1e-9)
+		require.InDelta(t, k.Distance, actual.Distance, 1e-9)
+	}
+}
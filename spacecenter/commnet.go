@@ -0,0 +1,264 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// SignalHop is one link in a CommNet signal path.
+type SignalHop struct {
+	// From is the name of the node the link starts at.
+	From string
+	// To is the name of the node the link ends at.
+	To string
+	// Type is the type of link.
+	Type CommLinkType
+	// Strength is the signal strength of the link, between 0 and 1.
+	Strength float64
+}
+
+// SignalPath is the path a vessel's signal takes to a control point.
+type SignalPath struct {
+	// Hops are the links in the path, starting at the vessel.
+	Hops []SignalHop
+	// Strength is the overall signal strength, between 0 and 1.
+	Strength float64
+	// Delay is the signal delay, in seconds.
+	Delay float64
+	// CanCommunicate is whether the vessel can communicate with a control
+	// point.
+	CanCommunicate bool
+}
+
+// WeakestHop returns the hop with the lowest signal strength. Returns false
+// if the path has no hops.
+func (p *SignalPath) WeakestHop() (SignalHop, bool) {
+	if len(p.Hops) == 0 {
+		return SignalHop{}, false
+	}
+	weakest := p.Hops[0]
+	for _, hop := range p.Hops[1:] {
+		if hop.Strength < weakest.Strength {
+			weakest = hop
+		}
+	}
+	return weakest, true
+}
+
+// Path gets the current signal path from the vessel to a control point.
+func (s *Comms) Path() (*SignalPath, error) {
+	var path SignalPath
+	var err error
+	if path.CanCommunicate, err = s.CanCommunicate(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if path.Strength, err = s.SignalStrength(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if path.Delay, err = s.SignalDelay(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	links, err := s.ControlPath()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, link := range links {
+		hop, err := newSignalHop(link)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		path.Hops = append(path.Hops, hop)
+	}
+	return &path, nil
+}
+
+func newSignalHop(link *CommLink) (SignalHop, error) {
+	var hop SignalHop
+	var err error
+	if hop.Type, err = link.Type(); err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	if hop.Strength, err = link.SignalStrength(); err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	start, err := link.Start()
+	if err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	if hop.From, err = start.Name(); err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	end, err := link.End()
+	if err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	if hop.To, err = end.Name(); err != nil {
+		return hop, tracerr.Wrap(err)
+	}
+	return hop, nil
+}
+
+// BlackoutWindow is a period when a body blocks the line of sight between a
+// vessel and its home body.
+type BlackoutWindow struct {
+	// Start and End are the universal times the blackout starts and ends.
+	Start, End float64
+	// Occluder is the name of the body blocking the signal.
+	Occluder string
+}
+
+// occluder is a body that can block line of sight.
+type occluder struct {
+	name   string
+	radius float64
+	// positionAt returns the body's position relative to the home body.
+	positionAt func(ut float64) (types.Vector3D, error)
+}
+
+// PredictBlackouts predicts when the line of sight between a vessel and its
+// home body (e.g. Kerbin, where the ground stations are) will be blocked by
+// another body, by sampling the vessel's orbit every step seconds between
+// start and end. The home body itself is never treated as blocking, since
+// ground stations cover it on all sides. Relays are not taken into account.
+func PredictBlackouts(vessel *Vessel, home *CelestialBody, bodies []*CelestialBody, start, end, step float64) ([]BlackoutWindow, error) {
+	if step <= 0 {
+		return nil, tracerr.Errorf("Step must be positive")
+	}
+	rf, err := home.NonRotatingReferenceFrame()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var occluders []occluder
+	for _, body := range occludingBodies(bodies, home) {
+		o, err := newOccluder(body, home, rf)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		occluders = append(occluders, o)
+	}
+	vesselAt, err := orbitPositions(orbit, home, rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return predictBlackouts(vesselAt, occluders, start, end, step)
+}
+
+// orbitPositions returns a function giving positions on an orbit in a
+// reference frame. Orbits around the frame's body are propagated locally,
+// since the positions are sampled many times; others are asked for from the
+// server.
+func orbitPositions(orbit *Orbit, home *CelestialBody, rf *ReferenceFrame) (func(ut float64) (types.Vector3D, error), error) {
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if body.ID_internal() == home.ID_internal() {
+		kepler, err := NewKeplerOrbit(orbit, rf)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		return func(ut float64) (types.Vector3D, error) {
+			return kepler.PositionAt(ut), nil
+		}, nil
+	}
+	return func(ut float64) (types.Vector3D, error) {
+		p, err := orbit.PositionAt(ut, rf)
+		return types.Vector3DFromTuple(p), tracerr.Wrap(err)
+	}, nil
+}
+
+// occludingBodies returns the bodies that can block line of sight to the
+// home body, which is every one of them except the home body itself.
+func occludingBodies(bodies []*CelestialBody, home *CelestialBody) []*CelestialBody {
+	var occluding []*CelestialBody
+	for _, body := range bodies {
+		if body.ID_internal() != home.ID_internal() {
+			occluding = append(occluding, body)
+		}
+	}
+	return occluding
+}
+
+func newOccluder(body, home *CelestialBody, rf *ReferenceFrame) (occluder, error) {
+	o := occluder{}
+	var err error
+	if o.name, err = body.Name(); err != nil {
+		return o, tracerr.Wrap(err)
+	}
+	radius, err := body.EquatorialRadius()
+	if err != nil {
+		return o, tracerr.Wrap(err)
+	}
+	o.radius = float64(radius)
+	orbit, err := body.Orbit()
+	if err != nil {
+		return o, tracerr.Wrap(err)
+	}
+	if orbit == nil {
+		// The sun doesn't orbit anything, so it stays where it is.
+		p, err := body.Position(rf)
+		if err != nil {
+			return o, tracerr.Wrap(err)
+		}
+		o.positionAt = func(float64) (types.Vector3D, error) {
+			return types.Vector3DFromTuple(p), nil
+		}
+		return o, nil
+	}
+	o.positionAt, err = orbitPositions(orbit, home, rf)
+	return o, tracerr.Wrap(err)
+}
+
+func predictBlackouts(vesselAt func(ut float64) (types.Vector3D, error), occluders []occluder, start, end, step float64) ([]BlackoutWindow, error) {
+	var windows []BlackoutWindow
+	// open holds the index in windows of each occluder's open window, or -1.
+	open := make([]int, len(occluders))
+	for i := range open {
+		open[i] = -1
+	}
+	home := types.Vector3D{}
+	for ut := start; ut <= end; ut += step {
+		v, err := vesselAt(ut)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		for i, o := range occluders {
+			center, err := o.positionAt(ut)
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			blocked := segmentIntersectsSphere(v, home, center, o.radius)
+			switch {
+			case blocked && open[i] < 0:
+				windows = append(windows, BlackoutWindow{Start: ut, End: ut, Occluder: o.name})
+				open[i] = len(windows) - 1
+			case blocked:
+				windows[open[i]].End = ut
+			case open[i] >= 0:
+				windows[open[i]].End = ut
+				open[i] = -1
+			}
+		}
+	}
+	return windows, nil
+}
+
+// segmentIntersectsSphere returns true if the line segment from a to b passes
+// through a sphere.
+func segmentIntersectsSphere(a, b, center types.Vector3D, radius float64) bool {
+	ab := b.Add(a.Scale(-1))
+	ac := center.Add(a.Scale(-1))
+	lengthSquared := ab.Dot(ab)
+	t := 0.0
+	if lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, ac.Dot(ab)/lengthSquared))
+	}
+	closest := a.Add(ab.Scale(t))
+	return closest.Add(center.Scale(-1)).Length() < radius
+}
@@ -0,0 +1,65 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentIntersectsSphere(t *testing.T) {
+	a := types.NewVector3D(-10, 0, 0)
+	b := types.NewVector3D(10, 0, 0)
+	require.True(t, segmentIntersectsSphere(a, b, types.NewVector3D(0, 1, 0), 2))
+	require.False(t, segmentIntersectsSphere(a, b, types.NewVector3D(0, 3, 0), 2))
+	// Beyond the end of the segment.
+	require.False(t, segmentIntersectsSphere(a, b, types.NewVector3D(15, 0, 0), 2))
+}
+
+func TestPredictBlackouts(t *testing.T) {
+	// A vessel in a circular orbit of radius 100 around the home body, with
+	// a moon sitting still at a distance of 50 along the x axis.
+	vesselAt := func(ut float64) (types.Vector3D, error) {
+		return types.NewVector3D(100*math.Cos(ut), 100*math.Sin(ut), 0), nil
+	}
+	moon := occluder{
+		name:   "Mun",
+		radius: 10,
+		positionAt: func(float64) (types.Vector3D, error) {
+			return types.NewVector3D(50, 0, 0), nil
+		},
+	}
+
+	windows, err := predictBlackouts(vesselAt, []occluder{moon}, -1, 2*math.Pi-1, 0.01)
+	require.NoError(t, err)
+	require.Len(t, windows, 1)
+	require.Equal(t, "Mun", windows[0].Occluder)
+	// The moon covers angles within asin(10/50) of the x axis.
+	halfWidth := math.Asin(10.0 / 50)
+	require.InDelta(t, -halfWidth, windows[0].Start, 0.02)
+	require.InDelta(t, halfWidth, windows[0].End, 0.02)
+}
+
+func TestOccludingBodies(t *testing.T) {
+	sun, kerbin, mun := NewCelestialBody(1, nil), NewCelestialBody(2, nil), NewCelestialBody(3, nil)
+	// A separate handle for the home body, as from a different call.
+	home := NewCelestialBody(2, nil)
+
+	occluding := occludingBodies([]*CelestialBody{sun, kerbin, mun}, home)
+	require.Equal(t, []*CelestialBody{sun, mun}, occluding)
+}
+
+func TestWeakestHop(t *testing.T) {
+	path := &SignalPath{}
+	_, ok := path.WeakestHop()
+	require.False(t, ok)
+
+	path.Hops = []SignalHop{
+		{From: "Vessel", To: "Relay", Strength: 0.8},
+		{From: "Relay", To: "Kerbin", Strength: 0.3},
+	}
+	hop, ok := path.WeakestHop()
+	require.True(t, ok)
+	require.Equal(t, "Relay", hop.From)
+}
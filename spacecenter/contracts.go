@@ -0,0 +1,253 @@
+package spacecenter
+
+import (
+	"fmt"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ErrContractAction is returned when a contract can't be accepted, declined
+// or canceled in its current state.
+type ErrContractAction struct {
+	Action string
+	Title  string
+	Reason string
+}
+
+// Error returns a human-readable error.
+func (err ErrContractAction) Error() string {
+	return fmt.Sprintf("Can't %v contract %q: %v", err.Action, err.Title, err.Reason)
+}
+
+// ContractParameterInfo is a snapshot of a contract parameter.
+type ContractParameterInfo struct {
+	Title     string
+	Notes     string
+	Completed bool
+	Failed    bool
+	Optional  bool
+	Children  []ContractParameterInfo
+}
+
+// ContractInfo is a snapshot of a contract and its parameters.
+type ContractInfo struct {
+	Contract *Contract
+
+	Type        string
+	Title       string
+	Synopsis    string
+	Description string
+	State       ContractState
+
+	FundsAdvance         float64
+	FundsCompletion      float64
+	FundsFailure         float64
+	ReputationCompletion float64
+	ReputationFailure    float64
+	ScienceCompletion    float64
+
+	CanBeCanceled bool
+	CanBeDeclined bool
+
+	Parameters []ContractParameterInfo
+}
+
+// Progress counts the required parameters of a contract, and how many of
+// them are complete. Parameters with children are counted through their
+// children. Contracts can't be completed through kRPC; the game completes
+// them once all their required parameters are.
+func (info ContractInfo) Progress() (completed, total int) {
+	var count func(params []ContractParameterInfo)
+	count = func(params []ContractParameterInfo) {
+		for _, p := range params {
+			if p.Optional {
+				continue
+			}
+			if len(p.Children) > 0 {
+				count(p.Children)
+				continue
+			}
+			total++
+			if p.Completed {
+				completed++
+			}
+		}
+	}
+	count(info.Parameters)
+	return completed, total
+}
+
+// Info gets a snapshot of a contract and all its parameters.
+func (s *Contract) Info() (ContractInfo, error) {
+	info := ContractInfo{Contract: s}
+	var err error
+	if info.Type, err = s.Type(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Title, err = s.Title(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Synopsis, err = s.Synopsis(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Description, err = s.Description(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.State, err = s.State(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.FundsAdvance, err = s.FundsAdvance(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.FundsCompletion, err = s.FundsCompletion(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.FundsFailure, err = s.FundsFailure(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.ReputationCompletion, err = s.ReputationCompletion(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.ReputationFailure, err = s.ReputationFailure(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.ScienceCompletion, err = s.ScienceCompletion(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.CanBeCanceled, err = s.CanBeCanceled(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.CanBeDeclined, err = s.CanBeDeclined(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	params, err := s.Parameters()
+	if err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Parameters, err = parameterInfos(params); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	return info, nil
+}
+
+func parameterInfos(params []*ContractParameter) ([]ContractParameterInfo, error) {
+	infos := make([]ContractParameterInfo, len(params))
+	for i, p := range params {
+		info := &infos[i]
+		var err error
+		if info.Title, err = p.Title(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if info.Notes, err = p.Notes(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if info.Completed, err = p.Completed(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if info.Failed, err = p.Failed(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if info.Optional, err = p.Optional(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		children, err := p.Children()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if info.Children, err = parameterInfos(children); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return infos, nil
+}
+
+func contractInfos(contracts []*Contract, err error) ([]ContractInfo, error) {
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	infos := make([]ContractInfo, len(contracts))
+	for i, c := range contracts {
+		if infos[i], err = c.Info(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return infos, nil
+}
+
+// ActiveInfo gets snapshots of all active contracts.
+func (s *ContractManager) ActiveInfo() ([]ContractInfo, error) {
+	return contractInfos(s.ActiveContracts())
+}
+
+// OfferedInfo gets snapshots of all offered contracts.
+func (s *ContractManager) OfferedInfo() ([]ContractInfo, error) {
+	return contractInfos(s.OfferedContracts())
+}
+
+// AcceptOffered accepts every offered contract that matches a filter,
+// returning the contracts that were accepted.
+func (s *ContractManager) AcceptOffered(match func(ContractInfo) bool) ([]ContractInfo, error) {
+	offered, err := s.OfferedInfo()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var accepted []ContractInfo
+	for _, info := range offered {
+		if !match(info) {
+			continue
+		}
+		if err := info.Contract.Accept(); err != nil {
+			return accepted, tracerr.Wrap(err)
+		}
+		accepted = append(accepted, info)
+	}
+	return accepted, nil
+}
+
+// TryAccept accepts a contract if it is on offer. Otherwise it returns
+// [ErrContractAction].
+func (s *Contract) TryAccept() error {
+	state, err := s.State()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if state != ContractState_Offered {
+		return s.actionError("accept", "it isn't on offer")
+	}
+	return tracerr.Wrap(s.Accept())
+}
+
+// TryDecline declines a contract if it can be declined. Otherwise it returns
+// [ErrContractAction].
+func (s *Contract) TryDecline() error {
+	ok, err := s.CanBeDeclined()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !ok {
+		return s.actionError("decline", "it can't be declined")
+	}
+	return tracerr.Wrap(s.Decline())
+}
+
+// TryCancel cancels a contract if it can be canceled. Otherwise it returns
+// [ErrContractAction].
+func (s *Contract) TryCancel() error {
+	ok, err := s.CanBeCanceled()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !ok {
+		return s.actionError("cancel", "it can't be canceled")
+	}
+	return tracerr.Wrap(s.Cancel())
+}
+
+// actionError creates an ErrContractAction, filling in the title if it can.
+func (s *Contract) actionError(action, reason string) error {
+	title, err := s.Title()
+	if err != nil {
+		title = "(unknown)"
+	}
+	return ErrContractAction{Action: action, Title: title, Reason: reason}
+}
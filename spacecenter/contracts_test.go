@@ -0,0 +1,31 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractProgress(t *testing.T) {
+	info := ContractInfo{
+		Parameters: []ContractParameterInfo{
+			{Title: "Launch", Completed: true},
+			{
+				Title: "Orbit",
+				Children: []ContractParameterInfo{
+					{Title: "Reach orbit", Completed: true},
+					{Title: "Stay in orbit"},
+				},
+			},
+			{Title: "Plant a flag", Optional: true, Completed: true},
+		},
+	}
+	completed, total := info.Progress()
+	require.Equal(t, 2, completed)
+	require.Equal(t, 3, total)
+}
+
+func TestErrContractAction(t *testing.T) {
+	err := ErrContractAction{Action: "decline", Title: "Test", Reason: "it can't be declined"}
+	require.Equal(t, `Can't decline contract "Test": it can't be declined`, err.Error())
+}
@@ -0,0 +1,182 @@
+package spacecenter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// DeadmanAction is something a Deadman does to make a vessel safe.
+type DeadmanAction struct {
+	Name string
+	Run  func(*Vessel) error
+}
+
+// CutThrottle is a DeadmanAction that sets the throttle to zero.
+var CutThrottle = DeadmanAction{
+	Name: "cut throttle",
+	Run: func(vessel *Vessel) error {
+		control, err := vessel.Control()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(control.SetThrottle(0))
+	},
+}
+
+// HoldStability is a DeadmanAction that disengages the autopilot and holds
+// the vessel's attitude with SAS in stability assist mode.
+var HoldStability = DeadmanAction{
+	Name: "hold stability",
+	Run: func(vessel *Vessel) error {
+		ap, err := vessel.AutoPilot()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := ap.Disengage(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		control, err := vessel.Control()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := control.SetSAS(true); err != nil {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(control.SetSASMode(SASMode_StabilityAssist))
+	},
+}
+
+// Quicksave is a DeadmanAction that quicksaves the game, so that the
+// state before anything else goes wrong can be recovered.
+var Quicksave = DeadmanAction{
+	Name: "quicksave",
+	Run: func(vessel *Vessel) error {
+		return tracerr.Wrap(New(vessel.Client).Quicksave())
+	},
+}
+
+// DeadmanConfig configures a Deadman.
+type DeadmanConfig struct {
+	// Timeout is how long the Deadman waits for a Kick before tripping.
+	// Defaults to 5 seconds.
+	Timeout time.Duration
+	// Actions are run in order when the Deadman trips. Defaults to
+	// CutThrottle and HoldStability.
+	Actions []DeadmanAction
+	// OnTrip, if set, is called after the actions have run, with why the
+	// Deadman tripped and any error from the actions.
+	OnTrip func(reason string, err error)
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *DeadmanConfig) SetDefaults() {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Actions == nil {
+		cfg.Actions = []DeadmanAction{CutThrottle, HoldStability}
+	}
+}
+
+// Deadman is a watchdog that makes a vessel safe if the code flying it
+// stops checking in, because it is stuck or has panicked:
+//
+//	d := spacecenter.NewDeadman(vessel, spacecenter.DeadmanConfig{})
+//	defer d.Stop()
+//	defer d.Recover()
+//	for ... {
+//		d.Kick()
+//		...
+//	}
+//
+// A Deadman trips at most once. It uses the same client as the code it
+// watches, so it can't help if the connection itself is lost.
+type Deadman struct {
+	vessel *Vessel
+	cfg    DeadmanConfig
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	tripped bool
+	stopped bool
+}
+
+// NewDeadman creates a new Deadman for a vessel, and starts waiting for the
+// first Kick.
+func NewDeadman(vessel *Vessel, cfg DeadmanConfig) *Deadman {
+	cfg.SetDefaults()
+	d := &Deadman{vessel: vessel, cfg: cfg}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timer = time.AfterFunc(cfg.Timeout, func() {
+		_ = d.Trip(fmt.Sprintf("not kicked for %v", cfg.Timeout))
+	})
+	return d
+}
+
+// Kick shows that the code flying the vessel is still running, putting off
+// tripping for the config's Timeout.
+func (d *Deadman) Kick() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.tripped && !d.stopped {
+		d.timer.Reset(d.cfg.Timeout)
+	}
+}
+
+// Trip runs the config's actions straight away, unless the Deadman has
+// already tripped or been stopped. Every action is run, even if one fails.
+func (d *Deadman) Trip(reason string) error {
+	d.mu.Lock()
+	if d.tripped || d.stopped {
+		d.mu.Unlock()
+		return nil
+	}
+	d.tripped = true
+	d.timer.Stop()
+	d.mu.Unlock()
+
+	var failures []string
+	for _, action := range d.cfg.Actions {
+		if err := action.Run(d.vessel); err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", action.Name, tracerr.Unwrap(err)))
+		}
+	}
+	var err error
+	if len(failures) > 0 {
+		err = tracerr.Errorf("Deadman actions failed: %v", strings.Join(failures, "; "))
+	}
+	if d.cfg.OnTrip != nil {
+		d.cfg.OnTrip(reason, err)
+	}
+	return err
+}
+
+// Recover trips the Deadman if the goroutine is panicking, then carries on
+// panicking. It must be deferred directly.
+func (d *Deadman) Recover() {
+	if r := recover(); r != nil {
+		_ = d.Trip(fmt.Sprintf("panic: %v", r))
+		panic(r)
+	}
+}
+
+// Tripped checks if the Deadman has tripped.
+func (d *Deadman) Tripped() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tripped
+}
+
+// Stop stops the Deadman without tripping it, e.g. once the code flying the
+// vessel has finished.
+func (d *Deadman) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = true
+	d.timer.Stop()
+}
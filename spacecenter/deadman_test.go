@@ -0,0 +1,121 @@
+package spacecenter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingActions returns actions that record when they run, the second of
+// which fails.
+func recordingActions() ([]DeadmanAction, func() []string) {
+	var mu sync.Mutex
+	var ran []string
+	record := func(name string, err error) DeadmanAction {
+		return DeadmanAction{Name: name, Run: func(*Vessel) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, name)
+			return err
+		}}
+	}
+	actions := []DeadmanAction{record("first", nil), record("second", errors.New("no control")), record("third", nil)}
+	return actions, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ran...)
+	}
+}
+
+func TestDeadmanTimeout(t *testing.T) {
+	actions, ran := recordingActions()
+	trips := make(chan string, 1)
+	d := NewDeadman(nil, DeadmanConfig{
+		Timeout: 100 * time.Millisecond,
+		Actions: actions,
+		OnTrip: func(reason string, err error) {
+			require.EqualError(t, err, "Deadman actions failed: second: no control")
+			trips <- reason
+		},
+	})
+	defer d.Stop()
+
+	// Kicks keep it from tripping.
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		d.Kick()
+	}
+	require.False(t, d.Tripped())
+
+	select {
+	case reason := <-trips:
+		require.Equal(t, "not kicked for 100ms", reason)
+	case <-time.After(time.Second):
+		require.FailNow(t, "deadman didn't trip")
+	}
+	require.True(t, d.Tripped())
+	require.Equal(t, []string{"first", "second", "third"}, ran())
+	// It only trips once.
+	require.NoError(t, d.Trip("again"))
+	require.Len(t, ran(), 3)
+}
+
+func TestDeadmanRecover(t *testing.T) {
+	actions, ran := recordingActions()
+	var reason string
+	d := NewDeadman(nil, DeadmanConfig{
+		Timeout: time.Hour,
+		Actions: actions,
+		OnTrip:  func(r string, err error) { reason = r },
+	})
+	defer d.Stop()
+
+	require.PanicsWithValue(t, "engine exploded", func() {
+		defer d.Recover()
+		panic("engine exploded")
+	})
+	require.True(t, d.Tripped())
+	require.Equal(t, "panic: engine exploded", reason)
+	require.Len(t, ran(), 3)
+
+	// Without a panic, nothing happens.
+	d = NewDeadman(nil, DeadmanConfig{Timeout: time.Hour, Actions: actions})
+	func() {
+		defer d.Recover()
+	}()
+	require.False(t, d.Tripped())
+	d.Stop()
+	require.NoError(t, d.Trip("stopped"))
+	require.False(t, d.Tripped())
+}
+
+func TestDeadmanActions(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "Vessel_get_Control", uint64(2))
+	server.Return("SpaceCenter", "Vessel_get_AutoPilot", uint64(3))
+	for _, procedure := range []string{"Control_set_Throttle", "AutoPilot_Disengage", "Control_set_SAS", "Control_set_SASMode", "Quicksave"} {
+		server.Return("SpaceCenter", procedure, nil)
+	}
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	d := NewDeadman(NewVessel(1, client), DeadmanConfig{Timeout: time.Hour})
+	d.cfg.Actions = append(d.cfg.Actions, Quicksave)
+	require.NoError(t, d.Trip("test"))
+	var procedures []string
+	for _, call := range server.Calls() {
+		procedures = append(procedures, call.Procedure)
+	}
+	require.Equal(t, []string{
+		"Vessel_get_Control", "Control_set_Throttle",
+		"Vessel_get_AutoPilot", "AutoPilot_Disengage", "Vessel_get_Control", "Control_set_SAS", "Control_set_SASMode",
+		"Quicksave",
+	}, procedures)
+}
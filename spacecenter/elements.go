@@ -0,0 +1,182 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// OrbitalElements are the Keplerian elements of an orbit, as plain values
+// that planners can work with without asking the server. Angles are in
+// radians, and positions and velocities are relative to the center of the
+// body, in its non-rotating reference frame.
+type OrbitalElements struct {
+	// Mu is the body's gravitational parameter, in m³/s².
+	Mu float64
+	// SemiMajorAxis is in meters, and negative for hyperbolic orbits.
+	SemiMajorAxis            float64
+	Eccentricity             float64
+	Inclination              float64
+	LongitudeOfAscendingNode float64
+	ArgumentOfPeriapsis      float64
+	// MeanAnomalyAtEpoch is the mean anomaly at the epoch, a universal time
+	// in seconds.
+	MeanAnomalyAtEpoch float64
+	Epoch              float64
+}
+
+// orbitElementGetters are the Orbit getters read by GetOrbitalElements, in
+// the order they are stored.
+var orbitElementGetters = []string{
+	"Orbit_get_SemiMajorAxis",
+	"Orbit_get_Eccentricity",
+	"Orbit_get_Inclination",
+	"Orbit_get_LongitudeOfAscendingNode",
+	"Orbit_get_ArgumentOfPeriapsis",
+	"Orbit_get_MeanAnomalyAtEpoch",
+	"Orbit_get_Epoch",
+	"Orbit_get_Radius",
+	"Orbit_get_Speed",
+}
+
+// GetOrbitalElements gets an orbit's elements from the server in a single
+// batched call. The body's gravitational parameter is worked out from the
+// orbit's current radius and speed, so that the body needn't be looked up
+// first.
+func GetOrbitalElements(orbit *Orbit) (OrbitalElements, error) {
+	argBytes, err := encode.Marshal(orbit)
+	if err != nil {
+		return OrbitalElements{}, tracerr.Wrap(err)
+	}
+	calls := make([]*types.ProcedureCall, len(orbitElementGetters))
+	for i, procedure := range orbitElementGetters {
+		calls[i] = &types.ProcedureCall{
+			Procedure: procedure,
+			Service:   "SpaceCenter",
+			Arguments: []*types.Argument{{Position: 0, Value: argBytes}},
+		}
+	}
+	results, err := orbit.Client.CallMultiple(calls)
+	if err != nil {
+		return OrbitalElements{}, tracerr.Wrap(err)
+	}
+	if len(results) != len(calls) {
+		return OrbitalElements{}, tracerr.Errorf("Expected %v results, got %v", len(calls), len(results))
+	}
+	var el OrbitalElements
+	var radius, speed float64
+	values := []*float64{
+		&el.SemiMajorAxis,
+		&el.Eccentricity,
+		&el.Inclination,
+		&el.LongitudeOfAscendingNode,
+		&el.ArgumentOfPeriapsis,
+		&el.MeanAnomalyAtEpoch,
+		&el.Epoch,
+		&radius,
+		&speed,
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			return OrbitalElements{}, tracerr.Wrap(result.Error)
+		}
+		if err := encode.Unmarshal(result.Value, values[i]); err != nil {
+			return OrbitalElements{}, tracerr.Wrap(err)
+		}
+	}
+	// By the vis-viva equation, v² = μ(2/r - 1/a).
+	el.Mu = speed * speed / (2/radius - 1/el.SemiMajorAxis)
+	return el, nil
+}
+
+// Kepler returns the orbit with these elements, for propagating it.
+func (el OrbitalElements) Kepler() *KeplerOrbit {
+	return &KeplerOrbit{
+		Mu:                       el.Mu,
+		SemiMajorAxis:            el.SemiMajorAxis,
+		Eccentricity:             el.Eccentricity,
+		Inclination:              el.Inclination,
+		LongitudeOfAscendingNode: el.LongitudeOfAscendingNode,
+		ArgumentOfPeriapsis:      el.ArgumentOfPeriapsis,
+		MeanAnomalyAtEpoch:       el.MeanAnomalyAtEpoch,
+		Epoch:                    el.Epoch,
+	}
+}
+
+// StateAt returns the position, in meters, and velocity, in m/s, at a
+// universal time.
+func (el OrbitalElements) StateAt(ut float64) (types.Vector3D, types.Vector3D) {
+	return el.Kepler().StateAt(ut)
+}
+
+// elementsTolerance is how close to zero the eccentricity or inclination
+// must be for an orbit to count as circular or equatorial, when the angles
+// they define can't be worked out.
+const elementsTolerance = 1e-9
+
+// ElementsFromState works out the elements of the orbit with a position and
+// velocity at a universal time, about a body with gravitational parameter
+// mu. The argument of periapsis of a circular orbit, and the longitude of
+// the ascending node of an equatorial one, are undefined, so they are zero.
+func ElementsFromState(mu float64, position, velocity types.Vector3D, ut float64) OrbitalElements {
+	// The body's non-rotating reference frame is left-handed with the y-axis
+	// as its normal, so swap y and z to work in a right-handed frame.
+	swap := func(v types.Vector3D) types.Vector3D { return types.NewVector3D(v.X, v.Z, v.Y) }
+	r, v := swap(position), swap(velocity)
+	rl, vl := r.Length(), v.Length()
+
+	h := r.Cross(v)
+	node := types.NewVector3D(-h.Y, h.X, 0)
+	ecc := r.Scale(vl*vl - mu/rl).Add(v.Scale(-r.Dot(v))).Scale(1 / mu)
+	el := OrbitalElements{
+		Mu:            mu,
+		SemiMajorAxis: 1 / (2/rl - vl*vl/mu),
+		Eccentricity:  ecc.Length(),
+		Inclination:   math.Acos(math.Max(-1, math.Min(1, h.Z/h.Length()))),
+		Epoch:         ut,
+	}
+	equatorial := node.Length() < elementsTolerance*h.Length()
+	circular := el.Eccentricity < elementsTolerance
+
+	// angle returns the angle from a to b in the orbit's direction of
+	// motion.
+	angle := func(a, b types.Vector3D) float64 {
+		return math.Atan2(a.Cross(b).Dot(h)/h.Length(), a.Dot(b))
+	}
+	if !equatorial {
+		el.LongitudeOfAscendingNode = math.Atan2(node.Y, node.X)
+	}
+	// Angles in the orbit are measured from the periapsis, or failing that
+	// the ascending node, or failing that the reference direction.
+	from := node
+	if equatorial {
+		from = types.NewVector3D(1, 0, 0)
+	}
+	if !circular {
+		el.ArgumentOfPeriapsis = angle(from, ecc)
+		from = ecc
+	}
+	trueAnomaly := angle(from, r)
+	if el.LongitudeOfAscendingNode < 0 {
+		el.LongitudeOfAscendingNode += 2 * math.Pi
+	}
+	if el.ArgumentOfPeriapsis < 0 {
+		el.ArgumentOfPeriapsis += 2 * math.Pi
+	}
+
+	e := el.Eccentricity
+	sv, cv := math.Sincos(trueAnomaly)
+	if e < 1 {
+		x := math.Atan2(math.Sqrt(1-e*e)*sv, e+cv)
+		el.MeanAnomalyAtEpoch = x - e*math.Sin(x)
+		if el.MeanAnomalyAtEpoch < 0 {
+			el.MeanAnomalyAtEpoch += 2 * math.Pi
+		}
+	} else {
+		x := 2 * math.Atanh(math.Sqrt((e-1)/(e+1))*math.Tan(trueAnomaly/2))
+		el.MeanAnomalyAtEpoch = e*math.Sinh(x) - x
+	}
+	return el
+}
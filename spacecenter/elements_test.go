@@ -0,0 +1,145 @@
+package spacecenter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementsFromState(t *testing.T) {
+	r := 700e3
+	tests := []struct {
+		name string
+		el   OrbitalElements
+		// degenerate is set for orbits whose angles can't all be recovered,
+		// so only their states are compared.
+		degenerate bool
+	}{
+		{
+			name: "inclined",
+			el: OrbitalElements{
+				SemiMajorAxis:            r,
+				Eccentricity:             0.2,
+				Inclination:              0.5,
+				LongitudeOfAscendingNode: 1,
+				ArgumentOfPeriapsis:      2,
+				MeanAnomalyAtEpoch:       3,
+			},
+		},
+		{
+			name: "retrograde",
+			el: OrbitalElements{
+				SemiMajorAxis:            2 * r,
+				Eccentricity:             0.6,
+				Inclination:              2.5,
+				LongitudeOfAscendingNode: 4,
+				ArgumentOfPeriapsis:      0.3,
+				MeanAnomalyAtEpoch:       5,
+			},
+		},
+		{
+			name: "hyperbolic",
+			el: OrbitalElements{
+				SemiMajorAxis:            -r,
+				Eccentricity:             1.5,
+				Inclination:              0.1,
+				LongitudeOfAscendingNode: 0.2,
+				ArgumentOfPeriapsis:      0.3,
+				MeanAnomalyAtEpoch:       -0.4,
+			},
+		},
+		{
+			name:       "circular equatorial",
+			el:         OrbitalElements{SemiMajorAxis: r, MeanAnomalyAtEpoch: 1},
+			degenerate: true,
+		},
+		{
+			name:       "circular inclined",
+			el:         OrbitalElements{SemiMajorAxis: r, Inclination: 0.5, LongitudeOfAscendingNode: 1, MeanAnomalyAtEpoch: 1},
+			degenerate: true,
+		},
+		{
+			name:       "elliptical equatorial",
+			el:         OrbitalElements{SemiMajorAxis: r, Eccentricity: 0.1, ArgumentOfPeriapsis: 1, MeanAnomalyAtEpoch: 1},
+			degenerate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			el := tc.el
+			el.Mu = kerbinMu
+			el.Epoch = 100
+			position, velocity := el.StateAt(200)
+			actual := ElementsFromState(kerbinMu, position, velocity, 200)
+			if !tc.degenerate {
+				expected := el.Kepler()
+				require.InDelta(t, el.SemiMajorAxis, actual.SemiMajorAxis, 1e-3)
+				require.InDelta(t, el.Eccentricity, actual.Eccentricity, 1e-9)
+				require.InDelta(t, el.Inclination, actual.Inclination, 1e-9)
+				require.InDelta(t, el.LongitudeOfAscendingNode, actual.LongitudeOfAscendingNode, 1e-9)
+				require.InDelta(t, el.ArgumentOfPeriapsis, actual.ArgumentOfPeriapsis, 1e-9)
+				require.InDelta(t, expected.MeanAnomalyAt(200), actual.MeanAnomalyAtEpoch, 1e-9)
+			}
+			// Either way, the orbit is the same.
+			for _, ut := range []float64{200, 500, 1000} {
+				p1, v1 := el.StateAt(ut)
+				p2, v2 := actual.StateAt(ut)
+				requireVectorInDelta(t, p1, p2, 1e-3)
+				requireVectorInDelta(t, v1, v2, 1e-6)
+			}
+		})
+	}
+}
+
+func TestGetOrbitalElements(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	r := 700e3
+	server.Return("SpaceCenter", "Orbit_get_SemiMajorAxis", r)
+	server.Return("SpaceCenter", "Orbit_get_Eccentricity", 0.0)
+	server.Return("SpaceCenter", "Orbit_get_Inclination", 0.1)
+	server.Return("SpaceCenter", "Orbit_get_LongitudeOfAscendingNode", 0.2)
+	server.Return("SpaceCenter", "Orbit_get_ArgumentOfPeriapsis", 0.3)
+	server.Return("SpaceCenter", "Orbit_get_MeanAnomalyAtEpoch", 0.4)
+	server.Return("SpaceCenter", "Orbit_get_Epoch", 50.0)
+	server.Return("SpaceCenter", "Orbit_get_Radius", r)
+	server.Return("SpaceCenter", "Orbit_get_Speed", math.Sqrt(kerbinMu/r))
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	el, err := GetOrbitalElements(NewOrbit(1, client))
+	require.NoError(t, err)
+	require.InDelta(t, kerbinMu, el.Mu, 1)
+	el.Mu = kerbinMu
+	require.Equal(t, OrbitalElements{
+		Mu:                       kerbinMu,
+		SemiMajorAxis:            r,
+		Inclination:              0.1,
+		LongitudeOfAscendingNode: 0.2,
+		ArgumentOfPeriapsis:      0.3,
+		MeanAnomalyAtEpoch:       0.4,
+		Epoch:                    50,
+	}, el)
+
+	// NewKeplerOrbit reads the same elements, plus the reference plane.
+	server.Return("SpaceCenter", "Orbit_static_ReferencePlaneDirection", types.NewTuple3(1.0, 0.0, 0.0))
+	server.Return("SpaceCenter", "Orbit_static_ReferencePlaneNormal", types.NewTuple3(0.0, 1.0, 0.0))
+	kepler, err := NewKeplerOrbit(NewOrbit(1, client), NewReferenceFrame(2, client))
+	require.NoError(t, err)
+	require.InDelta(t, kerbinMu, kepler.Mu, 1)
+	require.Equal(t, 0.3, kepler.ArgumentOfPeriapsis)
+	require.Equal(t, types.NewVector3D(1, 0, 0), kepler.ReferenceDirection)
+	require.Equal(t, types.NewVector3D(0, 1, 0), kepler.ReferenceNormal)
+
+	server.Handle("SpaceCenter", "Orbit_get_Speed", func(*krpctest.Call) (interface{}, error) {
+		return nil, errors.New("no orbit")
+	})
+	_, err = GetOrbitalElements(NewOrbit(1, client))
+	require.Error(t, err)
+}
@@ -0,0 +1,236 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// kerbalEVAModule is the part module that controls a kerbal on EVA.
+const kerbalEVAModule = "KerbalEVA"
+
+// EVAConfig configures an EVA.
+type EVAConfig struct {
+	// MaxSpeed is the fastest to fly the jetpack, in m/s.
+	MaxSpeed float64
+	// Acceleration is the jetpack's acceleration, in m/s², used to slow down
+	// in time.
+	Acceleration float64
+	// ArrivalDistance is how close to get to a target, in meters.
+	ArrivalDistance float64
+	// ArrivalSpeed is the relative speed to arrive at, in m/s.
+	ArrivalSpeed float64
+	// Gain turns the difference between the velocity wanted and the
+	// current velocity, in m/s, into translation inputs.
+	Gain float64
+	// Interval is how often to update the translation inputs.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *EVAConfig) SetDefaults() {
+	if cfg.MaxSpeed == 0 {
+		cfg.MaxSpeed = 2
+	}
+	if cfg.Acceleration == 0 {
+		cfg.Acceleration = 0.5
+	}
+	if cfg.ArrivalDistance == 0 {
+		cfg.ArrivalDistance = 1.5
+	}
+	if cfg.ArrivalSpeed == 0 {
+		cfg.ArrivalSpeed = 0.2
+	}
+	if cfg.Gain == 0 {
+		cfg.Gain = 2
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// evaTranslation works out the translation inputs that fly a kerbal towards
+// a target, given the target's position and velocity relative to the kerbal,
+// in the kerbal's reference frame. It reports whether the kerbal has arrived.
+func evaTranslation(position, velocity types.Vector3D, cfg EVAConfig) (forward, right, up float64, arrived bool) {
+	distance := position.Length()
+	if distance <= cfg.ArrivalDistance && velocity.Length() <= cfg.ArrivalSpeed {
+		return 0, 0, 0, true
+	}
+	// Close in no faster than the jetpack can stop from.
+	speed := math.Min(cfg.MaxSpeed, math.Sqrt(math.Max(0, 2*cfg.Acceleration*(distance-cfg.ArrivalDistance))))
+	desired := types.Vector3D{}
+	if distance > 0 {
+		desired = position.Scale(speed / distance)
+	}
+	// The kerbal's velocity relative to the target is the opposite of the
+	// target's.
+	command := desired.Add(velocity).Scale(cfg.Gain)
+	clamp := func(v float64) float64 {
+		return math.Max(-1, math.Min(1, v))
+	}
+	// The x-axis points right, the y-axis forwards and the z-axis down.
+	return clamp(command.Y), clamp(command.X), clamp(-command.Z), false
+}
+
+// EVA controls a kerbal on EVA, which kRPC treats as a vessel of its own.
+//
+// kRPC can't board or leave vessels or grab ladders directly. Where KSP
+// offers an action as an event on the kerbal, such as planting a flag, it can
+// be triggered with Trigger.
+type EVA struct {
+	kerbal *Vessel
+	cfg    EVAConfig
+}
+
+// NewEVA creates a new EVA.
+func NewEVA(kerbal *Vessel, cfg EVAConfig) *EVA {
+	cfg.SetDefaults()
+	return &EVA{kerbal: kerbal, cfg: cfg}
+}
+
+// EVAKerbal finds a kerbal on EVA by name. It returns nil if the kerbal isn't
+// on EVA.
+func EVAKerbal(sc *SpaceCenter, name string) (*Vessel, error) {
+	vessels, err := sc.Vessels()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, vessel := range vessels {
+		// A kerbal on EVA is a vessel named after them, with a KerbalEVA
+		// module.
+		vesselName, err := vessel.Name()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if vesselName != name {
+			continue
+		}
+		module, err := evaModule(vessel)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if module != nil {
+			return vessel, nil
+		}
+	}
+	return nil, nil
+}
+
+// evaModule returns a vessel's KerbalEVA module, or nil if it isn't a kerbal.
+func evaModule(vessel *Vessel) (*Module, error) {
+	parts, err := vessel.Parts()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	modules, err := parts.ModulesWithName(kerbalEVAModule)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if len(modules) == 0 {
+		return nil, nil
+	}
+	return modules[0], nil
+}
+
+// module returns the kerbal's KerbalEVA module.
+func (e *EVA) module() (*Module, error) {
+	module, err := evaModule(e.kerbal)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if module == nil {
+		return nil, tracerr.Errorf("Vessel isn't a kerbal on EVA")
+	}
+	return module, nil
+}
+
+// Events returns the actions the kerbal can take right now.
+func (e *EVA) Events() ([]string, error) {
+	module, err := e.module()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	events, err := module.Events()
+	return events, tracerr.Wrap(err)
+}
+
+// Trigger takes one of the actions from Events, such as "Plant Flag".
+func (e *EVA) Trigger(event string) error {
+	module, err := e.module()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ok, err := module.HasEvent(event)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !ok {
+		return tracerr.Errorf("Kerbal can't %q right now", event)
+	}
+	return tracerr.Wrap(module.TriggerEvent(event))
+}
+
+// Jetpack turns the kerbal's jetpack on or off.
+func (e *EVA) Jetpack(on bool) error {
+	control, err := e.kerbal.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(control.SetRCS(on))
+}
+
+// FlyTo flies the kerbal to a part, e.g. a hatch or ladder, with the jetpack,
+// and stops next to it. The jetpack is left on.
+func (e *EVA) FlyTo(ctx context.Context, target *Part) error {
+	control, err := e.kerbal.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rf, err := e.kerbal.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := control.SetRCS(true); err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer func() {
+		_ = control.SetForward(0)
+		_ = control.SetRight(0)
+		_ = control.SetUp(0)
+	}()
+
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case <-ticker.C:
+			p, err := target.Position(rf)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			v, err := target.Velocity(rf)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			forward, right, up, arrived := evaTranslation(types.Vector3DFromTuple(p), types.Vector3DFromTuple(v), e.cfg)
+			if arrived {
+				return nil
+			}
+			if err := control.SetForward(float32(forward)); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := control.SetRight(float32(right)); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := control.SetUp(float32(up)); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVATranslation(t *testing.T) {
+	cfg := EVAConfig{}
+	cfg.SetDefaults()
+	tcs := []struct {
+		name               string
+		position, velocity types.Vector3D
+		forward, right, up float64
+		arrived            bool
+	}{
+		{name: "arrived", position: types.NewVector3D(0, 1, 0), arrived: true},
+		{
+			name:     "drifting at the target",
+			position: types.NewVector3D(0, 1, 0),
+			velocity: types.NewVector3D(0.5, 0, 0),
+			right:    1,
+		},
+		{name: "target ahead", position: types.NewVector3D(0, 20, 0), forward: 1},
+		{name: "target above", position: types.NewVector3D(0, 0, -20), up: 1},
+		{name: "target to the left", position: types.NewVector3D(-20, 0, 0), right: -1},
+		{
+			// Closing at full speed, but close enough that it's time to slow
+			// down.
+			name:     "braking",
+			position: types.NewVector3D(0, 2, 0),
+			velocity: types.NewVector3D(0, -2, 0),
+			forward:  -1,
+		},
+		{
+			name:     "cruising",
+			position: types.NewVector3D(0, 50, 0),
+			velocity: types.NewVector3D(0, -2, 0),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			forward, right, up, arrived := evaTranslation(tc.position, tc.velocity, cfg)
+			require.Equal(t, tc.arrived, arrived)
+			require.InDelta(t, tc.forward, forward, 1e-9)
+			require.InDelta(t, tc.right, right, 1e-9)
+			require.InDelta(t, tc.up, up, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// NodeExecutionConfig configures how maneuver nodes are executed.
+type NodeExecutionConfig struct {
+	// Tolerance is the remaining delta-v, in m/s, at which the burn is
+	// finished.
+	Tolerance float64
+	// MinThrottle is the smallest throttle used near the end of a burn.
+	MinThrottle float64
+	// WarpLead is how long before a burn to stop time warp, in seconds. Time
+	// warp is skipped if this is negative.
+	WarpLead float64
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *NodeExecutionConfig) SetDefaults() {
+	if cfg.Tolerance == 0 {
+		cfg.Tolerance = 0.1
+	}
+	if cfg.MinThrottle == 0 {
+		cfg.MinThrottle = 0.05
+	}
+	if cfg.WarpLead == 0 {
+		cfg.WarpLead = 30
+	}
+}
+
+// burnThrottle returns the throttle to use with some delta-v left and a
+// maximum acceleration, easing off over the last second of the burn.
+func burnThrottle(remaining, acceleration, minThrottle float64) float64 {
+	return math.Max(minThrottle, math.Min(1, remaining/acceleration))
+}
+
+// ExecuteNode points a vessel along a maneuver node's burn vector, warps to
+// it, and burns until the node is complete. The node is removed afterwards.
+func ExecuteNode(ctx context.Context, vessel *Vessel, node *Node, cfg NodeExecutionConfig) error {
+	cfg.SetDefaults()
+	sc := New(vessel.Client)
+	control, err := vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ap, err := vessel.AutoPilot()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	rf, err := node.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.SetReferenceFrame(rf); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.SetTargetDirection(types.NewVector3D(0, 1, 0).Tuple()); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.Engage(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer ap.Disengage()
+
+	deltaV, err := node.DeltaV()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	acceleration, err := maxAcceleration(vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	estimate, err := EstimateVesselBurn(vessel, deltaV)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ut, err := node.UT()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	start := ut - estimate.HalfDuration
+
+	if cfg.WarpLead >= 0 {
+		now, err := sc.UT()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if start-cfg.WarpLead > now {
+			if err := sc.WarpTo(start-cfg.WarpLead, 100000, 2); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+	if err := ap.Wait(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := waitForUT(ctx, sc, start); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	remaining, err := node.RemainingDeltaVStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer remaining.Close()
+	defer control.SetThrottle(0)
+
+	best := math.Inf(1)
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case dv := <-remaining.C:
+			// Stop once done, or if the burn starts overshooting.
+			if dv < cfg.Tolerance || dv > best+cfg.Tolerance {
+				if err := control.SetThrottle(0); err != nil {
+					return tracerr.Wrap(err)
+				}
+				return tracerr.Wrap(node.Remove())
+			}
+			best = math.Min(best, dv)
+			if err := control.SetThrottle(float32(burnThrottle(dv, acceleration, cfg.MinThrottle))); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
+
+// maxAcceleration returns the acceleration a vessel can currently manage, in
+// m/s².
+func maxAcceleration(vessel *Vessel) (float64, error) {
+	thrust, err := vessel.AvailableThrust()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	if thrust <= 0 {
+		return 0, tracerr.Errorf("Vessel has no available thrust")
+	}
+	mass, err := vessel.Mass()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return float64(thrust) / float64(mass), nil
+}
+
+// waitForUT waits until the universal time reaches ut.
+func waitForUT(ctx context.Context, sc *SpaceCenter, ut float64) error {
+	stream, err := sc.UTStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer stream.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case now := <-stream.C:
+			if now >= ut {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package spacecenter
+
+import (
+	"sort"
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// Fleet is an index of all the vessels in the game by name and ID. The ID of
+// a vessel is its kRPC object ID, which stays the same for as long as the
+// vessel exists, including across switches of the active vessel. kRPC
+// doesn't expose the game's own vessel GUIDs.
+type Fleet struct {
+	sc     *SpaceCenter
+	nameOf func(vessel *Vessel) (string, error)
+
+	mu      sync.RWMutex
+	vessels map[uint64]*Vessel
+	names   map[uint64]string
+	byName  map[string][]uint64
+	err     error
+
+	stream *krpcgo.Stream[[]*Vessel]
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewFleet creates a new Fleet from the current list of vessels.
+func NewFleet(sc *SpaceCenter) (*Fleet, error) {
+	f := newFleet(sc)
+	if err := f.Refresh(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return f, nil
+}
+
+func newFleet(sc *SpaceCenter) *Fleet {
+	return &Fleet{
+		sc:      sc,
+		nameOf:  (*Vessel).Name,
+		vessels: make(map[uint64]*Vessel),
+		names:   make(map[uint64]string),
+		byName:  make(map[string][]uint64),
+		done:    make(chan struct{}),
+	}
+}
+
+// Refresh rescans every vessel, picking up any vessels that have been
+// renamed.
+func (f *Fleet) Refresh() error {
+	vessels, err := f.sc.Vessels()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	f.mu.Lock()
+	f.names = make(map[uint64]string)
+	f.mu.Unlock()
+	return tracerr.Wrap(f.update(vessels))
+}
+
+// update reindexes the fleet. Names are only fetched for vessels that
+// haven't been seen before.
+func (f *Fleet) update(vessels []*Vessel) error {
+	f.mu.RLock()
+	known := f.names
+	f.mu.RUnlock()
+
+	byID := make(map[uint64]*Vessel, len(vessels))
+	names := make(map[uint64]string, len(vessels))
+	byName := make(map[string][]uint64)
+	for _, vessel := range vessels {
+		id := vessel.ID_internal()
+		name, ok := known[id]
+		if !ok {
+			var err error
+			if name, err = f.nameOf(vessel); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+		byID[id] = vessel
+		names[id] = name
+		byName[name] = append(byName[name], id)
+	}
+	for _, ids := range byName {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.vessels = byID
+	f.names = names
+	f.byName = byName
+	return nil
+}
+
+// Watch keeps the fleet up to date as vessels are launched, destroyed or
+// recovered, until the fleet is closed.
+func (f *Fleet) Watch() error {
+	stream, err := f.sc.VesselsStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	f.mu.Lock()
+	f.stream = stream
+	f.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-f.done:
+				return
+			case vessels := <-stream.C:
+				for _, v := range vessels {
+					v.Client = f.sc.Client
+				}
+				if err := f.update(vessels); err != nil {
+					f.mu.Lock()
+					f.err = err
+					f.mu.Unlock()
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Err returns the last error from keeping the fleet up to date, if any.
+func (f *Fleet) Err() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.err
+}
+
+// ByName gets a vessel by name, or nil if there isn't one. If more than one
+// vessel has the name, the oldest one is returned.
+func (f *Fleet) ByName(name string) *Vessel {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	ids := f.byName[name]
+	if len(ids) == 0 {
+		return nil
+	}
+	return f.vessels[ids[0]]
+}
+
+// AllByName gets every vessel with a name.
+func (f *Fleet) AllByName(name string) []*Vessel {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var vessels []*Vessel
+	for _, id := range f.byName[name] {
+		vessels = append(vessels, f.vessels[id])
+	}
+	return vessels
+}
+
+// ByID gets a vessel by ID, or nil if there isn't one.
+func (f *Fleet) ByID(id uint64) *Vessel {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.vessels[id]
+}
+
+// NameOf gets the name of a vessel as of the last update.
+func (f *Fleet) NameOf(vessel *Vessel) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	name, ok := f.names[vessel.ID_internal()]
+	return name, ok
+}
+
+// Vessels lists every vessel, oldest first.
+func (f *Fleet) Vessels() []*Vessel {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	ids := make([]uint64, 0, len(f.vessels))
+	for id := range f.vessels {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	vessels := make([]*Vessel, len(ids))
+	for i, id := range ids {
+		vessels[i] = f.vessels[id]
+	}
+	return vessels
+}
+
+// Close stops watching for changes.
+func (f *Fleet) Close() error {
+	var err error
+	f.once.Do(func() {
+		close(f.done)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.stream != nil {
+			err = f.stream.Close()
+		}
+	})
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,40 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetIndex(t *testing.T) {
+	names := map[uint64]string{1: "Relay-1", 2: "Relay-2", 3: "Relay-1"}
+	var lookups []uint64
+	f := newFleet(nil)
+	f.nameOf = func(v *Vessel) (string, error) {
+		lookups = append(lookups, v.ID_internal())
+		return names[v.ID_internal()], nil
+	}
+
+	v1, v2, v3 := NewVessel(1, nil), NewVessel(2, nil), NewVessel(3, nil)
+	require.NoError(t, f.update([]*Vessel{v3, v2, v1}))
+	require.Equal(t, v1, f.ByName("Relay-1"))
+	require.Equal(t, []*Vessel{v1, v3}, f.AllByName("Relay-1"))
+	require.Equal(t, v2, f.ByID(2))
+	require.Nil(t, f.ByName("Relay-3"))
+	require.Equal(t, []*Vessel{v1, v2, v3}, f.Vessels())
+
+	// Known vessels aren't looked up again, and removed vessels are dropped.
+	lookups = nil
+	v4 := NewVessel(4, nil)
+	names[4] = "Relay-3"
+	require.NoError(t, f.update([]*Vessel{v2, v4}))
+	require.Equal(t, []uint64{4}, lookups)
+	require.Equal(t, v4, f.ByName("Relay-3"))
+	require.Nil(t, f.ByName("Relay-1"))
+	require.Nil(t, f.ByID(1))
+	name, ok := f.NameOf(v2)
+	require.True(t, ok)
+	require.Equal(t, "Relay-2", name)
+
+	require.NoError(t, f.Close())
+}
@@ -0,0 +1,217 @@
+package spacecenter
+
+import (
+	"fmt"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// VectorKind is what a FrameVector is, which decides how it changes between
+// reference frames.
+type VectorKind int
+
+const (
+	// DirectionVector is a direction, which is only rotated between frames.
+	DirectionVector VectorKind = iota
+	// PositionVector is a position, which is also moved between frames.
+	PositionVector
+	// VelocityVector is a velocity, which also depends on the frames'
+	// motion.
+	VelocityVector
+)
+
+// String returns the name of the kind.
+func (k VectorKind) String() string {
+	switch k {
+	case DirectionVector:
+		return "direction"
+	case PositionVector:
+		return "position"
+	case VelocityVector:
+		return "velocity"
+	}
+	return "unknown"
+}
+
+// ErrFrameMismatch is returned when vectors in different reference frames,
+// or of different kinds, are combined.
+type ErrFrameMismatch struct {
+	A, B FrameVector
+}
+
+// Error returns a human-readable error.
+func (e ErrFrameMismatch) Error() string {
+	if e.A.Kind != e.B.Kind {
+		return fmt.Sprintf("Can't combine a %v with a %v", e.A.Kind, e.B.Kind)
+	}
+	return fmt.Sprintf("Can't combine vectors in different reference frames (%v and %v)", frameID(e.A.Frame), frameID(e.B.Frame))
+}
+
+// frameID returns a frame's object ID, or 0 for a nil frame.
+func frameID(frame *ReferenceFrame) uint64 {
+	if frame == nil {
+		return 0
+	}
+	return frame.ID_internal()
+}
+
+// FrameVector is a vector tagged with the reference frame it is in, so that
+// vectors from different frames, such as a vessel-frame direction and a
+// body-frame velocity, can't be silently combined. Reference frames belong
+// to particular vessels and bodies, so they are checked when vectors are
+// combined rather than by type:
+//
+//	up, err := spacecenter.DirectionIn(vessel, surface)
+//	position, err := spacecenter.PositionIn(vessel, orbital)
+//	velocity, err := spacecenter.VelocityIn(vessel, orbital)
+//	_, err = up.Dot(velocity) // ErrFrameMismatch
+//	velocity, err = velocity.To(sc, surface, position)
+//	climb, err := up.Dot(velocity)
+//
+// Frames are told apart by their object IDs, which the server keeps the same
+// for the same frame.
+type FrameVector struct {
+	types.Vector3D
+	Kind  VectorKind
+	Frame *ReferenceFrame
+}
+
+// InFrame tags a vector with a reference frame.
+func InFrame(v types.Vector3D, kind VectorKind, frame *ReferenceFrame) FrameVector {
+	return FrameVector{Vector3D: v, Kind: kind, Frame: frame}
+}
+
+// Check returns an ErrFrameMismatch unless both vectors are in the same
+// frame. Their kinds may differ, as for the angle between a direction and a
+// velocity.
+func (v FrameVector) Check(w FrameVector) error {
+	if frameID(v.Frame) != frameID(w.Frame) {
+		return tracerr.Wrap(ErrFrameMismatch{A: v, B: w})
+	}
+	return nil
+}
+
+// checkKind is Check, but also checks the vectors are of the same kind.
+func (v FrameVector) checkKind(w FrameVector) error {
+	if v.Kind != w.Kind {
+		return tracerr.Wrap(ErrFrameMismatch{A: v, B: w})
+	}
+	return tracerr.Wrap(v.Check(w))
+}
+
+// Add adds two vectors of the same kind in the same frame.
+func (v FrameVector) Add(w FrameVector) (FrameVector, error) {
+	if err := v.checkKind(w); err != nil {
+		return FrameVector{}, tracerr.Wrap(err)
+	}
+	return InFrame(v.Vector3D.Add(w.Vector3D), v.Kind, v.Frame), nil
+}
+
+// Sub subtracts w from v, which must be of the same kind and in the same
+// frame. The difference of two positions is a direction.
+func (v FrameVector) Sub(w FrameVector) (FrameVector, error) {
+	if err := v.checkKind(w); err != nil {
+		return FrameVector{}, tracerr.Wrap(err)
+	}
+	kind := v.Kind
+	if kind == PositionVector {
+		kind = DirectionVector
+	}
+	return InFrame(v.Vector3D.Add(w.Vector3D.Scale(-1)), kind, v.Frame), nil
+}
+
+// Scale scales the vector by a constant value.
+func (v FrameVector) Scale(k float64) FrameVector {
+	return InFrame(v.Vector3D.Scale(k), v.Kind, v.Frame)
+}
+
+// Dot computes the dot product between two vectors in the same frame.
+func (v FrameVector) Dot(w FrameVector) (float64, error) {
+	if err := v.Check(w); err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return v.Vector3D.Dot(w.Vector3D), nil
+}
+
+// Cross computes the cross product between two vectors in the same frame,
+// which is a direction.
+func (v FrameVector) Cross(w FrameVector) (FrameVector, error) {
+	if err := v.Check(w); err != nil {
+		return FrameVector{}, tracerr.Wrap(err)
+	}
+	return InFrame(v.Vector3D.Cross(w.Vector3D), DirectionVector, v.Frame), nil
+}
+
+// AngleBetween is the angle between two vectors in the same frame, in
+// radians.
+func (v FrameVector) AngleBetween(w FrameVector) (float64, error) {
+	if err := v.Check(w); err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return v.Vector3D.AngleBetween(w.Vector3D), nil
+}
+
+// To converts the vector to another reference frame. Velocities depend on
+// where they are, so converting one takes its position, in the same frame;
+// at is ignored for other kinds of vector.
+func (v FrameVector) To(sc *SpaceCenter, frame *ReferenceFrame, at FrameVector) (FrameVector, error) {
+	if frameID(v.Frame) == frameID(frame) {
+		return v, nil
+	}
+	var t types.Tuple3[float64, float64, float64]
+	var err error
+	switch v.Kind {
+	case DirectionVector:
+		t, err = sc.TransformDirection(v.Tuple(), v.Frame, frame)
+	case PositionVector:
+		t, err = sc.TransformPosition(v.Tuple(), v.Frame, frame)
+	case VelocityVector:
+		if at.Kind != PositionVector {
+			return FrameVector{}, tracerr.Errorf("Converting a velocity needs a position, not a %v", at.Kind)
+		}
+		if err := v.Check(at); err != nil {
+			return FrameVector{}, tracerr.Wrap(err)
+		}
+		t, err = sc.TransformVelocity(at.Tuple(), v.Tuple(), v.Frame, frame)
+	default:
+		return FrameVector{}, tracerr.Errorf("Can't convert a vector of kind %v", v.Kind)
+	}
+	if err != nil {
+		return FrameVector{}, tracerr.Wrap(err)
+	}
+	return InFrame(types.Vector3DFromTuple(t), v.Kind, frame), nil
+}
+
+// Locatable is an object whose position, velocity and direction can be
+// found in any reference frame, such as a Vessel, Part or CelestialBody.
+type Locatable interface {
+	Position(*ReferenceFrame) (types.Tuple3[float64, float64, float64], error)
+	Velocity(*ReferenceFrame) (types.Tuple3[float64, float64, float64], error)
+	Direction(*ReferenceFrame) (types.Tuple3[float64, float64, float64], error)
+}
+
+// tagged tags the result of a getter with a reference frame.
+func tagged(get func(*ReferenceFrame) (types.Tuple3[float64, float64, float64], error), kind VectorKind, frame *ReferenceFrame) (FrameVector, error) {
+	t, err := get(frame)
+	if err != nil {
+		return FrameVector{}, tracerr.Wrap(err)
+	}
+	return InFrame(types.Vector3DFromTuple(t), kind, frame), nil
+}
+
+// PositionIn returns an object's position in a reference frame.
+func PositionIn(obj Locatable, frame *ReferenceFrame) (FrameVector, error) {
+	return tagged(obj.Position, PositionVector, frame)
+}
+
+// VelocityIn returns an object's velocity in a reference frame.
+func VelocityIn(obj Locatable, frame *ReferenceFrame) (FrameVector, error) {
+	return tagged(obj.Velocity, VelocityVector, frame)
+}
+
+// DirectionIn returns the direction an object is facing in a reference
+// frame.
+func DirectionIn(obj Locatable, frame *ReferenceFrame) (FrameVector, error) {
+	return tagged(obj.Direction, DirectionVector, frame)
+}
@@ -0,0 +1,92 @@
+package spacecenter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameVectorArithmetic(t *testing.T) {
+	surface := NewReferenceFrame(1, nil)
+	orbital := NewReferenceFrame(2, nil)
+	a := InFrame(types.NewVector3D(1, 0, 0), PositionVector, surface)
+	b := InFrame(types.NewVector3D(0, 2, 0), PositionVector, surface)
+	v := InFrame(types.NewVector3D(0, 0, 3), VelocityVector, surface)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, InFrame(types.NewVector3D(1, 2, 0), PositionVector, surface), sum)
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	require.Equal(t, InFrame(types.NewVector3D(1, -2, 0), DirectionVector, surface), diff)
+	cross, err := a.Cross(v)
+	require.NoError(t, err)
+	require.Equal(t, InFrame(types.NewVector3D(0, -3, 0), DirectionVector, surface), cross)
+	dot, err := b.Dot(v)
+	require.NoError(t, err)
+	require.Zero(t, dot)
+
+	// Vectors of different kinds can't be added.
+	_, err = a.Add(v)
+	var mismatch ErrFrameMismatch
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "Can't combine a position with a velocity", mismatch.Error())
+
+	// Vectors in different frames can't be combined at all.
+	other := InFrame(types.NewVector3D(1, 0, 0), PositionVector, orbital)
+	_, err = a.Add(other)
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "Can't combine vectors in different reference frames (1 and 2)", mismatch.Error())
+	_, err = a.Dot(other)
+	require.ErrorAs(t, err, &mismatch)
+	_, err = a.AngleBetween(other)
+	require.ErrorAs(t, err, &mismatch)
+	_, err = a.Cross(other)
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, InFrame(types.NewVector3D(2, 0, 0), PositionVector, orbital), other.Scale(2))
+}
+
+func TestFrameVectorTo(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "Vessel_Position", types.NewTuple3(1.0, 2.0, 3.0))
+	server.Return("SpaceCenter", "Vessel_Velocity", types.NewTuple3(0.0, 10.0, 0.0))
+	server.Return("SpaceCenter", "TransformVelocity", types.NewTuple3(0.0, 0.0, 7.0))
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	sc := New(client)
+	vessel := NewVessel(5, client)
+	orbital := NewReferenceFrame(2, client)
+	surface := NewReferenceFrame(1, client)
+	position, err := PositionIn(vessel, orbital)
+	require.NoError(t, err)
+	require.Equal(t, InFrame(types.NewVector3D(1, 2, 3), PositionVector, orbital), position)
+	velocity, err := VelocityIn(vessel, orbital)
+	require.NoError(t, err)
+
+	// Converting to the same frame needs no call.
+	same, err := velocity.To(sc, orbital, position)
+	require.NoError(t, err)
+	require.Equal(t, velocity, same)
+
+	_, err = velocity.To(sc, surface, velocity)
+	require.Error(t, err)
+	converted, err := velocity.To(sc, surface, position)
+	require.NoError(t, err)
+	require.Equal(t, InFrame(types.NewVector3D(0, 0, 7), VelocityVector, surface), converted)
+
+	calls := server.Calls()
+	require.Len(t, calls, 3)
+	call := calls[2]
+	require.Equal(t, "TransformVelocity", call.Procedure)
+	var from, to ReferenceFrame
+	require.NoError(t, call.Arg(2, &from))
+	require.NoError(t, call.Arg(3, &to))
+	require.Equal(t, uint64(2), from.ID_internal())
+	require.Equal(t, uint64(1), to.ID_internal())
+}
@@ -0,0 +1,379 @@
+package spacecenter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/ztrue/tracerr"
+)
+
+// HealthAlertKind is the kind of a health alert.
+type HealthAlertKind int
+
+const (
+	// HealthOverheating is raised when a part gets close to its maximum
+	// temperature.
+	HealthOverheating HealthAlertKind = iota
+	// HealthLowCharge is raised when the vessel runs low on electric charge.
+	HealthLowCharge
+	// HealthWeakSignal is raised when the vessel's signal is weak.
+	HealthWeakSignal
+	// HealthCommsLost is raised when the vessel can't communicate.
+	HealthCommsLost
+)
+
+// String returns the name of the alert kind.
+func (k HealthAlertKind) String() string {
+	switch k {
+	case HealthOverheating:
+		return "overheating"
+	case HealthLowCharge:
+		return "low charge"
+	case HealthWeakSignal:
+		return "weak signal"
+	case HealthCommsLost:
+		return "comms lost"
+	}
+	return "unknown"
+}
+
+// HealthAlert reports a problem with a vessel's systems, or that it has
+// cleared.
+type HealthAlert struct {
+	Kind     HealthAlertKind
+	Severity alert.Severity
+	// Part is the part the alert is about, for overheating alerts.
+	Part *Part
+	// Source names what the alert is about: the part's title, or the
+	// vessel's name.
+	Source string
+	// Value is the reading and Threshold the limit it crossed. Temperatures
+	// and charge are fractions of their maximums.
+	Value     float64
+	Threshold float64
+	Cleared   bool
+}
+
+// Alert converts the health alert into an alert for an alert.Bridge.
+func (a HealthAlert) Alert() alert.Alert {
+	message := fmt.Sprintf("%s: %s (%.2f, limit %.2f)", a.Source, a.Kind, a.Value, a.Threshold)
+	if a.Cleared {
+		message = fmt.Sprintf("%s: %s cleared", a.Source, a.Kind)
+	}
+	return alert.Alert{
+		Name:      a.Kind.String(),
+		Source:    a.Source,
+		Severity:  a.Severity,
+		Message:   message,
+		Value:     a.Value,
+		Threshold: a.Threshold,
+		Time:      time.Now(),
+		Cleared:   a.Cleared,
+	}
+}
+
+// HealthConfig configures a HealthMonitor. Temperatures are fractions of
+// each part's maximum, and charge is a fraction of the vessel's capacity.
+type HealthConfig struct {
+	// WarningHeat and CriticalHeat are the temperatures at which to raise
+	// warnings and critical alerts.
+	WarningHeat  float64
+	CriticalHeat float64
+	// WarningCharge and CriticalCharge are the charge levels at which to
+	// raise warnings and critical alerts.
+	WarningCharge  float64
+	CriticalCharge float64
+	// WeakSignal is the signal strength, between 0 and 1, below which to
+	// warn.
+	WeakSignal float64
+	// Hysteresis is how far a reading must move back past a threshold to
+	// clear its alert, so that alerts don't flicker.
+	Hysteresis float64
+	// Bridge, if set, is sent every alert.
+	Bridge *alert.Bridge
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *HealthConfig) SetDefaults() {
+	if cfg.WarningHeat == 0 {
+		cfg.WarningHeat = 0.8
+	}
+	if cfg.CriticalHeat == 0 {
+		cfg.CriticalHeat = 0.95
+	}
+	if cfg.WarningCharge == 0 {
+		cfg.WarningCharge = 0.2
+	}
+	if cfg.CriticalCharge == 0 {
+		cfg.CriticalCharge = 0.05
+	}
+	if cfg.WeakSignal == 0 {
+		cfg.WeakSignal = 0.1
+	}
+	if cfg.Hysteresis == 0 {
+		cfg.Hysteresis = 0.02
+	}
+}
+
+// healthLevel is how bad a reading is.
+type healthLevel int
+
+const (
+	healthOK healthLevel = iota
+	healthWarning
+	healthCritical
+)
+
+// severity returns the alert severity for a level.
+func (l healthLevel) severity() alert.Severity {
+	if l == healthCritical {
+		return alert.Critical
+	}
+	return alert.Warning
+}
+
+// thresholdLevel returns the level of a reading, where readings above the
+// thresholds are bad, given the current level. A level is kept until the
+// reading drops below its threshold by the hysteresis. For readings where
+// low values are bad, negate the reading and thresholds.
+func thresholdLevel(value, warning, critical, hysteresis float64, current healthLevel) healthLevel {
+	switch {
+	case value >= critical:
+		return healthCritical
+	case current == healthCritical && value > critical-hysteresis:
+		return healthCritical
+	case value >= warning:
+		return healthWarning
+	case current >= healthWarning && value > warning-hysteresis:
+		return healthWarning
+	}
+	return healthOK
+}
+
+// HealthMonitor streams a vessel's part temperatures, electric charge and
+// comms status, and raises alerts as they cross their thresholds.
+type HealthMonitor struct {
+	vessel *Vessel
+	cfg    HealthConfig
+	// OnAlert, if set, is called with every alert.
+	OnAlert func(HealthAlert)
+}
+
+// NewHealthMonitor creates a new HealthMonitor.
+func NewHealthMonitor(vessel *Vessel, cfg HealthConfig) *HealthMonitor {
+	cfg.SetDefaults()
+	return &HealthMonitor{vessel: vessel, cfg: cfg}
+}
+
+// healthReading is a value from one of the monitor's streams.
+type healthReading struct {
+	// part is the index of the part for temperature readings, or -1.
+	part int
+	kind HealthAlertKind
+	// skin is set for skin temperatures.
+	skin  bool
+	value float64
+}
+
+// forwardReadings sends values from a stream to the readings channel until
+// the context is done or the stream is closed.
+func forwardReadings[T any](ctx context.Context, c <-chan T, readings chan<- healthReading, reading func(T) healthReading) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case v, ok := <-c:
+			if !ok {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case readings <- reading(v):
+			}
+		}
+	}
+}
+
+// healthPart is a part being watched for overheating.
+type healthPart struct {
+	part           *Part
+	title          string
+	maxTemperature float64
+	maxSkin        float64
+	// heat and skin are the latest temperatures as fractions of their
+	// maximums.
+	heat, skin float64
+	level      healthLevel
+}
+
+// raise sends an alert to the callback and the bridge.
+func (m *HealthMonitor) raise(a HealthAlert) {
+	if m.OnAlert != nil {
+		m.OnAlert(a)
+	}
+	if m.cfg.Bridge != nil {
+		m.cfg.Bridge.Alert(a.Alert())
+	}
+}
+
+// update works out the new level of a reading and raises an alert if it has
+// changed. value and thresholds are as given to thresholdLevel, and shown
+// negated in alerts if negate is set.
+func (m *HealthMonitor) update(a HealthAlert, level *healthLevel, value, warning, critical float64, negate bool) {
+	next := thresholdLevel(value, warning, critical, m.cfg.Hysteresis, *level)
+	if next == *level {
+		return
+	}
+	// Report the threshold that was crossed.
+	threshold := warning
+	if next == healthCritical || (next == healthOK && *level == healthCritical) {
+		threshold = critical
+	}
+	*level = next
+	a.Severity = next.severity()
+	a.Cleared = next == healthOK
+	if a.Cleared {
+		a.Severity = alert.Info
+	}
+	a.Value, a.Threshold = value, threshold
+	if negate {
+		a.Value, a.Threshold = -value, -threshold
+	}
+	m.raise(a)
+}
+
+// Run watches the vessel until the context is canceled.
+func (m *HealthMonitor) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	readings := make(chan healthReading)
+
+	name, err := m.vessel.Name()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	parts, err := m.vessel.Parts()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	all, err := parts.All()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	watched := make([]healthPart, len(all))
+	for i, part := range all {
+		i := i
+		p := &watched[i]
+		p.part = part
+		if p.title, err = part.Title(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if p.maxTemperature, err = part.MaxTemperature(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if p.maxSkin, err = part.MaxSkinTemperature(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		temperatures, err := part.TemperatureStream()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		defer temperatures.Close()
+		go forwardReadings(ctx, temperatures.C, readings, func(v float64) healthReading {
+			return healthReading{part: i, kind: HealthOverheating, value: v}
+		})
+		skins, err := part.SkinTemperatureStream()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		defer skins.Close()
+		go forwardReadings(ctx, skins.C, readings, func(v float64) healthReading {
+			return healthReading{part: i, kind: HealthOverheating, skin: true, value: v}
+		})
+	}
+
+	resources, err := m.vessel.Resources()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	capacity, err := resources.Max("ElectricCharge")
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if capacity > 0 {
+		charges, err := resources.AmountStream("ElectricCharge")
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		defer charges.Close()
+		go forwardReadings(ctx, charges.C, readings, func(v float32) healthReading {
+			return healthReading{part: -1, kind: HealthLowCharge, value: float64(v / capacity)}
+		})
+	}
+
+	comms, err := m.vessel.Comms()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	connected, err := comms.CanCommunicateStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer connected.Close()
+	go forwardReadings(ctx, connected.C, readings, func(v bool) healthReading {
+		r := healthReading{part: -1, kind: HealthCommsLost}
+		if v {
+			r.value = 1
+		}
+		return r
+	})
+	strengths, err := comms.SignalStrengthStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer strengths.Close()
+	go forwardReadings(ctx, strengths.C, readings, func(v float64) healthReading {
+		return healthReading{part: -1, kind: HealthWeakSignal, value: v}
+	})
+
+	var chargeLevel, signalLevel, commsLevel healthLevel
+	vesselAlert := func(kind HealthAlertKind) HealthAlert {
+		return HealthAlert{Kind: kind, Source: name}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case r := <-readings:
+			switch r.kind {
+			case HealthOverheating:
+				p := &watched[r.part]
+				if r.skin {
+					p.skin = r.value / p.maxSkin
+				} else {
+					p.heat = r.value / p.maxTemperature
+				}
+				heat := p.heat
+				if p.skin > heat {
+					heat = p.skin
+				}
+				a := HealthAlert{Kind: HealthOverheating, Part: p.part, Source: p.title}
+				m.update(a, &p.level, heat, m.cfg.WarningHeat, m.cfg.CriticalHeat, false)
+			case HealthLowCharge:
+				m.update(vesselAlert(r.kind), &chargeLevel, -r.value, -m.cfg.WarningCharge, -m.cfg.CriticalCharge, true)
+			case HealthWeakSignal:
+				// A weak signal only matters while there is one.
+				if commsLevel == healthOK {
+					m.update(vesselAlert(r.kind), &signalLevel, -r.value, -m.cfg.WeakSignal, math.Inf(1), true)
+				}
+			case HealthCommsLost:
+				// Losing the connection is always critical.
+				m.update(vesselAlert(r.kind), &commsLevel, -r.value, -0.5, -0.5, true)
+			}
+		}
+	}
+}
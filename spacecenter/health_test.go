@@ -0,0 +1,62 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdLevel(t *testing.T) {
+	tcs := []struct {
+		name     string
+		value    float64
+		current  healthLevel
+		expected healthLevel
+	}{
+		{name: "fine", value: 0.5, expected: healthOK},
+		{name: "warm", value: 0.85, expected: healthWarning},
+		{name: "hot", value: 0.97, expected: healthCritical},
+		{name: "cooling", value: 0.94, current: healthCritical, expected: healthCritical},
+		{name: "cooled", value: 0.9, current: healthCritical, expected: healthWarning},
+		{name: "just under warning", value: 0.79, current: healthWarning, expected: healthWarning},
+		{name: "cleared", value: 0.7, current: healthWarning, expected: healthOK},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, thresholdLevel(tc.value, 0.8, 0.95, 0.02, tc.current))
+		})
+	}
+}
+
+func TestHealthMonitorUpdate(t *testing.T) {
+	bridge := &alert.Bridge{}
+	var bridged []alert.Alert
+	bridge.Add(alert.SinkFunc(func(a alert.Alert) {
+		bridged = append(bridged, a)
+	}))
+	m := NewHealthMonitor(nil, HealthConfig{Bridge: bridge})
+	var alerts []HealthAlert
+	m.OnAlert = func(a HealthAlert) {
+		alerts = append(alerts, a)
+	}
+
+	var level healthLevel
+	charge := func(v float64) {
+		m.update(HealthAlert{Kind: HealthLowCharge, Source: "Kerbal X"}, &level, -v, -m.cfg.WarningCharge, -m.cfg.CriticalCharge, true)
+	}
+	for _, v := range []float64{0.9, 0.5, 0.15, 0.14, 0.03, 0.5} {
+		charge(v)
+	}
+	require.Equal(t, []HealthAlert{
+		{Kind: HealthLowCharge, Source: "Kerbal X", Severity: alert.Warning, Value: 0.15, Threshold: 0.2},
+		{Kind: HealthLowCharge, Source: "Kerbal X", Severity: alert.Critical, Value: 0.03, Threshold: 0.05},
+		{Kind: HealthLowCharge, Source: "Kerbal X", Severity: alert.Info, Value: 0.5, Threshold: 0.05, Cleared: true},
+	}, alerts)
+
+	require.Len(t, bridged, 3)
+	require.Equal(t, "low charge", bridged[0].Name)
+	require.Equal(t, alert.Warning, bridged[0].Severity)
+	require.Equal(t, "Kerbal X: low charge (0.15, limit 0.20)", bridged[0].Message)
+	require.True(t, bridged[2].Cleared)
+}
@@ -0,0 +1,320 @@
+package spacecenter
+
+import (
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// InvalidationReason is why handles were invalidated.
+type InvalidationReason int
+
+const (
+	// VesselSwitched means the active vessel changed, because the player
+	// switched vessels or a save was loaded in the flight scene.
+	VesselSwitched InvalidationReason = iota
+	// SceneChanged means the game changed scene.
+	SceneChanged
+)
+
+// String returns the name of the reason.
+func (r InvalidationReason) String() string {
+	switch r {
+	case VesselSwitched:
+		return "vessel switched"
+	case SceneChanged:
+		return "scene changed"
+	}
+	return "unknown"
+}
+
+// Invalidation reports that class handles, such as vessels, parts and
+// nodes, from before it may be stale: the objects they refer to may be gone,
+// or their IDs may now refer to other objects.
+type Invalidation struct {
+	Reason InvalidationReason
+	// Scene is the scene the game is now in.
+	Scene krpc.GameScene
+	// ActiveVessel is the active vessel now, or nil outside the flight
+	// scene.
+	ActiveVessel *Vessel
+	// Generation counts the invalidations so far, including this one.
+	Generation uint64
+}
+
+// HandleTrackerConfig configures a HandleTracker.
+type HandleTrackerConfig struct {
+	// Settle is how long to wait for more changes before reporting one, so
+	// that a load, which changes both the scene and the active vessel, is
+	// reported once. Defaults to 200ms.
+	Settle time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *HandleTrackerConfig) SetDefaults() {
+	if cfg.Settle == 0 {
+		cfg.Settle = 200 * time.Millisecond
+	}
+}
+
+// TrackedVessel is a vessel whose handle is found again by name and type
+// when handles are invalidated.
+type TrackedVessel struct {
+	sc *SpaceCenter
+	id vesselIdentity
+
+	mu     sync.Mutex
+	vessel *Vessel
+}
+
+// Name returns the name of the vessel.
+func (v *TrackedVessel) Name() string {
+	return v.id.name
+}
+
+// Vessel returns the vessel's current handle, or nil if it couldn't be
+// found after the last invalidation.
+func (v *TrackedVessel) Vessel() *Vessel {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.vessel
+}
+
+// Resolve finds the vessel again, e.g. when it couldn't be found while a
+// save was still loading. It returns false if there is no such vessel.
+func (v *TrackedVessel) Resolve() (bool, error) {
+	vessels, err := v.sc.Vessels()
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	for _, vessel := range vessels {
+		id, err := identify(vessel)
+		if err != nil {
+			return false, tracerr.Wrap(err)
+		}
+		if id == v.id {
+			v.mu.Lock()
+			v.vessel = vessel
+			v.mu.Unlock()
+			return true, nil
+		}
+	}
+	v.mu.Lock()
+	v.vessel = nil
+	v.mu.Unlock()
+	return false, nil
+}
+
+// HandleTracker watches for vessel switches and scene changes, which can
+// leave class handles stale, and tells the code holding them, so that it
+// doesn't silently act on the wrong object:
+//
+//	tracker, err := spacecenter.NewHandleTracker(sc, spacecenter.HandleTrackerConfig{})
+//	...
+//	gen := tracker.Generation()
+//	parts, err := vessel.Parts()
+//	...
+//	if !tracker.Valid(gen) {
+//		// Look the parts up again.
+//	}
+//
+// Vessels can also be tracked, so that their handles are found again after
+// each invalidation. Callbacks run on the tracker's goroutine, one at a
+// time, after tracked vessels have been found again.
+type HandleTracker struct {
+	sc  *SpaceCenter
+	cfg HandleTrackerConfig
+
+	mu         sync.Mutex
+	generation uint64
+	callbacks  []func(Invalidation)
+	tracked    []*TrackedVessel
+
+	streams []interface{ Close() error }
+	done    chan struct{}
+	once    sync.Once
+}
+
+// activeVesselStream streams the object ID of the active vessel, which is 0
+// outside the flight scene.
+func (s *SpaceCenter) activeVesselStream() (*krpcgo.Stream[uint64], error) {
+	request := &types.ProcedureCall{
+		Procedure: "get_ActiveVessel",
+		Service:   "SpaceCenter",
+	}
+	k := krpc.New(s.Client)
+	st, err := k.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	stream := krpcgo.MapStream(s.Client.GetStream(st.Id), func(b []byte) uint64 {
+		var vessel Vessel
+		encode.Unmarshal(b, &vessel)
+		return vessel.ID_internal()
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(k.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// NewHandleTracker creates a new HandleTracker, following the scene and
+// active vessel by stream until it is closed. The tracker is closed when the
+// client is.
+func NewHandleTracker(sc *SpaceCenter, cfg HandleTrackerConfig) (*HandleTracker, error) {
+	if !sc.Client.StreamsAvailable() {
+		return nil, tracerr.Errorf("Tracking handles needs a stream connection")
+	}
+	cfg.SetDefaults()
+	k := krpc.New(sc.Client)
+	scene, err := k.CurrentGameScene()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var activeID uint64
+	if scene == krpc.GameScene_Flight {
+		active, err := sc.ActiveVessel()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if active != nil {
+			activeID = active.ID_internal()
+		}
+	}
+
+	sceneStream, err := k.CurrentGameSceneStream()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	activeStream, err := sc.activeVesselStream()
+	if err != nil {
+		_ = sceneStream.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	t := &HandleTracker{
+		sc:      sc,
+		cfg:     cfg,
+		streams: []interface{ Close() error }{sceneStream, activeStream},
+		done:    make(chan struct{}),
+	}
+	go t.run(sceneStream, activeStream, scene, activeID)
+	sc.Client.OnClose(func() { _ = t.Close() })
+	return t, nil
+}
+
+func (t *HandleTracker) run(sceneStream *krpcgo.Stream[krpc.GameScene], activeStream *krpcgo.Stream[uint64], scene krpc.GameScene, activeID uint64) {
+	// pending is set while a change waits to be reported, and settled
+	// fires once the changes have stopped.
+	var pending *Invalidation
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-t.done:
+			return
+		case s := <-sceneStream.C:
+			if s == scene {
+				continue
+			}
+			scene = s
+			if pending == nil {
+				pending = &Invalidation{}
+			}
+			// A scene change is the more drastic, so it is what's reported.
+			pending.Reason = SceneChanged
+			settled = time.After(t.cfg.Settle)
+		case id := <-activeStream.C:
+			if id == activeID {
+				continue
+			}
+			activeID = id
+			if pending == nil {
+				pending = &Invalidation{Reason: VesselSwitched}
+			}
+			settled = time.After(t.cfg.Settle)
+		case <-settled:
+			pending.Scene = scene
+			if activeID != 0 {
+				pending.ActiveVessel = NewVessel(activeID, t.sc.Client)
+			}
+			t.invalidate(*pending)
+			pending, settled = nil, nil
+		}
+	}
+}
+
+// invalidate finds tracked vessels again and calls the callbacks.
+func (t *HandleTracker) invalidate(inv Invalidation) {
+	t.mu.Lock()
+	t.generation++
+	inv.Generation = t.generation
+	tracked := t.tracked
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	for _, v := range tracked {
+		// A vessel that can't be found now may be once the game has
+		// finished loading, so failures leave it for Resolve.
+		if _, err := v.Resolve(); err != nil {
+			v.mu.Lock()
+			v.vessel = nil
+			v.mu.Unlock()
+		}
+	}
+	for _, f := range callbacks {
+		f(inv)
+	}
+}
+
+// Generation returns the number of invalidations so far.
+func (t *HandleTracker) Generation() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.generation
+}
+
+// Valid checks if handles obtained at a generation are still valid.
+func (t *HandleTracker) Valid(generation uint64) bool {
+	return t.Generation() == generation
+}
+
+// OnInvalidate registers a callback for invalidations.
+func (t *HandleTracker) OnInvalidate(f func(Invalidation)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.callbacks = append(t.callbacks, f)
+}
+
+// Track starts finding a vessel again, by its current name and type, after
+// each invalidation. Vessels with the same name and type can't be told
+// apart, so the first is used.
+func (t *HandleTracker) Track(vessel *Vessel) (*TrackedVessel, error) {
+	id, err := identify(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	v := &TrackedVessel{sc: t.sc, id: id, vessel: vessel}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracked = append(t.tracked, v)
+	return v, nil
+}
+
+// Close stops the tracker. Its callbacks are no longer called, and tracked
+// vessels are no longer found again.
+func (t *HandleTracker) Close() error {
+	var err error
+	t.once.Do(func() {
+		close(t.done)
+		for _, stream := range t.streams {
+			if closeErr := stream.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	})
+	return tracerr.Wrap(err)
+}
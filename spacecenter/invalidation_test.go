@@ -0,0 +1,87 @@
+package spacecenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleTracker(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	names := map[uint64]string{1: "Kerbal X", 2: "Relay", 5: "Kerbal X", 6: "Relay"}
+	server.Handle("SpaceCenter", "Vessel_get_Name", func(call *krpctest.Call) (interface{}, error) {
+		var vessel Vessel
+		if err := call.Arg(0, &vessel); err != nil {
+			return nil, err
+		}
+		return names[vessel.ID_internal()], nil
+	})
+	server.Return("SpaceCenter", "Vessel_get_Type", VesselType_Ship)
+	server.Return("KRPC", "get_CurrentGameScene", krpc.GameScene_Flight)
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(1))
+	server.Return("SpaceCenter", "get_Vessels", []uint64{1, 2})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc := New(client)
+
+	tracker, err := NewHandleTracker(sc, HandleTrackerConfig{Settle: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer tracker.Close()
+	relay, err := tracker.Track(NewVessel(2, client))
+	require.NoError(t, err)
+	require.Equal(t, "Relay", relay.Name())
+	invalidations := make(chan Invalidation, 2)
+	tracker.OnInvalidate(func(inv Invalidation) { invalidations <- inv })
+	gen := tracker.Generation()
+	require.True(t, tracker.Valid(gen))
+
+	next := func() Invalidation {
+		select {
+		case inv := <-invalidations:
+			return inv
+		case <-time.After(time.Second):
+			require.FailNow(t, "no invalidation")
+		}
+		return Invalidation{}
+	}
+
+	// Loading a save in flight gives every vessel a new ID.
+	server.Return("SpaceCenter", "get_Vessels", []uint64{5, 6})
+	server.Feed("SpaceCenter", "get_ActiveVessel", uint64(5))
+	inv := next()
+	require.Equal(t, VesselSwitched, inv.Reason)
+	require.Equal(t, krpc.GameScene_Flight, inv.Scene)
+	require.Equal(t, uint64(5), inv.ActiveVessel.ID_internal())
+	require.Equal(t, uint64(1), inv.Generation)
+	require.False(t, tracker.Valid(gen))
+	require.Equal(t, uint64(6), relay.Vessel().ID_internal())
+
+	// Leaving the flight scene changes the scene and the active vessel, and
+	// is reported once.
+	server.Return("SpaceCenter", "get_Vessels", []uint64{})
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(0))
+	server.Return("KRPC", "get_CurrentGameScene", krpc.GameScene_SpaceCenter)
+	server.Update()
+	inv = next()
+	require.Equal(t, SceneChanged, inv.Reason)
+	require.Equal(t, krpc.GameScene_SpaceCenter, inv.Scene)
+	require.Nil(t, inv.ActiveVessel)
+	require.Equal(t, uint64(2), tracker.Generation())
+	require.Nil(t, relay.Vessel())
+	select {
+	case inv := <-invalidations:
+		require.FailNow(t, "unexpected invalidation", "%+v", inv)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestInvalidationReasonString(t *testing.T) {
+	require.Equal(t, "scene changed", SceneChanged.String())
+	require.Equal(t, "unknown", InvalidationReason(-1).String())
+}
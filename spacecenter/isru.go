@@ -0,0 +1,376 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ISRUConfig configures an ISRU supervisor. Fractions of storage and charge
+// are between 0 and 1. Each limit has a matching resume level, so that parts
+// cycle on and off rather than flickering at the limit.
+type ISRUConfig struct {
+	// MinConcentration is the lowest ore concentration, as a fraction, worth
+	// drilling. It is only checked if the vessel has a scanner reporting it.
+	MinConcentration float64
+	// ConcentrationModule and ConcentrationField are the part module and
+	// field that report the ore concentration as a percentage.
+	ConcentrationModule string
+	ConcentrationField  string
+	// OreFull is the fraction of ore storage at which drilling stops, and
+	// OreResume is where it starts again.
+	OreFull   float64
+	OreResume float64
+	// OreEmpty is the fraction of ore storage below which converters stop.
+	OreEmpty float64
+	// Products are the resources the converters make.
+	Products []string
+	// ProductsFull is the fraction of storage at which converters stop once
+	// every product is that full, and ProductsResume is where they start
+	// again once any product drops below it.
+	ProductsFull   float64
+	ProductsResume float64
+	// MaxHeat is the core temperature, as a fraction of the optimum, at which
+	// everything stops to cool down, and ResumeHeat is where it starts again.
+	MaxHeat    float64
+	ResumeHeat float64
+	// MinCharge is the fraction of electric charge at which everything stops
+	// to recharge, and ResumeCharge is where it starts again.
+	MinCharge    float64
+	ResumeCharge float64
+	// Interval is how often to check the readings.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ISRUConfig) SetDefaults() {
+	if cfg.ConcentrationModule == "" {
+		cfg.ConcentrationModule = "ModuleResourceScanner"
+	}
+	if cfg.ConcentrationField == "" {
+		cfg.ConcentrationField = "Ore"
+	}
+	if cfg.OreFull == 0 {
+		cfg.OreFull = 0.98
+	}
+	if cfg.OreResume == 0 {
+		cfg.OreResume = 0.5
+	}
+	if cfg.OreEmpty == 0 {
+		cfg.OreEmpty = 0.01
+	}
+	if cfg.Products == nil {
+		cfg.Products = []string{"LiquidFuel", "Oxidizer"}
+	}
+	if cfg.ProductsFull == 0 {
+		cfg.ProductsFull = 0.99
+	}
+	if cfg.ProductsResume == 0 {
+		cfg.ProductsResume = 0.9
+	}
+	if cfg.MaxHeat == 0 {
+		cfg.MaxHeat = 1.2
+	}
+	if cfg.ResumeHeat == 0 {
+		cfg.ResumeHeat = 1
+	}
+	if cfg.MinCharge == 0 {
+		cfg.MinCharge = 0.1
+	}
+	if cfg.ResumeCharge == 0 {
+		cfg.ResumeCharge = 0.5
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+}
+
+// isruReadings are the measurements an ISRU supervisor works from.
+type isruReadings struct {
+	// concentration is the ore concentration, or NaN if it's unknown.
+	concentration float64
+	// ore, charge and products are fractions of storage.
+	ore      float64
+	charge   float64
+	products []float64
+	// heat is the hottest core temperature as a fraction of its optimum.
+	heat float64
+}
+
+// ISRUStatus is what an ISRU supervisor is doing.
+type ISRUStatus struct {
+	Drilling   bool
+	Converting bool
+	// PowerLow is set while waiting to recharge.
+	PowerLow bool
+	// Overheated is set while waiting to cool down.
+	Overheated bool
+}
+
+// Radiators reports whether radiators are needed.
+func (s ISRUStatus) Radiators() bool {
+	return s.Drilling || s.Converting
+}
+
+// isruDecide decides what to run next, given the readings and what is
+// running now.
+func isruDecide(r isruReadings, s ISRUStatus, cfg ISRUConfig) ISRUStatus {
+	next := ISRUStatus{
+		PowerLow:   r.charge < cfg.MinCharge || (s.PowerLow && r.charge < cfg.ResumeCharge),
+		Overheated: r.heat > cfg.MaxHeat || (s.Overheated && r.heat > cfg.ResumeHeat),
+	}
+	if next.PowerLow || next.Overheated {
+		return next
+	}
+
+	oreLimit := cfg.OreResume
+	if s.Drilling {
+		oreLimit = cfg.OreFull
+	}
+	richEnough := math.IsNaN(r.concentration) || r.concentration >= cfg.MinConcentration
+	next.Drilling = richEnough && r.ore < oreLimit
+
+	// Keep converting until every product is full, and start again once any
+	// of them has room.
+	full := len(r.products) > 0
+	room := false
+	for _, p := range r.products {
+		full = full && p >= cfg.ProductsFull
+		room = room || p < cfg.ProductsResume
+	}
+	wanted := room || (s.Converting && !full)
+	next.Converting = wanted && r.ore > cfg.OreEmpty
+	return next
+}
+
+// parsePercentage parses a field value such as "5.23%" into a fraction.
+func parsePercentage(value string) (float64, bool) {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v / 100, true
+}
+
+// ISRUSupervisor runs a vessel's drills, converters and radiators, cycling
+// them on and off to suit the ore concentration, storage, temperature and
+// electric charge.
+type ISRUSupervisor struct {
+	vessel *Vessel
+	cfg    ISRUConfig
+	// OnChange, if set, is called whenever the status changes.
+	OnChange func(ISRUStatus)
+}
+
+// NewISRUSupervisor creates a new ISRUSupervisor.
+func NewISRUSupervisor(vessel *Vessel, cfg ISRUConfig) *ISRUSupervisor {
+	cfg.SetDefaults()
+	return &ISRUSupervisor{vessel: vessel, cfg: cfg}
+}
+
+// storageFraction returns how full a vessel's storage of a resource is, or
+// NaN if it can't hold any.
+func storageFraction(resources *Resources, name string) (float64, error) {
+	max, err := resources.Max(name)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	if max == 0 {
+		return math.NaN(), nil
+	}
+	amount, err := resources.Amount(name)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return float64(amount / max), nil
+}
+
+// concentration reads the ore concentration from a scanner, or NaN if there
+// isn't one.
+func (s *ISRUSupervisor) concentration(parts *Parts) (float64, error) {
+	modules, err := parts.ModulesWithName(s.cfg.ConcentrationModule)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	for _, module := range modules {
+		ok, err := module.HasField(s.cfg.ConcentrationField)
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		if !ok {
+			continue
+		}
+		value, err := module.GetField(s.cfg.ConcentrationField)
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		if c, ok := parsePercentage(value); ok {
+			return c, nil
+		}
+	}
+	return math.NaN(), nil
+}
+
+// read takes the readings.
+func (s *ISRUSupervisor) read(parts *Parts, harvesters []*ResourceHarvester, converters []*ResourceConverter) (isruReadings, error) {
+	var r isruReadings
+	resources, err := s.vessel.Resources()
+	if err != nil {
+		return r, tracerr.Wrap(err)
+	}
+	if r.ore, err = storageFraction(resources, "Ore"); err != nil {
+		return r, tracerr.Wrap(err)
+	}
+	if r.charge, err = storageFraction(resources, "ElectricCharge"); err != nil {
+		return r, tracerr.Wrap(err)
+	}
+	if math.IsNaN(r.charge) {
+		r.charge = 1
+	}
+	for _, name := range s.cfg.Products {
+		p, err := storageFraction(resources, name)
+		if err != nil {
+			return r, tracerr.Wrap(err)
+		}
+		if !math.IsNaN(p) {
+			r.products = append(r.products, p)
+		}
+	}
+	if r.concentration, err = s.concentration(parts); err != nil {
+		return r, tracerr.Wrap(err)
+	}
+
+	type heater interface {
+		CoreTemperature() (float32, error)
+		OptimumCoreTemperature() (float32, error)
+	}
+	var heaters []heater
+	for _, h := range harvesters {
+		heaters = append(heaters, h)
+	}
+	for _, c := range converters {
+		heaters = append(heaters, c)
+	}
+	for _, h := range heaters {
+		core, err := h.CoreTemperature()
+		if err != nil {
+			return r, tracerr.Wrap(err)
+		}
+		optimum, err := h.OptimumCoreTemperature()
+		if err != nil {
+			return r, tracerr.Wrap(err)
+		}
+		if optimum > 0 {
+			r.heat = math.Max(r.heat, float64(core/optimum))
+		}
+	}
+	return r, nil
+}
+
+// apply sets the parts to match a status.
+func (s *ISRUSupervisor) apply(status ISRUStatus, harvesters []*ResourceHarvester, converters []*ResourceConverter, radiators []*Radiator) error {
+	for _, h := range harvesters {
+		deployed, err := h.Deployed()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if !deployed {
+			// Drills can't run until they're deployed, which takes a while,
+			// so leave them deployed between cycles.
+			if err := h.SetDeployed(true); err != nil {
+				return tracerr.Wrap(err)
+			}
+			continue
+		}
+		if err := h.SetActive(status.Drilling); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	for _, c := range converters {
+		count, err := c.Count()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		for i := int32(0); i < count; i++ {
+			active, err := c.Active(i)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			switch {
+			case status.Converting && !active:
+				err = c.Start(i)
+			case !status.Converting && active:
+				err = c.Stop(i)
+			}
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+	for _, r := range radiators {
+		deployable, err := r.Deployable()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if !deployable {
+			continue
+		}
+		if err := r.SetDeployed(status.Radiators()); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Run supervises the ISRU parts until the context is canceled, then stops
+// the drills and converters.
+func (s *ISRUSupervisor) Run(ctx context.Context) error {
+	parts, err := s.vessel.Parts()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	harvesters, err := parts.ResourceHarvesters()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	converters, err := parts.ResourceConverters()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	radiators, err := parts.Radiators()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if len(harvesters) == 0 && len(converters) == 0 {
+		return tracerr.Errorf("Vessel has no drills or converters")
+	}
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	var status ISRUStatus
+	first := true
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(s.apply(ISRUStatus{}, harvesters, converters, radiators))
+		case <-ticker.C:
+			readings, err := s.read(parts, harvesters, converters)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			next := isruDecide(readings, status, s.cfg)
+			if err := s.apply(next, harvesters, converters, radiators); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if (first || next != status) && s.OnChange != nil {
+				s.OnChange(next)
+			}
+			status, first = next, false
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestISRUDecide(t *testing.T) {
+	cfg := ISRUConfig{MinConcentration: 0.02}
+	cfg.SetDefaults()
+	normal := isruReadings{concentration: math.NaN(), ore: 0.3, charge: 0.8, products: []float64{0.5, 0.5}, heat: 0.9}
+	with := func(f func(r *isruReadings)) isruReadings {
+		r := normal
+		r.products = append([]float64(nil), normal.products...)
+		f(&r)
+		return r
+	}
+	running := ISRUStatus{Drilling: true, Converting: true}
+	tcs := []struct {
+		name     string
+		readings isruReadings
+		status   ISRUStatus
+		expected ISRUStatus
+	}{
+		{name: "start", readings: normal, expected: running},
+		{name: "keep running", readings: normal, status: running, expected: running},
+		{
+			name:     "ore nearly full",
+			readings: with(func(r *isruReadings) { r.ore = 0.99 }),
+			status:   running,
+			expected: ISRUStatus{Converting: true},
+		},
+		{
+			name:     "ore draining",
+			readings: with(func(r *isruReadings) { r.ore = 0.7 }),
+			status:   ISRUStatus{Converting: true},
+			expected: ISRUStatus{Converting: true},
+		},
+		{
+			name:     "ore drained",
+			readings: with(func(r *isruReadings) { r.ore = 0.4 }),
+			status:   ISRUStatus{Converting: true},
+			expected: running,
+		},
+		{
+			name:     "out of ore",
+			readings: with(func(r *isruReadings) { r.ore = 0 }),
+			status:   running,
+			expected: ISRUStatus{Drilling: true},
+		},
+		{
+			name:     "products full",
+			readings: with(func(r *isruReadings) { r.products = []float64{1, 0.995} }),
+			status:   running,
+			expected: ISRUStatus{Drilling: true},
+		},
+		{
+			name:     "one product still filling",
+			readings: with(func(r *isruReadings) { r.products = []float64{1, 0.95} }),
+			status:   running,
+			expected: running,
+		},
+		{
+			name:     "products used a little",
+			readings: with(func(r *isruReadings) { r.products = []float64{1, 0.95} }),
+			status:   ISRUStatus{Drilling: true},
+			expected: ISRUStatus{Drilling: true},
+		},
+		{
+			name:     "poor ground",
+			readings: with(func(r *isruReadings) { r.concentration = 0.01 }),
+			expected: ISRUStatus{Converting: true},
+		},
+		{
+			name:     "rich ground",
+			readings: with(func(r *isruReadings) { r.concentration = 0.05 }),
+			expected: running,
+		},
+		{
+			name:     "overheating",
+			readings: with(func(r *isruReadings) { r.heat = 1.3 }),
+			status:   running,
+			expected: ISRUStatus{Overheated: true},
+		},
+		{
+			name:     "cooling down",
+			readings: with(func(r *isruReadings) { r.heat = 1.1 }),
+			status:   ISRUStatus{Overheated: true},
+			expected: ISRUStatus{Overheated: true},
+		},
+		{
+			name:     "cooled down",
+			readings: normal,
+			status:   ISRUStatus{Overheated: true},
+			expected: running,
+		},
+		{
+			name:     "battery flat",
+			readings: with(func(r *isruReadings) { r.charge = 0.05 }),
+			status:   running,
+			expected: ISRUStatus{PowerLow: true},
+		},
+		{
+			name:     "recharging",
+			readings: with(func(r *isruReadings) { r.charge = 0.3 }),
+			status:   ISRUStatus{PowerLow: true},
+			expected: ISRUStatus{PowerLow: true},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isruDecide(tc.readings, tc.status, cfg))
+		})
+	}
+}
+
+func TestParsePercentage(t *testing.T) {
+	tcs := []struct {
+		value    string
+		expected float64
+		ok       bool
+	}{
+		{value: "5.23%", expected: 0.0523, ok: true},
+		{value: " 12 % ", expected: 0.12, ok: true},
+		{value: "0", expected: 0, ok: true},
+		{value: "n/a"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.value, func(t *testing.T) {
+			v, ok := parsePercentage(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.InDelta(t, tc.expected, v, 1e-12)
+		})
+	}
+}
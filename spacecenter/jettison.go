@@ -0,0 +1,235 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// FlightTrigger is an action to take once during an ascent, when the vessel
+// is high enough and past max Q.
+type FlightTrigger struct {
+	Name string
+	// Altitude is the mean altitude, in meters, at or above which to fire.
+	// Zero means any altitude.
+	Altitude float64
+	// DynamicPressure is the dynamic pressure, in Pa, that must be crossed
+	// on the way down, after max Q, before firing. Zero means any pressure.
+	DynamicPressure float64
+	// Action is what to do.
+	Action func() error
+}
+
+// conditions returns the trigger's conditions on altitude and dynamic
+// pressure.
+func (t FlightTrigger) conditions() (krpcgo.Condition[float64], krpcgo.Condition[float64]) {
+	altitude := krpcgo.Above(t.Altitude)
+	pressure := krpcgo.CrossedBelow(t.DynamicPressure)
+	if t.DynamicPressure == 0 {
+		pressure = krpcgo.Above(math.Inf(-1))
+	}
+	return altitude, pressure
+}
+
+// FlightTriggers fires actions, such as jettisoning fairings or the launch
+// escape system or extending antennas, as a vessel climbs.
+type FlightTriggers struct {
+	vessel   *Vessel
+	triggers []FlightTrigger
+	// OnFire, if set, is called with each trigger after it fires.
+	OnFire func(FlightTrigger)
+}
+
+// NewFlightTriggers creates a new FlightTriggers.
+func NewFlightTriggers(vessel *Vessel, triggers ...FlightTrigger) *FlightTriggers {
+	return &FlightTriggers{vessel: vessel, triggers: triggers}
+}
+
+// Add adds a trigger. It must not be called while running.
+func (f *FlightTriggers) Add(trigger FlightTrigger) {
+	f.triggers = append(f.triggers, trigger)
+}
+
+// Run fires each trigger once its conditions are met, until all of them
+// have fired.
+func (f *FlightTriggers) Run(ctx context.Context) error {
+	orbit, err := f.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rf, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	flight, err := f.vessel.Flight(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	altitudes, err := flight.MeanAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer altitudes.Close()
+	pressures, err := flight.DynamicPressureStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer pressures.Close()
+
+	type pending struct {
+		trigger     FlightTrigger
+		altitude    krpcgo.Condition[float64]
+		pressure    krpcgo.Condition[float64]
+		altitudeMet bool
+		pressureMet bool
+	}
+	var waiting []*pending
+	for _, t := range f.triggers {
+		altitude, pressure := t.conditions()
+		waiting = append(waiting, &pending{trigger: t, altitude: altitude, pressure: pressure})
+	}
+
+	for len(waiting) > 0 {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case v := <-altitudes.C:
+			for _, p := range waiting {
+				p.altitudeMet = p.altitude(v)
+			}
+		case v := <-pressures.C:
+			for _, p := range waiting {
+				p.pressureMet = p.pressure(float64(v))
+			}
+		}
+
+		var still []*pending
+		for _, p := range waiting {
+			if !p.altitudeMet || !p.pressureMet {
+				still = append(still, p)
+				continue
+			}
+			if err := p.trigger.Action(); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if f.OnFire != nil {
+				f.OnFire(p.trigger)
+			}
+		}
+		waiting = still
+	}
+	return nil
+}
+
+// ActionGroupAction returns an action that toggles an action group.
+func ActionGroupAction(control *Control, group uint32) func() error {
+	return func() error {
+		return tracerr.Wrap(control.ToggleActionGroup(group))
+	}
+}
+
+// DeployFairingsAction returns an action that jettisons all of a vessel's
+// fairings.
+func DeployFairingsAction(vessel *Vessel) func() error {
+	return func() error {
+		parts, err := vessel.Parts()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		fairings, err := parts.Fairings()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		for _, fairing := range fairings {
+			if err := fairing.Jettison(); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+		return nil
+	}
+}
+
+// ExtendAntennasAction returns an action that extends all of a vessel's
+// deployable antennas.
+func ExtendAntennasAction(vessel *Vessel) func() error {
+	return func() error {
+		parts, err := vessel.Parts()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		antennas, err := parts.Antennas()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		for _, antenna := range antennas {
+			deployable, err := antenna.Deployable()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			if !deployable {
+				continue
+			}
+			if err := antenna.SetDeployed(true); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+		return nil
+	}
+}
+
+// JettisonTaggedAction returns an action that jettisons parts with a tag,
+// such as a launch escape system: tagged engines are fired, then tagged
+// decouplers and fairings are released.
+func JettisonTaggedAction(vessel *Vessel, tag string) func() error {
+	return func() error {
+		parts, err := vessel.Parts()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		tagged, err := parts.WithTag(tag)
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if len(tagged) == 0 {
+			return tracerr.Errorf("No parts tagged %q", tag)
+		}
+		for _, part := range tagged {
+			engine, err := part.Engine()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			if engine != nil {
+				if err := engine.SetActive(true); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+		}
+		for _, part := range tagged {
+			decoupler, err := part.Decoupler()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			if decoupler != nil {
+				if _, err := decoupler.Decouple(); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+			fairing, err := part.Fairing()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			if fairing != nil {
+				if err := fairing.Jettison(); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+		}
+		return nil
+	}
+}
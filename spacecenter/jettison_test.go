@@ -0,0 +1,39 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightTriggerConditions(t *testing.T) {
+	// An ascent through max Q.
+	altitudes := []float64{0, 5000, 10000, 20000, 40000, 60000}
+	pressures := []float64{0, 15000, 25000, 12000, 2000, 100}
+	tcs := []struct {
+		name     string
+		trigger  FlightTrigger
+		expected int
+	}{
+		{name: "altitude", trigger: FlightTrigger{Altitude: 15000}, expected: 3},
+		{name: "pressure", trigger: FlightTrigger{DynamicPressure: 5000}, expected: 4},
+		{name: "both", trigger: FlightTrigger{Altitude: 50000, DynamicPressure: 5000}, expected: 5},
+		// The pressure starts below the threshold, but hasn't crossed it.
+		{name: "pressure before max Q", trigger: FlightTrigger{DynamicPressure: 20000}, expected: 3},
+		{name: "none", trigger: FlightTrigger{}, expected: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			altitude, pressure := tc.trigger.conditions()
+			fired := -1
+			for i := range altitudes {
+				altitudeMet := altitude(altitudes[i])
+				pressureMet := pressure(pressures[i])
+				if altitudeMet && pressureMet && fired < 0 {
+					fired = i
+				}
+			}
+			require.Equal(t, tc.expected, fired)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// KeplerOrbit is a two-body orbit that can be propagated without asking the
+// server, e.g. to predict a vessel's position many times over or while it's
+// out of contact. Angles are in radians. It ignores anything that changes an
+// orbit, such as sphere of influence changes, burns and drag.
+type KeplerOrbit struct {
+	// Mu is the body's gravitational parameter, in m³/s².
+	Mu float64
+	// SemiMajorAxis is in meters, and negative for hyperbolic orbits.
+	SemiMajorAxis            float64
+	Eccentricity             float64
+	Inclination              float64
+	LongitudeOfAscendingNode float64
+	ArgumentOfPeriapsis      float64
+	// MeanAnomalyAtEpoch is the mean anomaly at the epoch, a universal time
+	// in seconds.
+	MeanAnomalyAtEpoch float64
+	Epoch              float64
+	// ReferenceDirection is the direction from which the longitude of the
+	// ascending node is measured, and ReferenceNormal is the normal to the
+	// plane the inclination is measured from. They give the reference frame
+	// of the positions and velocities. If unset, they are the x- and y-axes,
+	// as in a body's non-rotating reference frame.
+	ReferenceDirection types.Vector3D
+	ReferenceNormal    types.Vector3D
+}
+
+// NewKeplerOrbit gets an orbit's elements from the server, giving positions
+// and velocities in a reference frame, which should be centered on the
+// orbit's body and not rotating with it. The elements are read with
+// GetOrbitalElements.
+func NewKeplerOrbit(orbit *Orbit, referenceFrame *ReferenceFrame) (*KeplerOrbit, error) {
+	el, err := GetOrbitalElements(orbit)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	o := el.Kepler()
+	direction, err := orbit.ReferencePlaneDirection(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	normal, err := orbit.ReferencePlaneNormal(referenceFrame)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	o.ReferenceDirection = types.Vector3DFromTuple(direction)
+	o.ReferenceNormal = types.Vector3DFromTuple(normal)
+	return o, nil
+}
+
+// Period returns the time taken for one orbit, in seconds, or infinity if
+// the orbit isn't closed.
+func (o *KeplerOrbit) Period() float64 {
+	if o.Eccentricity >= 1 {
+		return math.Inf(1)
+	}
+	return 2 * math.Pi / o.meanMotion()
+}
+
+// meanMotion returns how fast the mean anomaly changes, in radians per
+// second.
+func (o *KeplerOrbit) meanMotion() float64 {
+	a := math.Abs(o.SemiMajorAxis)
+	return math.Sqrt(o.Mu / (a * a * a))
+}
+
+// MeanAnomalyAt returns the mean anomaly at a universal time. For closed
+// orbits, it is between 0 and 2π.
+func (o *KeplerOrbit) MeanAnomalyAt(ut float64) float64 {
+	m := o.MeanAnomalyAtEpoch + o.meanMotion()*(ut-o.Epoch)
+	if o.Eccentricity < 1 {
+		m = math.Mod(m, 2*math.Pi)
+		if m < 0 {
+			m += 2 * math.Pi
+		}
+	}
+	return m
+}
+
+// eccentricAnomaly solves Kepler's equation for the eccentric anomaly, or
+// the hyperbolic anomaly for hyperbolic orbits, at a mean anomaly.
+func (o *KeplerOrbit) eccentricAnomaly(m float64) float64 {
+	e := o.Eccentricity
+	if e < 1 {
+		x := m
+		if e > 0.8 {
+			x = math.Pi
+		}
+		for i := 0; i < 50; i++ {
+			dx := (x - e*math.Sin(x) - m) / (1 - e*math.Cos(x))
+			x -= dx
+			if math.Abs(dx) < 1e-12 {
+				break
+			}
+		}
+		return x
+	}
+	x := math.Asinh(m / e)
+	for i := 0; i < 50; i++ {
+		dx := (e*math.Sinh(x) - x - m) / (e*math.Cosh(x) - 1)
+		x -= dx
+		if math.Abs(dx) < 1e-12 {
+			break
+		}
+	}
+	return x
+}
+
+// TrueAnomalyAt returns the true anomaly at a universal time, between -π and
+// π.
+func (o *KeplerOrbit) TrueAnomalyAt(ut float64) float64 {
+	p, _ := o.perifocalStateAt(ut)
+	return math.Atan2(p.Y, p.X)
+}
+
+// RadiusAt returns the distance from the center of the body at a universal
+// time, in meters.
+func (o *KeplerOrbit) RadiusAt(ut float64) float64 {
+	p, _ := o.perifocalStateAt(ut)
+	return p.Length()
+}
+
+// perifocalStateAt returns the position and velocity at a universal time with
+// the x-axis towards the periapsis and the y-axis a quarter of an orbit
+// further on.
+func (o *KeplerOrbit) perifocalStateAt(ut float64) (types.Vector3D, types.Vector3D) {
+	a, e := o.SemiMajorAxis, o.Eccentricity
+	x := o.eccentricAnomaly(o.MeanAnomalyAt(ut))
+	if e < 1 {
+		sx, cx := math.Sincos(x)
+		b := math.Sqrt(1 - e*e)
+		r := a * (1 - e*cx)
+		v := math.Sqrt(o.Mu*a) / r
+		return types.NewVector3D(a*(cx-e), a*b*sx, 0), types.NewVector3D(-v*sx, v*b*cx, 0)
+	}
+	sx, cx := math.Sinh(x), math.Cosh(x)
+	b := math.Sqrt(e*e - 1)
+	r := a * (1 - e*cx)
+	v := math.Sqrt(-o.Mu*a) / r
+	return types.NewVector3D(a*(cx-e), -a*b*sx, 0), types.NewVector3D(-v*sx, v*b*cx, 0)
+}
+
+// basis returns the reference frame's axes: the reference direction, the
+// direction a quarter turn prograde from it in the reference plane, and the
+// reference plane's normal.
+func (o *KeplerOrbit) basis() (types.Vector3D, types.Vector3D, types.Vector3D) {
+	x, z := o.ReferenceDirection, o.ReferenceNormal
+	if x.Length() == 0 || z.Length() == 0 {
+		x, z = types.NewVector3D(1, 0, 0), types.NewVector3D(0, 1, 0)
+	}
+	x, z = x.Scale(1/x.Length()), z.Scale(1/z.Length())
+	// kRPC's reference frames are left-handed, so the cross product turns
+	// the other way.
+	return x, x.Cross(z), z
+}
+
+// StateAt returns the position, in meters, and velocity, in m/s, at a
+// universal time, relative to the center of the body.
+func (o *KeplerOrbit) StateAt(ut float64) (types.Vector3D, types.Vector3D) {
+	position, velocity := o.perifocalStateAt(ut)
+	el := orbitElements{
+		inclination:              o.Inclination,
+		longitudeOfAscendingNode: o.LongitudeOfAscendingNode,
+		argumentOfPeriapsis:      o.ArgumentOfPeriapsis,
+	}
+	p, q := el.perifocal()
+	x, y, z := o.basis()
+	toFrame := func(v types.Vector3D) types.Vector3D {
+		// Rotate out of the perifocal frame, then into the reference frame.
+		w := p.Scale(v.X).Add(q.Scale(v.Y))
+		return x.Scale(w.X).Add(y.Scale(w.Y)).Add(z.Scale(w.Z))
+	}
+	return toFrame(position), toFrame(velocity)
+}
+
+// PositionAt returns the position, in meters, at a universal time, relative
+// to the center of the body.
+func (o *KeplerOrbit) PositionAt(ut float64) types.Vector3D {
+	position, _ := o.StateAt(ut)
+	return position
+}
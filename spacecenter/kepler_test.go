@@ -0,0 +1,98 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// kerbinMu is Kerbin's gravitational parameter.
+const kerbinMu = 3.5316e12
+
+func requireVectorInDelta(t *testing.T, expected, actual types.Vector3D, delta float64) {
+	t.Helper()
+	require.InDelta(t, expected.X, actual.X, delta)
+	require.InDelta(t, expected.Y, actual.Y, delta)
+	require.InDelta(t, expected.Z, actual.Z, delta)
+}
+
+func TestKeplerOrbitCircular(t *testing.T) {
+	r := 700e3
+	o := KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: r, Epoch: 100}
+	period := 2 * math.Pi * math.Sqrt(r*r*r/kerbinMu)
+	require.InDelta(t, period, o.Period(), 1e-6)
+
+	// The orbit starts at the periapsis, along the reference direction, and
+	// heads a quarter turn around from it.
+	position, velocity := o.StateAt(100)
+	requireVectorInDelta(t, types.NewVector3D(r, 0, 0), position, 1e-3)
+	requireVectorInDelta(t, types.NewVector3D(0, 0, math.Sqrt(kerbinMu/r)), velocity, 1e-6)
+	requireVectorInDelta(t, types.NewVector3D(0, 0, r), o.PositionAt(100+period/4), 1e-3)
+	requireVectorInDelta(t, position, o.PositionAt(100+period), 1e-3)
+}
+
+func TestKeplerOrbitConserved(t *testing.T) {
+	tcs := []struct {
+		name  string
+		orbit KeplerOrbit
+	}{
+		{
+			name: "elliptical",
+			orbit: KeplerOrbit{
+				Mu: kerbinMu, SemiMajorAxis: 2e6, Eccentricity: 0.6,
+				Inclination: 0.4, LongitudeOfAscendingNode: 1.2, ArgumentOfPeriapsis: 2.5,
+				MeanAnomalyAtEpoch: 0.3,
+			},
+		},
+		{
+			name: "highly eccentric",
+			orbit: KeplerOrbit{
+				Mu: kerbinMu, SemiMajorAxis: 10e6, Eccentricity: 0.95,
+				Inclination: 2.8, LongitudeOfAscendingNode: 4, ArgumentOfPeriapsis: 0.1,
+			},
+		},
+		{
+			name: "hyperbolic",
+			orbit: KeplerOrbit{
+				Mu: kerbinMu, SemiMajorAxis: -3e6, Eccentricity: 1.4,
+				Inclination: 0.2, LongitudeOfAscendingNode: 3, ArgumentOfPeriapsis: 1,
+				MeanAnomalyAtEpoch: -2,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			o := tc.orbit
+			e := o.Eccentricity
+			energy := -o.Mu / (2 * o.SemiMajorAxis)
+			momentum := math.Sqrt(o.Mu * o.SemiMajorAxis * (1 - e*e))
+			_, _, normal := o.basis()
+			for _, ut := range []float64{0, 500, 1234.5, 4000, 20000} {
+				position, velocity := o.StateAt(ut)
+				r, v := position.Length(), velocity.Length()
+				require.InDelta(t, energy, v*v/2-o.Mu/r, math.Abs(energy)*1e-9)
+				require.InDelta(t, momentum, position.Cross(velocity).Length(), momentum*1e-9)
+				require.InDelta(t, r, o.RadiusAt(ut), r*1e-12)
+				// The orbit keeps its inclination.
+				h := position.Cross(velocity)
+				require.InDelta(t, o.Inclination, h.AngleBetween(normal.Scale(-1)), 1e-9)
+			}
+		})
+	}
+}
+
+func TestKeplerOrbitTrueAnomaly(t *testing.T) {
+	o := KeplerOrbit{Mu: kerbinMu, SemiMajorAxis: 1.5e6, Eccentricity: 0.3, MeanAnomalyAtEpoch: 1}
+	el := orbitElements{mu: o.Mu, semiMajorAxis: o.SemiMajorAxis, eccentricity: o.Eccentricity, period: o.Period()}
+	for _, ut := range []float64{0, 100, 1000, 3000} {
+		nu := o.TrueAnomalyAt(ut)
+		m := el.meanAnomalyAt(nu)
+		if m < 0 {
+			m += 2 * math.Pi
+		}
+		require.InDelta(t, o.MeanAnomalyAt(ut), m, 1e-9)
+		require.InDelta(t, el.radiusAt(nu), o.RadiusAt(ut), 1e-3)
+	}
+}
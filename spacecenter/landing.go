@@ -0,0 +1,339 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// LandingPhase is a phase of a powered landing.
+type LandingPhase int
+
+const (
+	// LandingCoasting is falling with the engines off, waiting to ignite.
+	LandingCoasting LandingPhase = iota
+	// LandingBraking is the suicide burn, killing most of the speed.
+	LandingBraking
+	// LandingTouchdown is the final descent at a steady speed.
+	LandingTouchdown
+	// LandingLanded is safely on the ground.
+	LandingLanded
+)
+
+// String returns the name of the phase.
+func (p LandingPhase) String() string {
+	switch p {
+	case LandingCoasting:
+		return "coasting"
+	case LandingBraking:
+		return "braking"
+	case LandingTouchdown:
+		return "touchdown"
+	case LandingLanded:
+		return "landed"
+	}
+	return "unknown"
+}
+
+// LandingConfig configures a powered landing.
+type LandingConfig struct {
+	// TouchdownSpeed is the vertical speed to touch down at, in m/s.
+	TouchdownSpeed float64
+	// FinalAltitude is the height above the terrain, in meters, at which the
+	// suicide burn should finish and the final descent start.
+	FinalAltitude float64
+	// HeightOffset is the distance, in meters, from the vessel's center of
+	// mass down to the bottom of its landing legs.
+	HeightOffset float64
+	// Margin is the fraction of the vessel's thrust held in reserve when
+	// working out when to ignite, between 0 and 1.
+	Margin float64
+	// Gain is how hard the final descent corrects its vertical speed, in
+	// (m/s²)/(m/s).
+	Gain float64
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *LandingConfig) SetDefaults() {
+	if cfg.TouchdownSpeed == 0 {
+		cfg.TouchdownSpeed = 1.5
+	}
+	if cfg.FinalAltitude == 0 {
+		cfg.FinalAltitude = 10
+	}
+	if cfg.Margin == 0 {
+		cfg.Margin = 0.1
+	}
+	if cfg.Gain == 0 {
+		cfg.Gain = 1
+	}
+}
+
+// ignitionHeight returns how high above the end of the burn to ignite, so
+// that a vessel falling at some speed can slow down to the touchdown speed
+// with some fraction of its acceleration. Returns +Inf if the vessel can't
+// overcome gravity.
+func ignitionHeight(speed, acceleration, gravity float64, cfg LandingConfig) float64 {
+	net := acceleration*(1-cfg.Margin) - gravity
+	if net <= 0 {
+		return math.Inf(1)
+	}
+	return math.Max(0, speed*speed-cfg.TouchdownSpeed*cfg.TouchdownSpeed) / (2 * net)
+}
+
+// brakingThrottle returns the throttle that slows a vessel from its speed to
+// the touchdown speed over a height.
+func brakingThrottle(height, speed, acceleration, gravity float64, cfg LandingConfig) float64 {
+	if height <= 0 {
+		return 1
+	}
+	required := math.Max(0, speed*speed-cfg.TouchdownSpeed*cfg.TouchdownSpeed)/(2*height) + gravity
+	return math.Max(0, math.Min(1, required/acceleration))
+}
+
+// touchdownThrottle returns the throttle that holds the vertical speed at
+// the touchdown speed.
+func touchdownThrottle(verticalSpeed, acceleration, gravity float64, cfg LandingConfig) float64 {
+	required := gravity + cfg.Gain*(-cfg.TouchdownSpeed-verticalSpeed)
+	return math.Max(0, math.Min(1, required/acceleration))
+}
+
+// Lander flies a vessel down to the surface of an airless body with a suicide
+// burn: it coasts until the last moment, burns hard against its surface
+// velocity, then descends the last few meters at a steady speed. Drag is
+// ignored, so the landing is conservative in an atmosphere but wastes fuel.
+type Lander struct {
+	vessel *Vessel
+	cfg    LandingConfig
+	// OnPhase, if set, is called when the landing moves to a new phase.
+	OnPhase func(LandingPhase)
+}
+
+// NewLander creates a new Lander.
+func NewLander(vessel *Vessel, cfg LandingConfig) *Lander {
+	cfg.SetDefaults()
+	return &Lander{vessel: vessel, cfg: cfg}
+}
+
+// landingFields is a set of landingState fields that have been streamed.
+type landingFields uint8
+
+const (
+	landingAltitude landingFields = 1 << iota
+	landingSpeed
+	landingVerticalSpeed
+	landingThrust
+	landingMass
+	landingSituation
+
+	allLandingFields = landingAltitude | landingSpeed | landingVerticalSpeed |
+		landingThrust | landingMass | landingSituation
+)
+
+// landingState is the latest streamed state of a landing vessel.
+type landingState struct {
+	altitude      float64
+	speed         float64
+	verticalSpeed float64
+	thrust        float64
+	mass          float64
+	situation     VesselSituation
+	received      landingFields
+}
+
+// ready reports whether every stream has delivered its first value.
+func (s landingState) ready() bool {
+	return s.received == allLandingFields
+}
+
+// nextLandingPhase returns the phase a landing should be in, given its
+// current phase and state. Phases only move forward, and nothing changes
+// until the state is complete and the vessel has some thrust.
+func nextLandingPhase(phase LandingPhase, s landingState, gravity float64, cfg LandingConfig) LandingPhase {
+	if !s.ready() || s.mass == 0 || s.thrust == 0 {
+		return phase
+	}
+	acceleration := s.thrust / s.mass
+	// How far the burn has left to go before the final descent.
+	height := s.altitude - cfg.HeightOffset - cfg.FinalAltitude
+	if phase == LandingCoasting {
+		if height > ignitionHeight(s.speed, acceleration, gravity, cfg) {
+			return LandingCoasting
+		}
+		phase = LandingBraking
+	}
+	if phase == LandingBraking {
+		if height > 0 && s.speed > cfg.TouchdownSpeed {
+			return LandingBraking
+		}
+		phase = LandingTouchdown
+	}
+	return phase
+}
+
+// Land flies the vessel down until it has landed. The vessel should already
+// be on a trajectory that hits the surface.
+func (l *Lander) Land(ctx context.Context) error {
+	orbit, err := l.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	g, err := body.SurfaceGravity()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	gravity := float64(g)
+	bodyRF, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	flight, err := l.vessel.Flight(bodyRF)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	retrogradeRF, err := l.vessel.SurfaceVelocityReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	upRF, err := l.vessel.SurfaceReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	control, err := l.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ap, err := l.vessel.AutoPilot()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	altitudes, err := flight.SurfaceAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer altitudes.Close()
+	speeds, err := flight.SpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer speeds.Close()
+	verticalSpeeds, err := flight.VerticalSpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer verticalSpeeds.Close()
+	thrusts, err := l.vessel.AvailableThrustStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer thrusts.Close()
+	masses, err := l.vessel.MassStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer masses.Close()
+	situations, err := l.vessel.SituationStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer situations.Close()
+
+	// Point retrograde while coasting and braking.
+	if err := ap.SetReferenceFrame(retrogradeRF); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.SetTargetDirection(types.NewVector3D(0, -1, 0).Tuple()); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.Engage(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer ap.Disengage()
+	defer control.SetThrottle(0)
+
+	phase := LandingCoasting
+	setPhase := func(p LandingPhase) {
+		phase = p
+		if l.OnPhase != nil {
+			l.OnPhase(p)
+		}
+	}
+	var state landingState
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case v := <-altitudes.C:
+			state.altitude = v
+			state.received |= landingAltitude
+		case v := <-speeds.C:
+			state.speed = v
+			state.received |= landingSpeed
+		case v := <-verticalSpeeds.C:
+			state.verticalSpeed = v
+			state.received |= landingVerticalSpeed
+		case v := <-thrusts.C:
+			state.thrust = float64(v)
+			state.received |= landingThrust
+		case v := <-masses.C:
+			state.mass = float64(v)
+			state.received |= landingMass
+		case v := <-situations.C:
+			state.situation = v
+			state.received |= landingSituation
+		}
+		if !state.ready() {
+			continue
+		}
+
+		if state.situation == VesselSituation_Landed || state.situation == VesselSituation_Splashed {
+			if err := control.SetThrottle(0); err != nil {
+				return tracerr.Wrap(err)
+			}
+			setPhase(LandingLanded)
+			return nil
+		}
+		if state.mass == 0 || state.thrust == 0 {
+			// Nothing to fly with yet, e.g. between stages.
+			continue
+		}
+
+		next := nextLandingPhase(phase, state, gravity, l.cfg)
+		if phase == LandingCoasting && next != LandingCoasting {
+			if err := control.SetGear(true); err != nil {
+				return tracerr.Wrap(err)
+			}
+			setPhase(LandingBraking)
+		}
+		if phase == LandingBraking && next == LandingTouchdown {
+			// Stay upright for the final descent.
+			if err := ap.SetReferenceFrame(upRF); err != nil {
+				return tracerr.Wrap(err)
+			}
+			if err := ap.SetTargetDirection(types.NewVector3D(1, 0, 0).Tuple()); err != nil {
+				return tracerr.Wrap(err)
+			}
+			setPhase(LandingTouchdown)
+		}
+
+		acceleration := state.thrust / state.mass
+		var throttle float64
+		switch phase {
+		case LandingBraking:
+			height := state.altitude - l.cfg.HeightOffset - l.cfg.FinalAltitude
+			throttle = brakingThrottle(height, state.speed, acceleration, gravity, l.cfg)
+		case LandingTouchdown:
+			throttle = touchdownThrottle(state.verticalSpeed, acceleration, gravity, l.cfg)
+		}
+		if err := control.SetThrottle(float32(throttle)); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnitionHeight(t *testing.T) {
+	cfg := LandingConfig{Margin: 0.5, TouchdownSpeed: 2}
+	// Net deceleration is 10*0.5 - 1 = 4 m/s².
+	require.InDelta(t, (100*100-2*2)/8.0, ignitionHeight(100, 10, 1, cfg), 1e-9)
+	// Already slow enough.
+	require.Equal(t, 0.0, ignitionHeight(1, 10, 1, cfg))
+	// Not enough thrust to land.
+	require.True(t, math.IsInf(ignitionHeight(100, 2, 1.63, cfg), 1))
+}
+
+func TestBrakingThrottle(t *testing.T) {
+	cfg := LandingConfig{TouchdownSpeed: 0}
+	// Stopping from 20 m/s over 100 m needs 2 m/s², plus 1 for gravity.
+	require.InDelta(t, 0.3, brakingThrottle(100, 20, 10, 1, cfg), 1e-9)
+	// Too late: full throttle.
+	require.Equal(t, 1.0, brakingThrottle(1, 100, 10, 1, cfg))
+	require.Equal(t, 1.0, brakingThrottle(0, 10, 10, 1, cfg))
+}
+
+func TestTouchdownThrottle(t *testing.T) {
+	cfg := LandingConfig{TouchdownSpeed: 2, Gain: 1}
+	// At the right speed, just hover against gravity.
+	require.InDelta(t, 0.2, touchdownThrottle(-2, 5, 1, cfg), 1e-9)
+	// Falling too fast: more thrust.
+	require.InDelta(t, 0.6, touchdownThrottle(-4, 5, 1, cfg), 1e-9)
+	// Rising: cut the engines.
+	require.Equal(t, 0.0, touchdownThrottle(5, 5, 1, cfg))
+}
+
+func TestNextLandingPhaseWaitsForState(t *testing.T) {
+	cfg := LandingConfig{}
+	cfg.SetDefaults()
+	// A lander 10 km up, falling at 200 m/s, with plenty of thrust. Whatever
+	// order the first stream values arrive in, it should keep coasting.
+	updates := []func(*landingState){
+		func(s *landingState) { s.mass, s.received = 5000, s.received|landingMass },
+		func(s *landingState) { s.thrust, s.received = 60000, s.received|landingThrust },
+		func(s *landingState) {
+			s.situation, s.received = VesselSituation_SubOrbital, s.received|landingSituation
+		},
+		func(s *landingState) { s.speed, s.received = 200, s.received|landingSpeed },
+		func(s *landingState) { s.verticalSpeed, s.received = -200, s.received|landingVerticalSpeed },
+		func(s *landingState) { s.altitude, s.received = 10000, s.received|landingAltitude },
+	}
+	var state landingState
+	for i, update := range updates {
+		update(&state)
+		require.Equal(t, i == len(updates)-1, state.ready())
+		require.Equal(t, LandingCoasting, nextLandingPhase(LandingCoasting, state, 1.63, cfg))
+	}
+
+	// No thrust yet: keep coasting rather than igniting.
+	state.thrust = 0
+	require.Equal(t, LandingCoasting, nextLandingPhase(LandingCoasting, state, 1.63, cfg))
+
+	// Low enough: ignite, then finish the burn.
+	state.thrust = 60000
+	state.altitude = 500
+	require.Equal(t, LandingBraking, nextLandingPhase(LandingCoasting, state, 1.63, cfg))
+	state.altitude = 5
+	require.Equal(t, LandingTouchdown, nextLandingPhase(LandingCoasting, state, 1.63, cfg))
+	// Phases never go back.
+	state.altitude = 10000
+	require.Equal(t, LandingTouchdown, nextLandingPhase(LandingTouchdown, state, 1.63, cfg))
+}
@@ -0,0 +1,276 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ManeuverBurn is a planned impulsive burn. The components are in m/s, in the
+// same directions as a maneuver node's.
+type ManeuverBurn struct {
+	UT       float64
+	Prograde float64
+	Normal   float64
+	Radial   float64
+}
+
+// DeltaV returns the magnitude of the burn, in m/s.
+func (b ManeuverBurn) DeltaV() float64 {
+	return math.Sqrt(b.Prograde*b.Prograde + b.Normal*b.Normal + b.Radial*b.Radial)
+}
+
+// ManeuverPlan is a sequence of burns that carries out a maneuver.
+type ManeuverPlan struct {
+	// Name describes the maneuver, e.g. "hohmann" or "bi-elliptic".
+	Name  string
+	Burns []ManeuverBurn
+}
+
+// DeltaV returns the total delta-v of the plan, in m/s.
+func (p *ManeuverPlan) DeltaV() float64 {
+	var total float64
+	for _, b := range p.Burns {
+		total += b.DeltaV()
+	}
+	return total
+}
+
+// AddNodes adds a maneuver node for each burn in the plan.
+func (p *ManeuverPlan) AddNodes(control *Control) ([]*Node, error) {
+	nodes := make([]*Node, len(p.Burns))
+	for i, b := range p.Burns {
+		var err error
+		nodes[i], err = control.AddNode(b.UT, float32(b.Prograde), float32(b.Normal), float32(b.Radial))
+		if err != nil {
+			return nodes[:i], tracerr.Wrap(err)
+		}
+	}
+	return nodes, nil
+}
+
+// orbitElements are the parts of an orbit the planners need.
+type orbitElements struct {
+	mu                  float64
+	semiMajorAxis       float64
+	eccentricity        float64
+	period              float64
+	argumentOfPeriapsis float64
+	// inclination and longitudeOfAscendingNode are in radians.
+	inclination              float64
+	longitudeOfAscendingNode float64
+}
+
+func getOrbitElements(orbit *Orbit) (orbitElements, error) {
+	var el orbitElements
+	body, err := orbit.Body()
+	if err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	mu, err := body.GravitationalParameter()
+	if err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	el.mu = float64(mu)
+	if el.semiMajorAxis, err = orbit.SemiMajorAxis(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	if el.eccentricity, err = orbit.Eccentricity(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	if el.eccentricity >= 1 {
+		return el, tracerr.Errorf("Can't plan maneuvers from an escape trajectory")
+	}
+	if el.period, err = orbit.Period(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	if el.argumentOfPeriapsis, err = orbit.ArgumentOfPeriapsis(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	if el.inclination, err = orbit.Inclination(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	if el.longitudeOfAscendingNode, err = orbit.LongitudeOfAscendingNode(); err != nil {
+		return el, tracerr.Wrap(err)
+	}
+	return el, nil
+}
+
+// radiusAt returns the radius at a true anomaly.
+func (el orbitElements) radiusAt(trueAnomaly float64) float64 {
+	e := el.eccentricity
+	return el.semiMajorAxis * (1 - e*e) / (1 + e*math.Cos(trueAnomaly))
+}
+
+// meanAnomalyAt converts a true anomaly into a mean anomaly.
+func (el orbitElements) meanAnomalyAt(trueAnomaly float64) float64 {
+	e := el.eccentricity
+	eccentric := 2 * math.Atan(math.Sqrt((1-e)/(1+e))*math.Tan(trueAnomaly/2))
+	return eccentric - e*math.Sin(eccentric)
+}
+
+// timeBetween returns how long it takes to go from one true anomaly to the
+// next time the orbit reaches another.
+func (el orbitElements) timeBetween(from, to float64) float64 {
+	dm := math.Mod(el.meanAnomalyAt(to)-el.meanAnomalyAt(from), 2*math.Pi)
+	if dm < 0 {
+		dm += 2 * math.Pi
+	}
+	return dm / (2 * math.Pi) * el.period
+}
+
+// planeChangeBurn returns the burn that rotates the orbit by an angle (in
+// radians) about the line to a point at a true anomaly, without changing its
+// shape. Only the horizontal part of the velocity is rotated.
+func (el orbitElements) planeChangeBurn(trueAnomaly, angle float64) ManeuverBurn {
+	e := el.eccentricity
+	r := el.radiusAt(trueAnomaly)
+	angularMomentum := math.Sqrt(el.mu * el.semiMajorAxis * (1 - e*e))
+	horizontal := angularMomentum / r
+	flightPathAngle := math.Atan2(e*math.Sin(trueAnomaly), 1+e*math.Cos(trueAnomaly))
+	inPlane := horizontal * (math.Cos(angle) - 1)
+	return ManeuverBurn{
+		Prograde: inPlane * math.Cos(flightPathAngle),
+		Normal:   horizontal * math.Sin(angle),
+		Radial:   -inPlane * math.Sin(flightPathAngle),
+	}
+}
+
+// planPlaneChange chooses whichever of the ascending and descending nodes is
+// cheaper for a plane change, which is the one further from the body. change
+// is the change in inclination, in radians.
+func planPlaneChange(el orbitElements, now, ut, ascending, change float64) ManeuverBurn {
+	descending := ascending + math.Pi
+	trueAnomaly, angle := ascending, change
+	if el.radiusAt(descending) > el.radiusAt(ascending) {
+		// At the descending node the orbit is heading the other way, so the
+		// burn goes the other way too.
+		trueAnomaly, angle = descending, -change
+	}
+	burn := el.planeChangeBurn(trueAnomaly, angle)
+	burn.UT = ut + el.timeBetween(now, trueAnomaly)
+	return burn
+}
+
+// PlanInclinationChange plans a burn that changes the inclination of an
+// orbit, relative to the body's equator, to a new value in radians. The burn
+// is made at whichever equatorial node is cheaper.
+func PlanInclinationChange(orbit *Orbit, ut, inclination float64) (*ManeuverPlan, error) {
+	el, err := getOrbitElements(orbit)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	now, err := orbit.TrueAnomalyAtUT(ut)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	// The ascending node is where the argument of latitude is zero.
+	ascending := -el.argumentOfPeriapsis
+	burn := planPlaneChange(el, now, ut, ascending, inclination-el.inclination)
+	return &ManeuverPlan{Name: "inclination change", Burns: []ManeuverBurn{burn}}, nil
+}
+
+// PlanPlaneMatch plans a burn that puts an orbit in the same plane as a
+// target orbit around the same body. The burn is made at whichever relative
+// node is cheaper.
+func PlanPlaneMatch(orbit, target *Orbit, ut float64) (*ManeuverPlan, error) {
+	el, err := getOrbitElements(orbit)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	relative, err := orbit.RelativeInclination(target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	ascending, err := orbit.TrueAnomalyAtAN(target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	now, err := orbit.TrueAnomalyAtUT(ut)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	burn := planPlaneChange(el, now, ut, ascending, -relative)
+	return &ManeuverPlan{Name: "plane match", Burns: []ManeuverBurn{burn}}, nil
+}
+
+// visViva returns the orbital speed at a radius on an orbit with a
+// semi-major axis.
+func visViva(mu, radius, semiMajorAxis float64) float64 {
+	return math.Sqrt(mu * (2/radius - 1/semiMajorAxis))
+}
+
+// halfPeriod returns half the period of an orbit with a semi-major axis.
+func halfPeriod(mu, semiMajorAxis float64) float64 {
+	return math.Pi * math.Sqrt(semiMajorAxis*semiMajorAxis*semiMajorAxis/mu)
+}
+
+// hohmann plans a Hohmann transfer between circular orbits, starting at ut.
+func hohmann(mu, r1, r2, ut float64) []ManeuverBurn {
+	a := (r1 + r2) / 2
+	return []ManeuverBurn{
+		{UT: ut, Prograde: visViva(mu, r1, a) - visViva(mu, r1, r1)},
+		{UT: ut + halfPeriod(mu, a), Prograde: visViva(mu, r2, r2) - visViva(mu, r2, a)},
+	}
+}
+
+// biElliptic plans a bi-elliptic transfer between circular orbits through an
+// intermediate radius, starting at ut.
+func biElliptic(mu, r1, r2, rb, ut float64) []ManeuverBurn {
+	a1 := (r1 + rb) / 2
+	a2 := (r2 + rb) / 2
+	t1 := ut + halfPeriod(mu, a1)
+	return []ManeuverBurn{
+		{UT: ut, Prograde: visViva(mu, r1, a1) - visViva(mu, r1, r1)},
+		{UT: t1, Prograde: visViva(mu, rb, a2) - visViva(mu, rb, a1)},
+		{UT: t1 + halfPeriod(mu, a2), Prograde: visViva(mu, r2, r2) - visViva(mu, r2, a2)},
+	}
+}
+
+// planTransfer picks the cheaper of a Hohmann and a bi-elliptic transfer.
+// Bi-elliptic transfers are only considered if maxRadius is beyond both
+// orbits, and they get cheaper the further out they go, so maxRadius is used
+// as the intermediate radius.
+func planTransfer(mu, r1, r2, maxRadius, ut float64) *ManeuverPlan {
+	plan := &ManeuverPlan{Name: "hohmann", Burns: hohmann(mu, r1, r2, ut)}
+	if maxRadius <= math.Max(r1, r2) {
+		return plan
+	}
+	alt := &ManeuverPlan{Name: "bi-elliptic", Burns: biElliptic(mu, r1, r2, maxRadius, ut)}
+	if alt.DeltaV() < plan.DeltaV() {
+		return alt
+	}
+	return plan
+}
+
+// PlanCircularTransfer plans a transfer from a roughly circular orbit to a
+// circular orbit at another radius, starting at ut. A bi-elliptic transfer
+// reaching out to maxRadius is used when it is cheaper than a Hohmann
+// transfer. Pass zero for maxRadius to limit it to the body's sphere of
+// influence.
+func PlanCircularTransfer(orbit *Orbit, ut, radius, maxRadius float64) (*ManeuverPlan, error) {
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	mu, err := body.GravitationalParameter()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if maxRadius == 0 {
+		soi, err := body.SphereOfInfluence()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		// Stay well inside the sphere of influence. The sun's is infinite, so
+		// stick to Hohmann transfers there.
+		if !math.IsInf(float64(soi), 0) && !math.IsNaN(float64(soi)) {
+			maxRadius = 0.9 * float64(soi)
+		}
+	}
+	r1, err := orbit.RadiusAt(ut)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return planTransfer(float64(mu), r1, radius, maxRadius, ut), nil
+}
@@ -0,0 +1,73 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeBetween(t *testing.T) {
+	el := orbitElements{mu: 1, semiMajorAxis: 1, period: 100}
+	require.InDelta(t, 50, el.timeBetween(0, math.Pi), 1e-9)
+	require.InDelta(t, 50, el.timeBetween(math.Pi, 0), 1e-9)
+	require.InDelta(t, 25, el.timeBetween(-math.Pi/2, 0), 1e-9)
+	require.InDelta(t, 0, el.timeBetween(1, 1), 1e-9)
+
+	// On an eccentric orbit, more time is spent near apoapsis.
+	el.eccentricity = 0.5
+	require.Less(t, el.timeBetween(-math.Pi/2, math.Pi/2), 50.0)
+	require.InDelta(t, 50, el.timeBetween(0, math.Pi), 1e-9)
+}
+
+func TestPlanPlaneChange(t *testing.T) {
+	change := 10 * math.Pi / 180
+	t.Run("circular", func(t *testing.T) {
+		el := orbitElements{mu: 4e14, semiMajorAxis: 7e6, period: 6000}
+		speed := math.Sqrt(el.mu / el.semiMajorAxis)
+		burn := planPlaneChange(el, 0, 1000, math.Pi/2, change)
+		require.InDelta(t, 2500, burn.UT, 1e-6)
+		require.InDelta(t, 2*speed*math.Sin(change/2), burn.DeltaV(), 1e-6)
+		require.InDelta(t, speed*math.Sin(change), burn.Normal, 1e-6)
+		require.InDelta(t, 0, burn.Radial, 1e-6)
+	})
+	t.Run("eccentric", func(t *testing.T) {
+		// The ascending node is at periapsis, so the burn should be at
+		// apoapsis, where the orbit is heading the other way.
+		el := orbitElements{mu: 4e14, semiMajorAxis: 1e7, eccentricity: 0.5, period: 10000}
+		burn := planPlaneChange(el, 0, 0, 0, change)
+		require.InDelta(t, 5000, burn.UT, 1e-6)
+		require.Less(t, burn.Normal, 0.0)
+		apoapsisSpeed := math.Sqrt(el.mu * (2/1.5e7 - 1/1e7))
+		require.InDelta(t, 2*apoapsisSpeed*math.Sin(change/2), burn.DeltaV(), 1e-6)
+	})
+}
+
+func TestPlanTransfer(t *testing.T) {
+	// Low Earth orbit to geostationary orbit.
+	mu := 3.986004418e14
+	plan := planTransfer(mu, 6678e3, 42164e3, 0, 100)
+	require.Equal(t, "hohmann", plan.Name)
+	require.Len(t, plan.Burns, 2)
+	require.InDelta(t, 3893, plan.DeltaV(), 5)
+	require.Equal(t, 100.0, plan.Burns[0].UT)
+	// Half an orbit of the transfer ellipse is about 5.3 hours.
+	require.InDelta(t, 5.3*3600, plan.Burns[1].UT-100, 0.1*3600)
+
+	// A large radius ratio, with plenty of room, favors a bi-elliptic
+	// transfer.
+	plan = planTransfer(1, 1, 20, 1000, 0)
+	require.Equal(t, "bi-elliptic", plan.Name)
+	require.Len(t, plan.Burns, 3)
+	require.Less(t, plan.DeltaV(), (&ManeuverPlan{Burns: hohmann(1, 1, 20, 0)}).DeltaV())
+	// The final burn slows down to circularize.
+	require.Less(t, plan.Burns[2].Prograde, 0.0)
+
+	// A small ratio doesn't.
+	require.Equal(t, "hohmann", planTransfer(1, 1, 5, 1000, 0).Name)
+
+	// Going down is the same as going up in reverse.
+	down := planTransfer(mu, 42164e3, 6678e3, 0, 0)
+	require.InDelta(t, 3893, down.DeltaV(), 5)
+	require.Less(t, down.Burns[0].Prograde, 0.0)
+}
@@ -0,0 +1,273 @@
+package spacecenter
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ControlManagerConfig configures a ControlManager.
+type ControlManagerConfig struct {
+	// SwitchTimeout is how long to wait for the game to make a vessel
+	// active. Defaults to 10 seconds.
+	SwitchTimeout time.Duration
+	// Interval is how often to check whether a vessel has been made
+	// active. Defaults to 100ms.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ControlManagerConfig) SetDefaults() {
+	if cfg.SwitchTimeout == 0 {
+		cfg.SwitchTimeout = 10 * time.Second
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// fifoLock is a lock that is granted in the order it was asked for, and can
+// be given up on while waiting.
+type fifoLock struct {
+	mu    sync.Mutex
+	held  bool
+	queue []chan struct{}
+}
+
+// lock waits for the lock, or for the context to be done.
+func (l *fifoLock) lock(ctx context.Context) error {
+	l.mu.Lock()
+	if !l.held {
+		l.held = true
+		l.mu.Unlock()
+		return nil
+	}
+	turn := make(chan struct{})
+	l.queue = append(l.queue, turn)
+	l.mu.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		for i, other := range l.queue {
+			if other == turn {
+				l.queue = append(l.queue[:i], l.queue[i+1:]...)
+				l.mu.Unlock()
+				return tracerr.Wrap(ctx.Err())
+			}
+		}
+		l.mu.Unlock()
+		// The lock was handed over while giving up, so pass it on.
+		l.unlock()
+		return tracerr.Wrap(ctx.Err())
+	}
+}
+
+// unlock hands the lock to the next in line.
+func (l *fifoLock) unlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queue) == 0 {
+		l.held = false
+		return
+	}
+	close(l.queue[0])
+	l.queue = l.queue[1:]
+}
+
+// ControlManager coordinates flying several vessels at once, such as a
+// booster flying back while the upper stage goes on to orbit. Commands to
+// each vessel are made one at a time, but vessels are flown concurrently,
+// and work that needs a vessel to be the active one, such as staging, waits
+// its turn to switch to it:
+//
+//	m := spacecenter.NewControlManager(sc, spacecenter.ControlManagerConfig{})
+//	booster, err := m.Add(boosterVessel)
+//	orbiter, err := m.Add(orbiterVessel)
+//	go booster.Do(func(v *spacecenter.Vessel) error { ... })
+//	err = orbiter.DoActive(ctx, func(v *spacecenter.Vessel) error {
+//		control, err := v.Control()
+//		...
+//		_, err = control.ActivateNextStage()
+//		return err
+//	})
+//
+// Vessels can only be flown while they are loaded, within a couple of
+// kilometers of the active vessel. Switching to a vessel further away
+// reloads the flight scene, which invalidates every handle.
+type ControlManager struct {
+	sc  *SpaceCenter
+	cfg ControlManagerConfig
+
+	mu      sync.Mutex
+	vessels map[uint64]*ControlledVessel
+
+	// active is held while work that needs a particular active vessel runs.
+	active fifoLock
+}
+
+// NewControlManager creates a new ControlManager.
+func NewControlManager(sc *SpaceCenter, cfg ControlManagerConfig) *ControlManager {
+	cfg.SetDefaults()
+	return &ControlManager{sc: sc, cfg: cfg, vessels: map[uint64]*ControlledVessel{}}
+}
+
+// ControlledVessel is a vessel flown through a ControlManager.
+type ControlledVessel struct {
+	m      *ControlManager
+	vessel *Vessel
+	name   string
+
+	// mu makes commands to the vessel one at a time.
+	mu sync.Mutex
+
+	// streamsMu guards streams apart from mu, so that streams can be added
+	// from inside Do.
+	streamsMu sync.Mutex
+	streams   []interface{ Close() error }
+}
+
+// Add starts flying a vessel through the manager. Adding a vessel twice
+// returns the same ControlledVessel.
+func (m *ControlManager) Add(vessel *Vessel) (*ControlledVessel, error) {
+	m.mu.Lock()
+	if v, ok := m.vessels[vessel.ID_internal()]; ok {
+		m.mu.Unlock()
+		return v, nil
+	}
+	m.mu.Unlock()
+
+	name, err := vessel.Name()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.vessels[vessel.ID_internal()]; ok {
+		return v, nil
+	}
+	v := &ControlledVessel{m: m, vessel: vessel, name: name}
+	m.vessels[vessel.ID_internal()] = v
+	return v, nil
+}
+
+// Vessels returns the vessels being flown, sorted by name.
+func (m *ControlManager) Vessels() []*ControlledVessel {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	vessels := make([]*ControlledVessel, 0, len(m.vessels))
+	for _, v := range m.vessels {
+		vessels = append(vessels, v)
+	}
+	sort.Slice(vessels, func(i, j int) bool {
+		return vessels[i].name < vessels[j].name
+	})
+	return vessels
+}
+
+// Close stops flying every vessel, closing their streams.
+func (m *ControlManager) Close() error {
+	var err error
+	for _, v := range m.Vessels() {
+		if releaseErr := v.Release(); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}
+	return tracerr.Wrap(err)
+}
+
+// Name returns the vessel's name as of when it was added.
+func (v *ControlledVessel) Name() string {
+	return v.name
+}
+
+// Vessel returns the vessel's handle.
+func (v *ControlledVessel) Vessel() *Vessel {
+	return v.vessel
+}
+
+// Do runs commands for the vessel, after any others for it have finished.
+// Commands for other vessels run at the same time.
+func (v *ControlledVessel) Do(f func(*Vessel) error) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return tracerr.Wrap(f(v.vessel))
+}
+
+// DoActive runs commands that need the vessel to be the active vessel. It
+// waits for its turn behind other work that needs an active vessel, makes
+// the vessel active if it isn't already, and keeps it active until the
+// commands have finished.
+func (v *ControlledVessel) DoActive(ctx context.Context, f func(*Vessel) error) error {
+	if err := v.m.active.lock(ctx); err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer v.m.active.unlock()
+	if err := v.m.makeActive(ctx, v.vessel); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(v.Do(f))
+}
+
+// makeActive switches to a vessel and waits for the game to report it as
+// the active vessel.
+func (m *ControlManager) makeActive(ctx context.Context, vessel *Vessel) error {
+	active, err := m.sc.ActiveVessel()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if active != nil && active.ID_internal() == vessel.ID_internal() {
+		return nil
+	}
+	if err := m.sc.SetActiveVessel(vessel); err != nil {
+		return tracerr.Wrap(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.SwitchTimeout)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Errorf("Vessel didn't become active: %v", ctx.Err())
+		case <-time.After(m.cfg.Interval):
+		}
+		active, err := m.sc.ActiveVessel()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if active != nil && active.ID_internal() == vessel.ID_internal() {
+			return nil
+		}
+	}
+}
+
+// AddStream ties a stream to the vessel, so that it is closed when the
+// vessel is released.
+func (v *ControlledVessel) AddStream(stream interface{ Close() error }) {
+	v.streamsMu.Lock()
+	defer v.streamsMu.Unlock()
+	v.streams = append(v.streams, stream)
+}
+
+// Release stops flying the vessel through the manager, closing its streams.
+func (v *ControlledVessel) Release() error {
+	v.m.mu.Lock()
+	delete(v.m.vessels, v.vessel.ID_internal())
+	v.m.mu.Unlock()
+
+	v.streamsMu.Lock()
+	streams := v.streams
+	v.streams = nil
+	v.streamsMu.Unlock()
+	var err error
+	for _, stream := range streams {
+		if closeErr := stream.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return tracerr.Wrap(err)
+}
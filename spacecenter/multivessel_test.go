@@ -0,0 +1,150 @@
+package spacecenter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIFOLock(t *testing.T) {
+	var l fifoLock
+	require.NoError(t, l.lock(context.Background()))
+
+	order := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		i := i
+		go func() {
+			require.NoError(t, l.lock(context.Background()))
+			order <- i
+			l.unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Giving up while waiting leaves the queue as it was.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.lock(ctx), context.DeadlineExceeded)
+
+	l.unlock()
+	require.Equal(t, 0, <-order)
+	require.Equal(t, 1, <-order)
+	require.Equal(t, 2, <-order)
+	require.NoError(t, l.lock(context.Background()))
+}
+
+func TestControlManager(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	names := map[uint64]string{1: "Orbiter", 2: "Booster"}
+	server.Handle("SpaceCenter", "Vessel_get_Name", func(call *krpctest.Call) (interface{}, error) {
+		var vessel Vessel
+		if err := call.Arg(0, &vessel); err != nil {
+			return nil, err
+		}
+		return names[vessel.ID_internal()], nil
+	})
+	var mu sync.Mutex
+	active := uint64(1)
+	server.Handle("SpaceCenter", "get_ActiveVessel", func(*krpctest.Call) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return active, nil
+	})
+	server.Handle("SpaceCenter", "set_ActiveVessel", func(call *krpctest.Call) (interface{}, error) {
+		var vessel Vessel
+		if err := call.Arg(0, &vessel); err != nil {
+			return nil, err
+		}
+		// The game takes a moment to switch.
+		time.AfterFunc(20*time.Millisecond, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			active = vessel.ID_internal()
+		})
+		return nil, nil
+	})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	m := NewControlManager(New(client), ControlManagerConfig{Interval: 5 * time.Millisecond})
+	orbiter, err := m.Add(NewVessel(1, client))
+	require.NoError(t, err)
+	booster, err := m.Add(NewVessel(2, client))
+	require.NoError(t, err)
+	again, err := m.Add(NewVessel(2, client))
+	require.NoError(t, err)
+	require.Same(t, booster, again)
+	require.Equal(t, []*ControlledVessel{booster, orbiter}, m.Vessels())
+
+	activeDuring := func(v *ControlledVessel) uint64 {
+		var id uint64
+		require.NoError(t, v.DoActive(context.Background(), func(*Vessel) error {
+			mu.Lock()
+			defer mu.Unlock()
+			id = active
+			return nil
+		}))
+		return id
+	}
+	require.Equal(t, uint64(1), activeDuring(orbiter))
+	require.Equal(t, uint64(2), activeDuring(booster))
+	require.Equal(t, uint64(1), activeDuring(orbiter))
+
+	var switches int
+	for _, call := range server.Calls() {
+		if call.Procedure == "set_ActiveVessel" {
+			switches++
+		}
+	}
+	require.Equal(t, 2, switches)
+
+	stream := &closeCounter{}
+	booster.AddStream(stream)
+	// Streams are usually opened and added while commanding the vessel.
+	inner := &closeCounter{}
+	require.NoError(t, orbiter.Do(func(*Vessel) error {
+		orbiter.AddStream(inner)
+		return nil
+	}))
+	require.NoError(t, m.Close())
+	require.Equal(t, 1, stream.closed)
+	require.Equal(t, 1, inner.closed)
+	require.Empty(t, m.Vessels())
+}
+
+func TestControlManagerSwitchTimeout(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "Vessel_get_Name", "Booster")
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(1))
+	server.Return("SpaceCenter", "set_ActiveVessel", nil)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	m := NewControlManager(New(client), ControlManagerConfig{SwitchTimeout: 20 * time.Millisecond, Interval: 5 * time.Millisecond})
+	booster, err := m.Add(NewVessel(2, client))
+	require.NoError(t, err)
+	ran := false
+	err = booster.DoActive(context.Background(), func(*Vessel) error {
+		ran = true
+		return nil
+	})
+	require.ErrorContains(t, err, "didn't become active")
+	require.False(t, ran)
+}
+
+// closeCounter counts how often it is closed.
+type closeCounter struct {
+	closed int
+}
+
+func (c *closeCounter) Close() error {
+	c.closed++
+	return nil
+}
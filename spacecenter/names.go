@@ -0,0 +1,233 @@
+package spacecenter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ErrNameNotFound is returned when nothing matches a name.
+type ErrNameNotFound struct {
+	// Kind is the kind of thing being looked for, e.g. "body".
+	Kind string
+	Name string
+}
+
+// Error returns a human-readable error.
+func (err ErrNameNotFound) Error() string {
+	return fmt.Sprintf("No %v matches %q", err.Kind, err.Name)
+}
+
+// ErrAmbiguousName is returned when a name matches more than one thing
+// equally well.
+type ErrAmbiguousName struct {
+	// Kind is the kind of thing being looked for, e.g. "body".
+	Kind string
+	Name string
+	// Matches are the names of everything that matched, sorted.
+	Matches []string
+}
+
+// Error returns a human-readable error.
+func (err ErrAmbiguousName) Error() string {
+	return fmt.Sprintf("%q matches more than one %v: %v", err.Name, err.Kind, strings.Join(err.Matches, ", "))
+}
+
+// Name match qualities, best first.
+const (
+	matchExact = iota
+	matchFold
+	matchNormalized
+	matchPrefix
+	matchContains
+	matchFuzzy
+	matchNone
+)
+
+// normalizeName lower-cases a name and drops everything but its letters
+// and digits, so that "Clamp-O-Tron" and "clampotron" are the same.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// nameMatch returns how well a query, and its normalized form, match a
+// name.
+func nameMatch(query, normalized, name string) int {
+	switch {
+	case name == query:
+		return matchExact
+	case strings.EqualFold(name, query):
+		return matchFold
+	}
+	n := normalizeName(name)
+	switch {
+	case normalized == "":
+		return matchNone
+	case n == normalized:
+		return matchNormalized
+	case strings.HasPrefix(n, normalized):
+		return matchPrefix
+	case strings.Contains(n, normalized):
+		return matchContains
+	}
+	// Fuzzy matches have the query's letters in order, e.g. "kx" for
+	// "Kerbal X".
+	rest := normalized
+	for _, r := range n {
+		if rest == "" {
+			break
+		}
+		if strings.HasPrefix(rest, string(r)) {
+			rest = rest[len(string(r)):]
+		}
+	}
+	if rest == "" {
+		return matchFuzzy
+	}
+	return matchNone
+}
+
+// findName returns the index of the candidate that best matches a name.
+// Each candidate may go by several names, the first of which is used in
+// errors. A candidate that is a better match than all the others is found
+// even if they match too, so "Mun" finds the Mun rather than being
+// ambiguous with Minmus, but if the best match is shared, the name is
+// ambiguous.
+func findName(kind, name string, candidates [][]string) (int, error) {
+	normalized := normalizeName(name)
+	best := matchNone
+	var matches []int
+	for i, names := range candidates {
+		quality := matchNone
+		for _, n := range names {
+			if q := nameMatch(name, normalized, n); q < quality {
+				quality = q
+			}
+		}
+		switch {
+		case quality < best:
+			best, matches = quality, []int{i}
+		case quality == best && quality != matchNone:
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return 0, tracerr.Wrap(ErrNameNotFound{Kind: kind, Name: name})
+	case 1:
+		return matches[0], nil
+	}
+	err := ErrAmbiguousName{Kind: kind, Name: name}
+	for _, i := range matches {
+		err.Matches = append(err.Matches, candidates[i][0])
+	}
+	sort.Strings(err.Matches)
+	return 0, tracerr.Wrap(err)
+}
+
+// FindBody finds a celestial body by name, ignoring case and punctuation
+// and allowing abbreviations, e.g. "mun" or "minm". It returns
+// ErrNameNotFound or ErrAmbiguousName if the name doesn't pick out a single
+// body.
+func (s *SpaceCenter) FindBody(name string) (*CelestialBody, error) {
+	bodies, err := s.Bodies()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names := make([]string, 0, len(bodies))
+	for n := range bodies {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	candidates := make([][]string, len(names))
+	for i, n := range names {
+		candidates[i] = []string{n}
+	}
+	i, err := findName("body", name, candidates)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return bodies[names[i]], nil
+}
+
+// FindVessel finds a vessel by name, matching as FindBody does. Vessel
+// names are fetched in a single batched call.
+func (s *SpaceCenter) FindVessel(name string) (*Vessel, error) {
+	vessels, err := s.Vessels()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names, err := batchGet[string](s.Client, vessels, "Vessel_get_Name")
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	candidates := make([][]string, len(names))
+	for i, n := range names {
+		candidates[i] = []string{n}
+	}
+	i, err := findName("vessel", name, candidates)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return vessels[i], nil
+}
+
+// Find finds a vessel in the fleet by name, matching as FindBody does,
+// without making any calls. Unlike ByName, it returns ErrAmbiguousName if
+// more than one vessel has the name.
+func (f *Fleet) Find(name string) (*Vessel, error) {
+	vessels := f.Vessels()
+	candidates := make([][]string, len(vessels))
+	for i, v := range vessels {
+		n, _ := f.NameOf(v)
+		candidates[i] = []string{n}
+	}
+	i, err := findName("vessel", name, candidates)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return vessels[i], nil
+}
+
+// Find finds a part by its title, name or tag, matching as FindBody does,
+// e.g. "Clamp-O-Tron" for a "Clamp-O-Tron Docking Port". Part attributes
+// are fetched in batched calls. A vessel often has several of the same
+// part, which can be told apart by giving them tags.
+func (s *Parts) Find(name string) (*Part, error) {
+	parts, titles, err := s.Query().Titles()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	names, err := batchGet[string](s.Client, parts, "Part_get_Name")
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	tags, err := batchGet[string](s.Client, parts, "Part_get_Tag")
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	candidates := make([][]string, len(parts))
+	for i := range parts {
+		candidates[i] = []string{titles[i], names[i]}
+		if tags[i] != "" {
+			// Name the part by its tag as well in errors, so that the parts
+			// a name is ambiguous between can be told apart.
+			label := fmt.Sprintf("%v (%v)", titles[i], tags[i])
+			candidates[i] = append([]string{label}, append(candidates[i], tags[i])...)
+		}
+	}
+	i, err := findName("part", name, candidates)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return parts[i], nil
+}
@@ -0,0 +1,106 @@
+package spacecenter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindName(t *testing.T) {
+	bodies := [][]string{{"Kerbin"}, {"Mun"}, {"Minmus"}, {"Kerbol"}, {"Duna"}, {"Ike"}}
+	tests := []struct {
+		name     string
+		expected string
+		// ambiguous are the matches if the name is ambiguous.
+		ambiguous []string
+	}{
+		{name: "Mun", expected: "Mun"},
+		{name: "mun", expected: "Mun"},
+		{name: "MIN", expected: "Minmus"},
+		{name: "kerb", ambiguous: []string{"Kerbin", "Kerbol"}},
+		{name: "kerbi", expected: "Kerbin"},
+		{name: "nmu", expected: "Minmus"},
+		{name: "dn", expected: "Duna"},
+		{name: "Eve"},
+		{name: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := findName("body", tc.name, bodies)
+			switch {
+			case tc.expected != "":
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, bodies[i][0])
+			case tc.ambiguous != nil:
+				var ambiguous ErrAmbiguousName
+				require.ErrorAs(t, err, &ambiguous)
+				require.Equal(t, tc.ambiguous, ambiguous.Matches)
+			default:
+				var notFound ErrNameNotFound
+				require.ErrorAs(t, err, &notFound)
+				require.Equal(t, "body", notFound.Kind)
+			}
+		})
+	}
+
+	// Punctuation is ignored, and any of a candidate's names can match.
+	parts := [][]string{{"Clamp-O-Tron Docking Port", "dockingPort2"}, {"Mk1 Command Pod", "mk1pod"}}
+	i, err := findName("part", "clampotron", parts)
+	require.NoError(t, err)
+	require.Zero(t, i)
+	i, err = findName("part", "MK1POD", parts)
+	require.NoError(t, err)
+	require.Equal(t, 1, i)
+	_, err = findName("part", "o", parts)
+	require.EqualError(t, err, `"o" matches more than one part: Clamp-O-Tron Docking Port, Mk1 Command Pod`)
+}
+
+func TestFindBody(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_Bodies", map[string]uint64{"Kerbin": 1, "Mun": 2, "Minmus": 3})
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	body, err := New(client).FindBody("minm")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), body.ID_internal())
+	_, err = New(client).FindBody("Jool")
+	require.ErrorAs(t, err, &ErrNameNotFound{})
+}
+
+func TestFindPart(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "Parts_get_All", []uint64{1, 2, 3})
+	attribute := func(values ...string) krpctest.Handler {
+		return func(call *krpctest.Call) (interface{}, error) {
+			var part Part
+			if err := call.Arg(0, &part); err != nil {
+				return nil, err
+			}
+			return values[part.ID_internal()-1], nil
+		}
+	}
+	server.Handle("SpaceCenter", "Part_get_Title", attribute("Mk1 Command Pod", "Clamp-O-Tron Docking Port", "Clamp-O-Tron Docking Port"))
+	server.Handle("SpaceCenter", "Part_get_Name", attribute("mk1pod", "dockingPort2", "dockingPort2"))
+	server.Handle("SpaceCenter", "Part_get_Tag", attribute("", "front", "back"))
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+
+	parts := NewParts(1, client)
+	part, err := parts.Find("pod")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), part.ID_internal())
+	part, err = parts.Find("back")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), part.ID_internal())
+	_, err = parts.Find("Clamp-O-Tron")
+	var ambiguous ErrAmbiguousName
+	require.ErrorAs(t, err, &ambiguous)
+	require.Equal(t, []string{"Clamp-O-Tron Docking Port (back)", "Clamp-O-Tron Docking Port (front)"}, ambiguous.Matches)
+}
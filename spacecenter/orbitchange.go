@@ -0,0 +1,145 @@
+package spacecenter
+
+import (
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// TargetOrbit is an orbit to reach, given by its elements. Angles are in
+// radians. The argument of periapsis isn't targeted.
+type TargetOrbit struct {
+	SemiMajorAxis            float64
+	Eccentricity             float64
+	Inclination              float64
+	LongitudeOfAscendingNode float64
+}
+
+// ApsidesOrbit returns the target orbit with a periapsis and apoapsis radius,
+// in meters.
+func ApsidesOrbit(periapsis, apoapsis, inclination, longitudeOfAscendingNode float64) TargetOrbit {
+	return TargetOrbit{
+		SemiMajorAxis:            (periapsis + apoapsis) / 2,
+		Eccentricity:             (apoapsis - periapsis) / (apoapsis + periapsis),
+		Inclination:              inclination,
+		LongitudeOfAscendingNode: longitudeOfAscendingNode,
+	}
+}
+
+// Periapsis returns the periapsis radius, in meters.
+func (o TargetOrbit) Periapsis() float64 {
+	return o.SemiMajorAxis * (1 - o.Eccentricity)
+}
+
+// Apoapsis returns the apoapsis radius, in meters.
+func (o TargetOrbit) Apoapsis() float64 {
+	return o.SemiMajorAxis * (1 + o.Eccentricity)
+}
+
+// orbitNormal returns the unit angular momentum of an orbit.
+func orbitNormal(inclination, longitudeOfAscendingNode float64) types.Vector3D {
+	si, ci := math.Sincos(inclination)
+	sl, cl := math.Sincos(longitudeOfAscendingNode)
+	return types.NewVector3D(sl*si, -cl*si, ci)
+}
+
+// perifocal returns the unit vectors towards an orbit's periapsis and a
+// quarter of an orbit past it.
+func (el orbitElements) perifocal() (types.Vector3D, types.Vector3D) {
+	si, ci := math.Sincos(el.inclination)
+	sl, cl := math.Sincos(el.longitudeOfAscendingNode)
+	sw, cw := math.Sincos(el.argumentOfPeriapsis)
+	p := types.NewVector3D(cl*cw-sl*sw*ci, sl*cw+cl*sw*ci, sw*si)
+	q := types.NewVector3D(-cl*sw-sl*cw*ci, -sl*sw+cl*cw*ci, cw*si)
+	return p, q
+}
+
+// planShapeChange plans two burns at apsides that turn a coplanar orbit into
+// one with the target's periapsis and apoapsis, picking the cheapest of the
+// ways to do it. now is the true anomaly at ut.
+func planShapeChange(el orbitElements, now, ut float64, target TargetOrbit) []ManeuverBurn {
+	var best []ManeuverBurn
+	bestDeltaV := math.Inf(1)
+	targets := [][2]float64{
+		{target.Periapsis(), target.Apoapsis()},
+		{target.Apoapsis(), target.Periapsis()},
+	}
+	for _, start := range []float64{0, math.Pi} {
+		r := el.radiusAt(start)
+		startUT := ut + el.timeBetween(now, start)
+		for _, t := range targets {
+			// Raise or lower the far side of the orbit to the first apsis,
+			// then burn there to set the other.
+			far, other := t[0], t[1]
+			transfer := (r + far) / 2
+			burns := []ManeuverBurn{
+				{UT: startUT, Prograde: visViva(el.mu, r, transfer) - visViva(el.mu, r, el.semiMajorAxis)},
+				{UT: startUT + halfPeriod(el.mu, transfer), Prograde: visViva(el.mu, far, (far+other)/2) - visViva(el.mu, far, transfer)},
+			}
+			deltaV := math.Abs(burns[0].Prograde) + math.Abs(burns[1].Prograde)
+			if deltaV < bestDeltaV {
+				best, bestDeltaV = burns, deltaV
+			}
+		}
+	}
+	return best
+}
+
+// planOrbitChange plans a plane change into the target's plane, if needed,
+// followed by a change of shape. now is the true anomaly at ut.
+func planOrbitChange(el orbitElements, now, ut float64, target TargetOrbit) *ManeuverPlan {
+	plan := &ManeuverPlan{Name: "orbit change"}
+	current := orbitNormal(el.inclination, el.longitudeOfAscendingNode)
+	wanted := orbitNormal(target.Inclination, target.LongitudeOfAscendingNode)
+	if angle := current.AngleBetween(wanted); angle > 1e-9 && !math.IsNaN(angle) {
+		// Where the orbit rises through the target plane.
+		node := wanted.Cross(current)
+		p, q := el.perifocal()
+		ascending := math.Atan2(node.Dot(q), node.Dot(p))
+		burn := planPlaneChange(el, now, ut, ascending, -angle)
+		plan.Burns = append(plan.Burns, burn)
+		// The plane change doesn't change the shape, so carry on from
+		// whichever node it was made at.
+		now, ut = ascending, burn.UT
+		if el.radiusAt(ascending+math.Pi) > el.radiusAt(ascending) {
+			now += math.Pi
+		}
+	}
+	for _, burn := range planShapeChange(el, now, ut, target) {
+		if math.Abs(burn.Prograde) > 1e-6 {
+			plan.Burns = append(plan.Burns, burn)
+		}
+	}
+	return plan
+}
+
+// PlanOrbitChange plans the burns that take an orbit to a target orbit
+// around the same body, starting at ut: a plane change at the cheaper node,
+// if one is needed, then two burns at the apsides to set the periapsis and
+// apoapsis.
+func PlanOrbitChange(orbit *Orbit, ut float64, target TargetOrbit) (*ManeuverPlan, error) {
+	if target.Eccentricity < 0 || target.Eccentricity >= 1 || target.SemiMajorAxis <= 0 {
+		return nil, tracerr.Errorf("Target orbit must be closed")
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	radius, err := body.EquatorialRadius()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if target.Periapsis() <= float64(radius) {
+		return nil, tracerr.Errorf("Target orbit's periapsis is below the surface")
+	}
+	el, err := getOrbitElements(orbit)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	now, err := orbit.TrueAnomalyAtUT(ut)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return planOrbitChange(el, now, ut, target), nil
+}
@@ -0,0 +1,78 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanOrbitChange(t *testing.T) {
+	mu := 3.5316e12
+	r1 := 700e3
+	el := orbitElements{
+		mu:                       mu,
+		semiMajorAxis:            r1,
+		period:                   2 * halfPeriod(mu, r1),
+		inclination:              0.1,
+		longitudeOfAscendingNode: 1,
+		argumentOfPeriapsis:      0.5,
+	}
+
+	t.Run("nothing to do", func(t *testing.T) {
+		target := ApsidesOrbit(r1, r1, 0.1, 1)
+		require.Empty(t, planOrbitChange(el, 0, 0, target).Burns)
+	})
+
+	t.Run("coplanar circular", func(t *testing.T) {
+		// The same as a Hohmann transfer.
+		target := ApsidesOrbit(900e3, 900e3, 0.1, 1)
+		plan := planOrbitChange(el, 0, 100, target)
+		require.Len(t, plan.Burns, 2)
+		expected := &ManeuverPlan{Burns: hohmann(mu, r1, 900e3, 100)}
+		require.InDelta(t, expected.DeltaV(), plan.DeltaV(), 1e-6)
+		require.InDelta(t, expected.Burns[1].UT, plan.Burns[1].UT, 1e-6)
+	})
+
+	t.Run("coplanar eccentric", func(t *testing.T) {
+		target := ApsidesOrbit(700e3, 1000e3, 0.1, 1)
+		plan := planOrbitChange(el, 0, 0, target)
+		// Only the apoapsis needs raising.
+		require.Len(t, plan.Burns, 1)
+		require.InDelta(t, visViva(mu, r1, target.SemiMajorAxis)-visViva(mu, r1, r1), plan.Burns[0].Prograde, 1e-6)
+	})
+
+	t.Run("inclination change", func(t *testing.T) {
+		// The same as the equatorial inclination change, as the node is
+		// unchanged. The orbit is eccentric, so there's a cheaper node.
+		el := el
+		el.eccentricity = 0.2
+		target := TargetOrbit{SemiMajorAxis: r1, Eccentricity: 0.2, Inclination: 0.3, LongitudeOfAscendingNode: 1}
+		plan := planOrbitChange(el, 0, 0, target)
+		require.Len(t, plan.Burns, 1)
+		expected := planPlaneChange(el, 0, 0, -el.argumentOfPeriapsis, 0.2)
+		require.InDelta(t, expected.UT, plan.Burns[0].UT, 1e-6)
+		require.InDelta(t, expected.Normal, plan.Burns[0].Normal, 1e-6)
+		require.InDelta(t, expected.Prograde, plan.Burns[0].Prograde, 1e-6)
+	})
+
+	t.Run("plane and shape", func(t *testing.T) {
+		target := ApsidesOrbit(800e3, 1200e3, 0.5, 2)
+		plan := planOrbitChange(el, 0, 0, target)
+		require.Len(t, plan.Burns, 3)
+		for i := 1; i < len(plan.Burns); i++ {
+			require.Greater(t, plan.Burns[i].UT, plan.Burns[i-1].UT)
+		}
+		// The angle between the planes sets the size of the plane change.
+		angle := orbitNormal(0.1, 1).AngleBetween(orbitNormal(0.5, 2))
+		require.InDelta(t, 2*math.Sqrt(mu/r1)*math.Sin(angle/2), plan.Burns[0].DeltaV(), 1e-6)
+	})
+}
+
+func TestApsidesOrbit(t *testing.T) {
+	o := ApsidesOrbit(700e3, 1300e3, 0, 0)
+	require.InDelta(t, 1000e3, o.SemiMajorAxis, 1e-6)
+	require.InDelta(t, 0.3, o.Eccentricity, 1e-9)
+	require.InDelta(t, 700e3, o.Periapsis(), 1e-6)
+	require.InDelta(t, 1300e3, o.Apoapsis(), 1e-6)
+}
@@ -0,0 +1,267 @@
+package spacecenter
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// partFilter narrows down a list of parts. If first is true, candidates is
+// every part in the vessel and hasn't been fetched.
+type partFilter func(parts *Parts, candidates []*Part, first bool) ([]*Part, error)
+
+// PartQuery finds parts in a vessel. Filters are combined with AND and are
+// only evaluated when the results are requested. Filters that the server can
+// evaluate itself take a single call each, and part attributes are fetched
+// for all parts in a single batched call, rather than one call per part.
+//
+//	chutes, err := parts.Query().
+//		WithTag("chute").
+//		WithModule("RealChute").
+//		InStage(2).
+//		All()
+type PartQuery struct {
+	parts   *Parts
+	filters []partFilter
+}
+
+// Query starts a new query over the parts of a vessel.
+func (s *Parts) Query() *PartQuery {
+	return &PartQuery{parts: s}
+}
+
+func (q *PartQuery) where(f partFilter) *PartQuery {
+	// Copy the filters so that queries can be branched.
+	filters := make([]partFilter, len(q.filters), len(q.filters)+1)
+	copy(filters, q.filters)
+	return &PartQuery{parts: q.parts, filters: append(filters, f)}
+}
+
+// serverFilter creates a filter that is evaluated by the server.
+func serverFilter(get func(parts *Parts) ([]*Part, error)) partFilter {
+	return func(parts *Parts, candidates []*Part, first bool) ([]*Part, error) {
+		matches, err := get(parts)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if first {
+			return matches, nil
+		}
+		return intersectParts(candidates, matches), nil
+	}
+}
+
+// WithName matches parts with a name (as given in the part's .cfg file).
+func (q *PartQuery) WithName(name string) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.WithName(name)
+	}))
+}
+
+// WithTitle matches parts with a title (as shown in the editor).
+func (q *PartQuery) WithTitle(title string) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.WithTitle(title)
+	}))
+}
+
+// WithTag matches parts with a tag.
+func (q *PartQuery) WithTag(tag string) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.WithTag(tag)
+	}))
+}
+
+// WithModule matches parts that have a module.
+func (q *PartQuery) WithModule(moduleName string) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.WithModule(moduleName)
+	}))
+}
+
+// InStage matches parts that are activated in a stage.
+func (q *PartQuery) InStage(stage int32) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.InStage(stage)
+	}))
+}
+
+// InDecoupleStage matches parts that are decoupled in a stage.
+func (q *PartQuery) InDecoupleStage(stage int32) *PartQuery {
+	return q.where(serverFilter(func(parts *Parts) ([]*Part, error) {
+		return parts.InDecoupleStage(stage)
+	}))
+}
+
+// whereAttribute creates a filter on a part attribute, fetched for every
+// candidate in one batch.
+func whereAttribute[T any](q *PartQuery, procedure string, match func(T) bool) *PartQuery {
+	return q.where(func(parts *Parts, candidates []*Part, first bool) ([]*Part, error) {
+		if first {
+			var err error
+			if candidates, err = parts.All(); err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+		}
+		values, err := batchGet[T](parts.Client, candidates, procedure)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		var matches []*Part
+		for i, v := range values {
+			if match(v) {
+				matches = append(matches, candidates[i])
+			}
+		}
+		return matches, nil
+	})
+}
+
+// WhereTag matches parts whose tag satisfies a predicate.
+func (q *PartQuery) WhereTag(match func(tag string) bool) *PartQuery {
+	return whereAttribute(q, "Part_get_Tag", match)
+}
+
+// WhereTitle matches parts whose title satisfies a predicate.
+func (q *PartQuery) WhereTitle(match func(title string) bool) *PartQuery {
+	return whereAttribute(q, "Part_get_Title", match)
+}
+
+// WhereMass matches parts whose mass (in kg) satisfies a predicate.
+func (q *PartQuery) WhereMass(match func(mass float64) bool) *PartQuery {
+	return whereAttribute(q, "Part_get_Mass", match)
+}
+
+// All returns every matching part.
+func (q *PartQuery) All() ([]*Part, error) {
+	if len(q.filters) == 0 {
+		parts, err := q.parts.All()
+		return parts, tracerr.Wrap(err)
+	}
+	var candidates []*Part
+	for i, f := range q.filters {
+		var err error
+		candidates, err = f(q.parts, candidates, i == 0)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+	}
+	return candidates, nil
+}
+
+// First returns the first matching part, or nil if there are none.
+func (q *PartQuery) First() (*Part, error) {
+	parts, err := q.All()
+	if err != nil || len(parts) == 0 {
+		return nil, tracerr.Wrap(err)
+	}
+	return parts[0], nil
+}
+
+// Count returns the number of matching parts.
+func (q *PartQuery) Count() (int, error) {
+	parts, err := q.All()
+	return len(parts), tracerr.Wrap(err)
+}
+
+// queryAttribute fetches an attribute of every matching part in one batch.
+func queryAttribute[T any](q *PartQuery, procedure string) ([]*Part, []T, error) {
+	parts, err := q.All()
+	if err != nil {
+		return nil, nil, tracerr.Wrap(err)
+	}
+	values, err := batchGet[T](q.parts.Client, parts, procedure)
+	return parts, values, tracerr.Wrap(err)
+}
+
+// Tags returns every matching part along with its tag.
+func (q *PartQuery) Tags() ([]*Part, []string, error) {
+	return queryAttribute[string](q, "Part_get_Tag")
+}
+
+// Titles returns every matching part along with its title.
+func (q *PartQuery) Titles() ([]*Part, []string, error) {
+	return queryAttribute[string](q, "Part_get_Title")
+}
+
+// Names returns every matching part along with its name.
+func (q *PartQuery) Names() ([]*Part, []string, error) {
+	return queryAttribute[string](q, "Part_get_Name")
+}
+
+// Stages returns every matching part along with the stage it is activated
+// in.
+func (q *PartQuery) Stages() ([]*Part, []int32, error) {
+	return queryAttribute[int32](q, "Part_get_Stage")
+}
+
+// Masses returns every matching part along with its mass, in kg.
+func (q *PartQuery) Masses() ([]*Part, []float64, error) {
+	return queryAttribute[float64](q, "Part_get_Mass")
+}
+
+// getterCall creates a call to a property getter of an object, such as a
+// part.
+func getterCall(obj interface{}, procedure string) (*types.ProcedureCall, error) {
+	argBytes, err := encode.Marshal(obj)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &types.ProcedureCall{
+		Procedure: procedure,
+		Service:   "SpaceCenter",
+		Arguments: []*types.Argument{{Position: 0, Value: argBytes}},
+	}, nil
+}
+
+// batchGet calls a property getter for many objects, such as parts, in one
+// round trip.
+func batchGet[T, O any](client *krpcgo.KRPCClient, objs []O, procedure string) ([]T, error) {
+	values := make([]T, len(objs))
+	if len(objs) == 0 {
+		return values, nil
+	}
+	calls := make([]*types.ProcedureCall, len(objs))
+	for i, obj := range objs {
+		var err error
+		if calls[i], err = getterCall(obj, procedure); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	results, err := client.CallMultiple(calls)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	if len(results) != len(objs) {
+		return nil, tracerr.Errorf("Expected %v results, got %v", len(objs), len(results))
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, tracerr.Wrap(result.Error)
+		}
+		if err := encode.Unmarshal(result.Value, &values[i]); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return values, nil
+}
+
+// intersectParts returns the parts in a that are also in b, in the order they
+// appear in a.
+func intersectParts(a, b []*Part) []*Part {
+	ids := make(map[uint64]struct{}, len(b))
+	for _, p := range b {
+		ids[p.ID_internal()] = struct{}{}
+	}
+	var parts []*Part
+	for _, p := range a {
+		if _, ok := ids[p.ID_internal()]; ok {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
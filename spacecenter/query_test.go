@@ -0,0 +1,58 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/lib/encode"
+	"github.com/stretchr/testify/require"
+)
+
+func partsWithIDs(ids ...uint64) []*Part {
+	var parts []*Part
+	for _, id := range ids {
+		parts = append(parts, NewPart(id, nil))
+	}
+	return parts
+}
+
+func TestPartQuery(t *testing.T) {
+	matching := func(ids ...uint64) partFilter {
+		return serverFilter(func(*Parts) ([]*Part, error) {
+			return partsWithIDs(ids...), nil
+		})
+	}
+
+	q := NewParts(1, nil).Query()
+	q1 := q.where(matching(1, 2, 3, 4))
+	q2 := q1.where(matching(4, 3, 5))
+	q3 := q1.where(matching(2))
+
+	parts, err := q2.All()
+	require.NoError(t, err)
+	require.Equal(t, partsWithIDs(3, 4), parts)
+
+	// Branched queries don't affect each other.
+	parts, err = q3.All()
+	require.NoError(t, err)
+	require.Equal(t, partsWithIDs(2), parts)
+
+	count, err := q1.Count()
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+
+	first, err := q1.where(matching()).First()
+	require.NoError(t, err)
+	require.Nil(t, first)
+}
+
+func TestGetterCall(t *testing.T) {
+	call, err := getterCall(NewPart(42, nil), "Part_get_Tag")
+	require.NoError(t, err)
+	require.Equal(t, "SpaceCenter", call.Service)
+	require.Equal(t, "Part_get_Tag", call.Procedure)
+	require.Len(t, call.Arguments, 1)
+
+	var part Part
+	require.NoError(t, encode.Unmarshal(call.Arguments[0].Value, &part))
+	require.Equal(t, uint64(42), part.ID_internal())
+}
@@ -0,0 +1,325 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// realChuteModule is the part module used by the RealChute mod.
+const realChuteModule = "RealChuteModule"
+
+// ReentryConfig configures a ReentryManager.
+type ReentryConfig struct {
+	// DrogueAltitude is the height above the terrain, in meters, below which
+	// drogue chutes may be deployed.
+	DrogueAltitude float64
+	// DrogueMaxSpeed is the fastest, in m/s, that drogue chutes may be
+	// deployed at.
+	DrogueMaxSpeed float64
+	// MainAltitude is the height above the terrain, in meters, below which
+	// main chutes may be deployed.
+	MainAltitude float64
+	// MainMaxSpeed is the fastest, in m/s, that main chutes may be deployed
+	// at.
+	MainMaxSpeed float64
+	// NoArm stops the chutes from being armed at the start, so they are only
+	// deployed by the manager.
+	NoArm bool
+	// Interval is how often to report the status.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ReentryConfig) SetDefaults() {
+	if cfg.DrogueAltitude == 0 {
+		cfg.DrogueAltitude = 5000
+	}
+	if cfg.DrogueMaxSpeed == 0 {
+		cfg.DrogueMaxSpeed = 450
+	}
+	if cfg.MainAltitude == 0 {
+		cfg.MainAltitude = 1000
+	}
+	if cfg.MainMaxSpeed == 0 {
+		cfg.MainMaxSpeed = 250
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+}
+
+// LandingPrediction is where a vessel is predicted to land.
+type LandingPrediction struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// LandingPredictor predicts where a vessel will land, e.g. using the
+// Trajectories mod. It returns false if there is no prediction.
+type LandingPredictor interface {
+	PredictLanding() (LandingPrediction, bool, error)
+}
+
+// ReentryStatus reports the progress of a reentry.
+type ReentryStatus struct {
+	// Altitude is the height above the terrain, in meters.
+	Altitude float64
+	// Speed is the surface speed, in m/s.
+	Speed float64
+	// DynamicPressure is the current dynamic pressure, in Pa.
+	DynamicPressure float64
+	// MaxDynamicPressure is the highest dynamic pressure seen so far, in Pa.
+	MaxDynamicPressure float64
+	DroguesDeployed    bool
+	MainsDeployed      bool
+	// Landing is the predicted landing position, if there is a predictor
+	// and it has a prediction.
+	Landing *LandingPrediction
+}
+
+// chute is a stock or RealChute parachute.
+type chute struct {
+	drogue bool
+	arm    func() error
+	deploy func() error
+}
+
+// ReentryManager watches a vessel as it falls through the atmosphere and
+// deploys its parachutes once it is safe to: drogues first, then mains.
+// Drogues are told apart from mains by having "drogue" in their part name or
+// tag. Both stock parachutes and RealChute parachutes are supported.
+type ReentryManager struct {
+	vessel *Vessel
+	cfg    ReentryConfig
+	// Predictor, if set, is used to predict the landing position.
+	Predictor LandingPredictor
+	// OnStatus, if set, is called with the status at every interval.
+	OnStatus func(ReentryStatus)
+}
+
+// NewReentryManager creates a new ReentryManager.
+func NewReentryManager(vessel *Vessel, cfg ReentryConfig) *ReentryManager {
+	cfg.SetDefaults()
+	return &ReentryManager{vessel: vessel, cfg: cfg}
+}
+
+// isDrogue checks whether a part is a drogue chute.
+func isDrogue(part *Part) (bool, error) {
+	name, err := part.Name()
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	tag, err := part.Tag()
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	return strings.Contains(strings.ToLower(name+" "+tag), "drogue"), nil
+}
+
+// chutes finds every parachute on the vessel.
+func (m *ReentryManager) chutes() ([]chute, error) {
+	parts, err := m.vessel.Parts()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var chutes []chute
+
+	parachutes, err := parts.Parachutes()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, p := range parachutes {
+		p := p
+		part, err := p.Part()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		drogue, err := isDrogue(part)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		altitude := m.cfg.MainAltitude
+		if drogue {
+			altitude = m.cfg.DrogueAltitude
+		}
+		chutes = append(chutes, chute{
+			drogue: drogue,
+			arm: func() error {
+				if err := p.SetDeployAltitude(float32(altitude)); err != nil {
+					return tracerr.Wrap(err)
+				}
+				return tracerr.Wrap(p.Arm())
+			},
+			deploy: p.Deploy,
+		})
+	}
+
+	modules, err := parts.ModulesWithName(realChuteModule)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, module := range modules {
+		part, err := module.Part()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		drogue, err := isDrogue(part)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		chutes = append(chutes, chute{
+			drogue: drogue,
+			arm:    moduleEvent(module, "Arm parachute"),
+			deploy: moduleEvent(module, "Deploy Chute"),
+		})
+	}
+	return chutes, nil
+}
+
+// moduleEvent returns a function that triggers a part module event, if the
+// module currently has it.
+func moduleEvent(module *Module, event string) func() error {
+	return func() error {
+		ok, err := module.HasEvent(event)
+		if err != nil || !ok {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(module.TriggerEvent(event))
+	}
+}
+
+// chuteDecision decides which parachutes to deploy.
+func chuteDecision(altitude, speed float64, droguesDeployed, mainsDeployed bool, cfg ReentryConfig) (drogues, mains bool) {
+	mains = !mainsDeployed && altitude <= cfg.MainAltitude && speed <= cfg.MainMaxSpeed
+	// Drogues are pointless once the mains are out.
+	drogues = !droguesDeployed && !mainsDeployed && !mains &&
+		altitude <= cfg.DrogueAltitude && speed <= cfg.DrogueMaxSpeed
+	return drogues, mains
+}
+
+// Run arms the parachutes and deploys them as the vessel falls, until it has
+// landed or splashed down.
+func (m *ReentryManager) Run(ctx context.Context) error {
+	chutes, err := m.chutes()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if !m.cfg.NoArm {
+		for _, c := range chutes {
+			if err := c.arm(); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+
+	orbit, err := m.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	rf, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	flight, err := m.vessel.Flight(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	altitudes, err := flight.SurfaceAltitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer altitudes.Close()
+	speeds, err := flight.SpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer speeds.Close()
+	pressures, err := flight.DynamicPressureStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer pressures.Close()
+	situations, err := m.vessel.SituationStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer situations.Close()
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	status := ReentryStatus{Altitude: math.Inf(1), Speed: math.Inf(1)}
+	var haveAltitude, haveSpeed bool
+	deploy := func(drogue bool) error {
+		for _, c := range chutes {
+			if c.drogue == drogue {
+				if err := c.deploy(); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+		}
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case v := <-altitudes.C:
+			status.Altitude = v
+			haveAltitude = true
+		case v := <-speeds.C:
+			status.Speed = v
+			haveSpeed = true
+		case v := <-pressures.C:
+			status.DynamicPressure = float64(v)
+			status.MaxDynamicPressure = math.Max(status.MaxDynamicPressure, status.DynamicPressure)
+		case situation := <-situations.C:
+			if situation == VesselSituation_Landed || situation == VesselSituation_Splashed {
+				m.report(status)
+				return nil
+			}
+		case <-ticker.C:
+			m.report(status)
+			continue
+		}
+		if !haveAltitude || !haveSpeed {
+			// Don't deploy on a half-known state.
+			continue
+		}
+
+		drogues, mains := chuteDecision(status.Altitude, status.Speed, status.DroguesDeployed, status.MainsDeployed, m.cfg)
+		if drogues {
+			if err := deploy(true); err != nil {
+				return tracerr.Wrap(err)
+			}
+			status.DroguesDeployed = true
+		}
+		if mains {
+			if err := deploy(false); err != nil {
+				return tracerr.Wrap(err)
+			}
+			status.MainsDeployed = true
+		}
+	}
+}
+
+// report calls OnStatus, adding the landing prediction if there is one.
+func (m *ReentryManager) report(status ReentryStatus) {
+	if m.OnStatus == nil {
+		return
+	}
+	if m.Predictor != nil {
+		if prediction, ok, err := m.Predictor.PredictLanding(); err == nil && ok {
+			status.Landing = &prediction
+		}
+	}
+	m.OnStatus(status)
+}
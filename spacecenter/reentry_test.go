@@ -0,0 +1,34 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChuteDecision(t *testing.T) {
+	cfg := ReentryConfig{}
+	cfg.SetDefaults()
+	tests := []struct {
+		name                           string
+		altitude, speed                float64
+		droguesDeployed, mainsDeployed bool
+		drogues, mains                 bool
+	}{
+		{name: "too high", altitude: 20000, speed: 300},
+		{name: "too fast for drogues", altitude: 4000, speed: 800},
+		{name: "drogues", altitude: 4000, speed: 300, drogues: true},
+		{name: "drogues already out", altitude: 3000, speed: 200, droguesDeployed: true},
+		{name: "too fast for mains", altitude: 900, speed: 300, droguesDeployed: true},
+		{name: "mains", altitude: 900, speed: 100, droguesDeployed: true, mains: true},
+		{name: "straight to mains", altitude: 900, speed: 100, mains: true},
+		{name: "all out", altitude: 500, speed: 10, droguesDeployed: true, mainsDeployed: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			drogues, mains := chuteDecision(tc.altitude, tc.speed, tc.droguesDeployed, tc.mainsDeployed, cfg)
+			require.Equal(t, tc.drogues, drogues)
+			require.Equal(t, tc.mains, mains)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package spacecenter
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// ReferenceFrame - represents a reference frame for positions, rotations and
+// velocities. Contains: <list type="bullet"><item><description>The position of
+// the origin.</description></item><item><description>The directions of the x, y
+// and z axes.</description></item><item><description>The linear velocity of the
+// frame.</description></item><item><description>The angular velocity of the
+// frame.</description></item></list>
+type ReferenceFrame struct {
+	service.BaseClass
+}
+
+// NewReferenceFrame creates a new ReferenceFrame.
+func NewReferenceFrame(id uint64, client *krpcgo.KRPCClient) *ReferenceFrame {
+	c := &ReferenceFrame{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// CreateRelative - create a relative reference frame. This is a custom
+// reference frame whose components offset the components of a parent reference
+// frame.
+//
+// Allowed game scenes: any.
+func (s *ReferenceFrame) CreateRelative(referenceFrame *ReferenceFrame, position types.Tuple3[float64, float64, float64], rotation types.Tuple4[float64, float64, float64, float64], velocity types.Tuple3[float64, float64, float64], angularVelocity types.Tuple3[float64, float64, float64]) (*ReferenceFrame, error) {
+	var err error
+	var argBytes []byte
+	var vv ReferenceFrame
+	request := &types.ProcedureCall{
+		Procedure: "ReferenceFrame_static_CreateRelative",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(referenceFrame)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(position)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(rotation)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x2),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(velocity)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x3),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(angularVelocity)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x4),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
+
+// CreateHybrid - create a hybrid reference frame. This is a custom reference
+// frame whose components inherited from other reference frames.
+//
+// Allowed game scenes: any.
+func (s *ReferenceFrame) CreateHybrid(position *ReferenceFrame, rotation *ReferenceFrame, velocity *ReferenceFrame, angularVelocity *ReferenceFrame) (*ReferenceFrame, error) {
+	var err error
+	var argBytes []byte
+	var vv ReferenceFrame
+	request := &types.ProcedureCall{
+		Procedure: "ReferenceFrame_static_CreateHybrid",
+		Service:   "SpaceCenter",
+	}
+	argBytes, err = encode.Marshal(position)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(rotation)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(velocity)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x2),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(angularVelocity)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x3),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return &vv, tracerr.Wrap(err)
+	}
+	if vv.ID_internal() == 0 {
+		return nil, nil
+	}
+	vv.Client = s.Client
+	return &vv, nil
+}
@@ -0,0 +1,224 @@
+package spacecenter
+
+import (
+	"context"
+	"time"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/ztrue/tracerr"
+)
+
+// ReloadConfig configures loading a save with LoadAndResolve.
+type ReloadConfig struct {
+	// Timeout is how long to wait for the game to settle after loading.
+	// Defaults to 30 seconds.
+	Timeout time.Duration
+	// Interval is how often to check whether the game has settled.
+	// Defaults to 250ms.
+	Interval time.Duration
+	// Stable is how many checks in a row must see the same scene, active
+	// vessel and number of vessels before the game counts as settled.
+	// Defaults to 3.
+	Stable int
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ReloadConfig) SetDefaults() {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 250 * time.Millisecond
+	}
+	if cfg.Stable == 0 {
+		cfg.Stable = 3
+	}
+}
+
+// Reload is the outcome of loading a save.
+type Reload struct {
+	// Scene is the scene the game settled in.
+	Scene krpc.GameScene
+	// ActiveVessel is the active vessel, or nil outside the flight scene.
+	ActiveVessel *Vessel
+	// Vessels are new handles for the vessels passed to LoadAndResolve, in
+	// the same order, or nil for those that don't exist in the save.
+	Vessels []*Vessel
+	// Missing are the names of the vessels that don't exist in the save.
+	Missing []string
+	// Took is how long loading and settling took.
+	Took time.Duration
+}
+
+// vesselIdentity is what identifies a vessel across loads, since its
+// object ID changes.
+type vesselIdentity struct {
+	name string
+	kind VesselType
+}
+
+// identify gets a vessel's identity.
+func identify(vessel *Vessel) (vesselIdentity, error) {
+	name, err := vessel.Name()
+	if err != nil {
+		return vesselIdentity{}, tracerr.Wrap(err)
+	}
+	kind, err := vessel.Type()
+	if err != nil {
+		return vesselIdentity{}, tracerr.Wrap(err)
+	}
+	return vesselIdentity{name: name, kind: kind}, nil
+}
+
+// matchVessels finds each wanted vessel among the loaded ones, returning
+// the index of its match, or -1 if there is none. Vessels with the same
+// identity are matched in order, so two vessels named "Relay" still get
+// distinct handles.
+func matchVessels(want, loaded []vesselIdentity) []int {
+	used := make([]bool, len(loaded))
+	matches := make([]int, len(want))
+	for i, w := range want {
+		matches[i] = -1
+		for j, l := range loaded {
+			if !used[j] && l == w {
+				used[j] = true
+				matches[i] = j
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// sceneState is what is compared to tell whether the game has settled.
+type sceneState struct {
+	scene   krpc.GameScene
+	active  uint64
+	vessels int
+}
+
+// settle polls the game's state until the config's number of checks in a
+// row agree. Failed checks, as calls often do while a save is loading,
+// start the count again.
+func settle(ctx context.Context, cfg ReloadConfig, probe func() (sceneState, error)) (sceneState, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	var last sceneState
+	var lastErr error
+	stable := 0
+	for {
+		state, err := probe()
+		switch {
+		case err != nil:
+			lastErr = err
+			stable = 0
+		case stable > 0 && state == last:
+			stable++
+		default:
+			last = state
+			stable = 1
+		}
+		if stable >= cfg.Stable {
+			return last, nil
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, tracerr.Errorf("Game didn't settle after loading: %v", lastErr)
+			}
+			return last, tracerr.Errorf("Game didn't settle after loading")
+		case <-time.After(cfg.Interval):
+		}
+	}
+}
+
+// probe gets the game's state for settle.
+func (s *SpaceCenter) probe() (sceneState, error) {
+	var state sceneState
+	var err error
+	if state.scene, err = krpc.New(s.Client).CurrentGameScene(); err != nil {
+		return state, tracerr.Wrap(err)
+	}
+	if state.scene != krpc.GameScene_Flight {
+		return state, nil
+	}
+	active, err := s.ActiveVessel()
+	if err != nil {
+		return state, tracerr.Wrap(err)
+	}
+	if active != nil {
+		state.active = active.ID_internal()
+	}
+	vessels, err := s.Vessels()
+	if err != nil {
+		return state, tracerr.Wrap(err)
+	}
+	state.vessels = len(vessels)
+	return state, nil
+}
+
+// LoadAndResolve loads a save with load, such as Load or Quickload, waits
+// for the game to settle, and finds the given vessels again. Object IDs
+// change when a save is loaded, so any handle from before it fails or,
+// worse, refers to another object; use the handles in the result instead.
+// Vessels are found by name and type.
+func (s *SpaceCenter) LoadAndResolve(ctx context.Context, load func() error, cfg ReloadConfig, vessels ...*Vessel) (*Reload, error) {
+	cfg.SetDefaults()
+	start := time.Now()
+	want := make([]vesselIdentity, len(vessels))
+	for i, vessel := range vessels {
+		id, err := identify(vessel)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		want[i] = id
+	}
+
+	if err := load(); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	state, err := settle(ctx, cfg, s.probe)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	r := &Reload{Scene: state.scene, Vessels: make([]*Vessel, len(vessels))}
+	if state.scene == krpc.GameScene_Flight {
+		if r.ActiveVessel, err = s.ActiveVessel(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+
+	if len(vessels) > 0 {
+		loadedVessels, err := s.Vessels()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		loaded := make([]vesselIdentity, len(loadedVessels))
+		for i, vessel := range loadedVessels {
+			if loaded[i], err = identify(vessel); err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+		}
+		for i, j := range matchVessels(want, loaded) {
+			if j < 0 {
+				r.Missing = append(r.Missing, want[i].name)
+				continue
+			}
+			r.Vessels[i] = loadedVessels[j]
+		}
+	}
+	r.Took = time.Since(start)
+	return r, nil
+}
+
+// LoadNamed loads a named save with LoadAndResolve.
+func (s *SpaceCenter) LoadNamed(ctx context.Context, name string, cfg ReloadConfig, vessels ...*Vessel) (*Reload, error) {
+	r, err := s.LoadAndResolve(ctx, func() error { return s.Load(name) }, cfg, vessels...)
+	return r, tracerr.Wrap(err)
+}
+
+// QuickloadAndResolve loads the quicksave with LoadAndResolve.
+func (s *SpaceCenter) QuickloadAndResolve(ctx context.Context, cfg ReloadConfig, vessels ...*Vessel) (*Reload, error) {
+	r, err := s.LoadAndResolve(ctx, s.Quickload, cfg, vessels...)
+	return r, tracerr.Wrap(err)
+}
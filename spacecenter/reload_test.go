@@ -0,0 +1,93 @@
+package spacecenter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchVessels(t *testing.T) {
+	relay := vesselIdentity{name: "Relay", kind: VesselType_Relay}
+	probe := vesselIdentity{name: "Relay", kind: VesselType_Probe}
+	ship := vesselIdentity{name: "Kerbal X", kind: VesselType_Ship}
+	loaded := []vesselIdentity{ship, relay, probe, relay}
+	require.Equal(t, []int{1, 3, -1, 0, 2}, matchVessels(
+		[]vesselIdentity{relay, relay, relay, ship, probe}, loaded))
+}
+
+func TestSettle(t *testing.T) {
+	cfg := ReloadConfig{Interval: time.Millisecond, Stable: 3}
+	cfg.SetDefaults()
+	flight := sceneState{scene: krpc.GameScene_Flight, active: 5, vessels: 2}
+	// The game is loading, then briefly has the wrong active vessel.
+	results := []sceneState{{}, {}, flight, {scene: krpc.GameScene_Flight, active: 4, vessels: 2}, flight, flight, flight}
+	errs := []error{errors.New("loading"), nil, nil, nil, errors.New("loading"), nil, nil, nil}
+	calls := 0
+	state, err := settle(context.Background(), cfg, func() (sceneState, error) {
+		i := calls
+		calls++
+		if errs[i] != nil {
+			return sceneState{}, errs[i]
+		}
+		return results[i-1], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, flight, state)
+	require.Equal(t, 8, calls)
+
+	cfg.Timeout = 10 * time.Millisecond
+	_, err = settle(context.Background(), cfg, func() (sceneState, error) {
+		return sceneState{}, errors.New("loading")
+	})
+	require.ErrorContains(t, err, "didn't settle after loading: loading")
+}
+
+func TestLoadAndResolve(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	// Before loading, vessel 1 is "Kerbal X" and vessel 2 is "Relay"; the
+	// save has them as 12 and 11.
+	names := map[uint64]string{1: "Kerbal X", 2: "Relay", 3: "Debris", 11: "Relay", 12: "Kerbal X"}
+	server.Handle("SpaceCenter", "Vessel_get_Name", func(call *krpctest.Call) (interface{}, error) {
+		var vessel Vessel
+		if err := call.Arg(0, &vessel); err != nil {
+			return nil, err
+		}
+		return names[vessel.ID_internal()], nil
+	})
+	server.Return("SpaceCenter", "Vessel_get_Type", VesselType_Ship)
+	server.Return("KRPC", "get_CurrentGameScene", krpc.GameScene_Flight)
+	server.Return("SpaceCenter", "get_ActiveVessel", uint64(12))
+	server.Return("SpaceCenter", "get_Vessels", []uint64{11, 12})
+	server.Return("SpaceCenter", "Load", nil)
+	client, err := server.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	sc := New(client)
+
+	r, err := sc.LoadNamed(context.Background(), "orbit", ReloadConfig{Interval: time.Millisecond},
+		NewVessel(1, client), NewVessel(2, client), NewVessel(3, client))
+	require.NoError(t, err)
+	require.Equal(t, krpc.GameScene_Flight, r.Scene)
+	require.Equal(t, uint64(12), r.ActiveVessel.ID_internal())
+	require.Len(t, r.Vessels, 3)
+	require.Equal(t, uint64(12), r.Vessels[0].ID_internal())
+	require.Equal(t, uint64(11), r.Vessels[1].ID_internal())
+	require.Nil(t, r.Vessels[2])
+	require.Equal(t, []string{"Debris"}, r.Missing)
+
+	var loads []string
+	for _, call := range server.Calls() {
+		if call.Procedure == "Load" {
+			var name string
+			require.NoError(t, call.Arg(0, &name))
+			loads = append(loads, name)
+		}
+	}
+	require.Equal(t, []string{"orbit"}, loads)
+}
@@ -0,0 +1,431 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// RendezvousConfig configures a rendezvous.
+type RendezvousConfig struct {
+	// Distance is how close to get to the target, in meters.
+	Distance float64
+	// Speed is the relative speed to finish at, in m/s.
+	Speed float64
+	// MaxApproachSpeed is the fastest to close in on the target during
+	// terminal braking, in m/s.
+	MaxApproachSpeed float64
+	// BrakeLead is how long before closest approach to start terminal
+	// braking, in seconds.
+	BrakeLead float64
+	// DepartureLead is how long after Run starts to plan the departure burn,
+	// in seconds, leaving time to turn towards it. Negative values count as
+	// zero.
+	DepartureLead float64
+	// MaxPhasingOrbits is the most orbits to spend in a phasing orbit when
+	// the vessel and target share an orbit.
+	MaxPhasingOrbits int
+	// MinRadius is the lowest a phasing orbit may go, in meters from the
+	// center of the body. Defaults to the body's radius plus 10%.
+	MinRadius float64
+	// Execution configures how burns are executed.
+	Execution NodeExecutionConfig
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *RendezvousConfig) SetDefaults() {
+	if cfg.Distance == 0 {
+		cfg.Distance = 50
+	}
+	if cfg.Speed == 0 {
+		cfg.Speed = 0.5
+	}
+	if cfg.MaxApproachSpeed == 0 {
+		cfg.MaxApproachSpeed = 20
+	}
+	if cfg.BrakeLead == 0 {
+		cfg.BrakeLead = 120
+	}
+	if cfg.DepartureLead == 0 {
+		cfg.DepartureLead = 60
+	}
+	if cfg.MaxPhasingOrbits == 0 {
+		cfg.MaxPhasingOrbits = 5
+	}
+	cfg.Execution.SetDefaults()
+}
+
+// Rendezvous brings a vessel alongside a target vessel. Both vessels should
+// be in roughly circular orbits, in the same plane, around the same body;
+// use PlanPlaneMatch first if they aren't.
+type Rendezvous struct {
+	vessel *Vessel
+	target *Vessel
+	cfg    RendezvousConfig
+}
+
+// NewRendezvous creates a new Rendezvous.
+func NewRendezvous(vessel, target *Vessel, cfg RendezvousConfig) *Rendezvous {
+	cfg.SetDefaults()
+	return &Rendezvous{vessel: vessel, target: target, cfg: cfg}
+}
+
+// coorbitalTolerance is how close, as a fraction of the target's radius, two
+// orbits have to be to count as the same orbit.
+const coorbitalTolerance = 0.01
+
+// phaseAngle returns the angle, in radians between -pi and pi, that a target
+// at rt is ahead of a vessel at rv moving with velocity vv.
+func phaseAngle(rv, vv, rt types.Vector3D) float64 {
+	normal := rv.Cross(vv)
+	return math.Atan2(rv.Cross(rt).Dot(normal)/normal.Length(), rv.Dot(rt))
+}
+
+// planRendezvous plans the burns that bring a vessel in a circular orbit of
+// radius rv to a target in a circular orbit of radius rt that is phase
+// radians ahead of it at ut.
+func planRendezvous(mu, rv, rt, phase, ut, minRadius float64, maxOrbits int) (*ManeuverPlan, error) {
+	nv := math.Sqrt(mu / (rv * rv * rv))
+	nt := math.Sqrt(mu / (rt * rt * rt))
+
+	if math.Abs(rv-rt) > coorbitalTolerance*rt {
+		// Wait until the target is far enough ahead that it arrives at the
+		// far side of a Hohmann transfer at the same time as the vessel.
+		transfer := halfPeriod(mu, (rv+rt)/2)
+		lead := math.Pi - nt*transfer
+		var wait float64
+		if nt > nv {
+			wait = units.NormalizeRadians(lead-phase) / (nt - nv)
+		} else {
+			wait = units.NormalizeRadians(phase-lead) / (nv - nt)
+		}
+		return &ManeuverPlan{Name: "hohmann rendezvous", Burns: hohmann(mu, rv, rt, ut+wait)}, nil
+	}
+
+	// Sharing an orbit, so move into a phasing orbit that comes back around
+	// after k orbits just as the target gets there.
+	phase = math.Remainder(phase, 2*math.Pi)
+	for k := 1; k <= maxOrbits; k++ {
+		period := (2*math.Pi*float64(k) - phase) / (nt * float64(k))
+		a := math.Cbrt(mu * period * period / (4 * math.Pi * math.Pi))
+		if 2*a-rv < minRadius {
+			continue
+		}
+		return &ManeuverPlan{Name: "phasing rendezvous", Burns: []ManeuverBurn{
+			{UT: ut, Prograde: visViva(mu, rv, a) - visViva(mu, rv, rv)},
+			{UT: ut + float64(k)*period, Prograde: visViva(mu, rv, rv) - visViva(mu, rv, a)},
+		}}, nil
+	}
+	return nil, tracerr.Errorf("No phasing orbit within %v orbits stays above %v m", maxOrbits, minRadius)
+}
+
+// Plan plans the burns for a rendezvous starting at ut. The last burn
+// matches the target's orbit; Run replaces it with terminal braking.
+func (r *Rendezvous) Plan(ut float64) (*ManeuverPlan, error) {
+	orbit, err := r.vessel.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	mu, err := body.GravitationalParameter()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	minRadius := r.cfg.MinRadius
+	if minRadius == 0 {
+		radius, err := body.EquatorialRadius()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		minRadius = 1.1 * float64(radius)
+	}
+	rf, err := body.NonRotatingReferenceFrame()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	targetOrbit, err := r.target.Orbit()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rv, err := orbit.PositionAt(ut, rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rt, err := targetOrbit.PositionAt(ut, rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	vv, err := r.vessel.Velocity(rf)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	posV, posT := types.Vector3DFromTuple(rv), types.Vector3DFromTuple(rt)
+	phase := phaseAngle(posV, types.Vector3DFromTuple(vv), posT)
+	plan, err := planRendezvous(float64(mu), posV.Length(), posT.Length(), phase, ut, minRadius, r.cfg.MaxPhasingOrbits)
+	return plan, tracerr.Wrap(err)
+}
+
+// minimize finds a local minimum of f by coordinate descent, starting with a
+// step size and halving it until it drops below minStep.
+func minimize(f func(x [3]float64) (float64, error), x [3]float64, step, minStep float64) ([3]float64, float64, error) {
+	best, err := f(x)
+	if err != nil {
+		return x, 0, tracerr.Wrap(err)
+	}
+	for step >= minStep {
+		improved := false
+		for i := range x {
+			for _, dir := range []float64{1, -1} {
+				candidate := x
+				candidate[i] += dir * step
+				v, err := f(candidate)
+				if err != nil {
+					return x, best, tracerr.Wrap(err)
+				}
+				if v < best {
+					x, best, improved = candidate, v, true
+					break
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+	return x, best, nil
+}
+
+// Refine adjusts a maneuver node to bring the closest approach to the target
+// as close as it can, returning the new closest approach distance.
+func (r *Rendezvous) Refine(node *Node) (float64, error) {
+	targetOrbit, err := r.target.Orbit()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	set := func(x [3]float64) error {
+		if err := node.SetPrograde(x[0]); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := node.SetNormal(x[1]); err != nil {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(node.SetRadial(x[2]))
+	}
+	distance := func(x [3]float64) (float64, error) {
+		if err := set(x); err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		orbit, err := node.Orbit()
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		d, err := orbit.DistanceAtClosestApproach(targetOrbit)
+		return d, tracerr.Wrap(err)
+	}
+
+	var x [3]float64
+	if x[0], err = node.Prograde(); err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	if x[1], err = node.Normal(); err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	if x[2], err = node.Radial(); err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	x, best, err := minimize(distance, x, 1, 0.01)
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	// Leave the node at the best point found, not the last one tried.
+	return best, tracerr.Wrap(set(x))
+}
+
+// brakingCommand works out the change in velocity needed to approach a
+// target, given the vessel's position and velocity relative to it and the
+// acceleration it can manage. The approach speed is limited so the vessel can
+// always stop in time.
+func brakingCommand(position, velocity types.Vector3D, acceleration float64, cfg RendezvousConfig) (types.Vector3D, bool) {
+	distance := position.Length()
+	if distance <= cfg.Distance && velocity.Length() <= cfg.Speed {
+		return types.Vector3D{}, true
+	}
+	// Aim to arrive with half the available acceleration to spare.
+	speed := math.Sqrt(math.Max(0, acceleration*(distance-cfg.Distance)))
+	speed = math.Min(speed, cfg.MaxApproachSpeed)
+	desired := types.Vector3D{}
+	if distance > 0 {
+		desired = position.Scale(-speed / distance)
+	}
+	return desired.Add(velocity.Scale(-1)), false
+}
+
+// Brake closes in on the target and matches its velocity, finishing within
+// the configured distance and relative speed.
+func (r *Rendezvous) Brake(ctx context.Context) error {
+	rf, err := r.target.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	control, err := r.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ap, err := r.vessel.AutoPilot()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.SetReferenceFrame(rf); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ap.Engage(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer ap.Disengage()
+	defer control.SetThrottle(0)
+
+	positions, err := r.vessel.PositionStream(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer positions.Close()
+	velocities, err := r.vessel.VelocityStream(rf)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer velocities.Close()
+	apErrors, err := ap.ErrorStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer apErrors.Close()
+
+	acceleration, err := maxAcceleration(r.vessel)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	var position, velocity types.Vector3D
+	var havePosition, haveVelocity bool
+	var pointingError float32 = 180
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case p := <-positions.C:
+			position = types.Vector3DFromTuple(p)
+			havePosition = true
+		case v := <-velocities.C:
+			velocity = types.Vector3DFromTuple(v)
+			haveVelocity = true
+		case pointingError = <-apErrors.C:
+		}
+		if !havePosition || !haveVelocity {
+			// Don't mistake the zero vectors for having arrived.
+			continue
+		}
+
+		dv, done := brakingCommand(position, velocity, acceleration, r.cfg)
+		if done {
+			return nil
+		}
+		throttle := 0.0
+		if dv.Length() > r.cfg.Speed/2 {
+			if err := ap.SetTargetDirection(dv.Tuple()); err != nil {
+				return tracerr.Wrap(err)
+			}
+			// Only burn once pointing roughly the right way.
+			if pointingError < 10 {
+				throttle = math.Min(1, dv.Length()/acceleration)
+			}
+		}
+		if err := control.SetThrottle(float32(throttle)); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+}
+
+// Run carries out a whole rendezvous: the departure burn, a correction burn
+// to tighten up the closest approach, then terminal braking from shortly
+// before closest approach.
+func (r *Rendezvous) Run(ctx context.Context) error {
+	sc := New(r.vessel.Client)
+	control, err := r.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ut, err := sc.UT()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	// Leave time to turn towards the first burn.
+	plan, err := r.Plan(ut + math.Max(0, r.cfg.DepartureLead))
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	departure := plan.Burns[0]
+	node, err := control.AddNode(departure.UT, float32(departure.Prograde), float32(departure.Normal), float32(departure.Radial))
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := ExecuteNode(ctx, r.vessel, node, r.cfg.Execution); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	orbit, err := r.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	targetOrbit, err := r.target.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if ut, err = sc.UT(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	approach, err := FindClosestApproach(orbit, targetOrbit, ut)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	// Correct a third of the way to closest approach.
+	correction, err := control.AddNode(ut+(approach.UT-ut)/3, 0, 0, 0)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if _, err := r.Refine(correction); err != nil {
+		return tracerr.Wrap(err)
+	}
+	dv, err := correction.DeltaV()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if dv > r.cfg.Execution.Tolerance {
+		if err := ExecuteNode(ctx, r.vessel, correction, r.cfg.Execution); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if ut, err = sc.UT(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if approach, err = FindClosestApproach(orbit, targetOrbit, ut); err != nil {
+			return tracerr.Wrap(err)
+		}
+	} else if err := correction.Remove(); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	if ut, err = sc.UT(); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if brakeAt := approach.UT - r.cfg.BrakeLead; brakeAt > ut {
+		if err := sc.WarpTo(brakeAt, 100000, 2); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	return tracerr.Wrap(r.Brake(ctx))
+}
@@ -0,0 +1,117 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseAngle(t *testing.T) {
+	rv := types.NewVector3D(1, 0, 0)
+	vv := types.NewVector3D(0, 1, 0)
+	require.InDelta(t, math.Pi/2, phaseAngle(rv, vv, types.NewVector3D(0, 2, 0)), 1e-9)
+	require.InDelta(t, -math.Pi/2, phaseAngle(rv, vv, types.NewVector3D(0, -2, 0)), 1e-9)
+	// Reversing the direction of travel reverses the phase.
+	require.InDelta(t, -math.Pi/2, phaseAngle(rv, vv.Scale(-1), types.NewVector3D(0, 2, 0)), 1e-9)
+}
+
+// angleAt returns the angle of a body in a circular orbit of radius r that
+// starts at angle a0 at time 0.
+func angleAt(mu, r, a0, ut float64) float64 {
+	return a0 + math.Sqrt(mu/(r*r*r))*ut
+}
+
+func TestPlanRendezvousHohmann(t *testing.T) {
+	mu := 3.5316e12
+	for _, tc := range []struct {
+		name   string
+		rv, rt float64
+		phase  float64
+	}{
+		{name: "raise", rv: 700e3, rt: 800e3, phase: 1},
+		{name: "raise, target behind", rv: 700e3, rt: 800e3, phase: -2},
+		{name: "lower", rv: 800e3, rt: 700e3, phase: 0.5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := planRendezvous(mu, tc.rv, tc.rt, tc.phase, 0, 0, 5)
+			require.NoError(t, err)
+			require.Len(t, plan.Burns, 2)
+			depart, arrive := plan.Burns[0].UT, plan.Burns[1].UT
+			require.GreaterOrEqual(t, depart, 0.0)
+			// The vessel arrives on the far side of where it departed from,
+			// and the target should be there too.
+			vesselAngle := angleAt(mu, tc.rv, 0, depart) + math.Pi
+			targetAngle := angleAt(mu, tc.rt, tc.phase, arrive)
+			require.InDelta(t, 0, math.Remainder(vesselAngle-targetAngle, 2*math.Pi), 1e-6)
+		})
+	}
+}
+
+func TestPlanRendezvousPhasing(t *testing.T) {
+	mu := 3.5316e12
+	r := 700e3
+	for _, phase := range []float64{0.3, -0.3, -3} {
+		plan, err := planRendezvous(mu, r, r, phase, 100, 650e3, 5)
+		require.NoError(t, err)
+		require.Len(t, plan.Burns, 2)
+		// The vessel is back where it started when the target gets there.
+		targetAngle := angleAt(mu, r, phase, plan.Burns[1].UT-100)
+		require.InDelta(t, 0, math.Remainder(targetAngle, 2*math.Pi), 1e-6)
+		// The burns cancel out.
+		require.InDelta(t, 0, plan.Burns[0].Prograde+plan.Burns[1].Prograde, 1e-9)
+		if phase > 0 {
+			require.Less(t, plan.Burns[0].Prograde, 0.0)
+		} else {
+			require.Greater(t, plan.Burns[0].Prograde, 0.0)
+		}
+	}
+
+	// Catching up a long way in a single orbit would dip too low.
+	plan, err := planRendezvous(mu, r, r, 3, 0, 600e3, 5)
+	require.NoError(t, err)
+	require.Greater(t, plan.Burns[1].UT, 4*2*math.Pi*math.Sqrt(r*r*r/mu))
+	_, err = planRendezvous(mu, r, r, 3, 0, 600e3, 4)
+	require.Error(t, err)
+}
+
+func TestMinimize(t *testing.T) {
+	f := func(x [3]float64) (float64, error) {
+		return (x[0]-3)*(x[0]-3) + (x[1]+1.5)*(x[1]+1.5) + x[2]*x[2], nil
+	}
+	x, v, err := minimize(f, [3]float64{}, 1, 0.001)
+	require.NoError(t, err)
+	require.InDelta(t, 3, x[0], 0.01)
+	require.InDelta(t, -1.5, x[1], 0.01)
+	require.InDelta(t, 0, x[2], 0.01)
+	require.InDelta(t, 0, v, 1e-3)
+}
+
+func TestBrakingCommand(t *testing.T) {
+	cfg := RendezvousConfig{}
+	cfg.SetDefaults()
+
+	// Close enough and slow enough.
+	_, done := brakingCommand(types.NewVector3D(10, 0, 0), types.NewVector3D(0.1, 0, 0), 1, cfg)
+	require.True(t, done)
+
+	// Far away and stationary: head straight for the target.
+	dv, done := brakingCommand(types.NewVector3D(1000, 0, 0), types.Vector3D{}, 1, cfg)
+	require.False(t, done)
+	require.InDelta(t, -cfg.MaxApproachSpeed, dv.X, 1e-9)
+
+	// Closing too fast: slow down.
+	dv, _ = brakingCommand(types.NewVector3D(100, 0, 0), types.NewVector3D(-50, 0, 0), 1, cfg)
+	require.Greater(t, dv.X, 0.0)
+
+	// Drifting sideways: cancel it.
+	dv, _ = brakingCommand(types.NewVector3D(40, 0, 0), types.NewVector3D(0, 3, 0), 1, cfg)
+	require.InDelta(t, -3, dv.Y, 1e-9)
+}
+
+func TestBurnThrottle(t *testing.T) {
+	require.Equal(t, 1.0, burnThrottle(100, 10, 0.05))
+	require.InDelta(t, 0.5, burnThrottle(5, 10, 0.05), 1e-9)
+	require.Equal(t, 0.05, burnThrottle(0.1, 10, 0.05))
+}
@@ -0,0 +1,220 @@
+package spacecenter
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// DefaultKerbals are the kerbals that every new game starts with.
+var DefaultKerbals = []string{
+	"Jebediah Kerman",
+	"Bill Kerman",
+	"Bob Kerman",
+	"Valentina Kerman",
+}
+
+// DefaultRosterMaxAge is how long a roster is cached by default.
+const DefaultRosterMaxAge = 10 * time.Second
+
+// Kerbal is a snapshot of a kerbal in the roster.
+type Kerbal struct {
+	// Name is the kerbal's name.
+	Name string
+	// Trait is the kerbal's trait, e.g. "Pilot".
+	Trait string
+	// Type is the kerbal's crew member type.
+	Type CrewMemberType
+	// Status is the kerbal's roster status.
+	Status RosterStatus
+	// Vessel is the vessel the kerbal is aboard, or nil if they aren't
+	// aboard one.
+	Vessel *Vessel
+	// CrewMember is the kerbal's crew member.
+	CrewMember *CrewMember
+}
+
+// Roster lists the kerbals in the game. kRPC has no way to list every
+// kerbal, so the roster is made up of the crews of all vessels plus any
+// other kerbals that can be found by name: the starting kerbals, kerbals that
+// have been seen in a vessel before, and any names added with
+// [Roster.AddNames].
+type Roster struct {
+	sc *SpaceCenter
+	// MaxAge is how long the roster is cached before it's fetched again.
+	MaxAge time.Duration
+
+	mu      sync.Mutex
+	names   map[string]struct{}
+	kerbals map[string]Kerbal
+	updated time.Time
+	now     func() time.Time
+	load    func(names []string) (map[string]Kerbal, error)
+}
+
+// NewRoster creates a new Roster.
+func NewRoster(sc *SpaceCenter) *Roster {
+	r := &Roster{
+		sc:     sc,
+		MaxAge: DefaultRosterMaxAge,
+		names:  make(map[string]struct{}),
+		now:    time.Now,
+	}
+	r.load = r.fetch
+	r.AddNames(DefaultKerbals...)
+	return r
+}
+
+// AddNames adds names of kerbals to look for, such as kerbals created with
+// [SpaceCenter.CreateKerbal]. Adding a name invalidates the cache.
+func (r *Roster) AddNames(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		r.names[name] = struct{}{}
+	}
+	r.kerbals = nil
+}
+
+// Invalidate clears the cache, so the next lookup fetches the roster again.
+func (r *Roster) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.kerbals = nil
+}
+
+// Refresh fetches the roster again.
+func (r *Roster) Refresh() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return tracerr.Wrap(r.refresh())
+}
+
+// refresh fetches the roster. r.mu must be held.
+func (r *Roster) refresh() error {
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	kerbals, err := r.load(names)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	// Remember everyone we've seen, so that kerbals who leave a vessel can
+	// still be found.
+	for name := range kerbals {
+		r.names[name] = struct{}{}
+	}
+	r.kerbals = kerbals
+	r.updated = r.now()
+	return nil
+}
+
+// cached returns the cached roster, refreshing it if needed. r.mu must be
+// held.
+func (r *Roster) cached() (map[string]Kerbal, error) {
+	if r.kerbals == nil || r.now().Sub(r.updated) > r.MaxAge {
+		if err := r.refresh(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return r.kerbals, nil
+}
+
+// Kerbals lists the known kerbals, sorted by name.
+func (r *Roster) Kerbals() ([]Kerbal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kerbals, err := r.cached()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	list := make([]Kerbal, 0, len(kerbals))
+	for _, k := range kerbals {
+		list = append(list, k)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// Get gets a kerbal by name. Returns false if the kerbal isn't known.
+func (r *Roster) Get(name string) (Kerbal, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.names[name]; !ok {
+		// Check for a kerbal we haven't heard of before.
+		r.names[name] = struct{}{}
+		r.kerbals = nil
+	}
+	kerbals, err := r.cached()
+	if err != nil {
+		return Kerbal{}, false, tracerr.Wrap(err)
+	}
+	k, ok := kerbals[name]
+	return k, ok, nil
+}
+
+// fetch gets the crew of every vessel, then looks up the remaining names.
+func (r *Roster) fetch(names []string) (map[string]Kerbal, error) {
+	kerbals := make(map[string]Kerbal)
+	vessels, err := r.sc.Vessels()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, vessel := range vessels {
+		crew, err := vessel.Crew()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		for _, member := range crew {
+			k, err := newKerbal(member)
+			if err != nil {
+				return nil, tracerr.Wrap(err)
+			}
+			k.Vessel = vessel
+			kerbals[k.Name] = k
+		}
+	}
+
+	for _, name := range names {
+		if _, ok := kerbals[name]; ok {
+			continue
+		}
+		member, err := r.sc.GetKerbal(name)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if member == nil {
+			continue
+		}
+		k, err := newKerbal(member)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		kerbals[k.Name] = k
+	}
+	return kerbals, nil
+}
+
+func newKerbal(member *CrewMember) (Kerbal, error) {
+	k := Kerbal{CrewMember: member}
+	var err error
+	if k.Name, err = member.Name(); err != nil {
+		return k, tracerr.Wrap(err)
+	}
+	if k.Trait, err = member.Trait(); err != nil {
+		return k, tracerr.Wrap(err)
+	}
+	if k.Type, err = member.Type(); err != nil {
+		return k, tracerr.Wrap(err)
+	}
+	if k.Status, err = member.RosterStatus(); err != nil {
+		return k, tracerr.Wrap(err)
+	}
+	return k, nil
+}
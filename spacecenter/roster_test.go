@@ -0,0 +1,60 @@
+package spacecenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRosterCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	var loads [][]string
+	inGame := map[string]Kerbal{
+		"Bill Kerman":   {Name: "Bill Kerman", Trait: "Engineer"},
+		"Tester Kerman": {Name: "Tester Kerman", Trait: "Pilot"},
+	}
+	r := NewRoster(nil)
+	r.now = func() time.Time { return now }
+	r.load = func(names []string) (map[string]Kerbal, error) {
+		loads = append(loads, names)
+		kerbals := make(map[string]Kerbal)
+		for _, name := range names {
+			if k, ok := inGame[name]; ok {
+				kerbals[name] = k
+			}
+		}
+		return kerbals, nil
+	}
+
+	kerbals, err := r.Kerbals()
+	require.NoError(t, err)
+	require.Equal(t, []Kerbal{inGame["Bill Kerman"]}, kerbals)
+	require.Len(t, loads, 1)
+	require.Equal(t, []string{"Bill Kerman", "Bob Kerman", "Jebediah Kerman", "Valentina Kerman"}, loads[0])
+
+	// Cached.
+	_, ok, err := r.Get("Bill Kerman")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, loads, 1)
+
+	// New names are looked up.
+	k, ok, err := r.Get("Tester Kerman")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Pilot", k.Trait)
+	require.Len(t, loads, 2)
+
+	_, ok, err = r.Get("Nobody Kerman")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Len(t, loads, 3)
+
+	// Expired.
+	now = now.Add(DefaultRosterMaxAge + time.Second)
+	kerbals, err = r.Kerbals()
+	require.NoError(t, err)
+	require.Len(t, kerbals, 2)
+	require.Len(t, loads, 4)
+}
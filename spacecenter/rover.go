@@ -0,0 +1,386 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/atburke/krpc-go/control"
+	"github.com/ztrue/tracerr"
+)
+
+// roverStoppedSpeed is the speed, in m/s, below which a rover counts as
+// stopped.
+const roverStoppedSpeed = 0.1
+
+// RoverWaypoint is a point on the surface for a rover to drive to.
+type RoverWaypoint struct {
+	Latitude  float64
+	Longitude float64
+	// Pause is how long to stay stopped at the waypoint before driving on.
+	Pause time.Duration
+}
+
+// RoverConfig configures a Rover.
+type RoverConfig struct {
+	// MaxSpeed is the fastest to drive on flat ground, in m/s.
+	MaxSpeed float64
+	// MinSpeed is the slowest to drive while still making progress, in m/s.
+	MinSpeed float64
+	// MaxSlope is the steepest slope, in degrees, to drive over. The rover
+	// slows to MinSpeed as the slope approaches it.
+	MaxSlope float64
+	// MaxStress is the wheel stress, as a percentage of the wheels' stress
+	// tolerance, above which the rover brakes.
+	MaxStress float64
+	// ArrivalRadius is how close, in meters, the rover must get to a
+	// waypoint to have reached it.
+	ArrivalRadius float64
+	// Interval is how often the control loops run.
+	Interval time.Duration
+	// Steering turns the bearing to the waypoint (degrees) into steering.
+	Steering control.Gains
+	// Throttle turns speed error (m/s) into wheel throttle.
+	Throttle control.Gains
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *RoverConfig) SetDefaults() {
+	if cfg.MaxSpeed == 0 {
+		cfg.MaxSpeed = 10
+	}
+	if cfg.MinSpeed == 0 {
+		cfg.MinSpeed = 1
+	}
+	if cfg.MaxSlope == 0 {
+		cfg.MaxSlope = 25
+	}
+	if cfg.MaxStress == 0 {
+		cfg.MaxStress = 80
+	}
+	if cfg.ArrivalRadius == 0 {
+		cfg.ArrivalRadius = 10
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+	if cfg.Steering == (control.Gains{}) {
+		cfg.Steering = control.Gains{Kp: 0.02, Ki: 0, Kd: 0.005}
+	}
+	if cfg.Throttle == (control.Gains{}) {
+		cfg.Throttle = control.Gains{Kp: 0.3, Ki: 0.05, Kd: 0}
+	}
+}
+
+// roverSpeedLimit returns how fast a rover should drive, slowing down on
+// slopes and near the waypoint, and stopping if its wheels are overstressed.
+func roverSpeedLimit(distance, slope, stress float64, cfg RoverConfig) float64 {
+	if stress >= cfg.MaxStress {
+		return 0
+	}
+	limit := cfg.MaxSpeed
+	// Slow down linearly from half the maximum slope.
+	if steepness := (slope - cfg.MaxSlope/2) / (cfg.MaxSlope / 2); steepness > 0 {
+		limit -= (cfg.MaxSpeed - cfg.MinSpeed) * math.Min(1, steepness)
+	}
+	// Slow down over the last few arrival radii.
+	approach := cfg.MinSpeed + (cfg.MaxSpeed-cfg.MinSpeed)*(distance-cfg.ArrivalRadius)/(4*cfg.ArrivalRadius)
+	return math.Max(cfg.MinSpeed, math.Min(limit, approach))
+}
+
+// roverInputs are control inputs for a rover.
+type roverInputs struct {
+	throttle float64
+	steering float64
+	brakes   bool
+}
+
+// roverLoops are the steering and throttle loops of a rover.
+type roverLoops struct {
+	cfg      RoverConfig
+	steering *control.PID
+	throttle *control.PID
+}
+
+func newRoverLoops(cfg RoverConfig) *roverLoops {
+	return &roverLoops{
+		cfg:      cfg,
+		steering: control.New(cfg.Steering, -1, 1),
+		throttle: control.New(cfg.Throttle, -1, 1),
+	}
+}
+
+// step runs the loops once, for a rover relativeBearing degrees off course
+// and some distance from the waypoint. Positive steering is to the left, as
+// in Control.WheelSteering.
+func (l *roverLoops) step(distance, relativeBearing, speed, slope, stress, dt float64) roverInputs {
+	limit := roverSpeedLimit(distance, slope, stress, l.cfg)
+	if limit == 0 {
+		l.throttle.Reset()
+		return roverInputs{brakes: true}
+	}
+	// Turn around slowly rather than drive off in the wrong direction.
+	if math.Abs(relativeBearing) > 90 {
+		limit = l.cfg.MinSpeed
+	}
+	throttle := l.throttle.UpdateError(limit-speed, dt)
+	return roverInputs{
+		throttle: throttle,
+		steering: -l.steering.UpdateError(relativeBearing, dt),
+		brakes:   speed > limit*1.5,
+	}
+}
+
+// Rover drives a rover along a route of surface waypoints using the wheel
+// throttle and steering, slowing down on slopes and braking if the wheels
+// are overstressed.
+type Rover struct {
+	vessel *Vessel
+	cfg    RoverConfig
+	// OnArrive, if set, is called once the rover has stopped at a waypoint,
+	// e.g. to run experiments or take screenshots. The rover drives on when
+	// it returns, after the waypoint's pause.
+	OnArrive func(ctx context.Context, index int, waypoint RoverWaypoint) error
+}
+
+// NewRover creates a new Rover.
+func NewRover(vessel *Vessel, cfg RoverConfig) *Rover {
+	cfg.SetDefaults()
+	return &Rover{vessel: vessel, cfg: cfg}
+}
+
+// roverState is the latest streamed state of a rover.
+type roverState struct {
+	latitude, longitude float64
+	heading             float64
+	pitch, roll         float64
+	speed               float64
+	received            roverFields
+}
+
+// roverFields is a set of roverState fields that have been streamed.
+type roverFields uint8
+
+const (
+	roverLatitude roverFields = 1 << iota
+	roverLongitude
+	roverHeading
+	roverPitch
+	roverRoll
+	roverSpeed
+
+	allRoverFields = roverLatitude | roverLongitude | roverHeading |
+		roverPitch | roverRoll | roverSpeed
+)
+
+// located reports whether every stream has delivered its first value, so
+// that the rover knows where it is and which way it's going.
+func (s roverState) located() bool {
+	return s.received == allRoverFields
+}
+
+// wheelStress returns the highest stress on any of the wheels, as a
+// percentage of their stress tolerance. Returns an error if a wheel is
+// broken.
+func wheelStress(wheels []*Wheel) (float64, error) {
+	var stress float64
+	for _, wheel := range wheels {
+		broken, err := wheel.Broken()
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		if broken {
+			return 0, tracerr.Errorf("Wheel is broken")
+		}
+		s, err := wheel.StressPercentage()
+		if err != nil {
+			return 0, tracerr.Wrap(err)
+		}
+		stress = math.Max(stress, float64(s))
+	}
+	return stress, nil
+}
+
+// Drive drives the rover to each waypoint in turn, stopping at each one.
+// The rover is left stopped with its brakes on.
+func (r *Rover) Drive(ctx context.Context, waypoints []RoverWaypoint) error {
+	control, err := r.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	parts, err := r.vessel.Parts()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	wheels, err := parts.Wheels()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if len(wheels) == 0 {
+		return tracerr.Errorf("Vessel has no wheels")
+	}
+	orbit, err := r.vessel.Orbit()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	radius, err := body.EquatorialRadius()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	bodyRF, err := body.ReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	surfaceRF, err := r.vessel.SurfaceReferenceFrame()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	attitude, err := r.vessel.Flight(surfaceRF)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	motion, err := r.vessel.Flight(bodyRF)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	latitudes, err := attitude.LatitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer latitudes.Close()
+	longitudes, err := attitude.LongitudeStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer longitudes.Close()
+	headings, err := attitude.HeadingStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer headings.Close()
+	pitches, err := attitude.PitchStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer pitches.Close()
+	rolls, err := attitude.RollStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer rolls.Close()
+	speeds, err := motion.SpeedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer speeds.Close()
+
+	stop := func() error {
+		if err := control.SetWheelThrottle(0); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := control.SetWheelSteering(0); err != nil {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(control.SetBrakes(true))
+	}
+	defer stop()
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	var state roverState
+	for i, waypoint := range waypoints {
+		if err := control.SetBrakes(false); err != nil {
+			return tracerr.Wrap(err)
+		}
+		loops := newRoverLoops(r.cfg)
+		last := time.Now()
+	driving:
+		for {
+			select {
+			case <-ctx.Done():
+				return tracerr.Wrap(ctx.Err())
+			case v := <-latitudes.C:
+				state.latitude = v
+				state.received |= roverLatitude
+			case v := <-longitudes.C:
+				state.longitude = v
+				state.received |= roverLongitude
+			case v := <-headings.C:
+				state.heading = float64(v)
+				state.received |= roverHeading
+			case v := <-pitches.C:
+				state.pitch = float64(v)
+				state.received |= roverPitch
+			case v := <-rolls.C:
+				state.roll = float64(v)
+				state.received |= roverRoll
+			case v := <-speeds.C:
+				state.speed = v
+				state.received |= roverSpeed
+			case now := <-ticker.C:
+				dt := now.Sub(last).Seconds()
+				last = now
+				if !state.located() {
+					continue
+				}
+				nav := navigate(surfacePosition{
+					radius:    float64(radius),
+					latitude:  state.latitude,
+					longitude: state.longitude,
+					heading:   state.heading,
+				}, waypoint.Latitude, waypoint.Longitude, 0)
+				if nav.Distance <= r.cfg.ArrivalRadius {
+					break driving
+				}
+				stress, err := wheelStress(wheels)
+				if err != nil {
+					return tracerr.Wrap(err)
+				}
+				slope := math.Max(math.Abs(state.pitch), math.Abs(state.roll))
+				inputs := loops.step(nav.Distance, nav.RelativeBearing, state.speed, slope, stress, dt)
+				if err := setRoverInputs(control, inputs); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+		}
+
+		// Come to a stop before calling OnArrive.
+		if err := stop(); err != nil {
+			return tracerr.Wrap(err)
+		}
+		for state.speed > roverStoppedSpeed {
+			select {
+			case <-ctx.Done():
+				return tracerr.Wrap(ctx.Err())
+			case v := <-speeds.C:
+				state.speed = v
+			}
+		}
+		if r.OnArrive != nil {
+			if err := r.OnArrive(ctx, i, waypoint); err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case <-time.After(waypoint.Pause):
+		}
+	}
+	return nil
+}
+
+func setRoverInputs(control *Control, inputs roverInputs) error {
+	if err := control.SetWheelThrottle(float32(inputs.throttle)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := control.SetWheelSteering(float32(inputs.steering)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(control.SetBrakes(inputs.brakes))
+}
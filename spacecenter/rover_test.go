@@ -0,0 +1,61 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoverSpeedLimit(t *testing.T) {
+	cfg := RoverConfig{}
+	cfg.SetDefaults()
+	tcs := []struct {
+		name     string
+		distance float64
+		slope    float64
+		stress   float64
+		expected float64
+	}{
+		{name: "flat and far", distance: 1000, expected: 10},
+		{name: "gentle slope", distance: 1000, slope: 10, expected: 10},
+		{name: "steep slope", distance: 1000, slope: 18.75, expected: 5.5},
+		{name: "too steep", distance: 1000, slope: 40, expected: 1},
+		{name: "approaching", distance: 30, expected: 5.5},
+		{name: "arriving", distance: 5, expected: 1},
+		{name: "overstressed", distance: 1000, stress: 90, expected: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.expected, roverSpeedLimit(tc.distance, tc.slope, tc.stress, cfg), 1e-9)
+		})
+	}
+}
+
+func TestRoverLoops(t *testing.T) {
+	cfg := RoverConfig{}
+	cfg.SetDefaults()
+
+	// Waypoint to the right: steer right, which is negative.
+	inputs := newRoverLoops(cfg).step(1000, 30, 0, 0, 0, 0.1)
+	require.Less(t, inputs.steering, 0.0)
+	require.Greater(t, inputs.throttle, 0.0)
+	require.False(t, inputs.brakes)
+
+	// Much too fast.
+	inputs = newRoverLoops(cfg).step(1000, 0, 20, 0, 0, 0.1)
+	require.Less(t, inputs.throttle, 0.0)
+	require.True(t, inputs.brakes)
+
+	inputs = newRoverLoops(cfg).step(1000, 0, 5, 0, 95, 0.1)
+	require.Equal(t, roverInputs{brakes: true}, inputs)
+}
+
+func TestRoverStateLocated(t *testing.T) {
+	// Knowing the latitude alone isn't enough to navigate.
+	state := roverState{latitude: 1, received: roverLatitude}
+	require.False(t, state.located())
+	state.received |= roverLongitude | roverHeading | roverPitch | roverRoll
+	require.False(t, state.located())
+	state.received |= roverSpeed
+	require.True(t, state.located())
+}
@@ -0,0 +1,164 @@
+package spacecenter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// reservedSaves are the saves the game manages itself: the autosave, and
+// the quicksave that F5 overwrites.
+var reservedSaves = map[string]struct{}{
+	"persistent": {},
+	"quicksave":  {},
+}
+
+// ScenariosConfig configures Scenarios.
+type ScenariosConfig struct {
+	// Dir is the folder of the current save game, e.g.
+	// "Kerbal Space Program/saves/default", for listing scenarios. kRPC
+	// can't list saves, so without it List fails and Restore can't check
+	// that a scenario exists before loading it.
+	Dir string
+	// Prefix is added to the names of scenario saves, to tell them apart
+	// from the player's saves. Defaults to "scenario-".
+	Prefix string
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *ScenariosConfig) SetDefaults() {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "scenario-"
+	}
+}
+
+// Scenario is a saved scenario.
+type Scenario struct {
+	// Name is the scenario's name, without the prefix.
+	Name string
+	// Saved is when the scenario was last saved.
+	Saved time.Time
+}
+
+// Scenarios saves and restores named game saves, so that a complex
+// situation, such as a vessel in orbit that is low on fuel, can be set up
+// once and restored by each test or script that needs it:
+//
+//	scenarios := spacecenter.NewScenarios(sc, spacecenter.ScenariosConfig{Dir: dir})
+//	err := scenarios.Save("low-fuel-orbit")
+//	...
+//	err = scenarios.Restore("low-fuel-orbit")
+type Scenarios struct {
+	sc   *SpaceCenter
+	cfg  ScenariosConfig
+	save func(name string) error
+	load func(name string) error
+}
+
+// NewScenarios creates a new Scenarios.
+func NewScenarios(sc *SpaceCenter, cfg ScenariosConfig) *Scenarios {
+	cfg.SetDefaults()
+	return &Scenarios{sc: sc, cfg: cfg, save: sc.Save, load: sc.Load}
+}
+
+// saveName returns the name of a scenario's save. Names are used as file
+// names by the game, so they can't contain path separators, and can't be
+// the names of saves the game manages itself.
+func (s *Scenarios) saveName(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".sfs")
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:*?"<>|`) {
+		return "", tracerr.Errorf("Invalid scenario name %q", name)
+	}
+	saveName := s.cfg.Prefix + name
+	if _, ok := reservedSaves[strings.ToLower(saveName)]; ok {
+		return "", tracerr.Errorf("Scenario name %q is reserved by the game", name)
+	}
+	return saveName, nil
+}
+
+// Save saves the game as a scenario, replacing any with the same name.
+func (s *Scenarios) Save(name string) error {
+	saveName, err := s.saveName(name)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(s.save(saveName))
+}
+
+// Exists checks if a scenario has been saved. It needs the config's Dir.
+func (s *Scenarios) Exists(name string) (bool, error) {
+	saveName, err := s.saveName(name)
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	if s.cfg.Dir == "" {
+		return false, tracerr.Errorf("Checking for scenarios needs the save game's folder")
+	}
+	_, err = os.Stat(filepath.Join(s.cfg.Dir, saveName+".sfs"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, tracerr.Wrap(err)
+}
+
+// Restore loads a scenario. If the config has a Dir, a scenario that
+// doesn't exist is an error, rather than the game quietly not loading it.
+func (s *Scenarios) Restore(name string) error {
+	saveName, err := s.saveName(name)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if s.cfg.Dir != "" {
+		ok, err := s.Exists(name)
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if !ok {
+			return tracerr.Errorf("Scenario %q doesn't exist", name)
+		}
+	}
+	return tracerr.Wrap(s.load(saveName))
+}
+
+// RestoreAndResolve restores a scenario, then waits for the game to settle
+// and finds vessels again, as SpaceCenter.LoadAndResolve does.
+func (s *Scenarios) RestoreAndResolve(ctx context.Context, name string, cfg ReloadConfig, vessels ...*Vessel) (*Reload, error) {
+	r, err := s.sc.LoadAndResolve(ctx, func() error { return s.Restore(name) }, cfg, vessels...)
+	return r, tracerr.Wrap(err)
+}
+
+// List lists the saved scenarios, sorted by name. It needs the config's
+// Dir.
+func (s *Scenarios) List() ([]Scenario, error) {
+	if s.cfg.Dir == "" {
+		return nil, tracerr.Errorf("Listing scenarios needs the save game's folder")
+	}
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var scenarios []Scenario
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, s.cfg.Prefix) || !strings.HasSuffix(name, ".sfs") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		scenarios = append(scenarios, Scenario{
+			Name:  strings.TrimSuffix(strings.TrimPrefix(name, s.cfg.Prefix), ".sfs"),
+			Saved: info.ModTime(),
+		})
+	}
+	sort.Slice(scenarios, func(i, j int) bool {
+		return scenarios[i].Name < scenarios[j].Name
+	})
+	return scenarios, nil
+}
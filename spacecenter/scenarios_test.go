@@ -0,0 +1,65 @@
+package spacecenter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarios(t *testing.T) {
+	dir := t.TempDir()
+	var loaded []string
+	s := NewScenarios(nil, ScenariosConfig{Dir: dir})
+	s.save = func(name string) error {
+		return os.WriteFile(filepath.Join(dir, name+".sfs"), nil, 0o644)
+	}
+	s.load = func(name string) error {
+		loaded = append(loaded, name)
+		return nil
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "persistent.sfs"), nil, 0o644))
+
+	scenarios, err := s.List()
+	require.NoError(t, err)
+	require.Empty(t, scenarios)
+
+	require.NoError(t, s.Save("orbit"))
+	require.NoError(t, s.Save("low-fuel.sfs"))
+	scenarios, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, scenarios, 2)
+	require.Equal(t, "low-fuel", scenarios[0].Name)
+	require.Equal(t, "orbit", scenarios[1].Name)
+	require.False(t, scenarios[0].Saved.IsZero())
+
+	ok, err := s.Exists("orbit")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, s.Restore("orbit"))
+	require.Equal(t, []string{"scenario-orbit"}, loaded)
+
+	require.ErrorContains(t, s.Restore("landed"), "doesn't exist")
+	require.ErrorContains(t, s.Save("../persistent"), "Invalid scenario name")
+	require.ErrorContains(t, s.Save(""), "Invalid scenario name")
+}
+
+func TestScenariosWithoutDir(t *testing.T) {
+	var loaded []string
+	s := NewScenarios(nil, ScenariosConfig{Prefix: "quick"})
+	s.load = func(name string) error {
+		loaded = append(loaded, name)
+		return nil
+	}
+
+	// Without the folder, scenarios are loaded without checking for them.
+	require.NoError(t, s.Restore("orbit"))
+	require.Equal(t, []string{"quickorbit"}, loaded)
+	_, err := s.List()
+	require.Error(t, err)
+	_, err = s.Exists("orbit")
+	require.Error(t, err)
+
+	require.ErrorContains(t, s.Restore("save"), "reserved")
+}
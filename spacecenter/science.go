@@ -0,0 +1,220 @@
+package spacecenter
+
+import (
+	"context"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ScienceAction is what was done with an experiment.
+type ScienceAction int
+
+const (
+	// ScienceSkipped means the experiment wasn't run.
+	ScienceSkipped ScienceAction = iota
+	// ScienceTransmitted means the results were transmitted.
+	ScienceTransmitted
+	// ScienceStored means the results were kept on board for recovery.
+	ScienceStored
+	// ScienceDumped means the results were discarded.
+	ScienceDumped
+)
+
+// String returns the name of the action.
+func (a ScienceAction) String() string {
+	switch a {
+	case ScienceSkipped:
+		return "skipped"
+	case ScienceTransmitted:
+		return "transmitted"
+	case ScienceStored:
+		return "stored"
+	case ScienceDumped:
+		return "dumped"
+	}
+	return "unknown"
+}
+
+// SciencePolicy decides which experiments to run and what to do with the
+// results.
+type SciencePolicy struct {
+	// MinScience is the least science an experiment must be able to earn to
+	// be run.
+	MinScience float64
+	// MinTransmitRatio is the smallest fraction of an experiment's science
+	// value that transmitting must earn; otherwise the results are stored.
+	// 0 always transmits, and anything above 1 never transmits.
+	MinTransmitRatio float64
+	// DumpWorthless discards results that are worth no science, so the
+	// experiment can be run again somewhere more useful.
+	DumpWorthless bool
+	// Timeout is how long to wait for an experiment to produce data.
+	// Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// decide chooses what to do with results, given their value when recovered
+// and when transmitted.
+func (p SciencePolicy) decide(scienceValue, transmitValue float64) ScienceAction {
+	if scienceValue <= 0 {
+		if p.DumpWorthless {
+			return ScienceDumped
+		}
+		return ScienceStored
+	}
+	if transmitValue/scienceValue >= p.MinTransmitRatio {
+		return ScienceTransmitted
+	}
+	return ScienceStored
+}
+
+// ScienceResult reports what happened to an experiment.
+type ScienceResult struct {
+	Experiment *Experiment
+	// Title is the title of the experiment.
+	Title string
+	// Biome is the biome the experiment was run in.
+	Biome string
+	// Action is what was done with the experiment.
+	Action ScienceAction
+	// Reason explains why an experiment was skipped.
+	Reason string
+	// Science is the science the results are worth, if recovered or
+	// transmitted as the action says.
+	Science float64
+}
+
+// RunScience runs every experiment on a vessel that can earn science in the
+// current situation and biome, then transmits, stores or dumps the results
+// according to a policy.
+func RunScience(ctx context.Context, vessel *Vessel, policy SciencePolicy) ([]ScienceResult, error) {
+	if policy.Timeout == 0 {
+		policy.Timeout = 30 * time.Second
+	}
+	parts, err := vessel.Parts()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	experiments, err := parts.Experiments()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	var results []ScienceResult
+	for _, experiment := range experiments {
+		result, err := runExperiment(ctx, experiment, policy)
+		if err != nil {
+			return results, tracerr.Wrap(err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func runExperiment(ctx context.Context, experiment *Experiment, policy SciencePolicy) (ScienceResult, error) {
+	result := ScienceResult{Experiment: experiment}
+	var err error
+	if result.Title, err = experiment.Title(); err != nil {
+		return result, tracerr.Wrap(err)
+	}
+	skip := func(reason string) (ScienceResult, error) {
+		result.Action = ScienceSkipped
+		result.Reason = reason
+		return result, nil
+	}
+
+	if inoperable, err := experiment.Inoperable(); err != nil {
+		return result, tracerr.Wrap(err)
+	} else if inoperable {
+		return skip("inoperable")
+	}
+	if hasData, err := experiment.HasData(); err != nil {
+		return result, tracerr.Wrap(err)
+	} else if hasData {
+		return skip("already has data")
+	}
+	if available, err := experiment.Available(); err != nil {
+		return result, tracerr.Wrap(err)
+	} else if !available {
+		return skip("not available in this situation")
+	}
+	if result.Biome, err = experiment.Biome(); err != nil {
+		return result, tracerr.Wrap(err)
+	}
+
+	subject, err := experiment.ScienceSubject()
+	if err != nil {
+		return result, tracerr.Wrap(err)
+	}
+	if subject != nil {
+		science, err := subject.Science()
+		if err != nil {
+			return result, tracerr.Wrap(err)
+		}
+		scienceCap, err := subject.ScienceCap()
+		if err != nil {
+			return result, tracerr.Wrap(err)
+		}
+		if remaining := float64(scienceCap - science); remaining <= 0 || remaining < policy.MinScience {
+			return skip("not enough science left")
+		}
+	}
+
+	if err := experiment.Run(); err != nil {
+		return result, tracerr.Wrap(err)
+	}
+	if err := waitForData(ctx, experiment, policy.Timeout); err != nil {
+		return result, tracerr.Wrap(err)
+	}
+
+	data, err := experiment.Data()
+	if err != nil {
+		return result, tracerr.Wrap(err)
+	}
+	var scienceValue, transmitValue float64
+	for _, d := range data {
+		sv, err := d.ScienceValue()
+		if err != nil {
+			return result, tracerr.Wrap(err)
+		}
+		tv, err := d.TransmitValue()
+		if err != nil {
+			return result, tracerr.Wrap(err)
+		}
+		scienceValue += float64(sv)
+		transmitValue += float64(tv)
+	}
+
+	result.Action = policy.decide(scienceValue, transmitValue)
+	switch result.Action {
+	case ScienceTransmitted:
+		result.Science = transmitValue
+		err = experiment.Transmit()
+	case ScienceStored:
+		result.Science = scienceValue
+	case ScienceDumped:
+		err = experiment.Dump()
+	}
+	return result, tracerr.Wrap(err)
+}
+
+// waitForData waits for an experiment to finish running.
+func waitForData(ctx context.Context, experiment *Experiment, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	hasData, err := experiment.HasDataStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	defer hasData.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Errorf("Timed out waiting for experiment data: %v", ctx.Err())
+		case ok := <-hasData.C:
+			if ok {
+				return nil
+			}
+		}
+	}
+}
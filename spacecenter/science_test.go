@@ -0,0 +1,52 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSciencePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        SciencePolicy
+		scienceValue  float64
+		transmitValue float64
+		expected      ScienceAction
+	}{
+		{
+			name:          "always transmit",
+			scienceValue:  10,
+			transmitValue: 2,
+			expected:      ScienceTransmitted,
+		},
+		{
+			name:          "transmit value too low",
+			policy:        SciencePolicy{MinTransmitRatio: 0.5},
+			scienceValue:  10,
+			transmitValue: 2,
+			expected:      ScienceStored,
+		},
+		{
+			name:          "transmit value high enough",
+			policy:        SciencePolicy{MinTransmitRatio: 0.5},
+			scienceValue:  10,
+			transmitValue: 10,
+			expected:      ScienceTransmitted,
+		},
+		{
+			name:     "worthless kept",
+			expected: ScienceStored,
+		},
+		{
+			name:     "worthless dumped",
+			policy:   SciencePolicy{DumpWorthless: true},
+			expected: ScienceDumped,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.policy.decide(tc.scienceValue, tc.transmitValue))
+		})
+	}
+}
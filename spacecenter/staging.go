@@ -0,0 +1,235 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// StagingConfig configures a Stager.
+type StagingConfig struct {
+	// Resources maps each fuel to the amount at or below which it counts as
+	// spent. A stage is dropped once any fuel it holds is spent.
+	Resources map[string]float64
+	// HotStagingLead stages early, this long before a fuel is predicted to
+	// run out, so the next stage's engines light before the current ones
+	// stop.
+	HotStagingLead time.Duration
+	// Delay is how long to wait between activating stages, so that debris can
+	// clear.
+	Delay time.Duration
+	// MinStage is the last stage to activate.
+	MinStage int32
+	// AllowParachutes allows activating stages containing parachutes, which
+	// are otherwise left for the reentry.
+	AllowParachutes bool
+	// PayloadTag is the tag of parts, such as payload decouplers or fairings,
+	// whose stages must not be activated.
+	PayloadTag string
+	// Interval is how often to check the fuel.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *StagingConfig) SetDefaults() {
+	if cfg.Resources == nil {
+		cfg.Resources = map[string]float64{
+			"LiquidFuel": 0.1,
+			"SolidFuel":  0.1,
+		}
+	}
+	if cfg.Delay == 0 {
+		cfg.Delay = 500 * time.Millisecond
+	}
+	if cfg.PayloadTag == "" {
+		cfg.PayloadTag = "payload"
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// fuelLevel is how much of a fuel is left in a stage.
+type fuelLevel struct {
+	amount float64
+	// rate is how fast the fuel is being used, per second.
+	rate float64
+}
+
+// stageSpent decides whether a stage's fuel is spent. Stages holding none of
+// the fuels are spent straight away.
+func stageSpent(levels map[string]fuelLevel, cfg StagingConfig) bool {
+	for name, level := range levels {
+		if level.amount <= cfg.Resources[name] {
+			return true
+		}
+		if level.rate > 0 && (level.amount-cfg.Resources[name])/level.rate <= cfg.HotStagingLead.Seconds() {
+			return true
+		}
+	}
+	return len(levels) == 0
+}
+
+// Stager activates a vessel's stages as their fuel runs out. It stops before
+// any stage containing parachutes or tagged payload parts, so that they are
+// only ever activated deliberately.
+type Stager struct {
+	vessel *Vessel
+	cfg    StagingConfig
+	// OnStage, if set, is called with the new stage after each activation.
+	OnStage func(stage int32)
+}
+
+// NewStager creates a new Stager.
+func NewStager(vessel *Vessel, cfg StagingConfig) *Stager {
+	cfg.SetDefaults()
+	return &Stager{vessel: vessel, cfg: cfg}
+}
+
+// excluded checks whether a stage must not be activated.
+func (s *Stager) excluded(stage int32) (bool, error) {
+	parts, err := s.vessel.Parts()
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	inStage, err := parts.InStage(stage)
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	for _, part := range inStage {
+		tag, err := part.Tag()
+		if err != nil {
+			return false, tracerr.Wrap(err)
+		}
+		if strings.EqualFold(tag, s.cfg.PayloadTag) {
+			return true, nil
+		}
+		if s.cfg.AllowParachutes {
+			continue
+		}
+		parachute, err := part.Parachute()
+		if err != nil {
+			return false, tracerr.Wrap(err)
+		}
+		if parachute != nil {
+			return true, nil
+		}
+	}
+	if s.cfg.AllowParachutes {
+		return false, nil
+	}
+	modules, err := parts.ModulesWithName(realChuteModule)
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	for _, module := range modules {
+		part, err := module.Part()
+		if err != nil {
+			return false, tracerr.Wrap(err)
+		}
+		partStage, err := part.Stage()
+		if err != nil {
+			return false, tracerr.Wrap(err)
+		}
+		if partStage == stage {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// amounts returns how much of each fuel is held by the parts dropped when a
+// stage is activated.
+func (s *Stager) amounts(stage int32) (map[string]float64, error) {
+	resources, err := s.vessel.ResourcesInDecoupleStage(stage, false)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	amounts := make(map[string]float64)
+	for name := range s.cfg.Resources {
+		max, err := resources.Max(name)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if max == 0 {
+			continue
+		}
+		amount, err := resources.Amount(name)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		amounts[name] = float64(amount)
+	}
+	return amounts, nil
+}
+
+// Run stages the vessel until it reaches the minimum stage or a stage that
+// mustn't be activated. It doesn't activate the first stage; launch the
+// vessel before calling it.
+func (s *Stager) Run(ctx context.Context) error {
+	control, err := s.vessel.Control()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	var last map[string]float64
+	lastTime := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case now := <-ticker.C:
+			stage, err := control.CurrentStage()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			next := stage - 1
+			if next < s.cfg.MinStage {
+				return nil
+			}
+			excluded, err := s.excluded(next)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			if excluded {
+				return nil
+			}
+
+			amounts, err := s.amounts(next)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			dt := now.Sub(lastTime).Seconds()
+			levels := make(map[string]fuelLevel, len(amounts))
+			for name, amount := range amounts {
+				level := fuelLevel{amount: amount}
+				if prev, ok := last[name]; ok && dt > 0 {
+					level.rate = math.Max(0, (prev-amount)/dt)
+				}
+				levels[name] = level
+			}
+			last, lastTime = amounts, now
+			if !stageSpent(levels, s.cfg) {
+				continue
+			}
+
+			if _, err := control.ActivateNextStage(); err != nil {
+				return tracerr.Wrap(err)
+			}
+			last = nil
+			if s.OnStage != nil {
+				s.OnStage(next)
+			}
+			select {
+			case <-ctx.Done():
+				return tracerr.Wrap(ctx.Err())
+			case <-time.After(s.cfg.Delay):
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package spacecenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageSpent(t *testing.T) {
+	cfg := StagingConfig{HotStagingLead: 2 * time.Second}
+	cfg.SetDefaults()
+	tcs := []struct {
+		name     string
+		levels   map[string]fuelLevel
+		expected bool
+	}{
+		{name: "no fuel held", levels: map[string]fuelLevel{}, expected: true},
+		{name: "full", levels: map[string]fuelLevel{"LiquidFuel": {amount: 360}}},
+		{name: "burning", levels: map[string]fuelLevel{"LiquidFuel": {amount: 360, rate: 10}}},
+		{name: "empty", levels: map[string]fuelLevel{"LiquidFuel": {amount: 0.05}}, expected: true},
+		{name: "about to run out", levels: map[string]fuelLevel{"LiquidFuel": {amount: 15, rate: 10}}, expected: true},
+		{
+			name: "boosters burnt out",
+			levels: map[string]fuelLevel{
+				"LiquidFuel": {amount: 200, rate: 5},
+				"SolidFuel":  {amount: 0},
+			},
+			expected: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, stageSpent(tc.levels, cfg))
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package spacecenter
+
+import (
+	"context"
+	"math"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// transferEpsilon is the smallest amount of a resource worth transferring.
+const transferEpsilon = 1e-3
+
+// TransferStep is a single transfer of a resource between two parts.
+type TransferStep struct {
+	From   *Part
+	To     *Part
+	Amount float32
+}
+
+// TransferPlan is a sequence of transfers of a resource.
+type TransferPlan struct {
+	Resource string
+	Steps    []TransferStep
+}
+
+// Total returns the total amount of the resource moved by the plan.
+func (p *TransferPlan) Total() float32 {
+	var total float32
+	for _, step := range p.Steps {
+		total += step.Amount
+	}
+	return total
+}
+
+// TransferProgress reports the progress of a transfer plan.
+type TransferProgress struct {
+	// Step is the index of the step in progress.
+	Step int
+	// StepAmount is the amount transferred so far in the current step.
+	StepAmount float32
+	// Total is the amount transferred so far by the whole plan.
+	Total float32
+}
+
+// tankLevel is the amount of a resource in a part.
+type tankLevel struct {
+	part   *Part
+	amount float64
+	max    float64
+}
+
+// ResourceTransfers plans and runs resource transfers between parts.
+type ResourceTransfers struct {
+	client *krpcgo.KRPCClient
+	// OnProgress, if set, is called as transfers progress.
+	OnProgress func(TransferProgress)
+}
+
+// NewResourceTransfers creates a new ResourceTransfers.
+func NewResourceTransfers(client *krpcgo.KRPCClient) *ResourceTransfers {
+	return &ResourceTransfers{client: client}
+}
+
+// levels gets the amount of a resource in each part.
+func (t *ResourceTransfers) levels(resource string, parts []*Part) ([]tankLevel, error) {
+	levels := make([]tankLevel, len(parts))
+	for i, part := range parts {
+		resources, err := part.Resources()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		amount, err := resources.Amount(resource)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		max, err := resources.Max(resource)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		levels[i] = tankLevel{part: part, amount: float64(amount), max: float64(max)}
+	}
+	return levels, nil
+}
+
+// PlanMove plans moving a resource out of one set of parts and into another,
+// emptying sources and filling destinations in order. If amount is zero or
+// less, as much as possible is moved.
+func (t *ResourceTransfers) PlanMove(resource string, from, to []*Part, amount float32) (*TransferPlan, error) {
+	sources, err := t.levels(resource, from)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	destinations, err := t.levels(resource, to)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	limit := math.Inf(1)
+	if amount > 0 {
+		limit = float64(amount)
+	}
+	return &TransferPlan{Resource: resource, Steps: planMove(sources, destinations, limit)}, nil
+}
+
+// PlanBalance plans transfers that leave every part equally full (as a
+// fraction of its capacity), e.g. to balance fuel across symmetric tanks.
+func (t *ResourceTransfers) PlanBalance(resource string, parts []*Part) (*TransferPlan, error) {
+	levels, err := t.levels(resource, parts)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return &TransferPlan{Resource: resource, Steps: planBalance(levels)}, nil
+}
+
+// planMove matches sources with destinations greedily.
+func planMove(sources, destinations []tankLevel, limit float64) []TransferStep {
+	available := make([]float64, len(sources))
+	for i, s := range sources {
+		available[i] = s.amount
+	}
+	space := make([]float64, len(destinations))
+	for i, d := range destinations {
+		space[i] = d.max - d.amount
+	}
+
+	var steps []TransferStep
+	for i := range sources {
+		for j := range destinations {
+			if limit <= transferEpsilon || available[i] <= transferEpsilon {
+				break
+			}
+			// Separate handles can refer to the same part, so compare IDs.
+			if space[j] <= transferEpsilon || destinations[j].part.ID_internal() == sources[i].part.ID_internal() {
+				continue
+			}
+			amount := math.Min(limit, math.Min(available[i], space[j]))
+			steps = append(steps, TransferStep{
+				From:   sources[i].part,
+				To:     destinations[j].part,
+				Amount: float32(amount),
+			})
+			available[i] -= amount
+			space[j] -= amount
+			limit -= amount
+		}
+	}
+	return steps
+}
+
+// planBalance moves the excess from fuller parts into emptier ones.
+func planBalance(levels []tankLevel) []TransferStep {
+	var total, capacity float64
+	for _, l := range levels {
+		total += l.amount
+		capacity += l.max
+	}
+	if capacity == 0 {
+		return nil
+	}
+	fill := total / capacity
+
+	var sources, destinations []tankLevel
+	for _, l := range levels {
+		target := fill * l.max
+		switch diff := l.amount - target; {
+		case diff > transferEpsilon:
+			// Only offer the excess.
+			sources = append(sources, tankLevel{part: l.part, amount: diff})
+		case diff < -transferEpsilon:
+			// Only accept the shortfall.
+			destinations = append(destinations, tankLevel{part: l.part, max: -diff})
+		}
+	}
+	return planMove(sources, destinations, math.Inf(1))
+}
+
+// Execute runs each step of a plan in turn, waiting for each transfer to
+// complete before starting the next.
+func (t *ResourceTransfers) Execute(ctx context.Context, plan *TransferPlan) error {
+	starter := NewResourceTransfer(0, t.client)
+	var total float32
+	for i, step := range plan.Steps {
+		transfer, err := starter.Start(step.From, step.To, plan.Resource, step.Amount)
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if transfer == nil {
+			return tracerr.Errorf("Failed to start transfer of %v", plan.Resource)
+		}
+		amount, err := t.wait(ctx, transfer, func(amount float32) {
+			if t.OnProgress != nil {
+				t.OnProgress(TransferProgress{Step: i, StepAmount: amount, Total: total + amount})
+			}
+		})
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		total += amount
+	}
+	return nil
+}
+
+// wait waits for a transfer to complete, returning the amount transferred.
+func (t *ResourceTransfers) wait(ctx context.Context, transfer *ResourceTransfer, progress func(float32)) (float32, error) {
+	complete, err := transfer.CompleteStream()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	defer complete.Close()
+	amounts, err := transfer.AmountStream()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	defer amounts.Close()
+
+	var amount float32
+	for {
+		select {
+		case <-ctx.Done():
+			return amount, tracerr.Wrap(ctx.Err())
+		case amount = <-amounts.C:
+			progress(amount)
+		case done := <-complete.C:
+			if !done {
+				continue
+			}
+			// The final amount may not have been streamed yet.
+			final, err := transfer.Amount()
+			if err != nil {
+				return amount, tracerr.Wrap(err)
+			}
+			progress(final)
+			return final, nil
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package spacecenter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBalance(t *testing.T) {
+	left, right, center := NewPart(1, nil), NewPart(2, nil), NewPart(3, nil)
+	tests := []struct {
+		name     string
+		levels   []tankLevel
+		expected []TransferStep
+	}{
+		{
+			name: "already balanced",
+			levels: []tankLevel{
+				{part: left, amount: 50, max: 100},
+				{part: right, amount: 50, max: 100},
+			},
+		},
+		{
+			name: "symmetric pair",
+			levels: []tankLevel{
+				{part: left, amount: 80, max: 100},
+				{part: right, amount: 20, max: 100},
+			},
+			expected: []TransferStep{{From: left, To: right, Amount: 30}},
+		},
+		{
+			name: "different sizes",
+			levels: []tankLevel{
+				{part: left, amount: 100, max: 100},
+				{part: right, amount: 0, max: 100},
+				{part: center, amount: 0, max: 200},
+			},
+			expected: []TransferStep{
+				{From: left, To: right, Amount: 25},
+				{From: left, To: center, Amount: 50},
+			},
+		},
+		{
+			name: "empty tanks",
+			levels: []tankLevel{
+				{part: left, max: 0},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, planBalance(tc.levels))
+		})
+	}
+}
+
+func TestPlanMove(t *testing.T) {
+	a, b, c := NewPart(1, nil), NewPart(2, nil), NewPart(3, nil)
+	sources := []tankLevel{
+		{part: a, amount: 30, max: 100},
+		{part: b, amount: 50, max: 100},
+	}
+	destinations := []tankLevel{
+		{part: c, amount: 60, max: 100},
+		{part: b, amount: 50, max: 100},
+	}
+
+	steps := planMove(sources, destinations, math.Inf(1))
+	require.Equal(t, []TransferStep{
+		{From: a, To: c, Amount: 30},
+		{From: b, To: c, Amount: 10},
+	}, steps)
+
+	steps = planMove(sources, destinations, 20)
+	require.Equal(t, []TransferStep{{From: a, To: c, Amount: 20}}, steps)
+}
+
+func TestPlanMoveSamePart(t *testing.T) {
+	a, b, c := NewPart(1, nil), NewPart(2, nil), NewPart(3, nil)
+	// A different handle for the same part as b.
+	b2 := NewPart(2, nil)
+	sources := []tankLevel{
+		{part: b, amount: 50, max: 100},
+		{part: a, amount: 30, max: 100},
+	}
+	destinations := []tankLevel{
+		{part: b2, amount: 50, max: 100},
+		{part: c, amount: 90, max: 100},
+	}
+
+	// b can't move into itself, but a still can.
+	steps := planMove(sources, destinations, math.Inf(1))
+	require.Equal(t, []TransferStep{
+		{From: b, To: c, Amount: 10},
+		{From: a, To: b2, Amount: 30},
+	}, steps)
+}
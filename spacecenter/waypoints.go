@@ -0,0 +1,194 @@
+package spacecenter
+
+import (
+	"github.com/atburke/krpc-go/units"
+	"github.com/ztrue/tracerr"
+)
+
+// WaypointInfo is a snapshot of a waypoint.
+type WaypointInfo struct {
+	Waypoint *Waypoint
+
+	Name      string
+	Body      string
+	Latitude  float64
+	Longitude float64
+	// MeanAltitude is the altitude above sea level, in meters.
+	MeanAltitude float64
+}
+
+// Info gets a snapshot of a waypoint.
+func (s *Waypoint) Info() (WaypointInfo, error) {
+	info := WaypointInfo{Waypoint: s}
+	var err error
+	if info.Name, err = s.Name(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	body, err := s.Body()
+	if err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Body, err = body.Name(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Latitude, err = s.Latitude(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.Longitude, err = s.Longitude(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	if info.MeanAltitude, err = s.MeanAltitude(); err != nil {
+		return info, tracerr.Wrap(err)
+	}
+	return info, nil
+}
+
+// List gets snapshots of every waypoint.
+func (s *WaypointManager) List() ([]WaypointInfo, error) {
+	waypoints, err := s.Waypoints()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	infos := make([]WaypointInfo, len(waypoints))
+	for i, w := range waypoints {
+		if infos[i], err = w.Info(); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+	return infos, nil
+}
+
+// Find gets the first waypoint with a name, or nil if there is none.
+func (s *WaypointManager) Find(name string) (*Waypoint, error) {
+	waypoints, err := s.Waypoints()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	for _, w := range waypoints {
+		n, err := w.Name()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		if n == name {
+			return w, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddWaypointAhead creates a waypoint a distance (in meters) away from a
+// vessel along a bearing (in degrees from north), on the body the vessel is
+// orbiting. This is handy for setting up rover and aircraft routes.
+func (s *WaypointManager) AddWaypointAhead(vessel *Vessel, bearing, distance float64, name string) (*Waypoint, error) {
+	pos, err := vesselPosition(vessel)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	lat, lon := units.DestinationPoint(pos.latitude, pos.longitude, bearing, distance, pos.radius)
+	w, err := s.AddWaypoint(lat, lon, pos.body, name)
+	return w, tracerr.Wrap(err)
+}
+
+// Navigation is the course from a vessel to a point on the surface.
+type Navigation struct {
+	// Distance is the great-circle distance along the surface, in meters.
+	Distance float64
+	// Bearing is the initial bearing to the point, in degrees clockwise from
+	// north.
+	Bearing float64
+	// RelativeBearing is the bearing relative to the vessel's heading, in
+	// degrees between -180 and 180. Negative values are to the left.
+	RelativeBearing float64
+	// AltitudeDifference is how far the point is above the vessel, in meters.
+	AltitudeDifference float64
+}
+
+// surfacePosition is where a vessel is over its body.
+type surfacePosition struct {
+	body      *CelestialBody
+	radius    float64
+	latitude  float64
+	longitude float64
+	altitude  float64
+	heading   float64
+}
+
+func vesselPosition(vessel *Vessel) (surfacePosition, error) {
+	var pos surfacePosition
+	orbit, err := vessel.Orbit()
+	if err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	if pos.body, err = orbit.Body(); err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	radius, err := pos.body.EquatorialRadius()
+	if err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	pos.radius = float64(radius)
+	rf, err := vessel.SurfaceReferenceFrame()
+	if err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	flight, err := vessel.Flight(rf)
+	if err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	if pos.latitude, err = flight.Latitude(); err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	if pos.longitude, err = flight.Longitude(); err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	if pos.altitude, err = flight.MeanAltitude(); err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	heading, err := flight.Heading()
+	if err != nil {
+		return pos, tracerr.Wrap(err)
+	}
+	pos.heading = float64(heading)
+	return pos, nil
+}
+
+// NavigateTo gets the course from a vessel to a point on the body it is
+// orbiting.
+func NavigateTo(vessel *Vessel, latitude, longitude, altitude float64) (Navigation, error) {
+	pos, err := vesselPosition(vessel)
+	if err != nil {
+		return Navigation{}, tracerr.Wrap(err)
+	}
+	return navigate(pos, latitude, longitude, altitude), nil
+}
+
+// NavigationFrom gets the course from a vessel to the waypoint. The vessel
+// must be orbiting the same body as the waypoint.
+func (s *Waypoint) NavigationFrom(vessel *Vessel) (Navigation, error) {
+	pos, err := vesselPosition(vessel)
+	if err != nil {
+		return Navigation{}, tracerr.Wrap(err)
+	}
+	body, err := s.Body()
+	if err != nil {
+		return Navigation{}, tracerr.Wrap(err)
+	}
+	if body.ID_internal() != pos.body.ID_internal() {
+		return Navigation{}, tracerr.Errorf("Waypoint is on a different body from the vessel")
+	}
+	info, err := s.Info()
+	if err != nil {
+		return Navigation{}, tracerr.Wrap(err)
+	}
+	return navigate(pos, info.Latitude, info.Longitude, info.MeanAltitude), nil
+}
+
+func navigate(pos surfacePosition, latitude, longitude, altitude float64) Navigation {
+	bearing := units.InitialBearing(pos.latitude, pos.longitude, latitude, longitude)
+	return Navigation{
+		Distance:           units.GreatCircleDistance(pos.latitude, pos.longitude, latitude, longitude, pos.radius),
+		Bearing:            bearing,
+		RelativeBearing:    units.RelativeBearing(pos.heading, bearing),
+		AltitudeDifference: altitude - pos.altitude,
+	}
+}
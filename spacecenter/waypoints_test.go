@@ -0,0 +1,21 @@
+package spacecenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// kerbinRadius is the equatorial radius of Kerbin, in meters.
+const kerbinRadius = 600000
+
+func TestNavigate(t *testing.T) {
+	pos := surfacePosition{radius: kerbinRadius, heading: 350, altitude: 70}
+	nav := navigate(pos, 0, 1, 100)
+	require.InDelta(t, 90, nav.Bearing, 1e-9)
+	require.InDelta(t, 100, nav.RelativeBearing, 1e-9)
+	require.InDelta(t, 30, nav.AltitudeDifference, 1e-9)
+
+	pos.heading = 100
+	require.InDelta(t, -10, navigate(pos, 0, 1, 0).RelativeBearing, 1e-9)
+}
@@ -18,39 +18,52 @@ import (
 // StreamClient is a client for kRPC streams.
 type StreamClient struct {
 	sync.RWMutex
-	conn    net.Conn
+	conn    io.ReadWriteCloser
 	streams map[uint64]*streamManager
+	// ids maps the server's ids for streams to the ids the client knows
+	// them by, where they differ after switching servers.
+	ids map[uint64]uint64
 }
 
 // NewStreamClient creates a new stream client with an existing connection.
-func NewStreamClient(conn net.Conn) *StreamClient {
+func NewStreamClient(conn io.ReadWriteCloser) *StreamClient {
 	return &StreamClient{
 		conn:    conn,
 		streams: make(map[uint64]*streamManager),
 	}
 }
 
+// connection returns the current connection to the stream server.
+func (s *StreamClient) connection() io.ReadWriteCloser {
+	s.RLock()
+	defer s.RUnlock()
+	return s.conn
+}
+
 // Close closes the stream client.
 func (s *StreamClient) Close() error {
-	return tracerr.Wrap(s.conn.Close())
+	return tracerr.Wrap(s.connection().Close())
 }
 
 // Send sends protobuf-encoded data to a stream server.
 func (s *StreamClient) Send(data []byte) error {
-	return tracerr.Wrap(send(s.conn, data))
+	return tracerr.Wrap(send(s.connection(), data))
 }
 
 // Receive receives protobuf-encoded data from a stream server.
 func (s *StreamClient) Receive() ([]byte, error) {
-	data, err := receive(s.conn)
+	data, err := receive(s.connection())
 	return data, tracerr.Wrap(err)
 }
 
-// Run starts the stream handler.
+// Run starts the stream handler. It handles the connection the client has
+// when it starts, so after switching servers it must be run again.
 func (s *StreamClient) Run(ctx context.Context) {
+	conn := s.connection()
 	for {
-		data, err := s.Receive()
-		if errors.Is(err, io.EOF) {
+		data, err := receive(conn)
+		// The server closed the connection, or the client was closed.
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, os.ErrClosed) {
 			return
 		}
 		if err != nil {
@@ -62,18 +75,53 @@ func (s *StreamClient) Run(ctx context.Context) {
 			fmt.Fprintf(os.Stderr, "Error unmarshaling stream result: %v\n", err)
 		}
 		for _, result := range streamUpdate.Results {
-			s.WriteToStream(result.Id, result.Result.Value)
+			s.WriteToStream(s.localID(result.Id), result.Result.Value)
 		}
 
 		select {
 		case <-ctx.Done():
-			s.Close()
+			conn.Close()
 			return
 		default:
 		}
 	}
 }
 
+// swap replaces the client's connection, and the map from the server's
+// stream ids to the client's, returning the old connection.
+func (s *StreamClient) swap(conn io.ReadWriteCloser, ids map[uint64]uint64) io.ReadWriteCloser {
+	s.Lock()
+	defer s.Unlock()
+	old := s.conn
+	s.conn, s.ids = conn, ids
+	return old
+}
+
+// mapID records that the server knows a stream by a different id from the
+// client, or forgets that it does if the ids are the same.
+func (s *StreamClient) mapID(server, local uint64) {
+	s.Lock()
+	defer s.Unlock()
+	if server == local {
+		delete(s.ids, server)
+		return
+	}
+	if s.ids == nil {
+		s.ids = make(map[uint64]uint64)
+	}
+	s.ids[server] = local
+}
+
+// localID returns the id the client knows a stream by, given the server's.
+func (s *StreamClient) localID(server uint64) uint64 {
+	s.RLock()
+	defer s.RUnlock()
+	if local, ok := s.ids[server]; ok {
+		return local
+	}
+	return server
+}
+
 func (s *StreamClient) getStreamManager(id uint64) *streamManager {
 	s.RLock()
 	sm, ok := s.streams[id]
@@ -0,0 +1,328 @@
+package krpcgo
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/atburke/krpc-go/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/ztrue/tracerr"
+)
+
+// trackedStream is a stream added through the client.
+type trackedStream struct {
+	// call is the encoded procedure call the stream is of.
+	call    []byte
+	started bool
+	// rate is the encoded update rate set for the stream, if one was.
+	rate []byte
+	// server is the id the server knows the stream by.
+	server uint64
+}
+
+// hasStreamCalls checks if a batch of calls adds or changes any streams.
+func hasStreamCalls(calls []*types.ProcedureCall) bool {
+	for _, call := range calls {
+		if call.Service != "KRPC" {
+			continue
+		}
+		switch call.Procedure {
+		case "AddStream", "StartStream", "SetStreamRate", "RemoveStream":
+			return true
+		}
+	}
+	return false
+}
+
+// streamID decodes the id of the stream a call is for.
+func streamID(call *types.ProcedureCall) (uint64, bool) {
+	for _, arg := range call.Arguments {
+		if arg.Position == 0 {
+			id, n := proto.DecodeVarint(arg.Value)
+			return id, n > 0
+		}
+	}
+	return 0, false
+}
+
+// toServerIDs returns a batch of calls with the ids of any streams they are
+// for changed to those the server knows them by. The streams mutex must be
+// held.
+func (c *KRPCClient) toServerIDs(calls []*types.ProcedureCall) []*types.ProcedureCall {
+	sent := make([]*types.ProcedureCall, len(calls))
+	for i, call := range calls {
+		sent[i] = call
+		if call.Service != "KRPC" || call.Procedure == "AddStream" {
+			continue
+		}
+		id, ok := streamID(call)
+		if !ok {
+			continue
+		}
+		if st, ok := c.tracked[id]; ok && st.server != id {
+			changed := &types.ProcedureCall{Service: call.Service, Procedure: call.Procedure}
+			for _, arg := range call.Arguments {
+				if arg.Position == 0 {
+					arg = &types.Argument{Position: 0, Value: proto.EncodeVarint(st.server)}
+				}
+				changed.Arguments = append(changed.Arguments, arg)
+			}
+			sent[i] = changed
+		}
+	}
+	return sent
+}
+
+// trackStreams records the streams a batch of calls added, started and
+// removed. Streams the server knows by an id the client already uses for
+// another are given a new id, which their results are changed to. The
+// streams mutex must be held.
+func (c *KRPCClient) trackStreams(calls []*types.ProcedureCall, results []*types.ProcedureResult) error {
+	if c.tracked == nil {
+		c.tracked = make(map[uint64]*trackedStream)
+	}
+	for i, call := range calls {
+		if call.Service != "KRPC" || i >= len(results) || results[i].Error != nil {
+			continue
+		}
+		switch call.Procedure {
+		case "AddStream":
+			var st types.Stream
+			if err := proto.Unmarshal(results[i].Value, &st); err != nil {
+				return tracerr.Wrap(err)
+			}
+			local, tracked := c.localStreamID(st.Id)
+			if tracked == nil {
+				tracked = &trackedStream{server: st.Id}
+				for _, arg := range call.Arguments {
+					switch arg.Position {
+					case 0:
+						tracked.call = arg.Value
+					case 1:
+						tracked.started = decodeBool(arg.Value)
+					}
+				}
+				c.tracked[local] = tracked
+			}
+			if local != st.Id {
+				b, err := proto.Marshal(&types.Stream{Id: local})
+				if err != nil {
+					return tracerr.Wrap(err)
+				}
+				results[i].Value = b
+				if c.StreamClient != nil {
+					c.StreamClient.mapID(st.Id, local)
+				}
+			}
+		case "StartStream":
+			if id, ok := streamID(call); ok && c.tracked[id] != nil {
+				c.tracked[id].started = true
+			}
+		case "SetStreamRate":
+			id, ok := streamID(call)
+			if !ok || c.tracked[id] == nil {
+				continue
+			}
+			for _, arg := range call.Arguments {
+				if arg.Position == 1 {
+					c.tracked[id].rate = arg.Value
+				}
+			}
+		case "RemoveStream":
+			id, ok := streamID(call)
+			if !ok || c.tracked[id] == nil {
+				continue
+			}
+			if c.StreamClient != nil {
+				server := c.tracked[id].server
+				c.StreamClient.mapID(server, server)
+			}
+			delete(c.tracked, id)
+		}
+	}
+	return nil
+}
+
+// localStreamID returns the id for the client to know a stream by, given
+// the server's, and the tracked stream if it is one the client already
+// knows about, as the server gives the same stream for the same call. The
+// streams mutex must be held.
+func (c *KRPCClient) localStreamID(server uint64) (uint64, *trackedStream) {
+	for local, st := range c.tracked {
+		if st.server == server {
+			return local, st
+		}
+	}
+	if _, used := c.tracked[server]; !used {
+		return server, nil
+	}
+	// The server counts up from 1, so count down from the top to stay out
+	// of its way.
+	if c.nextStreamID == 0 {
+		c.nextStreamID = math.MaxUint64
+	}
+	for {
+		id := c.nextStreamID
+		c.nextStreamID--
+		if _, used := c.tracked[id]; !used {
+			return id, nil
+		}
+	}
+}
+
+// OnSwitch registers a function to be called after the client has switched
+// servers, for refreshing state that doesn't carry over. Hooks are called
+// in order of registration, and may make calls.
+func (c *KRPCClient) OnSwitch(f func()) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.switchHooks = append(c.switchHooks, f)
+}
+
+// Switch connects the client to a different server, or to the same one
+// again after the game has restarted, so that service structs created with
+// it needn't be rewired to a new client. The streams added through the
+// client are added again on the new server, and keep their ids and
+// channels, then OnSwitch hooks are called. Objects such as vessels have
+// different ids on a new server, so they should be looked up again. If
+// switching fails, the client is left disconnected, and Switch can be
+// called again.
+func (c *KRPCClient) Switch(host, rpcPort, streamPort string) error {
+	if err := c.reconnect(host, rpcPort, streamPort); err != nil {
+		return tracerr.Wrap(err)
+	}
+	c.hooksMu.Lock()
+	hooks := c.switchHooks
+	c.hooksMu.Unlock()
+	for _, f := range hooks {
+		f()
+	}
+	return nil
+}
+
+// reconnect replaces the client's connections with ones to a server, and
+// adds the tracked streams to it.
+func (c *KRPCClient) reconnect(host, rpcPort, streamPort string) error {
+	c.connectMu.Lock()
+	connected, closed := c.connected, c.closed
+	c.connectMu.Unlock()
+	if closed {
+		return tracerr.Errorf("Client is closed")
+	}
+	if !connected {
+		c.mu.Lock()
+		c.Host, c.RPCPort, c.StreamPort = host, rpcPort, streamPort
+		c.mu.Unlock()
+		if c.Lazy {
+			return nil
+		}
+		return tracerr.Wrap(c.Connect(context.Background()))
+	}
+
+	// Hold off calls that change streams until they are all added again.
+	c.streamsMu.Lock()
+	defer c.streamsMu.Unlock()
+	c.mu.Lock()
+	c.Host, c.RPCPort, c.StreamPort = host, rpcPort, streamPort
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	err := c.connectRPC(context.Background())
+	c.mu.Unlock()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if c.StreamClient == nil {
+		return nil
+	}
+
+	conn, err := c.dialStream(context.Background())
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	// The streams are added stopped, and only have their rates set and are
+	// started once their updates can be told apart by id.
+	locals := make([]uint64, 0, len(c.tracked))
+	for local := range c.tracked {
+		locals = append(locals, local)
+	}
+	sort.Slice(locals, func(i, j int) bool { return locals[i] < locals[j] })
+	calls := make([]*types.ProcedureCall, len(locals))
+	for i, local := range locals {
+		calls[i] = &types.ProcedureCall{
+			Service:   "KRPC",
+			Procedure: "AddStream",
+			Arguments: []*types.Argument{
+				{Position: 0, Value: c.tracked[local].call},
+				{Position: 1, Value: proto.EncodeVarint(0)},
+			},
+		}
+	}
+	var results []*types.ProcedureResult
+	if len(calls) > 0 {
+		if results, err = c.roundTrip(calls); err != nil {
+			conn.Close()
+			return tracerr.Wrap(err)
+		}
+		if len(results) != len(calls) {
+			conn.Close()
+			return tracerr.Errorf("Expected %v results, got %v", len(calls), len(results))
+		}
+	}
+	ids := make(map[uint64]uint64)
+	var start []*types.ProcedureCall
+	for i, local := range locals {
+		if results[i].Error != nil {
+			conn.Close()
+			return tracerr.Wrap(results[i].Error)
+		}
+		var st types.Stream
+		if err := proto.Unmarshal(results[i].Value, &st); err != nil {
+			conn.Close()
+			return tracerr.Wrap(err)
+		}
+		tracked := c.tracked[local]
+		tracked.server = st.Id
+		if st.Id != local {
+			ids[st.Id] = local
+		}
+		if tracked.rate != nil {
+			start = append(start, &types.ProcedureCall{
+				Service:   "KRPC",
+				Procedure: "SetStreamRate",
+				Arguments: []*types.Argument{
+					{Position: 0, Value: proto.EncodeVarint(st.Id)},
+					{Position: 1, Value: tracked.rate},
+				},
+			})
+		}
+		if tracked.started {
+			start = append(start, &types.ProcedureCall{
+				Service:   "KRPC",
+				Procedure: "StartStream",
+				Arguments: []*types.Argument{{Position: 0, Value: proto.EncodeVarint(st.Id)}},
+			})
+		}
+	}
+	c.StreamClient.swap(conn, ids).Close()
+	ctx := c.runCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go c.StreamClient.Run(ctx)
+
+	if len(start) == 0 {
+		return nil
+	}
+	results, err = c.roundTrip(start)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return tracerr.Wrap(result.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package krpcgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpc"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+// receive waits for a value from a stream.
+func receive[T any](t *testing.T, s *krpcgo.Stream[T]) T {
+	t.Helper()
+	select {
+	case v := <-s.C:
+		return v
+	case <-time.After(time.Second):
+		require.FailNow(t, "no stream update")
+	}
+	var zero T
+	return zero
+}
+
+func TestSwitch(t *testing.T) {
+	first := krpctest.NewServer()
+	defer first.Close()
+	first.Return("SpaceCenter", "get_UT", 1.0)
+	first.Return("SpaceCenter", "get_WarpRate", float32(1))
+	client, err := first.Client(context.Background())
+	require.NoError(t, err)
+	defer client.Close()
+	switched := 0
+	client.OnSwitch(func() { switched++ })
+
+	sc := spacecenter.New(client)
+	warp, err := sc.WarpRateStream()
+	require.NoError(t, err)
+	ut, err := sc.UTStream()
+	require.NoError(t, err)
+	require.NoError(t, warp.Close())
+	require.NoError(t, krpc.New(client).SetStreamRate(ut.ID, 5))
+	require.Equal(t, []float32{5}, first.StreamRates("SpaceCenter", "get_UT"))
+	first.Feed("SpaceCenter", "get_UT", 2.0)
+	require.Equal(t, 2.0, receive(t, ut))
+
+	second := krpctest.NewServer()
+	defer second.Close()
+	second.Return("SpaceCenter", "get_UT", 10.0)
+	second.Return("SpaceCenter", "get_WarpRate", float32(4))
+	require.NoError(t, second.Start())
+	cfg := second.ClientConfig()
+	require.NoError(t, client.Switch(cfg.Host, cfg.RPCPort, cfg.StreamPort))
+	require.Equal(t, 1, switched)
+
+	// The UT stream has a different id on the second server, but keeps its
+	// id, channel and rate.
+	require.Equal(t, []float32{5}, second.StreamRates("SpaceCenter", "get_UT"))
+	value, err := sc.UT()
+	require.NoError(t, err)
+	require.Equal(t, 10.0, value)
+	second.Feed("SpaceCenter", "get_UT", 11.0)
+	require.Equal(t, 11.0, receive(t, ut))
+
+	// A new stream that the server gives the UT stream's old id gets
+	// another one, and removing the UT stream leaves it alone.
+	warp, err = sc.WarpRateStream()
+	require.NoError(t, err)
+	require.NotEqual(t, ut.ID, warp.ID)
+	require.NoError(t, ut.Close())
+	second.Feed("SpaceCenter", "get_WarpRate", float32(8))
+	require.Equal(t, float32(8), receive(t, warp))
+
+	require.NoError(t, client.Close())
+	require.Error(t, client.Switch(cfg.Host, cfg.RPCPort, cfg.StreamPort))
+}
@@ -0,0 +1,236 @@
+package telemetry
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`)
+)
+
+// appendLine appends a record in InfluxDB line protocol, with the vessel and
+// phase as tags and the UT and channels as fields. Values that aren't
+// numbers are left out, since InfluxDB can't store them, and a record with
+// no values is skipped.
+func appendLine(b []byte, measurement string, meta Metadata, rec Record) []byte {
+	line := []byte(measurementEscaper.Replace(measurement))
+	for _, tag := range [][2]string{{"vessel", meta.Vessel}, {"phase", rec.Phase}} {
+		if tag[1] != "" {
+			line = append(line, ',')
+			line = append(line, tag[0]...)
+			line = append(line, '=')
+			line = append(line, keyEscaper.Replace(tag[1])...)
+		}
+	}
+	fields := 0
+	field := func(name string, v float64) {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return
+		}
+		if fields == 0 {
+			line = append(line, ' ')
+		} else {
+			line = append(line, ',')
+		}
+		line = append(line, keyEscaper.Replace(name)...)
+		line = append(line, '=')
+		line = strconv.AppendFloat(line, v, 'g', -1, 64)
+		fields++
+	}
+	if rec.UT != 0 {
+		field("ut", rec.UT)
+	}
+	for i, c := range meta.Channels {
+		field(c.Name, rec.Values[i])
+	}
+	if fields == 0 {
+		return b
+	}
+	line = append(line, ' ')
+	line = strconv.AppendInt(line, rec.Time.UnixNano(), 10)
+	line = append(line, '\n')
+	return append(b, line...)
+}
+
+// LineProtocolWriter writes a recording as InfluxDB line protocol, e.g. for
+// Telegraf or the influx CLI to pick up.
+type LineProtocolWriter struct {
+	w           io.Writer
+	meta        Metadata
+	measurement string
+}
+
+// NewLineProtocolWriter creates a new LineProtocolWriter. The measurement
+// defaults to "telemetry".
+func NewLineProtocolWriter(w io.Writer, meta Metadata, measurement string) *LineProtocolWriter {
+	if measurement == "" {
+		measurement = "telemetry"
+	}
+	return &LineProtocolWriter{w: w, meta: meta, measurement: measurement}
+}
+
+// Write writes a record as a line.
+func (w *LineProtocolWriter) Write(rec Record) error {
+	line := appendLine(nil, w.measurement, w.meta, rec)
+	if len(line) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(line)
+	return tracerr.Wrap(err)
+}
+
+// Close does nothing, since every line is written straight away.
+func (w *LineProtocolWriter) Close() error {
+	return nil
+}
+
+// InfluxConfig configures an InfluxWriter.
+type InfluxConfig struct {
+	// URL is the InfluxDB server's address, e.g. "http://localhost:8086".
+	URL    string
+	Org    string
+	Bucket string
+	// Token is the API token, if the server needs one.
+	Token       string
+	Measurement string
+	// BatchSize is how many records to send at once.
+	BatchSize int
+	// FlushInterval is the longest a record waits before it is sent, so that
+	// dashboards stay live at low sample rates.
+	FlushInterval time.Duration
+	// Retries is how many times to retry a failed write, and RetryDelay is
+	// how long to wait before the first retry. The wait doubles after each
+	// retry.
+	Retries    int
+	RetryDelay time.Duration
+	// Client is the HTTP client to use.
+	Client *http.Client
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *InfluxConfig) SetDefaults() {
+	if cfg.Measurement == "" {
+		cfg.Measurement = "telemetry"
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	if cfg.Retries == 0 {
+		cfg.Retries = 3
+	}
+	if cfg.RetryDelay == 0 {
+		cfg.RetryDelay = time.Second
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+}
+
+// InfluxWriter sends a recording to InfluxDB's v2 write API in batches.
+type InfluxWriter struct {
+	cfg  InfluxConfig
+	meta Metadata
+	// batch holds the lines waiting to be sent, the oldest of which was
+	// added at since.
+	batch []byte
+	count int
+	since time.Time
+	sleep func(time.Duration)
+}
+
+// NewInfluxWriter creates a new InfluxWriter.
+func NewInfluxWriter(meta Metadata, cfg InfluxConfig) *InfluxWriter {
+	cfg.SetDefaults()
+	return &InfluxWriter{cfg: cfg, meta: meta, sleep: time.Sleep}
+}
+
+// writeURL returns the address to send batches to.
+func (w *InfluxWriter) writeURL() string {
+	query := url.Values{}
+	query.Set("org", w.cfg.Org)
+	query.Set("bucket", w.cfg.Bucket)
+	query.Set("precision", "ns")
+	return strings.TrimSuffix(w.cfg.URL, "/") + "/api/v2/write?" + query.Encode()
+}
+
+// post sends a batch once.
+func (w *InfluxWriter) post(batch []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.writeURL(), bytes.NewReader(batch))
+	if err != nil {
+		return false, tracerr.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.cfg.Token != "" {
+		req.Header.Set("Authorization", "Token "+w.cfg.Token)
+	}
+	resp, err := w.cfg.Client.Do(req)
+	if err != nil {
+		return true, tracerr.Wrap(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = tracerr.Errorf("InfluxDB write failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	// Retry server errors and rate limiting, but not bad requests.
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// Flush sends any waiting records. A batch that still can't be sent after
+// the retries is dropped, so that a long outage doesn't use up memory.
+func (w *InfluxWriter) Flush() error {
+	if w.count == 0 {
+		return nil
+	}
+	batch := w.batch
+	w.batch, w.count = nil, 0
+	delay := w.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(batch)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.cfg.Retries {
+			return tracerr.Errorf("Dropped %d bytes of telemetry: %v", len(batch), err)
+		}
+		w.sleep(delay)
+		delay *= 2
+	}
+}
+
+// Write adds a record to the batch, and sends the batch if it is full or
+// has waited long enough.
+func (w *InfluxWriter) Write(rec Record) error {
+	before := len(w.batch)
+	w.batch = appendLine(w.batch, w.cfg.Measurement, w.meta, rec)
+	if len(w.batch) == before {
+		return nil
+	}
+	if w.count == 0 {
+		w.since = time.Now()
+	}
+	w.count++
+	if w.count >= w.cfg.BatchSize || time.Since(w.since) >= w.cfg.FlushInterval {
+		return tracerr.Wrap(w.Flush())
+	}
+	return nil
+}
+
+// Close sends any waiting records.
+func (w *InfluxWriter) Close() error {
+	return tracerr.Wrap(w.Flush())
+}
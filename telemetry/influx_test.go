@@ -0,0 +1,171 @@
+package telemetry
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendLine(t *testing.T) {
+	now := time.Unix(1000, 5)
+	tests := []struct {
+		name     string
+		meta     Metadata
+		rec      Record
+		expected string
+	}{
+		{
+			name:     "tags and fields",
+			meta:     testMetadata(),
+			rec:      Record{Time: now, UT: 5, Phase: "ascent", Values: []float64{100.5, 20}},
+			expected: "telemetry,vessel=Kerbal\\ X,phase=ascent ut=5,altitude=100.5,speed=20 1000000000005\n",
+		},
+		{
+			name:     "no UT or phase",
+			meta:     testMetadata(),
+			rec:      Record{Time: now, Values: []float64{1, 2}},
+			expected: "telemetry,vessel=Kerbal\\ X altitude=1,speed=2 1000000000005\n",
+		},
+		{
+			name:     "skips NaN",
+			meta:     testMetadata(),
+			rec:      Record{Time: now, Values: []float64{math.NaN(), 2}},
+			expected: "telemetry,vessel=Kerbal\\ X speed=2 1000000000005\n",
+		},
+		{
+			name:     "no values",
+			meta:     testMetadata(),
+			rec:      Record{Time: now, Values: []float64{math.NaN(), math.Inf(1)}},
+			expected: "",
+		},
+		{
+			name:     "escapes keys",
+			meta:     Metadata{Channels: []ChannelInfo{{Name: "g force"}}},
+			rec:      Record{Time: now, Phase: "a=b,c", Values: []float64{1e-7}},
+			expected: "telemetry,phase=a\\=b\\,c g\\ force=1e-07 1000000000005\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, string(appendLine(nil, "telemetry", tc.meta, tc.rec)))
+		})
+	}
+}
+
+func TestLineProtocolWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewLineProtocolWriter(&buf, testMetadata(), "flight")
+	require.NoError(t, w.Write(Record{Time: time.Unix(1, 0), Values: []float64{1, 2}}))
+	require.NoError(t, w.Write(Record{Time: time.Unix(2, 0), Values: []float64{math.NaN(), math.NaN()}}))
+	require.NoError(t, w.Close())
+	require.Equal(t, "flight,vessel=Kerbal\\ X altitude=1,speed=2 1000000000\n", buf.String())
+}
+
+// influxServer records write requests and replies with the given statuses in
+// turn, then 204.
+type influxServer struct {
+	statuses []int
+	bodies   []string
+	requests []*http.Request
+}
+
+func (s *influxServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(body))
+	s.requests = append(s.requests, r)
+	status := http.StatusNoContent
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestInfluxWriter(t *testing.T, s *influxServer, cfg InfluxConfig) *InfluxWriter {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	cfg.URL = server.URL + "/"
+	cfg.Org = "kerbals"
+	cfg.Bucket = "flights"
+	cfg.Token = "secret"
+	w := NewInfluxWriter(Metadata{Channels: []ChannelInfo{{Name: "altitude"}}}, cfg)
+	w.sleep = func(time.Duration) {}
+	return w
+}
+
+func TestInfluxWriterBatches(t *testing.T) {
+	s := &influxServer{}
+	w := newTestInfluxWriter(t, s, InfluxConfig{BatchSize: 2, FlushInterval: time.Hour})
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, w.Write(Record{Time: time.Unix(int64(i), 0), Values: []float64{float64(i)}}))
+	}
+	require.Equal(t, []string{"telemetry altitude=1 1000000000\ntelemetry altitude=2 2000000000\n"}, s.bodies)
+	require.NoError(t, w.Close())
+	require.Equal(t, "telemetry altitude=3 3000000000\n", s.bodies[1])
+
+	r := s.requests[0]
+	require.Equal(t, "/api/v2/write", r.URL.Path)
+	require.Equal(t, "kerbals", r.URL.Query().Get("org"))
+	require.Equal(t, "flights", r.URL.Query().Get("bucket"))
+	require.Equal(t, "ns", r.URL.Query().Get("precision"))
+	require.Equal(t, "Token secret", r.Header.Get("Authorization"))
+}
+
+func TestInfluxWriterFlushInterval(t *testing.T) {
+	s := &influxServer{}
+	w := newTestInfluxWriter(t, s, InfluxConfig{BatchSize: 100, FlushInterval: 20 * time.Millisecond})
+	require.NoError(t, w.Write(Record{Time: time.Unix(1, 0), Values: []float64{1}}))
+	require.Empty(t, s.bodies)
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, w.Write(Record{Time: time.Unix(2, 0), Values: []float64{2}}))
+	require.Equal(t, []string{"telemetry altitude=1 1000000000\ntelemetry altitude=2 2000000000\n"}, s.bodies)
+}
+
+func TestInfluxWriterRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int
+		expectErr        bool
+	}{
+		{
+			name:             "recovers",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			expectedRequests: 3,
+		},
+		{
+			name:             "gives up",
+			statuses:         []int{500, 500, 500},
+			expectedRequests: 3,
+			expectErr:        true,
+		},
+		{
+			name:             "bad request",
+			statuses:         []int{http.StatusBadRequest},
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &influxServer{statuses: tc.statuses}
+			w := newTestInfluxWriter(t, s, InfluxConfig{Retries: 2})
+			require.NoError(t, w.Write(Record{Time: time.Unix(1, 0), Values: []float64{1}}))
+			err := w.Close()
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, s.requests, tc.expectedRequests)
+			// The batch is gone either way.
+			require.NoError(t, w.Flush())
+			require.Len(t, s.requests, tc.expectedRequests)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/ztrue/tracerr"
+)
+
+// JSONLWriter writes a recording as JSON lines, one object per sample with a
+// field for each channel, as read by pandas.read_json(lines=True) and
+// DuckDB's read_json. Values that aren't numbers, such as NaN, are written
+// as null.
+type JSONLWriter struct {
+	enc  *json.Encoder
+	meta Metadata
+}
+
+// NewJSONLWriter creates a new JSONLWriter.
+func NewJSONLWriter(w io.Writer, meta Metadata) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w), meta: meta}
+}
+
+// jsonRow builds the JSON object for a record, with a field for each of the
+// given channels, or every channel if there are none.
+func jsonRow(meta Metadata, rec Record, channels []int) map[string]any {
+	row := make(map[string]any, len(rec.Values)+4)
+	row["time"] = rec.Time
+	row["ut"] = rec.UT
+	if meta.Vessel != "" {
+		row["vessel"] = meta.Vessel
+	}
+	row["phase"] = rec.Phase
+	field := func(i int) {
+		v := rec.Values[i]
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			row[meta.Channels[i].Name] = nil
+		} else {
+			row[meta.Channels[i].Name] = v
+		}
+	}
+	if channels == nil {
+		for i := range meta.Channels {
+			field(i)
+		}
+	}
+	for _, i := range channels {
+		field(i)
+	}
+	return row
+}
+
+// RecordFields returns the fields of the JSON object that a JSONLWriter writes
+// for a record.
+func RecordFields(meta Metadata, rec Record) map[string]any {
+	return jsonRow(meta, rec, nil)
+}
+
+// Write writes a record as a line.
+func (w *JSONLWriter) Write(rec Record) error {
+	return tracerr.Wrap(w.enc.Encode(jsonRow(w.meta, rec, nil)))
+}
+
+// Close does nothing, since every line is written straight away.
+func (w *JSONLWriter) Close() error {
+	return nil
+}
+
+// WriteMetadata writes a recording's metadata, including the channels'
+// units, as JSON, e.g. to a file alongside a JSON lines recording.
+func WriteMetadata(w io.Writer, meta Metadata) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return tracerr.Wrap(enc.Encode(meta))
+}
@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONLWriter(&buf, testMetadata())
+	now := time.Unix(1000, 0).UTC()
+	require.NoError(t, w.Write(Record{Time: now, UT: 5, Phase: "ascent", Values: []float64{100, 20}}))
+	require.NoError(t, w.Write(Record{Time: now, UT: 6, Phase: "coast", Values: []float64{200, math.NaN()}}))
+	require.NoError(t, w.Close())
+
+	var rows []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var row map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Equal(t, []map[string]any{
+		{"time": "1970-01-01T00:16:40Z", "ut": 5.0, "vessel": "Kerbal X", "phase": "ascent", "altitude": 100.0, "speed": 20.0},
+		{"time": "1970-01-01T00:16:40Z", "ut": 6.0, "vessel": "Kerbal X", "phase": "coast", "altitude": 200.0, "speed": nil},
+	}, rows)
+}
+
+func TestWriteMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetadata(&buf, testMetadata()))
+	var meta Metadata
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &meta))
+	require.Equal(t, testMetadata(), meta)
+}
@@ -0,0 +1,234 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ztrue/tracerr"
+)
+
+// LiveConfig configures a LiveServer.
+type LiveConfig struct {
+	// Buffer is how many records to queue for each client. Records are
+	// dropped for clients that fall further behind, so that a slow browser
+	// can't hold up the recording.
+	Buffer int
+	// AllowOrigin, if set, is sent as the Access-Control-Allow-Origin header
+	// so that dashboards served from elsewhere can connect.
+	AllowOrigin string
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *LiveConfig) SetDefaults() {
+	if cfg.Buffer == 0 {
+		cfg.Buffer = 16
+	}
+}
+
+// liveClient is a connected dashboard.
+type liveClient struct {
+	// channels are the indexes of the channels the client wants, or nil for
+	// all of them.
+	channels []int
+	messages chan []byte
+}
+
+// LiveServer publishes a recording as it happens, for browser dashboards.
+// It is a Writer, so add it to a Recorder, and an http.Handler serving:
+//
+//	/metadata  the recording's metadata as JSON
+//	/events    records as server-sent events
+//	/ws        records as WebSocket text messages
+//
+// Each record is sent as a JSON object in the same form as a JSONLWriter's
+// lines. Clients can pick channels with a comma-separated channels query
+// parameter, e.g. /events?channels=altitude,speed. Clients are sent the
+// latest record as soon as they connect.
+type LiveServer struct {
+	meta Metadata
+	cfg  LiveConfig
+	mux  *http.ServeMux
+
+	mu      sync.Mutex
+	clients map[*liveClient]struct{}
+	closed  bool
+	// last is the latest record, sent to clients as they connect.
+	last *Record
+}
+
+// NewLiveServer creates a new LiveServer.
+func NewLiveServer(meta Metadata, cfg LiveConfig) *LiveServer {
+	cfg.SetDefaults()
+	s := &LiveServer{
+		meta:    meta,
+		cfg:     cfg,
+		mux:     http.NewServeMux(),
+		clients: map[*liveClient]struct{}{},
+	}
+	s.mux.HandleFunc("/metadata", s.serveMetadata)
+	s.mux.HandleFunc("/events", s.serveEvents)
+	s.mux.HandleFunc("/ws", s.serveWebSocket)
+	return s
+}
+
+// ServeHTTP serves the endpoints.
+func (s *LiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.cfg.AllowOrigin)
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// selectChannels returns the indexes of the named channels, or nil if no
+// channels are named.
+func selectChannels(meta Metadata, names string) ([]int, error) {
+	if names == "" {
+		return nil, nil
+	}
+	channels := []int{}
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for i, c := range meta.Channels {
+			if c.Name == name {
+				channels = append(channels, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, tracerr.Errorf("Unknown channel %q", name)
+		}
+	}
+	return channels, nil
+}
+
+// subscribe adds a client, or returns nil if the server is closed or the
+// request names unknown channels, having replied with an error.
+func (s *LiveServer) subscribe(w http.ResponseWriter, r *http.Request) *liveClient {
+	channels, err := selectChannels(s.meta, r.URL.Query().Get("channels"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		http.Error(w, "recording has finished", http.StatusGone)
+		return nil
+	}
+	c := &liveClient{channels: channels, messages: make(chan []byte, s.cfg.Buffer)}
+	if s.last != nil {
+		if m, err := json.Marshal(jsonRow(s.meta, *s.last, channels)); err == nil {
+			c.messages <- m
+		}
+	}
+	s.clients[c] = struct{}{}
+	return c
+}
+
+// unsubscribe removes a client.
+func (s *LiveServer) unsubscribe(c *liveClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, c)
+}
+
+func (s *LiveServer) serveMetadata(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.meta)
+}
+
+func (s *LiveServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	c := s.subscribe(w, r)
+	if c == nil {
+		return
+	}
+	defer s.unsubscribe(c)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case m, ok := <-c.messages:
+			if !ok {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", m); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *LiveServer) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	c := s.subscribe(w, r)
+	if c == nil {
+		return
+	}
+	defer s.unsubscribe(c)
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	go conn.readControl(done)
+	for {
+		select {
+		case <-done:
+			return
+		case m, ok := <-c.messages:
+			if !ok {
+				_ = conn.writeFrame(wsClose, nil)
+				return
+			}
+			if conn.writeFrame(wsText, m) != nil {
+				return
+			}
+		}
+	}
+}
+
+// Write sends a record to every client.
+func (s *LiveServer) Write(rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = &rec
+	for c := range s.clients {
+		m, err := json.Marshal(jsonRow(s.meta, rec, c.channels))
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		select {
+		case c.messages <- m:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close disconnects every client and refuses new ones.
+func (s *LiveServer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for c := range s.clients {
+		close(c.messages)
+	}
+	return nil
+}
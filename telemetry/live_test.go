@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLiveServer(t *testing.T) (*LiveServer, *httptest.Server) {
+	live := NewLiveServer(testMetadata(), LiveConfig{AllowOrigin: "*"})
+	server := httptest.NewServer(live)
+	t.Cleanup(server.Close)
+	return live, server
+}
+
+// waitForClients waits until the server has the given number of clients.
+func waitForClients(t *testing.T, live *LiveServer, n int) {
+	require.Eventually(t, func() bool {
+		live.mu.Lock()
+		defer live.mu.Unlock()
+		return len(live.clients) == n
+	}, time.Second, time.Millisecond)
+}
+
+func testRecord() Record {
+	return Record{Time: time.Unix(1000, 0).UTC(), UT: 5, Phase: "ascent", Values: []float64{100, 20}}
+}
+
+func TestLiveServerMetadata(t *testing.T) {
+	_, server := newTestLiveServer(t)
+	resp, err := http.Get(server.URL + "/metadata")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	var meta Metadata
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&meta))
+	require.Equal(t, testMetadata(), meta)
+}
+
+func TestLiveServerEvents(t *testing.T) {
+	live, server := newTestLiveServer(t)
+
+	resp, err := http.Get(server.URL + "/events?channels=nope")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/events?channels=speed")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	waitForClients(t, live, 1)
+
+	require.NoError(t, live.Write(testRecord()))
+	r := bufio.NewReader(resp.Body)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, `data: {"phase":"ascent","speed":20,"time":"1970-01-01T00:16:40Z","ut":5,"vessel":"Kerbal X"}`+"\n", line)
+
+	require.NoError(t, live.Close())
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "\n", string(rest))
+	waitForClients(t, live, 0)
+
+	resp, err = http.Get(server.URL + "/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusGone, resp.StatusCode)
+}
+
+func TestLiveServerSendsLatest(t *testing.T) {
+	live, server := newTestLiveServer(t)
+	require.NoError(t, live.Write(testRecord()))
+
+	resp, err := http.Get(server.URL + "/events?channels=altitude")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, `data: {"altitude":100,"phase":"ascent","time":"1970-01-01T00:16:40Z","ut":5,"vessel":"Kerbal X"}`+"\n", line)
+}
+
+// readServerFrame reads an unmasked frame sent by the server.
+func readServerFrame(t *testing.T, r *bufio.Reader) (byte, string) {
+	c := &wsConn{r: r}
+	opcode, payload, err := c.readFrame()
+	require.NoError(t, err)
+	return opcode, string(payload)
+}
+
+func TestLiveServerWebSocket(t *testing.T) {
+	live, server := newTestLiveServer(t)
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("GET /ws?channels=altitude HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"))
+	require.NoError(t, err)
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	waitForClients(t, live, 1)
+
+	require.NoError(t, live.Write(testRecord()))
+	opcode, payload := readServerFrame(t, r)
+	require.Equal(t, byte(wsText), opcode)
+	require.JSONEq(t, `{"phase":"ascent","altitude":100,"time":"1970-01-01T00:16:40Z","ut":5,"vessel":"Kerbal X"}`, payload)
+
+	// Pings from the client are answered.
+	_, err = conn.Write([]byte{0x80 | wsPing, 0x80 | 2, 0, 0, 0, 0, 'h', 'i'})
+	require.NoError(t, err)
+	opcode, payload = readServerFrame(t, r)
+	require.Equal(t, byte(wsPong), opcode)
+	require.Equal(t, "hi", payload)
+
+	require.NoError(t, live.Close())
+	opcode, _ = readServerFrame(t, r)
+	require.Equal(t, byte(wsClose), opcode)
+	waitForClients(t, live, 0)
+}
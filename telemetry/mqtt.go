@@ -0,0 +1,348 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// MQTT 3.1.1 packet types.
+const (
+	mqttConnect    = 1
+	mqttConnack    = 2
+	mqttPublish    = 3
+	mqttPuback     = 4
+	mqttPubrec     = 5
+	mqttPubrel     = 6
+	mqttPubcomp    = 7
+	mqttPingreq    = 12
+	mqttPingresp   = 13
+	mqttDisconnect = 14
+)
+
+// maxMQTTPacket limits the size of packets read from the broker, which only
+// ever sends small acknowledgements to a publisher.
+const maxMQTTPacket = 1 << 16
+
+// appendMQTTPacket appends a packet with a fixed header.
+func appendMQTTPacket(b []byte, packetType, flags byte, body []byte) []byte {
+	b = append(b, packetType<<4|flags)
+	n := len(body)
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if n == 0 {
+			break
+		}
+	}
+	return append(b, body...)
+}
+
+// appendMQTTString appends a length-prefixed string.
+func appendMQTTString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// readMQTTPacket reads a packet, returning its type, flags and body.
+func readMQTTPacket(r *bufio.Reader) (packetType, flags byte, body []byte, err error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, nil, tracerr.Wrap(err)
+	}
+	size, multiplier := 0, 1
+	for i := 0; ; i++ {
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, 0, nil, tracerr.Wrap(err)
+		}
+		size += int(digit&0x7f) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, 0, nil, tracerr.Errorf("Malformed MQTT packet length")
+		}
+		multiplier *= 128
+	}
+	if size > maxMQTTPacket {
+		return 0, 0, nil, tracerr.Errorf("MQTT packet too large: %d bytes", size)
+	}
+	body = make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, 0, nil, tracerr.Wrap(err)
+	}
+	return header >> 4, header & 0x0f, body, nil
+}
+
+// MQTTConfig configures an MQTTWriter.
+type MQTTConfig struct {
+	// Address is the broker's host and port, e.g. "localhost:1883".
+	Address  string
+	ClientID string
+	// Username and Password, if set, are sent when connecting.
+	Username string
+	Password string
+	// Topic is the template for each channel's topic. "{vessel}",
+	// "{channel}" and "{phase}" are replaced with the vessel's name, the
+	// channel's name and the current phase, with any "/", "+" or "#" in them
+	// replaced with "_".
+	Topic string
+	// QoS is the quality of service to publish with: 0, 1 or 2.
+	QoS byte
+	// Retain asks the broker to keep each topic's last value for new
+	// subscribers.
+	Retain bool
+	// KeepAlive is the keep alive interval sent to the broker, which must be
+	// longer than the time between records. Records with nothing to publish
+	// ping the broker instead once half of it has passed.
+	KeepAlive time.Duration
+	// Timeout is how long to wait to connect and for acknowledgements.
+	Timeout time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *MQTTConfig) SetDefaults() {
+	if cfg.ClientID == "" {
+		cfg.ClientID = "krpc-go"
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = "krpc/{vessel}/{channel}"
+	}
+	if cfg.KeepAlive == 0 {
+		cfg.KeepAlive = time.Minute
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+}
+
+// mqttAck is an acknowledgement from the broker.
+type mqttAck struct {
+	packetType byte
+	id         uint16
+}
+
+// MQTTWriter publishes each channel's values to an MQTT broker as text, one
+// topic per channel. Values that aren't numbers are skipped.
+type MQTTWriter struct {
+	cfg  MQTTConfig
+	meta Metadata
+	conn net.Conn
+
+	mu       sync.Mutex
+	lastSent time.Time
+	nextID   uint16
+	acks     chan mqttAck
+	// done is closed when the broker's connection is lost, and err holds
+	// why.
+	done chan struct{}
+	err  error
+}
+
+var topicEscaper = strings.NewReplacer("/", "_", "+", "_", "#", "_")
+
+// topic returns a channel's topic.
+func (w *MQTTWriter) topic(channel, phase string) string {
+	return strings.NewReplacer(
+		"{vessel}", topicEscaper.Replace(w.meta.Vessel),
+		"{channel}", topicEscaper.Replace(channel),
+		"{phase}", topicEscaper.Replace(phase),
+	).Replace(w.cfg.Topic)
+}
+
+// NewMQTTWriter connects to a broker.
+func NewMQTTWriter(meta Metadata, cfg MQTTConfig) (*MQTTWriter, error) {
+	cfg.SetDefaults()
+	if cfg.QoS > 2 {
+		return nil, tracerr.Errorf("Invalid MQTT QoS %d", cfg.QoS)
+	}
+	conn, err := net.DialTimeout("tcp", cfg.Address, cfg.Timeout)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	w := &MQTTWriter{
+		cfg:  cfg,
+		meta: meta,
+		conn: conn,
+		acks: make(chan mqttAck, 1),
+		done: make(chan struct{}),
+	}
+	r := bufio.NewReader(conn)
+	if err := w.connect(r); err != nil {
+		conn.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	go w.read(r)
+	return w, nil
+}
+
+// connect sends the CONNECT packet and waits for the broker to accept it.
+func (w *MQTTWriter) connect(r *bufio.Reader) error {
+	flags := byte(0x02) // Clean session
+	if w.cfg.Username != "" {
+		flags |= 0x80
+	}
+	if w.cfg.Password != "" {
+		flags |= 0x40
+	}
+	body := appendMQTTString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(w.cfg.KeepAlive/time.Second))
+	body = appendMQTTString(body, w.cfg.ClientID)
+	if w.cfg.Username != "" {
+		body = appendMQTTString(body, w.cfg.Username)
+	}
+	if w.cfg.Password != "" {
+		body = appendMQTTString(body, w.cfg.Password)
+	}
+	if err := w.conn.SetDeadline(time.Now().Add(w.cfg.Timeout)); err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := w.send(mqttConnect, 0, body); err != nil {
+		return tracerr.Wrap(err)
+	}
+	packetType, _, reply, err := readMQTTPacket(r)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if packetType != mqttConnack || len(reply) != 2 {
+		return tracerr.Errorf("Expected CONNACK from MQTT broker, got packet type %d", packetType)
+	}
+	if reply[1] != 0 {
+		return tracerr.Errorf("MQTT broker refused connection with code %d", reply[1])
+	}
+	return tracerr.Wrap(w.conn.SetDeadline(time.Time{}))
+}
+
+// read handles packets from the broker until the connection is lost.
+func (w *MQTTWriter) read(r *bufio.Reader) {
+	for {
+		packetType, _, body, err := readMQTTPacket(r)
+		if err != nil {
+			w.err = err
+			close(w.done)
+			return
+		}
+		switch packetType {
+		case mqttPuback, mqttPubrec, mqttPubcomp:
+			if len(body) < 2 {
+				continue
+			}
+			// Drop acknowledgements nothing is waiting for any more.
+			select {
+			case w.acks <- mqttAck{packetType: packetType, id: binary.BigEndian.Uint16(body)}:
+			default:
+			}
+		}
+	}
+}
+
+// send writes a packet.
+func (w *MQTTWriter) send(packetType, flags byte, body []byte) error {
+	_, err := w.conn.Write(appendMQTTPacket(nil, packetType, flags, body))
+	w.lastSent = time.Now()
+	return tracerr.Wrap(err)
+}
+
+// await waits for an acknowledgement of a packet.
+func (w *MQTTWriter) await(packetType byte, id uint16) error {
+	timeout := time.NewTimer(w.cfg.Timeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case ack := <-w.acks:
+			if ack.packetType == packetType && ack.id == id {
+				return nil
+			}
+		case <-w.done:
+			return tracerr.Wrap(w.err)
+		case <-timeout.C:
+			return tracerr.Errorf("Timed out waiting for MQTT acknowledgement")
+		}
+	}
+}
+
+// publish sends a message with the configured QoS, waiting for it to be
+// acknowledged.
+func (w *MQTTWriter) publish(topic string, payload []byte) error {
+	flags := w.cfg.QoS << 1
+	if w.cfg.Retain {
+		flags |= 0x01
+	}
+	body := appendMQTTString(nil, topic)
+	var id uint16
+	if w.cfg.QoS > 0 {
+		w.nextID++
+		if w.nextID == 0 {
+			w.nextID = 1
+		}
+		id = w.nextID
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	body = append(body, payload...)
+	if err := w.send(mqttPublish, flags, body); err != nil {
+		return tracerr.Wrap(err)
+	}
+	switch w.cfg.QoS {
+	case 1:
+		return tracerr.Wrap(w.await(mqttPuback, id))
+	case 2:
+		if err := w.await(mqttPubrec, id); err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := w.send(mqttPubrel, 0x02, binary.BigEndian.AppendUint16(nil, id)); err != nil {
+			return tracerr.Wrap(err)
+		}
+		return tracerr.Wrap(w.await(mqttPubcomp, id))
+	}
+	return nil
+}
+
+// Write publishes each channel's value.
+func (w *MQTTWriter) Write(rec Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	select {
+	case <-w.done:
+		return tracerr.Errorf("Lost connection to MQTT broker: %v", w.err)
+	default:
+	}
+	for i, c := range w.meta.Channels {
+		v := rec.Values[i]
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		payload := strconv.AppendFloat(nil, v, 'g', -1, 64)
+		if err := w.publish(w.topic(c.Name, rec.Phase), payload); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if time.Since(w.lastSent) > w.cfg.KeepAlive/2 {
+		return tracerr.Wrap(w.send(mqttPingreq, 0, nil))
+	}
+	return nil
+}
+
+// Close disconnects from the broker.
+func (w *MQTTWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.send(mqttDisconnect, 0, nil)
+	if closeErr := w.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,142 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMQTTPacketRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 5, 127, 128, 16383, 16384, 60000} {
+		body := make([]byte, size)
+		packet := appendMQTTPacket(nil, mqttPublish, 0x03, body)
+		r := bufio.NewReader(bytes.NewReader(packet))
+		packetType, flags, got, err := readMQTTPacket(r)
+		require.NoError(t, err)
+		require.Equal(t, byte(mqttPublish), packetType)
+		require.Equal(t, byte(0x03), flags)
+		require.Len(t, got, size)
+	}
+	require.Equal(t, []byte{0x30, 0x80, 0x01}, appendMQTTPacket(nil, mqttPublish, 0, make([]byte, 128))[:3])
+}
+
+// mqttPublished is a message received by the fake broker.
+type mqttPublished struct {
+	topic   string
+	payload string
+	qos     byte
+	retain  bool
+}
+
+// fakeBroker accepts one connection, acknowledges everything and reports
+// what it receives.
+func fakeBroker(t *testing.T, connackCode byte) (string, <-chan mqttPublished, <-chan []byte) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	published := make(chan mqttPublished, 16)
+	connects := make(chan []byte, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			packetType, flags, body, err := readMQTTPacket(r)
+			if err != nil {
+				close(published)
+				return
+			}
+			switch packetType {
+			case mqttConnect:
+				connects <- body
+				_, _ = conn.Write(appendMQTTPacket(nil, mqttConnack, 0, []byte{0, connackCode}))
+			case mqttPublish:
+				n := binary.BigEndian.Uint16(body)
+				m := mqttPublished{topic: string(body[2 : 2+n]), qos: flags >> 1 & 0x03, retain: flags&1 != 0}
+				rest := body[2+n:]
+				if m.qos > 0 {
+					id := rest[:2]
+					rest = rest[2:]
+					reply := byte(mqttPuback)
+					if m.qos == 2 {
+						reply = mqttPubrec
+					}
+					_, _ = conn.Write(appendMQTTPacket(nil, reply, 0, id))
+				}
+				m.payload = string(rest)
+				published <- m
+			case mqttPubrel:
+				_, _ = conn.Write(appendMQTTPacket(nil, mqttPubcomp, 0, body))
+			case mqttDisconnect:
+				close(published)
+				return
+			}
+		}
+	}()
+	return l.Addr().String(), published, connects
+}
+
+func TestMQTTWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		qos    byte
+		retain bool
+	}{
+		{name: "QoS 0", qos: 0},
+		{name: "QoS 1 retained", qos: 1, retain: true},
+		{name: "QoS 2", qos: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, published, connects := fakeBroker(t, 0)
+			w, err := NewMQTTWriter(testMetadata(), MQTTConfig{
+				Address:  addr,
+				Username: "jeb",
+				Password: "secret",
+				Topic:    "ksp/{vessel}/{phase}/{channel}",
+				QoS:      tc.qos,
+				Retain:   tc.retain,
+				Timeout:  time.Second,
+			})
+			require.NoError(t, err)
+
+			connect := <-connects
+			require.Equal(t, "\x00\x04MQTT\x04\xc2\x00\x3c\x00\x07krpc-go\x00\x03jeb\x00\x06secret", string(connect))
+
+			rec := Record{Phase: "ascent", Values: []float64{1500.5, math.NaN()}}
+			require.NoError(t, w.Write(rec))
+			require.NoError(t, w.Close())
+
+			var got []mqttPublished
+			for m := range published {
+				got = append(got, m)
+			}
+			require.Equal(t, []mqttPublished{
+				{topic: "ksp/Kerbal X/ascent/altitude", payload: "1500.5", qos: tc.qos, retain: tc.retain},
+			}, got)
+		})
+	}
+}
+
+func TestMQTTWriterRefused(t *testing.T) {
+	addr, _, _ := fakeBroker(t, 5)
+	_, err := NewMQTTWriter(testMetadata(), MQTTConfig{Address: addr, Timeout: time.Second})
+	require.Error(t, err)
+
+	_, err = NewMQTTWriter(testMetadata(), MQTTConfig{Address: addr, QoS: 3})
+	require.Error(t, err)
+}
+
+func TestMQTTTopic(t *testing.T) {
+	w := &MQTTWriter{meta: Metadata{Vessel: "Mun/Lander #2"}, cfg: MQTTConfig{Topic: "krpc/{vessel}/{channel}"}}
+	require.Equal(t, "krpc/Mun_Lander _2/g_force", w.topic("g+force", ""))
+}
@@ -0,0 +1,221 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Parquet enum values, from parquet.thrift.
+const (
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetRequired = 0
+
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+
+	parquetPlain        = 0
+	parquetUncompressed = 0
+	parquetDataPage     = 0
+)
+
+// parquetMagic starts and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// parquetMetadataKey is the key of the recording's metadata, as JSON, in the
+// file's key-value metadata.
+const parquetMetadataKey = "krpcgo.metadata"
+
+// parquetColumn is a column of a Parquet file being written.
+type parquetColumn struct {
+	name          string
+	typ           int32
+	convertedType int32
+	// hasConverted is set if convertedType applies.
+	hasConverted bool
+	data         bytes.Buffer
+}
+
+// ParquetWriter writes a recording as a Parquet file, with a column for the
+// time, UT, vessel, phase and each channel. The recording's metadata,
+// including the channels' units, is stored as JSON in the file's key-value
+// metadata under "krpcgo.metadata", and each channel's unit is also stored
+// under "<channel>.unit".
+//
+// Values are plain encoded and uncompressed, and the whole recording is kept
+// in memory and written as one row group when the writer is closed.
+type ParquetWriter struct {
+	w       io.Writer
+	meta    Metadata
+	columns []*parquetColumn
+	rows    int64
+}
+
+// NewParquetWriter creates a new ParquetWriter.
+func NewParquetWriter(w io.Writer, meta Metadata) *ParquetWriter {
+	pw := &ParquetWriter{w: w, meta: meta}
+	pw.columns = []*parquetColumn{
+		{name: "time", typ: parquetInt64, convertedType: parquetTimestampMillis, hasConverted: true},
+		{name: "ut", typ: parquetDouble},
+		{name: "vessel", typ: parquetByteArray, convertedType: parquetUTF8, hasConverted: true},
+		{name: "phase", typ: parquetByteArray, convertedType: parquetUTF8, hasConverted: true},
+	}
+	for _, c := range meta.Channels {
+		pw.columns = append(pw.columns, &parquetColumn{name: c.Name, typ: parquetDouble})
+	}
+	return pw
+}
+
+func (c *parquetColumn) writeInt64(v int64) {
+	_ = binary.Write(&c.data, binary.LittleEndian, v)
+}
+
+func (c *parquetColumn) writeDouble(v float64) {
+	_ = binary.Write(&c.data, binary.LittleEndian, math.Float64bits(v))
+}
+
+func (c *parquetColumn) writeString(v string) {
+	_ = binary.Write(&c.data, binary.LittleEndian, uint32(len(v)))
+	c.data.WriteString(v)
+}
+
+// Write adds a record to the file.
+func (w *ParquetWriter) Write(rec Record) error {
+	if len(rec.Values) != len(w.meta.Channels) {
+		return tracerr.Errorf("Record has %d values, expected %d", len(rec.Values), len(w.meta.Channels))
+	}
+	w.columns[0].writeInt64(rec.Time.UnixMilli())
+	w.columns[1].writeDouble(rec.UT)
+	w.columns[2].writeString(w.meta.Vessel)
+	w.columns[3].writeString(rec.Phase)
+	for i, v := range rec.Values {
+		w.columns[4+i].writeDouble(v)
+	}
+	w.rows++
+	return nil
+}
+
+// pageHeader encodes the header of a data page.
+func (w *ParquetWriter) pageHeader(size int) []byte {
+	var t thriftWriter
+	t.begin()
+	t.i32(1, parquetDataPage)
+	t.i32(2, int32(size))
+	t.i32(3, int32(size))
+	t.structField(5)
+	t.i32(1, int32(w.rows))
+	t.i32(2, parquetPlain)
+	// Required columns have no levels, but the encodings must still be set.
+	t.i32(3, 3)
+	t.i32(4, 3)
+	t.end()
+	t.end()
+	return t.buf.Bytes()
+}
+
+// columnChunk records where a column was written.
+type columnChunk struct {
+	offset int64
+	size   int64
+}
+
+// footer encodes the file metadata.
+func (w *ParquetWriter) footer(chunks []columnChunk) ([]byte, error) {
+	metaJSON, err := json.Marshal(w.meta)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	var t thriftWriter
+	t.begin()
+	t.i32(1, 1)
+
+	t.list(2, thriftStruct, len(w.columns)+1)
+	t.begin()
+	t.string(4, "schema")
+	t.i32(5, int32(len(w.columns)))
+	t.end()
+	for _, c := range w.columns {
+		t.begin()
+		t.i32(1, c.typ)
+		t.i32(3, parquetRequired)
+		t.string(4, c.name)
+		if c.hasConverted {
+			t.i32(6, c.convertedType)
+		}
+		t.end()
+	}
+
+	t.i64(3, w.rows)
+
+	var total int64
+	for _, c := range chunks {
+		total += c.size
+	}
+	t.list(4, thriftStruct, 1)
+	t.begin()
+	t.list(1, thriftStruct, len(w.columns))
+	for i, c := range w.columns {
+		t.begin()
+		t.i64(2, chunks[i].offset)
+		t.structField(3)
+		t.i32(1, c.typ)
+		t.i32List(2, parquetPlain)
+		t.stringList(3, c.name)
+		t.i32(4, parquetUncompressed)
+		t.i64(5, w.rows)
+		t.i64(6, chunks[i].size)
+		t.i64(7, chunks[i].size)
+		t.i64(9, chunks[i].offset)
+		t.end()
+		t.end()
+	}
+	t.i64(2, total)
+	t.i64(3, w.rows)
+	t.end()
+
+	t.list(5, thriftStruct, len(w.meta.Channels)+1)
+	t.begin()
+	t.string(1, parquetMetadataKey)
+	t.string(2, string(metaJSON))
+	t.end()
+	for _, c := range w.meta.Channels {
+		t.begin()
+		t.string(1, c.Name+".unit")
+		t.string(2, c.Unit)
+		t.end()
+	}
+
+	t.string(6, "krpc-go")
+	t.end()
+	return t.buf.Bytes(), nil
+}
+
+// Close writes the file.
+func (w *ParquetWriter) Close() error {
+	var out bytes.Buffer
+	out.WriteString(parquetMagic)
+	chunks := make([]columnChunk, len(w.columns))
+	for i, c := range w.columns {
+		offset := int64(out.Len())
+		out.Write(w.pageHeader(c.data.Len()))
+		out.Write(c.data.Bytes())
+		chunks[i] = columnChunk{offset: offset, size: int64(out.Len()) - offset}
+	}
+	footer, err := w.footer(chunks)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	out.Write(footer)
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(footer)))
+	out.WriteString(parquetMagic)
+	_, err = w.w.Write(out.Bytes())
+	return tracerr.Wrap(err)
+}
@@ -0,0 +1,152 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThriftWriter(t *testing.T) {
+	var w thriftWriter
+	w.begin()
+	w.i32(1, 1)
+	w.i32(3, -2)
+	w.string(4, "ab")
+	w.i64(20, 300)
+	w.i32List(21, 0, 3)
+	w.structField(22)
+	w.i32(1, 7)
+	w.end()
+	w.end()
+	require.Equal(t, []byte{
+		0x15, 0x02, // field 1, i32 1
+		0x25, 0x03, // field 3, i32 -2
+		0x18, 0x02, 'a', 'b', // field 4, "ab"
+		0x06, 0x28, 0xd8, 0x04, // field 20, long form, i64 300
+		0x19, 0x25, 0x00, 0x06, // field 21, list of 2 i32s
+		0x1c, 0x15, 0x0e, 0x00, // field 22, struct with i32 7
+		0x00,
+	}, w.buf.Bytes())
+}
+
+// thriftReader decodes the Thrift compact protocol into maps of field IDs to
+// values, to check the files written.
+type thriftReader struct {
+	r *bytes.Reader
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		panic(err)
+	}
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n, _ := binary.ReadUvarint(r.r)
+		b := make([]byte, n)
+		_, _ = r.r.Read(b)
+		return string(b)
+	case thriftList:
+		header, _ := r.r.ReadByte()
+		size := int(header >> 4)
+		if size == 15 {
+			n, _ := binary.ReadUvarint(r.r)
+			size = int(n)
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		fields := map[int16]any{}
+		var id int16
+		for {
+			header, _ := r.r.ReadByte()
+			if header == 0 {
+				return fields
+			}
+			if delta := header >> 4; delta != 0 {
+				id += int16(delta)
+			} else {
+				id = int16(r.zigzag())
+			}
+			fields[id] = r.value(header & 0x0f)
+		}
+	}
+	panic("unsupported type")
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewParquetWriter(&buf, testMetadata())
+	now := time.Unix(1000, 0)
+	require.NoError(t, w.Write(Record{Time: now, UT: 5, Phase: "ascent", Values: []float64{100, 20}}))
+	require.NoError(t, w.Write(Record{Time: now.Add(time.Second), UT: 6, Phase: "coast", Values: []float64{200, 30}}))
+	require.Error(t, w.Write(Record{Values: []float64{1}}))
+	require.NoError(t, w.Close())
+
+	data := buf.Bytes()
+	require.Equal(t, parquetMagic, string(data[:4]))
+	require.Equal(t, parquetMagic, string(data[len(data)-4:]))
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := data[len(data)-8-footerSize : len(data)-8]
+	meta := (&thriftReader{bytes.NewReader(footer)}).value(thriftStruct).(map[int16]any)
+
+	require.Equal(t, int64(2), meta[3])
+	schema := meta[2].([]any)
+	require.Len(t, schema, 7)
+	var names []any
+	for _, s := range schema[1:] {
+		names = append(names, s.(map[int16]any)[4])
+	}
+	require.Equal(t, []any{"time", "ut", "vessel", "phase", "altitude", "speed"}, names)
+
+	keyValues := map[string]string{}
+	for _, kv := range meta[5].([]any) {
+		kv := kv.(map[int16]any)
+		keyValues[kv[1].(string)] = kv[2].(string)
+	}
+	require.Equal(t, "m", keyValues["altitude.unit"])
+	require.Equal(t, "m/s", keyValues["speed.unit"])
+	var stored Metadata
+	require.NoError(t, json.Unmarshal([]byte(keyValues[parquetMetadataKey]), &stored))
+	require.Equal(t, testMetadata(), stored)
+
+	// Read each column's values back from its page.
+	columns := meta[4].([]any)[0].(map[int16]any)[1].([]any)
+	require.Len(t, columns, 6)
+	readPage := func(i int) []byte {
+		chunk := columns[i].(map[int16]any)[3].(map[int16]any)
+		r := bytes.NewReader(data[chunk[9].(int64):])
+		header := (&thriftReader{r}).value(thriftStruct).(map[int16]any)
+		page := make([]byte, header[3].(int64))
+		_, _ = r.Read(page)
+		return page
+	}
+	doubles := func(page []byte) []float64 {
+		vs := make([]float64, len(page)/8)
+		for i := range vs {
+			vs[i] = math.Float64frombits(binary.LittleEndian.Uint64(page[8*i:]))
+		}
+		return vs
+	}
+	require.Equal(t, []float64{5, 6}, doubles(readPage(1)))
+	require.Equal(t, []float64{100, 200}, doubles(readPage(4)))
+	require.Equal(t, []float64{20, 30}, doubles(readPage(5)))
+	times := readPage(0)
+	require.Equal(t, uint64(1000000), binary.LittleEndian.Uint64(times))
+	require.Equal(t, uint64(1001000), binary.LittleEndian.Uint64(times[8:]))
+	require.Equal(t, append([]byte{6, 0, 0, 0}, "ascent\x05\x00\x00\x00coast"...), readPage(3))
+}
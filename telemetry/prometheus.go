@@ -0,0 +1,277 @@
+package telemetry
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// PrometheusConfig configures a PrometheusExporter.
+type PrometheusConfig struct {
+	// Namespace prefixes every metric's name. Defaults to "krpc".
+	Namespace string
+	// Labels are added to every metric, e.g. to tell apart the missions of
+	// several exporters scraped by one Prometheus.
+	Labels map[string]string
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *PrometheusConfig) SetDefaults() {
+	if cfg.Namespace == "" {
+		cfg.Namespace = "krpc"
+	}
+}
+
+// metricName converts a channel name into a Prometheus metric name, e.g.
+// "resources.LiquidFuel" into "resources_liquid_fuel".
+func metricName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			// Start a new word at an upper case letter after a lower case
+			// one or a digit, or at the last capital of an acronym.
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+// formatLabels formats labels in Prometheus' text format, sorted by name.
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = metricName(name) + `="` + labelEscaper.Replace(labels[name]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// formatMetricValue formats a value in Prometheus' text format.
+func formatMetricValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// promFraction is a channel exported as a fraction of another, e.g. a
+// resource's amount over its capacity.
+type promFraction struct {
+	name            string
+	value, capacity int
+}
+
+// promVessel is the latest record of one vessel's recording.
+type promVessel struct {
+	meta Metadata
+	// names are the metric names of the channels.
+	names     []string
+	fractions []promFraction
+	last      *Record
+}
+
+// newPromVessel works out the metrics for a recording.
+func newPromVessel(meta Metadata) *promVessel {
+	v := &promVessel{meta: meta, names: make([]string, len(meta.Channels))}
+	index := map[string]int{}
+	for i, c := range meta.Channels {
+		v.names[i] = metricName(c.Name)
+		index[c.Name] = i
+	}
+	for i, c := range meta.Channels {
+		if capacity, ok := index[c.Name+".max"]; ok {
+			v.fractions = append(v.fractions, promFraction{name: v.names[i] + "_fraction", value: i, capacity: capacity})
+		}
+	}
+	return v
+}
+
+// promSample is one line of a metric.
+type promSample struct {
+	labels string
+	value  float64
+}
+
+// promMetric is a metric and its samples.
+type promMetric struct {
+	help    string
+	samples []promSample
+}
+
+// PrometheusExporter serves the latest values of any number of vessels'
+// recordings as Prometheus gauges, for monitoring many missions at once.
+// Add a writer from Writer to each vessel's Recorder, and serve the
+// exporter as the scrape target, e.g. at /metrics.
+//
+// Each channel is a gauge named after it, e.g. "orbit.apoapsis" becomes
+// krpc_orbit_apoapsis, labelled with the vessel's name. A channel with a
+// matching ".max" channel, such as the resources.<name> channels of
+// dashboard.VesselChannels, is also exported as a fraction of it, e.g.
+// krpc_resources_liquid_fuel_fraction. Each vessel also has:
+//
+//	krpc_ut                             the UT of the latest record
+//	krpc_phase                          1, labelled with the current phase
+//	krpc_last_record_timestamp_seconds  when the latest record was taken
+//
+// Values that aren't numbers are left out. A vessel's metrics are removed
+// when its writer is closed.
+type PrometheusExporter struct {
+	cfg PrometheusConfig
+
+	mu      sync.Mutex
+	vessels []*promVessel
+}
+
+// NewPrometheusExporter creates a new PrometheusExporter.
+func NewPrometheusExporter(cfg PrometheusConfig) *PrometheusExporter {
+	cfg.SetDefaults()
+	return &PrometheusExporter{cfg: cfg}
+}
+
+// prometheusWriter passes a recording's records to an exporter.
+type prometheusWriter struct {
+	e *PrometheusExporter
+	v *promVessel
+}
+
+// Writer returns a writer exporting a vessel's recording. Vessels should
+// have different names, since their metrics are told apart by them.
+func (e *PrometheusExporter) Writer(meta Metadata) Writer {
+	v := newPromVessel(meta)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.vessels = append(e.vessels, v)
+	return &prometheusWriter{e: e, v: v}
+}
+
+// Write makes a record the vessel's latest.
+func (w *prometheusWriter) Write(rec Record) error {
+	w.e.mu.Lock()
+	defer w.e.mu.Unlock()
+	rec.Values = append([]float64(nil), rec.Values...)
+	w.v.last = &rec
+	return nil
+}
+
+// Close removes the vessel's metrics.
+func (w *prometheusWriter) Close() error {
+	w.e.mu.Lock()
+	defer w.e.mu.Unlock()
+	for i, v := range w.e.vessels {
+		if v == w.v {
+			w.e.vessels = append(w.e.vessels[:i], w.e.vessels[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// metrics returns the current metrics by name.
+func (e *PrometheusExporter) metrics() map[string]*promMetric {
+	metrics := map[string]*promMetric{}
+	add := func(name, help string, labels map[string]string, value float64) {
+		if math.IsNaN(value) {
+			return
+		}
+		name = e.cfg.Namespace + "_" + name
+		m, ok := metrics[name]
+		if !ok {
+			m = &promMetric{help: help}
+			metrics[name] = m
+		}
+		m.samples = append(m.samples, promSample{labels: formatLabels(labels), value: value})
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, v := range e.vessels {
+		if v.last == nil {
+			continue
+		}
+		labels := func() map[string]string {
+			l := map[string]string{}
+			for name, value := range e.cfg.Labels {
+				l[name] = value
+			}
+			l["vessel"] = v.meta.Vessel
+			return l
+		}
+		rec := v.last
+		for i, c := range v.meta.Channels {
+			help := c.Name
+			if c.Unit != "" {
+				help += " (" + c.Unit + ")"
+			}
+			add(v.names[i], help, labels(), rec.Values[i])
+		}
+		for _, f := range v.fractions {
+			if capacity := rec.Values[f.capacity]; capacity > 0 {
+				add(f.name, v.meta.Channels[f.value].Name+" as a fraction of its maximum", labels(), rec.Values[f.value]/capacity)
+			}
+		}
+		if rec.UT != 0 {
+			add("ut", "Universal time of the latest record (s)", labels(), rec.UT)
+		}
+		if rec.Phase != "" {
+			l := labels()
+			l["phase"] = rec.Phase
+			add("phase", "Current mission phase", l, 1)
+		}
+		add("last_record_timestamp_seconds", "Time the latest record was taken", labels(), float64(rec.Time.UnixNano())/1e9)
+	}
+	return metrics
+}
+
+// ServeHTTP serves the metrics in Prometheus' text format.
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	metrics := e.metrics()
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		m := metrics[name]
+		b.WriteString("# HELP " + name + " " + helpEscaper.Replace(m.help) + "\n")
+		b.WriteString("# TYPE " + name + " gauge\n")
+		sort.SliceStable(m.samples, func(i, j int) bool {
+			return m.samples[i].labels < m.samples[j].labels
+		})
+		for _, s := range m.samples {
+			b.WriteString(name + s.labels + " " + formatMetricValue(s.value) + "\n")
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
@@ -0,0 +1,101 @@
+package telemetry
+
+import (
+	"io"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "altitude", expected: "altitude"},
+		{name: "orbit.time_to_apoapsis", expected: "orbit_time_to_apoapsis"},
+		{name: "resources.LiquidFuel", expected: "resources_liquid_fuel"},
+		{name: "resources.LiquidFuel.max", expected: "resources_liquid_fuel_max"},
+		{name: "UT", expected: "ut"},
+		{name: "RCSThrust", expected: "rcs_thrust"},
+		{name: "stage2Mass", expected: "stage2_mass"},
+		{name: "g-force", expected: "g_force"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, metricName(tc.name))
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	require.Equal(t, `{mission="Mun \"1\"",vessel="Kerbal\\X\n"}`, formatLabels(map[string]string{
+		"vessel":  "Kerbal\\X\n",
+		"mission": `Mun "1"`,
+	}))
+}
+
+func TestPrometheusExporter(t *testing.T) {
+	e := NewPrometheusExporter(PrometheusConfig{Labels: map[string]string{"team": "blue"}})
+	at := time.Unix(1700000000, 500000000)
+	x := e.Writer(Metadata{Vessel: "Kerbal X", Channels: []ChannelInfo{
+		{Name: "altitude", Unit: "m"},
+		{Name: "resources.LiquidFuel"},
+		{Name: "resources.LiquidFuel.max"},
+	}})
+	y := e.Writer(Metadata{Vessel: "Kerbal Y", Channels: []ChannelInfo{
+		{Name: "altitude", Unit: "m"},
+		{Name: "orbit.apoapsis", Unit: "m"},
+	}})
+	// A vessel without records yet isn't exported.
+	z := e.Writer(Metadata{Vessel: "Kerbal Z", Channels: []ChannelInfo{{Name: "speed"}}})
+	require.NoError(t, x.Write(Record{Time: at, UT: 1000, Phase: "ascent", Values: []float64{1500, 270, 360}}))
+	require.NoError(t, y.Write(Record{Time: at, Values: []float64{80000.5, math.NaN()}}))
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	require.Equal(t, `# HELP krpc_altitude altitude (m)
+# TYPE krpc_altitude gauge
+krpc_altitude{team="blue",vessel="Kerbal X"} 1500
+krpc_altitude{team="blue",vessel="Kerbal Y"} 80000.5
+# HELP krpc_last_record_timestamp_seconds Time the latest record was taken
+# TYPE krpc_last_record_timestamp_seconds gauge
+krpc_last_record_timestamp_seconds{team="blue",vessel="Kerbal X"} 1.7000000005e+09
+krpc_last_record_timestamp_seconds{team="blue",vessel="Kerbal Y"} 1.7000000005e+09
+# HELP krpc_phase Current mission phase
+# TYPE krpc_phase gauge
+krpc_phase{phase="ascent",team="blue",vessel="Kerbal X"} 1
+# HELP krpc_resources_liquid_fuel resources.LiquidFuel
+# TYPE krpc_resources_liquid_fuel gauge
+krpc_resources_liquid_fuel{team="blue",vessel="Kerbal X"} 270
+# HELP krpc_resources_liquid_fuel_fraction resources.LiquidFuel as a fraction of its maximum
+# TYPE krpc_resources_liquid_fuel_fraction gauge
+krpc_resources_liquid_fuel_fraction{team="blue",vessel="Kerbal X"} 0.75
+# HELP krpc_resources_liquid_fuel_max resources.LiquidFuel.max
+# TYPE krpc_resources_liquid_fuel_max gauge
+krpc_resources_liquid_fuel_max{team="blue",vessel="Kerbal X"} 360
+# HELP krpc_ut Universal time of the latest record (s)
+# TYPE krpc_ut gauge
+krpc_ut{team="blue",vessel="Kerbal X"} 1000
+`, scrape())
+
+	// Closing a vessel's writer removes its metrics.
+	require.NoError(t, x.Close())
+	require.NoError(t, z.Close())
+	require.Equal(t, `# HELP krpc_altitude altitude (m)
+# TYPE krpc_altitude gauge
+krpc_altitude{team="blue",vessel="Kerbal Y"} 80000.5
+# HELP krpc_last_record_timestamp_seconds Time the latest record was taken
+# TYPE krpc_last_record_timestamp_seconds gauge
+krpc_last_record_timestamp_seconds{team="blue",vessel="Kerbal Y"} 1.7000000005e+09
+`, scrape())
+}
@@ -0,0 +1,181 @@
+// Package telemetry records values from a vessel, such as its altitude and
+// speed, at a steady rate and writes them out for analysis.
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Channel is one value to record.
+type Channel struct {
+	Name string
+	// Unit is the channel's unit, e.g. "m" or "m/s".
+	Unit string
+	// Read returns the current value.
+	Read func() (float64, error)
+}
+
+// ChannelInfo describes a recorded channel.
+type ChannelInfo struct {
+	Name string `json:"name"`
+	Unit string `json:"unit,omitempty"`
+}
+
+// Metadata describes a recording.
+type Metadata struct {
+	// Vessel is the name of the vessel being recorded.
+	Vessel   string        `json:"vessel,omitempty"`
+	Channels []ChannelInfo `json:"channels"`
+	Started  time.Time     `json:"started"`
+}
+
+// Record is one sample of every channel.
+type Record struct {
+	Time time.Time
+	// UT is the universal time of the sample, if the recorder knows it.
+	UT float64
+	// Phase is the mission phase when the sample was taken.
+	Phase string
+	// Values holds each channel's value, in the same order as the
+	// recording's channels.
+	Values []float64
+}
+
+// Writer writes a recording.
+type Writer interface {
+	Write(Record) error
+	// Close finishes the recording. It doesn't close the underlying writer.
+	Close() error
+}
+
+// RecorderConfig configures a Recorder.
+type RecorderConfig struct {
+	// Vessel is the name of the vessel, for the metadata.
+	Vessel string
+	// UT, if set, returns the universal time for each sample.
+	UT func() (float64, error)
+	// Interval is how often to sample.
+	Interval time.Duration
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *RecorderConfig) SetDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+}
+
+// Recorder samples channels and passes the samples to writers.
+type Recorder struct {
+	cfg      RecorderConfig
+	channels []Channel
+	meta     Metadata
+
+	mu      sync.Mutex
+	writers []Writer
+	phase   string
+}
+
+// NewRecorder creates a new Recorder.
+func NewRecorder(cfg RecorderConfig, channels ...Channel) *Recorder {
+	cfg.SetDefaults()
+	meta := Metadata{Vessel: cfg.Vessel, Started: time.Now()}
+	for _, c := range channels {
+		meta.Channels = append(meta.Channels, ChannelInfo{Name: c.Name, Unit: c.Unit})
+	}
+	return &Recorder{cfg: cfg, channels: channels, meta: meta}
+}
+
+// Metadata returns the recording's metadata, for creating writers.
+func (r *Recorder) Metadata() Metadata {
+	return r.meta
+}
+
+// AddWriter adds a writer. It is closed when the recorder stops.
+func (r *Recorder) AddWriter(w Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writers = append(r.writers, w)
+}
+
+// SetPhase sets the mission phase to record with each sample.
+func (r *Recorder) SetPhase(phase string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phase = phase
+}
+
+// sample reads every channel.
+func (r *Recorder) sample(now time.Time) (Record, error) {
+	rec := Record{Time: now, Values: make([]float64, len(r.channels))}
+	if r.cfg.UT != nil {
+		ut, err := r.cfg.UT()
+		if err != nil {
+			return rec, tracerr.Wrap(err)
+		}
+		rec.UT = ut
+	}
+	for i, c := range r.channels {
+		v, err := c.Read()
+		if err != nil {
+			return rec, tracerr.Wrap(err)
+		}
+		rec.Values[i] = v
+	}
+	r.mu.Lock()
+	rec.Phase = r.phase
+	r.mu.Unlock()
+	return rec, nil
+}
+
+// write passes a record to every writer.
+func (r *Recorder) write(rec Record) error {
+	r.mu.Lock()
+	writers := r.writers
+	r.mu.Unlock()
+	for _, w := range writers {
+		if err := w.Write(rec); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// close closes every writer, returning the first error.
+func (r *Recorder) close() error {
+	r.mu.Lock()
+	writers := r.writers
+	r.mu.Unlock()
+	var first error
+	for _, w := range writers {
+		if err := w.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return tracerr.Wrap(first)
+}
+
+// Run records until the context is canceled, then closes the writers.
+func (r *Recorder) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(r.close())
+		case now := <-ticker.C:
+			rec, err := r.sample(now)
+			if err == nil {
+				err = r.write(rec)
+			}
+			if err != nil {
+				_ = r.close()
+				return tracerr.Wrap(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWriter keeps records in memory.
+type memoryWriter struct {
+	records []Record
+	closed  bool
+}
+
+func (w *memoryWriter) Write(rec Record) error {
+	w.records = append(w.records, rec)
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func testMetadata() Metadata {
+	return Metadata{
+		Vessel: "Kerbal X",
+		Channels: []ChannelInfo{
+			{Name: "altitude", Unit: "m"},
+			{Name: "speed", Unit: "m/s"},
+		},
+		Started: time.Unix(1000, 0).UTC(),
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	altitude := 0.0
+	rec := NewRecorder(RecorderConfig{
+		Vessel:   "Kerbal X",
+		UT:       func() (float64, error) { return 42, nil },
+		Interval: time.Millisecond,
+	}, Channel{
+		Name: "altitude",
+		Unit: "m",
+		Read: func() (float64, error) {
+			altitude += 10
+			return altitude, nil
+		},
+	})
+	require.Equal(t, []ChannelInfo{{Name: "altitude", Unit: "m"}}, rec.Metadata().Channels)
+	require.Equal(t, "Kerbal X", rec.Metadata().Vessel)
+
+	w := &memoryWriter{}
+	rec.AddWriter(w)
+	rec.SetPhase("ascent")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, rec.Run(ctx))
+	require.True(t, w.closed)
+	require.NotEmpty(t, w.records)
+	for i, r := range w.records {
+		require.Equal(t, 42.0, r.UT)
+		require.Equal(t, "ascent", r.Phase)
+		require.Equal(t, []float64{float64(10 * (i + 1))}, r.Values)
+	}
+}
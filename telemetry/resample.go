@@ -0,0 +1,278 @@
+package telemetry
+
+import (
+	"math"
+	"time"
+
+	"github.com/ztrue/tracerr"
+)
+
+// Series is a recording's metadata and records, e.g. a replay.Recording's,
+// for resampling.
+type Series struct {
+	Metadata Metadata
+	Records  []Record
+}
+
+// recordUTs returns the time of each record for resampling: its UT, or if
+// the recording has no UTs, the seconds since its first record.
+func recordUTs(records []Record) []float64 {
+	uts := make([]float64, len(records))
+	hasUT := false
+	for _, rec := range records {
+		if rec.UT != 0 {
+			hasUT = true
+			break
+		}
+	}
+	for i, rec := range records {
+		if hasUT {
+			uts[i] = rec.UT
+		} else {
+			uts[i] = rec.Time.Sub(records[0].Time).Seconds()
+		}
+	}
+	return uts
+}
+
+// Decimate keeps every nth record, starting with the first.
+func Decimate(records []Record, n int) []Record {
+	if n <= 1 {
+		return records
+	}
+	kept := make([]Record, 0, (len(records)+n-1)/n)
+	for i := 0; i < len(records); i += n {
+		kept = append(kept, records[i])
+	}
+	return kept
+}
+
+// Average replaces the records in each window of UT, window seconds long
+// and starting at a multiple of window, with one record of their mean UT,
+// time and values, and the last one's phase. Values that aren't numbers are
+// left out of the means. Records must be in order.
+func Average(records []Record, window float64) []Record {
+	if window <= 0 || len(records) == 0 {
+		return records
+	}
+	uts := recordUTs(records)
+	var averaged []Record
+	for start := 0; start < len(records); {
+		bucket := math.Floor(uts[start] / window)
+		end := start + 1
+		for end < len(records) && math.Floor(uts[end]/window) == bucket {
+			end++
+		}
+		averaged = append(averaged, averageRecords(records[start:end], uts[start:end]))
+		start = end
+	}
+	return averaged
+}
+
+// averageRecords returns the mean of records.
+func averageRecords(records []Record, uts []float64) Record {
+	n := len(records)
+	first := records[0].Time
+	var ut float64
+	var offset time.Duration
+	values := make([]float64, len(records[0].Values))
+	counts := make([]int, len(values))
+	for i, rec := range records {
+		ut += uts[i]
+		offset += rec.Time.Sub(first)
+		for j, v := range rec.Values {
+			if j < len(values) && !math.IsNaN(v) {
+				values[j] += v
+				counts[j]++
+			}
+		}
+	}
+	for j := range values {
+		if counts[j] == 0 {
+			values[j] = math.NaN()
+		} else {
+			values[j] /= float64(counts[j])
+		}
+	}
+	avg := Record{
+		Time:   first.Add(offset / time.Duration(n)),
+		Phase:  records[n-1].Phase,
+		Values: values,
+	}
+	if records[0].UT != 0 {
+		avg.UT = ut / float64(n)
+	}
+	return avg
+}
+
+// grid returns the multiples of step from start to end.
+func grid(start, end, step float64) []float64 {
+	var points []float64
+	for i := math.Ceil(start / step); i*step <= end; i++ {
+		points = append(points, i*step)
+	}
+	return points
+}
+
+// sample is one known value of a channel.
+type sample struct {
+	ut, value float64
+}
+
+// interpolate returns a channel's value at each point, interpolated
+// linearly between its known values, or NaN outside them. Both must be in
+// order.
+func interpolate(samples []sample, points []float64) []float64 {
+	values := make([]float64, len(points))
+	next := 0
+	for i, p := range points {
+		for next < len(samples) && samples[next].ut < p {
+			next++
+		}
+		switch {
+		case next < len(samples) && samples[next].ut == p:
+			values[i] = samples[next].value
+		case next == 0 || next == len(samples):
+			values[i] = math.NaN()
+		default:
+			a, b := samples[next-1], samples[next]
+			values[i] = a.value + (b.value-a.value)*(p-a.ut)/(b.ut-a.ut)
+		}
+	}
+	return values
+}
+
+// resampled is a recording resampled onto a grid, one column per channel.
+type resampled struct {
+	times   []time.Time
+	phases  []string
+	columns [][]float64
+}
+
+// resample resamples records onto points.
+func resample(records []Record, channels int, points []float64) resampled {
+	uts := recordUTs(records)
+	r := resampled{
+		times:   make([]time.Time, len(points)),
+		phases:  make([]string, len(points)),
+		columns: make([][]float64, channels),
+	}
+	// Times and phases come from the records either side of each point.
+	next := 0
+	for i, p := range points {
+		for next < len(records) && uts[next] < p {
+			next++
+		}
+		switch {
+		case next < len(records) && uts[next] == p:
+			r.times[i], r.phases[i] = records[next].Time, records[next].Phase
+		case next == 0 || next == len(records):
+		default:
+			a, b := records[next-1], records[next]
+			frac := (p - uts[next-1]) / (uts[next] - uts[next-1])
+			r.times[i] = a.Time.Add(time.Duration(float64(b.Time.Sub(a.Time)) * frac))
+			r.phases[i] = a.Phase
+		}
+	}
+	// Channels are interpolated separately, so that one channel's missing
+	// values don't leave gaps in the others.
+	for c := range r.columns {
+		var samples []sample
+		for i, rec := range records {
+			if c < len(rec.Values) && !math.IsNaN(rec.Values[c]) {
+				samples = append(samples, sample{uts[i], rec.Values[c]})
+			}
+		}
+		r.columns[c] = interpolate(samples, points)
+	}
+	return r
+}
+
+// Resample interpolates records onto a grid of UTs step seconds apart, at
+// multiples of step, so that records taken at uneven intervals or with
+// gaps can be analyzed and plotted against a regular axis. Each channel is
+// interpolated linearly between its nearest values, skipping values that
+// aren't numbers, and each record takes the phase of the last record before
+// it. Records must be in order.
+func Resample(records []Record, step float64) []Record {
+	if step <= 0 || len(records) == 0 {
+		return records
+	}
+	uts := recordUTs(records)
+	points := grid(uts[0], uts[len(uts)-1], step)
+	r := resample(records, len(records[0].Values), points)
+	out := make([]Record, len(points))
+	for i, p := range points {
+		out[i] = Record{Time: r.times[i], Phase: r.phases[i], Values: make([]float64, len(r.columns))}
+		if records[0].UT != 0 {
+			out[i].UT = p
+		}
+		for c, column := range r.columns {
+			out[i].Values[c] = column[i]
+		}
+	}
+	return out
+}
+
+// Align resamples several recordings onto one grid of UTs step seconds
+// apart, as Resample does, and combines their channels into one recording,
+// e.g. to compare values recorded at different rates or by different
+// recorders. The grid covers every recording, and channels are NaN where
+// their recording doesn't. Channel names must be unique across the
+// recordings, and the recordings must all have UTs. Times and phases come
+// from the first recording covering each point.
+func Align(step float64, series ...Series) (Series, error) {
+	if step <= 0 {
+		return Series{}, tracerr.Errorf("Step must be positive")
+	}
+	var aligned Series
+	names := map[string]bool{}
+	start, end := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, c := range s.Metadata.Channels {
+			if names[c.Name] {
+				return Series{}, tracerr.Errorf("Channel %q is in more than one recording", c.Name)
+			}
+			names[c.Name] = true
+			aligned.Metadata.Channels = append(aligned.Metadata.Channels, c)
+		}
+		if len(s.Records) == 0 {
+			continue
+		}
+		for _, rec := range s.Records {
+			if rec.UT == 0 {
+				return Series{}, tracerr.Errorf("Recording of %q has no UTs", s.Metadata.Vessel)
+			}
+		}
+		start = math.Min(start, s.Records[0].UT)
+		end = math.Max(end, s.Records[len(s.Records)-1].UT)
+		if aligned.Metadata.Vessel == "" {
+			aligned.Metadata.Vessel = s.Metadata.Vessel
+		}
+		if aligned.Metadata.Started.IsZero() || s.Metadata.Started.Before(aligned.Metadata.Started) {
+			aligned.Metadata.Started = s.Metadata.Started
+		}
+	}
+	if math.IsInf(start, 1) {
+		return aligned, nil
+	}
+
+	points := grid(start, end, step)
+	aligned.Records = make([]Record, len(points))
+	for i, p := range points {
+		aligned.Records[i] = Record{UT: p}
+	}
+	for _, s := range series {
+		r := resample(s.Records, len(s.Metadata.Channels), points)
+		for i := range aligned.Records {
+			rec := &aligned.Records[i]
+			if rec.Time.IsZero() && !r.times[i].IsZero() {
+				rec.Time, rec.Phase = r.times[i], r.phases[i]
+			}
+			for _, column := range r.columns {
+				rec.Values = append(rec.Values, column[i])
+			}
+		}
+	}
+	return aligned, nil
+}
@@ -0,0 +1,161 @@
+package telemetry
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var resampleStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// rec makes a record at a UT, taken ut seconds after resampleStart.
+func rec(ut float64, phase string, values ...float64) Record {
+	return Record{
+		Time:   resampleStart.Add(time.Duration(ut * float64(time.Second))),
+		UT:     ut,
+		Phase:  phase,
+		Values: values,
+	}
+}
+
+// requireRecords compares records, treating NaNs as equal.
+func requireRecords(t *testing.T, expected, actual []Record) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].UT, actual[i].UT, "record %d", i)
+		require.True(t, expected[i].Time.Equal(actual[i].Time), "record %d: expected time %v, got %v", i, expected[i].Time, actual[i].Time)
+		require.Equal(t, expected[i].Phase, actual[i].Phase, "record %d", i)
+		require.Len(t, actual[i].Values, len(expected[i].Values), "record %d", i)
+		for j, v := range expected[i].Values {
+			if math.IsNaN(v) {
+				require.True(t, math.IsNaN(actual[i].Values[j]), "record %d value %d: expected NaN, got %v", i, j, actual[i].Values[j])
+			} else {
+				require.InDelta(t, v, actual[i].Values[j], 1e-9, "record %d value %d", i, j)
+			}
+		}
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	records := []Record{rec(1, ""), rec(2, ""), rec(3, ""), rec(4, ""), rec(5, "")}
+	tests := []struct {
+		name     string
+		n        int
+		expected []Record
+	}{
+		{name: "every record", n: 1, expected: records},
+		{name: "every other record", n: 2, expected: []Record{rec(1, ""), rec(3, ""), rec(5, "")}},
+		{name: "every third record", n: 3, expected: []Record{rec(1, ""), rec(4, "")}},
+		{name: "more than there are", n: 10, expected: []Record{rec(1, "")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requireRecords(t, tc.expected, Decimate(records, tc.n))
+		})
+	}
+}
+
+func TestAverage(t *testing.T) {
+	nan := math.NaN()
+	records := []Record{
+		rec(10, "ascent", 1, nan),
+		rec(10.5, "ascent", 3, nan),
+		rec(11, "ascent", 5, 2),
+		rec(11.5, "coast", nan, 4),
+		rec(13, "coast", 7, 6),
+	}
+	requireRecords(t, []Record{
+		rec(10.25, "ascent", 2, nan),
+		rec(11.25, "coast", 5, 3),
+		rec(13, "coast", 7, 6),
+	}, Average(records, 1))
+}
+
+func TestAverageWithoutUT(t *testing.T) {
+	records := []Record{rec(0, "", 1), rec(1, "", 3), rec(2, "", 5)}
+	for i := range records {
+		records[i].UT = 0
+	}
+	averaged := Average(records, 2)
+	require.Len(t, averaged, 2)
+	require.Zero(t, averaged[0].UT)
+	require.Equal(t, []float64{2}, averaged[0].Values)
+	require.True(t, averaged[0].Time.Equal(resampleStart.Add(500*time.Millisecond)))
+}
+
+func TestResample(t *testing.T) {
+	nan := math.NaN()
+	records := []Record{
+		rec(9.5, "ascent", 0, 10),
+		rec(10.5, "ascent", 10, nan),
+		rec(11, "coast", 20, nan),
+		rec(12.5, "coast", 50, 40),
+	}
+	requireRecords(t, []Record{
+		rec(10, "ascent", 5, 15),
+		rec(11, "coast", 20, 25),
+		rec(12, "coast", 40, 35),
+	}, Resample(records, 1))
+}
+
+func TestAlign(t *testing.T) {
+	nan := math.NaN()
+	fast := Series{
+		Metadata: Metadata{Vessel: "Kerbal X", Channels: []ChannelInfo{{Name: "altitude", Unit: "m"}}, Started: resampleStart},
+		Records: []Record{
+			rec(10, "ascent", 100),
+			rec(10.5, "ascent", 150),
+			rec(11, "ascent", 200),
+			rec(11.5, "coast", 250),
+		},
+	}
+	slow := Series{
+		Metadata: Metadata{Vessel: "Kerbal X", Channels: []ChannelInfo{{Name: "resources.LiquidFuel"}}, Started: resampleStart.Add(time.Second)},
+		Records: []Record{
+			rec(11, "ascent", 30),
+			rec(13, "coast", 10),
+		},
+	}
+	aligned, err := Align(1, fast, slow)
+	require.NoError(t, err)
+	require.Equal(t, Metadata{
+		Vessel:   "Kerbal X",
+		Channels: []ChannelInfo{{Name: "altitude", Unit: "m"}, {Name: "resources.LiquidFuel"}},
+		Started:  resampleStart,
+	}, aligned.Metadata)
+	requireRecords(t, []Record{
+		rec(10, "ascent", 100, nan),
+		rec(11, "ascent", 200, 30),
+		rec(12, "ascent", nan, 20),
+		rec(13, "coast", nan, 10),
+	}, aligned.Records)
+}
+
+func TestAlignErrors(t *testing.T) {
+	s := Series{
+		Metadata: Metadata{Channels: []ChannelInfo{{Name: "altitude"}}},
+		Records:  []Record{rec(10, "", 100)},
+	}
+	noUT := Series{
+		Metadata: Metadata{Channels: []ChannelInfo{{Name: "speed"}}},
+		Records:  []Record{{Time: resampleStart, Values: []float64{1}}},
+	}
+	tests := []struct {
+		name   string
+		step   float64
+		series []Series
+	}{
+		{name: "no step", step: 0, series: []Series{s}},
+		{name: "duplicate channel", step: 1, series: []Series{s, s}},
+		{name: "no UT", step: 1, series: []Series{s, noUT}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Align(tc.step, tc.series...)
+			require.Error(t, err)
+		})
+	}
+}
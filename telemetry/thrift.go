@@ -0,0 +1,111 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type IDs.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the subset of the Thrift compact protocol that
+// Parquet's metadata needs.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// fields holds the last field ID written in each open struct.
+	fields []int16
+}
+
+// varint writes an unsigned varint.
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+// zigzag writes a signed varint.
+func (w *thriftWriter) zigzag(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+// field writes a field header.
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.fields[len(w.fields)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+	*last = id
+}
+
+// begin starts a struct, either at the top level or as a list element.
+func (w *thriftWriter) begin() {
+	w.fields = append(w.fields, 0)
+}
+
+// end finishes a struct.
+func (w *thriftWriter) end() {
+	w.buf.WriteByte(0)
+	w.fields = w.fields[:len(w.fields)-1]
+}
+
+// structField starts a struct field.
+func (w *thriftWriter) structField(id int16) {
+	w.field(id, thriftStruct)
+	w.begin()
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) binary(v string) {
+	w.varint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) string(id int16, v string) {
+	w.field(id, thriftBinary)
+	w.binary(v)
+}
+
+// list starts a list field. The elements are written straight after.
+func (w *thriftWriter) list(id int16, elem byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elem)
+	} else {
+		w.buf.WriteByte(0xf0 | elem)
+		w.varint(uint64(size))
+	}
+}
+
+// i32List writes a list of i32s.
+func (w *thriftWriter) i32List(id int16, vs ...int32) {
+	w.list(id, thriftI32, len(vs))
+	for _, v := range vs {
+		w.zigzag(int64(v))
+	}
+}
+
+// stringList writes a list of strings.
+func (w *thriftWriter) stringList(id int16, vs ...string) {
+	w.list(id, thriftBinary, len(vs))
+	for _, v := range vs {
+		w.binary(v)
+	}
+}
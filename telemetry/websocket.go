@@ -0,0 +1,169 @@
+package telemetry
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ztrue/tracerr"
+)
+
+// WebSocket opcodes.
+const (
+	wsText  = 0x1
+	wsClose = 0x8
+	wsPing  = 0x9
+	wsPong  = 0xa
+)
+
+// wsGUID is appended to the client's key to make the accept key.
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxWSPayload limits the size of frames read from clients, which only ever
+// need to send control frames.
+const maxWSPayload = 1 << 16
+
+// wsConn is the server side of a WebSocket connection. It only supports
+// what a server that publishes messages needs: sending unfragmented frames
+// and reading control frames.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	mu   sync.Mutex
+}
+
+// wsAcceptKey returns the Sec-WebSocket-Accept value for a client's key.
+func wsAcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// upgradeWebSocket completes a WebSocket handshake.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "expected a WebSocket handshake", http.StatusBadRequest)
+		return nil, tracerr.Errorf("Not a WebSocket handshake")
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "can't upgrade connection", http.StatusInternalServerError)
+		return nil, tracerr.Errorf("Connection can't be hijacked")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	_, err = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAcceptKey(key) + "\r\n\r\n")
+	if err == nil {
+		err = rw.Flush()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// appendWSFrame appends an unmasked, final frame.
+func appendWSFrame(b []byte, opcode byte, payload []byte) []byte {
+	b = append(b, 0x80|opcode)
+	switch n := len(payload); {
+	case n < 126:
+		b = append(b, byte(n))
+	case n <= 0xffff:
+		b = append(b, 126)
+		b = binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, 127)
+		b = binary.BigEndian.AppendUint64(b, uint64(n))
+	}
+	return append(b, payload...)
+}
+
+// writeFrame sends a frame.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.conn.Write(appendWSFrame(nil, opcode, payload))
+	return tracerr.Wrap(err)
+}
+
+// readFrame reads a frame from the client, unmasking it.
+func (c *wsConn) readFrame() (opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, nil, tracerr.Wrap(err)
+	}
+	opcode = header[0] & 0x0f
+	size := uint64(header[1] & 0x7f)
+	switch size {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.r, b[:]); err != nil {
+			return 0, nil, tracerr.Wrap(err)
+		}
+		size = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.r, b[:]); err != nil {
+			return 0, nil, tracerr.Wrap(err)
+		}
+		size = binary.BigEndian.Uint64(b[:])
+	}
+	if size > maxWSPayload {
+		return 0, nil, tracerr.Errorf("WebSocket frame too large: %d bytes", size)
+	}
+	var mask [4]byte
+	masked := header[1]&0x80 != 0
+	if masked {
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return 0, nil, tracerr.Wrap(err)
+		}
+	}
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, nil, tracerr.Wrap(err)
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return opcode, payload, nil
+}
+
+// readControl answers pings until the client closes the connection or it
+// fails, then closes done.
+func (c *wsConn) readControl(done chan<- struct{}) {
+	defer close(done)
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case wsPing:
+			if c.writeFrame(wsPong, payload) != nil {
+				return
+			}
+		case wsClose:
+			_ = c.writeFrame(wsClose, nil)
+			return
+		}
+	}
+}
+
+// Close closes the connection.
+func (c *wsConn) Close() error {
+	return tracerr.Wrap(c.conn.Close())
+}
@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSAcceptKey(t *testing.T) {
+	// The example from RFC 6455.
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", wsAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestAppendWSFrame(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int
+		expectedHeader []byte
+	}{
+		{name: "short", size: 5, expectedHeader: []byte{0x81, 5}},
+		{name: "medium", size: 300, expectedHeader: []byte{0x81, 126, 0x01, 0x2c}},
+		{name: "long", size: 70000, expectedHeader: []byte{0x81, 127, 0, 0, 0, 0, 0, 0x01, 0x11, 0x70}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := bytes.Repeat([]byte{'x'}, tc.size)
+			frame := appendWSFrame(nil, wsText, payload)
+			require.Equal(t, tc.expectedHeader, frame[:len(tc.expectedHeader)])
+			require.Equal(t, payload, frame[len(tc.expectedHeader):])
+		})
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | wsPing, 0x80 | 3}
+	frame = append(frame, mask...)
+	for i, b := range []byte("abc") {
+		frame = append(frame, b^mask[i%4])
+	}
+	c := &wsConn{r: bufio.NewReader(bytes.NewReader(frame))}
+	opcode, payload, err := c.readFrame()
+	require.NoError(t, err)
+	require.Equal(t, byte(wsPing), opcode)
+	require.Equal(t, "abc", string(payload))
+
+	c = &wsConn{r: bufio.NewReader(strings.NewReader("\x81\x7f\x00\x00\x00\x00\x01\x00\x00\x00"))}
+	_, _, err = c.readFrame()
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package krpcgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/ztrue/tracerr"
+)
+
+// ErrNoStreamServer is returned by transports without a stream server. A
+// client whose transport returns it carries on with RPCs only.
+var ErrNoStreamServer = errors.New("the transport has no stream server")
+
+// Transport opens the connections a client talks to a kRPC server over.
+// Messages are sent over them prefixed with their length as a varint, as
+// kRPC's TCP servers expect; transports to servers that frame messages
+// differently must translate.
+type Transport interface {
+	// DialRPC opens a connection to the RPC server.
+	DialRPC(ctx context.Context, cfg KRPCClientConfig) (io.ReadWriteCloser, error)
+	// DialStream opens a connection to the stream server, or returns
+	// ErrNoStreamServer if there isn't one.
+	DialStream(ctx context.Context, cfg KRPCClientConfig) (io.ReadWriteCloser, error)
+}
+
+// DialFunc opens a network connection, like net.Dialer's DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// TCPTransport connects to kRPC's TCP servers, at the host and ports in
+// the client's config.
+type TCPTransport struct {
+	// Dial, if set, opens the connections in place of net.Dialer, e.g. to
+	// go through an SSH tunnel or a SOCKS proxy, or to connect to an
+	// in-memory server in tests.
+	Dial DialFunc
+}
+
+// dial opens a TCP connection to an address.
+func (t TCPTransport) dial(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+	dial := t.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	return conn, nil
+}
+
+// DialRPC opens a connection to the RPC server.
+func (t TCPTransport) DialRPC(ctx context.Context, cfg KRPCClientConfig) (io.ReadWriteCloser, error) {
+	conn, err := t.dial(ctx, net.JoinHostPort(cfg.Host, cfg.RPCPort))
+	return conn, tracerr.Wrap(err)
+}
+
+// DialStream opens a connection to the stream server.
+func (t TCPTransport) DialStream(ctx context.Context, cfg KRPCClientConfig) (io.ReadWriteCloser, error) {
+	conn, err := t.dial(ctx, net.JoinHostPort(cfg.Host, cfg.StreamPort))
+	return conn, tracerr.Wrap(err)
+}
@@ -0,0 +1,84 @@
+package krpcgo_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/krpctest"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPTransportDial(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+
+	var mu sync.Mutex
+	var dialed []string
+	cfg.Transport = krpcgo.TCPTransport{
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			mu.Lock()
+			dialed = append(dialed, network+" "+addr)
+			mu.Unlock()
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+	client := krpcgo.NewKRPCClient(cfg)
+	require.NoError(t, client.Connect(context.Background()))
+	defer client.Close()
+	ut, err := spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+	require.True(t, client.StreamsAvailable())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{
+		"tcp " + net.JoinHostPort(cfg.Host, cfg.RPCPort),
+		"tcp " + net.JoinHostPort(cfg.Host, cfg.StreamPort),
+	}, dialed)
+}
+
+func TestTCPTransportContext(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	require.NoError(t, server.Start())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := krpcgo.NewKRPCClient(server.ClientConfig())
+	require.ErrorIs(t, client.Connect(ctx), context.Canceled)
+}
+
+// rpcOnlyTransport is a transport without a stream server.
+type rpcOnlyTransport struct {
+	krpcgo.TCPTransport
+}
+
+func (rpcOnlyTransport) DialStream(context.Context, krpcgo.KRPCClientConfig) (io.ReadWriteCloser, error) {
+	return nil, krpcgo.ErrNoStreamServer
+}
+
+func TestTransportWithoutStreams(t *testing.T) {
+	server := krpctest.NewServer()
+	defer server.Close()
+	server.Return("SpaceCenter", "get_UT", 1000.0)
+	require.NoError(t, server.Start())
+	cfg := server.ClientConfig()
+	cfg.Transport = rpcOnlyTransport{}
+
+	client := krpcgo.NewKRPCClient(cfg)
+	require.NoError(t, client.Connect(context.Background()))
+	defer client.Close()
+	require.False(t, client.StreamsAvailable())
+	ut, err := spacecenter.New(client).UT()
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, ut)
+}
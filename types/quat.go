@@ -0,0 +1,90 @@
+package types
+
+import "math"
+
+// NewQuaternion creates a quaternion from components.
+func NewQuaternion(x, y, z, w float64) Quaternion {
+	return Quaternion{
+		X: x,
+		Y: y,
+		Z: z,
+		W: w,
+	}
+}
+
+// QuaternionFromAxisAngle creates a rotation by an angle, in radians, around
+// an axis.
+func QuaternionFromAxisAngle(axis Vector3D, angle float64) Quaternion {
+	length := axis.Length()
+	if length == 0 {
+		return IdentityQuaternion()
+	}
+	v := axis.Scale(math.Sin(angle/2) / length)
+	return NewQuaternion(v.X, v.Y, v.Z, math.Cos(angle/2))
+}
+
+// QuaternionBetween creates the shortest rotation that turns the direction of
+// one vector into the direction of another.
+func QuaternionBetween(from, to Vector3D) Quaternion {
+	axis := from.Cross(to)
+	angle := from.AngleBetween(to)
+	if math.IsNaN(angle) {
+		return IdentityQuaternion()
+	}
+	if axis.Length() < 1e-12 && angle > math.Pi/2 {
+		// Opposite directions: turn around any perpendicular axis.
+		axis = from.Cross(NewVector3D(1, 0, 0))
+		if axis.Length() < 1e-12 {
+			axis = from.Cross(NewVector3D(0, 1, 0))
+		}
+	}
+	return QuaternionFromAxisAngle(axis, angle)
+}
+
+// Mul composes two rotations, giving the rotation q2 followed by q.
+func (q Quaternion) Mul(q2 Quaternion) Quaternion {
+	return NewQuaternion(
+		q.W*q2.X+q.X*q2.W+q.Y*q2.Z-q.Z*q2.Y,
+		q.W*q2.Y-q.X*q2.Z+q.Y*q2.W+q.Z*q2.X,
+		q.W*q2.Z+q.X*q2.Y-q.Y*q2.X+q.Z*q2.W,
+		q.W*q2.W-q.X*q2.X-q.Y*q2.Y-q.Z*q2.Z,
+	)
+}
+
+// Conjugate is the inverse of a unit quaternion.
+func (q Quaternion) Conjugate() Quaternion {
+	return NewQuaternion(-q.X, -q.Y, -q.Z, q.W)
+}
+
+// Length is the norm of the quaternion.
+func (q Quaternion) Length() float64 {
+	return math.Sqrt(q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W)
+}
+
+// Normalize scales the quaternion to unit length.
+func (q Quaternion) Normalize() Quaternion {
+	l := q.Length()
+	return NewQuaternion(q.X/l, q.Y/l, q.Z/l, q.W/l)
+}
+
+// Rotate rotates a vector.
+func (q Quaternion) Rotate(v Vector3D) Vector3D {
+	r := q.Mul(NewQuaternion(v.X, v.Y, v.Z, 0)).Mul(q.Conjugate())
+	return NewVector3D(r.X, r.Y, r.Z)
+}
+
+// AxisAngle converts a unit quaternion into a rotation around an axis by an
+// angle, in radians between 0 and π. The axis is a unit vector, or zero if
+// there is no rotation.
+func (q Quaternion) AxisAngle() (Vector3D, float64) {
+	// q and -q are the same rotation; take the shorter way around.
+	if q.W < 0 {
+		q = NewQuaternion(-q.X, -q.Y, -q.Z, -q.W)
+	}
+	v := NewVector3D(q.X, q.Y, q.Z)
+	s := v.Length()
+	if s < 1e-12 {
+		return NewVector3D(0, 0, 0), 0
+	}
+	return v.Scale(1 / s), 2 * math.Atan2(s, q.W)
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuaternionRotate(t *testing.T) {
+	q := QuaternionFromAxisAngle(NewVector3D(0, 0, 2), math.Pi/2)
+	requireVectorsEqual(t, NewVector3D(0, 1, 0), q.Rotate(NewVector3D(1, 0, 0)))
+	requireVectorsEqual(t, NewVector3D(-1, 0, 0), q.Rotate(NewVector3D(0, 1, 0)))
+	requireVectorsEqual(t, NewVector3D(1, 0, 0), q.Conjugate().Rotate(NewVector3D(0, 1, 0)))
+	require.InDelta(t, 1, q.Length(), delta)
+}
+
+func TestQuaternionMul(t *testing.T) {
+	x := QuaternionFromAxisAngle(NewVector3D(1, 0, 0), math.Pi/2)
+	z := QuaternionFromAxisAngle(NewVector3D(0, 0, 1), math.Pi/2)
+	v := NewVector3D(0, 1, 0)
+	// z then x.
+	requireVectorsEqual(t, x.Rotate(z.Rotate(v)), x.Mul(z).Rotate(v))
+	requireVectorsEqual(t, NewVector3D(-1, 0, 0), x.Mul(z).Rotate(v))
+}
+
+func TestQuaternionBetween(t *testing.T) {
+	tcs := []struct {
+		name     string
+		from, to Vector3D
+	}{
+		{name: "perpendicular", from: NewVector3D(1, 0, 0), to: NewVector3D(0, 0, 3)},
+		{name: "oblique", from: NewVector3D(1, 2, 3), to: NewVector3D(-2, 1, 0.5)},
+		{name: "same", from: NewVector3D(0, 1, 0), to: NewVector3D(0, 2, 0)},
+		{name: "opposite", from: NewVector3D(0, 1, 0), to: NewVector3D(0, -1, 0)},
+		{name: "opposite along x", from: NewVector3D(1, 0, 0), to: NewVector3D(-1, 0, 0)},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			q := QuaternionBetween(tc.from, tc.to)
+			expected := tc.to.Scale(tc.from.Length() / tc.to.Length())
+			requireVectorsEqual(t, expected, q.Rotate(tc.from))
+		})
+	}
+}
+
+func TestQuaternionAxisAngle(t *testing.T) {
+	axis, angle := QuaternionFromAxisAngle(NewVector3D(0, 3, 0), 1).AxisAngle()
+	requireVectorsEqual(t, NewVector3D(0, 1, 0), axis)
+	require.InDelta(t, 1, angle, delta)
+
+	// Three quarters of a turn is a quarter turn the other way.
+	axis, angle = QuaternionFromAxisAngle(NewVector3D(0, 1, 0), 3*math.Pi/2).AxisAngle()
+	requireVectorsEqual(t, NewVector3D(0, -1, 0), axis)
+	require.InDelta(t, math.Pi/2, angle, delta)
+
+	axis, angle = IdentityQuaternion().AxisAngle()
+	requireVectorsEqual(t, NewVector3D(0, 0, 0), axis)
+	require.Equal(t, 0.0, angle)
+}
+
+func TestQuaternionTuple(t *testing.T) {
+	q := NewQuaternion(1, 2, 3, 4)
+	require.Equal(t, q, QuaternionFromTuple(q.Tuple()))
+	require.InDelta(t, 1, q.Normalize().Length(), delta)
+}
@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"sync"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// Default layout values for [PanelBuilder].
+const (
+	DefaultPanelWidth = 200
+	DefaultPadding    = 10
+	DefaultSpacing    = 5
+	DefaultRowHeight  = 30
+)
+
+// container is a UI element that other elements can be added to, i.e. a
+// *Canvas or *Panel.
+type container interface {
+	AddPanel(visible bool) (*Panel, error)
+	AddText(content string, visible bool) (*Text, error)
+	AddInputField(visible bool) (*InputField, error)
+	AddButton(content string, visible bool) (*Button, error)
+}
+
+type elementKind int
+
+const (
+	textElement elementKind = iota
+	buttonElement
+	inputFieldElement
+)
+
+type element struct {
+	kind    elementKind
+	name    string
+	content string
+	onClick func()
+}
+
+// PanelBuilder builds a panel of elements stacked from top to bottom. Each
+// element is named so that it can be retrieved from the built [PanelHandle].
+//
+//	handle, err := ui.NewPanelBuilder(canvas).
+//		Position(-300, 100).
+//		Text("status", "Ready").
+//		Button("launch", "Launch", func() { ... }).
+//		Build()
+type PanelBuilder struct {
+	client    *krpcgo.KRPCClient
+	parent    container
+	width     float64
+	height    float64
+	position  types.Vector2D
+	padding   float64
+	spacing   float64
+	rowHeight float64
+	elements  []element
+}
+
+// NewPanelBuilder creates a new PanelBuilder for a panel on a canvas.
+func NewPanelBuilder(canvas *Canvas) *PanelBuilder {
+	return newPanelBuilder(canvas.Client, canvas)
+}
+
+// NewSubPanelBuilder creates a new PanelBuilder for a panel inside another
+// panel.
+func NewSubPanelBuilder(panel *Panel) *PanelBuilder {
+	return newPanelBuilder(panel.Client, panel)
+}
+
+func newPanelBuilder(client *krpcgo.KRPCClient, parent container) *PanelBuilder {
+	return &PanelBuilder{
+		client:    client,
+		parent:    parent,
+		width:     DefaultPanelWidth,
+		padding:   DefaultPadding,
+		spacing:   DefaultSpacing,
+		rowHeight: DefaultRowHeight,
+	}
+}
+
+// Size sets the size of the panel. A height of 0 fits the panel to its
+// elements, which is the default.
+func (b *PanelBuilder) Size(width, height float64) *PanelBuilder {
+	b.width = width
+	b.height = height
+	return b
+}
+
+// Position sets the position of the center of the panel, relative to the
+// center of its parent.
+func (b *PanelBuilder) Position(x, y float64) *PanelBuilder {
+	b.position = types.NewVector2D(x, y)
+	return b
+}
+
+// Padding sets the space between the edge of the panel and its elements.
+func (b *PanelBuilder) Padding(padding float64) *PanelBuilder {
+	b.padding = padding
+	return b
+}
+
+// Spacing sets the space between elements.
+func (b *PanelBuilder) Spacing(spacing float64) *PanelBuilder {
+	b.spacing = spacing
+	return b
+}
+
+// RowHeight sets the height of each element.
+func (b *PanelBuilder) RowHeight(height float64) *PanelBuilder {
+	b.rowHeight = height
+	return b
+}
+
+// Text adds a text element.
+func (b *PanelBuilder) Text(name, content string) *PanelBuilder {
+	b.elements = append(b.elements, element{kind: textElement, name: name, content: content})
+	return b
+}
+
+// Button adds a button. If onClick is not nil, it is called (from its own
+// goroutine) every time the button is clicked.
+func (b *PanelBuilder) Button(name, content string, onClick func()) *PanelBuilder {
+	b.elements = append(b.elements, element{kind: buttonElement, name: name, content: content, onClick: onClick})
+	return b
+}
+
+// InputField adds an input field with an initial value.
+func (b *PanelBuilder) InputField(name, value string) *PanelBuilder {
+	b.elements = append(b.elements, element{kind: inputFieldElement, name: name, content: value})
+	return b
+}
+
+// layout computes the height of the panel and the center of each element,
+// relative to the center of the panel.
+func (b *PanelBuilder) layout() (float64, []types.Vector2D) {
+	n := float64(len(b.elements))
+	height := b.height
+	if height == 0 {
+		height = 2*b.padding + n*b.rowHeight
+		if n > 0 {
+			height += (n - 1) * b.spacing
+		}
+	}
+	positions := make([]types.Vector2D, len(b.elements))
+	top := height/2 - b.padding
+	for i := range b.elements {
+		offset := float64(i) * (b.rowHeight + b.spacing)
+		positions[i] = types.NewVector2D(0, top-offset-b.rowHeight/2)
+	}
+	return height, positions
+}
+
+// Build creates the panel and its elements. If any element fails to be
+// created, the panel is removed.
+func (b *PanelBuilder) Build() (*PanelHandle, error) {
+	names := make(map[string]struct{})
+	hasCallbacks := false
+	for _, e := range b.elements {
+		if _, ok := names[e.name]; ok {
+			return nil, tracerr.Errorf("Duplicate element name %q", e.name)
+		}
+		names[e.name] = struct{}{}
+		hasCallbacks = hasCallbacks || e.onClick != nil
+	}
+	if hasCallbacks && !b.client.StreamsAvailable() {
+		return nil, tracerr.Errorf("Button callbacks need a stream connection")
+	}
+
+	panel, err := b.parent.AddPanel(true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	h := &PanelHandle{
+		Panel:       panel,
+		texts:       make(map[string]*Text),
+		buttons:     make(map[string]*Button),
+		inputFields: make(map[string]*InputField),
+		done:        make(chan struct{}),
+	}
+	if err := b.build(h); err != nil {
+		h.Close()
+		return nil, tracerr.Wrap(err)
+	}
+	b.client.OnClose(func() {
+		// There's nobody to report this error to.
+		_ = h.Close()
+	})
+	return h, nil
+}
+
+func (b *PanelBuilder) build(h *PanelHandle) error {
+	height, positions := b.layout()
+	rt, err := h.Panel.RectTransform()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	if err := placeRect(rt, b.position, types.NewVector2D(b.width, height)); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	size := types.NewVector2D(b.width-2*b.padding, b.rowHeight)
+	for i, e := range b.elements {
+		var rt *RectTransform
+		switch e.kind {
+		case textElement:
+			text, err := h.Panel.AddText(e.content, true)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			h.texts[e.name] = text
+			rt, err = text.RectTransform()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+		case buttonElement:
+			button, err := h.Panel.AddButton(e.content, true)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			h.buttons[e.name] = button
+			if e.onClick != nil {
+				if err := h.listen(button, e.onClick); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+			rt, err = button.RectTransform()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+		case inputFieldElement:
+			field, err := h.Panel.AddInputField(true)
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+			h.inputFields[e.name] = field
+			if e.content != "" {
+				if err := field.SetValue(e.content); err != nil {
+					return tracerr.Wrap(err)
+				}
+			}
+			rt, err = field.RectTransform()
+			if err != nil {
+				return tracerr.Wrap(err)
+			}
+		}
+		if err := placeRect(rt, positions[i], size); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func placeRect(rt *RectTransform, position, size types.Vector2D) error {
+	if err := rt.SetSize(size.Tuple()); err != nil {
+		return tracerr.Wrap(err)
+	}
+	return tracerr.Wrap(rt.SetPosition(position.Tuple()))
+}
+
+// PanelHandle is a panel built by a [PanelBuilder].
+type PanelHandle struct {
+	// Panel is the panel containing all the elements.
+	Panel       *Panel
+	texts       map[string]*Text
+	buttons     map[string]*Button
+	inputFields map[string]*InputField
+
+	once    sync.Once
+	done    chan struct{}
+	streams []*krpcgo.Stream[bool]
+}
+
+// Text gets a text element by name, or nil if there isn't one.
+func (h *PanelHandle) Text(name string) *Text {
+	return h.texts[name]
+}
+
+// Button gets a button by name, or nil if there isn't one.
+func (h *PanelHandle) Button(name string) *Button {
+	return h.buttons[name]
+}
+
+// InputField gets an input field by name, or nil if there isn't one.
+func (h *PanelHandle) InputField(name string) *InputField {
+	return h.inputFields[name]
+}
+
+// listen calls onClick whenever a button is clicked.
+func (h *PanelHandle) listen(button *Button, onClick func()) error {
+	clicked, err := button.ClickedStream()
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	h.streams = append(h.streams, clicked)
+	go func() {
+		for {
+			select {
+			case <-h.done:
+				return
+			case c := <-clicked.C:
+				if !c {
+					continue
+				}
+				// The button stays clicked until it's reset.
+				if err := button.SetClicked(false); err != nil {
+					continue
+				}
+				onClick()
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops listening for button clicks and removes the panel.
+func (h *PanelHandle) Close() error {
+	var err error
+	h.once.Do(func() {
+		close(h.done)
+		for _, s := range h.streams {
+			s.Close()
+		}
+		err = h.Panel.Remove()
+	})
+	return tracerr.Wrap(err)
+}
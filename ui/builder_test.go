@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanelLayout(t *testing.T) {
+	tests := []struct {
+		name              string
+		builder           *PanelBuilder
+		expectedHeight    float64
+		expectedPositions []types.Vector2D
+	}{
+		{
+			name:              "empty",
+			builder:           newPanelBuilder(nil, nil),
+			expectedHeight:    20,
+			expectedPositions: []types.Vector2D{},
+		},
+		{
+			name: "fit to elements",
+			builder: newPanelBuilder(nil, nil).
+				Text("a", "a").
+				Button("b", "b", nil).
+				InputField("c", ""),
+			expectedHeight: 120,
+			expectedPositions: []types.Vector2D{
+				types.NewVector2D(0, 35),
+				types.NewVector2D(0, 0),
+				types.NewVector2D(0, -35),
+			},
+		},
+		{
+			name: "fixed height",
+			builder: newPanelBuilder(nil, nil).
+				Size(100, 200).
+				Padding(0).
+				RowHeight(20).
+				Text("a", "a"),
+			expectedHeight:    200,
+			expectedPositions: []types.Vector2D{types.NewVector2D(0, 90)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			height, positions := tc.builder.layout()
+			require.Equal(t, tc.expectedHeight, height)
+			require.Equal(t, tc.expectedPositions, positions)
+		})
+	}
+}
+
+func TestPanelBuilderValidation(t *testing.T) {
+	client := krpcgo.DefaultKRPCClient()
+
+	_, err := newPanelBuilder(client, nil).
+		Text("a", "a").
+		Text("a", "b").
+		Build()
+	require.Error(t, err)
+
+	// Callbacks can't be wired up without streams.
+	_, err = newPanelBuilder(client, nil).
+		Button("a", "a", func() {}).
+		Build()
+	require.Error(t, err)
+}
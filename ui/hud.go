@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/ztrue/tracerr"
+)
+
+// HUDField is a labelled value shown on a [HUD].
+type HUDField struct {
+	label  string
+	listen func(done <-chan struct{}, update func(string))
+	close  func() error
+}
+
+// StreamField creates a HUD field that shows the latest value of a stream,
+// formatted with fmt.Sprintf and format (e.g. "%.1f m/s"). The stream is
+// closed when the HUD is closed.
+func StreamField[T any](label string, stream *krpcgo.Stream[T], format string) HUDField {
+	return HUDField{
+		label: label,
+		listen: func(done <-chan struct{}, update func(string)) {
+			for {
+				select {
+				case <-done:
+					return
+				case v := <-stream.C:
+					update(fmt.Sprintf(format, v))
+				}
+			}
+		},
+		close: stream.Close,
+	}
+}
+
+// HUDConfig is the config for a HUD.
+type HUDConfig struct {
+	// Title is shown above the fields. Omitted if empty.
+	Title string
+	// X and Y are the position of the center of the HUD, relative to the
+	// center of the canvas.
+	X, Y float64
+	// Width is the width of the HUD. Defaults to DefaultPanelWidth.
+	Width float64
+	// Interval is how often the HUD is redrawn. Defaults to 250ms.
+	Interval time.Duration
+}
+
+// SetDefaults sets the config defaults.
+func (cfg *HUDConfig) SetDefaults() {
+	if cfg.Width == 0 {
+		cfg.Width = DefaultPanelWidth
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 250 * time.Millisecond
+	}
+}
+
+// hudValue is the latest text for a field.
+type hudValue struct {
+	label   string
+	content string
+	dirty   bool
+}
+
+// HUD is a panel of live values that redraws itself as the values change.
+type HUD struct {
+	handle *PanelHandle
+	fields []HUDField
+
+	mu     sync.Mutex
+	values []hudValue
+	err    error
+
+	once sync.Once
+	done chan struct{}
+}
+
+// NewHUD creates a HUD on a canvas and starts updating it.
+func NewHUD(canvas *Canvas, cfg HUDConfig, fields ...HUDField) (*HUD, error) {
+	cfg.SetDefaults()
+	b := NewPanelBuilder(canvas).
+		Size(cfg.Width, 0).
+		Position(cfg.X, cfg.Y)
+	if cfg.Title != "" {
+		b.Text("title", cfg.Title)
+	}
+	h := &HUD{
+		fields: fields,
+		values: make([]hudValue, len(fields)),
+		done:   make(chan struct{}),
+	}
+	for i, f := range fields {
+		h.values[i] = hudValue{label: f.label, content: "-"}
+		b.Text(fieldName(i), h.values[i].text())
+	}
+	handle, err := b.Build()
+	if err != nil {
+		// The HUD owns the fields' streams, so close them even though it
+		// never started.
+		for _, f := range fields {
+			f.close()
+		}
+		return nil, tracerr.Wrap(err)
+	}
+	h.handle = handle
+
+	for i, f := range fields {
+		i := i
+		go f.listen(h.done, func(content string) { h.set(i, content) })
+	}
+	go h.run(cfg.Interval)
+	canvas.Client.OnClose(func() {
+		// There's nobody to report this error to.
+		_ = h.Close()
+	})
+	return h, nil
+}
+
+func fieldName(i int) string {
+	return fmt.Sprintf("field%v", i)
+}
+
+func (v hudValue) text() string {
+	return v.label + ": " + v.content
+}
+
+// set records a new value for a field.
+func (h *HUD) set(i int, content string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.values[i].content != content {
+		h.values[i].content = content
+		h.values[i].dirty = true
+	}
+}
+
+// changed returns the fields that have changed since the last call.
+func (h *HUD) changed() map[int]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	changed := make(map[int]string)
+	for i, v := range h.values {
+		if v.dirty {
+			changed[i] = v.text()
+			h.values[i].dirty = false
+		}
+	}
+	return changed
+}
+
+func (h *HUD) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+		}
+		for i, content := range h.changed() {
+			if err := h.handle.Text(fieldName(i)).SetContent(content); err != nil {
+				h.mu.Lock()
+				h.err = err
+				h.mu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error that stopped the HUD from updating, if any.
+func (h *HUD) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Close stops updating the HUD, closes its streams, and removes it.
+func (h *HUD) Close() error {
+	var err error
+	h.once.Do(func() {
+		close(h.done)
+		for _, f := range h.fields {
+			f.close()
+		}
+		err = h.handle.Close()
+	})
+	return tracerr.Wrap(err)
+}
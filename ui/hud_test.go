@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	krpcgo "github.com/atburke/krpc-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHUDFields(t *testing.T) {
+	stream := &krpcgo.Stream[float64]{C: make(chan float64)}
+	field := StreamField("Altitude", stream, "%.1f m")
+	h := &HUD{
+		fields: []HUDField{field},
+		values: []hudValue{{label: "Altitude", content: "-"}},
+		done:   make(chan struct{}),
+	}
+	go field.listen(h.done, func(content string) { h.set(0, content) })
+	defer close(h.done)
+
+	require.Empty(t, h.changed())
+
+	stream.C <- 1234.56
+	require.Eventually(t, func() bool {
+		return len(h.changed()) > 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, "Altitude: 1234.6 m", h.values[0].text())
+
+	// Unchanged values aren't redrawn.
+	h.set(0, "1234.6 m")
+	require.Empty(t, h.changed())
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/types"
+	"github.com/ztrue/tracerr"
+)
+
+// MessageSinkConfig is the config for a MessageSink.
+type MessageSinkConfig struct {
+	// Duration is how long each message is shown. Defaults to 5s.
+	Duration time.Duration
+	// Position is where messages are shown on the screen.
+	Position MessagePosition
+	// Size is the font size. Defaults to 20.
+	Size float32
+	// MinSeverity is the least severe alert to show. Cleared alerts are
+	// always shown.
+	MinSeverity alert.Severity
+}
+
+// SetDefaults sets the config defaults.
+func (cfg *MessageSinkConfig) SetDefaults() {
+	if cfg.Duration == 0 {
+		cfg.Duration = 5 * time.Second
+	}
+	if cfg.Size == 0 {
+		cfg.Size = 20
+	}
+}
+
+// severityColor returns the color to show an alert in: red for critical
+// alerts, yellow for warnings, green for cleared alerts and white for the
+// rest.
+func severityColor(a alert.Alert) types.Color[float64] {
+	switch {
+	case a.Cleared:
+		return types.Color[float64]{R: 0.4, G: 1, B: 0.4}
+	case a.Severity == alert.Critical:
+		return types.Color[float64]{R: 1, G: 0.2, B: 0.2}
+	case a.Severity == alert.Warning:
+		return types.Color[float64]{R: 1, G: 0.8, B: 0.2}
+	}
+	return types.Color[float64]{R: 1, G: 1, B: 1}
+}
+
+// MessageSink is an alert.Sink that shows alerts as on-screen messages in
+// the game.
+type MessageSink struct {
+	ui  *UI
+	cfg MessageSinkConfig
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewMessageSink creates a new MessageSink.
+func NewMessageSink(ui *UI, cfg MessageSinkConfig) *MessageSink {
+	cfg.SetDefaults()
+	return &MessageSink{ui: ui, cfg: cfg}
+}
+
+// Alert shows an alert's message.
+func (s *MessageSink) Alert(a alert.Alert) {
+	if !a.Cleared && a.Severity < s.cfg.MinSeverity {
+		return
+	}
+	err := s.ui.Message(a.Message, float32(s.cfg.Duration.Seconds()), s.cfg.Position, severityColor(a).Tuple(), s.cfg.Size)
+	if err != nil {
+		s.mu.Lock()
+		if s.err == nil {
+			s.err = tracerr.Wrap(err)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Err returns the first error showing a message, if any.
+func (s *MessageSink) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
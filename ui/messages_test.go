@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		alert    alert.Alert
+		expected types.Color[float64]
+	}{
+		{name: "info", alert: alert.Alert{Severity: alert.Info}, expected: types.Color[float64]{R: 1, G: 1, B: 1}},
+		{name: "warning", alert: alert.Alert{Severity: alert.Warning}, expected: types.Color[float64]{R: 1, G: 0.8, B: 0.2}},
+		{name: "critical", alert: alert.Alert{Severity: alert.Critical}, expected: types.Color[float64]{R: 1, G: 0.2, B: 0.2}},
+		{name: "cleared", alert: alert.Alert{Severity: alert.Critical, Cleared: true}, expected: types.Color[float64]{R: 0.4, G: 1, B: 0.4}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, severityColor(tc.alert))
+		})
+	}
+}
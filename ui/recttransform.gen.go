@@ -0,0 +1,1112 @@
+package ui
+
+import (
+	krpcgo "github.com/atburke/krpc-go"
+	krpc "github.com/atburke/krpc-go/krpc"
+	encode "github.com/atburke/krpc-go/lib/encode"
+	service "github.com/atburke/krpc-go/lib/service"
+	types "github.com/atburke/krpc-go/types"
+	tracerr "github.com/ztrue/tracerr"
+)
+
+// Code generated by gen_services.go. DO NOT EDIT.
+
+// RectTransform - a Unity engine Rect Transform for a UI object. See the <a
+// href="https://docs.unity3d.com/Manual/class-RectTransform.html">Unity
+// manual</a> for more details.
+type RectTransform struct {
+	service.BaseClass
+}
+
+// NewRectTransform creates a new RectTransform.
+func NewRectTransform(id uint64, client *krpcgo.KRPCClient) *RectTransform {
+	c := &RectTransform{BaseClass: service.BaseClass{Client: client}}
+	c.SetID_internal(id)
+	return c
+}
+
+// Position - position of the rectangles pivot point relative to the anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) Position() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Position",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// PositionStream - position of the rectangles pivot point relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) PositionStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Position",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetPosition - position of the rectangles pivot point relative to the anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetPosition(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Position",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// PositionProp - returns a handle to the Position property.
+func (s *RectTransform) PositionProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.Position, s.SetPosition, s.PositionStream)
+}
+
+// LocalPosition - position of the rectangles pivot point relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) LocalPosition() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_LocalPosition",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// LocalPositionStream - position of the rectangles pivot point relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) LocalPositionStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_LocalPosition",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetLocalPosition - position of the rectangles pivot point relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetLocalPosition(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_LocalPosition",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// LocalPositionProp - returns a handle to the LocalPosition property.
+func (s *RectTransform) LocalPositionProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.LocalPosition, s.SetLocalPosition, s.LocalPositionStream)
+}
+
+// Size - width and height of the rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) Size() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Size",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// SizeStream - width and height of the rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SizeStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Size",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetSize - width and height of the rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetSize(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Size",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// SizeProp - returns a handle to the Size property.
+func (s *RectTransform) SizeProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.Size, s.SetSize, s.SizeStream)
+}
+
+// UpperRight - position of the rectangles upper right corner relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) UpperRight() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_UpperRight",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// UpperRightStream - position of the rectangles upper right corner relative to
+// the anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) UpperRightStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_UpperRight",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetUpperRight - position of the rectangles upper right corner relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetUpperRight(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_UpperRight",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// UpperRightProp - returns a handle to the UpperRight property.
+func (s *RectTransform) UpperRightProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.UpperRight, s.SetUpperRight, s.UpperRightStream)
+}
+
+// LowerLeft - position of the rectangles lower left corner relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) LowerLeft() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_LowerLeft",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// LowerLeftStream - position of the rectangles lower left corner relative to
+// the anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) LowerLeftStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_LowerLeft",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetLowerLeft - position of the rectangles lower left corner relative to the
+// anchors.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetLowerLeft(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_LowerLeft",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// LowerLeftProp - returns a handle to the LowerLeft property.
+func (s *RectTransform) LowerLeftProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.LowerLeft, s.SetLowerLeft, s.LowerLeftStream)
+}
+
+// SetAnchor - set the minimum and maximum anchor points as a fraction of the
+// size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetAnchor(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Anchor",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// AnchorMax - the anchor point for the lower left corner of the rectangle
+// defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) AnchorMax() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_AnchorMax",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AnchorMaxStream - the anchor point for the lower left corner of the rectangle
+// defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) AnchorMaxStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_AnchorMax",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetAnchorMax - the anchor point for the lower left corner of the rectangle
+// defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetAnchorMax(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_AnchorMax",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// AnchorMaxProp - returns a handle to the AnchorMax property.
+func (s *RectTransform) AnchorMaxProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.AnchorMax, s.SetAnchorMax, s.AnchorMaxStream)
+}
+
+// AnchorMin - the anchor point for the upper right corner of the rectangle
+// defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) AnchorMin() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_AnchorMin",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// AnchorMinStream - the anchor point for the upper right corner of the
+// rectangle defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) AnchorMinStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_AnchorMin",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetAnchorMin - the anchor point for the upper right corner of the rectangle
+// defined as a fraction of the size of the parent rectangle.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetAnchorMin(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_AnchorMin",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// AnchorMinProp - returns a handle to the AnchorMin property.
+func (s *RectTransform) AnchorMinProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.AnchorMin, s.SetAnchorMin, s.AnchorMinStream)
+}
+
+// Pivot - location of the pivot point around which the rectangle rotates,
+// defined as a fraction of the size of the rectangle itself.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) Pivot() (types.Tuple2[float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple2[float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Pivot",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// PivotStream - location of the pivot point around which the rectangle rotates,
+// defined as a fraction of the size of the rectangle itself.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) PivotStream() (*krpcgo.Stream[types.Tuple2[float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Pivot",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple2[float64, float64] {
+		var value types.Tuple2[float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetPivot - location of the pivot point around which the rectangle rotates,
+// defined as a fraction of the size of the rectangle itself.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetPivot(value types.Tuple2[float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Pivot",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// PivotProp - returns a handle to the Pivot property.
+func (s *RectTransform) PivotProp() *krpcgo.Property[types.Tuple2[float64, float64]] {
+	return krpcgo.NewProperty(s.Pivot, s.SetPivot, s.PivotStream)
+}
+
+// Rotation - rotation, as a quaternion, of the object around its pivot point.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) Rotation() (types.Tuple4[float64, float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple4[float64, float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Rotation",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// RotationStream - rotation, as a quaternion, of the object around its pivot
+// point.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) RotationStream() (*krpcgo.Stream[types.Tuple4[float64, float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Rotation",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple4[float64, float64, float64, float64] {
+		var value types.Tuple4[float64, float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetRotation - rotation, as a quaternion, of the object around its pivot
+// point.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetRotation(value types.Tuple4[float64, float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Rotation",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// RotationProp - returns a handle to the Rotation property.
+func (s *RectTransform) RotationProp() *krpcgo.Property[types.Tuple4[float64, float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Rotation, s.SetRotation, s.RotationStream)
+}
+
+// Scale - scale factor applied to the object in the x, y and z dimensions.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) Scale() (types.Tuple3[float64, float64, float64], error) {
+	var err error
+	var argBytes []byte
+	var vv types.Tuple3[float64, float64, float64]
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Scale",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	result, err := s.Client.Call(request)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	err = encode.Unmarshal(result.Value, &vv)
+	if err != nil {
+		return vv, tracerr.Wrap(err)
+	}
+	return vv, nil
+}
+
+// ScaleStream - scale factor applied to the object in the x, y and z
+// dimensions.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) ScaleStream() (*krpcgo.Stream[types.Tuple3[float64, float64, float64]], error) {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_get_Scale",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	krpc := krpc.New(s.Client)
+	st, err := krpc.AddStream(request, true)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+	rawStream := s.Client.GetStream(st.Id)
+	stream := krpcgo.MapStream(rawStream, func(b []byte) types.Tuple3[float64, float64, float64] {
+		var value types.Tuple3[float64, float64, float64]
+		encode.Unmarshal(b, &value)
+		return value
+	})
+	stream.AddCloser(func() error {
+		return tracerr.Wrap(krpc.RemoveStream(st.Id))
+	})
+	return stream, nil
+}
+
+// SetScale - scale factor applied to the object in the x, y and z dimensions.
+//
+// Allowed game scenes: any.
+func (s *RectTransform) SetScale(value types.Tuple3[float64, float64, float64]) error {
+	var err error
+	var argBytes []byte
+	request := &types.ProcedureCall{
+		Procedure: "RectTransform_set_Scale",
+		Service:   "UI",
+	}
+	argBytes, err = encode.Marshal(s)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x0),
+		Value:    argBytes,
+	})
+	argBytes, err = encode.Marshal(value)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	request.Arguments = append(request.Arguments, &types.Argument{
+		Position: uint32(0x1),
+		Value:    argBytes,
+	})
+	_, err = s.Client.Call(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+	return nil
+}
+
+// ScaleProp - returns a handle to the Scale property.
+func (s *RectTransform) ScaleProp() *krpcgo.Property[types.Tuple3[float64, float64, float64]] {
+	return krpcgo.NewProperty(s.Scale, s.SetScale, s.ScaleStream)
+}
@@ -0,0 +1,115 @@
+// Package units converts between the units kRPC uses, which are SI units
+// and radians unless documented otherwise, and those more convenient for
+// people, and does the angle and great-circle arithmetic of navigating over
+// a body's surface.
+package units
+
+import "math"
+
+const (
+	// DegreesPerRadian converts radians to degrees.
+	DegreesPerRadian = 180 / math.Pi
+	// MetersPerKilometer converts kilometers to meters.
+	MetersPerKilometer = 1000
+	// KmhPerMetersPerSecond converts meters per second to kilometers per
+	// hour.
+	KmhPerMetersPerSecond = 3.6
+)
+
+// Degrees converts radians to degrees.
+func Degrees(rad float64) float64 {
+	return rad * DegreesPerRadian
+}
+
+// Radians converts degrees to radians.
+func Radians(deg float64) float64 {
+	return deg / DegreesPerRadian
+}
+
+// Kilometers converts meters to kilometers.
+func Kilometers(m float64) float64 {
+	return m / MetersPerKilometer
+}
+
+// Meters converts kilometers to meters.
+func Meters(km float64) float64 {
+	return km * MetersPerKilometer
+}
+
+// Kmh converts meters per second to kilometers per hour.
+func Kmh(mps float64) float64 {
+	return mps * KmhPerMetersPerSecond
+}
+
+// MetersPerSecond converts kilometers per hour to meters per second.
+func MetersPerSecond(kmh float64) float64 {
+	return kmh / KmhPerMetersPerSecond
+}
+
+// NormalizeHeading wraps an angle in degrees into [0, 360), as for a compass
+// heading.
+func NormalizeHeading(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// NormalizeBearing wraps an angle in degrees into [-180, 180), as for a
+// bearing relative to the way a vessel is facing, where negative is to the
+// left.
+func NormalizeBearing(deg float64) float64 {
+	return NormalizeHeading(deg+180) - 180
+}
+
+// RelativeBearing returns the bearing, relative to a heading, of a point at
+// an absolute bearing, both in degrees clockwise from north.
+func RelativeBearing(heading, bearing float64) float64 {
+	return NormalizeBearing(bearing - heading)
+}
+
+// NormalizeRadians wraps an angle in radians into [0, 2pi).
+func NormalizeRadians(rad float64) float64 {
+	rad = math.Mod(rad, 2*math.Pi)
+	if rad < 0 {
+		rad += 2 * math.Pi
+	}
+	return rad
+}
+
+// GreatCircleDistance returns the distance over the surface of a body of a
+// radius, in meters, between two points given as latitudes and longitudes in
+// degrees, using the haversine formula.
+func GreatCircleDistance(lat1, lon1, lat2, lon2, radius float64) float64 {
+	phi1, phi2 := Radians(lat1), Radians(lat2)
+	dPhi := phi2 - phi1
+	dLambda := Radians(lon2 - lon1)
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * radius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// InitialBearing returns the bearing, in degrees clockwise from north, to
+// set off on to follow the great circle from one point to another.
+func InitialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := Radians(lat1), Radians(lat2)
+	dLambda := Radians(lon2 - lon1)
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return NormalizeHeading(Degrees(math.Atan2(y, x)))
+}
+
+// DestinationPoint returns the latitude and longitude reached by travelling
+// a distance along a great circle from a starting point and bearing.
+func DestinationPoint(lat, lon, bearing, distance, radius float64) (float64, float64) {
+	phi1, lambda1 := Radians(lat), Radians(lon)
+	theta := Radians(bearing)
+	delta := distance / radius
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2),
+	)
+	return Degrees(phi2), NormalizeBearing(Degrees(lambda2))
+}
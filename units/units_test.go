@@ -0,0 +1,74 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// kerbinRadius is the equatorial radius of Kerbin, in meters.
+const kerbinRadius = 600000
+
+func TestConversions(t *testing.T) {
+	require.InDelta(t, 180, Degrees(math.Pi), 1e-9)
+	require.InDelta(t, math.Pi/2, Radians(90), 1e-9)
+	require.Equal(t, 70.0, Kilometers(70000))
+	require.Equal(t, 70000.0, Meters(70))
+	require.InDelta(t, 360, Kmh(100), 1e-9)
+	require.InDelta(t, 100, MetersPerSecond(360), 1e-9)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		deg, heading, bearing float64
+	}{
+		{deg: 0, heading: 0, bearing: 0},
+		{deg: 90, heading: 90, bearing: 90},
+		{deg: 180, heading: 180, bearing: -180},
+		{deg: 270, heading: 270, bearing: -90},
+		{deg: -90, heading: 270, bearing: -90},
+		{deg: 725, heading: 5, bearing: 5},
+		{deg: -725, heading: 355, bearing: -5},
+	}
+	for _, tc := range tests {
+		require.InDelta(t, tc.heading, NormalizeHeading(tc.deg), 1e-9, "heading of %v", tc.deg)
+		require.InDelta(t, tc.bearing, NormalizeBearing(tc.deg), 1e-9, "bearing of %v", tc.deg)
+	}
+	require.InDelta(t, 20, RelativeBearing(350, 10), 1e-9)
+	require.InDelta(t, -20, RelativeBearing(10, 350), 1e-9)
+	require.InDelta(t, math.Pi/2, NormalizeRadians(-3*math.Pi/2), 1e-9)
+}
+
+func TestGreatCircleDistance(t *testing.T) {
+	quarter := math.Pi / 2 * kerbinRadius
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		expected               float64
+	}{
+		{name: "same point", lat1: 10, lon1: 20, lat2: 10, lon2: 20, expected: 0},
+		{name: "equator to pole", lat1: 0, lon1: 0, lat2: 90, lon2: 0, expected: quarter},
+		{name: "along equator", lat1: 0, lon1: -45, lat2: 0, lon2: 45, expected: quarter},
+		{name: "across antimeridian", lat1: 0, lon1: 170, lat2: 0, lon2: -170, expected: quarter * 20 / 90},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.expected, GreatCircleDistance(tc.lat1, tc.lon1, tc.lat2, tc.lon2, kerbinRadius), 1e-6)
+		})
+	}
+}
+
+func TestInitialBearing(t *testing.T) {
+	require.InDelta(t, 0, InitialBearing(0, 0, 10, 0), 1e-9)
+	require.InDelta(t, 90, InitialBearing(0, 0, 0, 10), 1e-9)
+	require.InDelta(t, 180, InitialBearing(10, 0, 0, 0), 1e-9)
+	require.InDelta(t, 270, InitialBearing(0, 10, 0, 0), 1e-9)
+}
+
+func TestDestinationPoint(t *testing.T) {
+	// Go there and check the distance and bearing agree.
+	lat, lon := DestinationPoint(-0.1, -74.6, 45, 10000, kerbinRadius)
+	require.InDelta(t, 10000, GreatCircleDistance(-0.1, -74.6, lat, lon, kerbinRadius), 1e-6)
+	require.InDelta(t, 45, InitialBearing(-0.1, -74.6, lat, lon), 0.01)
+}
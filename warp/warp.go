@@ -0,0 +1,300 @@
+// Package warp controls time warp, stepping it down on the approach to a
+// target time so that it can't overshoot, keeping it within limits, and
+// dropping out of warp when something goes wrong. It replaces calls to
+// SpaceCenter.WarpTo, which can't be interrupted:
+//
+//	w := warp.New(sc, vessel, warp.Config{MinRailsAltitude: 70000})
+//	bridge.Add(w) // drop out of warp on alerts
+//	err := w.WarpTo(ctx, burnUT-10)
+package warp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/atburke/krpc-go/spacecenter"
+	"github.com/ztrue/tracerr"
+)
+
+// RailsRates are the warp rates of the stock rails warp factors.
+var RailsRates = []float64{1, 5, 10, 50, 100, 1000, 10000, 100000}
+
+// PhysicsRates are the warp rates of the stock physics warp factors.
+var PhysicsRates = []float64{1, 2, 3, 4}
+
+// Config configures a Controller.
+type Config struct {
+	// MaxRailsFactor is the highest rails warp factor to use, up to 7.
+	// Defaults to 7. Rails warp is never used if this is negative.
+	MaxRailsFactor int32
+	// MaxPhysicsFactor is the highest physics warp factor to use, up to 3.
+	// Defaults to 3. Physics warp is never used if this is negative.
+	MaxPhysicsFactor int32
+	// MinRailsAltitude, if set, is the altitude above sea level below which
+	// only physics warp is used, on top of the game's own limits.
+	MinRailsAltitude float64
+	// Lead is how much wall-clock time at the current rate must be left
+	// before the target for a warp factor to be used; warp steps down
+	// through the factors as the target nears. Defaults to 3s.
+	Lead time.Duration
+	// Interval is how often the warp factor is checked. Defaults to 100ms.
+	Interval time.Duration
+	// MinSeverity is the least severe alert that stops warp. Cleared alerts
+	// never stop it.
+	MinSeverity alert.Severity
+}
+
+// SetDefaults sets the default values for any unset fields.
+func (cfg *Config) SetDefaults() {
+	if cfg.MaxRailsFactor == 0 {
+		cfg.MaxRailsFactor = int32(len(RailsRates) - 1)
+	}
+	if cfg.MaxPhysicsFactor == 0 {
+		cfg.MaxPhysicsFactor = int32(len(PhysicsRates) - 1)
+	}
+	if cfg.Lead == 0 {
+		cfg.Lead = 3 * time.Second
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+}
+
+// ErrAlert is returned by WarpTo when an alert stopped warp.
+type ErrAlert struct {
+	Alert alert.Alert
+}
+
+// Error returns a human-readable error.
+func (err ErrAlert) Error() string {
+	return fmt.Sprintf("Warp stopped by %v alert %q: %v", err.Alert.Severity, err.Alert.Name, err.Alert.Message)
+}
+
+// game is the part of the game a Controller uses.
+type game interface {
+	UT() (float64, error)
+	// Altitude is the vessel's altitude above sea level.
+	Altitude() (float64, error)
+	CanRailsWarpAt(factor int32) (bool, error)
+	SetRailsWarpFactor(factor int32) error
+	SetPhysicsWarpFactor(factor int32) error
+}
+
+// spaceCenterGame is a game backed by a server.
+type spaceCenterGame struct {
+	*spacecenter.SpaceCenter
+	vessel *spacecenter.Vessel
+}
+
+// Altitude gets the vessel's altitude above sea level.
+func (g spaceCenterGame) Altitude() (float64, error) {
+	orbit, err := g.vessel.Orbit()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	radius, err := orbit.Radius()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	body, err := orbit.Body()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	bodyRadius, err := body.EquatorialRadius()
+	if err != nil {
+		return 0, tracerr.Wrap(err)
+	}
+	return radius - float64(bodyRadius), nil
+}
+
+// Controller controls time warp. Its methods are safe to call from any
+// goroutine, but only one warp runs at a time.
+type Controller struct {
+	cfg  Config
+	game game
+
+	mu sync.Mutex
+	// stop stops the running warp, if there is one.
+	stop  context.CancelFunc
+	alert *alert.Alert
+	// rails and physics are the factors last set, or -1 if they aren't
+	// known.
+	rails   int32
+	physics int32
+}
+
+// New creates a new Controller for warping with a vessel. The vessel is only
+// used for its altitude, so it may be nil if MinRailsAltitude isn't set.
+func New(sc *spacecenter.SpaceCenter, vessel *spacecenter.Vessel, cfg Config) *Controller {
+	return newController(spaceCenterGame{SpaceCenter: sc, vessel: vessel}, cfg)
+}
+
+// newController creates a Controller for a game.
+func newController(g game, cfg Config) *Controller {
+	cfg.SetDefaults()
+	return &Controller{cfg: cfg, game: g}
+}
+
+// factorFor returns the highest factor, up to max, whose rate leaves at
+// least lead of wall-clock time until the target.
+func factorFor(rates []float64, maxFactor int32, remaining float64, lead time.Duration) int32 {
+	for f := maxFactor; f > 0; f-- {
+		if int(f) < len(rates) && remaining/rates[f] >= lead.Seconds() {
+			return f
+		}
+	}
+	return 0
+}
+
+// set changes the warp factors, if they aren't already set. Rails and
+// physics warp can't be used together, so the one being turned off is
+// changed first.
+func (c *Controller) set(rails, physics int32) error {
+	c.mu.Lock()
+	currentRails, currentPhysics := c.rails, c.physics
+	c.mu.Unlock()
+	if rails == currentRails && physics == currentPhysics {
+		return nil
+	}
+	if rails == 0 && currentRails != 0 {
+		if err := c.game.SetRailsWarpFactor(0); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if physics == 0 && currentPhysics != 0 {
+		if err := c.game.SetPhysicsWarpFactor(0); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if rails != 0 && rails != currentRails {
+		if err := c.game.SetRailsWarpFactor(rails); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	if physics != 0 && physics != currentPhysics {
+		if err := c.game.SetPhysicsWarpFactor(physics); err != nil {
+			return tracerr.Wrap(err)
+		}
+	}
+	c.mu.Lock()
+	c.rails, c.physics = rails, physics
+	c.mu.Unlock()
+	return nil
+}
+
+// choose picks the warp factors for the time remaining until the target.
+func (c *Controller) choose(remaining float64) (rails, physics int32, err error) {
+	railsAllowed := true
+	if c.cfg.MinRailsAltitude > 0 {
+		altitude, err := c.game.Altitude()
+		if err != nil {
+			return 0, 0, tracerr.Wrap(err)
+		}
+		railsAllowed = altitude >= c.cfg.MinRailsAltitude
+	}
+	if railsAllowed {
+		// The game limits rails warp by altitude and forbids it under
+		// acceleration, so the highest factor it allows is used.
+		for f := factorFor(RailsRates, c.cfg.MaxRailsFactor, remaining, c.cfg.Lead); f > 0; f-- {
+			ok, err := c.game.CanRailsWarpAt(f)
+			if err != nil {
+				return 0, 0, tracerr.Wrap(err)
+			}
+			if ok {
+				return f, 0, nil
+			}
+		}
+	}
+	return 0, factorFor(PhysicsRates, c.cfg.MaxPhysicsFactor, remaining, c.cfg.Lead), nil
+}
+
+// WarpTo warps until a universal time, stepping down the warp factor as it
+// nears, and returns once the time has been reached with warp stopped. It
+// stops warp and returns early if the context is done, Stop is called, or an
+// alert of at least the config's MinSeverity arrives, in which case the error
+// is an ErrAlert.
+func (c *Controller) WarpTo(ctx context.Context, ut float64) error {
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	c.mu.Lock()
+	if c.stop != nil {
+		c.mu.Unlock()
+		return tracerr.Errorf("Already warping")
+	}
+	c.stop = stop
+	c.alert = nil
+	// The factors may have been changed by something else since the last
+	// warp, so they are set again.
+	c.rails, c.physics = -1, -1
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.stop = nil
+		c.mu.Unlock()
+	}()
+
+	err := c.warpTo(ctx, ut)
+	// Warp is always stopped, even if the context was cancelled.
+	if stopErr := c.set(0, 0); err == nil {
+		err = stopErr
+	}
+	c.mu.Lock()
+	a := c.alert
+	c.mu.Unlock()
+	if a != nil {
+		return tracerr.Wrap(ErrAlert{Alert: *a})
+	}
+	return tracerr.Wrap(err)
+}
+
+// warpTo runs a warp, leaving the final warp factor set.
+func (c *Controller) warpTo(ctx context.Context, ut float64) error {
+	for {
+		now, err := c.game.UT()
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		remaining := ut - now
+		if remaining <= 0 {
+			return nil
+		}
+		rails, physics, err := c.choose(remaining)
+		if err != nil {
+			return tracerr.Wrap(err)
+		}
+		if err := c.set(rails, physics); err != nil {
+			return tracerr.Wrap(err)
+		}
+		select {
+		case <-ctx.Done():
+			return tracerr.Wrap(ctx.Err())
+		case <-time.After(c.cfg.Interval):
+		}
+	}
+}
+
+// Stop stops the running warp, if there is one.
+func (c *Controller) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		c.stop()
+	}
+}
+
+// Alert stops the running warp if the alert is severe enough, so that a
+// Controller can be added to an alert.Bridge.
+func (c *Controller) Alert(a alert.Alert) {
+	if a.Cleared || a.Severity < c.cfg.MinSeverity {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil && c.alert == nil {
+		c.alert = &a
+		c.stop()
+	}
+}
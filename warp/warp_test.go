@@ -0,0 +1,161 @@
+package warp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atburke/krpc-go/alert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGame is a game where each check of the UT takes a second of wall
+// clock time at the current warp rate.
+type fakeGame struct {
+	mu sync.Mutex
+	ut float64
+	// last is the UT last checked.
+	last     float64
+	altitude float64
+	// maxRails is the highest rails factor the game allows.
+	maxRails int32
+	rails    int32
+	physics  int32
+	// frozen stops time, to test stopping warp part way.
+	frozen bool
+	// railsSet and physicsSet are the factors set, in order.
+	railsSet   []int32
+	physicsSet []int32
+}
+
+func (g *fakeGame) UT() (float64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ut := g.ut
+	g.last = ut
+	if !g.frozen {
+		g.ut += RailsRates[g.rails] * PhysicsRates[g.physics]
+	}
+	return ut, nil
+}
+
+func (g *fakeGame) Altitude() (float64, error) {
+	return g.altitude, nil
+}
+
+func (g *fakeGame) CanRailsWarpAt(factor int32) (bool, error) {
+	return factor <= g.maxRails, nil
+}
+
+func (g *fakeGame) SetRailsWarpFactor(factor int32) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if factor != 0 && g.physics != 0 {
+		return errors.New("physics warp is on")
+	}
+	g.rails = factor
+	g.railsSet = append(g.railsSet, factor)
+	return nil
+}
+
+func (g *fakeGame) SetPhysicsWarpFactor(factor int32) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if factor != 0 && g.rails != 0 {
+		return errors.New("rails warp is on")
+	}
+	g.physics = factor
+	g.physicsSet = append(g.physicsSet, factor)
+	return nil
+}
+
+func TestFactorFor(t *testing.T) {
+	require.Equal(t, int32(7), factorFor(RailsRates, 7, 1e6, time.Second))
+	require.Equal(t, int32(5), factorFor(RailsRates, 5, 1e6, time.Second))
+	require.Equal(t, int32(4), factorFor(RailsRates, 7, 999, 2*time.Second))
+	require.Equal(t, int32(0), factorFor(RailsRates, 7, 9, 2*time.Second))
+	require.Equal(t, int32(3), factorFor(PhysicsRates, 3, 8, 2*time.Second))
+}
+
+func TestWarpTo(t *testing.T) {
+	g := &fakeGame{maxRails: 7}
+	c := newController(g, Config{Lead: 2 * time.Second, Interval: time.Millisecond})
+	require.NoError(t, c.WarpTo(context.Background(), 1000))
+	// Warp steps down as the target nears, through rails and then physics
+	// warp, and is stopped at the end.
+	require.Equal(t, []int32{4, 3, 2, 1, 0}, g.railsSet)
+	require.Equal(t, []int32{0, 3, 1, 0}, g.physicsSet)
+	require.GreaterOrEqual(t, g.last, 1000.0)
+	require.Less(t, g.last, 1002.0)
+}
+
+func TestWarpToLimits(t *testing.T) {
+	// The game only allows low rails warp, and the config caps physics.
+	g := &fakeGame{maxRails: 2}
+	c := newController(g, Config{MaxPhysicsFactor: 1, Lead: time.Second, Interval: time.Millisecond})
+	require.NoError(t, c.WarpTo(context.Background(), 100))
+	require.Equal(t, int32(2), g.railsSet[0])
+
+	// Below the minimum altitude, only physics warp is used.
+	g = &fakeGame{maxRails: 7, altitude: 1000}
+	c = newController(g, Config{MaxPhysicsFactor: 1, MinRailsAltitude: 70000, Lead: time.Second, Interval: time.Millisecond})
+	require.NoError(t, c.WarpTo(context.Background(), 100))
+	require.Equal(t, []int32{0}, g.railsSet)
+	require.Equal(t, []int32{1, 0}, g.physicsSet)
+
+	// Physics warp can be turned off altogether.
+	g = &fakeGame{maxRails: 7, altitude: 1000}
+	c = newController(g, Config{MaxPhysicsFactor: -1, MinRailsAltitude: 70000, Lead: time.Second, Interval: time.Millisecond})
+	require.NoError(t, c.WarpTo(context.Background(), 100))
+	require.Equal(t, []int32{0}, g.railsSet)
+	require.Equal(t, []int32{0}, g.physicsSet)
+}
+
+func TestWarpToAlert(t *testing.T) {
+	g := &fakeGame{maxRails: 7, frozen: true}
+	c := newController(g, Config{MinSeverity: alert.Warning, Interval: time.Millisecond})
+	var bridge alert.Bridge
+	bridge.Add(c)
+	done := make(chan error)
+	go func() { done <- c.WarpTo(context.Background(), 1e6) }()
+
+	time.Sleep(20 * time.Millisecond)
+	bridge.Alert(alert.Alert{Name: "low-power", Severity: alert.Info})
+	bridge.Alert(alert.Alert{Name: "overheating", Severity: alert.Critical, Cleared: true})
+	select {
+	case <-done:
+		require.FailNow(t, "warp stopped by an ignored alert")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	bridge.Alert(alert.Alert{Name: "overheating", Severity: alert.Critical, Message: "Engine at 95%"})
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "warp not stopped by alert")
+	}
+	var alertErr ErrAlert
+	require.True(t, errors.As(err, &alertErr))
+	require.Equal(t, "overheating", alertErr.Alert.Name)
+	require.Equal(t, `Warp stopped by critical alert "overheating": Engine at 95%`, alertErr.Error())
+	require.Equal(t, int32(0), g.rails)
+}
+
+func TestWarpToCancel(t *testing.T) {
+	g := &fakeGame{maxRails: 7, frozen: true}
+	c := newController(g, Config{Interval: time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.WarpTo(ctx, 1e6), context.DeadlineExceeded)
+	require.Equal(t, int32(0), g.rails)
+
+	done := make(chan error)
+	go func() { done <- c.WarpTo(context.Background(), 1e6) }()
+	time.Sleep(20 * time.Millisecond)
+	require.ErrorContains(t, c.WarpTo(context.Background(), 1e6), "Already warping")
+	c.Stop()
+	require.ErrorIs(t, <-done, context.Canceled)
+}